@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/config"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+	"github.com/nicholasflintwillow/github-mcp/internal/server"
+)
+
+// checkTokenTimeout bounds the GitHub request made by check-token
+const checkTokenTimeout = 10 * time.Second
+
+// usage prints the commands accepted by the binary
+func usage(w io.Writer) {
+	fmt.Fprint(w, `Usage: github-mcp [command] [flags]
+
+Commands:
+  serve            Run the MCP server (the default when no command is given);
+                   with --print-manifest, print its capability manifest instead
+  tools list       Print the tool catalog with input schemas as JSON
+  validate-config  Load and validate the configuration without starting the server
+  check-token      Validate the GitHub token and print its user and scopes
+  help             Print this help
+
+Commands that read the configuration accept the same flags, environment
+variables and config file as serve; run "github-mcp serve -h" to list them.
+`)
+}
+
+// commandLogger returns a logger for the diagnostic commands, which write
+// their results to stdout and only report failures on stderr
+func commandLogger() *logger.Logger {
+	log, err := logger.NewWithWriter("ERROR", "text", os.Stderr)
+	if err != nil {
+		panic(err)
+	}
+	return log
+}
+
+// loadConfig loads and validates the configuration for a diagnostic
+// command, reporting failures on stderr. ok is false when the command should
+// exit with code.
+func loadConfig(args []string) (cfg *config.Config, code int, ok bool) {
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil, 0, false
+	}
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		return nil, 1, false
+	}
+	return cfg, 0, true
+}
+
+// toolsCommand runs the tools subcommands
+func toolsCommand(args []string) int {
+	if len(args) == 0 || args[0] != "list" {
+		fmt.Fprintln(os.Stderr, "Usage: github-mcp tools list [flags]")
+		return 2
+	}
+	cfg, code, ok := loadConfig(args[1:])
+	if !ok {
+		return code
+	}
+
+	handler, code, ok := configuredHandler(cfg)
+	if !ok {
+		return code
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(mcp.ToolsListResult{Tools: handler.ServedTools()}); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write tools: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// validateConfig loads and validates the configuration
+func validateConfig(args []string) int {
+	if _, code, ok := loadConfig(args); !ok {
+		return code
+	}
+	fmt.Println("Configuration is valid")
+	return 0
+}
+
+// checkToken validates the configured GitHub token and prints its user and
+// scopes
+func checkToken(args []string) int {
+	cfg, code, ok := loadConfig(args)
+	if !ok {
+		return code
+	}
+
+	if cfg.DeviceFlowEnabled() {
+		token, err := storedDeviceFlowToken(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Token check failed: %v\n", err)
+			return 1
+		}
+		cfg.GitHubToken = token
+	}
+
+	githubClient := client.NewGitHubClient(cfg.GitHubToken, commandLogger())
+	if err := githubClient.SetTransport(server.GitHubTransport(cfg)); err != nil {
+		fmt.Fprintf(os.Stderr, "Token check failed: %v\n", err)
+		return 1
+	}
+	if cfg.GitHubTokenFile != "" {
+		if err := githubClient.SetTokenFile(cfg.GitHubTokenFile, 0); err != nil {
+			fmt.Fprintf(os.Stderr, "Token check failed: %v\n", err)
+			return 1
+		}
+	}
+	if cfg.GitHubAPIVersion != "" {
+		githubClient.SetAPIVersion(cfg.GitHubAPIVersion)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), checkTokenTimeout)
+	defer cancel()
+
+	if cfg.GitHubAppID != 0 {
+		return checkAppAuth(ctx, githubClient, cfg)
+	}
+
+	if err := githubClient.ValidateToken(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Token check failed: %v\n", err)
+		return 1
+	}
+	info, err := githubClient.GetTokenInfo(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Token check failed: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Token is valid for %s\n", info.Login)
+	if len(info.Scopes) > 0 {
+		fmt.Printf("Scopes: %s\n", strings.Join(info.Scopes, ", "))
+	} else {
+		fmt.Println("Scopes: none reported (fine-grained tokens carry permissions instead of scopes)")
+	}
+	if info.Expiration != "" {
+		fmt.Printf("Expires: %s\n", info.Expiration)
+	}
+	return 0
+}
+
+// checkAppAuth validates the configured GitHub App credentials
+func checkAppAuth(ctx context.Context, githubClient *client.GitHubClient, cfg *config.Config) int {
+	key, err := cfg.AppPrivateKey()
+	if err == nil {
+		err = githubClient.SetAppAuth(client.AppAuthConfig{
+			AppID:          cfg.GitHubAppID,
+			PrivateKey:     key,
+			InstallationID: cfg.GitHubAppInstallationID,
+		})
+	}
+	if err == nil {
+		err = githubClient.ValidateToken(ctx)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "GitHub App check failed: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("GitHub App %d credentials are valid\n", cfg.GitHubAppID)
+	return 0
+}
+
+// extractFlag removes a boolean flag, given as -name or --name, from args and
+// reports whether it was present
+func extractFlag(args []string, name string) (bool, []string) {
+	found := false
+	rest := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "-"+name || arg == "--"+name {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return found, rest
+}
+
+// configuredHandler returns a handler configured by cfg as the server
+// configures it. The catalog does not depend on GitHub, so no request is made.
+func configuredHandler(cfg *config.Config) (*mcp.Handler, int, bool) {
+	handler := mcp.NewHandler(nil, commandLogger())
+	if err := server.ConfigureHandler(handler, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		return nil, 1, false
+	}
+	return handler, 0, true
+}
+
+// writeManifest prints the capability manifest of a server configured by cfg
+func writeManifest(cfg *config.Config) int {
+	handler, code, ok := configuredHandler(cfg)
+	if !ok {
+		return code
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(handler.Manifest(cfg.EnabledTransports())); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write manifest: %v\n", err)
+		return 1
+	}
+	return 0
+}
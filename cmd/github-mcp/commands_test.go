@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+)
+
+// runCommand runs a command with stdout captured, returning its exit code
+// and output
+func runCommand(t *testing.T, command func([]string) int, args ...string) (int, string) {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		output <- string(data)
+	}()
+	code := command(args)
+	writer.Close()
+	return code, <-output
+}
+
+func TestToolsListAppliesConfiguration(t *testing.T) {
+	t.Setenv("GITHUB_PERSONAL_ACCESS_TOKEN", "test-token")
+	t.Setenv("READ_ONLY", "true")
+	t.Setenv("TOOL_PREFIX", "gh_")
+
+	code, output := runCommand(t, toolsCommand, "list")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+	var result mcp.ToolsListResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Failed to decode the catalog: %v", err)
+	}
+	if len(result.Tools) == 0 {
+		t.Fatal("Expected tools to be listed")
+	}
+	for _, tool := range result.Tools {
+		if !strings.HasPrefix(tool.Name, "gh_") {
+			t.Errorf("Expected %s to be served with the prefix", tool.Name)
+		}
+		if tool.Name == "gh_create_or_update_file" || tool.Name == "gh_delete_repository" {
+			t.Errorf("Expected read-only mode to remove %s", tool.Name)
+		}
+	}
+}
+
+func TestPrintManifestAppliesConfiguration(t *testing.T) {
+	t.Setenv("GITHUB_PERSONAL_ACCESS_TOKEN", "test-token")
+	t.Setenv("READ_ONLY", "true")
+	t.Setenv("SERVER_NAME", "octo-mcp")
+
+	code, output := runCommand(t, serve, "--print-manifest")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+	var manifest mcp.Manifest
+	if err := json.Unmarshal([]byte(output), &manifest); err != nil {
+		t.Fatalf("Failed to decode the manifest: %v", err)
+	}
+	if manifest.Name != "octo-mcp" {
+		t.Errorf("Expected the configured server name, got %q", manifest.Name)
+	}
+	for _, tool := range manifest.Tools {
+		if !tool.Annotations.ReadOnlyHint && tool.Name != "github_api_request" {
+			t.Errorf("Expected read-only mode to remove %s", tool.Name)
+		}
+	}
+}
+
+// newGitHubProxy starts a proxy tunnelling every connection to a TLS server
+// answering as GitHub with handler, and points the GitHub client at it
+func newGitHubProxy(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	github := httptest.NewTLSServer(handler)
+	t.Cleanup(github.Close)
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "CONNECT expected", http.StatusMethodNotAllowed)
+			return
+		}
+		upstream, err := net.Dial("tcp", github.Listener.Addr().String())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		conn, buffered, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		go func() {
+			io.Copy(upstream, buffered)
+			upstream.Close()
+		}()
+		io.Copy(conn, upstream)
+		conn.Close()
+	}))
+	t.Cleanup(proxy.Close)
+
+	t.Setenv("GITHUB_PROXY_URL", proxy.URL)
+	// The test server's certificate does not name api.github.com
+	t.Setenv("GITHUB_TLS_INSECURE_SKIP_VERIFY", "true")
+}
+
+func TestValidateConfig(t *testing.T) {
+	t.Setenv("GITHUB_PERSONAL_ACCESS_TOKEN", "test-token")
+	if code, output := runCommand(t, validateConfig); code != 0 || output != "Configuration is valid\n" {
+		t.Errorf("Expected a valid configuration, got %d %q", code, output)
+	}
+
+	t.Setenv("PORT", "70000")
+	if code, output := runCommand(t, validateConfig); code != 1 || output != "" {
+		t.Errorf("Expected exit code 1 for an invalid configuration, got %d %q", code, output)
+	}
+
+	if code, _ := runCommand(t, validateConfig, "-h"); code != 0 {
+		t.Errorf("Expected help to exit with code 0, got %d", code)
+	}
+}
+
+func TestToolsCommandUsage(t *testing.T) {
+	for _, args := range [][]string{nil, {"show"}} {
+		if code, output := runCommand(t, toolsCommand, args...); code != 2 || output != "" {
+			t.Errorf("Expected exit code 2 for tools %v, got %d %q", args, code, output)
+		}
+	}
+}
+
+func TestCheckToken(t *testing.T) {
+	t.Setenv("GITHUB_PERSONAL_ACCESS_TOKEN", "test-token")
+	newGitHubProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" || r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"message": "Bad credentials"}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-OAuth-Scopes", "repo, read:org")
+		io.WriteString(w, `{"login": "octocat"}`)
+	})
+
+	code, output := runCommand(t, checkToken)
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+	if output != "Token is valid for octocat\nScopes: repo, read:org\n" {
+		t.Errorf("Unexpected output %q", output)
+	}
+
+	t.Setenv("GITHUB_PERSONAL_ACCESS_TOKEN", "revoked-token")
+	if code, output := runCommand(t, checkToken); code != 1 || output != "" {
+		t.Errorf("Expected exit code 1 for a rejected token, got %d %q", code, output)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/config"
+	"github.com/nicholasflintwillow/github-mcp/internal/deviceflow"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/server"
+)
+
+// deviceFlowToken returns the token stored by an earlier device flow, or runs
+// the flow, printing the user code on prompt, and stores the token obtained
+func deviceFlowToken(ctx context.Context, cfg *config.Config, prompt io.Writer, log *logger.Logger) (string, error) {
+	var store *deviceflow.Store
+	if cfg.GitHubTokenStore != "" {
+		store = deviceflow.NewStore(cfg.GitHubTokenStore, cfg.GitHubTokenStorePassphrase)
+		token, err := store.Load()
+		if err != nil {
+			return "", err
+		}
+		if token != "" {
+			githubClient := client.NewGitHubClient(token, log)
+			if err := githubClient.SetTransport(server.GitHubTransport(cfg)); err != nil {
+				return "", err
+			}
+			err := githubClient.ValidateToken(ctx)
+			if err == nil {
+				log.Info("Using the GitHub token stored by the device flow", "path", cfg.GitHubTokenStore)
+				return token, nil
+			}
+			if !errors.IsType(err, errors.ErrorTypeAuthentication) {
+				return "", err
+			}
+			log.Warn("The stored GitHub token is no longer valid; authorizing again", "path", cfg.GitHubTokenStore)
+		}
+	}
+
+	transport, err := client.NewTransport(server.GitHubTransport(cfg))
+	if err != nil {
+		return "", err
+	}
+	flow := deviceflow.New(cfg.GitHubOAuthClientID, cfg.GitHubOAuthScopes, &http.Client{Transport: transport, Timeout: client.DefaultTimeout})
+	code, err := flow.Start(ctx)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(prompt, "To authorize the GitHub MCP server, open %s and enter the code %s\n", code.VerificationURI, code.UserCode)
+
+	token, err := flow.Poll(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	log.Info("Obtained a GitHub token with the device flow")
+
+	if store != nil {
+		if err := store.Save(token); err != nil {
+			return "", err
+		}
+	}
+	return token, nil
+}
+
+// storedDeviceFlowToken returns the token stored by the device flow, without
+// running the flow when none is stored
+func storedDeviceFlowToken(cfg *config.Config) (string, error) {
+	if cfg.GitHubTokenStore == "" {
+		return "", fmt.Errorf("no token is configured, and the device flow token store is not set")
+	}
+	token, err := deviceflow.NewStore(cfg.GitHubTokenStore, cfg.GitHubTokenStorePassphrase).Load()
+	if err == nil && token == "" {
+		err = fmt.Errorf("no token is stored yet; run serve to authorize with the device flow")
+	}
+	return token, err
+}
@@ -2,62 +2,184 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/nicholasflintwillow/github-mcp/internal/config"
+	"github.com/nicholasflintwillow/github-mcp/internal/lifecycle"
 	"github.com/nicholasflintwillow/github-mcp/internal/logger"
 	"github.com/nicholasflintwillow/github-mcp/internal/server"
+	"github.com/nicholasflintwillow/github-mcp/internal/telemetry"
+	"github.com/nicholasflintwillow/github-mcp/internal/version"
 )
 
+// shutdownTimeout bounds the graceful shutdown of all subsystems
+const shutdownTimeout = 30 * time.Second
+
 func main() {
+	// The first argument names a command unless it is a flag, in which case
+	// the server is started as before subcommands existed
+	name, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	switch name {
+	case "serve":
+		os.Exit(serve(args))
+	case "tools":
+		os.Exit(toolsCommand(args))
+	case "validate-config":
+		os.Exit(validateConfig(args))
+	case "check-token":
+		os.Exit(checkToken(args))
+	case "help":
+		usage(os.Stdout)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", name)
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+}
+
+// serve runs the MCP server until it is interrupted or a listener exits
+func serve(args []string) int {
+	printManifest, args := extractFlag(args, "print-manifest")
+
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		log.Printf("Failed to load configuration: %v", err)
+		return 1
+	}
+	if printManifest {
+		return writeManifest(cfg)
 	}
 
-	// Initialize logger
-	logger, err := logger.New(cfg.LogLevel, cfg.LogFormat)
+	// Initialize logger; stdout is reserved for protocol messages when serving stdio
+	logOutput := os.Stdout
+	if cfg.StdioEnabled() {
+		logOutput = os.Stderr
+	}
+	logger, err := logger.NewWithWriter(cfg.LogLevel, cfg.LogFormat, logOutput)
 	if err != nil {
-		log.Fatalf("Failed to initialize logger: %v", err)
+		log.Printf("Failed to initialize logger: %v", err)
+		return 1
+	}
+
+	// Subsystems start in the order they are registered and stop in reverse
+	lc := lifecycle.New(logger)
+
+	// Initialize tracing first so that it is flushed last
+	shutdownTracing, err := telemetry.Setup(context.Background(), cfg.TracingEnabled)
+	if err != nil {
+		logger.Error("Failed to initialize tracing", "error", err)
+		return 1
+	}
+	if cfg.TracingEnabled {
+		logger.Info("OpenTelemetry tracing enabled")
+	}
+	lc.Append(lifecycle.Hook{Name: "tracing", OnStop: shutdownTracing, StopTimeout: 5 * time.Second})
+
+	// Obtain a token with the device flow when none is configured; the user
+	// code is printed on stderr, which is never used for protocol messages
+	if cfg.DeviceFlowEnabled() {
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		cfg.GitHubToken, err = deviceFlowToken(ctx, cfg, os.Stderr, logger)
+		stop()
+		if err != nil {
+			logger.Error("Failed to obtain a GitHub token with the device flow", "error", err)
+			return 1
+		}
+	}
+	deviceToken := ""
+	if cfg.GitHubOAuthClientID != "" {
+		deviceToken = cfg.GitHubToken
 	}
 
 	// Create server
 	srv, err := server.New(cfg, logger)
 	if err != nil {
 		logger.Error("Failed to create server", "error", err)
-		os.Exit(1)
+		return 1
 	}
-
-	// Start server in a goroutine
-	go func() {
-		logger.Info("Starting GitHub MCP server", "port", cfg.Port)
-		if err := srv.Start(); err != nil {
-			logger.Error("Server failed to start", "error", err)
-			os.Exit(1)
+	srv.Register(lc)
+	srv.SetConfigLoader(func() (*config.Config, error) {
+		cfg, err := config.Load(args)
+		if err == nil && cfg.DeviceFlowEnabled() {
+			// Keep the token the device flow obtained at startup
+			cfg.GitHubToken = deviceToken
 		}
-	}()
+		return cfg, err
+	})
 
-	// Wait for interrupt signal to gracefully shutdown the server
+	build := version.Get()
+	manifest := srv.Manifest()
+	toolsetNames := make([]string, 0, len(manifest.Toolsets))
+	for _, toolset := range manifest.Toolsets {
+		toolsetNames = append(toolsetNames, toolset.Name)
+	}
+	logger.Info("Starting GitHub MCP server",
+		"port", cfg.Port,
+		"transports", manifest.Transports,
+		"version", build.Version,
+		"commit", build.Commit,
+		"toolsets", toolsetNames,
+		"tools", len(manifest.Tools),
+		"resources", len(manifest.Resources))
+	if err := lc.Start(context.Background()); err != nil {
+		logger.Error("Server failed to start", "error", err)
+		return 1
+	}
+
+	// Reload the configuration on SIGHUP
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+
+	// Wait for an interrupt signal, or for a listener to exit (such as the
+	// stdio host disconnecting), to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+wait:
+	for {
+		select {
+		case <-reload:
+			if err := srv.Reload(); err != nil {
+				logger.Error("Failed to reload configuration", "error", err)
+			}
+		case <-quit:
+			break wait
+		case <-lc.Done():
+			break wait
+		}
+	}
 
 	logger.Info("Shutting down server...")
 
 	// Create a deadline to wait for
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
 	// Attempt graceful shutdown
-	if err := srv.Shutdown(ctx); err != nil {
+	if err := lc.Stop(ctx); err != nil {
 		logger.Error("Server forced to shutdown", "error", err)
-		os.Exit(1)
+		return 1
+	}
+	if err := lc.Err(); err != nil {
+		logger.Error("Server failed", "error", err)
+		return 1
 	}
 
 	logger.Info("Server exited")
+	return 0
 }
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+)
+
+// Authentication methods recorded on an Identity
+const (
+	// MethodMTLS identifies clients authenticated by a verified TLS client certificate
+	MethodMTLS = "mtls"
+	// MethodBearerToken identifies clients authenticated by a configured static bearer token
+	MethodBearerToken = "bearer_token"
+	// MethodOAuth identifies clients authenticated by an OAuth access token
+	MethodOAuth = "oauth"
+)
+
+// Identity describes the authenticated caller of an MCP endpoint
+type Identity struct {
+	// Subject is the principal name (certificate common name, token name, ...)
+	Subject string `json:"subject"`
+	// Method is the authentication method that produced this identity
+	Method string `json:"method"`
+	// Attributes holds method-specific details such as certificate serial numbers
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// identityContextKey is the context key for the authenticated identity
+type identityContextKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the given identity
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// IdentityFromContext returns the identity stored in ctx, if any
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityContextKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
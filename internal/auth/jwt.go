@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"math/big"
+	"strings"
+)
+
+// jwtHeader is the JOSE header of a compact JWS
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Type      string `json:"typ"`
+}
+
+// Claims holds the registered and MCP-relevant claims of an access token
+type Claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  Audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	IssuedAt  int64    `json:"iat"`
+	Scope     string   `json:"scope"`
+	ClientID  string   `json:"client_id"`
+	AZP       string   `json:"azp"`
+}
+
+// Audience is the "aud" claim, which may be a single string or an array
+type Audience []string
+
+// UnmarshalJSON accepts both the string and array forms of the audience claim
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("invalid audience claim")
+	}
+	*a = multiple
+	return nil
+}
+
+// Contains reports whether the audience includes the given value
+func (a Audience) Contains(value string) bool {
+	for _, aud := range a {
+		if aud == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Scopes returns the space-delimited scope claim as a slice
+func (c *Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// parsedJWT is a decoded but not yet verified token
+type parsedJWT struct {
+	header       jwtHeader
+	claims       Claims
+	signingInput string
+	signature    []byte
+}
+
+// parseJWT decodes a compact JWS without verifying it
+func parseJWT(token string) (*parsedJWT, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token is not a compact JWS")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token header")
+	}
+
+	var header jwtHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("malformed token header")
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token payload")
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("malformed token payload")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token signature")
+	}
+
+	return &parsedJWT{
+		header:       header,
+		claims:       claims,
+		signingInput: parts[0] + "." + parts[1],
+		signature:    signature,
+	}, nil
+}
+
+// verifySignature checks the JWS signature with the given public key
+func verifySignature(algorithm string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	var hashFunc crypto.Hash
+	var hasher hash.Hash
+
+	switch algorithm {
+	case "RS256", "ES256", "PS256":
+		hashFunc, hasher = crypto.SHA256, sha256.New()
+	case "RS384", "ES384", "PS384":
+		hashFunc, hasher = crypto.SHA384, sha512.New384()
+	case "RS512", "ES512", "PS512":
+		hashFunc, hasher = crypto.SHA512, sha512.New()
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+
+	hasher.Write([]byte(signingInput))
+	digest := hasher.Sum(nil)
+
+	switch algorithm[:2] {
+	case "RS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match algorithm %s", algorithm)
+		}
+		return rsa.VerifyPKCS1v15(rsaKey, hashFunc, digest, signature)
+	case "PS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match algorithm %s", algorithm)
+		}
+		return rsa.VerifyPSS(rsaKey, hashFunc, digest, signature, nil)
+	case "ES":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match algorithm %s", algorithm)
+		}
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("invalid signature length")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return fmt.Errorf("signature verification failed")
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported signing algorithm %q", algorithm)
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// clockSkew is the leeway applied to exp/nbf checks
+const clockSkew = 60 * time.Second
+
+// TokenValidator validates OAuth 2.1 access tokens (JWTs) issued for this
+// server by a trusted authorization server
+type TokenValidator struct {
+	issuer         string
+	audience       string
+	requiredScopes []string
+	keys           *JWKSCache
+	now            func() time.Time
+}
+
+// NewTokenValidator creates a validator for tokens from the given issuer.
+// Tokens must list audience in their "aud" claim and carry every required scope.
+func NewTokenValidator(issuer, audience string, requiredScopes []string, keys *JWKSCache) *TokenValidator {
+	return &TokenValidator{
+		issuer:         issuer,
+		audience:       audience,
+		requiredScopes: requiredScopes,
+		keys:           keys,
+		now:            time.Now,
+	}
+}
+
+// Validate verifies the token signature and claims and returns the caller identity
+func (v *TokenValidator) Validate(ctx context.Context, token string) (*Identity, error) {
+	parsed, err := parseJWT(token)
+	if err != nil {
+		return nil, errors.Authentication(err.Error())
+	}
+
+	if parsed.header.Algorithm == "" || strings.EqualFold(parsed.header.Algorithm, "none") {
+		return nil, errors.Authentication("unsigned tokens are not accepted")
+	}
+
+	key, err := v.keys.Key(ctx, parsed.header.KeyID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeAuthentication, "unable to resolve token signing key")
+	}
+
+	if err := verifySignature(parsed.header.Algorithm, key, parsed.signingInput, parsed.signature); err != nil {
+		return nil, errors.Authentication("invalid token signature")
+	}
+
+	claims := parsed.claims
+	now := v.now()
+
+	if claims.Issuer != v.issuer {
+		return nil, errors.Authentication("token issuer is not trusted")
+	}
+	if v.audience == "" || !claims.Audience.Contains(v.audience) {
+		return nil, errors.Authentication("token was not issued for this resource")
+	}
+	if claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)) {
+		return nil, errors.Authentication("token has expired")
+	}
+	if claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, errors.Authentication("token is not valid yet")
+	}
+
+	granted := make(map[string]bool)
+	for _, scope := range claims.Scopes() {
+		granted[scope] = true
+	}
+	for _, scope := range v.requiredScopes {
+		if !granted[scope] {
+			return nil, errors.Authorization(fmt.Sprintf("token is missing required scope %q", scope)).
+				WithContext("required_scopes", v.requiredScopes)
+		}
+	}
+
+	clientID := claims.ClientID
+	if clientID == "" {
+		clientID = claims.AZP
+	}
+
+	return &Identity{
+		Subject: claims.Subject,
+		Method:  MethodOAuth,
+		Attributes: map[string]string{
+			"issuer":    claims.Issuer,
+			"client_id": clientID,
+			"scope":     claims.Scope,
+		},
+	}, nil
+}
+
+// RequiredScopes returns the scopes every token must carry
+func (v *TokenValidator) RequiredScopes() []string {
+	return v.requiredScopes
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+const (
+	testIssuer   = "https://auth.example.com"
+	testResource = "https://mcp.example.com"
+	testKeyID    = "test-key"
+)
+
+func newTestJWKSServer(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": testKeyID,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(jwks)
+	}))
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": testKeyID, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestTokenValidator_Validate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	server := newTestJWKSServer(t, key)
+	defer server.Close()
+
+	validator := NewTokenValidator(testIssuer, testResource, []string{"mcp:tools"}, NewJWKSCache(server.URL, nil))
+
+	validClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":       testIssuer,
+			"sub":       "user-123",
+			"aud":       []string{testResource},
+			"exp":       time.Now().Add(time.Hour).Unix(),
+			"scope":     "openid mcp:tools",
+			"client_id": "agent",
+		}
+	}
+
+	tests := []struct {
+		name         string
+		token        func() string
+		expectedType errors.ErrorType
+	}{
+		{
+			name:  "valid token",
+			token: func() string { return signTestToken(t, key, validClaims()) },
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				claims := validClaims()
+				claims["iss"] = "https://evil.example.com"
+				return signTestToken(t, key, claims)
+			},
+			expectedType: errors.ErrorTypeAuthentication,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "https://other.example.com"
+				return signTestToken(t, key, claims)
+			},
+			expectedType: errors.ErrorTypeAuthentication,
+		},
+		{
+			name: "expired",
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return signTestToken(t, key, claims)
+			},
+			expectedType: errors.ErrorTypeAuthentication,
+		},
+		{
+			name: "missing scope",
+			token: func() string {
+				claims := validClaims()
+				claims["scope"] = "openid"
+				return signTestToken(t, key, claims)
+			},
+			expectedType: errors.ErrorTypeAuthorization,
+		},
+		{
+			name:         "signed by unknown key",
+			token:        func() string { return signTestToken(t, otherKey, validClaims()) },
+			expectedType: errors.ErrorTypeAuthentication,
+		},
+		{
+			name:         "malformed",
+			token:        func() string { return "not-a-jwt" },
+			expectedType: errors.ErrorTypeAuthentication,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := validator.Validate(context.Background(), tt.token())
+
+			if tt.expectedType != "" {
+				if err == nil {
+					t.Fatal("Expected error but got none")
+				}
+				if errors.GetType(err) != tt.expectedType {
+					t.Errorf("Expected error type %s, got %s", tt.expectedType, errors.GetType(err))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if identity.Subject != "user-123" {
+				t.Errorf("Expected subject user-123, got %s", identity.Subject)
+			}
+			if identity.Method != MethodOAuth {
+				t.Errorf("Expected method %s, got %s", MethodOAuth, identity.Method)
+			}
+			if identity.Attributes["client_id"] != "agent" {
+				t.Errorf("Expected client_id agent, got %s", identity.Attributes["client_id"])
+			}
+		})
+	}
+}
+
+func TestTokenValidator_RequiresAudience(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	server := newTestJWKSServer(t, key)
+	defer server.Close()
+
+	// A validator without an audience accepts no token, rather than tokens
+	// for any resource
+	validator := NewTokenValidator(testIssuer, "", nil, NewJWKSCache(server.URL, nil))
+	token := signTestToken(t, key, map[string]interface{}{
+		"iss": testIssuer,
+		"sub": "user-123",
+		"aud": []string{"https://other.example.com"},
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	if _, err := validator.Validate(context.Background(), token); errors.GetType(err) != errors.ErrorTypeAuthentication {
+		t.Errorf("Expected the token to be rejected, got %v", err)
+	}
+}
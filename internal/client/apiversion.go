@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxTrackedDeprecations bounds the number of deprecated endpoints remembered
+const maxTrackedDeprecations = 256
+
+// apiVersionContextKey is the context key for a per-request API version
+type apiVersionContextKey struct{}
+
+// WithAPIVersion returns a context whose GitHub API requests are sent with the
+// given X-GitHub-Api-Version instead of the client's default
+func WithAPIVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, apiVersionContextKey{}, version)
+}
+
+// ValidAPIVersion reports whether version is a GitHub REST API version date
+// such as 2022-11-28
+func ValidAPIVersion(version string) bool {
+	_, err := time.Parse("2006-01-02", version)
+	return err == nil
+}
+
+// SetAPIVersion sets the X-GitHub-Api-Version sent with every request
+func (c *GitHubClient) SetAPIVersion(version string) {
+	c.apiVersion = version
+}
+
+// requestAPIVersion returns the API version for a request made with ctx
+func (c *GitHubClient) requestAPIVersion(ctx context.Context) string {
+	if version, ok := ctx.Value(apiVersionContextKey{}).(string); ok && version != "" {
+		return version
+	}
+	return c.apiVersion
+}
+
+// DeprecatedEndpoint describes an endpoint GitHub has signalled is deprecated
+// or will be retired, through the Deprecation or Sunset response headers
+type DeprecatedEndpoint struct {
+	Method      string    `json:"method"`
+	Endpoint    string    `json:"endpoint"`
+	Deprecation string    `json:"deprecation,omitempty"`
+	Sunset      string    `json:"sunset,omitempty"`
+	Link        string    `json:"link,omitempty"`
+	Responses   int64     `json:"responses"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+// deprecationTracker records deprecated endpoints seen in responses
+type deprecationTracker struct {
+	mu        sync.Mutex
+	endpoints map[string]*DeprecatedEndpoint
+	responses int64
+}
+
+// observe records a response and reports whether its endpoint is newly
+// tracked as deprecated. It returns nil when the response carries no
+// deprecation headers.
+func (t *deprecationTracker) observe(method, endpoint string, header http.Header) (*DeprecatedEndpoint, bool) {
+	deprecation := header.Get("Deprecation")
+	sunset := header.Get("Sunset")
+	if deprecation == "" && sunset == "" {
+		return nil, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.responses++
+	key := method + " " + endpoint
+	entry, seen := t.endpoints[key]
+	added := false
+	if !seen {
+		entry = &DeprecatedEndpoint{Method: method, Endpoint: endpoint}
+		if t.endpoints == nil {
+			t.endpoints = make(map[string]*DeprecatedEndpoint)
+		}
+		if len(t.endpoints) < maxTrackedDeprecations {
+			t.endpoints[key] = entry
+			added = true
+		}
+	}
+	entry.Deprecation = deprecation
+	entry.Sunset = sunset
+	entry.Link = header.Get("Link")
+	entry.Responses++
+	entry.LastSeen = time.Now()
+
+	snapshot := *entry
+	return &snapshot, added
+}
+
+// checkDeprecation logs responses that signal their endpoint is deprecated,
+// warning once per endpoint
+func (c *GitHubClient) checkDeprecation(ctx context.Context, method, endpoint string, header http.Header) *DeprecatedEndpoint {
+	entry, first := c.deprecations.observe(method, endpoint, header)
+	if entry == nil {
+		return nil
+	}
+
+	log := c.logger.WithContext(ctx)
+	fields := []interface{}{"method", method, "endpoint", endpoint, "deprecation", entry.Deprecation, "sunset", entry.Sunset}
+	if first {
+		log.Warn("GitHub API endpoint is deprecated", fields...)
+	} else {
+		log.Debug("GitHub API endpoint is deprecated", fields...)
+	}
+	return entry
+}
+
+// DeprecatedEndpoints returns the deprecated endpoints seen so far, most recent first
+func (c *GitHubClient) DeprecatedEndpoints() []DeprecatedEndpoint {
+	c.deprecations.mu.Lock()
+	defer c.deprecations.mu.Unlock()
+
+	endpoints := make([]DeprecatedEndpoint, 0, len(c.deprecations.endpoints))
+	for _, entry := range c.deprecations.endpoints {
+		endpoints = append(endpoints, *entry)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].LastSeen.After(endpoints[j].LastSeen)
+	})
+	return endpoints
+}
+
+// DeprecatedResponses returns the number of responses carrying deprecation headers
+func (c *GitHubClient) DeprecatedResponses() int64 {
+	c.deprecations.mu.Lock()
+	defer c.deprecations.mu.Unlock()
+	return c.deprecations.responses
+}
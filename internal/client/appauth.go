@@ -0,0 +1,362 @@
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+const (
+	// appJWTLifetime is how long an app JWT is valid; GitHub allows at most ten minutes
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockSkew backdates app JWTs to tolerate clock drift
+	appJWTClockSkew = time.Minute
+	// installationTokenRefreshMargin is how long before expiry an
+	// installation token is replaced
+	installationTokenRefreshMargin = 5 * time.Minute
+	// DefaultInstallationMissTTL is how long an owner without an
+	// installation of the app is remembered before it is looked up again
+	DefaultInstallationMissTTL = 10 * time.Minute
+)
+
+// AppAuthConfig identifies a GitHub App and the installation it acts as
+type AppAuthConfig struct {
+	AppID int64
+	// PrivateKey is the app's PEM encoded RSA private key
+	PrivateKey []byte
+	// PrivateKeyFile is read for the key when PrivateKey is empty, and read
+	// again when it changes, checked at most once per FileCheckInterval
+	PrivateKeyFile    string
+	FileCheckInterval time.Duration
+	// InstallationID is used for requests not naming an owner with an
+	// installation of the app; zero requires every request to name one
+	InstallationID int64
+	// InstallationMissTTL is how long an owner without an installation is
+	// remembered; DefaultInstallationMissTTL when zero
+	InstallationMissTTL time.Duration
+}
+
+// installationToken is an installation access token and its expiry
+type installationToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// installationEntry is the installation found for an owner, or the error
+// looking it up reported; entries for owners without an installation expire
+type installationEntry struct {
+	id        int64
+	err       error
+	expiresAt time.Time
+}
+
+// appTokenSource authenticates requests as a GitHub App: app endpoints with
+// a JWT signed by the app's key, and other endpoints with a token of the
+// installation on the owner they name, which is minted and refreshed as needed
+type appTokenSource struct {
+	client         *GitHubClient
+	appID          string
+	installationID int64
+	missTTL        time.Duration
+
+	// key signs the JWTs; it is parsed again when keyFile changes
+	keyMu   sync.Mutex
+	key     *rsa.PrivateKey
+	keyFile *watchedFile
+
+	mu sync.Mutex
+	// installations maps owners to the app's installation on them
+	installations map[string]installationEntry
+	tokens        map[int64]installationToken
+
+	// flights looks up an installation or mints a token once for the
+	// requests needing it at the same time
+	flights flightGroup
+}
+
+// SetAppAuth authenticates the client as a GitHub App instead of with a token
+func (c *GitHubClient) SetAppAuth(cfg AppAuthConfig) error {
+	source := &appTokenSource{
+		client:         c,
+		appID:          strconv.FormatInt(cfg.AppID, 10),
+		installationID: cfg.InstallationID,
+		missTTL:        cfg.InstallationMissTTL,
+		installations:  make(map[string]installationEntry),
+		tokens:         make(map[int64]installationToken),
+	}
+	if source.missTTL <= 0 {
+		source.missTTL = DefaultInstallationMissTTL
+	}
+
+	pemKey := cfg.PrivateKey
+	if len(pemKey) == 0 && cfg.PrivateKeyFile != "" {
+		interval := cfg.FileCheckInterval
+		if interval <= 0 {
+			interval = DefaultFileCheckInterval
+		}
+		file, err := newWatchedFile(cfg.PrivateKeyFile, interval)
+		if err != nil {
+			return err
+		}
+		source.keyFile = file
+		pemKey, _, _ = file.read()
+	}
+
+	key, err := parseRSAPrivateKey(pemKey)
+	if err != nil {
+		return err
+	}
+	source.key = key
+	c.tokens = source
+	return nil
+}
+
+// usesAppAuth reports whether the client authenticates as a GitHub App
+func (c *GitHubClient) usesAppAuth() bool {
+	_, ok := c.tokens.(*appTokenSource)
+	return ok
+}
+
+// parseRSAPrivateKey parses a PKCS #1 or PKCS #8 PEM encoded RSA private key
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Validation("GitHub App private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeValidation, "failed to parse GitHub App private key")
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.Validation("GitHub App private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// Token returns the app JWT for app endpoints and an installation token for
+// the others
+func (s *appTokenSource) Token(ctx context.Context, endpoint string) (string, error) {
+	path, _, _ := strings.Cut(endpoint, "?")
+	if path == "/app" || strings.HasPrefix(path, "/app/") {
+		return s.jwt()
+	}
+
+	id, err := s.installationFor(ctx, endpointOwner(path))
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	token, ok := s.tokens[id]
+	s.mu.Unlock()
+	if ok && time.Until(token.expiresAt) > installationTokenRefreshMargin {
+		return token.token, nil
+	}
+
+	minted, err := s.flights.do(ctx, "token:"+strconv.FormatInt(id, 10), func() (interface{}, error) {
+		return s.mintToken(ctx, id)
+	})
+	if err != nil {
+		return "", err
+	}
+	return minted.(string), nil
+}
+
+// mintToken mints a token of installation id and caches it until it nears
+// its expiry
+func (s *appTokenSource) mintToken(ctx context.Context, id int64) (string, error) {
+	var token struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := s.call(ctx, http.MethodPost, fmt.Sprintf("/app/installations/%d/access_tokens", id), &token); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	s.tokens[id] = installationToken{token: token.Token, expiresAt: token.ExpiresAt}
+	s.mu.Unlock()
+	s.client.logger.WithContext(ctx).Debug("Minted GitHub App installation token", "installation_id", id, "expires_at", token.ExpiresAt)
+	return token.Token, nil
+}
+
+// reject drops a cached installation token GitHub rejected, so the next
+// request mints a new one
+func (s *appTokenSource) reject(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, cached := range s.tokens {
+		if cached.token == token {
+			delete(s.tokens, id)
+		}
+	}
+}
+
+// installationFor returns the installation authenticating requests about
+// owner, looking it up on first use
+func (s *appTokenSource) installationFor(ctx context.Context, owner string) (int64, error) {
+	if owner == "" {
+		if s.installationID == 0 {
+			return 0, errors.Validation("the request names no owner; set a default GitHub App installation ID")
+		}
+		return s.installationID, nil
+	}
+
+	key := strings.ToLower(owner)
+	s.mu.Lock()
+	entry, ok := s.installations[key]
+	s.mu.Unlock()
+	if ok && (entry.expiresAt.IsZero() || time.Now().Before(entry.expiresAt)) {
+		return entry.id, entry.err
+	}
+
+	id, err := s.flights.do(ctx, "installation:"+key, func() (interface{}, error) {
+		return s.lookupInstallation(ctx, owner, key)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id.(int64), nil
+}
+
+// lookupInstallation looks up the installation of the app on owner and
+// caches it as key. Owners without an installation are cached for
+// s.missTTL, failed lookups not at all.
+func (s *appTokenSource) lookupInstallation(ctx context.Context, owner, key string) (int64, error) {
+	var installation struct {
+		ID int64 `json:"id"`
+	}
+	err := s.call(ctx, http.MethodGet, "/users/"+url.PathEscape(owner)+"/installation", &installation)
+	var entry installationEntry
+	switch {
+	case err == nil:
+		entry = installationEntry{id: installation.ID}
+	case errors.IsType(err, errors.ErrorTypeNotFound) && s.installationID != 0:
+		// Owners without an installation, such as other users, are read
+		// with the default installation
+		entry = installationEntry{id: s.installationID, expiresAt: time.Now().Add(s.missTTL)}
+	case errors.IsType(err, errors.ErrorTypeNotFound):
+		entry = installationEntry{err: errors.NotFound(fmt.Sprintf("GitHub App is not installed on %s", owner)), expiresAt: time.Now().Add(s.missTTL)}
+	default:
+		return 0, err
+	}
+
+	s.mu.Lock()
+	s.installations[key] = entry
+	s.mu.Unlock()
+	return entry.id, entry.err
+}
+
+// endpointOwner returns the user or organization an endpoint is about, if
+// any. Bot users, which apps cannot be installed on, are about no owner.
+func endpointOwner(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(segments) < 2 || segments[1] == "" || strings.HasSuffix(segments[1], "[bot]") {
+		return ""
+	}
+	switch segments[0] {
+	case "orgs", "repos", "users":
+		return segments[1]
+	}
+	return ""
+}
+
+// signingKey returns the app's private key, parsing it again when its file
+// has changed. A rotated key that cannot be parsed is reported and the
+// previous key kept.
+func (s *appTokenSource) signingKey() *rsa.PrivateKey {
+	s.keyMu.Lock()
+	defer s.keyMu.Unlock()
+	if s.keyFile == nil {
+		return s.key
+	}
+	data, changed, _ := s.keyFile.read()
+	if !changed {
+		return s.key
+	}
+	key, err := parseRSAPrivateKey(data)
+	if err != nil {
+		s.client.logger.Error("Ignoring the changed GitHub App private key", "path", s.keyFile.path, "error", err)
+		return s.key
+	}
+	s.client.logger.Info("Reloaded the GitHub App private key from its file", "path", s.keyFile.path)
+	s.key = key
+	return key
+}
+
+// jwt returns a JWT authenticating as the app
+func (s *appTokenSource) jwt() (string, error) {
+	now := time.Now()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": s.appID,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, errors.ErrorTypeInternal, "failed to encode GitHub App JWT claims")
+	}
+
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.signingKey(), crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, errors.ErrorTypeInternal, "failed to sign GitHub App JWT")
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// call sends an app-authenticated request and decodes its response into v.
+// It bypasses the client's request path, which would ask for a token again.
+func (s *appTokenSource) call(ctx context.Context, method, endpoint string, v interface{}) error {
+	jwt, err := s.jwt()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := s.client.requestContext(ctx)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, s.client.baseURL+endpoint, nil)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to create HTTP request")
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", DefaultMediaType)
+	req.Header.Set("X-GitHub-Api-Version", s.client.apiVersion)
+	req.Header.Set("User-Agent", s.client.userAgent)
+
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrorTypeNetwork, "GitHub App authentication request failed")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrorTypeNetwork, "failed to read response body")
+	}
+	if resp.StatusCode >= 400 {
+		return s.client.handleAPIError(resp.StatusCode, resp.Header, body)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.Wrap(err, errors.ErrorTypeGitHubAPI, "failed to decode GitHub App authentication response")
+	}
+	return nil
+}
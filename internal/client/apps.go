@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// GitHub Apps data structures
+
+// Installation represents a GitHub App installation
+type Installation struct {
+	ID                  int64             `json:"id"`
+	AppID               int64             `json:"app_id"`
+	AppSlug             string            `json:"app_slug"`
+	TargetID            int64             `json:"target_id"`
+	TargetType          string            `json:"target_type"`
+	Account             User              `json:"account"`
+	RepositorySelection string            `json:"repository_selection"`
+	AccessTokensURL     string            `json:"access_tokens_url"`
+	RepositoriesURL     string            `json:"repositories_url"`
+	HTMLURL             string            `json:"html_url"`
+	Permissions         map[string]string `json:"permissions"`
+	Events              []string          `json:"events"`
+	SuspendedAt         *string           `json:"suspended_at"`
+	CreatedAt           string            `json:"created_at"`
+	UpdatedAt           string            `json:"updated_at"`
+}
+
+// InstallationList is the paginated list envelope returned by installation endpoints
+type InstallationList struct {
+	TotalCount    int            `json:"total_count"`
+	Installations []Installation `json:"installations"`
+}
+
+// InstallationRepositoryList is the paginated list envelope of repositories accessible to an installation
+type InstallationRepositoryList struct {
+	TotalCount   int          `json:"total_count"`
+	Repositories []Repository `json:"repositories"`
+}
+
+// App is a GitHub App
+type App struct {
+	ID      int64  `json:"id"`
+	Slug    string `json:"slug"`
+	Name    string `json:"name"`
+	Owner   User   `json:"owner"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CommitIdentity is the author or committer recorded on a commit
+type CommitIdentity struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	// Date is set on the identities of commits read from GitHub
+	Date string `json:"date,omitempty"`
+}
+
+// GitHub Apps API client functions
+
+// ListOrganizationInstallations lists GitHub App installations in an organization
+func (c *GitHubClient) ListOrganizationInstallations(ctx context.Context, org string, page, perPage int) (*InstallationList, *PageInfo, error) {
+	c.logger.Debug("Listing organization installations", "org", org, "page", page, "per_page", perPage)
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/orgs/%s/installations", org), pageParams(page, perPage))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var installations InstallationList
+	if err := resp.GetJSON(&installations); err != nil {
+		return nil, nil, err
+	}
+
+	return &installations, resp.PageInfo(), nil
+}
+
+// ListUserInstallations lists GitHub App installations the authenticated user can access
+func (c *GitHubClient) ListUserInstallations(ctx context.Context, page, perPage int) (*InstallationList, *PageInfo, error) {
+	c.logger.Debug("Listing user installations", "page", page, "per_page", perPage)
+
+	resp, err := c.Get(ctx, "/user/installations", pageParams(page, perPage))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var installations InstallationList
+	if err := resp.GetJSON(&installations); err != nil {
+		return nil, nil, err
+	}
+
+	return &installations, resp.PageInfo(), nil
+}
+
+// ListInstallationRepositories lists repositories the authenticated user can access through an installation
+func (c *GitHubClient) ListInstallationRepositories(ctx context.Context, installationID int64, page, perPage int) (*InstallationRepositoryList, *PageInfo, error) {
+	c.logger.Debug("Listing installation repositories", "installation_id", installationID, "page", page, "per_page", perPage)
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/user/installations/%d/repositories", installationID), pageParams(page, perPage))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var repositories InstallationRepositoryList
+	if err := resp.GetJSON(&repositories); err != nil {
+		return nil, nil, err
+	}
+
+	return &repositories, resp.PageInfo(), nil
+}
+
+// AddInstallationRepository adds a repository to an installation with selected repository access
+func (c *GitHubClient) AddInstallationRepository(ctx context.Context, installationID, repositoryID int64) error {
+	c.logger.Debug("Adding installation repository", "installation_id", installationID, "repository_id", repositoryID)
+
+	_, err := c.Put(ctx, fmt.Sprintf("/user/installations/%d/repositories/%d", installationID, repositoryID), nil)
+	return err
+}
+
+// RemoveInstallationRepository removes a repository from an installation with selected repository access
+func (c *GitHubClient) RemoveInstallationRepository(ctx context.Context, installationID, repositoryID int64) error {
+	c.logger.Debug("Removing installation repository", "installation_id", installationID, "repository_id", repositoryID)
+
+	_, err := c.Delete(ctx, fmt.Sprintf("/user/installations/%d/repositories/%d", installationID, repositoryID))
+	return err
+}
+
+// GetAuthenticatedApp gets the GitHub App the client authenticates as
+func (c *GitHubClient) GetAuthenticatedApp(ctx context.Context) (*App, error) {
+	c.logger.Debug("Getting authenticated app")
+
+	resp, err := c.Get(ctx, "/app", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var app App
+	if err := resp.GetJSON(&app); err != nil {
+		return nil, err
+	}
+
+	return &app, nil
+}
+
+// GetAppBotCommitIdentity resolves the commit identity of a GitHub App's bot user.
+// Commits authored with this identity are attributed to the app on GitHub.
+func (c *GitHubClient) GetAppBotCommitIdentity(ctx context.Context, appSlug string) (*CommitIdentity, error) {
+	c.logger.Debug("Getting app bot commit identity", "app_slug", appSlug)
+
+	if appSlug == "" {
+		return nil, errors.Validation("app slug is required")
+	}
+
+	botLogin := appSlug + "[bot]"
+	bot, err := c.GetUser(ctx, botLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommitIdentity{
+		Name:  botLogin,
+		Email: fmt.Sprintf("%d+%s@users.noreply.github.com", bot.ID, botLogin),
+	}, nil
+}
+
+// SetCommitAuthor authors the commits of file changes with identity, such as
+// the bot identity of a GitHub App; nil leaves the author to GitHub. The
+// committer is left to GitHub, which signs the commits of GitHub Apps.
+func (c *GitHubClient) SetCommitAuthor(identity *CommitIdentity) {
+	c.commitAuthor = identity
+}
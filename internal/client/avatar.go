@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+const (
+	// avatarHost serves the avatars of github.com users
+	avatarHost = "avatars.githubusercontent.com"
+	// maxAvatarSize bounds the size of a downloaded avatar image
+	maxAvatarSize = 1 << 20
+)
+
+// GetAvatar downloads the avatar image at avatarURL, as returned in the
+// avatar_url of users and organizations, scaled to size pixels when size is
+// positive. It returns the image and its media type. Only GitHub's avatar
+// host and the host of the API are contacted, and no token is sent.
+func (c *GitHubClient) GetAvatar(ctx context.Context, avatarURL string, size int) ([]byte, string, error) {
+	target, err := url.Parse(avatarURL)
+	if err != nil || target.Scheme != "https" {
+		return nil, "", errors.Validation("invalid avatar URL").WithContext("url", avatarURL)
+	}
+	base, _ := url.Parse(c.baseURL)
+	if target.Host != avatarHost && (base == nil || target.Host != base.Host) {
+		return nil, "", errors.Validation("avatar URL is not on a GitHub host").WithContext("url", avatarURL)
+	}
+	if size > 0 {
+		query := target.Query()
+		query.Set("s", strconv.Itoa(size))
+		target.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, "", errors.Wrap(err, errors.ErrorTypeValidation, "failed to create avatar request")
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return nil, "", errors.Wrap(err, errors.ErrorTypeNetwork, "avatar request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", errors.GitHubAPI(fmt.Sprintf("avatar request failed with status %d", resp.StatusCode))
+	}
+
+	mediaType := strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	if !strings.HasPrefix(mediaType, "image/") {
+		return nil, "", errors.GitHubAPI(fmt.Sprintf("avatar is not an image: %s", mediaType))
+	}
+	image, err := io.ReadAll(io.LimitReader(resp.Body, maxAvatarSize+1))
+	if err != nil {
+		return nil, "", errors.Wrap(err, errors.ErrorTypeNetwork, "failed to read avatar")
+	}
+	if len(image) > maxAvatarSize {
+		return nil, "", errors.GitHubAPI("avatar is too large")
+	}
+	return image, mediaType, nil
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// GitHub Branch Protection data structures
+
+// BranchProtection represents the protection of a branch. Protections not
+// set on the branch are nil.
+type BranchProtection struct {
+	URL                            string                      `json:"url"`
+	RequiredStatusChecks           *RequiredStatusChecks       `json:"required_status_checks,omitempty"`
+	EnforceAdmins                  *ProtectionSetting          `json:"enforce_admins,omitempty"`
+	RequiredPullRequestReviews     *RequiredPullRequestReviews `json:"required_pull_request_reviews,omitempty"`
+	Restrictions                   *BranchRestrictions         `json:"restrictions,omitempty"`
+	RequiredLinearHistory          *ProtectionSetting          `json:"required_linear_history,omitempty"`
+	AllowForcePushes               *ProtectionSetting          `json:"allow_force_pushes,omitempty"`
+	AllowDeletions                 *ProtectionSetting          `json:"allow_deletions,omitempty"`
+	RequiredConversationResolution *ProtectionSetting          `json:"required_conversation_resolution,omitempty"`
+	LockBranch                     *ProtectionSetting          `json:"lock_branch,omitempty"`
+}
+
+// ProtectionSetting is a branch protection that is either on or off
+type ProtectionSetting struct {
+	Enabled bool `json:"enabled"`
+}
+
+// RequiredStatusChecks are the status checks that must pass before a branch
+// can be merged into
+type RequiredStatusChecks struct {
+	// Strict requires branches to be up to date before merging
+	Strict   bool     `json:"strict"`
+	Contexts []string `json:"contexts"`
+	Checks   []struct {
+		Context string `json:"context"`
+		AppID   *int64 `json:"app_id"`
+	} `json:"checks,omitempty"`
+}
+
+// RequiredPullRequestReviews are the reviews pull requests need before
+// they can be merged into a branch
+type RequiredPullRequestReviews struct {
+	DismissStaleReviews          bool                `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool                `json:"require_code_owner_reviews"`
+	RequiredApprovingReviewCount int                 `json:"required_approving_review_count"`
+	RequireLastPushApproval      bool                `json:"require_last_push_approval"`
+	DismissalRestrictions        *BranchRestrictions `json:"dismissal_restrictions,omitempty"`
+}
+
+// BranchRestrictions are the users, teams and apps allowed to push to a
+// branch, or to dismiss its reviews
+type BranchRestrictions struct {
+	Users []User `json:"users"`
+	Teams []Team `json:"teams"`
+	Apps  []struct {
+		ID   int64  `json:"id"`
+		Slug string `json:"slug"`
+		Name string `json:"name"`
+	} `json:"apps"`
+}
+
+// GitHub Branch Protection API client functions
+
+// branchProtectionEndpoint returns the endpoint of the protection of a
+// branch, whose name may contain slashes
+func branchProtectionEndpoint(owner, repo, branch string) (string, error) {
+	escaped, err := escapePath(branch)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/repos/%s/%s/branches/%s/protection", owner, repo, escaped), nil
+}
+
+// GetBranchProtection gets the protection of a branch
+func (c *GitHubClient) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*BranchProtection, error) {
+	c.logger.Debug("Getting branch protection", "owner", owner, "repo", repo, "branch", branch)
+
+	endpoint, err := branchProtectionEndpoint(owner, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Get(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var protection BranchProtection
+	if err := resp.GetJSON(&protection); err != nil {
+		return nil, err
+	}
+
+	return &protection, nil
+}
+
+// UpdateBranchProtection replaces the protection of a branch. protection
+// must set required_status_checks, enforce_admins,
+// required_pull_request_reviews and restrictions, to null to turn them off,
+// and turns off the other protections it leaves out.
+func (c *GitHubClient) UpdateBranchProtection(ctx context.Context, owner, repo, branch string, protection map[string]interface{}) (*BranchProtection, error) {
+	c.logger.Debug("Updating branch protection", "owner", owner, "repo", repo, "branch", branch)
+
+	endpoint, err := branchProtectionEndpoint(owner, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Put(ctx, endpoint, protection)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated BranchProtection
+	if err := resp.GetJSON(&updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteBranchProtection removes the protection of a branch
+func (c *GitHubClient) DeleteBranchProtection(ctx context.Context, owner, repo, branch string) error {
+	c.logger.Debug("Deleting branch protection", "owner", owner, "repo", repo, "branch", branch)
+
+	endpoint, err := branchProtectionEndpoint(owner, repo, branch)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Delete(ctx, endpoint)
+	return err
+}
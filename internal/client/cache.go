@@ -0,0 +1,147 @@
+package client
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// DefaultResponseCacheSize is the default number of GET responses cached
+	DefaultResponseCacheSize = 1000
+	// maxCachedBodySize bounds the size of a cached response body
+	maxCachedBodySize = 1 << 20
+)
+
+// cachedResponse is a GET response stored with the ETag used to revalidate it
+type cachedResponse struct {
+	key        string
+	etag       string
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// responseCache holds GET responses by request, least recently used first
+// out. Entries are always revalidated with If-None-Match, so they never
+// need to expire; a 304 Not Modified reply costs GitHub no rate limit.
+type responseCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+
+	// hits counts responses served from the cache after a 304
+	hits atomic.Int64
+}
+
+// newResponseCache creates a cache holding up to size responses
+func newResponseCache(size int) *responseCache {
+	return &responseCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// cacheKey identifies a GET request. Responses depend on the credentials and
+// the representation requested, so those are part of the key.
+func cacheKey(req *http.Request) string {
+	auth := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return req.URL.String() + "\x00" +
+		req.Header.Get("Accept") + "\x00" +
+		req.Header.Get("X-GitHub-Api-Version") + "\x00" +
+		hex.EncodeToString(auth[:8])
+}
+
+// get returns the cached response for key, if any
+func (rc *responseCache) get(key string) *cachedResponse {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	elem, ok := rc.entries[key]
+	if !ok {
+		return nil
+	}
+	rc.order.MoveToFront(elem)
+	return elem.Value.(*cachedResponse)
+}
+
+// put stores a successful response carrying an ETag and returns its entry,
+// or nil when the response cannot be cached
+func (rc *responseCache) put(key string, resp *APIResponse) *cachedResponse {
+	etag := resp.Headers.Get("ETag")
+	if etag == "" || resp.StatusCode != http.StatusOK || len(resp.Body) > maxCachedBodySize {
+		return nil
+	}
+	entry := &cachedResponse{
+		key:        key,
+		etag:       etag,
+		statusCode: resp.StatusCode,
+		header:     resp.Headers.Clone(),
+		body:       append([]byte(nil), resp.Body...),
+	}
+	rc.add(entry)
+	return entry
+}
+
+// add stores entry, evicting the least recently used response when the
+// cache is full
+func (rc *responseCache) add(entry *cachedResponse) {
+	key := entry.key
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if elem, ok := rc.entries[key]; ok {
+		elem.Value = entry
+		rc.order.MoveToFront(elem)
+		return
+	}
+	rc.entries[key] = rc.order.PushFront(entry)
+	for rc.order.Len() > rc.size {
+		oldest := rc.order.Back()
+		rc.order.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*cachedResponse).key)
+	}
+}
+
+// revalidated builds the response for a 304 reply to a request for entry:
+// the cached status and body, with the headers of the 304 (such as the
+// current rate limit) replacing the stored ones
+func (rc *responseCache) revalidated(entry *cachedResponse, notModified *http.Response) *http.Response {
+	rc.hits.Add(1)
+
+	header := entry.header.Clone()
+	for name, values := range notModified.Header {
+		header[name] = values
+	}
+	return &http.Response{
+		StatusCode: entry.statusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(entry.body)),
+	}
+}
+
+// SetResponseCache caches up to size GET responses and revalidates them
+// with If-None-Match; zero disables the cache
+func (c *GitHubClient) SetResponseCache(size int) {
+	if size <= 0 {
+		c.cache = nil
+		return
+	}
+	c.cache = newResponseCache(size)
+}
+
+// CachedResponses returns the number of GET responses served from the cache
+// because GitHub reported them not modified
+func (c *GitHubClient) CachedResponses() int64 {
+	if c.cache == nil {
+		return 0
+	}
+	return c.cache.hits.Load()
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+)
+
+// SetCallLogSampleRate sets the fraction of GitHub API calls, between 0 and
+// 1, logged at INFO so operators can see which tools are expensive; the
+// other calls are logged at DEBUG
+func (c *GitHubClient) SetCallLogSampleRate(rate float64) {
+	c.callLogSampleRate = rate
+}
+
+// logAPICall logs a GitHub API call with the tool it was made for
+func (c *GitHubClient) logAPICall(ctx context.Context, method, endpoint string, apiResp *APIResponse, err error, duration time.Duration) {
+	level := slog.LevelDebug
+	if c.callLogSampleRate > 0 && rand.Float64() < c.callLogSampleRate {
+		level = slog.LevelInfo
+	}
+	log := c.logger.WithContext(ctx)
+	if !log.Enabled(ctx, level) {
+		return
+	}
+
+	status, remaining := 0, -1
+	if apiResp != nil {
+		status = apiResp.StatusCode
+		if n, convErr := strconv.Atoi(apiResp.RateLimit.Remaining); convErr == nil {
+			remaining = n
+		}
+	}
+
+	var fields []interface{}
+	if tool := execstats.FromContext(ctx).Tool(); tool != "" {
+		fields = append(fields, "tool", tool)
+	}
+	if err != nil {
+		fields = append(fields, "error", err)
+	}
+	log.LogGitHubAPICall(level, method, endpoint, status, duration.String(), remaining, fields...)
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// GitHub Commits data structures
+
+// Commit represents a commit of a repository. Stats and Files are only
+// returned for a single commit, not in lists.
+type Commit struct {
+	SHA     string    `json:"sha"`
+	NodeID  string    `json:"node_id"`
+	URL     string    `json:"url"`
+	HTMLURL string    `json:"html_url"`
+	Commit  GitCommit `json:"commit"`
+	// Author and Committer are the GitHub users of the commit's identities,
+	// nil when their emails match no user
+	Author    *User `json:"author"`
+	Committer *User `json:"committer"`
+	Parents   []struct {
+		SHA     string `json:"sha"`
+		URL     string `json:"url"`
+		HTMLURL string `json:"html_url"`
+	} `json:"parents"`
+	Stats *CommitStats `json:"stats,omitempty"`
+	Files []CommitFile `json:"files,omitempty"`
+}
+
+// GitCommit is the git data of a commit
+type GitCommit struct {
+	Message      string         `json:"message"`
+	Author       CommitIdentity `json:"author"`
+	Committer    CommitIdentity `json:"committer"`
+	CommentCount int            `json:"comment_count"`
+	Tree         struct {
+		SHA string `json:"sha"`
+		URL string `json:"url"`
+	} `json:"tree"`
+	Verification *struct {
+		Verified bool   `json:"verified"`
+		Reason   string `json:"reason"`
+	} `json:"verification,omitempty"`
+}
+
+// CommitStats counts the lines a commit changed
+type CommitStats struct {
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+	Total     int `json:"total"`
+}
+
+// CommitFile is a file changed by a commit or between two commits
+type CommitFile struct {
+	SHA      string `json:"sha"`
+	Filename string `json:"filename"`
+	// Status is added, removed, modified, renamed, copied, changed or
+	// unchanged
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Changes   int    `json:"changes"`
+	BlobURL   string `json:"blob_url"`
+	RawURL    string `json:"raw_url"`
+	// Patch is the file's diff, left out by GitHub for binary and very
+	// large files
+	Patch            string `json:"patch,omitempty"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+}
+
+// Comparison is the comparison of two commits of a repository
+type Comparison struct {
+	URL             string `json:"url"`
+	HTMLURL         string `json:"html_url"`
+	PermalinkURL    string `json:"permalink_url"`
+	DiffURL         string `json:"diff_url"`
+	PatchURL        string `json:"patch_url"`
+	BaseCommit      Commit `json:"base_commit"`
+	MergeBaseCommit Commit `json:"merge_base_commit"`
+	// Status is diverged, ahead, behind or identical, of head compared to
+	// base
+	Status       string       `json:"status"`
+	AheadBy      int          `json:"ahead_by"`
+	BehindBy     int          `json:"behind_by"`
+	TotalCommits int          `json:"total_commits"`
+	Commits      []Commit     `json:"commits"`
+	Files        []CommitFile `json:"files,omitempty"`
+}
+
+// GitHub Commits API client functions
+
+// ListCommits lists the commits of a repository, newest first, reachable
+// from sha, or the default branch if sha is empty. path, author, since and
+// until filter the commits when not empty; since and until are ISO 8601
+// timestamps.
+func (c *GitHubClient) ListCommits(ctx context.Context, owner, repo, sha, path, author, since, until string, page, perPage int) ([]Commit, *PageInfo, error) {
+	c.logger.Debug("Listing commits", "owner", owner, "repo", repo, "sha", sha, "path", path, "author", author, "page", page, "per_page", perPage)
+
+	params := pageParams(page, perPage)
+	for name, value := range map[string]string{"sha": sha, "path": path, "author": author, "since": since, "until": until} {
+		if value != "" {
+			params[name] = value
+		}
+	}
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/repos/%s/%s/commits", owner, repo), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var commits []Commit
+	if err := resp.GetJSON(&commits); err != nil {
+		return nil, nil, err
+	}
+
+	return commits, resp.PageInfo(), nil
+}
+
+// GetCommit gets a commit by SHA, branch or tag, with its stats and the
+// files it changed
+func (c *GitHubClient) GetCommit(ctx context.Context, owner, repo, ref string) (*Commit, error) {
+	c.logger.Debug("Getting commit", "owner", owner, "repo", repo, "ref", ref)
+
+	escaped, err := escapePath(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s", owner, repo, escaped), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var commit Commit
+	if err := resp.GetJSON(&commit); err != nil {
+		return nil, err
+	}
+
+	return &commit, nil
+}
+
+// CompareCommits compares head to base, which are commit SHAs, branches or
+// tags. The commits between them are paginated by page and perPage; GitHub
+// returns every file changed on the first page only.
+func (c *GitHubClient) CompareCommits(ctx context.Context, owner, repo, base, head string, page, perPage int) (*Comparison, error) {
+	c.logger.Debug("Comparing commits", "owner", owner, "repo", repo, "base", base, "head", head, "page", page, "per_page", perPage)
+
+	escapedBase, err := escapePath(base)
+	if err != nil {
+		return nil, err
+	}
+	escapedHead, err := escapePath(head)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/repos/%s/%s/compare/%s...%s", owner, repo, escapedBase, escapedHead), pageParams(page, perPage))
+	if err != nil {
+		return nil, err
+	}
+
+	var comparison Comparison
+	if err := resp.GetJSON(&comparison); err != nil {
+		return nil, err
+	}
+
+	return &comparison, nil
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// GitHub Contents data structures
+
+// FileContent represents a file returned by the repository contents API
+type FileContent struct {
+	Type        string `json:"type"`
+	Encoding    string `json:"encoding"`
+	Size        int    `json:"size"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Content     string `json:"content"`
+	SHA         string `json:"sha"`
+	URL         string `json:"url"`
+	HTMLURL     string `json:"html_url"`
+	DownloadURL string `json:"download_url"`
+}
+
+// FileCommit is the result of a change to a file through the contents API:
+// the file as changed, nil once deleted, and the commit making the change
+type FileCommit struct {
+	Content *FileContent `json:"content"`
+	Commit  struct {
+		SHA       string         `json:"sha"`
+		HTMLURL   string         `json:"html_url"`
+		Message   string         `json:"message"`
+		Author    CommitIdentity `json:"author"`
+		Committer CommitIdentity `json:"committer"`
+	} `json:"commit"`
+}
+
+// DecodedContent returns the file content decoded from its transfer encoding
+func (f *FileContent) DecodedContent() ([]byte, error) {
+	switch f.Encoding {
+	case "base64":
+		// GitHub wraps base64 content at 60 characters
+		decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(f.Content, "\n", ""))
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to decode file content")
+		}
+		return decoded, nil
+	case "", "utf-8":
+		return []byte(f.Content), nil
+	default:
+		return nil, errors.Internal(fmt.Sprintf("unsupported content encoding %q", f.Encoding))
+	}
+}
+
+// GitHub Contents API client functions
+
+// GetFileContents gets a file from a repository at the given ref, or the default branch if ref is empty
+func (c *GitHubClient) GetFileContents(ctx context.Context, owner, repo, path, ref string) (*FileContent, error) {
+	file, _, err := c.GetContents(ctx, owner, repo, path, ref)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, errors.Validation(fmt.Sprintf("%s is a dir, not a file", path))
+	}
+	return file, nil
+}
+
+// GetContents gets a file or directory from a repository at the given ref,
+// or the default branch if ref is empty. It returns the file, or the entries
+// of the directory without their content.
+func (c *GitHubClient) GetContents(ctx context.Context, owner, repo, path, ref string) (*FileContent, []FileContent, error) {
+	c.logger.Debug("Getting file contents", "owner", owner, "repo", repo, "path", path, "ref", ref)
+
+	params := make(map[string]string)
+	if ref != "" {
+		params["ref"] = ref
+	}
+
+	// The root directory is listed without a path
+	endpoint := fmt.Sprintf("/repos/%s/%s/contents", owner, repo)
+	if strings.Trim(path, "/") != "" {
+		escaped, err := escapePath(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		endpoint += "/" + escaped
+	}
+
+	resp, err := c.Get(ctx, endpoint, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Directories are listed as an array of their entries
+	if strings.HasPrefix(strings.TrimSpace(string(resp.Body)), "[") {
+		var entries []FileContent
+		if err := resp.GetJSON(&entries); err != nil {
+			return nil, nil, err
+		}
+		return nil, entries, nil
+	}
+
+	var content FileContent
+	if err := resp.GetJSON(&content); err != nil {
+		return nil, nil, err
+	}
+	if content.Type != "file" {
+		return nil, nil, errors.Validation(fmt.Sprintf("%s is a %s, not a file", path, content.Type))
+	}
+
+	return &content, nil, nil
+}
+
+// CreateOrUpdateFile commits content to a file on branch, or the default
+// branch if branch is empty. sha is the blob SHA of the file being replaced,
+// and must be empty when creating a file.
+func (c *GitHubClient) CreateOrUpdateFile(ctx context.Context, owner, repo, path string, content []byte, message, branch, sha string) (*FileCommit, error) {
+	c.logger.Debug("Creating or updating file", "owner", owner, "repo", repo, "path", path, "branch", branch)
+
+	body := map[string]interface{}{
+		"message": message,
+		"content": base64.StdEncoding.EncodeToString(content),
+	}
+	if branch != "" {
+		body["branch"] = branch
+	}
+	if sha != "" {
+		body["sha"] = sha
+	}
+	if c.commitAuthor != nil {
+		body["author"] = c.commitAuthor
+	}
+
+	escaped, err := escapePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Put(ctx, fmt.Sprintf("/repos/%s/%s/contents/%s", owner, repo, escaped), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var commit FileCommit
+	if err := resp.GetJSON(&commit); err != nil {
+		return nil, err
+	}
+
+	return &commit, nil
+}
+
+// DeleteFile commits the deletion of the file with blob SHA sha from branch,
+// or the default branch if branch is empty
+func (c *GitHubClient) DeleteFile(ctx context.Context, owner, repo, path, message, branch, sha string) (*FileCommit, error) {
+	c.logger.Debug("Deleting file", "owner", owner, "repo", repo, "path", path, "branch", branch)
+
+	body := map[string]interface{}{
+		"message": message,
+		"sha":     sha,
+	}
+	if branch != "" {
+		body["branch"] = branch
+	}
+	if c.commitAuthor != nil {
+		body["author"] = c.commitAuthor
+	}
+
+	escaped, err := escapePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/repos/%s/%s/contents/%s", owner, repo, escaped), nil, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var commit FileCommit
+	if err := resp.GetJSON(&commit); err != nil {
+		return nil, err
+	}
+
+	return &commit, nil
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Download streams the response to a GET request for endpoint to w instead
+// of buffering it in an APIResponse, returning the number of bytes written.
+// The Accept media type is set with WithMediaType. Redirects to
+// codeload or blob storage are followed without the Authorization header.
+// The client timeout, or a sooner context deadline, covers the whole
+// transfer.
+func (c *GitHubClient) Download(ctx context.Context, endpoint string, params map[string]string, w io.Writer) (written int64, err error) {
+	if err := c.checkAccess(http.MethodGet, endpoint, params); err != nil {
+		return 0, err
+	}
+	ctx, span := tracer.Start(ctx, "GitHub API download",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("github.endpoint", endpoint)))
+	start := time.Now()
+	var apiResp *APIResponse
+	defer func() {
+		c.logAPICall(ctx, http.MethodGet, endpoint, apiResp, err, time.Since(start))
+		execstats.FromContext(ctx).AddEndpoint(http.MethodGet, endpoint)
+		if errors.IsType(err, errors.ErrorTypeRateLimit) || errors.IsType(err, errors.ErrorTypeSecondaryRateLimit) {
+			execstats.FromContext(ctx).SetRateLimited(err)
+		}
+		span.SetAttributes(attribute.Int64("github.download.bytes", written))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err := c.reserveBudget(ctx, endpoint); err != nil {
+		return 0, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(params) > 0 {
+		q := req.URL.Query()
+		for key, value := range params {
+			q.Add(key, value)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	c.logger.WithContext(ctx).Debug("Downloading from GitHub API", "url", req.URL.String(), "endpoint", endpoint)
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.breaker.record(true)
+		}
+		return 0, errors.Wrap(err, errors.ErrorTypeNetwork, "GitHub API request failed")
+	}
+	defer resp.Body.Close()
+	c.breaker.record(resp.StatusCode >= http.StatusInternalServerError)
+	if observer, ok := c.tokens.(rateLimitObserver); ok {
+		observer.observe(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "), resp.StatusCode, resp.Header)
+	}
+	c.rejectToken(req, resp.StatusCode)
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxInspectedBodySize))
+		apiResp = newAPIResponse(resp, body)
+		c.recordRateLimit(ctx, apiResp)
+		return 0, c.handleAPIError(resp.StatusCode, resp.Header, body)
+	}
+	apiResp = newAPIResponse(resp, nil)
+	c.recordRateLimit(ctx, apiResp)
+
+	written, err = io.Copy(w, resp.Body)
+	if err != nil {
+		return written, errors.Wrap(err, errors.ErrorTypeNetwork, "failed to stream response body").WithContext("bytes_written", written)
+	}
+	return written, nil
+}
+
+// DownloadFileContents streams the raw contents of a file at ref, or the
+// default branch if ref is empty, to w. Unlike GetFileContents it handles
+// files larger than 1 MB.
+func (c *GitHubClient) DownloadFileContents(ctx context.Context, owner, repo, path, ref string, w io.Writer) (int64, error) {
+	c.logger.Debug("Downloading file contents", "owner", owner, "repo", repo, "path", path, "ref", ref)
+
+	params := make(map[string]string)
+	if ref != "" {
+		params["ref"] = ref
+	}
+	escaped, err := escapePath(path)
+	if err != nil {
+		return 0, err
+	}
+	return c.Download(WithMediaType(ctx, RawMediaType), fmt.Sprintf("/repos/%s/%s/contents/%s", owner, repo, escaped), params, w)
+}
+
+// DownloadArchive streams a zipball or tarball of a repository at ref, or
+// the default branch if ref is empty, to w
+func (c *GitHubClient) DownloadArchive(ctx context.Context, owner, repo, format, ref string, w io.Writer) (int64, error) {
+	c.logger.Debug("Downloading repository archive", "owner", owner, "repo", repo, "format", format, "ref", ref)
+
+	if format != "zipball" && format != "tarball" {
+		return 0, errors.Validation(fmt.Sprintf("unsupported archive format %q, expected zipball or tarball", format))
+	}
+	endpoint := fmt.Sprintf("/repos/%s/%s/%s", owner, repo, format)
+	if ref != "" {
+		escaped, err := escapePath(ref)
+		if err != nil {
+			return 0, err
+		}
+		endpoint += "/" + escaped
+	}
+	return c.Download(ctx, endpoint, nil, w)
+}
+
+// DownloadWorkflowRunLogs streams the zip archive of the logs of a workflow
+// run to w
+func (c *GitHubClient) DownloadWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64, w io.Writer) (int64, error) {
+	c.logger.Debug("Downloading workflow run logs", "owner", owner, "repo", repo, "run_id", runID)
+
+	return c.Download(ctx, fmt.Sprintf("/repos/%s/%s/actions/runs/%d/logs", owner, repo, runID), nil, w)
+}
+
+// DownloadArtifact streams the zip archive of a workflow artifact to w
+func (c *GitHubClient) DownloadArtifact(ctx context.Context, owner, repo string, artifactID int64, w io.Writer) (int64, error) {
+	c.logger.Debug("Downloading workflow artifact", "owner", owner, "repo", repo, "artifact_id", artifactID)
+
+	return c.Download(ctx, fmt.Sprintf("/repos/%s/%s/actions/artifacts/%d/zip", owner, repo, artifactID), nil, w)
+}
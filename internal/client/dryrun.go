@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// PlannedRequest is a GitHub API request a dry run described instead of
+// sending it
+type PlannedRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// dryRunKey is the context key of the requests planned by a dry run
+type dryRunKey struct{}
+
+// readRequestKey is the context key marking requests as reads
+type readRequestKey struct{}
+
+// dryRun collects the requests planned under a dry run
+type dryRun struct {
+	mu      sync.Mutex
+	planned []PlannedRequest
+}
+
+// WithDryRun returns a context under which requests that change data are
+// described rather than sent, and fail with a validation error so that the
+// caller stops. GET requests are still sent.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, &dryRun{})
+}
+
+// PlannedRequests returns the requests described under the dry run of ctx
+func PlannedRequests(ctx context.Context) []PlannedRequest {
+	run, ok := ctx.Value(dryRunKey{}).(*dryRun)
+	if !ok {
+		return nil
+	}
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	return append([]PlannedRequest(nil), run.planned...)
+}
+
+// WithReadRequest marks requests made with ctx as reads even when they use
+// POST, such as GraphQL queries, so dry runs still send them
+func WithReadRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readRequestKey{}, true)
+}
+
+// planRequest records a request that changes data instead of sending it
+// when ctx is a dry run, reporting whether it did with the error returned
+// to the caller
+func (c *GitHubClient) planRequest(ctx context.Context, method, endpoint string, params map[string]string, body interface{}) (bool, error) {
+	run, ok := ctx.Value(dryRunKey{}).(*dryRun)
+	if !ok || method == http.MethodGet || ctx.Value(readRequestKey{}) != nil {
+		return false, nil
+	}
+
+	planned := PlannedRequest{Method: method, URL: c.endpointURL(endpoint)}
+	if len(params) > 0 {
+		query := url.Values{}
+		for key, value := range params {
+			query.Add(key, value)
+		}
+		planned.URL += "?" + query.Encode()
+	}
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return true, errors.Wrap(err, errors.ErrorTypeValidation, "failed to marshal request body")
+		}
+		planned.Body = data
+	}
+
+	run.mu.Lock()
+	run.planned = append(run.planned, planned)
+	run.mu.Unlock()
+	c.logger.WithContext(ctx).Debug("Dry run: GitHub API request not sent", "method", method, "url", planned.URL)
+	return true, errors.Validation(fmt.Sprintf("dry run: %s %s was not sent", method, endpoint))
+}
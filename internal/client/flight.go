@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup runs one call per key at a time, sharing its result with the
+// callers asking for the same key while it runs
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is a call in progress; done is closed once value and err are set
+type flight struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// do calls fn for key, or waits for the call for key in progress and returns
+// its result; waiting stops when ctx is done
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if f, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.value, f.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if g.calls == nil {
+		g.calls = make(map[string]*flight)
+	}
+	f := &flight{done: make(chan struct{})}
+	g.calls[key] = f
+	g.mu.Unlock()
+
+	f.value, f.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(f.done)
+	return f.value, f.err
+}
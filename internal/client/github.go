@@ -7,13 +7,25 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
 	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/requestid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracer creates the spans of GitHub API calls
+var tracer = otel.Tracer("github.com/nicholasflintwillow/github-mcp/internal/client")
+
 // HTTPClientInterface defines the interface for HTTP clients
 type HTTPClientInterface interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -22,9 +34,9 @@ type HTTPClientInterface interface {
 const (
 	// GitHubAPIBaseURL is the base URL for GitHub API v4 (GraphQL)
 	GitHubAPIBaseURL = "https://api.github.com"
-	// GitHubAPIVersion is the API version to use
+	// GitHubAPIVersion is the default REST API version requested
 	GitHubAPIVersion = "2022-11-28"
-	// DefaultTimeout is the default timeout for HTTP requests
+	// DefaultTimeout is the default timeout of HTTP requests
 	DefaultTimeout = 30 * time.Second
 	// DefaultUserAgent is the default user agent for requests
 	DefaultUserAgent = "github-mcp-server/1.0.0"
@@ -32,31 +44,86 @@ const (
 
 // GitHubClient represents a GitHub API client
 type GitHubClient struct {
-	token      string
+	tokens     TokenSource
 	baseURL    string
 	httpClient HTTPClientInterface
 	logger     *logger.Logger
 	userAgent  string
+	apiVersion string
+
+	// deprecations records endpoints GitHub reports as deprecated
+	deprecations deprecationTracker
+
+	// rateLimit is the rate limit reported by the most recent response
+	rateMu    sync.Mutex
+	rateLimit RateLimitInfo
+
+	// breaker tracks consecutive failed requests
+	breaker circuitBreaker
+
+	// cache holds GET responses revalidated with ETags; nil disables caching
+	cache *responseCache
+
+	// retry controls how transient failures are retried
+	retry RetryPolicy
+
+	// rateStates tracks the rate limit of each resource, and ratePolicy the
+	// budget kept in reserve
+	rateStates rateLimitTracker
+	ratePolicy RateLimitPolicy
+
+	// accessPolicy restricts the owners and repositories requests touch
+	accessPolicy AccessPolicy
+
+	// limiter bounds the requests in flight; nil leaves them unbounded
+	limiter *concurrencyLimiter
+
+	// callLogSampleRate is the fraction of calls logged at INFO; the others
+	// are logged at DEBUG
+	callLogSampleRate float64
+
+	// mediaTypes are accepted by every request in addition to DefaultMediaType
+	mediaTypes []string
+
+	// shared holds the cache and rate limit state shared with other
+	// replicas; nil keeps them local
+	shared *sharedState
+
+	// timeout bounds each request, or the context deadline when sooner
+	timeout time.Duration
+
+	// commitAuthor authors the commits of file changes; nil leaves it to
+	// GitHub
+	commitAuthor *CommitIdentity
 }
 
 // NewGitHubClient creates a new GitHub API client
 func NewGitHubClient(token string, logger *logger.Logger) *GitHubClient {
 	return &GitHubClient{
-		token:   token,
-		baseURL: GitHubAPIBaseURL,
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
-		logger:    logger,
-		userAgent: DefaultUserAgent,
+		tokens:     staticToken(token),
+		baseURL:    GitHubAPIBaseURL,
+		httpClient: &http.Client{},
+		timeout:    DefaultTimeout,
+		logger:     logger,
+		userAgent:  DefaultUserAgent,
+		apiVersion: GitHubAPIVersion,
 	}
 }
 
-// SetTimeout sets the HTTP client timeout
+// SetTimeout sets the timeout of each HTTP request, including reading its
+// response. A sooner context deadline, such as that of a tool call, bounds
+// requests instead. Zero disables the timeout.
 func (c *GitHubClient) SetTimeout(timeout time.Duration) {
-	if httpClient, ok := c.httpClient.(*http.Client); ok {
-		httpClient.Timeout = timeout
+	c.timeout = timeout
+}
+
+// requestContext returns the context of an HTTP request made with ctx,
+// bounded by the client timeout or the deadline of ctx, whichever is sooner
+func (c *GitHubClient) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return ctx, func() {}
 	}
+	return context.WithTimeout(ctx, c.timeout)
 }
 
 // SetUserAgent sets the user agent for requests
@@ -69,12 +136,36 @@ func (c *GitHubClient) SetUserAgent(userAgent string) {
 	c.userAgent = userAgent
 }
 
-// ValidateToken validates the GitHub Personal Access Token
+// SetBaseURL sets the REST API base URL, such as
+// https://github.example.com/api/v3 for a GitHub Enterprise Server
+func (c *GitHubClient) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// endpointURL returns the URL of endpoint. The GraphQL API is beside the
+// REST API, at /api/graphql next to /api/v3 on GitHub Enterprise Server.
+func (c *GitHubClient) endpointURL(endpoint string) string {
+	if path, _, _ := strings.Cut(endpoint, "?"); path == "/graphql" {
+		if base, ok := strings.CutSuffix(c.baseURL, "/v3"); ok {
+			return base + endpoint
+		}
+	}
+	return c.baseURL + endpoint
+}
+
+// ValidateToken validates the GitHub Personal Access Token, or the app ID
+// and private key when authenticating as a GitHub App
 func (c *GitHubClient) ValidateToken(ctx context.Context) error {
 	c.logger.Info("Validating GitHub Personal Access Token")
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
 
-	// Make a simple request to /user to validate the token
-	req, err := c.newRequest(ctx, "GET", "/user", nil)
+	// Make a simple request to /user, or /app for a GitHub App, to validate the token
+	endpoint := "/user"
+	if c.usesAppAuth() {
+		endpoint = "/app"
+	}
+	req, err := c.newRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to create validation request")
 	}
@@ -103,6 +194,8 @@ func (c *GitHubClient) ValidateToken(ctx context.Context) error {
 	} else {
 		if login, ok := user["login"].(string); ok {
 			c.logger.Info("GitHub token validated successfully", "user", login)
+		} else if slug, ok := user["slug"].(string); ok {
+			c.logger.Info("GitHub App credentials validated successfully", "app", slug)
 		}
 	}
 
@@ -134,8 +227,51 @@ func (c *GitHubClient) Patch(ctx context.Context, endpoint string, body interfac
 	return c.request(ctx, "PATCH", endpoint, nil, body)
 }
 
+// Request performs a request with any method to the GitHub API, for
+// endpoints without a dedicated method
+func (c *GitHubClient) Request(ctx context.Context, method, endpoint string, params map[string]string, body interface{}) (*APIResponse, error) {
+	return c.request(ctx, method, endpoint, params, body)
+}
+
 // request performs an HTTP request to the GitHub API
-func (c *GitHubClient) request(ctx context.Context, method, endpoint string, params map[string]string, body interface{}) (*APIResponse, error) {
+func (c *GitHubClient) request(ctx context.Context, method, endpoint string, params map[string]string, body interface{}) (apiResp *APIResponse, err error) {
+	if err := c.checkAccess(method, endpoint, params); err != nil {
+		return nil, err
+	}
+	if planned, err := c.planRequest(ctx, method, endpoint, params, body); planned {
+		return nil, err
+	}
+
+	ctx, span := tracer.Start(ctx, "GitHub API "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("github.endpoint", endpoint),
+		))
+	start := time.Now()
+	defer func() {
+		c.logAPICall(ctx, method, endpoint, apiResp, err, time.Since(start))
+		execstats.FromContext(ctx).AddEndpoint(method, endpoint)
+		if errors.IsType(err, errors.ErrorTypeRateLimit) || errors.IsType(err, errors.ErrorTypeSecondaryRateLimit) {
+			execstats.FromContext(ctx).SetRateLimited(err)
+		}
+		if apiResp != nil {
+			span.SetAttributes(attribute.Int("http.response.status_code", apiResp.StatusCode))
+			if apiResp.RateLimit.Remaining != "" {
+				span.SetAttributes(attribute.String("github.rate_limit.remaining", apiResp.RateLimit.Remaining))
+			}
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err := c.reserveBudget(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
 	req, err := c.newRequest(ctx, method, endpoint, body)
 	if err != nil {
 		return nil, err
@@ -150,18 +286,73 @@ func (c *GitHubClient) request(ctx context.Context, method, endpoint string, par
 		req.URL.RawQuery = q.Encode()
 	}
 
-	c.logger.Debug("Making GitHub API request",
+	c.logger.WithContext(ctx).Debug("Making GitHub API request",
 		"method", method,
 		"url", req.URL.String(),
 		"endpoint", endpoint)
 
-	resp, err := c.httpClient.Do(req)
+	// Revalidate a cached response instead of fetching it again
+	var cached *cachedResponse
+	var key string
+	if c.cache != nil && method == http.MethodGet {
+		key = cacheKey(req)
+		if cached = c.cache.get(key); cached == nil {
+			cached = c.sharedCacheGet(ctx, key)
+		}
+		if cached != nil {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+	}
+
+	resp, err := c.do(ctx, req)
 	if err != nil {
+		if ctx.Err() == nil {
+			c.breaker.record(true)
+		}
 		return nil, errors.Wrap(err, errors.ErrorTypeNetwork, "GitHub API request failed")
 	}
 	defer resp.Body.Close()
+	c.breaker.record(resp.StatusCode >= http.StatusInternalServerError)
+	if observer, ok := c.tokens.(rateLimitObserver); ok {
+		observer.observe(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "), resp.StatusCode, resp.Header)
+	}
+	c.rejectToken(req, resp.StatusCode)
+
+	if deprecated := c.checkDeprecation(ctx, method, endpoint, resp.Header); deprecated != nil {
+		span.SetAttributes(
+			attribute.String("github.deprecation", deprecated.Deprecation),
+			attribute.String("github.sunset", deprecated.Sunset),
+		)
+	}
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		span.SetAttributes(attribute.Bool("github.cache_hit", true))
+		execstats.FromContext(ctx).AddCacheHit()
+		apiResp, err = c.parseResponse(c.cache.revalidated(cached, resp))
+		c.recordRateLimit(ctx, apiResp)
+		return apiResp, err
+	}
+
+	apiResp, err = c.parseResponse(resp)
+	c.recordRateLimit(ctx, apiResp)
+	if err == nil && key != "" {
+		c.sharedCachePut(c.cache.put(key, apiResp))
+	}
+	return apiResp, err
+}
 
-	return c.parseResponse(resp)
+// escapePath escapes each segment of a path inside a repository, such as a
+// file path or a branch name, for an endpoint. It rejects . and .. segments,
+// which would make the endpoint leave the repository.
+func escapePath(p string) (string, error) {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, segment := range segments {
+		if segment == "." || segment == ".." {
+			return "", errors.Validation(fmt.Sprintf("path %q contains a . or .. segment", p))
+		}
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/"), nil
 }
 
 // newRequest creates a new HTTP request with proper headers
@@ -171,8 +362,13 @@ func (c *GitHubClient) newRequest(ctx context.Context, method, endpoint string,
 		endpoint = "/" + endpoint
 	}
 
+	// Reject endpoints built from empty path parameters (e.g. "/users/")
+	if path, _, _ := strings.Cut(endpoint, "?"); len(path) > 1 && (strings.HasSuffix(path, "/") || strings.Contains(path, "//")) {
+		return nil, errors.Validation("endpoint contains an empty path segment").WithContext("endpoint", endpoint)
+	}
+
 	// Build full URL
-	fullURL := c.baseURL + endpoint
+	fullURL := c.endpointURL(endpoint)
 
 	var bodyReader io.Reader
 	if body != nil {
@@ -188,11 +384,21 @@ func (c *GitHubClient) newRequest(ctx context.Context, method, endpoint string,
 		return nil, errors.Wrap(err, errors.ErrorTypeInternal, "failed to create HTTP request")
 	}
 
+	token, ok := TokenFromContext(ctx)
+	if !ok {
+		if token, err = c.tokens.Token(ctx, endpoint); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set headers
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("X-GitHub-Api-Version", GitHubAPIVersion)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", c.requestAccept(ctx))
+	req.Header.Set("X-GitHub-Api-Version", c.requestAPIVersion(ctx))
 	req.Header.Set("User-Agent", c.userAgent)
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
 
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
@@ -201,6 +407,32 @@ func (c *GitHubClient) newRequest(ctx context.Context, method, endpoint string,
 	return req, nil
 }
 
+// recordRateLimit records the rate limit reported by a response. Responses
+// to requests made with a caller's token do not count against the budget of
+// the client's tokens and are only recorded in the stats of the tool call.
+func (c *GitHubClient) recordRateLimit(ctx context.Context, apiResp *APIResponse) {
+	if apiResp == nil || apiResp.RateLimit.Remaining == "" {
+		return
+	}
+	if state, ok := parseRateLimitState(apiResp.Headers); ok {
+		execstats.FromContext(ctx).SetRateLimit(execstats.RateLimit{
+			Resource:  state.Resource,
+			Limit:     state.Limit,
+			Remaining: state.Remaining,
+			Reset:     state.Reset,
+		})
+	}
+	if _, ok := TokenFromContext(ctx); ok {
+		return
+	}
+	c.rateMu.Lock()
+	c.rateLimit = apiResp.RateLimit
+	c.rateMu.Unlock()
+	if state, ok := c.rateStates.update(apiResp.Headers); ok {
+		c.shareRateLimit(state)
+	}
+}
+
 // parseResponse parses the HTTP response from GitHub API
 func (c *GitHubClient) parseResponse(resp *http.Response) (*APIResponse, error) {
 	body, err := io.ReadAll(resp.Body)
@@ -208,26 +440,11 @@ func (c *GitHubClient) parseResponse(resp *http.Response) (*APIResponse, error)
 		return nil, errors.Wrap(err, errors.ErrorTypeNetwork, "failed to read response body")
 	}
 
-	apiResp := &APIResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    resp.Header,
-		Body:       body,
-	}
-
-	// Parse rate limit headers
-	if limit := resp.Header.Get("X-RateLimit-Limit"); limit != "" {
-		apiResp.RateLimit.Limit = limit
-	}
-	if remaining := resp.Header.Get("X-RateLimit-Remaining"); remaining != "" {
-		apiResp.RateLimit.Remaining = remaining
-	}
-	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
-		apiResp.RateLimit.Reset = reset
-	}
+	apiResp := newAPIResponse(resp, body)
 
 	// Check for errors
 	if resp.StatusCode >= 400 {
-		return apiResp, c.handleAPIError(resp.StatusCode, body)
+		return apiResp, c.handleAPIError(resp.StatusCode, resp.Header, body)
 	}
 
 	// Try to parse JSON response
@@ -244,8 +461,30 @@ func (c *GitHubClient) parseResponse(resp *http.Response) (*APIResponse, error)
 	return apiResp, nil
 }
 
+// newAPIResponse returns the APIResponse of resp with the given body and the
+// rate limit its headers report
+func newAPIResponse(resp *http.Response, body []byte) *APIResponse {
+	apiResp := &APIResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Body:       body,
+	}
+
+	// Parse rate limit headers
+	if limit := resp.Header.Get("X-RateLimit-Limit"); limit != "" {
+		apiResp.RateLimit.Limit = limit
+	}
+	if remaining := resp.Header.Get("X-RateLimit-Remaining"); remaining != "" {
+		apiResp.RateLimit.Remaining = remaining
+	}
+	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
+		apiResp.RateLimit.Reset = reset
+	}
+	return apiResp
+}
+
 // handleAPIError handles GitHub API errors
-func (c *GitHubClient) handleAPIError(statusCode int, body []byte) error {
+func (c *GitHubClient) handleAPIError(statusCode int, header http.Header, body []byte) error {
 	var errorResp struct {
 		Message          string `json:"message"`
 		DocumentationURL string `json:"documentation_url"`
@@ -256,13 +495,14 @@ func (c *GitHubClient) handleAPIError(statusCode int, body []byte) error {
 		} `json:"errors"`
 	}
 
-	// Try to parse error response
-	if err := json.Unmarshal(body, &errorResp); err != nil {
-		// If we can't parse the error, return a generic error
-		return errors.GitHubAPI(fmt.Sprintf("GitHub API error (status %d): %s", statusCode, string(body)))
+	// Try to parse error response; an unparseable body still maps by status code
+	message := ""
+	if err := json.Unmarshal(body, &errorResp); err == nil {
+		message = errorResp.Message
+	} else if len(body) > 0 {
+		message = fmt.Sprintf("GitHub API error (status %d): %s", statusCode, string(body))
 	}
 
-	message := errorResp.Message
 	if message == "" {
 		message = fmt.Sprintf("GitHub API error (status %d)", statusCode)
 	}
@@ -271,14 +511,18 @@ func (c *GitHubClient) handleAPIError(statusCode int, body []byte) error {
 	switch statusCode {
 	case http.StatusUnauthorized:
 		return errors.Authentication(message)
-	case http.StatusForbidden:
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if err := rateLimitError(message, header); err != nil {
+			return err
+		}
+		if statusCode == http.StatusTooManyRequests {
+			return errors.RateLimit(message)
+		}
 		return errors.Authorization(message)
 	case http.StatusNotFound:
 		return errors.NotFound(message)
 	case http.StatusUnprocessableEntity:
 		return errors.Validation(message)
-	case http.StatusTooManyRequests:
-		return errors.RateLimit(message)
 	default:
 		return errors.GitHubAPI(message)
 	}
@@ -300,6 +544,25 @@ type RateLimitInfo struct {
 	Reset     string `json:"reset"`
 }
 
+// RateLimitBudget returns the remaining and total requests of the current rate
+// limit window, as reported by the most recent GitHub response. ok is false
+// until a response with rate limit headers has been received.
+func (c *GitHubClient) RateLimitBudget() (remaining, limit int, ok bool) {
+	c.rateMu.Lock()
+	info := c.rateLimit
+	c.rateMu.Unlock()
+
+	remaining, err := strconv.Atoi(info.Remaining)
+	if err != nil {
+		return 0, 0, false
+	}
+	limit, err = strconv.Atoi(info.Limit)
+	if err != nil {
+		return 0, 0, false
+	}
+	return remaining, limit, true
+}
+
 // GetJSON unmarshals the response body into the provided interface
 func (r *APIResponse) GetJSON(v interface{}) error {
 	if len(r.Body) == 0 {
@@ -417,7 +680,7 @@ func (c *GitHubClient) UpdateAuthenticatedUser(ctx context.Context, updates map[
 }
 
 // ListUsers lists all users
-func (c *GitHubClient) ListUsers(ctx context.Context, since int64, perPage int) ([]User, error) {
+func (c *GitHubClient) ListUsers(ctx context.Context, since int64, perPage int) ([]User, *PageInfo, error) {
 	c.logger.Debug("Listing users", "since", since, "per_page", perPage)
 
 	params := make(map[string]string)
@@ -430,19 +693,19 @@ func (c *GitHubClient) ListUsers(ctx context.Context, since int64, perPage int)
 
 	resp, err := c.Get(ctx, "/users", params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var users []User
 	if err := resp.GetJSON(&users); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return users, nil
+	return users, resp.PageInfo(), nil
 }
 
 // ListUserFollowers lists followers of a user
-func (c *GitHubClient) ListUserFollowers(ctx context.Context, username string, page, perPage int) ([]User, error) {
+func (c *GitHubClient) ListUserFollowers(ctx context.Context, username string, page, perPage int) ([]User, *PageInfo, error) {
 	c.logger.Debug("Listing user followers", "username", username, "page", page, "per_page", perPage)
 
 	params := make(map[string]string)
@@ -455,19 +718,19 @@ func (c *GitHubClient) ListUserFollowers(ctx context.Context, username string, p
 
 	resp, err := c.Get(ctx, fmt.Sprintf("/users/%s/followers", username), params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var followers []User
 	if err := resp.GetJSON(&followers); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return followers, nil
+	return followers, resp.PageInfo(), nil
 }
 
 // ListUserFollowing lists users followed by a user
-func (c *GitHubClient) ListUserFollowing(ctx context.Context, username string, page, perPage int) ([]User, error) {
+func (c *GitHubClient) ListUserFollowing(ctx context.Context, username string, page, perPage int) ([]User, *PageInfo, error) {
 	c.logger.Debug("Listing user following", "username", username, "page", page, "per_page", perPage)
 
 	params := make(map[string]string)
@@ -480,15 +743,15 @@ func (c *GitHubClient) ListUserFollowing(ctx context.Context, username string, p
 
 	resp, err := c.Get(ctx, fmt.Sprintf("/users/%s/following", username), params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var following []User
 	if err := resp.GetJSON(&following); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return following, nil
+	return following, resp.PageInfo(), nil
 }
 
 // CheckUserFollowing checks if the authenticated user follows another user
@@ -654,7 +917,7 @@ func (c *GitHubClient) UpdateOrganization(ctx context.Context, org string, updat
 }
 
 // ListOrganizations lists all organizations
-func (c *GitHubClient) ListOrganizations(ctx context.Context, since int64, perPage int) ([]Organization, error) {
+func (c *GitHubClient) ListOrganizations(ctx context.Context, since int64, perPage int) ([]Organization, *PageInfo, error) {
 	c.logger.Debug("Listing organizations", "since", since, "per_page", perPage)
 
 	params := make(map[string]string)
@@ -667,19 +930,19 @@ func (c *GitHubClient) ListOrganizations(ctx context.Context, since int64, perPa
 
 	resp, err := c.Get(ctx, "/organizations", params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var organizations []Organization
 	if err := resp.GetJSON(&organizations); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return organizations, nil
+	return organizations, resp.PageInfo(), nil
 }
 
 // ListUserOrganizations lists organizations for a user
-func (c *GitHubClient) ListUserOrganizations(ctx context.Context, username string, page, perPage int) ([]Organization, error) {
+func (c *GitHubClient) ListUserOrganizations(ctx context.Context, username string, page, perPage int) ([]Organization, *PageInfo, error) {
 	c.logger.Debug("Listing user organizations", "username", username, "page", page, "per_page", perPage)
 
 	params := make(map[string]string)
@@ -692,19 +955,19 @@ func (c *GitHubClient) ListUserOrganizations(ctx context.Context, username strin
 
 	resp, err := c.Get(ctx, fmt.Sprintf("/users/%s/orgs", username), params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var organizations []Organization
 	if err := resp.GetJSON(&organizations); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return organizations, nil
+	return organizations, resp.PageInfo(), nil
 }
 
 // ListAuthenticatedUserOrganizations lists organizations for the authenticated user
-func (c *GitHubClient) ListAuthenticatedUserOrganizations(ctx context.Context, page, perPage int) ([]Organization, error) {
+func (c *GitHubClient) ListAuthenticatedUserOrganizations(ctx context.Context, page, perPage int) ([]Organization, *PageInfo, error) {
 	c.logger.Debug("Listing authenticated user organizations", "page", page, "per_page", perPage)
 
 	params := make(map[string]string)
@@ -717,19 +980,19 @@ func (c *GitHubClient) ListAuthenticatedUserOrganizations(ctx context.Context, p
 
 	resp, err := c.Get(ctx, "/user/orgs", params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var organizations []Organization
 	if err := resp.GetJSON(&organizations); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return organizations, nil
+	return organizations, resp.PageInfo(), nil
 }
 
 // ListOrganizationMembers lists members of an organization
-func (c *GitHubClient) ListOrganizationMembers(ctx context.Context, org string, filter string, role string, page, perPage int) ([]OrganizationMember, error) {
+func (c *GitHubClient) ListOrganizationMembers(ctx context.Context, org string, filter string, role string, page, perPage int) ([]OrganizationMember, *PageInfo, error) {
 	c.logger.Debug("Listing organization members", "org", org, "filter", filter, "role", role, "page", page, "per_page", perPage)
 
 	params := make(map[string]string)
@@ -748,15 +1011,41 @@ func (c *GitHubClient) ListOrganizationMembers(ctx context.Context, org string,
 
 	resp, err := c.Get(ctx, fmt.Sprintf("/orgs/%s/members", org), params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var members []OrganizationMember
 	if err := resp.GetJSON(&members); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return members, nil
+	return members, resp.PageInfo(), nil
+}
+
+// ListOrganizationPublicMembers lists members of an organization who made
+// their membership public
+func (c *GitHubClient) ListOrganizationPublicMembers(ctx context.Context, org string, page, perPage int) ([]OrganizationMember, *PageInfo, error) {
+	c.logger.Debug("Listing organization public members", "org", org, "page", page, "per_page", perPage)
+
+	params := make(map[string]string)
+	if page > 0 {
+		params["page"] = fmt.Sprintf("%d", page)
+	}
+	if perPage > 0 {
+		params["per_page"] = fmt.Sprintf("%d", perPage)
+	}
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/orgs/%s/public_members", org), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var members []OrganizationMember
+	if err := resp.GetJSON(&members); err != nil {
+		return nil, nil, err
+	}
+
+	return members, resp.PageInfo(), nil
 }
 
 // CheckOrganizationMembership checks if a user is a member of an organization
@@ -959,7 +1248,7 @@ type TeamRepository struct {
 // GitHub Teams API client functions
 
 // ListTeams lists teams in an organization
-func (c *GitHubClient) ListTeams(ctx context.Context, org string, page, perPage int) ([]Team, error) {
+func (c *GitHubClient) ListTeams(ctx context.Context, org string, page, perPage int) ([]Team, *PageInfo, error) {
 	c.logger.Debug("Listing teams", "org", org, "page", page, "per_page", perPage)
 
 	params := make(map[string]string)
@@ -972,15 +1261,41 @@ func (c *GitHubClient) ListTeams(ctx context.Context, org string, page, perPage
 
 	resp, err := c.Get(ctx, fmt.Sprintf("/orgs/%s/teams", org), params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var teams []Team
 	if err := resp.GetJSON(&teams); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	return teams, resp.PageInfo(), nil
+}
+
+// ListAuthenticatedUserTeams lists the teams, across all organizations, the
+// authenticated user belongs to
+func (c *GitHubClient) ListAuthenticatedUserTeams(ctx context.Context, page, perPage int) ([]Team, *PageInfo, error) {
+	c.logger.Debug("Listing authenticated user teams", "page", page, "per_page", perPage)
+
+	params := make(map[string]string)
+	if page > 0 {
+		params["page"] = fmt.Sprintf("%d", page)
+	}
+	if perPage > 0 {
+		params["per_page"] = fmt.Sprintf("%d", perPage)
+	}
+
+	resp, err := c.Get(ctx, "/user/teams", params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var teams []Team
+	if err := resp.GetJSON(&teams); err != nil {
+		return nil, nil, err
 	}
 
-	return teams, nil
+	return teams, resp.PageInfo(), nil
 }
 
 // GetTeam gets a team by organization and team slug
@@ -1043,7 +1358,7 @@ func (c *GitHubClient) DeleteTeam(ctx context.Context, org, teamSlug string) err
 }
 
 // ListTeamMembers lists members of a team
-func (c *GitHubClient) ListTeamMembers(ctx context.Context, org, teamSlug string, role string, page, perPage int) ([]TeamMember, error) {
+func (c *GitHubClient) ListTeamMembers(ctx context.Context, org, teamSlug string, role string, page, perPage int) ([]TeamMember, *PageInfo, error) {
 	c.logger.Debug("Listing team members", "org", org, "team_slug", teamSlug, "role", role, "page", page, "per_page", perPage)
 
 	params := make(map[string]string)
@@ -1059,15 +1374,15 @@ func (c *GitHubClient) ListTeamMembers(ctx context.Context, org, teamSlug string
 
 	resp, err := c.Get(ctx, fmt.Sprintf("/orgs/%s/teams/%s/members", org, teamSlug), params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var members []TeamMember
 	if err := resp.GetJSON(&members); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return members, nil
+	return members, resp.PageInfo(), nil
 }
 
 // GetTeamMembership gets team membership for a user
@@ -1118,7 +1433,7 @@ func (c *GitHubClient) RemoveTeamMembership(ctx context.Context, org, teamSlug,
 }
 
 // ListTeamRepositories lists repositories for a team
-func (c *GitHubClient) ListTeamRepositories(ctx context.Context, org, teamSlug string, page, perPage int) ([]TeamRepository, error) {
+func (c *GitHubClient) ListTeamRepositories(ctx context.Context, org, teamSlug string, page, perPage int) ([]TeamRepository, *PageInfo, error) {
 	c.logger.Debug("Listing team repositories", "org", org, "team_slug", teamSlug, "page", page, "per_page", perPage)
 
 	params := make(map[string]string)
@@ -1131,15 +1446,15 @@ func (c *GitHubClient) ListTeamRepositories(ctx context.Context, org, teamSlug s
 
 	resp, err := c.Get(ctx, fmt.Sprintf("/orgs/%s/teams/%s/repos", org, teamSlug), params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var repositories []TeamRepository
 	if err := resp.GetJSON(&repositories); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return repositories, nil
+	return repositories, resp.PageInfo(), nil
 }
 
 // CheckTeamRepository checks if a team has access to a repository
@@ -0,0 +1,174 @@
+// Package graphql executes queries and mutations against the GitHub GraphQL
+// API, sharing the authentication, retries and rate limit tracking of the
+// REST client.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// Endpoint is the endpoint operations are posted to, which the REST client
+// resolves to the GraphQL API beside its base URL
+const Endpoint = "/graphql"
+
+// Request is a GraphQL operation with its variables
+type Request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+}
+
+// Error is an entry of the errors of a GraphQL response
+type Error struct {
+	// Type is GitHub's error type, such as NOT_FOUND or FORBIDDEN
+	Type      string        `json:"type,omitempty"`
+	Message   string        `json:"message"`
+	Path      []interface{} `json:"path,omitempty"`
+	Locations []struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"locations,omitempty"`
+}
+
+// response is the body of a GraphQL response
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []Error         `json:"errors"`
+}
+
+// Cost is the rate limit cost of the operations executed by a client. GitHub
+// reports the cost of a query that selects rateLimit { cost remaining
+// resetAt }; other operations are counted at the minimum cost of one point.
+type Cost struct {
+	// Operations is the number of operations executed
+	Operations int64 `json:"operations"`
+	// Total is the sum of the cost of every operation
+	Total int64 `json:"total"`
+	// Last is the cost of the most recent operation
+	Last int `json:"last"`
+	// Remaining and ResetAt are the GraphQL rate limit after the most recent
+	// operation reporting it
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// Client executes GraphQL operations
+type Client struct {
+	rest *client.GitHubClient
+
+	mu   sync.Mutex
+	cost Cost
+}
+
+// New creates a GraphQL client sending operations through rest
+func New(rest *client.GitHubClient) *Client {
+	return &Client{rest: rest}
+}
+
+// Query executes query with variables and decodes its data into result
+func (c *Client) Query(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	return c.Do(client.WithReadRequest(ctx), Request{Query: query, Variables: variables}, result)
+}
+
+// Mutate executes mutation with input as its $input variable and decodes
+// its data into result
+func (c *Client) Mutate(ctx context.Context, mutation string, input interface{}, result interface{}) error {
+	return c.Do(ctx, Request{Query: mutation, Variables: map[string]interface{}{"input": input}}, result)
+}
+
+// Do executes req and decodes its data into result, which may be nil. When
+// GitHub reports errors alongside partial data, the data is decoded and the
+// errors are returned.
+func (c *Client) Do(ctx context.Context, req Request, result interface{}) error {
+	if strings.TrimSpace(req.Query) == "" {
+		return errors.Validation("GraphQL query is required")
+	}
+
+	resp, err := c.rest.Post(ctx, Endpoint, req)
+	if err != nil {
+		return err
+	}
+
+	var body response
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return errors.Wrap(err, errors.ErrorTypeGitHubAPI, "failed to decode GraphQL response")
+	}
+	c.recordCost(body.Data)
+
+	if result != nil && len(body.Data) > 0 && string(body.Data) != "null" {
+		if err := json.Unmarshal(body.Data, result); err != nil {
+			return errors.Wrap(err, errors.ErrorTypeValidation, "failed to unmarshal GraphQL data")
+		}
+	}
+	if len(body.Errors) > 0 {
+		return mapErrors(body.Errors)
+	}
+	return nil
+}
+
+// recordCost adds the cost reported in data, or the minimum cost, to the
+// client's totals
+func (c *Client) recordCost(data json.RawMessage) {
+	var reported struct {
+		RateLimit *struct {
+			Cost      int       `json:"cost"`
+			Remaining int       `json:"remaining"`
+			ResetAt   time.Time `json:"resetAt"`
+		} `json:"rateLimit"`
+	}
+	json.Unmarshal(data, &reported)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cost.Operations++
+	c.cost.Last = 1
+	if rl := reported.RateLimit; rl != nil {
+		c.cost.Last = rl.Cost
+		c.cost.Remaining = rl.Remaining
+		c.cost.ResetAt = rl.ResetAt
+	} else if state, ok := c.rest.RateLimitState(client.RateLimitGraphQL); ok {
+		c.cost.Remaining = state.Remaining
+		c.cost.ResetAt = state.Reset
+	}
+	c.cost.Total += int64(c.cost.Last)
+}
+
+// Cost returns the rate limit cost of the operations executed so far
+func (c *Client) Cost() Cost {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cost
+}
+
+// mapErrors converts GraphQL errors to an AppError typed after the first
+// error, carrying them all in its context
+func mapErrors(errs []Error) *errors.AppError {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Message
+	}
+	message := strings.Join(messages, "; ")
+
+	var appErr *errors.AppError
+	switch errs[0].Type {
+	case "NOT_FOUND":
+		appErr = errors.NotFound(message)
+	case "FORBIDDEN", "INSUFFICIENT_SCOPES":
+		appErr = errors.Authorization(message)
+	case "RATE_LIMITED":
+		appErr = errors.RateLimit(message)
+	case "UNPROCESSABLE", "ARGUMENT_ERROR", "MAX_NODE_LIMIT_EXCEEDED":
+		appErr = errors.Validation(message)
+	default:
+		appErr = errors.GitHubAPI(message)
+	}
+	return appErr.WithContext("graphql_errors", errs)
+}
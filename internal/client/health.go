@@ -0,0 +1,85 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// breakerFailureThreshold is the number of consecutive failed requests
+	// after which GitHub is considered unavailable
+	breakerFailureThreshold = 5
+	// breakerCooldown is how long GitHub is considered unavailable before
+	// requests are tried again
+	breakerCooldown = 30 * time.Second
+)
+
+// Reasons GitHub is reported as degraded
+const (
+	// DegradedCircuitOpen means recent requests to GitHub failed
+	DegradedCircuitOpen = "circuit_open"
+	// DegradedRateLimited means the rate limit budget is exhausted
+	DegradedRateLimited = "rate_limited"
+)
+
+// Health describes whether GitHub can currently serve requests
+type Health struct {
+	Degraded bool
+	// Reason is DegradedCircuitOpen or DegradedRateLimited when degraded
+	Reason string
+	// RetryAfter is how long until requests are expected to succeed again
+	RetryAfter time.Duration
+}
+
+// circuitBreaker tracks consecutive network and server failures. It opens
+// after breakerFailureThreshold failures and stays open for breakerCooldown;
+// afterwards a single failure reopens it and a success closes it.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// record updates the breaker with the outcome of a request
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+	b.failures++
+	if b.failures >= breakerFailureThreshold {
+		b.openUntil = time.Now().Add(breakerCooldown)
+	}
+}
+
+// openFor returns how long the breaker stays open, or zero when closed
+func (b *circuitBreaker) openFor(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.Before(b.openUntil) {
+		return b.openUntil.Sub(now)
+	}
+	return 0
+}
+
+// Health reports whether GitHub is currently unavailable, either because
+// recent requests failed or because the core rate limit budget is exhausted
+func (c *GitHubClient) Health() Health {
+	now := time.Now()
+	if wait := c.breaker.openFor(now); wait > 0 {
+		return Health{Degraded: true, Reason: DegradedCircuitOpen, RetryAfter: wait}
+	}
+
+	state, ok := c.RateLimitState(RateLimitCore)
+	if !ok || state.Remaining > 0 {
+		return Health{}
+	}
+	if wait := state.Reset.Sub(now); wait > 0 {
+		return Health{Degraded: true, Reason: DegradedRateLimited, RetryAfter: wait}
+	}
+	return Health{}
+}
@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GitHub Issues data structures
+
+// Reactions summarizes the reactions on an issue or comment
+type Reactions struct {
+	TotalCount int `json:"total_count"`
+	PlusOne    int `json:"+1"`
+	MinusOne   int `json:"-1"`
+	Laugh      int `json:"laugh"`
+	Hooray     int `json:"hooray"`
+	Confused   int `json:"confused"`
+	Heart      int `json:"heart"`
+	Rocket     int `json:"rocket"`
+	Eyes       int `json:"eyes"`
+}
+
+// Label represents a GitHub issue label
+type Label struct {
+	ID          int64   `json:"id"`
+	NodeID      string  `json:"node_id"`
+	Name        string  `json:"name"`
+	Color       string  `json:"color"`
+	Description *string `json:"description"`
+	Default     bool    `json:"default"`
+}
+
+// Milestone represents a GitHub milestone
+type Milestone struct {
+	ID           int64   `json:"id"`
+	NodeID       string  `json:"node_id"`
+	Number       int     `json:"number"`
+	Title        string  `json:"title"`
+	Description  *string `json:"description"`
+	State        string  `json:"state"`
+	OpenIssues   int     `json:"open_issues"`
+	ClosedIssues int     `json:"closed_issues"`
+	HTMLURL      string  `json:"html_url"`
+	DueOn        *string `json:"due_on"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    string  `json:"updated_at"`
+	ClosedAt     *string `json:"closed_at"`
+}
+
+// IssuePullRequest links an issue to its pull request when the issue is a pull request
+type IssuePullRequest struct {
+	URL     string  `json:"url"`
+	HTMLURL string  `json:"html_url"`
+	Merged  *string `json:"merged_at"`
+}
+
+// Issue represents a GitHub issue or pull request
+type Issue struct {
+	ID                int64             `json:"id"`
+	NodeID            string            `json:"node_id"`
+	Number            int               `json:"number"`
+	Title             string            `json:"title"`
+	State             string            `json:"state"`
+	User              User              `json:"user"`
+	Body              *string           `json:"body"`
+	Labels            []Label           `json:"labels"`
+	Assignees         []User            `json:"assignees"`
+	Milestone         *Milestone        `json:"milestone"`
+	StateReason       *string           `json:"state_reason"`
+	Comments          int               `json:"comments"`
+	AuthorAssociation string            `json:"author_association"`
+	HTMLURL           string            `json:"html_url"`
+	RepositoryURL     string            `json:"repository_url"`
+	PullRequest       *IssuePullRequest `json:"pull_request,omitempty"`
+	Reactions         *Reactions        `json:"reactions,omitempty"`
+	CreatedAt         string            `json:"created_at"`
+	UpdatedAt         string            `json:"updated_at"`
+	ClosedAt          *string           `json:"closed_at"`
+}
+
+// IsPullRequest returns true if the issue is a pull request
+func (i *Issue) IsPullRequest() bool {
+	return i.PullRequest != nil
+}
+
+// IssueComment represents a comment on a GitHub issue or pull request
+type IssueComment struct {
+	ID                int64      `json:"id"`
+	NodeID            string     `json:"node_id"`
+	User              User       `json:"user"`
+	Body              string     `json:"body"`
+	AuthorAssociation string     `json:"author_association"`
+	HTMLURL           string     `json:"html_url"`
+	Reactions         *Reactions `json:"reactions,omitempty"`
+	CreatedAt         string     `json:"created_at"`
+	UpdatedAt         string     `json:"updated_at"`
+}
+
+// GitHub Issues API client functions
+
+// GetIssue gets an issue or pull request by number
+func (c *GitHubClient) GetIssue(ctx context.Context, owner, repo string, number int) (*Issue, error) {
+	c.logger.Debug("Getting issue", "owner", owner, "repo", repo, "number", number)
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d", owner, repo, number), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue Issue
+	if err := resp.GetJSON(&issue); err != nil {
+		return nil, err
+	}
+
+	return &issue, nil
+}
+
+// ListIssueComments lists comments on an issue or pull request in ascending order of creation
+func (c *GitHubClient) ListIssueComments(ctx context.Context, owner, repo string, number, page, perPage int) ([]IssueComment, *PageInfo, error) {
+	c.logger.Debug("Listing issue comments", "owner", owner, "repo", repo, "number", number, "page", page, "per_page", perPage)
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d/comments", owner, repo, number), pageParams(page, perPage))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var comments []IssueComment
+	if err := resp.GetJSON(&comments); err != nil {
+		return nil, nil, err
+	}
+
+	return comments, resp.PageInfo(), nil
+}
+
+// UpdateIssue updates an issue's state, milestone or other fields
+func (c *GitHubClient) UpdateIssue(ctx context.Context, owner, repo string, number int, updates map[string]interface{}) (*Issue, error) {
+	c.logger.Debug("Updating issue", "owner", owner, "repo", repo, "number", number)
+
+	resp, err := c.Patch(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d", owner, repo, number), updates)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue Issue
+	if err := resp.GetJSON(&issue); err != nil {
+		return nil, err
+	}
+
+	return &issue, nil
+}
+
+// AddIssueLabels adds labels to an issue, keeping its existing labels
+func (c *GitHubClient) AddIssueLabels(ctx context.Context, owner, repo string, number int, labels []string) ([]Label, error) {
+	c.logger.Debug("Adding issue labels", "owner", owner, "repo", repo, "number", number, "labels", labels)
+
+	resp, err := c.Post(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d/labels", owner, repo, number), map[string]interface{}{
+		"labels": labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Label
+	if err := resp.GetJSON(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RemoveIssueLabel removes a label from an issue
+func (c *GitHubClient) RemoveIssueLabel(ctx context.Context, owner, repo string, number int, label string) error {
+	c.logger.Debug("Removing issue label", "owner", owner, "repo", repo, "number", number, "label", label)
+
+	_, err := c.Delete(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d/labels/%s", owner, repo, number, url.PathEscape(label)))
+	return err
+}
+
+// AddIssueAssignees adds assignees to an issue, keeping its existing assignees
+func (c *GitHubClient) AddIssueAssignees(ctx context.Context, owner, repo string, number int, assignees []string) (*Issue, error) {
+	c.logger.Debug("Adding issue assignees", "owner", owner, "repo", repo, "number", number, "assignees", assignees)
+
+	resp, err := c.Post(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d/assignees", owner, repo, number), map[string]interface{}{
+		"assignees": assignees,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var issue Issue
+	if err := resp.GetJSON(&issue); err != nil {
+		return nil, err
+	}
+
+	return &issue, nil
+}
+
+// RemoveIssueAssignees removes assignees from an issue
+func (c *GitHubClient) RemoveIssueAssignees(ctx context.Context, owner, repo string, number int, assignees []string) (*Issue, error) {
+	c.logger.Debug("Removing issue assignees", "owner", owner, "repo", repo, "number", number, "assignees", assignees)
+
+	resp, err := c.request(ctx, "DELETE", fmt.Sprintf("/repos/%s/%s/issues/%d/assignees", owner, repo, number), nil, map[string]interface{}{
+		"assignees": assignees,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var issue Issue
+	if err := resp.GetJSON(&issue); err != nil {
+		return nil, err
+	}
+
+	return &issue, nil
+}
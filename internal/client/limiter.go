@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// concurrencyLimiter bounds the number of GitHub requests in flight; a
+// request holds its slot until its response body is closed
+type concurrencyLimiter struct {
+	slots chan struct{}
+}
+
+// SetMaxConcurrency bounds the number of requests sent to GitHub at the same
+// time; further requests wait for a slot. Zero removes the bound.
+func (c *GitHubClient) SetMaxConcurrency(n int) {
+	if n <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = &concurrencyLimiter{slots: make(chan struct{}, n)}
+}
+
+// RequestsInFlight returns the number of GitHub requests holding a
+// concurrency slot, or zero without a bound
+func (c *GitHubClient) RequestsInFlight() int {
+	if c.limiter == nil {
+		return 0
+	}
+	return len(c.limiter.slots)
+}
+
+// acquireSlot waits for a concurrency slot and returns the function
+// releasing it
+func (c *GitHubClient) acquireSlot(ctx context.Context) (func(), error) {
+	if c.limiter == nil {
+		return func() {}, nil
+	}
+	select {
+	case c.limiter.slots <- struct{}{}:
+	default:
+		c.logger.WithContext(ctx).Debug("Waiting for a GitHub request slot", "in_flight", len(c.limiter.slots))
+		select {
+		case c.limiter.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), errors.ErrorTypeNetwork, "GitHub API request cancelled while waiting for a request slot")
+		}
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { <-c.limiter.slots }) }, nil
+}
+
+// releasingBody releases a concurrency slot when the response body is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
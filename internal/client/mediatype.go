@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"strings"
+)
+
+// GitHub REST API media types
+const (
+	// DefaultMediaType is the Accept media type of GitHub REST API requests
+	DefaultMediaType = "application/vnd.github+json"
+	// RawMediaType requests file contents as raw bytes rather than base64
+	// encoded JSON, which also lifts the 1 MB limit of the contents API
+	RawMediaType = "application/vnd.github.raw+json"
+	// DiffMediaType requests a commit, comparison or pull request as a diff
+	DiffMediaType = "application/vnd.github.diff"
+	// PatchMediaType requests a commit, comparison or pull request as a patch
+	PatchMediaType = "application/vnd.github.patch"
+)
+
+// mediaTypeContextKey is the context key for a per-request Accept media type
+type mediaTypeContextKey struct{}
+
+// WithMediaType returns a context whose GitHub API requests are sent with
+// the given Accept media type instead of the client's default, such as
+// DiffMediaType or RawMediaType
+func WithMediaType(ctx context.Context, mediaType string) context.Context {
+	return context.WithValue(ctx, mediaTypeContextKey{}, mediaType)
+}
+
+// SetMediaTypes sets media types accepted by every request in addition to
+// DefaultMediaType, such as API previews
+func (c *GitHubClient) SetMediaTypes(mediaTypes []string) {
+	c.mediaTypes = mediaTypes
+}
+
+// requestAccept returns the Accept header for a request made with ctx
+func (c *GitHubClient) requestAccept(ctx context.Context) string {
+	if mediaType, ok := ctx.Value(mediaTypeContextKey{}).(string); ok && mediaType != "" {
+		return mediaType
+	}
+	if len(c.mediaTypes) == 0 {
+		return DefaultMediaType
+	}
+	return strings.Join(append([]string{DefaultMediaType}, c.mediaTypes...), ", ")
+}
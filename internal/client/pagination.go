@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageInfo describes the pagination state of a list response, as advertised
+// by GitHub in the Link header
+type PageInfo struct {
+	// NextPage is the page number of the next page, or 0 when unknown
+	NextPage int `json:"next_page,omitempty"`
+	// LastPage is the page number of the last page, or 0 when unknown
+	LastPage int `json:"last_page,omitempty"`
+	// NextParams holds the query parameters of the rel="next" link
+	NextParams map[string]string `json:"-"`
+}
+
+// HasMore returns true if another page of results is available
+func (p *PageInfo) HasMore() bool {
+	return p != nil && len(p.NextParams) > 0
+}
+
+// ParseLinkHeader parses an RFC 8288 Link header into a map of rel to URL
+func ParseLinkHeader(header string) map[string]string {
+	links := make(map[string]string)
+	if header == "" {
+		return links
+	}
+
+	for _, part := range strings.Split(header, ",") {
+		segments := strings.Split(strings.TrimSpace(part), ";")
+		if len(segments) < 2 {
+			continue
+		}
+
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		target = strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+
+		for _, param := range segments[1:] {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(key) != "rel" {
+				continue
+			}
+			for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+				links[rel] = target
+			}
+		}
+	}
+
+	return links
+}
+
+// PageInfo returns the pagination state parsed from the response Link header
+func (r *APIResponse) PageInfo() *PageInfo {
+	info := &PageInfo{}
+	if r == nil || r.Headers == nil {
+		return info
+	}
+
+	links := ParseLinkHeader(r.Headers.Get("Link"))
+
+	if next, ok := links["next"]; ok {
+		info.NextParams = linkQueryParams(next)
+		info.NextPage = pageNumber(info.NextParams)
+	}
+	if last, ok := links["last"]; ok {
+		info.LastPage = pageNumber(linkQueryParams(last))
+	}
+
+	return info
+}
+
+// linkQueryParams extracts the query parameters of a link target
+func linkQueryParams(link string) map[string]string {
+	params := make(map[string]string)
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return params
+	}
+
+	for key, values := range parsed.Query() {
+		if len(values) > 0 {
+			params[key] = values[0]
+		}
+	}
+
+	return params
+}
+
+// pageNumber returns the numeric page parameter, or 0 if absent
+func pageNumber(params map[string]string) int {
+	page, err := strconv.Atoi(params["page"])
+	if err != nil {
+		return 0
+	}
+	return page
+}
+
+// pageParams builds the standard page/per_page query parameters
+func pageParams(page, perPage int) map[string]string {
+	params := make(map[string]string)
+	if page > 0 {
+		params["page"] = fmt.Sprintf("%d", page)
+	}
+	if perPage > 0 {
+		params["per_page"] = fmt.Sprintf("%d", perPage)
+	}
+	return params
+}
+
+// GetAllPages calls fetch for page and then for the pages its responses link
+// to with rel="next", until the last page or maxPages pages have been
+// fetched. The PageInfo returned is that of the last page fetched, so it
+// reports further pages when maxPages stopped the walk.
+func GetAllPages[T any](ctx context.Context, page, maxPages int, fetch func(ctx context.Context, page int) ([]T, *PageInfo, error)) ([]T, *PageInfo, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	var items []T
+	for fetched := 1; ; fetched++ {
+		pageItems, info, err := fetch(ctx, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, pageItems...)
+
+		if !info.HasMore() || info.NextPage <= page || fetched >= maxPages {
+			return items, info, nil
+		}
+		page = info.NextPage
+	}
+}
@@ -0,0 +1,247 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// AccessPolicy restricts the owners and repositories requests may touch.
+// Owners are users or organizations; repositories are owner/repo patterns
+// as matched by path.Match, such as octo-org/*. Names match without regard
+// to case. Endpoints under /repos/{owner}/{repo}, /repos/{owner},
+// /networks/{owner}/{repo}, /orgs/{org}, /users/{user} and the
+// authenticated user's follows, blocks, stars and watches are checked, as
+// are the repo:, org: and user: qualifiers of searches. While a policy is
+// set, endpoints naming repositories, organizations or teams by ID and
+// GraphQL queries are denied, since their targets cannot be checked.
+type AccessPolicy struct {
+	// AllowedOwners and AllowedRepos, when either is set, are the only
+	// owners and repositories requests may touch. The profile of an owner
+	// of an allowed repository, GET /orgs/{org} or /users/{user}, may be
+	// read too; nothing else of that owner may be touched.
+	AllowedOwners []string
+	AllowedRepos  []string
+	// DeniedOwners and DeniedRepos may never be touched, even when allowed
+	DeniedOwners []string
+	DeniedRepos  []string
+}
+
+// isSet reports whether the policy restricts anything
+func (p AccessPolicy) isSet() bool {
+	return len(p.AllowedOwners) > 0 || len(p.AllowedRepos) > 0 || len(p.DeniedOwners) > 0 || len(p.DeniedRepos) > 0
+}
+
+// restrictsToAllowed reports whether only allowed owners and repositories may
+// be touched
+func (p AccessPolicy) restrictsToAllowed() bool {
+	return len(p.AllowedOwners) > 0 || len(p.AllowedRepos) > 0
+}
+
+// SetAccessPolicy restricts the owners and repositories requests may touch;
+// requests are unrestricted until it is called
+func (c *GitHubClient) SetAccessPolicy(policy AccessPolicy) {
+	c.accessPolicy = policy
+}
+
+// CheckAccess returns an authorization error when the access policy denies
+// touching owner, or repository owner/repo when repo is not empty. Requests
+// are checked by themselves; it checks targets requests only name in their
+// body, such as the destination of a transfer.
+func (c *GitHubClient) CheckAccess(owner, repo string) error {
+	if !c.accessPolicy.isSet() {
+		return nil
+	}
+	return c.accessPolicy.check(owner, repo, false)
+}
+
+// checkAccess returns an error when the access policy denies a request with
+// method to endpoint with the query parameters params
+func (c *GitHubClient) checkAccess(method, endpoint string, params map[string]string) error {
+	p := c.accessPolicy
+	if !p.isSet() {
+		return nil
+	}
+
+	endpointPath, rawQuery, _ := strings.Cut(endpoint, "?")
+	// Match the path GitHub sees, so escaped slashes and dots cannot smuggle
+	// another target past the check
+	decoded, err := url.PathUnescape(endpointPath)
+	if err != nil {
+		return errors.Validation("endpoint is not a valid path").WithContext("endpoint", endpoint)
+	}
+	segments := strings.Split(strings.Trim(decoded, "/"), "/")
+	for _, segment := range segments {
+		if segment == "." || segment == ".." {
+			return errors.Validation("endpoint contains a dot segment").WithContext("endpoint", endpoint)
+		}
+	}
+	decoded = path.Clean("/" + decoded)
+	segments = strings.Split(strings.TrimPrefix(decoded, "/"), "/")
+
+	if uncheckableEndpoint(segments) {
+		return errors.Authorization(fmt.Sprintf("%s cannot be checked against the server's access policy, so it is denied", decoded)).
+			WithContext("target", decoded)
+	}
+	if segments[0] == "search" {
+		query := params["q"]
+		if query == "" {
+			values, _ := url.ParseQuery(rawQuery)
+			query = values.Get("q")
+		}
+		return p.checkSearch(query)
+	}
+
+	owner, repo := endpointTarget(segments)
+	if owner == "" {
+		return nil
+	}
+	profile := method == http.MethodGet && len(segments) == 2 && (segments[0] == "orgs" || segments[0] == "users")
+	return p.check(owner, repo, profile)
+}
+
+// check returns an error when the policy denies touching owner, or
+// repository owner/repo when repo is not empty. A profile read of owner is
+// also allowed when it owns an allowed repository.
+func (p AccessPolicy) check(owner, repo string, profile bool) error {
+	target := owner
+	if repo != "" {
+		target = owner + "/" + repo
+	}
+
+	denied := matchOwner(p.DeniedOwners, owner) || (repo != "" && matchRepo(p.DeniedRepos, owner, repo))
+	if !denied && p.restrictsToAllowed() {
+		allowed := matchOwner(p.AllowedOwners, owner)
+		if repo != "" {
+			allowed = allowed || matchRepo(p.AllowedRepos, owner, repo)
+		} else if profile {
+			allowed = allowed || ownsAllowedRepo(p.AllowedRepos, owner)
+		}
+		denied = !allowed
+	}
+	if denied {
+		return errors.Authorization(fmt.Sprintf("access to %s is denied by the server's access policy", target)).
+			WithContext("target", target)
+	}
+	return nil
+}
+
+// checkSearch returns an error when the policy denies a repository or owner
+// a search query is scoped to with its repo:, org: and user: qualifiers.
+// While only allowed targets may be touched, searches must be scoped to
+// them.
+func (p AccessPolicy) checkSearch(query string) error {
+	scoped := false
+	for _, term := range strings.Fields(query) {
+		qualifier, value, ok := strings.Cut(term, ":")
+		if !ok || strings.HasPrefix(qualifier, "-") {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		var err error
+		switch strings.ToLower(qualifier) {
+		case "repo":
+			owner, repo, _ := strings.Cut(value, "/")
+			err = p.check(owner, repo, false)
+		case "org", "user":
+			err = p.check(value, "", false)
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		scoped = true
+	}
+	if !scoped && p.restrictsToAllowed() {
+		return errors.Authorization("searches must be scoped with repo:, org: or user: qualifiers allowed by the server's access policy").
+			WithContext("target", "search")
+	}
+	return nil
+}
+
+// uncheckableEndpoint reports whether an endpoint names its target in a way
+// the policy cannot check: by repository, organization or team ID, or inside
+// a GraphQL query
+func uncheckableEndpoint(segments []string) bool {
+	switch segments[0] {
+	case "graphql", "organizations", "repositories", "teams":
+		return true
+	case "user":
+		// /user/installations/{id}/repositories lists and changes the
+		// repositories of an installation by ID
+		return len(segments) > 3 && segments[1] == "installations" && segments[3] == "repositories"
+	}
+	return false
+}
+
+// endpointTarget returns the owner and, for repository endpoints, the
+// repository an endpoint touches from its decoded path segments
+func endpointTarget(segments []string) (owner, repo string) {
+	if len(segments) < 2 {
+		return "", ""
+	}
+	switch segments[0] {
+	case "repos", "networks":
+		if len(segments) > 2 {
+			return segments[1], segments[2]
+		}
+		return segments[1], ""
+	case "orgs", "users":
+		return segments[1], ""
+	case "user":
+		// /user/following/{user} and /user/blocks/{user} name a user, and
+		// /user/starred/{owner}/{repo} and /user/subscriptions/{owner}/{repo}
+		// a repository
+		if len(segments) < 3 {
+			return "", ""
+		}
+		switch segments[1] {
+		case "following", "blocks":
+			return segments[2], ""
+		case "starred", "subscriptions":
+			if len(segments) > 3 {
+				return segments[2], segments[3]
+			}
+			return segments[2], ""
+		}
+	}
+	return "", ""
+}
+
+// matchOwner reports whether owner is one of owners
+func matchOwner(owners []string, owner string) bool {
+	for _, candidate := range owners {
+		if strings.EqualFold(candidate, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRepo reports whether owner/repo matches one of patterns
+func matchRepo(patterns []string, owner, repo string) bool {
+	name := strings.ToLower(owner + "/" + repo)
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ownsAllowedRepo reports whether a pattern of patterns names a repository
+// of owner
+func ownsAllowedRepo(patterns []string, owner string) bool {
+	for _, pattern := range patterns {
+		patternOwner, _, _ := strings.Cut(pattern, "/")
+		if matched, _ := path.Match(strings.ToLower(patternOwner), strings.ToLower(owner)); matched {
+			return true
+		}
+	}
+	return false
+}
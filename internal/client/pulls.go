@@ -0,0 +1,88 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+)
+
+// GitHub Pull Requests data structures
+
+// PullRequestBranch is the head or base branch of a pull request
+type PullRequestBranch struct {
+	Label string `json:"label"`
+	Ref   string `json:"ref"`
+	SHA   string `json:"sha"`
+}
+
+// PullRequest represents a GitHub pull request
+type PullRequest struct {
+	ID           int64             `json:"id"`
+	NodeID       string            `json:"node_id"`
+	Number       int               `json:"number"`
+	Title        string            `json:"title"`
+	State        string            `json:"state"`
+	User         User              `json:"user"`
+	Body         *string           `json:"body"`
+	Draft        bool              `json:"draft"`
+	Merged       bool              `json:"merged"`
+	Head         PullRequestBranch `json:"head"`
+	Base         PullRequestBranch `json:"base"`
+	Commits      int               `json:"commits"`
+	Additions    int               `json:"additions"`
+	Deletions    int               `json:"deletions"`
+	ChangedFiles int               `json:"changed_files"`
+	HTMLURL      string            `json:"html_url"`
+	CreatedAt    string            `json:"created_at"`
+	UpdatedAt    string            `json:"updated_at"`
+	MergedAt     *string           `json:"merged_at"`
+}
+
+// GitHub Pull Requests API client functions
+
+// GetPullRequest gets a pull request by number
+func (c *GitHubClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, error) {
+	c.logger.Debug("Getting pull request", "owner", owner, "repo", repo, "number", number)
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pull PullRequest
+	if err := resp.GetJSON(&pull); err != nil {
+		return nil, err
+	}
+
+	return &pull, nil
+}
+
+// GetPullRequestDiff gets the unified diff of a pull request, keeping its
+// first maxBytes bytes. The rest is streamed past without being held in
+// memory.
+func (c *GitHubClient) GetPullRequestDiff(ctx context.Context, owner, repo string, number, maxBytes int) (string, error) {
+	c.logger.Debug("Getting pull request diff", "owner", owner, "repo", repo, "number", number)
+
+	diff := &cappedBuffer{limit: maxBytes}
+	if _, err := c.Download(WithMediaType(ctx, DiffMediaType), fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number), nil, diff); err != nil {
+		return "", err
+	}
+
+	return diff.buf.String(), nil
+}
+
+// cappedBuffer keeps the first limit bytes written to it and discards the
+// rest. The buffer is not embedded, so io.Copy cannot bypass Write through
+// its ReadFrom.
+type cappedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+// Write keeps what fits of p, always reporting all of it written
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(room, len(p))])
+	}
+	return len(p), nil
+}
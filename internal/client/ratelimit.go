@@ -0,0 +1,231 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// secondaryRateLimitWait is how long GitHub asks clients to wait after a
+// secondary rate limit response without a Retry-After header
+const secondaryRateLimitWait = time.Minute
+
+// Rate limit resources GitHub budgets separately
+const (
+	// RateLimitCore is the budget of most REST endpoints
+	RateLimitCore = "core"
+	// RateLimitSearch is the budget of the search endpoints
+	RateLimitSearch = "search"
+	// RateLimitGraphQL is the budget of the GraphQL endpoint
+	RateLimitGraphQL = "graphql"
+)
+
+// RateLimitState is the rate limit of one resource as reported by the most
+// recent GitHub response counting against it
+type RateLimitState struct {
+	Resource  string    `json:"resource"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	Reset     time.Time `json:"reset"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RateLimitPolicy keeps a reserve of the rate limit budget. Requests made
+// while the remaining budget is below Floor wait for the reset when it is
+// at most MaxWait away and are rejected otherwise.
+type RateLimitPolicy struct {
+	// Floor is the budget kept in reserve; zero disables the policy
+	Floor int
+	// MaxWait bounds how long a request waits for the budget to reset
+	MaxWait time.Duration
+}
+
+// rateLimitTracker holds the rate limit state of each resource
+type rateLimitTracker struct {
+	mu     sync.Mutex
+	states map[string]RateLimitState
+
+	// rejected counts requests rejected to keep the budget floor
+	rejected atomic.Int64
+}
+
+// update records the rate limit headers of a response and returns the
+// state they report, if any
+func (t *rateLimitTracker) update(header http.Header) (RateLimitState, bool) {
+	state, ok := parseRateLimitState(header)
+	if !ok {
+		return RateLimitState{}, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.states == nil {
+		t.states = make(map[string]RateLimitState)
+	}
+	t.states[state.Resource] = state
+	return state, true
+}
+
+// parseRateLimitState returns the rate limit state the headers of a
+// response report, if any
+func parseRateLimitState(header http.Header) (RateLimitState, bool) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimitState{}, false
+	}
+	state := RateLimitState{
+		Resource:  header.Get("X-RateLimit-Resource"),
+		Remaining: remaining,
+		UpdatedAt: time.Now(),
+	}
+	if state.Resource == "" {
+		state.Resource = RateLimitCore
+	}
+	state.Limit, _ = strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	state.Used, _ = strconv.Atoi(header.Get("X-RateLimit-Used"))
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		state.Reset = time.Unix(reset, 0)
+	}
+	return state, true
+}
+
+// merge records state reported by another replica when it is of a later
+// window than the local state, or of the same window with less remaining
+func (t *rateLimitTracker) merge(state RateLimitState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	local, ok := t.states[state.Resource]
+	if ok && (state.Reset.Before(local.Reset) || (state.Reset.Equal(local.Reset) && state.Remaining >= local.Remaining)) {
+		return
+	}
+	if t.states == nil {
+		t.states = make(map[string]RateLimitState)
+	}
+	t.states[state.Resource] = state
+}
+
+// get returns the state of resource, if a response reported it
+func (t *rateLimitTracker) get(resource string) (RateLimitState, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.states[resource]
+	return state, ok
+}
+
+// rateLimitResource returns the rate limit resource an endpoint counts against
+func rateLimitResource(endpoint string) string {
+	path, _, _ := strings.Cut(strings.TrimPrefix(endpoint, "/"), "?")
+	switch {
+	case strings.HasPrefix(path, "search/"):
+		return RateLimitSearch
+	case path == "graphql":
+		return RateLimitGraphQL
+	}
+	return RateLimitCore
+}
+
+// SetRateLimitPolicy sets the rate limit budget kept in reserve; clients
+// spend their whole budget until it is called
+func (c *GitHubClient) SetRateLimitPolicy(policy RateLimitPolicy) {
+	c.ratePolicy = policy
+}
+
+// RateLimitState returns the rate limit of resource, such as RateLimitCore,
+// as reported by the most recent response, or with a token pool that of the
+// token with the most budget left. ok is false until a response reported it.
+func (c *GitHubClient) RateLimitState(resource string) (RateLimitState, bool) {
+	if observer, ok := c.tokens.(rateLimitObserver); ok {
+		return observer.budget(resource)
+	}
+	return c.rateStates.get(resource)
+}
+
+// RateLimitRejections returns the number of requests rejected because the
+// rate limit budget was below its floor
+func (c *GitHubClient) RateLimitRejections() int64 {
+	return c.rateStates.rejected.Load()
+}
+
+// reserveBudget waits for or rejects a request to endpoint when the
+// remaining budget of its resource is below the policy floor. Requests made
+// with a caller's token spend the caller's budget and are not held back.
+func (c *GitHubClient) reserveBudget(ctx context.Context, endpoint string) error {
+	if _, ok := TokenFromContext(ctx); ok || c.ratePolicy.Floor <= 0 {
+		return nil
+	}
+	c.refreshRateLimit(ctx, rateLimitResource(endpoint))
+	state, ok := c.RateLimitState(rateLimitResource(endpoint))
+	if !ok || state.Remaining >= c.ratePolicy.Floor {
+		return nil
+	}
+	wait := time.Until(state.Reset)
+	if wait <= 0 {
+		return nil
+	}
+
+	if wait > c.ratePolicy.MaxWait {
+		c.rateStates.rejected.Add(1)
+		return errors.RateLimit(fmt.Sprintf("rate limit budget exhausted, resets at %s", state.Reset.UTC().Format(time.RFC3339))).
+			WithContext("resource", state.Resource).
+			WithContext("remaining", state.Remaining).
+			WithContext("reset", state.Reset.Unix()).
+			WithContext("retry_after_seconds", waitSeconds(wait))
+	}
+
+	c.logger.WithContext(ctx).Warn("Waiting for the GitHub rate limit to reset",
+		"resource", state.Resource, "remaining", state.Remaining, "wait", wait)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), errors.ErrorTypeNetwork, "GitHub API request cancelled while waiting for the rate limit to reset")
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitError returns the error of a 403 or 429 response caused by a
+// rate limit, or nil when the response was refused for another reason. The
+// error carries the seconds to wait before retrying as retry_after_seconds.
+func rateLimitError(message string, header http.Header) *errors.AppError {
+	wait, hasWait := retryAfter(header)
+
+	if isSecondaryRateLimitMessage(message) {
+		if !hasWait {
+			wait = secondaryRateLimitWait
+		}
+		return errors.SecondaryRateLimit(message).WithContext("retry_after_seconds", waitSeconds(wait))
+	}
+
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		appErr := errors.RateLimit(message)
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			appErr.WithContext("reset", reset)
+			if !hasWait {
+				wait, hasWait = max(time.Until(time.Unix(reset, 0)), 0), true
+			}
+		}
+		if hasWait {
+			appErr.WithContext("retry_after_seconds", waitSeconds(wait))
+		}
+		return appErr
+	}
+
+	if hasWait {
+		return errors.RateLimit(message).WithContext("retry_after_seconds", waitSeconds(wait))
+	}
+	return nil
+}
+
+// waitSeconds rounds a wait up to whole seconds
+func waitSeconds(wait time.Duration) int {
+	return int((wait + time.Second - 1) / time.Second)
+}
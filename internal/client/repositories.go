@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// GitHub Repository data structures
+
+// Repository represents a GitHub repository
+type Repository struct {
+	ID              int64    `json:"id"`
+	NodeID          string   `json:"node_id"`
+	Name            string   `json:"name"`
+	FullName        string   `json:"full_name"`
+	Private         bool     `json:"private"`
+	Owner           User     `json:"owner"`
+	HTMLURL         string   `json:"html_url"`
+	Description     *string  `json:"description"`
+	Fork            bool     `json:"fork"`
+	URL             string   `json:"url"`
+	CloneURL        string   `json:"clone_url"`
+	SSHURL          string   `json:"ssh_url"`
+	Homepage        *string  `json:"homepage"`
+	Language        *string  `json:"language"`
+	ForksCount      int      `json:"forks_count"`
+	StargazersCount int      `json:"stargazers_count"`
+	WatchersCount   int      `json:"watchers_count"`
+	Size            int      `json:"size"`
+	DefaultBranch   string   `json:"default_branch"`
+	OpenIssuesCount int      `json:"open_issues_count"`
+	IsTemplate      bool     `json:"is_template"`
+	Topics          []string `json:"topics"`
+	HasIssues       bool     `json:"has_issues"`
+	HasProjects     bool     `json:"has_projects"`
+	HasWiki         bool     `json:"has_wiki"`
+	HasPages        bool     `json:"has_pages"`
+	HasDiscussions  bool     `json:"has_discussions"`
+	Archived        bool     `json:"archived"`
+	Disabled        bool     `json:"disabled"`
+	Visibility      string   `json:"visibility"`
+	PushedAt        *string  `json:"pushed_at"`
+	CreatedAt       string   `json:"created_at"`
+	UpdatedAt       string   `json:"updated_at"`
+	// The merge and fork settings are only returned for a single
+	// repository, not in lists
+	AllowForking        *bool `json:"allow_forking,omitempty"`
+	AllowMergeCommit    *bool `json:"allow_merge_commit,omitempty"`
+	AllowSquashMerge    *bool `json:"allow_squash_merge,omitempty"`
+	AllowRebaseMerge    *bool `json:"allow_rebase_merge,omitempty"`
+	AllowAutoMerge      *bool `json:"allow_auto_merge,omitempty"`
+	DeleteBranchOnMerge *bool `json:"delete_branch_on_merge,omitempty"`
+	// Parent is the repository a fork was forked from
+	Parent      *Repository `json:"parent,omitempty"`
+	Permissions *struct {
+		Admin    bool `json:"admin"`
+		Maintain bool `json:"maintain"`
+		Push     bool `json:"push"`
+		Triage   bool `json:"triage"`
+		Pull     bool `json:"pull"`
+	} `json:"permissions,omitempty"`
+}
+
+// GitHub Repository API client functions
+
+// ListOrganizationRepositories lists repositories in an organization
+func (c *GitHubClient) ListOrganizationRepositories(ctx context.Context, org, repoType string, page, perPage int) ([]Repository, *PageInfo, error) {
+	c.logger.Debug("Listing organization repositories", "org", org, "type", repoType, "page", page, "per_page", perPage)
+
+	params := pageParams(page, perPage)
+	if repoType != "" {
+		params["type"] = repoType
+	}
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/orgs/%s/repos", org), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var repos []Repository
+	if err := resp.GetJSON(&repos); err != nil {
+		return nil, nil, err
+	}
+
+	return repos, resp.PageInfo(), nil
+}
+
+// ListRepositories lists the repositories of a user or organization, of
+// repoType (all, owner or member) and ordered by sort (created, updated,
+// pushed or full_name) in direction (asc or desc); empty values use
+// GitHub's defaults
+func (c *GitHubClient) ListRepositories(ctx context.Context, owner, repoType, sort, direction string, page, perPage int) ([]Repository, *PageInfo, error) {
+	c.logger.Debug("Listing repositories", "owner", owner, "type", repoType, "sort", sort, "direction", direction, "page", page, "per_page", perPage)
+
+	params := pageParams(page, perPage)
+	if repoType != "" {
+		params["type"] = repoType
+	}
+	if sort != "" {
+		params["sort"] = sort
+	}
+	if direction != "" {
+		params["direction"] = direction
+	}
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/users/%s/repos", owner), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var repos []Repository
+	if err := resp.GetJSON(&repos); err != nil {
+		return nil, nil, err
+	}
+
+	return repos, resp.PageInfo(), nil
+}
+
+// GetRepository gets a repository by owner and name
+func (c *GitHubClient) GetRepository(ctx context.Context, owner, repo string) (*Repository, error) {
+	c.logger.Debug("Getting repository", "owner", owner, "repo", repo)
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/repos/%s/%s", owner, repo), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repository Repository
+	if err := resp.GetJSON(&repository); err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
+
+// CreateRepository creates a repository in an organization, or for the
+// authenticated user when org is empty
+func (c *GitHubClient) CreateRepository(ctx context.Context, org string, repoData map[string]interface{}) (*Repository, error) {
+	c.logger.Debug("Creating repository", "org", org)
+
+	endpoint := "/user/repos"
+	if org != "" {
+		endpoint = fmt.Sprintf("/orgs/%s/repos", org)
+	}
+	resp, err := c.Post(ctx, endpoint, repoData)
+	if err != nil {
+		return nil, err
+	}
+
+	var repository Repository
+	if err := resp.GetJSON(&repository); err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
+
+// UpdateRepository updates a repository's settings
+func (c *GitHubClient) UpdateRepository(ctx context.Context, owner, repo string, updates map[string]interface{}) (*Repository, error) {
+	c.logger.Debug("Updating repository", "owner", owner, "repo", repo)
+
+	resp, err := c.Patch(ctx, fmt.Sprintf("/repos/%s/%s", owner, repo), updates)
+	if err != nil {
+		return nil, err
+	}
+
+	var repository Repository
+	if err := resp.GetJSON(&repository); err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
+
+// DeleteRepository deletes a repository
+func (c *GitHubClient) DeleteRepository(ctx context.Context, owner, repo string) error {
+	c.logger.Debug("Deleting repository", "owner", owner, "repo", repo)
+
+	_, err := c.Delete(ctx, fmt.Sprintf("/repos/%s/%s", owner, repo))
+	return err
+}
+
+// ForkRepository forks a repository into an organization, or for the
+// authenticated user when org is empty, optionally under another name and
+// with only the default branch. GitHub creates the fork asynchronously, so
+// its contents can take a moment to appear.
+func (c *GitHubClient) ForkRepository(ctx context.Context, owner, repo, org, name string, defaultBranchOnly bool) (*Repository, error) {
+	c.logger.Debug("Forking repository", "owner", owner, "repo", repo, "org", org, "name", name)
+
+	body := map[string]interface{}{}
+	if org != "" {
+		body["organization"] = org
+	}
+	if name != "" {
+		body["name"] = name
+	}
+	if defaultBranchOnly {
+		body["default_branch_only"] = true
+	}
+
+	resp, err := c.Post(ctx, fmt.Sprintf("/repos/%s/%s/forks", owner, repo), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var repository Repository
+	if err := resp.GetJSON(&repository); err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
+
+// TransferRepository transfers a repository to another user or
+// organization, optionally renaming it and giving teams of the new owner
+// access. GitHub completes the transfer asynchronously.
+func (c *GitHubClient) TransferRepository(ctx context.Context, owner, repo, newOwner, newName string, teamIDs []int64) (*Repository, error) {
+	c.logger.Debug("Transferring repository", "owner", owner, "repo", repo, "new_owner", newOwner, "new_name", newName)
+
+	body := map[string]interface{}{"new_owner": newOwner}
+	if newName != "" {
+		body["new_name"] = newName
+	}
+	if len(teamIDs) > 0 {
+		body["team_ids"] = teamIDs
+	}
+
+	resp, err := c.Post(ctx, fmt.Sprintf("/repos/%s/%s/transfer", owner, repo), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var repository Repository
+	if err := resp.GetJSON(&repository); err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
@@ -0,0 +1,223 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+)
+
+const (
+	// DefaultRetryAttempts is the default number of attempts made per request
+	DefaultRetryAttempts = 3
+	// DefaultRetryMaxDelay is the default bound on the delay before a retry
+	DefaultRetryMaxDelay = 30 * time.Second
+	// defaultRetryBaseDelay is the delay before the first retry, before jitter
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	// maxInspectedBodySize bounds the body read to recognize secondary rate limits
+	maxInspectedBodySize = 64 << 10
+)
+
+// errBodyNotRewindable reports a request whose body cannot be sent again
+var errBodyNotRewindable = errors.New("request body cannot be rewound")
+
+// RetryPolicy controls how requests that fail transiently are retried:
+// server errors and network failures of idempotent requests, and requests
+// rejected by rate limits that lift within MaxDelay
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts; one disables retries
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. It doubles for every
+	// further retry and is jittered.
+	BaseDelay time.Duration
+	// MaxDelay bounds the backoff delay. Rate limits asking to wait longer,
+	// with Retry-After or their reset time, are not retried.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by the server
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultRetryAttempts,
+		BaseDelay:   defaultRetryBaseDelay,
+		MaxDelay:    DefaultRetryMaxDelay,
+	}
+}
+
+// SetRetryPolicy sets how transient failures are retried; clients make a
+// single attempt until it is called
+func (c *GitHubClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
+// do sends req, retrying transient failures according to the retry policy
+func (c *GitHubClient) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	stats := execstats.FromContext(ctx)
+
+	for attempt := 1; ; attempt++ {
+		release, err := c.acquireSlot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		attemptCtx, cancel := c.requestContext(ctx)
+		start := time.Now()
+		resp, err := c.httpClient.Do(req.WithContext(attemptCtx))
+		stats.AddRoundTrip(time.Since(start))
+		if err != nil {
+			release()
+			cancel()
+		} else {
+			// The attempt's timeout keeps running while the body is read
+			resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() { release(); cancel() }}
+		}
+
+		if attempt >= c.retry.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+		delay, retry := c.retryDelay(req.Method, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+
+		// The retry replaces the response; req can only be resent with its body rewound
+		next, rewindErr := rewindRequest(req)
+		if rewindErr != nil {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, maxInspectedBodySize))
+			resp.Body.Close()
+		}
+
+		logArgs := []interface{}{"method", req.Method, "url", req.URL.String(), "attempt", attempt, "delay", delay}
+		if err != nil {
+			logArgs = append(logArgs, "error", err)
+		} else {
+			logArgs = append(logArgs, "status", resp.StatusCode)
+		}
+		c.logger.WithContext(ctx).Warn("Retrying GitHub API request", logArgs...)
+		stats.AddRetry()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		req = next
+	}
+}
+
+// retryDelay reports whether a request should be retried after the given
+// outcome of attempt, and how long to wait first
+func (c *GitHubClient) retryDelay(method string, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		return c.backoff(attempt), isIdempotent(method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !isIdempotent(method) {
+			return 0, false
+		}
+		if delay, ok := retryAfter(resp.Header); ok {
+			return delay, delay <= c.retry.MaxDelay
+		}
+		return c.backoff(attempt), true
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		// Rate limited requests were not processed, so any method may be retried
+		if delay, ok := retryAfter(resp.Header); ok {
+			return delay, delay <= c.retry.MaxDelay
+		}
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+			if err != nil {
+				return 0, false
+			}
+			delay := max(time.Until(time.Unix(reset, 0)), 0) + time.Second
+			return delay, delay <= c.retry.MaxDelay
+		}
+		if isSecondaryRateLimit(resp) {
+			// Without a Retry-After, GitHub asks to wait at least a minute
+			return time.Minute, time.Minute <= c.retry.MaxDelay
+		}
+	}
+	return 0, false
+}
+
+// backoff returns the jittered exponential delay before retrying attempt
+func (c *GitHubClient) backoff(attempt int) time.Duration {
+	delay := c.retry.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > c.retry.MaxDelay {
+		delay = c.retry.MaxDelay
+	}
+	// Equal jitter keeps at least half the delay while spreading clients out
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// isSecondaryRateLimit reports whether a 403 or 429 response was caused by a
+// secondary rate limit. The body it inspects is restored for the caller.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxInspectedBodySize))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	return err == nil && isSecondaryRateLimitMessage(string(body))
+}
+
+// isSecondaryRateLimitMessage reports whether a GitHub error message is
+// about a secondary rate limit, which older responses call abuse detection
+func isSecondaryRateLimitMessage(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "secondary rate limit") || strings.Contains(message, "abuse detection")
+}
+
+// isIdempotent reports whether a request with method may be repeated when
+// its outcome is unknown
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rewindRequest returns a copy of req that can be sent again
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, errBodyNotRewindable
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = body
+	}
+	return next, nil
+}
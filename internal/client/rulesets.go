@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// GitHub Rulesets data structures
+
+// Ruleset represents a repository or organization ruleset. Lists leave out
+// its bypass actors, conditions and rules.
+type Ruleset struct {
+	ID     int64  `json:"id"`
+	NodeID string `json:"node_id"`
+	Name   string `json:"name"`
+	// Target is branch, tag or push
+	Target string `json:"target"`
+	// SourceType is Repository or Organization
+	SourceType string `json:"source_type"`
+	Source     string `json:"source"`
+	// Enforcement is disabled, active or evaluate
+	Enforcement  string                 `json:"enforcement"`
+	BypassActors []BypassActor          `json:"bypass_actors,omitempty"`
+	Conditions   map[string]interface{} `json:"conditions,omitempty"`
+	Rules        []RulesetRule          `json:"rules,omitempty"`
+	// CurrentUserCanBypass is always, pull_requests_only or never
+	CurrentUserCanBypass string `json:"current_user_can_bypass,omitempty"`
+	CreatedAt            string `json:"created_at"`
+	UpdatedAt            string `json:"updated_at"`
+}
+
+// BypassActor is an actor allowed to bypass a ruleset
+type BypassActor struct {
+	ActorID *int64 `json:"actor_id"`
+	// ActorType is Integration, OrganizationAdmin, RepositoryRole, Team or
+	// DeployKey
+	ActorType string `json:"actor_type"`
+	// BypassMode is always or pull_request
+	BypassMode string `json:"bypass_mode"`
+}
+
+// RulesetRule is a rule of a ruleset, such as pull_request or
+// required_status_checks, with its parameters
+type RulesetRule struct {
+	Type       string                 `json:"type"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// BranchRule is a rule applying to a branch, with the ruleset it comes from
+type BranchRule struct {
+	RulesetRule
+	RulesetSourceType string `json:"ruleset_source_type"`
+	RulesetSource     string `json:"ruleset_source"`
+	RulesetID         int64  `json:"ruleset_id"`
+}
+
+// GitHub Rulesets API client functions
+
+// rulesetsEndpoint returns the endpoint of the rulesets of repository
+// owner/repo, or of organization owner when repo is empty
+func rulesetsEndpoint(owner, repo string) string {
+	if repo == "" {
+		return fmt.Sprintf("/orgs/%s/rulesets", owner)
+	}
+	return fmt.Sprintf("/repos/%s/%s/rulesets", owner, repo)
+}
+
+// ListRulesets lists the rulesets of repository owner/repo, including those
+// of its organization, or of organization owner when repo is empty
+func (c *GitHubClient) ListRulesets(ctx context.Context, owner, repo string, page, perPage int) ([]Ruleset, *PageInfo, error) {
+	c.logger.Debug("Listing rulesets", "owner", owner, "repo", repo, "page", page, "per_page", perPage)
+
+	resp, err := c.Get(ctx, rulesetsEndpoint(owner, repo), pageParams(page, perPage))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rulesets []Ruleset
+	if err := resp.GetJSON(&rulesets); err != nil {
+		return nil, nil, err
+	}
+
+	return rulesets, resp.PageInfo(), nil
+}
+
+// GetRuleset gets a ruleset of repository owner/repo, or of organization
+// owner when repo is empty
+func (c *GitHubClient) GetRuleset(ctx context.Context, owner, repo string, id int64) (*Ruleset, error) {
+	c.logger.Debug("Getting ruleset", "owner", owner, "repo", repo, "id", id)
+
+	resp, err := c.Get(ctx, fmt.Sprintf("%s/%d", rulesetsEndpoint(owner, repo), id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ruleset Ruleset
+	if err := resp.GetJSON(&ruleset); err != nil {
+		return nil, err
+	}
+
+	return &ruleset, nil
+}
+
+// CreateRuleset creates a ruleset for repository owner/repo, or for
+// organization owner when repo is empty
+func (c *GitHubClient) CreateRuleset(ctx context.Context, owner, repo string, rulesetData map[string]interface{}) (*Ruleset, error) {
+	c.logger.Debug("Creating ruleset", "owner", owner, "repo", repo)
+
+	resp, err := c.Post(ctx, rulesetsEndpoint(owner, repo), rulesetData)
+	if err != nil {
+		return nil, err
+	}
+
+	var ruleset Ruleset
+	if err := resp.GetJSON(&ruleset); err != nil {
+		return nil, err
+	}
+
+	return &ruleset, nil
+}
+
+// UpdateRuleset updates a ruleset of repository owner/repo, or of
+// organization owner when repo is empty. Fields left out of updates are
+// kept.
+func (c *GitHubClient) UpdateRuleset(ctx context.Context, owner, repo string, id int64, updates map[string]interface{}) (*Ruleset, error) {
+	c.logger.Debug("Updating ruleset", "owner", owner, "repo", repo, "id", id)
+
+	resp, err := c.Put(ctx, fmt.Sprintf("%s/%d", rulesetsEndpoint(owner, repo), id), updates)
+	if err != nil {
+		return nil, err
+	}
+
+	var ruleset Ruleset
+	if err := resp.GetJSON(&ruleset); err != nil {
+		return nil, err
+	}
+
+	return &ruleset, nil
+}
+
+// DeleteRuleset deletes a ruleset of repository owner/repo, or of
+// organization owner when repo is empty
+func (c *GitHubClient) DeleteRuleset(ctx context.Context, owner, repo string, id int64) error {
+	c.logger.Debug("Deleting ruleset", "owner", owner, "repo", repo, "id", id)
+
+	_, err := c.Delete(ctx, fmt.Sprintf("%s/%d", rulesetsEndpoint(owner, repo), id))
+	return err
+}
+
+// GetBranchRules lists the rules of every active ruleset applying to a
+// branch of a repository
+func (c *GitHubClient) GetBranchRules(ctx context.Context, owner, repo, branch string, page, perPage int) ([]BranchRule, *PageInfo, error) {
+	c.logger.Debug("Getting branch rules", "owner", owner, "repo", repo, "branch", branch, "page", page, "per_page", perPage)
+
+	escaped, err := escapePath(branch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/repos/%s/%s/rules/branches/%s", owner, repo, escaped), pageParams(page, perPage))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rules []BranchRule
+	if err := resp.GetJSON(&rules); err != nil {
+		return nil, nil, err
+	}
+
+	return rules, resp.PageInfo(), nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+)
+
+// GitHub Search data structures
+
+// IssueSearchResult represents the result of an issue and pull request search
+type IssueSearchResult struct {
+	TotalCount        int     `json:"total_count"`
+	IncompleteResults bool    `json:"incomplete_results"`
+	Items             []Issue `json:"items"`
+}
+
+// GitHub Search API client functions
+
+// SearchIssues searches issues and pull requests using GitHub search qualifiers
+func (c *GitHubClient) SearchIssues(ctx context.Context, query, sort, order string, page, perPage int) (*IssueSearchResult, *PageInfo, error) {
+	c.logger.Debug("Searching issues", "query", query, "sort", sort, "order", order, "page", page, "per_page", perPage)
+
+	params := pageParams(page, perPage)
+	params["q"] = query
+	if sort != "" {
+		params["sort"] = sort
+	}
+	if order != "" {
+		params["order"] = order
+	}
+
+	resp, err := c.Get(ctx, "/search/issues", params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result IssueSearchResult
+	if err := resp.GetJSON(&result); err != nil {
+		return nil, nil, err
+	}
+
+	return &result, resp.PageInfo(), nil
+}
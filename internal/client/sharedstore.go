@@ -0,0 +1,286 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// sharedCacheTTL bounds how long a response stays in the shared store
+	// without being stored again
+	sharedCacheTTL = 24 * time.Hour
+	// sharedRateLimitRefresh bounds how often the rate limit of a resource
+	// is read from the shared store
+	sharedRateLimitRefresh = time.Second
+	// sharedStoreTimeout bounds a shared store operation, so an unreachable
+	// store slows requests down only a little
+	sharedStoreTimeout = 500 * time.Millisecond
+	// sharedPutQueueSize bounds the puts waiting to be written to the
+	// shared store; puts beyond it are dropped
+	sharedPutQueueSize = 256
+	// sharedBackoffMin and sharedBackoffMax bound how long the shared store
+	// is skipped after it failed, doubling while it keeps failing
+	sharedBackoffMin = time.Second
+	sharedBackoffMax = 30 * time.Second
+)
+
+// SharedStore is a key-value store shared by the replicas of a deployment,
+// such as Redis. It must be safe for concurrent use.
+type SharedStore interface {
+	// Get returns the value of key, reporting whether it is set
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set sets key to value, expiring it after ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// sharedState is the state a client keeps in a shared store
+type sharedState struct {
+	store SharedStore
+
+	// puts holds the values waiting to be written by writeLoop, which
+	// closes done once stop is closed and the queue drained
+	puts      chan sharedPut
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// refreshed holds when the rate limit of each resource was last read
+	mu        sync.Mutex
+	refreshed map[string]time.Time
+	// retryAt is when the store is used again after it failed, backoff how
+	// long it was skipped for
+	retryAt time.Time
+	backoff time.Duration
+
+	// failing is set while the store fails, so failures are logged once
+	failing atomic.Bool
+}
+
+// sharedPut is a value waiting to be written to the shared store
+type sharedPut struct {
+	key   string
+	value []byte
+	ttl   time.Duration
+}
+
+// sharedResponse is a cached response as kept in a shared store
+type sharedResponse struct {
+	ETag       string      `json:"etag"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// SetSharedStore shares cached responses and the rate limit budget with the
+// other replicas using store. A response one replica cached is revalidated
+// by the others instead of fetched again, and every replica holds back
+// requests by the budget the latest responses of all of them report.
+// Replicas sharing a store must use the same credentials; with a token pool
+// only responses are shared. Values are written to the store in the
+// background, and the store is skipped for a while after it fails.
+func (c *GitHubClient) SetSharedStore(store SharedStore) {
+	if store == nil {
+		c.shared = nil
+		return
+	}
+	c.shared = &sharedState{
+		store:     store,
+		puts:      make(chan sharedPut, sharedPutQueueSize),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		refreshed: make(map[string]time.Time),
+	}
+	go c.sharedWriteLoop(c.shared)
+}
+
+// CloseSharedStore writes the values waiting for the shared store, until ctx
+// is done, and closes the store if it is an io.Closer
+func (c *GitHubClient) CloseSharedStore(ctx context.Context) error {
+	shared := c.shared
+	if shared == nil {
+		return nil
+	}
+	shared.closeOnce.Do(func() { close(shared.stop) })
+	select {
+	case <-shared.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if closer, ok := shared.store.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// sharedWriteLoop writes the values put for the shared store until it is
+// closed, then the ones still waiting
+func (c *GitHubClient) sharedWriteLoop(shared *sharedState) {
+	defer close(shared.done)
+	for {
+		select {
+		case put := <-shared.puts:
+			c.sharedWrite(shared, put)
+		case <-shared.stop:
+			for {
+				select {
+				case put := <-shared.puts:
+					c.sharedWrite(shared, put)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// sharedWrite writes a value to the shared store unless it is backing off
+func (c *GitHubClient) sharedWrite(shared *sharedState, put sharedPut) {
+	if !shared.available() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sharedStoreTimeout)
+	defer cancel()
+	c.sharedResult(ctx, shared, shared.store.Set(ctx, put.key, put.value, put.ttl))
+}
+
+// queueSharedPut queues a value to be written to the shared store, dropping
+// it when the queue is full or the store closed
+func (c *GitHubClient) queueSharedPut(key string, value []byte, ttl time.Duration) {
+	select {
+	case <-c.shared.stop:
+	case c.shared.puts <- sharedPut{key: key, value: value, ttl: ttl}:
+	default:
+	}
+}
+
+// available reports whether the store is used, which it is not while
+// backing off after a failure
+func (s *sharedState) available() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !time.Now().Before(s.retryAt)
+}
+
+// sharedCacheKey returns the shared store key of the response cached as key
+func sharedCacheKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "cache:" + hex.EncodeToString(sum[:])
+}
+
+// sharedCacheGet returns the response another replica cached as key and
+// adds it to the local cache, or nil
+func (c *GitHubClient) sharedCacheGet(ctx context.Context, key string) *cachedResponse {
+	if c.shared == nil || !c.shared.available() {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, sharedStoreTimeout)
+	defer cancel()
+	data, ok, err := c.shared.store.Get(ctx, sharedCacheKey(key))
+	c.sharedResult(ctx, c.shared, err)
+	if !ok {
+		return nil
+	}
+
+	var shared sharedResponse
+	if err := json.Unmarshal(data, &shared); err != nil {
+		return nil
+	}
+	entry := &cachedResponse{key: key, etag: shared.ETag, statusCode: shared.StatusCode, header: shared.Header, body: shared.Body}
+	c.cache.add(entry)
+	return entry
+}
+
+// sharedCachePut stores a cached response for the other replicas
+func (c *GitHubClient) sharedCachePut(entry *cachedResponse) {
+	if c.shared == nil || entry == nil {
+		return
+	}
+	data, err := json.Marshal(sharedResponse{ETag: entry.etag, StatusCode: entry.statusCode, Header: entry.header, Body: entry.body})
+	if err != nil {
+		return
+	}
+	c.queueSharedPut(sharedCacheKey(entry.key), data, sharedCacheTTL)
+}
+
+// sharesRateLimit reports whether the rate limit is shared with the other
+// replicas; a token pool tracks the budget of each of its tokens locally
+func (c *GitHubClient) sharesRateLimit() bool {
+	_, pooled := c.tokens.(rateLimitObserver)
+	return c.shared != nil && !pooled
+}
+
+// shareRateLimit stores the rate limit a response reported for the other
+// replicas, until its window resets
+func (c *GitHubClient) shareRateLimit(state RateLimitState) {
+	ttl := time.Until(state.Reset)
+	if !c.sharesRateLimit() || ttl <= 0 {
+		return
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	c.queueSharedPut("ratelimit:"+state.Resource, data, ttl)
+}
+
+// refreshRateLimit merges the rate limit of resource the other replicas
+// reported, reading it at most once per sharedRateLimitRefresh
+func (c *GitHubClient) refreshRateLimit(ctx context.Context, resource string) {
+	if !c.sharesRateLimit() || !c.shared.available() {
+		return
+	}
+	c.shared.mu.Lock()
+	if time.Since(c.shared.refreshed[resource]) < sharedRateLimitRefresh {
+		c.shared.mu.Unlock()
+		return
+	}
+	c.shared.refreshed[resource] = time.Now()
+	c.shared.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, sharedStoreTimeout)
+	defer cancel()
+	data, ok, err := c.shared.store.Get(ctx, "ratelimit:"+resource)
+	c.sharedResult(ctx, c.shared, err)
+	if !ok {
+		return
+	}
+	var state RateLimitState
+	if err := json.Unmarshal(data, &state); err == nil && state.Resource == resource {
+		c.rateStates.merge(state)
+	}
+}
+
+// sharedResult backs off from the shared store while it fails and logs when
+// it starts or stops failing
+func (c *GitHubClient) sharedResult(ctx context.Context, shared *sharedState, err error) {
+	if errors.Is(err, context.Canceled) {
+		// The request was cancelled, which says nothing about the store
+		return
+	}
+	shared.mu.Lock()
+	if err != nil {
+		shared.backoff = min(max(2*shared.backoff, sharedBackoffMin), sharedBackoffMax)
+		shared.retryAt = time.Now().Add(shared.backoff)
+	} else {
+		shared.backoff = 0
+	}
+	shared.mu.Unlock()
+
+	if err != nil {
+		if !shared.failing.Swap(true) {
+			c.logger.WithContext(ctx).Warn("Shared store unavailable, using local state only", "error", err)
+		}
+		return
+	}
+	if shared.failing.Swap(false) {
+		c.logger.WithContext(ctx).Info("Shared store available again")
+	}
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"strings"
+)
+
+// TokenInfo describes the token the client authenticates with
+type TokenInfo struct {
+	Login string `json:"login"`
+	// Scopes are the OAuth scopes of a classic token; fine-grained and
+	// installation tokens report none
+	Scopes []string `json:"scopes"`
+	// Expiration is when the token expires, as reported by GitHub; empty for
+	// tokens without an expiration
+	Expiration string `json:"expiration,omitempty"`
+}
+
+// GetTokenInfo returns the user and scopes of the token the client
+// authenticates with
+func (c *GitHubClient) GetTokenInfo(ctx context.Context) (*TokenInfo, error) {
+	c.logger.Debug("Getting token info")
+
+	resp, err := c.Get(ctx, "/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := resp.GetJSON(&user); err != nil {
+		return nil, err
+	}
+
+	info := &TokenInfo{
+		Login:      user.Login,
+		Scopes:     []string{},
+		Expiration: resp.Headers.Get("GitHub-Authentication-Token-Expiration"),
+	}
+	for _, scope := range strings.Split(resp.Headers.Get("X-OAuth-Scopes"), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			info.Scopes = append(info.Scopes, scope)
+		}
+	}
+	return info, nil
+}
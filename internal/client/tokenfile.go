@@ -0,0 +1,111 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+)
+
+// DefaultFileCheckInterval is how often credential files are checked for
+// changes, such as a Kubernetes secret or Vault agent rotating them
+const DefaultFileCheckInterval = 10 * time.Second
+
+// watchedFile is a file read again when its modification time or size
+// changes, checked at most once per interval
+type watchedFile struct {
+	path     string
+	interval time.Duration
+
+	mu        sync.Mutex
+	data      []byte
+	modTime   time.Time
+	size      int64
+	checkedAt time.Time
+}
+
+// newWatchedFile reads the file at path
+func newWatchedFile(path string, interval time.Duration) (*watchedFile, error) {
+	f := &watchedFile{path: path, interval: interval}
+	if _, _, err := f.read(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// read returns the contents of the file, reporting whether they changed
+// since the previous read. When the file cannot be read after it was read
+// once, the previous contents are kept so a rotation in progress does not
+// fail requests.
+func (f *watchedFile) read() ([]byte, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.data != nil && time.Since(f.checkedAt) < f.interval {
+		return f.data, false, nil
+	}
+	f.checkedAt = time.Now()
+
+	info, err := os.Stat(f.path)
+	if err == nil && f.data != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.data, false, nil
+	}
+	var data []byte
+	if err == nil {
+		data, err = os.ReadFile(f.path)
+	}
+	if err == nil && len(bytes.TrimSpace(data)) == 0 {
+		err = errors.Validation("file is empty")
+	}
+	if err != nil {
+		if f.data != nil {
+			return f.data, false, nil
+		}
+		return nil, false, errors.Wrap(err, errors.ErrorTypeValidation, "failed to read credential file "+f.path)
+	}
+
+	changed := f.data != nil && !bytes.Equal(data, f.data)
+	f.data, f.modTime, f.size = data, info.ModTime(), info.Size()
+	return data, changed, nil
+}
+
+// fileToken is a token read from a file and read again when it changes
+type fileToken struct {
+	file   *watchedFile
+	logger *logger.Logger
+}
+
+// SetTokenFile authenticates requests with the token in the file at path,
+// checking it for a new token at most once per interval, or per
+// DefaultFileCheckInterval when interval is zero. It cannot be combined with
+// a token pool.
+func (c *GitHubClient) SetTokenFile(path string, interval time.Duration) error {
+	if _, pooled := c.tokens.(*tokenPool); pooled {
+		return errors.Validation("a token file cannot be combined with a token pool")
+	}
+	if interval <= 0 {
+		interval = DefaultFileCheckInterval
+	}
+	file, err := newWatchedFile(path, interval)
+	if err != nil {
+		return err
+	}
+	c.tokens = &fileToken{file: file, logger: c.logger}
+	return nil
+}
+
+// Token returns the token currently in the file
+func (t *fileToken) Token(ctx context.Context, _ string) (string, error) {
+	data, changed, err := t.file.read()
+	if err != nil {
+		return "", err
+	}
+	if changed {
+		t.logger.WithContext(ctx).Info("Reloaded the GitHub token from its file", "path", t.file.path)
+	}
+	return string(bytes.TrimSpace(data)), nil
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// rateLimitObserver is implemented by token sources that track the rate
+// limit of each of their tokens
+type rateLimitObserver interface {
+	// observe records the status and headers of a response to a request
+	// authenticated with token
+	observe(token string, status int, header http.Header)
+	// budget returns the rate limit of resource for the token the next
+	// request would use, if a response reported it
+	budget(resource string) (RateLimitState, bool)
+}
+
+// pooledToken is a token of a pool and its rate limit
+type pooledToken struct {
+	token   string
+	states  rateLimitTracker
+	invalid bool
+}
+
+// tokenPool rotates requests between tokens, preferring the token with the
+// most budget left for the resource a request counts against
+type tokenPool struct {
+	mu     sync.Mutex
+	tokens []*pooledToken
+	// next is where the search for the best token starts, so that tokens
+	// with the same budget take turns
+	next int
+}
+
+// SetTokenPool authenticates requests with the token of tokens that has the
+// most rate limit budget left. Tokens whose budget is not known yet are
+// tried first, and tokens GitHub rejects are no longer used. It cannot be
+// combined with a token file.
+func (c *GitHubClient) SetTokenPool(tokens []string) error {
+	if len(tokens) == 0 {
+		return errors.Validation("token pool requires at least one token")
+	}
+	if _, fromFile := c.tokens.(*fileToken); fromFile {
+		return errors.Validation("a token pool cannot be combined with a token file")
+	}
+	pool := &tokenPool{tokens: make([]*pooledToken, len(tokens))}
+	for i, token := range tokens {
+		pool.tokens[i] = &pooledToken{token: token}
+	}
+	c.tokens = pool
+	return nil
+}
+
+// Token returns the token with the most budget left for the resource
+// endpoint counts against
+func (p *tokenPool) Token(_ context.Context, endpoint string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	best := p.best(rateLimitResource(endpoint))
+	if best < 0 {
+		return "", errors.Authentication("every token of the token pool was rejected by GitHub")
+	}
+	p.next = (best + 1) % len(p.tokens)
+	return p.tokens[best].token, nil
+}
+
+// best returns the index of the valid token with the most budget left for
+// resource, or -1 when no token is valid; p.mu must be held
+func (p *tokenPool) best(resource string) int {
+	best, bestRemaining := -1, 0
+	for n := 0; n < len(p.tokens); n++ {
+		i := (p.next + n) % len(p.tokens)
+		if p.tokens[i].invalid {
+			continue
+		}
+		remaining := p.tokens[i].remaining(resource)
+		if best < 0 || remaining > bestRemaining {
+			best, bestRemaining = i, remaining
+		}
+	}
+	return best
+}
+
+// remaining returns the budget left for resource, which is unlimited until
+// a response reports it or after its window has reset
+func (t *pooledToken) remaining(resource string) int {
+	state, ok := t.states.get(resource)
+	if !ok || time.Now().After(state.Reset) {
+		return int(^uint(0) >> 1)
+	}
+	return state.Remaining
+}
+
+func (p *tokenPool) observe(token string, status int, header http.Header) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, t := range p.tokens {
+		if t.token != token {
+			continue
+		}
+		if status == http.StatusUnauthorized {
+			t.invalid = true
+			return
+		}
+		t.states.update(header)
+		return
+	}
+}
+
+func (p *tokenPool) budget(resource string) (RateLimitState, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	best := p.best(resource)
+	if best < 0 {
+		return RateLimitState{}, false
+	}
+	return p.tokens[best].states.get(resource)
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// TokenSource supplies the token authenticating a request to an endpoint
+type TokenSource interface {
+	Token(ctx context.Context, endpoint string) (string, error)
+}
+
+// tokenRejecter is implemented by token sources caching tokens that GitHub
+// may revoke before they expire
+type tokenRejecter interface {
+	// reject drops token, which GitHub answered with 401 Unauthorized
+	reject(token string)
+}
+
+// staticToken is a token used for every request, such as a personal access token
+type staticToken string
+
+// Token returns the token
+func (t staticToken) Token(context.Context, string) (string, error) {
+	return string(t), nil
+}
+
+// rejectToken tells the token source that GitHub rejected the token a
+// request was authenticated with
+func (c *GitHubClient) rejectToken(req *http.Request, status int) {
+	if rejecter, ok := c.tokens.(tokenRejecter); ok && status == http.StatusUnauthorized {
+		rejecter.reject(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+	}
+}
+
+// SetTokenSource sets where the client gets the token of each request;
+// clients use the token they were created with until it is called
+func (c *GitHubClient) SetTokenSource(tokens TokenSource) {
+	c.tokens = tokens
+}
+
+// tokenContextKey is the context key for a per-request token
+type tokenContextKey struct{}
+
+// WithToken returns a context whose GitHub API requests are authenticated
+// with token instead of the client's tokens, such as the token of the MCP
+// client a request is made for
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, token)
+}
+
+// TokenFromContext returns the per-request token set by WithToken, if any
+func TokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenContextKey{}).(string)
+	return token, ok && token != ""
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// TransportConfig controls how connections to GitHub are made
+type TransportConfig struct {
+	// ProxyURL is the proxy requests are sent through. When empty the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables apply.
+	ProxyURL string
+	// NoProxy lists hosts reached without ProxyURL: a host name also
+	// matching its subdomains, a leading dot for subdomains only, an IP
+	// address, a CIDR range or * for every host
+	NoProxy []string
+	// CAFile is a PEM bundle of root CAs trusted in addition to the system
+	// roots, such as that of a GitHub Enterprise Server or an inspecting proxy
+	CAFile string
+	// InsecureSkipVerify disables verifying GitHub's certificate
+	InsecureSkipVerify bool
+}
+
+// NewTransport returns an HTTP transport configured by cfg
+func NewTransport(cfg TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return nil, errors.Validation("invalid proxy URL").WithContext("url", proxyURL.Redacted())
+		}
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			if bypassProxy(req.URL.Hostname(), cfg.NoProxy) {
+				return nil, nil
+			}
+			return proxyURL, nil
+		}
+	}
+
+	if cfg.CAFile != "" || cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+		}
+	}
+	if cfg.CAFile != "" {
+		bundle, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrorTypeValidation, "failed to read CA bundle")
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(bundle) {
+			return nil, errors.Validation("CA bundle contains no valid certificates").WithContext("file", cfg.CAFile)
+		}
+		transport.TLSClientConfig.RootCAs = roots
+	}
+
+	return transport, nil
+}
+
+// SetTransport makes the client connect to GitHub as configured by cfg. It
+// fails once SetHTTPClient replaced the client with one that is not an
+// *http.Client, whose transport cannot be set.
+func (c *GitHubClient) SetTransport(cfg TransportConfig) error {
+	httpClient, ok := c.httpClient.(*http.Client)
+	if !ok {
+		return errors.Validation("cannot set the transport of a replaced HTTP client; configure its transport directly")
+	}
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		return err
+	}
+	httpClient.Transport = transport
+	return nil
+}
+
+// bypassProxy reports whether host matches an entry of noProxy
+func bypassProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+		case entry == "*":
+			return true
+		case strings.HasPrefix(entry, "."):
+			if strings.HasSuffix(host, entry) {
+				return true
+			}
+		case ip != nil:
+			if _, network, err := net.ParseCIDR(entry); err == nil && network.Contains(ip) {
+				return true
+			}
+			if entryIP := net.ParseIP(entry); entryIP != nil && entryIP.Equal(ip) {
+				return true
+			}
+		case host == entry || strings.HasSuffix(host, "."+entry):
+			return true
+		}
+	}
+	return false
+}
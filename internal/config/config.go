@@ -1,94 +1,339 @@
 package config
 
 import (
+	"flag"
 	"fmt"
 	"os"
-	"strconv"
+	"reflect"
 	"strings"
 )
 
+// DefaultMaxRequestSize is the default maximum MCP request body size in bytes
+const DefaultMaxRequestSize = 1 << 20
+
+// MaxSSEDrainPeriod bounds the SSE drain period so the HTTP server still has
+// time to shut down within its stop timeout
+const MaxSSEDrainPeriod = 15
+
 // Config holds all configuration for the GitHub MCP server
 type Config struct {
 	// Server configuration
 	Port int    `json:"port"`
 	Host string `json:"host"`
 
+	// Transports lists the transports to serve MCP on ("http", "stdio"); empty means HTTP only
+	Transports []string `json:"transports"`
+
+	// TLS configuration
+	TLSCertFile     string `json:"tls_cert_file"`
+	TLSKeyFile      string `json:"tls_key_file"`
+	TLSClientCAFile string `json:"tls_client_ca_file"`
+
+	// MCP endpoint authentication
+	MCPAuthTokens []string `json:"-"` // Don't serialize the tokens
+
+	// Admin API authentication; the admin endpoints are disabled without tokens
+	AdminTokens []string `json:"-"` // Don't serialize the tokens
+
+	// OAuth 2.1 resource server configuration
+	OAuthIssuer         string   `json:"oauth_issuer"`
+	OAuthJWKSURL        string   `json:"oauth_jwks_url"`
+	OAuthResource       string   `json:"oauth_resource"`
+	OAuthRequiredScopes []string `json:"oauth_required_scopes"`
+
 	// GitHub API configuration
-	GitHubToken string `json:"-"` // Don't serialize the token
+	GitHubToken      string   `json:"-"` // Don't serialize the token
+	GitHubAPIVersion string   `json:"github_api_version"`
+	GitHubMediaTypes []string `json:"github_media_types"`
+
+	// Connections to GitHub: an explicit proxy, hosts bypassing it, extra
+	// root CAs and, only when opted in, no certificate verification
+	GitHubProxyURL              string   `json:"-"` // May carry credentials
+	GitHubNoProxy               []string `json:"github_no_proxy"`
+	GitHubCAFile                string   `json:"github_ca_file"`
+	GitHubTLSInsecureSkipVerify bool     `json:"github_tls_insecure_skip_verify"`
+
+	// TokenPassthrough lets MCP clients send their own GitHub token with a
+	// request to /mcp/request, which is used instead of the server's
+	TokenPassthrough bool `json:"token_passthrough"`
+
+	// GitHubTokenFile holds the token instead of GitHubToken, and is read
+	// again when it changes
+	GitHubTokenFile string `json:"github_token_file"`
+
+	// GitHubTokens are further tokens requests rotate between
+	GitHubTokens []string `json:"-"` // Don't serialize the tokens
+
+	// GitHub App authentication, used instead of the token when an app ID is set
+	GitHubAppID             int64  `json:"github_app_id"`
+	GitHubAppPrivateKey     string `json:"-"` // PEM encoded; don't serialize the key
+	GitHubAppPrivateKeyFile string `json:"github_app_private_key_file"`
+	GitHubAppInstallationID int64  `json:"github_app_installation_id"`
+	// GitHubAppCommitAsBot authors the commits of file changes as the
+	// app's bot user
+	GitHubAppCommitAsBot bool `json:"github_app_commit_as_bot"`
+
+	// OAuth device flow, run at startup when no token is configured and a
+	// client ID is set; the token obtained is kept in GitHubTokenStore
+	GitHubOAuthClientID        string   `json:"github_oauth_client_id"`
+	GitHubOAuthScopes          []string `json:"github_oauth_scopes"`
+	GitHubTokenStore           string   `json:"github_token_store"`
+	GitHubTokenStorePassphrase string   `json:"-"` // Don't serialize the passphrase
 
 	// Logging configuration
 	LogLevel  string `json:"log_level"`
 	LogFormat string `json:"log_format"`
 
-	// Cache configuration
-	CacheTTL int `json:"cache_ttl"`
+	// PprofAddr is the address serving net/http/pprof endpoints; empty disables them
+	PprofAddr string `json:"pprof_addr"`
+
+	// Tracing configuration; the OTLP exporter reads the standard OTEL_* variables
+	TracingEnabled bool `json:"tracing_enabled"`
+
+	// Cache configuration; ResponseCacheSize is the number of GitHub GET
+	// responses revalidated with ETags, zero disabling the cache
+	CacheTTL          int `json:"cache_ttl"`
+	PrefetchInterval  int `json:"prefetch_interval"`
+	ResponseCacheSize int `json:"response_cache_size"`
+
+	// SubscriptionInterval is how often subscribed resources are polled for
+	// changes, in seconds; zero disables resource subscriptions
+	SubscriptionInterval int `json:"subscription_interval"`
+
+	// ListPageSize is the most entries a tools/list or resources/list
+	// response holds; zero returns every entry at once
+	ListPageSize int `json:"list_page_size"`
+
+	// GitHub request retries; RetryMaxDelay is in seconds
+	RetryMaxAttempts int `json:"retry_max_attempts"`
+	RetryMaxDelay    int `json:"retry_max_delay"`
+
+	// Timeouts in seconds, zero disabling them: of a GitHub request, and of
+	// a tool call by default and by tool or toolset name
+	GitHubTimeout int            `json:"github_timeout"`
+	ToolTimeout   int            `json:"tool_timeout"`
+	ToolTimeouts  map[string]int `json:"tool_timeouts"`
+
+	// ToolPrefix is prepended to the names tools are served under, and
+	// ToolAliases map tool names to the names they are served under instead
+	ToolPrefix  string            `json:"tool_prefix"`
+	ToolAliases map[string]string `json:"tool_aliases"`
+
+	// Rate limit budget kept in reserve; zero floor disables it and
+	// RateLimitMaxWait is in seconds
+	RateLimitFloor   int `json:"rate_limit_floor"`
+	RateLimitMaxWait int `json:"rate_limit_max_wait"`
+	// RateLimitWarnings are the percentages of the rate limit budget left
+	// at which clients are notified
+	RateLimitWarnings []int `json:"rate_limit_warnings"`
+
+	// GitHubAPILogSamplePercent is the percentage of GitHub API calls logged
+	// at INFO; the others are logged at DEBUG
+	GitHubAPILogSamplePercent int `json:"github_api_log_sample_percent"`
+
+	// FetchAllMaxPages caps the pages list tools fetch when called with fetch_all
+	FetchAllMaxPages int `json:"fetch_all_max_pages"`
+
+	// MaxResultSize limits the bytes of list tool items; zero disables it
+	MaxResultSize int `json:"max_result_size"`
 
 	// Performance configuration
-	MaxConcurrentRequests int `json:"max_concurrent_requests"`
+	MaxConcurrentRequests int   `json:"max_concurrent_requests"`
+	MaxRequestSize        int64 `json:"max_request_size"`
+
+	// LoadShedding rejects uncached tool calls while GitHub is unavailable
+	LoadShedding bool `json:"load_shedding"`
+
+	// Per-session tool call limits; zero disables the limit or lets any number queue
+	SessionMaxConcurrentTools int `json:"session_max_concurrent_tools"`
+	SessionMaxQueuedTools     int `json:"session_max_queued_tools"`
+
+	// SessionIdleTimeout is how long, in seconds, an HTTP session is kept
+	// without requests; zero keeps sessions until clients end them
+	SessionIdleTimeout int `json:"session_idle_timeout"`
+	// MaxSessions bounds the number of open HTTP sessions; zero is unbounded
+	MaxSessions int `json:"max_sessions"`
+
+	// Streaming configuration; SSEDrainPeriod is in seconds
+	SSEReplayBufferSize int `json:"sse_replay_buffer_size"`
+	SSEDrainPeriod      int `json:"sse_drain_period"`
+
+	// SSE heartbeats; SSEHeartbeatInterval is in seconds and zero disables them
+	SSEHeartbeatInterval int    `json:"sse_heartbeat_interval"`
+	SSEHeartbeatFormat   string `json:"sse_heartbeat_format"`
+
+	// Pub/sub fan-out of stream events between replicas; empty URL disables it
+	PubSubURL     string `json:"-"` // May carry a password
+	PubSubChannel string `json:"pubsub_channel"`
+
+	// Shared store configuration lets replicas share cached GitHub responses
+	// and the rate limit budget
+	SharedStoreURL    string `json:"-"` // May carry a password
+	SharedStorePrefix string `json:"shared_store_prefix"`
+
+	// Response compression configuration
+	CompressionEnabled bool `json:"compression_enabled"`
+
+	// Tool argument configuration
+	StrictArguments bool `json:"strict_arguments"`
+
+	// Server metadata reported to clients; ServerInstructions is a
+	// text/template, empty for the defaults
+	ServerName         string `json:"server_name"`
+	ServerInstructions string `json:"server_instructions"`
+
+	// DefaultOrg and DefaultRepo ("owner/repo") fill in the owner, org and
+	// repo arguments tool calls lack when the client declares no roots
+	DefaultOrg  string `json:"default_org"`
+	DefaultRepo string `json:"default_repo"`
+
+	// Tool result configuration
+	Locale            string `json:"locale"`
+	ExecutionMetadata bool   `json:"execution_metadata"`
+
+	// Safe delete mode captures the state of objects removed by destructive tools
+	SafeDelete      bool   `json:"safe_delete"`
+	DeletionLogFile string `json:"deletion_log_file"`
+
+	// PolicyFile is a YAML file of the rules deciding whether tool calls
+	// may run
+	PolicyFile string `json:"policy_file"`
+
+	// AuditLog is a file audit records of tool calls are appended to, or an
+	// http(s) URL they are POSTed to
+	AuditLog string `json:"audit_log"`
+
+	// APIAllowlist holds the "METHOD /path/pattern" rules of the requests
+	// github_api_request may make; the tool is offered only when set
+	APIAllowlist []string `json:"api_allowlist"`
+
+	// Owners and owner/repo patterns requests may or may not touch
+	AllowedOrgs  []string `json:"allowed_orgs"`
+	DeniedOrgs   []string `json:"denied_orgs"`
+	AllowedRepos []string `json:"allowed_repos"`
+	DeniedRepos  []string `json:"denied_repos"`
+
+	// Dry run mode describes the requests of tools that change data instead of sending them
+	DryRun bool `json:"dry_run"`
+
+	// ReadOnly removes the tools that change data
+	ReadOnly bool `json:"read_only"`
+
+	// ConfirmDestructive makes destructive tools ask clients supporting
+	// elicitation for confirmation before running, and rejects their calls
+	// from other clients
+	ConfirmDestructive bool `json:"confirm_destructive"`
 }
 
-// Load loads configuration from environment variables with sensible defaults
-func Load() (*Config, error) {
-	cfg := &Config{
+// defaults returns the configuration used when no source sets an option
+func defaults() *Config {
+	return &Config{
 		Port:                  8080,
 		Host:                  "0.0.0.0",
 		LogLevel:              "INFO",
 		LogFormat:             "json",
 		CacheTTL:              60,
+		ResponseCacheSize:     1000,
+		RetryMaxAttempts:      3,
+		RetryMaxDelay:         30,
+		GitHubTimeout:         30,
+		SubscriptionInterval:  60,
+		ListPageSize:          100,
+		FetchAllMaxPages:      10,
+		MaxResultSize:         100 * 1024,
 		MaxConcurrentRequests: 100,
+		MaxRequestSize:        DefaultMaxRequestSize,
+		LoadShedding:          true,
+		SSEReplayBufferSize:   1000,
+		SSEDrainPeriod:        10,
+		SSEHeartbeatInterval:  30,
+		SSEHeartbeatFormat:    "event",
+		PubSubChannel:         "github-mcp:stream",
+		SharedStorePrefix:     "github-mcp:",
+		CompressionEnabled:    true,
+		Locale:                "en",
+		Transports:            []string{"http"},
+		GitHubAPIVersion:      "2022-11-28",
+		GitHubOAuthScopes:     []string{"repo", "read:org"},
+		ConfirmDestructive:    true,
+		SessionIdleTimeout:    3600,
+		MaxSessions:           10000,
+		RateLimitWarnings:     []int{20, 10, 5},
 	}
+}
 
-	// Load GitHub token (required)
-	cfg.GitHubToken = os.Getenv("GITHUB_PERSONAL_ACCESS_TOKEN")
-	if cfg.GitHubToken == "" {
-		return nil, fmt.Errorf("GITHUB_PERSONAL_ACCESS_TOKEN environment variable is required")
-	}
+// Load loads configuration from, in increasing order of precedence, built-in
+// defaults, an optional YAML config file, environment variables and the
+// command-line flags in args. The config file is named by the --config flag
+// or the CONFIG_FILE environment variable. flag.ErrHelp is returned when
+// args ask for usage.
+func Load(args []string) (*Config, error) {
+	fs := flag.NewFlagSet("github-mcp", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "Path to a YAML config file (env CONFIG_FILE)")
 
-	// Load optional configuration
-	if port := os.Getenv("PORT"); port != "" {
-		if p, err := strconv.Atoi(port); err == nil && p > 0 && p <= 65535 {
-			cfg.Port = p
+	type flagValue struct {
+		opt   option
+		value string
+	}
+	var flagValues []flagValue
+	for _, opt := range options {
+		if opt.secret {
+			continue
+		}
+		opt := opt
+		usage := fmt.Sprintf("%s (env %s)", opt.usage, opt.env)
+		record := func(value string) error {
+			flagValues = append(flagValues, flagValue{opt: opt, value: value})
+			return nil
+		}
+		if opt.boolean {
+			fs.BoolFunc(opt.flagName(), usage, record)
 		} else {
-			return nil, fmt.Errorf("invalid PORT value: %s", port)
+			fs.Func(opt.flagName(), usage, record)
 		}
 	}
 
-	if host := os.Getenv("HOST"); host != "" {
-		cfg.Host = host
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument: %s", fs.Arg(0))
 	}
 
-	if logLevel := os.Getenv("LOG_LEVEL"); logLevel != "" {
-		logLevel = strings.ToUpper(logLevel)
-		if isValidLogLevel(logLevel) {
-			cfg.LogLevel = logLevel
-		} else {
-			return nil, fmt.Errorf("invalid LOG_LEVEL value: %s (must be DEBUG, INFO, WARN, or ERROR)", logLevel)
+	cfg := defaults()
+
+	if *configFile != "" {
+		if err := loadFile(cfg, *configFile); err != nil {
+			return nil, err
 		}
 	}
 
-	if logFormat := os.Getenv("LOG_FORMAT"); logFormat != "" {
-		logFormat = strings.ToLower(logFormat)
-		if logFormat == "json" || logFormat == "text" {
-			cfg.LogFormat = logFormat
-		} else {
-			return nil, fmt.Errorf("invalid LOG_FORMAT value: %s (must be 'json' or 'text')", logFormat)
+	for _, opt := range options {
+		if value := os.Getenv(opt.env); value != "" {
+			if err := opt.apply(cfg, value, opt.env); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	if cacheTTL := os.Getenv("CACHE_TTL"); cacheTTL != "" {
-		if ttl, err := strconv.Atoi(cacheTTL); err == nil && ttl >= 0 {
-			cfg.CacheTTL = ttl
-		} else {
-			return nil, fmt.Errorf("invalid CACHE_TTL value: %s", cacheTTL)
+	for _, fv := range flagValues {
+		if err := fv.opt.apply(cfg, fv.value, "--"+fv.opt.flagName()); err != nil {
+			return nil, err
 		}
 	}
 
-	if maxReq := os.Getenv("MAX_CONCURRENT_REQUESTS"); maxReq != "" {
-		if max, err := strconv.Atoi(maxReq); err == nil && max > 0 {
-			cfg.MaxConcurrentRequests = max
-		} else {
-			return nil, fmt.Errorf("invalid MAX_CONCURRENT_REQUESTS value: %s", maxReq)
-		}
+	if cfg.GitHubToken == "" && len(cfg.GitHubTokens) > 0 {
+		cfg.GitHubToken = cfg.GitHubTokens[0]
+	}
+
+	// GitHub token (required unless authenticating as a GitHub App or with the device flow)
+	if !cfg.hasCredentials() && !cfg.DeviceFlowEnabled() {
+		return nil, fmt.Errorf("GITHUB_PERSONAL_ACCESS_TOKEN environment variable or github_token config file option is required, unless GITHUB_TOKEN_FILE, GITHUB_APP_ID or GITHUB_OAUTH_CLIENT_ID is set")
+	}
+
+	cfg.OAuthIssuer = strings.TrimSuffix(cfg.OAuthIssuer, "/")
+	if cfg.OAuthIssuer != "" && cfg.OAuthJWKSURL == "" {
+		cfg.OAuthJWKSURL = cfg.OAuthIssuer + "/.well-known/jwks.json"
 	}
 
 	return cfg, nil
@@ -107,8 +352,20 @@ func isValidLogLevel(level string) bool {
 
 // Validate validates the configuration
 func (c *Config) Validate() error {
-	if c.GitHubToken == "" {
-		return fmt.Errorf("GitHub token is required")
+	if !c.hasCredentials() && !c.DeviceFlowEnabled() {
+		return fmt.Errorf("GitHub token, token file, GitHub App ID or OAuth client ID is required")
+	}
+
+	if len(c.TokenPool()) > 0 && c.GitHubTokenFile != "" {
+		return fmt.Errorf("GitHub token or tokens and token file cannot both be set")
+	}
+
+	if c.GitHubAppID != 0 && c.GitHubAppPrivateKey == "" && c.GitHubAppPrivateKeyFile == "" {
+		return fmt.Errorf("GitHub App private key or private key file is required with a GitHub App ID")
+	}
+
+	if c.GitHubAppCommitAsBot && (c.GitHubAppID == 0 || c.GitHubAppInstallationID == 0) {
+		return fmt.Errorf("GitHub App ID and installation ID are required to commit as the app's bot user")
 	}
 
 	if c.Port <= 0 || c.Port > 65535 {
@@ -127,9 +384,203 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cache TTL must be non-negative")
 	}
 
+	if c.PrefetchInterval < 0 {
+		return fmt.Errorf("prefetch interval must be non-negative")
+	}
+
+	if c.ResponseCacheSize < 0 {
+		return fmt.Errorf("response cache size must be non-negative")
+	}
+
+	if c.ListPageSize < 0 {
+		return fmt.Errorf("list page size must be non-negative")
+	}
+
+	if c.RetryMaxAttempts < 0 || c.RetryMaxDelay < 0 {
+		return fmt.Errorf("retry attempts and delay must be non-negative")
+	}
+
+	if c.GitHubTimeout < 0 || c.ToolTimeout < 0 {
+		return fmt.Errorf("timeouts must be non-negative")
+	}
+
+	if c.GitHubAPILogSamplePercent < 0 || c.GitHubAPILogSamplePercent > 100 {
+		return fmt.Errorf("GitHub API log sample percent must be between 0 and 100")
+	}
+
+	if c.MaxResultSize < 0 {
+		return fmt.Errorf("max result size must be non-negative")
+	}
+
+	if c.FetchAllMaxPages < 0 {
+		return fmt.Errorf("fetch all max pages must be non-negative")
+	}
+
+	if c.RateLimitFloor < 0 || c.RateLimitMaxWait < 0 {
+		return fmt.Errorf("rate limit floor and wait must be non-negative")
+	}
+
 	if c.MaxConcurrentRequests <= 0 {
 		return fmt.Errorf("max concurrent requests must be positive")
 	}
 
+	if c.SessionMaxConcurrentTools < 0 || c.SessionMaxQueuedTools < 0 {
+		return fmt.Errorf("session tool limits must be non-negative")
+	}
+
+	if c.SessionIdleTimeout < 0 {
+		return fmt.Errorf("session idle timeout must be non-negative")
+	}
+
+	if c.MaxSessions < 0 {
+		return fmt.Errorf("max sessions must be non-negative")
+	}
+
+	if c.MaxRequestSize < 0 {
+		return fmt.Errorf("max request size must be non-negative")
+	}
+
+	if c.SSEReplayBufferSize < 0 {
+		return fmt.Errorf("SSE replay buffer size must be non-negative")
+	}
+
+	if c.SSEHeartbeatInterval < 0 {
+		return fmt.Errorf("SSE heartbeat interval must be non-negative")
+	}
+
+	if c.SSEHeartbeatFormat != "" && c.SSEHeartbeatFormat != "event" && c.SSEHeartbeatFormat != "comment" {
+		return fmt.Errorf("SSE heartbeat format must be 'event' or 'comment'")
+	}
+
+	if c.SSEDrainPeriod < 0 || c.SSEDrainPeriod > MaxSSEDrainPeriod {
+		return fmt.Errorf("SSE drain period must be between 0 and %d seconds", MaxSSEDrainPeriod)
+	}
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("TLS certificate and key files must be configured together")
+	}
+
+	if c.TLSClientCAFile != "" && !c.TLSEnabled() {
+		return fmt.Errorf("TLS client CA file requires a TLS certificate and key")
+	}
+
+	if c.OAuthEnabled() && c.OAuthJWKSURL == "" {
+		return fmt.Errorf("OAuth JWKS URL is required when an OAuth issuer is configured")
+	}
+	// Without an audience to check, tokens the issuer grants for any other
+	// resource would be accepted
+	if c.OAuthEnabled() && c.OAuthResource == "" {
+		return fmt.Errorf("OAuth resource is required when an OAuth issuer is configured")
+	}
+	if c.OAuthResource != "" && !strings.Contains(c.OAuthResource, "://") {
+		return fmt.Errorf("OAuth resource must be an absolute URI, such as https://mcp.example.com")
+	}
+
+	for _, transport := range c.Transports {
+		if transport != "http" && transport != "stdio" {
+			return fmt.Errorf("invalid transport: %s (must be 'http' or 'stdio')", transport)
+		}
+	}
+
 	return nil
 }
+
+// HTTPEnabled reports whether the HTTP/SSE endpoints should be served
+func (c *Config) HTTPEnabled() bool {
+	return len(c.Transports) == 0 || c.hasTransport("http")
+}
+
+// StdioEnabled reports whether MCP should be served on stdin and stdout
+func (c *Config) StdioEnabled() bool {
+	return c.hasTransport("stdio")
+}
+
+// EnabledTransports returns the transports MCP is served on
+func (c *Config) EnabledTransports() []string {
+	var transports []string
+	if c.HTTPEnabled() {
+		transports = append(transports, "http")
+	}
+	if c.StdioEnabled() {
+		transports = append(transports, "stdio")
+	}
+	return transports
+}
+
+// AppPrivateKey returns the PEM encoded GitHub App private key, read from
+// its file when it is not set inline
+func (c *Config) AppPrivateKey() ([]byte, error) {
+	if c.GitHubAppPrivateKey != "" {
+		return []byte(c.GitHubAppPrivateKey), nil
+	}
+	key, err := os.ReadFile(c.GitHubAppPrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+	}
+	return key, nil
+}
+
+// TokenPool returns the GitHub token and the further tokens without
+// duplicates; requests rotate between them when there is more than one
+func (c *Config) TokenPool() []string {
+	var tokens []string
+	seen := make(map[string]bool)
+	for _, token := range append([]string{c.GitHubToken}, c.GitHubTokens...) {
+		if token != "" && !seen[token] {
+			tokens = append(tokens, token)
+			seen[token] = true
+		}
+	}
+	return tokens
+}
+
+// DeviceFlowEnabled reports whether a GitHub token is obtained with the OAuth
+// device flow at startup
+func (c *Config) DeviceFlowEnabled() bool {
+	return !c.hasCredentials() && c.GitHubOAuthClientID != ""
+}
+
+// hasCredentials reports whether a GitHub token, token file or GitHub App is
+// configured
+func (c *Config) hasCredentials() bool {
+	return c.GitHubToken != "" || c.GitHubTokenFile != "" || c.GitHubAppID != 0
+}
+
+// hasTransport reports whether a transport is configured
+func (c *Config) hasTransport(name string) bool {
+	for _, transport := range c.Transports {
+		if transport == name {
+			return true
+		}
+	}
+	return false
+}
+
+// OAuthEnabled reports whether MCP endpoints accept OAuth access tokens
+func (c *Config) OAuthEnabled() bool {
+	return c.OAuthIssuer != ""
+}
+
+// TLSEnabled reports whether the server should serve HTTPS
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// Changed returns the names of the settings that differ between c and other,
+// using their JSON names where they have one
+func (c *Config) Changed(other *Config) []string {
+	var changed []string
+	a, b := reflect.ValueOf(c).Elem(), reflect.ValueOf(other).Elem()
+	for i := 0; i < a.NumField(); i++ {
+		if reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			continue
+		}
+		field := a.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+		changed = append(changed, name)
+	}
+	return changed
+}
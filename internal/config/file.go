@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadFile applies the options set in a YAML config file. Keys are the option
+// names in snake_case, such as log_level; list options accept either a YAML
+// sequence or a comma-separated string.
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		opt, ok := lookupOption(key)
+		if !ok {
+			return fmt.Errorf("unknown option %q in config file %s", key, path)
+		}
+
+		value, err := fileValue(values[key])
+		if err != nil {
+			return fmt.Errorf("invalid %s value in config file %s: %w", key, path, err)
+		}
+		if value == "" {
+			continue
+		}
+		if err := opt.apply(cfg, value, key); err != nil {
+			return fmt.Errorf("%w in config file %s", err, path)
+		}
+	}
+
+	return nil
+}
+
+// fileValue converts a YAML scalar or sequence of scalars to the string form
+// accepted by environment variables and flags
+func fileValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := fileValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	case map[string]interface{}:
+		return "", fmt.Errorf("expected a value or list, got a mapping")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
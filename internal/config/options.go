@@ -0,0 +1,404 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errInvalidValue reports a value that cannot be parsed for an option
+var errInvalidValue = errors.New("invalid value")
+
+// option is a setting that can be read from the config file, an environment
+// variable and a command-line flag. The config file key is key, and the flag
+// name is key with dashes instead of underscores.
+type option struct {
+	key   string
+	env   string
+	usage string
+	// secret options are not accepted as flags, which other local users could
+	// read from the process list
+	secret bool
+	// boolean options may be given as flags without a value
+	boolean bool
+	set     func(cfg *Config, value string) error
+}
+
+// flagName returns the command-line flag of the option
+func (o option) flagName() string {
+	return strings.ReplaceAll(o.key, "_", "-")
+}
+
+// apply parses value into cfg, naming source in the error if it is invalid
+func (o option) apply(cfg *Config, value, source string) error {
+	if err := o.set(cfg, value); err != nil {
+		if errors.Is(err, errInvalidValue) {
+			return fmt.Errorf("invalid %s value: %s", source, value)
+		}
+		return fmt.Errorf("invalid %s value: %s (%v)", source, value, err)
+	}
+	return nil
+}
+
+// options lists every configuration setting
+var options = []option{
+	{key: "github_token", env: "GITHUB_PERSONAL_ACCESS_TOKEN", usage: "GitHub Personal Access Token", secret: true,
+		set: func(c *Config, v string) error { c.GitHubToken = v; return nil }},
+	{key: "github_token_file", env: "GITHUB_TOKEN_FILE", usage: "File holding the GitHub token, read again when it changes",
+		set: func(c *Config, v string) error { c.GitHubTokenFile = v; return nil }},
+	{key: "github_tokens", env: "GITHUB_PERSONAL_ACCESS_TOKENS", usage: "Comma-separated GitHub tokens requests rotate between", secret: true,
+		set: func(c *Config, v string) error { c.GitHubTokens = splitList(v, ","); return nil }},
+	{key: "token_passthrough", env: "TOKEN_PASSTHROUGH", usage: "Use the GitHub token sent by an MCP client in X-GitHub-Token for its requests", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.TokenPassthrough, v) }},
+	{key: "github_app_id", env: "GITHUB_APP_ID", usage: "GitHub App ID; authenticates as the app instead of with a token",
+		set: func(c *Config, v string) error { return setInt64(&c.GitHubAppID, v) }},
+	{key: "github_app_private_key", env: "GITHUB_APP_PRIVATE_KEY", usage: "PEM encoded GitHub App private key", secret: true,
+		set: func(c *Config, v string) error { c.GitHubAppPrivateKey = v; return nil }},
+	{key: "github_app_private_key_file", env: "GITHUB_APP_PRIVATE_KEY_FILE", usage: "Path to the PEM encoded GitHub App private key",
+		set: func(c *Config, v string) error { c.GitHubAppPrivateKeyFile = v; return nil }},
+	{key: "github_app_installation_id", env: "GITHUB_APP_INSTALLATION_ID", usage: "GitHub App installation used for requests naming no owner with an installation",
+		set: func(c *Config, v string) error { return setInt64(&c.GitHubAppInstallationID, v) }},
+	{key: "github_app_commit_as_bot", env: "GITHUB_APP_COMMIT_AS_BOT", usage: "Author the commits of file changes as the GitHub App's bot user", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.GitHubAppCommitAsBot, v) }},
+	{key: "github_oauth_client_id", env: "GITHUB_OAUTH_CLIENT_ID", usage: "OAuth client ID used to obtain a token with the device flow when none is configured",
+		set: func(c *Config, v string) error { c.GitHubOAuthClientID = v; return nil }},
+	{key: "github_oauth_scopes", env: "GITHUB_OAUTH_SCOPES", usage: "Comma-separated scopes requested by the device flow",
+		set: func(c *Config, v string) error { c.GitHubOAuthScopes = splitList(v, ","); return nil }},
+	{key: "github_token_store", env: "GITHUB_TOKEN_STORE", usage: "File keeping the token obtained with the device flow across restarts",
+		set: func(c *Config, v string) error { c.GitHubTokenStore = v; return nil }},
+	{key: "github_token_store_passphrase", env: "GITHUB_TOKEN_STORE_PASSPHRASE", usage: "Passphrase encrypting the token store", secret: true,
+		set: func(c *Config, v string) error { c.GitHubTokenStorePassphrase = v; return nil }},
+	{key: "github_api_version", env: "GITHUB_API_VERSION", usage: "GitHub REST API version sent as X-GitHub-Api-Version",
+		set: func(c *Config, v string) error {
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return errors.New("must be a date such as 2022-11-28")
+			}
+			c.GitHubAPIVersion = v
+			return nil
+		}},
+	{key: "github_proxy_url", env: "GITHUB_PROXY_URL", usage: "Proxy GitHub requests are sent through, instead of HTTPS_PROXY", secret: true,
+		set: func(c *Config, v string) error {
+			if u, err := url.Parse(v); err != nil || u.Host == "" {
+				return errors.New("must be a URL such as http://proxy:3128")
+			}
+			c.GitHubProxyURL = v
+			return nil
+		}},
+	{key: "github_no_proxy", env: "GITHUB_NO_PROXY", usage: "Comma-separated hosts, domains and CIDR ranges reached without GITHUB_PROXY_URL",
+		set: func(c *Config, v string) error { c.GitHubNoProxy = splitList(v, ","); return nil }},
+	{key: "github_ca_file", env: "GITHUB_CA_FILE", usage: "PEM bundle of root CAs trusted for GitHub in addition to the system roots",
+		set: func(c *Config, v string) error { c.GitHubCAFile = v; return nil }},
+	{key: "github_tls_insecure_skip_verify", env: "GITHUB_TLS_INSECURE_SKIP_VERIFY", usage: "Do not verify GitHub's TLS certificate (insecure)", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.GitHubTLSInsecureSkipVerify, v) }},
+	{key: "github_media_types", env: "GITHUB_MEDIA_TYPES", usage: "Comma-separated media types accepted by every GitHub request in addition to application/vnd.github+json",
+		set: func(c *Config, v string) error {
+			mediaTypes := splitList(v, ",")
+			for _, mediaType := range mediaTypes {
+				if _, _, err := mime.ParseMediaType(mediaType); err != nil {
+					return fmt.Errorf("%q is not a media type", mediaType)
+				}
+			}
+			c.GitHubMediaTypes = mediaTypes
+			return nil
+		}},
+	{key: "port", env: "PORT", usage: "Server port",
+		set: func(c *Config, v string) error { return setInt(&c.Port, v, 1, 65535) }},
+	{key: "host", env: "HOST", usage: "Server host",
+		set: func(c *Config, v string) error { c.Host = v; return nil }},
+	{key: "transports", env: "TRANSPORTS", usage: "Comma-separated transports to serve MCP on: http, stdio",
+		set: func(c *Config, v string) error {
+			c.Transports = splitList(strings.ToLower(v), ",")
+			return nil
+		}},
+	{key: "tls_cert_file", env: "TLS_CERT_FILE", usage: "Server certificate (PEM)",
+		set: func(c *Config, v string) error { c.TLSCertFile = v; return nil }},
+	{key: "tls_key_file", env: "TLS_KEY_FILE", usage: "Server private key (PEM)",
+		set: func(c *Config, v string) error { c.TLSKeyFile = v; return nil }},
+	{key: "tls_client_ca_file", env: "TLS_CLIENT_CA_FILE", usage: "CA bundle (PEM) used to verify client certificates",
+		set: func(c *Config, v string) error { c.TLSClientCAFile = v; return nil }},
+	{key: "mcp_auth_tokens", env: "MCP_AUTH_TOKENS", usage: "Comma-separated bearer tokens required on /mcp/*", secret: true,
+		set: func(c *Config, v string) error { c.MCPAuthTokens = splitList(v, ","); return nil }},
+	{key: "admin_tokens", env: "ADMIN_TOKENS", usage: "Comma-separated bearer tokens required on /admin/*; enables the admin API", secret: true,
+		set: func(c *Config, v string) error { c.AdminTokens = splitList(v, ","); return nil }},
+	{key: "oauth_issuer", env: "OAUTH_ISSUER", usage: "Trusted OAuth 2.1 authorization server",
+		set: func(c *Config, v string) error { c.OAuthIssuer = v; return nil }},
+	{key: "oauth_jwks_url", env: "OAUTH_JWKS_URL", usage: "JWKS document used to verify access tokens",
+		set: func(c *Config, v string) error { c.OAuthJWKSURL = v; return nil }},
+	{key: "oauth_resource", env: "OAUTH_RESOURCE", usage: "Canonical resource URI of this server",
+		set: func(c *Config, v string) error { c.OAuthResource = v; return nil }},
+	{key: "oauth_required_scopes", env: "OAUTH_REQUIRED_SCOPES", usage: "Space- or comma-separated scopes every access token must carry",
+		set: func(c *Config, v string) error {
+			c.OAuthRequiredScopes = strings.Fields(strings.ReplaceAll(v, ",", " "))
+			return nil
+		}},
+	{key: "log_level", env: "LOG_LEVEL", usage: "Log level (DEBUG, INFO, WARN, ERROR)",
+		set: func(c *Config, v string) error {
+			if v = strings.ToUpper(v); !isValidLogLevel(v) {
+				return errors.New("must be DEBUG, INFO, WARN, or ERROR")
+			}
+			c.LogLevel = v
+			return nil
+		}},
+	{key: "log_format", env: "LOG_FORMAT", usage: "Log format (json, text)",
+		set: func(c *Config, v string) error {
+			if v = strings.ToLower(v); v != "json" && v != "text" {
+				return errors.New("must be 'json' or 'text'")
+			}
+			c.LogFormat = v
+			return nil
+		}},
+	{key: "pprof_addr", env: "PPROF_ADDR", usage: "Address for net/http/pprof debug endpoints",
+		set: func(c *Config, v string) error { c.PprofAddr = v; return nil }},
+	{key: "tracing_enabled", env: "TRACING_ENABLED", usage: "Export OpenTelemetry traces over OTLP/HTTP", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.TracingEnabled, v) }},
+	{key: "cache_ttl", env: "CACHE_TTL", usage: "Cache TTL in seconds",
+		set: func(c *Config, v string) error { return setInt(&c.CacheTTL, v, 0, -1) }},
+	{key: "prefetch_interval", env: "PREFETCH_INTERVAL", usage: "Seconds between background refreshes of hot tool results (0 disables)",
+		set: func(c *Config, v string) error { return setInt(&c.PrefetchInterval, v, 0, -1) }},
+	{key: "subscription_interval", env: "SUBSCRIPTION_INTERVAL", usage: "Seconds between polls of subscribed resources for changes (0 disables resource subscriptions)",
+		set: func(c *Config, v string) error { return setInt(&c.SubscriptionInterval, v, 0, -1) }},
+	{key: "list_page_size", env: "LIST_PAGE_SIZE", usage: "Most entries per tools/list or resources/list page (0 returns every entry at once)",
+		set: func(c *Config, v string) error { return setInt(&c.ListPageSize, v, 0, -1) }},
+	{key: "response_cache_size", env: "RESPONSE_CACHE_SIZE", usage: "GitHub GET responses cached and revalidated with ETags (0 disables)",
+		set: func(c *Config, v string) error { return setInt(&c.ResponseCacheSize, v, 0, -1) }},
+	{key: "retry_max_attempts", env: "RETRY_MAX_ATTEMPTS", usage: "Attempts made for GitHub requests failing transiently (1 disables retries)",
+		set: func(c *Config, v string) error { return setInt(&c.RetryMaxAttempts, v, 1, 10) }},
+	{key: "retry_max_delay", env: "RETRY_MAX_DELAY", usage: "Maximum seconds to wait before retrying a GitHub request",
+		set: func(c *Config, v string) error { return setInt(&c.RetryMaxDelay, v, 0, -1) }},
+	{key: "github_timeout", env: "GITHUB_TIMEOUT", usage: "Seconds a GitHub request may take when its tool call sets no deadline (0 disables)",
+		set: func(c *Config, v string) error { return setInt(&c.GitHubTimeout, v, 0, -1) }},
+	{key: "tool_timeout", env: "TOOL_TIMEOUT", usage: "Seconds a tool call may take, including its GitHub requests and retries (0 disables)",
+		set: func(c *Config, v string) error { return setInt(&c.ToolTimeout, v, 0, -1) }},
+	{key: "tool_timeouts", env: "TOOL_TIMEOUTS", usage: "Comma-separated name=seconds timeouts of tool calls by tool or toolset name, overriding TOOL_TIMEOUT",
+		set: func(c *Config, v string) error {
+			timeouts := make(map[string]int)
+			for _, item := range splitList(v, ",") {
+				name, seconds, ok := strings.Cut(item, "=")
+				name = strings.TrimSpace(name)
+				if !ok || name == "" {
+					return fmt.Errorf("%q is not name=seconds", item)
+				}
+				var timeout int
+				if err := setInt(&timeout, strings.TrimSpace(seconds), 0, -1); err != nil {
+					return fmt.Errorf("%q is not name=seconds", item)
+				}
+				timeouts[name] = timeout
+			}
+			c.ToolTimeouts = timeouts
+			return nil
+		}},
+	{key: "tool_prefix", env: "TOOL_PREFIX", usage: "Prefix of the names tools are served under, such as gh_, avoiding clashes with other MCP servers",
+		set: func(c *Config, v string) error { c.ToolPrefix = v; return nil }},
+	{key: "tool_aliases", env: "TOOL_ALIASES", usage: "Comma-separated tool=alias names tools are served under instead of their prefixed names",
+		set: func(c *Config, v string) error {
+			aliases := make(map[string]string)
+			for _, item := range splitList(v, ",") {
+				name, alias, ok := strings.Cut(item, "=")
+				name, alias = strings.TrimSpace(name), strings.TrimSpace(alias)
+				if !ok || name == "" || alias == "" {
+					return fmt.Errorf("%q is not tool=alias", item)
+				}
+				aliases[name] = alias
+			}
+			c.ToolAliases = aliases
+			return nil
+		}},
+	{key: "rate_limit_floor", env: "RATE_LIMIT_FLOOR", usage: "GitHub rate limit budget kept in reserve; requests below it wait for the reset or fail (0 disables)",
+		set: func(c *Config, v string) error { return setInt(&c.RateLimitFloor, v, 0, -1) }},
+	{key: "rate_limit_max_wait", env: "RATE_LIMIT_MAX_WAIT", usage: "Maximum seconds a request below the rate limit floor waits for the reset",
+		set: func(c *Config, v string) error { return setInt(&c.RateLimitMaxWait, v, 0, -1) }},
+	{key: "rate_limit_warnings", env: "RATE_LIMIT_WARNINGS", usage: "Comma-separated percentages of the GitHub rate limit budget left at which clients are notified (0 disables)",
+		set: func(c *Config, v string) error {
+			var warnings []int
+			for _, item := range splitList(v, ",") {
+				var percent int
+				if err := setInt(&percent, item, 0, 99); err != nil {
+					return err
+				}
+				if percent > 0 {
+					warnings = append(warnings, percent)
+				}
+			}
+			c.RateLimitWarnings = warnings
+			return nil
+		}},
+	{key: "github_api_log_sample_percent", env: "GITHUB_API_LOG_SAMPLE_PERCENT", usage: "Percentage of GitHub API calls logged at INFO rather than DEBUG",
+		set: func(c *Config, v string) error { return setInt(&c.GitHubAPILogSamplePercent, v, 0, 100) }},
+	{key: "fetch_all_max_pages", env: "FETCH_ALL_MAX_PAGES", usage: "Maximum pages list tools fetch when called with fetch_all",
+		set: func(c *Config, v string) error { return setInt(&c.FetchAllMaxPages, v, 1, 100) }},
+	{key: "max_result_size", env: "MAX_RESULT_SIZE", usage: "Maximum bytes of the items of a list tool result; longer lists are truncated with a cursor to continue (0 disables)",
+		set: func(c *Config, v string) error { return setInt(&c.MaxResultSize, v, 0, -1) }},
+	{key: "max_concurrent_requests", env: "MAX_CONCURRENT_REQUESTS", usage: "Maximum MCP requests processed at once",
+		set: func(c *Config, v string) error { return setInt(&c.MaxConcurrentRequests, v, 1, -1) }},
+	{key: "session_max_concurrent_tools", env: "SESSION_MAX_CONCURRENT_TOOLS", usage: "Maximum tool calls one session runs at once (0 disables)",
+		set: func(c *Config, v string) error { return setInt(&c.SessionMaxConcurrentTools, v, 0, -1) }},
+	{key: "session_max_queued_tools", env: "SESSION_MAX_QUEUED_TOOLS", usage: "Maximum tool calls one session may queue before they are rejected (0 is unbounded)",
+		set: func(c *Config, v string) error { return setInt(&c.SessionMaxQueuedTools, v, 0, -1) }},
+	{key: "session_idle_timeout", env: "SESSION_IDLE_TIMEOUT", usage: "Seconds an HTTP session is kept without requests (0 keeps sessions until clients end them)",
+		set: func(c *Config, v string) error { return setInt(&c.SessionIdleTimeout, v, 0, -1) }},
+	{key: "max_sessions", env: "MAX_SESSIONS", usage: "Maximum HTTP sessions open at once; initialize requests beyond it are rejected (0 is unbounded)",
+		set: func(c *Config, v string) error { return setInt(&c.MaxSessions, v, 0, -1) }},
+	{key: "load_shedding", env: "LOAD_SHEDDING", usage: "Reject uncached tool calls while GitHub is unavailable or rate limited", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.LoadShedding, v) }},
+	{key: "max_request_size", env: "MAX_REQUEST_SIZE", usage: "Maximum MCP request body size in bytes",
+		set: func(c *Config, v string) error {
+			size, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || size <= 0 {
+				return errInvalidValue
+			}
+			c.MaxRequestSize = size
+			return nil
+		}},
+	{key: "sse_replay_buffer_size", env: "SSE_REPLAY_BUFFER_SIZE", usage: "Broadcast SSE events retained for replay (0 disables)",
+		set: func(c *Config, v string) error { return setInt(&c.SSEReplayBufferSize, v, 0, -1) }},
+	{key: "sse_heartbeat_interval", env: "SSE_HEARTBEAT_INTERVAL", usage: "Seconds between SSE heartbeats (0 disables)",
+		set: func(c *Config, v string) error { return setInt(&c.SSEHeartbeatInterval, v, 0, -1) }},
+	{key: "sse_heartbeat_format", env: "SSE_HEARTBEAT_FORMAT", usage: "SSE heartbeat format (event, comment)",
+		set: func(c *Config, v string) error {
+			if v = strings.ToLower(v); v != "event" && v != "comment" {
+				return errors.New("must be 'event' or 'comment'")
+			}
+			c.SSEHeartbeatFormat = v
+			return nil
+		}},
+	{key: "sse_drain_period", env: "SSE_DRAIN_PERIOD", usage: "Seconds to wait for in-flight tool calls before closing SSE streams on shutdown",
+		set: func(c *Config, v string) error { return setInt(&c.SSEDrainPeriod, v, 0, MaxSSEDrainPeriod) }},
+	{key: "pubsub_url", env: "PUBSUB_URL", usage: "Redis URL (redis:// or rediss://) used to fan stream events out to other replicas", secret: true,
+		set: func(c *Config, v string) error {
+			if !strings.HasPrefix(v, "redis://") && !strings.HasPrefix(v, "rediss://") {
+				return errors.New("must be a redis:// or rediss:// URL")
+			}
+			c.PubSubURL = v
+			return nil
+		}},
+	{key: "pubsub_channel", env: "PUBSUB_CHANNEL", usage: "Redis channel stream events are published on",
+		set: func(c *Config, v string) error { c.PubSubChannel = v; return nil }},
+	{key: "shared_store_url", env: "SHARED_STORE_URL", usage: "Redis URL (redis:// or rediss://) of the GitHub response cache and rate limit budget shared by replicas", secret: true,
+		set: func(c *Config, v string) error {
+			if !strings.HasPrefix(v, "redis://") && !strings.HasPrefix(v, "rediss://") {
+				return errors.New("must be a redis:// or rediss:// URL")
+			}
+			c.SharedStoreURL = v
+			return nil
+		}},
+	{key: "shared_store_prefix", env: "SHARED_STORE_PREFIX", usage: "Prefix of the keys kept in the shared store",
+		set: func(c *Config, v string) error { c.SharedStorePrefix = v; return nil }},
+	{key: "compression_enabled", env: "COMPRESSION_ENABLED", usage: "Compress JSON and SSE responses", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.CompressionEnabled, v) }},
+	{key: "strict_arguments", env: "STRICT_ARGUMENTS", usage: "Disable coercion of tool arguments", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.StrictArguments, v) }},
+	{key: "server_name", env: "SERVER_NAME", usage: "Name the server reports to MCP clients",
+		set: func(c *Config, v string) error { c.ServerName = v; return nil }},
+	{key: "server_instructions", env: "SERVER_INSTRUCTIONS", usage: "Instructions sent to MCP clients on initialize, a Go template of .ServerName, .Version, .Toolsets, .DefaultOrg and .DefaultRepo",
+		set: func(c *Config, v string) error { c.ServerInstructions = v; return nil }},
+	{key: "default_org", env: "DEFAULT_ORG", usage: "Organization filling in the org and owner arguments tool calls lack",
+		set: func(c *Config, v string) error { c.DefaultOrg = v; return nil }},
+	{key: "default_repo", env: "DEFAULT_REPO", usage: "Repository, as owner/repo, filling in the owner and repo arguments tool calls lack",
+		set: func(c *Config, v string) error {
+			if owner, repo, found := strings.Cut(v, "/"); v != "" && (!found || owner == "" || repo == "" || strings.Contains(repo, "/")) {
+				return errInvalidValue
+			}
+			c.DefaultRepo = v
+			return nil
+		}},
+	{key: "locale", env: "LOCALE", usage: "Language of tool result text (en, es)",
+		set: func(c *Config, v string) error { c.Locale = v; return nil }},
+	{key: "safe_delete", env: "SAFE_DELETE", usage: "Capture the state of objects before destructive tools remove them", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.SafeDelete, v) }},
+	{key: "deletion_log_file", env: "DELETION_LOG_FILE", usage: "File deletion records are appended to as JSON lines in safe delete mode",
+		set: func(c *Config, v string) error { c.DeletionLogFile = v; return nil }},
+	{key: "policy_file", env: "POLICY_FILE", usage: "YAML file of the rules allowing, denying or asking to confirm tool calls",
+		set: func(c *Config, v string) error { c.PolicyFile = v; return nil }},
+	{key: "audit_log", env: "AUDIT_LOG", usage: "File audit records of tool calls are appended to as JSON lines, or http(s) URL they are POSTed to",
+		set: func(c *Config, v string) error { c.AuditLog = v; return nil }},
+	{key: "api_allowlist", env: "API_ALLOWLIST", usage: "Comma-separated \"METHOD /path/pattern\" rules of the GitHub REST API requests github_api_request may make",
+		set: func(c *Config, v string) error { c.APIAllowlist = splitList(v, ","); return nil }},
+	{key: "allowed_orgs", env: "ALLOWED_ORGS", usage: "Comma-separated users and organizations whose repositories tools may touch; with ALLOWED_REPOS, no others",
+		set: func(c *Config, v string) error { c.AllowedOrgs = splitList(v, ","); return nil }},
+	{key: "denied_orgs", env: "DENIED_ORGS", usage: "Comma-separated users and organizations tools may never touch",
+		set: func(c *Config, v string) error { c.DeniedOrgs = splitList(v, ","); return nil }},
+	{key: "allowed_repos", env: "ALLOWED_REPOS", usage: "Comma-separated owner/repo patterns of the repositories tools may touch; with ALLOWED_ORGS, no others",
+		set: func(c *Config, v string) error { return setRepoPatterns(&c.AllowedRepos, v) }},
+	{key: "denied_repos", env: "DENIED_REPOS", usage: "Comma-separated owner/repo patterns of the repositories tools may never touch",
+		set: func(c *Config, v string) error { return setRepoPatterns(&c.DeniedRepos, v) }},
+	{key: "dry_run", env: "DRY_RUN", usage: "Describe the GitHub API requests of tools that change data instead of sending them", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.DryRun, v) }},
+	{key: "read_only", env: "READ_ONLY", usage: "Remove the tools that change data and reject calls to them", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.ReadOnly, v) }},
+	{key: "confirm_destructive", env: "CONFIRM_DESTRUCTIVE", usage: "Ask clients supporting elicitation to confirm calls to destructive tools, rejecting them from other clients", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.ConfirmDestructive, v) }},
+	{key: "execution_metadata", env: "EXECUTION_METADATA", usage: "Report GitHub round trips, retries, cache hits and duration in tool results", boolean: true,
+		set: func(c *Config, v string) error { return setBool(&c.ExecutionMetadata, v) }},
+}
+
+// lookupOption returns the option with the given config file key
+func lookupOption(key string) (option, bool) {
+	for _, opt := range options {
+		if opt.key == key {
+			return opt, true
+		}
+	}
+	return option{}, false
+}
+
+// setInt parses an integer of at least min and, unless max is negative, at most max
+func setInt(dst *int, value string, min, max int) error {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || (max >= 0 && n > max) {
+		return errInvalidValue
+	}
+	*dst = n
+	return nil
+}
+
+// setInt64 parses a positive 64-bit ID
+func setInt64(dst *int64, value string) error {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 {
+		return errInvalidValue
+	}
+	*dst = n
+	return nil
+}
+
+// setRepoPatterns parses comma-separated owner/repo patterns, such as octo-org/*
+func setRepoPatterns(dst *[]string, value string) error {
+	patterns := splitList(value, ",")
+	for _, pattern := range patterns {
+		owner, repo, ok := strings.Cut(pattern, "/")
+		if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+			return errInvalidValue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errInvalidValue
+		}
+	}
+	*dst = patterns
+	return nil
+}
+
+// setBool parses a boolean as accepted by strconv.ParseBool
+func setBool(dst *bool, value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return errInvalidValue
+	}
+	*dst = b
+	return nil
+}
+
+// splitList splits value on sep, dropping surrounding whitespace and empty items
+func splitList(value, sep string) []string {
+	var items []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
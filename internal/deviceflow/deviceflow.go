@@ -0,0 +1,164 @@
+// Package deviceflow obtains GitHub user access tokens with the OAuth device
+// authorization flow, in which the user enters a code shown by the server on
+// github.com instead of creating a personal access token.
+package deviceflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is where the device flow endpoints of github.com live
+	DefaultBaseURL = "https://github.com"
+	// slowDownInterval is added to the polling interval when GitHub asks to slow down
+	slowDownInterval = 5 * time.Second
+)
+
+var (
+	// ErrExpired is returned when the user code expires before it is entered
+	ErrExpired = errors.New("device code expired before authorization")
+	// ErrDenied is returned when the user declines the authorization
+	ErrDenied = errors.New("authorization was denied")
+)
+
+// Code is the verification code the user enters to authorize the device
+type Code struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// Flow runs the device flow for an OAuth or GitHub App client
+type Flow struct {
+	clientID   string
+	scopes     []string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// New creates a flow for the client ID requesting scopes
+func New(clientID string, scopes []string, httpClient *http.Client) *Flow {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Flow{clientID: clientID, scopes: scopes, baseURL: DefaultBaseURL, httpClient: httpClient}
+}
+
+// SetBaseURL sets the GitHub web URL, such as that of a GitHub Enterprise Server
+func (f *Flow) SetBaseURL(baseURL string) {
+	f.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// Start requests a device and user code
+func (f *Flow) Start(ctx context.Context) (*Code, error) {
+	form := url.Values{"client_id": {f.clientID}}
+	if len(f.scopes) > 0 {
+		form.Set("scope", strings.Join(f.scopes, " "))
+	}
+
+	var code struct {
+		Code
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := f.post(ctx, "/login/device/code", form, &code); err != nil {
+		return nil, err
+	}
+	if code.Error != "" {
+		return nil, fmt.Errorf("device flow failed: %s (%s)", code.Error, code.ErrorDescription)
+	}
+	if code.DeviceCode == "" {
+		return nil, fmt.Errorf("device flow failed: no device code returned")
+	}
+	return &code.Code, nil
+}
+
+// Poll waits until the user enters code, returning the access token, or
+// until the code expires, the user declines or ctx is done
+func (f *Flow) Poll(ctx context.Context, code *Code) (string, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = slowDownInterval
+	}
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	form := url.Values{
+		"client_id":   {f.clientID},
+		"device_code": {code.DeviceCode},
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var token struct {
+			AccessToken      string `json:"access_token"`
+			Interval         int    `json:"interval"`
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if err := f.post(ctx, "/login/oauth/access_token", form, &token); err != nil {
+			return "", err
+		}
+
+		switch token.Error {
+		case "":
+			if token.AccessToken == "" {
+				return "", fmt.Errorf("device flow failed: no access token returned")
+			}
+			return token.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += slowDownInterval
+			if token.Interval > 0 {
+				interval = time.Duration(token.Interval) * time.Second
+			}
+		case "expired_token":
+			return "", ErrExpired
+		case "access_denied":
+			return "", ErrDenied
+		default:
+			return "", fmt.Errorf("device flow failed: %s (%s)", token.Error, token.ErrorDescription)
+		}
+
+		if code.ExpiresIn > 0 && time.Now().After(deadline) {
+			return "", ErrExpired
+		}
+	}
+}
+
+// post sends a form to a device flow endpoint and decodes its JSON response
+func (f *Flow) post(ctx context.Context, path string, form url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create device flow request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("device flow request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("device flow request failed with status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode device flow response: %w", err)
+	}
+	return nil
+}
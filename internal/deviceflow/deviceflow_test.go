@@ -0,0 +1,153 @@
+package deviceflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestServer answers device flow requests, reporting the authorization
+// as pending pending times before answering with final
+func newTestServer(t *testing.T, pending int32, final map[string]string) *httptest.Server {
+	t.Helper()
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("client_id") != "client-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/login/device/code":
+			if r.Form.Get("scope") != "repo read:org" {
+				t.Errorf("Expected the scopes to be requested, got %q", r.Form.Get("scope"))
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"device_code": "device-1", "user_code": "ABCD-1234",
+				"verification_uri": "https://github.com/login/device", "expires_in": 900, "interval": 1,
+			})
+		case "/login/oauth/access_token":
+			if r.Form.Get("device_code") != "device-1" {
+				t.Errorf("Expected the device code to be polled, got %q", r.Form.Get("device_code"))
+			}
+			if polls.Add(1) <= pending {
+				json.NewEncoder(w).Encode(map[string]string{"error": "authorization_pending"})
+				return
+			}
+			json.NewEncoder(w).Encode(final)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFlow(t *testing.T) {
+	tests := []struct {
+		name    string
+		final   map[string]string
+		want    string
+		wantErr error
+	}{
+		{name: "authorized", final: map[string]string{"access_token": "gho_token"}, want: "gho_token"},
+		{name: "denied", final: map[string]string{"error": "access_denied"}, wantErr: ErrDenied},
+		{name: "expired", final: map[string]string{"error": "expired_token"}, wantErr: ErrExpired},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := newTestServer(t, 1, tt.final)
+			flow := New("client-1", []string{"repo", "read:org"}, server.Client())
+			flow.SetBaseURL(server.URL + "/")
+
+			code, err := flow.Start(context.Background())
+			if err != nil {
+				t.Fatalf("Start failed: %v", err)
+			}
+			if code.UserCode != "ABCD-1234" {
+				t.Errorf("Expected user code ABCD-1234, got %q", code.UserCode)
+			}
+
+			token, err := flow.Poll(context.Background(), code)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil || token != tt.want {
+				t.Errorf("Expected token %q, got %q and %v", tt.want, token, err)
+			}
+		})
+	}
+}
+
+func TestFlow_PollCanceled(t *testing.T) {
+	flow := New("client-1", nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := flow.Poll(ctx, &Code{DeviceCode: "device-1", Interval: 1}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected polling to stop when the context is canceled, got %v", err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens", "github")
+
+	if token, err := NewStore(path, "").Load(); err != nil || token != "" {
+		t.Fatalf("Expected no stored token, got %q and %v", token, err)
+	}
+
+	plain := NewStore(path, "")
+	if err := plain.Save("gho_plain"); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if token, err := plain.Load(); err != nil || token != "gho_plain" {
+		t.Errorf("Expected the plain token, got %q and %v", token, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected the token file to be readable only by its owner, got %v and %v", info.Mode(), err)
+	}
+	if _, err := NewStore(path, "secret").Load(); err == nil {
+		t.Error("Expected a plain token to be rejected when a passphrase is set")
+	}
+
+	encrypted := NewStore(path, "secret")
+	if err := encrypted.Save("gho_encrypted"); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "gho_encrypted") {
+		t.Error("Expected the token to be stored encrypted")
+	}
+	if token, err := encrypted.Load(); err != nil || token != "gho_encrypted" {
+		t.Errorf("Expected the encrypted token, got %q and %v", token, err)
+	}
+	if err := encrypted.Save("gho_encrypted"); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	resaved, _ := os.ReadFile(path)
+	salt := func(data []byte) string {
+		return strings.SplitN(strings.TrimPrefix(string(data), encryptedPrefix), ":", 2)[0]
+	}
+	if salt(resaved) == salt(data) {
+		t.Error("Expected every save to use a new salt")
+	}
+	if _, err := NewStore(path, "wrong").Load(); err == nil {
+		t.Error("Expected the wrong passphrase to be rejected")
+	}
+	if _, err := NewStore(path, "").Load(); err == nil {
+		t.Error("Expected an encrypted token to be rejected without a passphrase")
+	}
+}
@@ -0,0 +1,146 @@
+package deviceflow
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// encryptedPrefix marks a stored token encrypted with a key derived from the
+// store's passphrase, followed by the salt of the key and the sealed token
+const encryptedPrefix = "scrypt-aes-gcm:"
+
+// Parameters of the scrypt key derivation, as recommended for interactive
+// logins
+const (
+	scryptN       = 1 << 15
+	scryptR       = 8
+	scryptP       = 1
+	scryptKeySize = 32
+	saltSize      = 16
+)
+
+// Store keeps the token obtained by the flow in a file readable only by its
+// owner, encrypted with AES-GCM when a passphrase is set
+type Store struct {
+	path       string
+	passphrase string
+}
+
+// NewStore creates a store for the file at path; an empty passphrase stores
+// the token in plain text
+func NewStore(path, passphrase string) *Store {
+	return &Store{path: path, passphrase: passphrase}
+}
+
+// Load returns the stored token, or an empty string when none is stored
+func (s *Store) Load() (string, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read stored token: %w", err)
+	}
+
+	content := strings.TrimSpace(string(data))
+	encoded, encrypted := strings.CutPrefix(content, encryptedPrefix)
+	switch {
+	case !encrypted:
+		if s.passphrase != "" {
+			return "", fmt.Errorf("stored token is not encrypted, but a passphrase is set")
+		}
+		return content, nil
+	case s.passphrase == "":
+		return "", fmt.Errorf("stored token is encrypted, but no passphrase is set")
+	}
+
+	encodedSalt, encodedSealed, ok := strings.Cut(encoded, ":")
+	if !ok {
+		return "", fmt.Errorf("stored token is truncated")
+	}
+	salt, err := base64.StdEncoding.DecodeString(encodedSalt)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode stored token: %w", err)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encodedSealed)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode stored token: %w", err)
+	}
+	gcm, err := s.cipher(salt)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("stored token is truncated")
+	}
+	token, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt stored token; is the passphrase correct?")
+	}
+	return string(token), nil
+}
+
+// Save stores token, replacing any stored token
+func (s *Store) Save(token string) error {
+	content := token
+	if s.passphrase != "" {
+		// Every file gets its own salt, so the same passphrase never derives
+		// the same key twice
+		salt := make([]byte, saltSize)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return fmt.Errorf("failed to generate salt: %w", err)
+		}
+		gcm, err := s.cipher(salt)
+		if err != nil {
+			return err
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		content = encryptedPrefix + base64.StdEncoding.EncodeToString(salt) + ":" +
+			base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(token), nil))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create token directory: %w", err)
+	}
+	// Write to a temporary file first so that a crash never leaves a partial token
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content+"\n"), 0o600); err != nil {
+		return fmt.Errorf("failed to store token: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to store token: %w", err)
+	}
+	return nil
+}
+
+// cipher returns the AES-GCM cipher of the key derived from the store's
+// passphrase and salt
+func (s *Store) cipher(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(s.passphrase), salt, scryptN, scryptR, scryptP, scryptKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return gcm, nil
+}
@@ -19,6 +19,9 @@ const (
 	ErrorTypeNotFound ErrorType = "not_found"
 	// ErrorTypeRateLimit represents rate limit errors
 	ErrorTypeRateLimit ErrorType = "rate_limit"
+	// ErrorTypeSecondaryRateLimit represents GitHub secondary rate limit
+	// (abuse detection) errors, which ask clients to slow down
+	ErrorTypeSecondaryRateLimit ErrorType = "secondary_rate_limit"
 	// ErrorTypeInternal represents internal server errors
 	ErrorTypeInternal ErrorType = "internal"
 	// ErrorTypeGitHubAPI represents GitHub API errors
@@ -88,7 +91,7 @@ func getDefaultStatusCode(errorType ErrorType) int {
 		return http.StatusForbidden
 	case ErrorTypeNotFound:
 		return http.StatusNotFound
-	case ErrorTypeRateLimit:
+	case ErrorTypeRateLimit, ErrorTypeSecondaryRateLimit:
 		return http.StatusTooManyRequests
 	case ErrorTypeGitHubAPI:
 		return http.StatusBadGateway
@@ -126,6 +129,11 @@ func RateLimit(message string) *AppError {
 	return New(ErrorTypeRateLimit, message)
 }
 
+// SecondaryRateLimit creates a secondary rate limit error
+func SecondaryRateLimit(message string) *AppError {
+	return New(ErrorTypeSecondaryRateLimit, message)
+}
+
 // Internal creates an internal server error
 func Internal(message string) *AppError {
 	return New(ErrorTypeInternal, message)
@@ -0,0 +1,189 @@
+// Package execstats collects where the time of a single tool call went, such
+// as GitHub API round trips, retries and cache hits, through its context
+package execstats
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Stats accumulates the work done for one tool call. It is safe for
+// concurrent use, and all methods are no-ops on a nil *Stats.
+type Stats struct {
+	mu         sync.Mutex
+	tool       string
+	started    time.Time
+	roundTrips int
+	apiTime    time.Duration
+	retries    int
+	cacheHits  int
+	rateLimit  error
+	budget     *RateLimit
+	endpoints  []string
+}
+
+// Snapshot is a point-in-time copy of Stats
+type Snapshot struct {
+	DurationMs       int64 `json:"durationMs"`
+	GitHubRoundTrips int   `json:"githubRoundTrips"`
+	GitHubTimeMs     int64 `json:"githubTimeMs"`
+	Retries          int   `json:"retries"`
+	CacheHits        int   `json:"cacheHits"`
+	// RateLimit is the budget the last GitHub response reported
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+}
+
+// RateLimit is the rate limit budget a GitHub response reported
+type RateLimit struct {
+	Resource  string    `json:"resource"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// maxEndpoints bounds the distinct endpoints recorded for a tool call
+const maxEndpoints = 100
+
+// contextKey is the context key for the stats of the current tool call
+type contextKey struct{}
+
+// NewContext returns a context collecting stats for a tool call starting now
+func NewContext(ctx context.Context) (context.Context, *Stats) {
+	stats := &Stats{started: time.Now()}
+	return context.WithValue(ctx, contextKey{}, stats), stats
+}
+
+// FromContext returns the stats collected through ctx, or nil
+func FromContext(ctx context.Context) *Stats {
+	stats, _ := ctx.Value(contextKey{}).(*Stats)
+	return stats
+}
+
+// SetTool records the name of the tool being called
+func (s *Stats) SetTool(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tool = name
+}
+
+// Tool returns the name of the tool being called, if set
+func (s *Stats) Tool() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tool
+}
+
+// AddRoundTrip records a GitHub API request that took d
+func (s *Stats) AddRoundTrip(d time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roundTrips++
+	s.apiTime += d
+}
+
+// AddEndpoint records a GitHub API endpoint requested with method, once
+func (s *Stats) AddEndpoint(method, endpoint string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	request := method + " " + endpoint
+	for _, seen := range s.endpoints {
+		if seen == request {
+			return
+		}
+	}
+	if len(s.endpoints) < maxEndpoints {
+		s.endpoints = append(s.endpoints, request)
+	}
+}
+
+// Endpoints returns the distinct "METHOD /endpoint" requests recorded, in
+// the order first made
+func (s *Stats) Endpoints() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.endpoints...)
+}
+
+// AddRetry records a retried GitHub API request
+func (s *Stats) AddRetry() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retries++
+}
+
+// AddCacheHit records a result served from a cache
+func (s *Stats) AddCacheHit() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cacheHits++
+}
+
+// SetRateLimited records the rate limit error a GitHub API request of the
+// tool call failed with
+func (s *Stats) SetRateLimited(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimit = err
+}
+
+// SetRateLimit records the rate limit budget a GitHub response reported
+func (s *Stats) SetRateLimit(budget RateLimit) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.budget = &budget
+}
+
+// RateLimited returns the last rate limit error recorded, or nil
+func (s *Stats) RateLimited() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rateLimit
+}
+
+// Snapshot returns the stats collected so far
+func (s *Stats) Snapshot() Snapshot {
+	if s == nil {
+		return Snapshot{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Snapshot{
+		DurationMs:       time.Since(s.started).Milliseconds(),
+		GitHubRoundTrips: s.roundTrips,
+		GitHubTimeMs:     s.apiTime.Milliseconds(),
+		Retries:          s.retries,
+		CacheHits:        s.cacheHits,
+		RateLimit:        s.budget,
+	}
+}
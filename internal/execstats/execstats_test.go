@@ -0,0 +1,58 @@
+package execstats
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	ctx, stats := NewContext(context.Background())
+	if FromContext(ctx) != stats {
+		t.Fatal("Expected FromContext to return the stats of NewContext")
+	}
+
+	FromContext(ctx).AddRoundTrip(20 * time.Millisecond)
+	FromContext(ctx).AddRoundTrip(30 * time.Millisecond)
+	FromContext(ctx).AddRetry()
+	FromContext(ctx).AddCacheHit()
+	FromContext(ctx).SetRateLimit(RateLimit{Resource: "core", Limit: 5000, Remaining: 4999})
+
+	snapshot := stats.Snapshot()
+	if snapshot.GitHubRoundTrips != 2 || snapshot.GitHubTimeMs != 50 {
+		t.Errorf("Expected 2 round trips taking 50ms, got %d taking %dms", snapshot.GitHubRoundTrips, snapshot.GitHubTimeMs)
+	}
+	if snapshot.Retries != 1 || snapshot.CacheHits != 1 {
+		t.Errorf("Expected 1 retry and 1 cache hit, got %+v", snapshot)
+	}
+	if snapshot.RateLimit == nil || snapshot.RateLimit.Remaining != 4999 {
+		t.Errorf("Expected the last rate limit budget, got %+v", snapshot.RateLimit)
+	}
+
+	stats.AddEndpoint("GET", "/orgs/acme")
+	stats.AddEndpoint("PATCH", "/orgs/acme")
+	stats.AddEndpoint("GET", "/orgs/acme")
+	if endpoints := stats.Endpoints(); len(endpoints) != 2 || endpoints[0] != "GET /orgs/acme" || endpoints[1] != "PATCH /orgs/acme" {
+		t.Errorf("Expected each endpoint once in order, got %v", endpoints)
+	}
+}
+
+func TestStats_NilSafe(t *testing.T) {
+	stats := FromContext(context.Background())
+	if stats != nil {
+		t.Fatal("Expected no stats without NewContext")
+	}
+
+	stats.AddRoundTrip(time.Second)
+	stats.AddRetry()
+	stats.AddCacheHit()
+	stats.SetRateLimited(context.DeadlineExceeded)
+	stats.SetRateLimit(RateLimit{Remaining: 1})
+	stats.AddEndpoint("GET", "/user")
+	if stats.RateLimited() != nil || stats.Endpoints() != nil {
+		t.Error("Expected no rate limit error without NewContext")
+	}
+	if snapshot := stats.Snapshot(); snapshot != (Snapshot{}) {
+		t.Errorf("Expected empty snapshot, got %+v", snapshot)
+	}
+}
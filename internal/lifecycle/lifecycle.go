@@ -0,0 +1,154 @@
+// Package lifecycle starts and stops the server's subsystems in order
+package lifecycle
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+)
+
+// DefaultStopTimeout bounds a subsystem's shutdown when its hook sets no timeout
+const DefaultStopTimeout = 10 * time.Second
+
+// Hook describes how to start and stop a subsystem. OnStart must not block;
+// long-running work is handed to Manager.Go.
+type Hook struct {
+	Name        string
+	OnStart     func(ctx context.Context) error
+	OnStop      func(ctx context.Context) error
+	StopTimeout time.Duration
+}
+
+// Manager starts subsystems in registration order and stops them in reverse
+type Manager struct {
+	logger *logger.Logger
+
+	mu      sync.Mutex
+	hooks   []Hook
+	started []Hook
+
+	done     chan struct{}
+	doneOnce sync.Once
+	err      error
+	wg       sync.WaitGroup
+}
+
+// New creates an empty lifecycle manager
+func New(logger *logger.Logger) *Manager {
+	return &Manager{
+		logger: logger,
+		done:   make(chan struct{}),
+	}
+}
+
+// Append registers a subsystem. Subsystems start in the order they are appended.
+func (m *Manager) Append(hook Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook)
+}
+
+// Start starts all subsystems in order. If one fails, the subsystems already
+// started are stopped and the start error is returned.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	hooks := append([]Hook(nil), m.hooks...)
+	m.mu.Unlock()
+
+	for _, hook := range hooks {
+		if hook.OnStart != nil {
+			m.logger.Debug("Starting subsystem", "subsystem", hook.Name)
+			if err := hook.OnStart(ctx); err != nil {
+				startErr := fmt.Errorf("failed to start %s: %w", hook.Name, err)
+				if stopErr := m.Stop(ctx); stopErr != nil {
+					return stderrors.Join(startErr, stopErr)
+				}
+				return startErr
+			}
+		}
+
+		m.mu.Lock()
+		m.started = append(m.started, hook)
+		m.mu.Unlock()
+	}
+
+	return nil
+}
+
+// Stop stops the started subsystems in reverse order, giving each at most its
+// stop timeout within the deadline of ctx. All subsystems are stopped even if
+// some fail; their errors are returned together.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	started := m.started
+	m.started = nil
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		hook := started[i]
+		if hook.OnStop == nil {
+			continue
+		}
+
+		timeout := hook.StopTimeout
+		if timeout <= 0 {
+			timeout = DefaultStopTimeout
+		}
+		stopCtx, cancel := context.WithTimeout(ctx, timeout)
+
+		m.logger.Debug("Stopping subsystem", "subsystem", hook.Name)
+		if err := hook.OnStop(stopCtx); err != nil {
+			m.logger.Error("Failed to stop subsystem", "subsystem", hook.Name, "error", err)
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", hook.Name, err))
+		}
+		cancel()
+	}
+
+	return stderrors.Join(errs...)
+}
+
+// Go runs long-lived work of a subsystem, such as serving a listener, in the
+// background. When run returns, Done is closed; a non-nil error is reported by Err.
+func (m *Manager) Go(name string, run func() error) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		err := run()
+		if err != nil {
+			m.logger.Error("Subsystem failed", "subsystem", name, "error", err)
+			err = fmt.Errorf("%s: %w", name, err)
+		} else {
+			m.logger.Info("Subsystem finished", "subsystem", name)
+		}
+
+		m.doneOnce.Do(func() {
+			m.mu.Lock()
+			m.err = err
+			m.mu.Unlock()
+			close(m.done)
+		})
+	}()
+}
+
+// Done returns a channel that is closed when the first background run returns
+func (m *Manager) Done() <-chan struct{} {
+	return m.done
+}
+
+// Err returns the error of the first background run to return, if it failed
+func (m *Manager) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+// Wait waits for all background runs to return
+func (m *Manager) Wait() {
+	m.wg.Wait()
+}
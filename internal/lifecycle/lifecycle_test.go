@@ -0,0 +1,115 @@
+package lifecycle
+
+import (
+	"context"
+	stderrors "errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	return New(testLogger)
+}
+
+// recordingHook returns a hook that records its start and stop calls
+func recordingHook(name string, calls *[]string, startErr, stopErr error) Hook {
+	return Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			*calls = append(*calls, "start "+name)
+			return startErr
+		},
+		OnStop: func(ctx context.Context) error {
+			*calls = append(*calls, "stop "+name)
+			return stopErr
+		},
+	}
+}
+
+func TestManagerStartStopOrder(t *testing.T) {
+	m := newTestManager(t)
+	var calls []string
+	m.Append(recordingHook("a", &calls, nil, nil))
+	m.Append(recordingHook("b", &calls, nil, stderrors.New("b failed")))
+	m.Append(recordingHook("c", &calls, nil, stderrors.New("c failed")))
+
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Unexpected start error: %v", err)
+	}
+	err := m.Stop(context.Background())
+
+	expected := []string{"start a", "start b", "start c", "stop c", "stop b", "stop a"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+	if err == nil || !strings.Contains(err.Error(), "b failed") || !strings.Contains(err.Error(), "c failed") {
+		t.Errorf("Expected aggregated stop errors, got %v", err)
+	}
+}
+
+func TestManagerStartFailureStopsStarted(t *testing.T) {
+	m := newTestManager(t)
+	var calls []string
+	m.Append(recordingHook("a", &calls, nil, nil))
+	m.Append(recordingHook("b", &calls, stderrors.New("bind failed"), nil))
+	m.Append(recordingHook("c", &calls, nil, nil))
+
+	err := m.Start(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to start b") {
+		t.Fatalf("Expected start error for b, got %v", err)
+	}
+
+	expected := []string{"start a", "start b", "stop a"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+}
+
+func TestManagerStopTimeout(t *testing.T) {
+	m := newTestManager(t)
+	m.Append(Hook{
+		Name: "slow",
+		OnStop: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		StopTimeout: 10 * time.Millisecond,
+	})
+
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Unexpected start error: %v", err)
+	}
+
+	start := time.Now()
+	err := m.Stop(context.Background())
+	if !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("Expected stop to be bounded by the hook timeout, took %v", time.Since(start))
+	}
+}
+
+func TestManagerGo(t *testing.T) {
+	m := newTestManager(t)
+	m.Go("listener", func() error { return stderrors.New("accept failed") })
+
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected Done to be closed when a background run returns")
+	}
+	if err := m.Err(); err == nil || !strings.Contains(err.Error(), "listener: accept failed") {
+		t.Errorf("Expected background error, got %v", err)
+	}
+	m.Wait()
+}
@@ -1,37 +1,43 @@
 package logger
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/requestid"
 )
 
 // Logger wraps slog.Logger with additional functionality
 type Logger struct {
 	*slog.Logger
+
+	// level is shared by all loggers derived from the same root so that
+	// SetLevel applies to every component
+	level *slog.LevelVar
 }
 
-// New creates a new logger with the specified level and format
+// New creates a new logger with the specified level and format, writing to stdout
 func New(level, format string) (*Logger, error) {
+	return NewWithWriter(level, format, os.Stdout)
+}
+
+// NewWithWriter creates a new logger with the specified level and format, writing to w
+func NewWithWriter(level, format string, w io.Writer) (*Logger, error) {
 	// Parse log level
-	var logLevel slog.Level
-	switch strings.ToUpper(level) {
-	case "DEBUG":
-		logLevel = slog.LevelDebug
-	case "INFO":
-		logLevel = slog.LevelInfo
-	case "WARN":
-		logLevel = slog.LevelWarn
-	case "ERROR":
-		logLevel = slog.LevelError
-	default:
-		return nil, fmt.Errorf("invalid log level: %s", level)
+	logLevel, err := parseLevel(level)
+	if err != nil {
+		return nil, err
 	}
+	levelVar := new(slog.LevelVar)
+	levelVar.Set(logLevel)
 
 	// Create handler options
 	opts := &slog.HandlerOptions{
-		Level:     logLevel,
+		Level:     levelVar,
 		AddSource: true,
 	}
 
@@ -39,9 +45,9 @@ func New(level, format string) (*Logger, error) {
 	var handler slog.Handler
 	switch strings.ToLower(format) {
 	case "json":
-		handler = slog.NewJSONHandler(os.Stdout, opts)
+		handler = slog.NewJSONHandler(w, opts)
 	case "text":
-		handler = slog.NewTextHandler(os.Stdout, opts)
+		handler = slog.NewTextHandler(w, opts)
 	default:
 		return nil, fmt.Errorf("invalid log format: %s (must be 'json' or 'text')", format)
 	}
@@ -49,7 +55,37 @@ func New(level, format string) (*Logger, error) {
 	// Create logger
 	logger := slog.New(handler)
 
-	return &Logger{Logger: logger}, nil
+	return &Logger{Logger: logger, level: levelVar}, nil
+}
+
+// parseLevel parses a level name such as "INFO"
+func parseLevel(level string) (slog.Level, error) {
+	switch strings.ToUpper(level) {
+	case "DEBUG":
+		return slog.LevelDebug, nil
+	case "INFO":
+		return slog.LevelInfo, nil
+	case "WARN":
+		return slog.LevelWarn, nil
+	case "ERROR":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level: %s", level)
+	}
+}
+
+// SetLevel changes the minimum level logged by this logger and every logger
+// derived from the same root
+func (l *Logger) SetLevel(level string) error {
+	logLevel, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	if l.level == nil {
+		return fmt.Errorf("logger level cannot be changed")
+	}
+	l.level.Set(logLevel)
+	return nil
 }
 
 // Debug logs a debug message with optional key-value pairs
@@ -74,12 +110,20 @@ func (l *Logger) Error(msg string, keysAndValues ...interface{}) {
 
 // With returns a new logger with the given key-value pairs added to the context
 func (l *Logger) With(keysAndValues ...interface{}) *Logger {
-	return &Logger{Logger: l.Logger.With(keysAndValues...)}
+	return &Logger{Logger: l.Logger.With(keysAndValues...), level: l.level}
+}
+
+// WithContext returns a logger that adds the request ID carried by ctx, if any, to every line
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	if id := requestid.FromContext(ctx); id != "" {
+		return l.With("request_id", id)
+	}
+	return l
 }
 
 // WithGroup returns a new logger with the given group name
 func (l *Logger) WithGroup(name string) *Logger {
-	return &Logger{Logger: l.Logger.WithGroup(name)}
+	return &Logger{Logger: l.Logger.WithGroup(name), level: l.level}
 }
 
 // LogRequest logs an HTTP request with structured fields
@@ -94,15 +138,17 @@ func (l *Logger) LogRequest(method, path, userAgent, remoteAddr string, statusCo
 	)
 }
 
-// LogGitHubAPICall logs a GitHub API call with structured fields
-func (l *Logger) LogGitHubAPICall(method, endpoint string, statusCode int, duration string, rateLimitRemaining int) {
-	l.Info("GitHub API call",
+// LogGitHubAPICall logs a GitHub API call with structured fields at level;
+// rateLimitRemaining is -1 when the response did not report it
+func (l *Logger) LogGitHubAPICall(level slog.Level, method, endpoint string, statusCode int, duration string, rateLimitRemaining int, keysAndValues ...interface{}) {
+	args := append([]interface{}{
 		"method", method,
 		"endpoint", endpoint,
 		"status_code", statusCode,
 		"duration", duration,
 		"rate_limit_remaining", rateLimitRemaining,
-	)
+	}, keysAndValues...)
+	l.Logger.Log(context.Background(), level, "GitHub API call", args...)
 }
 
 // LogError logs an error with additional context
@@ -0,0 +1,468 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/client/graphql"
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+)
+
+const (
+	// defaultAnalyticsWindowDays is the reporting window used when none is given
+	defaultAnalyticsWindowDays = 30
+	// maxAnalyticsWindowDays bounds the reporting window
+	maxAnalyticsWindowDays = 90
+	// maxAnalyticsPages bounds the number of search result pages fetched per report
+	maxAnalyticsPages = 3
+	// maxResponseTimeSamples bounds the number of threads whose comments are fetched
+	maxResponseTimeSamples = 30
+	// topParticipantsLimit is the number of participants included in a report
+	topParticipantsLimit = 10
+	// discussionCommentSamples is the number of comments of each discussion
+	// fetched along with it for first-response times
+	discussionCommentSamples = 10
+)
+
+// discussionSearchQuery searches discussions, which are only exposed
+// through GraphQL, with their first comments
+const discussionSearchQuery = `query($query: String!, $first: Int!, $after: String, $comments: Int!) {
+  search(type: DISCUSSION, query: $query, first: $first, after: $after) {
+    discussionCount
+    pageInfo { hasNextPage endCursor }
+    nodes {
+      ... on Discussion {
+        createdAt
+        closedAt
+        author { login __typename }
+        comments(first: $comments) {
+          totalCount
+          nodes { createdAt author { login __typename } }
+        }
+        reactionGroups { content reactors { totalCount } }
+      }
+    }
+  }
+}`
+
+// discussionActor is the author of a discussion or discussion comment
+type discussionActor struct {
+	Login    string `json:"login"`
+	Typename string `json:"__typename"`
+}
+
+// user returns the actor as a REST user, for isBotUser
+func (a *discussionActor) user() client.User {
+	if a == nil {
+		return client.User{Login: "ghost"}
+	}
+	user := client.User{Login: a.Login}
+	if a.Typename == "Bot" {
+		user.Type = "Bot"
+	}
+	return user
+}
+
+// discussion is a discussion matched by discussionSearchQuery
+type discussion struct {
+	CreatedAt string           `json:"createdAt"`
+	ClosedAt  *string          `json:"closedAt"`
+	Author    *discussionActor `json:"author"`
+	Comments  struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			CreatedAt string           `json:"createdAt"`
+			Author    *discussionActor `json:"author"`
+		} `json:"nodes"`
+	} `json:"comments"`
+	ReactionGroups []struct {
+		Content  string `json:"content"`
+		Reactors struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"reactors"`
+	} `json:"reactionGroups"`
+}
+
+// discussionReactions maps GraphQL reaction contents to their REST names
+var discussionReactions = map[string]string{
+	"THUMBS_UP":   "+1",
+	"THUMBS_DOWN": "-1",
+	"LAUGH":       "laugh",
+	"HOORAY":      "hooray",
+	"CONFUSED":    "confused",
+	"HEART":       "heart",
+	"ROCKET":      "rocket",
+	"EYES":        "eyes",
+}
+
+// orgActivityReport summarizes issue, pull request and discussion activity in
+// an organization
+type orgActivityReport struct {
+	Org             string                `json:"org"`
+	Since           string                `json:"since"`
+	Until           string                `json:"until"`
+	WindowDays      int                   `json:"window_days"`
+	Issues          activityCounts        `json:"issues"`
+	PullRequests    activityCounts        `json:"pull_requests"`
+	Discussions     activityCounts        `json:"discussions"`
+	Comments        int                   `json:"comments"`
+	Reactions       reactionSummary       `json:"reactions"`
+	ResponseTimes   responseTimeSummary   `json:"response_times"`
+	TopParticipants []participantActivity `json:"top_participants"`
+	// Analyzed is the number of threads included in the report; Truncated is set
+	// when the search matched more threads than were fetched
+	Analyzed    int    `json:"analyzed"`
+	Truncated   bool   `json:"truncated"`
+	GeneratedAt string `json:"generated_at"`
+}
+
+// activityCounts counts threads opened and closed within the reporting window
+type activityCounts struct {
+	Opened int `json:"opened"`
+	Closed int `json:"closed"`
+}
+
+// reactionSummary totals reactions across analyzed threads
+type reactionSummary struct {
+	Total  int            `json:"total"`
+	ByType map[string]int `json:"by_type"`
+}
+
+// responseTimeSummary describes how quickly threads received a first response
+// from someone other than their author
+type responseTimeSummary struct {
+	Sampled      int     `json:"sampled"`
+	Responded    int     `json:"responded"`
+	MedianHours  float64 `json:"median_hours"`
+	AverageHours float64 `json:"average_hours"`
+}
+
+// participantActivity counts the contributions of a single user
+type participantActivity struct {
+	Login    string `json:"login"`
+	Opened   int    `json:"opened"`
+	Comments int    `json:"comments"`
+}
+
+// orgActivityReport returns the activity report for an organization, using the cache when possible
+func (h *Handler) orgActivityReport(ctx context.Context, org string, days int) (*orgActivityReport, error) {
+	key := fmt.Sprintf("%s:%d", strings.ToLower(org), days)
+	shared := sharedCache(ctx)
+	if report, ok := h.analytics.get(key); ok && shared {
+		execstats.FromContext(ctx).AddCacheHit()
+		h.logger.Debug("Serving cached organization analytics", "org", org, "days", days)
+		SetResultMeta(ctx, cacheMetaKey, CachedReport{Hit: true, GeneratedAt: report.GeneratedAt})
+		return report, nil
+	}
+
+	report, err := h.buildOrgActivityReport(ctx, org, days, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	SetResultMeta(ctx, cacheMetaKey, CachedReport{GeneratedAt: report.GeneratedAt})
+
+	if shared {
+		h.analytics.set(key, report, h.CacheTTL())
+	}
+	return report, nil
+}
+
+// buildOrgActivityReport builds an activity report from search and comment
+// data. Discussions are searched through GraphQL with their first comments;
+// when that fails the report covers issues and pull requests only.
+func (h *Handler) buildOrgActivityReport(ctx context.Context, org string, days int, now time.Time) (*orgActivityReport, error) {
+	since := now.AddDate(0, 0, -days)
+	report := &orgActivityReport{
+		Org:         org,
+		Since:       since.Format(time.RFC3339),
+		Until:       now.Format(time.RFC3339),
+		WindowDays:  days,
+		Reactions:   reactionSummary{ByType: make(map[string]int)},
+		GeneratedAt: now.Format(time.RFC3339),
+	}
+
+	// Threads updated in the window cover both newly opened and newly closed ones
+	query := fmt.Sprintf("org:%s updated:>=%s", org, since.Format("2006-01-02"))
+
+	var threads []client.Issue
+	for page := 1; page <= maxAnalyticsPages; page++ {
+		result, pageInfo, err := h.githubClient.SearchIssues(ctx, query, "created", "desc", page, 100)
+		if err != nil {
+			return nil, err
+		}
+		threads = append(threads, result.Items...)
+		report.Truncated = result.TotalCount > len(threads) || result.IncompleteResults
+		if !pageInfo.HasMore() {
+			break
+		}
+	}
+	report.Analyzed = len(threads)
+
+	participants := make(map[string]*participantActivity)
+	participant := func(login string) *participantActivity {
+		p, ok := participants[login]
+		if !ok {
+			p = &participantActivity{Login: login}
+			participants[login] = p
+		}
+		return p
+	}
+
+	var responseHours []float64
+	for i := range threads {
+		thread := &threads[i]
+
+		counts := &report.Issues
+		if thread.IsPullRequest() {
+			counts = &report.PullRequests
+		}
+
+		created, createdErr := time.Parse(time.RFC3339, thread.CreatedAt)
+		if createdErr == nil && !created.Before(since) {
+			counts.Opened++
+			if !isBotUser(thread.User) {
+				participant(thread.User.Login).Opened++
+			}
+		}
+		if thread.ClosedAt != nil {
+			if closed, err := time.Parse(time.RFC3339, *thread.ClosedAt); err == nil && !closed.Before(since) {
+				counts.Closed++
+			}
+		}
+
+		report.Comments += thread.Comments
+		addReactions(&report.Reactions, thread.Reactions)
+
+		// Sample first-response times from the most recently created threads
+		if createdErr != nil || created.Before(since) || report.ResponseTimes.Sampled >= maxResponseTimeSamples {
+			continue
+		}
+		report.ResponseTimes.Sampled++
+		if thread.Comments == 0 {
+			continue
+		}
+
+		owner, repo, ok := repositoryFromURL(thread.RepositoryURL)
+		if !ok {
+			continue
+		}
+		comments, _, err := h.githubClient.ListIssueComments(ctx, owner, repo, thread.Number, 1, 100)
+		if err != nil {
+			h.logger.Warn("Failed to fetch comments for analytics", "org", org, "repo", repo, "number", thread.Number, "error", err)
+			continue
+		}
+
+		responded := false
+		for _, comment := range comments {
+			if isBotUser(comment.User) {
+				continue
+			}
+			participant(comment.User.Login).Comments++
+			if responded || comment.User.Login == thread.User.Login {
+				continue
+			}
+			if at, err := time.Parse(time.RFC3339, comment.CreatedAt); err == nil {
+				responseHours = append(responseHours, at.Sub(created).Hours())
+				responded = true
+			}
+		}
+	}
+
+	discussions, truncated, err := h.searchDiscussions(ctx, query)
+	if err != nil {
+		h.logger.Warn("Failed to search discussions for analytics", "org", org, "error", err)
+	}
+	report.Analyzed += len(discussions)
+	report.Truncated = report.Truncated || truncated
+	for i := range discussions {
+		d := &discussions[i]
+		author := d.Author.user()
+
+		created, createdErr := time.Parse(time.RFC3339, d.CreatedAt)
+		if createdErr == nil && !created.Before(since) {
+			report.Discussions.Opened++
+			if !isBotUser(author) {
+				participant(author.Login).Opened++
+			}
+		}
+		if d.ClosedAt != nil {
+			if closed, err := time.Parse(time.RFC3339, *d.ClosedAt); err == nil && !closed.Before(since) {
+				report.Discussions.Closed++
+			}
+		}
+
+		report.Comments += d.Comments.TotalCount
+		for _, group := range d.ReactionGroups {
+			if name, ok := discussionReactions[group.Content]; ok && group.Reactors.TotalCount > 0 {
+				report.Reactions.Total += group.Reactors.TotalCount
+				report.Reactions.ByType[name] += group.Reactors.TotalCount
+			}
+		}
+
+		// The first comments came with the search, so every discussion
+		// opened in the window is sampled
+		if createdErr != nil || created.Before(since) {
+			continue
+		}
+		report.ResponseTimes.Sampled++
+		responded := false
+		for _, comment := range d.Comments.Nodes {
+			commenter := comment.Author.user()
+			if isBotUser(commenter) {
+				continue
+			}
+			participant(commenter.Login).Comments++
+			if responded || commenter.Login == author.Login {
+				continue
+			}
+			if at, err := time.Parse(time.RFC3339, comment.CreatedAt); err == nil {
+				responseHours = append(responseHours, at.Sub(created).Hours())
+				responded = true
+			}
+		}
+	}
+
+	report.ResponseTimes.Responded = len(responseHours)
+	report.ResponseTimes.MedianHours, report.ResponseTimes.AverageHours = summarizeHours(responseHours)
+	report.TopParticipants = topParticipants(participants, topParticipantsLimit)
+
+	return report, nil
+}
+
+// searchDiscussions returns the discussions matching query, fetching at most
+// maxAnalyticsPages pages, and whether more discussions matched
+func (h *Handler) searchDiscussions(ctx context.Context, query string) ([]discussion, bool, error) {
+	gql := graphql.New(h.githubClient)
+	variables := map[string]interface{}{"query": query, "first": 100, "comments": discussionCommentSamples}
+
+	var discussions []discussion
+	for page := 1; page <= maxAnalyticsPages; page++ {
+		var result struct {
+			Search struct {
+				DiscussionCount int `json:"discussionCount"`
+				PageInfo        struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []discussion `json:"nodes"`
+			} `json:"search"`
+		}
+		if err := gql.Query(ctx, discussionSearchQuery, variables, &result); err != nil {
+			return discussions, false, err
+		}
+		discussions = append(discussions, result.Search.Nodes...)
+		if !result.Search.PageInfo.HasNextPage {
+			return discussions, false, nil
+		}
+		variables["after"] = result.Search.PageInfo.EndCursor
+	}
+	return discussions, true, nil
+}
+
+// addReactions adds a thread's reactions to the summary
+func addReactions(summary *reactionSummary, reactions *client.Reactions) {
+	if reactions == nil {
+		return
+	}
+
+	summary.Total += reactions.TotalCount
+	for name, count := range map[string]int{
+		"+1":       reactions.PlusOne,
+		"-1":       reactions.MinusOne,
+		"laugh":    reactions.Laugh,
+		"hooray":   reactions.Hooray,
+		"confused": reactions.Confused,
+		"heart":    reactions.Heart,
+		"rocket":   reactions.Rocket,
+		"eyes":     reactions.Eyes,
+	} {
+		if count > 0 {
+			summary.ByType[name] += count
+		}
+	}
+}
+
+// summarizeHours returns the median and average of the given durations, rounded to a tenth of an hour
+func summarizeHours(hours []float64) (float64, float64) {
+	if len(hours) == 0 {
+		return 0, 0
+	}
+
+	sorted := append([]float64(nil), hours...)
+	sort.Float64s(sorted)
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	var total float64
+	for _, h := range sorted {
+		total += h
+	}
+
+	return roundTenth(median), roundTenth(total / float64(len(sorted)))
+}
+
+// roundTenth rounds a value to one decimal place
+func roundTenth(v float64) float64 {
+	return float64(int64(v*10+0.5)) / 10
+}
+
+// topParticipants returns the most active participants, ordered by total activity
+func topParticipants(participants map[string]*participantActivity, limit int) []participantActivity {
+	result := make([]participantActivity, 0, len(participants))
+	for _, p := range participants {
+		result = append(result, *p)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		ti, tj := result[i].Opened+result[i].Comments, result[j].Opened+result[j].Comments
+		if ti != tj {
+			return ti > tj
+		}
+		return result[i].Login < result[j].Login
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// isBotUser returns true if the user is a bot account
+func isBotUser(user client.User) bool {
+	return user.Type == "Bot" || strings.HasSuffix(user.Login, "[bot]")
+}
+
+// repositoryFromURL extracts the owner and name from a repository API URL
+func repositoryFromURL(repositoryURL string) (string, string, bool) {
+	_, path, found := strings.Cut(repositoryURL, "/repos/")
+	if !found {
+		return "", "", false
+	}
+
+	owner, repo, found := strings.Cut(path, "/")
+	if !found || owner == "" || repo == "" {
+		return "", "", false
+	}
+	return owner, repo, true
+}
+
+// orgAnalyticsResourceOrg returns the organization named by an analytics resource URI
+func orgAnalyticsResourceOrg(uri string) (string, bool) {
+	path, found := strings.CutPrefix(uri, "github://org/")
+	if !found {
+		return "", false
+	}
+
+	org, found := strings.CutSuffix(path, "/analytics")
+	if !found || org == "" || strings.Contains(org, "/") {
+		return "", false
+	}
+	return org, true
+}
@@ -0,0 +1,163 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestSummarizeHours(t *testing.T) {
+	tests := []struct {
+		name            string
+		hours           []float64
+		expectedMedian  float64
+		expectedAverage float64
+	}{
+		{name: "empty", hours: nil},
+		{name: "odd count", hours: []float64{5, 1, 3}, expectedMedian: 3, expectedAverage: 3},
+		{name: "even count", hours: []float64{4, 1, 2, 10}, expectedMedian: 3, expectedAverage: 4.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			median, average := summarizeHours(tt.hours)
+			if median != tt.expectedMedian {
+				t.Errorf("Expected median %v, got %v", tt.expectedMedian, median)
+			}
+			if average != tt.expectedAverage {
+				t.Errorf("Expected average %v, got %v", tt.expectedAverage, average)
+			}
+		})
+	}
+}
+
+func TestTopParticipants(t *testing.T) {
+	participants := map[string]*participantActivity{
+		"alice": {Login: "alice", Opened: 1, Comments: 4},
+		"bob":   {Login: "bob", Opened: 3, Comments: 0},
+		"carol": {Login: "carol", Opened: 5, Comments: 5},
+		"dave":  {Login: "dave", Opened: 0, Comments: 1},
+	}
+
+	top := topParticipants(participants, 3)
+
+	expected := []string{"carol", "alice", "bob"}
+	if len(top) != len(expected) {
+		t.Fatalf("Expected %d participants, got %d", len(expected), len(top))
+	}
+	for i, login := range expected {
+		if top[i].Login != login {
+			t.Errorf("Expected participant %d to be %s, got %s", i, login, top[i].Login)
+		}
+	}
+}
+
+func TestOrgAnalyticsResourceOrg(t *testing.T) {
+	tests := []struct {
+		uri      string
+		expected string
+		ok       bool
+	}{
+		{uri: "github://org/octo-org/analytics", expected: "octo-org", ok: true},
+		{uri: "github://org/octo-org/members", ok: false},
+		{uri: "github://org//analytics", ok: false},
+		{uri: "github://user/octocat", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			org, ok := orgAnalyticsResourceOrg(tt.uri)
+			if ok != tt.ok || org != tt.expected {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.expected, tt.ok, org, ok)
+			}
+		})
+	}
+}
+
+func TestBuildOrgActivityReport(t *testing.T) {
+	var discussionSearch struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Path {
+			case "/search/issues":
+				return mocks.MockJSONResponse(200, `{"total_count": 3, "incomplete_results": false, "items": [
+					{"number": 1, "created_at": "2024-05-28T00:00:00Z", "comments": 2, "user": {"login": "alice"},
+					 "repository_url": "https://api.github.com/repos/octo-org/widgets", "reactions": {"total_count": 2, "+1": 2}},
+					{"number": 2, "created_at": "2024-05-29T00:00:00Z", "closed_at": "2024-05-30T00:00:00Z", "comments": 0, "user": {"login": "bob"},
+					 "repository_url": "https://api.github.com/repos/octo-org/widgets", "pull_request": {"url": "x"}},
+					{"number": 3, "created_at": "2024-01-01T00:00:00Z", "closed_at": "2024-05-31T00:00:00Z", "comments": 0, "user": {"login": "carol"},
+					 "repository_url": "https://api.github.com/repos/octo-org/widgets"}
+				]}`), nil
+			case "/repos/octo-org/widgets/issues/1/comments":
+				return mocks.MockJSONResponse(200, `[
+					{"created_at": "2024-05-28T01:00:00Z", "user": {"login": "alice"}},
+					{"created_at": "2024-05-28T03:00:00Z", "user": {"login": "bob"}}
+				]`), nil
+			case "/graphql":
+				if err := json.NewDecoder(req.Body).Decode(&discussionSearch); err != nil {
+					t.Errorf("Failed to decode GraphQL request: %v", err)
+				}
+				return mocks.MockJSONResponse(200, `{"data": {"search": {"discussionCount": 1, "pageInfo": {"hasNextPage": false}, "nodes": [
+					{"createdAt": "2024-05-30T00:00:00Z", "closedAt": "2024-05-31T00:00:00Z", "author": {"login": "dave", "__typename": "User"},
+					 "comments": {"totalCount": 3, "nodes": [
+						{"createdAt": "2024-05-30T01:00:00Z", "author": {"login": "helper", "__typename": "Bot"}},
+						{"createdAt": "2024-05-30T05:00:00Z", "author": {"login": "alice", "__typename": "User"}},
+						{"createdAt": "2024-05-30T06:00:00Z", "author": {"login": "dave", "__typename": "User"}}
+					 ]},
+					 "reactionGroups": [{"content": "HEART", "reactors": {"totalCount": 3}}, {"content": "EYES", "reactors": {"totalCount": 0}}]}
+				]}}}`), nil
+			}
+			return mocks.MockJSONResponse(404, `{"message": "Not Found"}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	report, err := h.buildOrgActivityReport(context.Background(), "octo-org", 7, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(discussionSearch.Query, "type: DISCUSSION") || discussionSearch.Variables["query"] != "org:octo-org updated:>=2024-05-25" {
+		t.Errorf("Expected a discussion search of the organization, got %+v", discussionSearch)
+	}
+	if report.Issues != (activityCounts{Opened: 1, Closed: 1}) || report.PullRequests != (activityCounts{Opened: 1, Closed: 1}) ||
+		report.Discussions != (activityCounts{Opened: 1, Closed: 1}) {
+		t.Errorf("Unexpected counts: issues %+v, pull requests %+v, discussions %+v", report.Issues, report.PullRequests, report.Discussions)
+	}
+	if report.Analyzed != 4 || report.Truncated || report.Comments != 5 {
+		t.Errorf("Expected 4 threads and 5 comments, got %d threads and %d comments (truncated %v)", report.Analyzed, report.Comments, report.Truncated)
+	}
+	if report.Reactions.Total != 5 || report.Reactions.ByType["+1"] != 2 || report.Reactions.ByType["heart"] != 3 || len(report.Reactions.ByType) != 2 {
+		t.Errorf("Unexpected reactions %+v", report.Reactions)
+	}
+	// The issue was answered by bob after 3 hours and the discussion by
+	// alice after 5, the bot's comment not counting
+	if rt := report.ResponseTimes; rt.Sampled != 3 || rt.Responded != 2 || rt.MedianHours != 4 || rt.AverageHours != 4 {
+		t.Errorf("Unexpected response times %+v", rt)
+	}
+	expected := []participantActivity{
+		{Login: "alice", Opened: 1, Comments: 2},
+		{Login: "bob", Opened: 1, Comments: 1},
+		{Login: "dave", Opened: 1, Comments: 1},
+	}
+	if len(report.TopParticipants) != len(expected) {
+		t.Fatalf("Expected participants %+v, got %+v", expected, report.TopParticipants)
+	}
+	for i := range expected {
+		if report.TopParticipants[i] != expected[i] {
+			t.Errorf("Expected participants %+v, got %+v", expected, report.TopParticipants)
+			break
+		}
+	}
+}
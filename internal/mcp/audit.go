@@ -0,0 +1,310 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/auth"
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+	"github.com/nicholasflintwillow/github-mcp/internal/requestid"
+)
+
+const (
+	// auditQueueSize bounds the audit records waiting to be written; records
+	// are dropped while it is full so a slow sink never holds up tool calls
+	auditQueueSize = 1024
+	// auditPostTimeout bounds a POST of audit records to an HTTP sink
+	auditPostTimeout = 10 * time.Second
+	// auditMaxStringSize bounds the string argument values kept in an audit
+	// record, such as descriptions
+	auditMaxStringSize = 256
+)
+
+// auditSecretNames mark the arguments whose values are left out of audit
+// records
+var auditSecretNames = []string{"token", "password", "secret", "credential", "private_key", "authorization"}
+
+// auditContentNames are the arguments carrying file contents or request
+// bodies, which are left out of audit records but for their size
+var auditContentNames = map[string]bool{"content": true, "body": true}
+
+// AuditRecord describes a tools/call request and how it ended
+type AuditRecord struct {
+	Time      time.Time `json:"time"`
+	RequestID string    `json:"request_id,omitempty"`
+	SessionID string    `json:"session_id,omitempty"`
+	Client    string    `json:"client,omitempty"`
+	Subject   string    `json:"subject,omitempty"`
+	Tool      string    `json:"tool"`
+	// Arguments are the call's arguments, with secrets redacted and long
+	// strings cut
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	// Outcome is success, or error for error results and failed requests
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+	// Endpoints are the GitHub API requests the call made
+	Endpoints  []string `json:"endpoints,omitempty"`
+	DurationMs int64    `json:"duration_ms"`
+}
+
+// auditLog writes audit records to a JSON lines file, or POSTs them to an
+// HTTP endpoint, in the background
+type auditLog struct {
+	file     string
+	endpoint string
+	client   *http.Client
+
+	queue   chan AuditRecord
+	stop    chan struct{}
+	done    chan struct{}
+	started bool
+	// failing is set while the sink fails, so failures are logged once
+	failing bool
+}
+
+// SetAuditLog records every tools/call request to target: a file the
+// records are appended to as JSON lines, or an http(s) URL they are POSTed
+// to as newline-delimited JSON. Records are written by StartAuditLog. An
+// empty target disables the audit log.
+func (h *Handler) SetAuditLog(target string) error {
+	if target == "" {
+		h.audit = nil
+		return nil
+	}
+
+	audit := &auditLog{queue: make(chan AuditRecord, auditQueueSize), stop: make(chan struct{}), done: make(chan struct{})}
+	if strings.Contains(target, "://") {
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("audit log URL must be an http or https URL")
+		}
+		audit.endpoint = target
+		audit.client = &http.Client{Timeout: auditPostTimeout}
+	} else {
+		audit.file = target
+	}
+	h.audit = audit
+	return nil
+}
+
+// StartAuditLog writes audit records in the background until
+// StopAuditLog. It does nothing when the audit log is disabled.
+func (h *Handler) StartAuditLog() {
+	if h.audit == nil || h.audit.started {
+		return
+	}
+	h.audit.started = true
+	go h.audit.run(h)
+}
+
+// StopAuditLog writes the queued audit records and stops
+func (h *Handler) StopAuditLog() {
+	if h.audit == nil || !h.audit.started {
+		return
+	}
+	close(h.audit.stop)
+	<-h.audit.done
+}
+
+// run writes queued records until stopped, then the records still queued
+func (l *auditLog) run(h *Handler) {
+	defer close(l.done)
+	for {
+		select {
+		case record := <-l.queue:
+			l.write(h, l.batch(record))
+		case <-l.stop:
+			for {
+				select {
+				case record := <-l.queue:
+					l.write(h, l.batch(record))
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// batch returns first with the records queued after it
+func (l *auditLog) batch(first AuditRecord) []AuditRecord {
+	batch := []AuditRecord{first}
+	for len(batch) < auditQueueSize {
+		select {
+		case record := <-l.queue:
+			batch = append(batch, record)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// write writes a batch of records to the sink, logging when it starts or
+// stops failing
+func (l *auditLog) write(h *Handler, batch []AuditRecord) {
+	var lines bytes.Buffer
+	encoder := json.NewEncoder(&lines)
+	for _, record := range batch {
+		encoder.Encode(record)
+	}
+
+	err := l.writeFile(lines.Bytes())
+	if l.endpoint != "" {
+		err = l.post(lines.Bytes())
+	}
+	if err != nil {
+		if !l.failing {
+			h.logger.Warn("Failed to write audit records", "records", len(batch), "error", err)
+		}
+		l.failing = true
+		return
+	}
+	if l.failing {
+		h.logger.Info("Audit records written again")
+	}
+	l.failing = false
+}
+
+// writeFile appends lines to the audit file
+func (l *auditLog) writeFile(lines []byte) error {
+	if l.file == "" {
+		return nil
+	}
+	f, err := os.OpenFile(l.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(lines); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// post sends lines to the audit endpoint
+func (l *auditLog) post(lines []byte) error {
+	resp, err := l.client.Post(l.endpoint, "application/x-ndjson", bytes.NewReader(lines))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("audit endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// auditCall queues the audit record of a tools/call request that started
+// at start and was answered with response
+func (h *Handler) auditCall(ctx context.Context, start time.Time, req *CallToolRequest, stats *execstats.Stats, response *JSONRPCMessage) {
+	if h.audit == nil {
+		return
+	}
+
+	session := h.session(ctx)
+	record := AuditRecord{
+		Time:       start.UTC(),
+		RequestID:  requestid.FromContext(ctx),
+		SessionID:  session.ID(),
+		Client:     session.ClientInfo().Name,
+		Tool:       req.Name,
+		Arguments:  sanitizeArguments(req.Arguments),
+		Outcome:    "success",
+		Endpoints:  stats.Endpoints(),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		record.Subject = identity.Subject
+	}
+	switch {
+	case response == nil:
+	case response.Error != nil:
+		record.Outcome, record.Error = "error", response.Error.Message
+	default:
+		if result, ok := response.Result.(*CallToolResult); ok && result.IsError {
+			record.Outcome = "error"
+			if len(result.Content) > 0 {
+				record.Error = truncateString(result.Content[0].Text)
+			}
+		}
+	}
+
+	select {
+	case h.audit.queue <- record:
+	default:
+		h.logger.WithContext(ctx).Warn("Audit queue full, record dropped", "tool", req.Name)
+	}
+}
+
+// sanitizeArguments returns a copy of args with the values of secret
+// arguments redacted and long strings cut
+func sanitizeArguments(args map[string]interface{}) map[string]interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+	sanitized := make(map[string]interface{}, len(args))
+	for name, value := range args {
+		if secretArgument(name) {
+			sanitized[name] = "[REDACTED]"
+			continue
+		}
+		if auditContentNames[strings.ToLower(name)] {
+			sanitized[name] = redactContent(value)
+			continue
+		}
+		sanitized[name] = sanitizeValue(value)
+	}
+	return sanitized
+}
+
+// sanitizeValue cuts the long strings of an argument value
+func sanitizeValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		return truncateString(value)
+	case map[string]interface{}:
+		return sanitizeArguments(value)
+	case []interface{}:
+		items := make([]interface{}, len(value))
+		for i, item := range value {
+			items[i] = sanitizeValue(item)
+		}
+		return items
+	}
+	return value
+}
+
+// redactContent replaces a content argument value by its size
+func redactContent(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("[REDACTED] (%d bytes)", len(s))
+	}
+	return "[REDACTED]"
+}
+
+// secretArgument reports whether the argument named name holds a secret
+func secretArgument(name string) bool {
+	name = strings.ToLower(name)
+	for _, secret := range auditSecretNames {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateString cuts s to auditMaxStringSize bytes, noting its length
+func truncateString(s string) string {
+	if len(s) <= auditMaxStringSize {
+		return s
+	}
+	return fmt.Sprintf("%s... (%d bytes)", strings.ToValidUTF8(s[:auditMaxStringSize], ""), len(s))
+}
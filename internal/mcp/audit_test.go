@@ -0,0 +1,160 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func auditTestHandler(t *testing.T, target string) (*Handler, context.Context) {
+	t.Helper()
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/users/missing" {
+				return mocks.MockJSONResponse(http.StatusNotFound, `{"message": "Not Found"}`), nil
+			}
+			return mocks.MockJSONResponse(http.StatusOK, `{"login": "octocat", "id": 1}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	if err := h.SetAuditLog(target); err != nil {
+		t.Fatalf("SetAuditLog failed: %v", err)
+	}
+
+	session := NewSession(TransportStdio)
+	session.setClientInfo(ClientInfo{Name: "test-client"}, "2025-06-18")
+	session.setInitialized()
+	return h, WithSession(context.Background(), session)
+}
+
+func callAuditedTool(h *Handler, ctx context.Context, name string, args map[string]interface{}) {
+	params, _ := json.Marshal(CallToolRequest{Name: name, Arguments: args})
+	var raw interface{}
+	json.Unmarshal(params, &raw)
+	h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: raw})
+}
+
+func readAuditRecords(t *testing.T, r io.Reader) []AuditRecord {
+	t.Helper()
+	var records []AuditRecord
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Invalid audit record %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestAuditLog_File(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "audit.jsonl")
+	h, ctx := auditTestHandler(t, file)
+	h.StartAuditLog()
+
+	callAuditedTool(h, ctx, "get_user", map[string]interface{}{"username": "octocat"})
+	callAuditedTool(h, ctx, "get_user", map[string]interface{}{"username": "missing"})
+	h.StopAuditLog()
+
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatalf("Failed to open audit log: %v", err)
+	}
+	defer f.Close()
+	records := readAuditRecords(t, f)
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 audit records, got %+v", records)
+	}
+
+	ok := records[0]
+	if ok.Tool != "get_user" || ok.Outcome != "success" || ok.Arguments["username"] != "octocat" || ok.Client != "test-client" {
+		t.Errorf("Unexpected audit record: %+v", ok)
+	}
+	if len(ok.Endpoints) != 1 || ok.Endpoints[0] != "GET /users/octocat" {
+		t.Errorf("Expected the GitHub endpoint in the audit record, got %v", ok.Endpoints)
+	}
+	if failed := records[1]; failed.Outcome != "error" || failed.Error == "" {
+		t.Errorf("Expected the failed call audited as an error, got %+v", failed)
+	}
+}
+
+func TestAuditLog_HTTP(t *testing.T) {
+	var mu sync.Mutex
+	var records []AuditRecord
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("Unexpected content type %q", r.Header.Get("Content-Type"))
+		}
+		mu.Lock()
+		records = append(records, readAuditRecords(t, r.Body)...)
+		mu.Unlock()
+	}))
+	defer sink.Close()
+
+	h, ctx := auditTestHandler(t, sink.URL)
+	h.StartAuditLog()
+	callAuditedTool(h, ctx, "get_user", map[string]interface{}{"username": "octocat"})
+	h.StopAuditLog()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(records) != 1 || records[0].Tool != "get_user" {
+		t.Errorf("Expected the call POSTed to the audit endpoint, got %+v", records)
+	}
+}
+
+func TestSetAuditLog_InvalidURL(t *testing.T) {
+	h := NewHandler(client.NewGitHubClient("test-token", createTestLogger()), createTestLogger())
+	if err := h.SetAuditLog("ftp://audit.example.com"); err == nil {
+		t.Error("Expected a non-HTTP audit URL to be rejected")
+	}
+	// Stopping an audit log never started returns at once
+	h.StopAuditLog()
+}
+
+func TestSanitizeArguments(t *testing.T) {
+	args := map[string]interface{}{
+		"org":            "acme",
+		"api_token":      "ghp_secret",
+		"description":    strings.Repeat("a", auditMaxStringSize+10),
+		"content":        "c2VjcmV0",
+		"body":           map[string]interface{}{"ref": "main"},
+		"config":         map[string]interface{}{"password": "hunter2"},
+		"labels":         []interface{}{"bug"},
+		"per_page":       float64(10),
+		"webhook_Secret": "s3cret",
+	}
+	sanitized := sanitizeArguments(args)
+
+	if sanitized["org"] != "acme" || sanitized["per_page"] != float64(10) {
+		t.Errorf("Expected plain arguments kept, got %v", sanitized)
+	}
+	if sanitized["api_token"] != "[REDACTED]" || sanitized["webhook_Secret"] != "[REDACTED]" {
+		t.Errorf("Expected secrets redacted, got %v", sanitized)
+	}
+	if nested := sanitized["config"].(map[string]interface{}); nested["password"] != "[REDACTED]" {
+		t.Errorf("Expected nested secrets redacted, got %v", nested)
+	}
+	if description := sanitized["description"].(string); !strings.HasSuffix(description, "(266 bytes)") {
+		t.Errorf("Expected long strings cut, got %q", description)
+	}
+	if sanitized["content"] != "[REDACTED] (8 bytes)" || sanitized["body"] != "[REDACTED]" {
+		t.Errorf("Expected contents redacted, got %v and %v", sanitized["content"], sanitized["body"])
+	}
+	if args["api_token"] != "ghp_secret" {
+		t.Error("Expected the arguments left unchanged")
+	}
+}
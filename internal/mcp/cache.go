@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+const (
+	// defaultCacheTTL is how long computed reports are cached unless configured otherwise
+	defaultCacheTTL = 60 * time.Second
+	// maxCacheEntries bounds the number of values a cache holds
+	maxCacheEntries = 1000
+)
+
+// sharedCache reports whether results computed for ctx may be cached and
+// served to other callers. Results fetched with a caller's own token may
+// include data other callers cannot see, so they are neither cached nor
+// served from the cache.
+func sharedCache(ctx context.Context) bool {
+	_, ok := client.TokenFromContext(ctx)
+	return !ok
+}
+
+// ttlCache caches computed values for a limited time, holding at most
+// maxEntries of them
+type ttlCache[V any] struct {
+	mu         sync.Mutex
+	entries    map[string]ttlCacheEntry[V]
+	maxEntries int
+}
+
+// ttlCacheEntry is a cached value and its expiry time
+type ttlCacheEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// newTTLCache creates an empty cache
+func newTTLCache[V any]() *ttlCache[V] {
+	return &ttlCache[V]{entries: make(map[string]ttlCacheEntry[V]), maxEntries: maxCacheEntries}
+}
+
+// get returns a cached value if it has not expired
+func (c *ttlCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// set caches a value for the given TTL; a non-positive TTL disables caching.
+// A full cache first drops its expired values and, when none have, the value
+// expiring soonest.
+func (c *ttlCache[V]) set(key string, value V, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+	c.entries[key] = ttlCacheEntry[V]{value: value, expires: time.Now().Add(ttl)}
+}
+
+// evict makes room for a value, dropping the expired values or else the one
+// expiring soonest
+func (c *ttlCache[V]) evict() {
+	now := time.Now()
+	var soonest string
+	for key, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, key)
+		} else if soonest == "" || entry.expires.Before(c.entries[soonest].expires) {
+			soonest = key
+		}
+	}
+	if len(c.entries) >= c.maxEntries {
+		delete(c.entries, soonest)
+	}
+}
@@ -0,0 +1,31 @@
+package mcp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTTLCache_Bounded(t *testing.T) {
+	cache := newTTLCache[int]()
+	cache.maxEntries = 2
+
+	cache.set("expired", 1, time.Nanosecond)
+	cache.set("soon", 2, time.Minute)
+	time.Sleep(time.Millisecond)
+	cache.set("late", 3, time.Hour)
+	if _, ok := cache.get("soon"); !ok || len(cache.entries) != 2 {
+		t.Fatalf("Expected the expired value to make room, got %v", cache.entries)
+	}
+
+	cache.set("latest", 4, time.Hour)
+	if _, ok := cache.get("soon"); ok {
+		t.Error("Expected the value expiring soonest to be dropped from a full cache")
+	}
+	for i := 0; i < 10; i++ {
+		cache.set(fmt.Sprint(i), i, time.Hour)
+	}
+	if len(cache.entries) != 2 {
+		t.Errorf("Expected the cache to stay bounded, got %d values", len(cache.entries))
+	}
+}
@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// requestKey identifies a request by the client that sent it: its session,
+// or for HTTP clients without one, who authenticated the request and the
+// SSE client ID it sent
+type requestKey struct {
+	session *Session
+	owner   string
+	client  string
+	id      string
+}
+
+// inFlightRequest is a request being handled
+type inFlightRequest struct {
+	cancel    context.CancelFunc
+	cancelled atomic.Bool
+}
+
+// inFlightRequests holds the requests being handled, so that a client can
+// cancel them with notifications/cancelled
+type inFlightRequests struct {
+	mu   sync.Mutex
+	byID map[requestKey]*inFlightRequest
+}
+
+// newRequestKey returns the key of the request with the given ID sent in
+// ctx. It returns false when nothing tells the client apart from others, so
+// its requests cannot be cancelled: an HTTP client without a session, an
+// authenticated identity or an SSE client ID.
+func newRequestKey(ctx context.Context, id interface{}) (requestKey, bool) {
+	session, _ := ctx.Value(sessionContextKey{}).(*Session)
+	key := requestKey{session: session, owner: streamOwner(ctx), client: streamClient(ctx), id: fmt.Sprint(id)}
+	return key, key.session != nil || key.owner != "" || key.client != ""
+}
+
+// start records a request as being handled. It returns the context the
+// request is handled in, a function reporting whether the client cancelled
+// the request and a function to call once it is handled.
+func (r *inFlightRequests) start(ctx context.Context, id interface{}) (context.Context, func() bool, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	request := &inFlightRequest{cancel: cancel}
+	key, ok := newRequestKey(ctx, id)
+	if !ok {
+		return ctx, request.cancelled.Load, cancel
+	}
+
+	r.mu.Lock()
+	if r.byID == nil {
+		r.byID = make(map[requestKey]*inFlightRequest)
+	}
+	// A client reusing the ID of a running request can only cancel the latest
+	r.byID[key] = request
+	r.mu.Unlock()
+
+	done := func() {
+		r.mu.Lock()
+		if r.byID[key] == request {
+			delete(r.byID, key)
+		}
+		r.mu.Unlock()
+		cancel()
+	}
+	return ctx, request.cancelled.Load, done
+}
+
+// cancel cancels the context of the request with the given ID, reporting
+// whether it was being handled
+func (r *inFlightRequests) cancel(ctx context.Context, id interface{}) bool {
+	key, ok := newRequestKey(ctx, id)
+	if !ok {
+		return false
+	}
+	r.mu.Lock()
+	request, ok := r.byID[key]
+	r.mu.Unlock()
+	if !ok {
+		return false
+	}
+	request.cancelled.Store(true)
+	request.cancel()
+	return true
+}
+
+// handleCancelled handles the cancelled notification by cancelling the
+// context of the request it names. Requests that already completed or were
+// never received are ignored, as the notification may race their response.
+func (h *Handler) handleCancelled(ctx context.Context, msg *JSONRPCMessage) {
+	log := h.logger.WithContext(ctx)
+
+	var params CancelledParams
+	if err := msg.GetParams(&params); err != nil || params.RequestID == nil {
+		log.Warn("Invalid cancelled notification", "error", err)
+		return
+	}
+
+	if h.requests.cancel(ctx, params.RequestID) {
+		log.Info("Request cancelled by client", "id", params.RequestID, "reason", params.Reason)
+	} else {
+		log.Debug("Cancelled request is not running", "id", params.RequestID)
+	}
+}
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestHandleCancelled(t *testing.T) {
+	started := make(chan struct{}, 1)
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			started <- struct{}{}
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		},
+	})
+
+	h := NewHandler(githubClient, createTestLogger())
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	call, _ := json.Marshal(NewRequest(7, MethodCallTool, map[string]interface{}{
+		"name": "get_user", "arguments": map[string]interface{}{"username": "octocat"},
+	}))
+	type result struct {
+		data []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		data, err := h.HandleMessage(ctx, call)
+		done <- result{data, err}
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the tool call to reach GitHub")
+	}
+
+	// A cancellation from another session does not match the request
+	other := WithSession(context.Background(), NewSession(TransportStdio))
+	cancel, _ := json.Marshal(NewNotification(MethodCancelled, map[string]interface{}{"requestId": 7, "reason": "user aborted"}))
+	if data, err := h.HandleMessage(other, cancel); data != nil || err != nil {
+		t.Fatalf("Expected no response to a notification, got %s (%v)", data, err)
+	}
+	select {
+	case <-done:
+		t.Fatal("Expected the request to keep running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	h.HandleMessage(ctx, cancel)
+	select {
+	case res := <-done:
+		if res.data != nil || res.err != nil {
+			t.Errorf("Expected no response to a cancelled request, got %s (%v)", res.data, res.err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the tool call to stop once cancelled")
+	}
+	if len(h.requests.byID) != 0 {
+		t.Errorf("Expected no requests left in flight, got %d", len(h.requests.byID))
+	}
+}
+
+func TestInFlightRequests_Sessionless(t *testing.T) {
+	var requests inFlightRequests
+	alice := WithStreamOwner(context.Background(), "alice")
+	mallory := WithStreamOwner(context.Background(), "mallory")
+
+	ctx, cancelled, done := requests.start(alice, 1)
+	defer done()
+	if requests.cancel(mallory, 1) || ctx.Err() != nil {
+		t.Fatal("Expected another client not to cancel the request")
+	}
+	if !requests.cancel(alice, 1) || ctx.Err() == nil || !cancelled() {
+		t.Error("Expected the client to cancel its request")
+	}
+
+	// Anonymous clients without a session cannot be told apart
+	_, _, anonymousDone := requests.start(context.Background(), 2)
+	defer anonymousDone()
+	if requests.cancel(context.Background(), 2) {
+		t.Error("Expected the request of an anonymous client not to be cancellable")
+	}
+}
@@ -0,0 +1,214 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// coerceArguments normalizes common LLM input mistakes into values that match
+// the tool's input schema. Values that cannot be coerced are left untouched so
+// the executor can report a proper error.
+func coerceArguments(schema interface{}, args map[string]interface{}) map[string]interface{} {
+	if args == nil {
+		return args
+	}
+
+	properties := schemaProperties(schema)
+	if properties == nil {
+		return args
+	}
+
+	coerced := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		coerced[key] = coerceValue(properties[key], value)
+	}
+
+	coerceRepositoryReference(properties, coerced)
+
+	return coerced
+}
+
+// schemaProperties extracts the properties map from a JSON schema
+func schemaProperties(schema interface{}) map[string]interface{} {
+	schemaMap, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	properties, ok := schemaMap["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	return properties
+}
+
+// schemaType returns the declared type of a property schema
+func schemaType(propertySchema interface{}) string {
+	propertyMap, ok := propertySchema.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	propertyType, _ := propertyMap["type"].(string)
+	return propertyType
+}
+
+// coerceValue converts a single argument value to the type declared in its
+// schema: numbers and booleans sent as strings, numbers sent for strings,
+// and lists sent as a JSON or comma-separated string or as a single item
+func coerceValue(propertySchema interface{}, value interface{}) interface{} {
+	switch schemaType(propertySchema) {
+	case "integer", "number":
+		if str, ok := value.(string); ok {
+			if n, err := strconv.ParseFloat(strings.TrimSpace(str), 64); err == nil {
+				return n
+			}
+		}
+	case "boolean":
+		if str, ok := value.(string); ok {
+			if b, ok := parseBoolean(str); ok {
+				return b
+			}
+		}
+	case "string":
+		switch v := value.(type) {
+		case string:
+			return canonicalEnumValue(propertySchema, strings.TrimSpace(v))
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	case "array":
+		return coerceArray(propertySchema, value)
+	}
+
+	return value
+}
+
+// parseBoolean parses the ways LLMs spell booleans
+func parseBoolean(str string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(str)) {
+	case "true", "t", "1", "yes", "y", "on":
+		return true, true
+	case "false", "f", "0", "no", "n", "off":
+		return false, true
+	}
+	return false, false
+}
+
+// coerceArray converts a list sent as a JSON array string, a comma-separated
+// string or a single item into an array, and coerces its items
+func coerceArray(propertySchema interface{}, value interface{}) interface{} {
+	var items []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		items = v
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if trimmed == "" {
+			return value
+		}
+		if strings.HasPrefix(trimmed, "[") {
+			if err := json.Unmarshal([]byte(trimmed), &items); err != nil {
+				return value
+			}
+			break
+		}
+		for _, item := range strings.Split(trimmed, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	case float64, bool:
+		items = []interface{}{v}
+	default:
+		return value
+	}
+
+	propertyMap, _ := propertySchema.(map[string]interface{})
+	coerced := make([]interface{}, len(items))
+	for i, item := range items {
+		coerced[i] = coerceValue(propertyMap["items"], item)
+	}
+	return coerced
+}
+
+// canonicalEnumValue returns the enum value of a property schema that
+// matches value ignoring case, or value when none does
+func canonicalEnumValue(propertySchema interface{}, value string) string {
+	propertyMap, _ := propertySchema.(map[string]interface{})
+	for _, item := range schemaList(propertyMap["enum"]) {
+		if enumValue, ok := item.(string); ok && strings.EqualFold(enumValue, value) {
+			return enumValue
+		}
+	}
+	return value
+}
+
+// coerceRepositoryReference rewrites repository URLs and "owner/repo" strings
+// into separate owner and repo arguments when the schema expects both.
+func coerceRepositoryReference(properties map[string]interface{}, args map[string]interface{}) {
+	_, hasOwnerProperty := properties["owner"]
+	_, hasRepoProperty := properties["repo"]
+
+	if hasOwnerProperty {
+		if owner, ok := args["owner"].(string); ok {
+			if parsedOwner, parsedRepo := parseRepositoryReference(owner); parsedOwner != "" {
+				args["owner"] = parsedOwner
+				if _, exists := args["repo"]; !exists && hasRepoProperty && parsedRepo != "" {
+					args["repo"] = parsedRepo
+				}
+			}
+		}
+	}
+
+	if hasRepoProperty {
+		if repo, ok := args["repo"].(string); ok {
+			if parsedOwner, parsedRepo := parseRepositoryReference(repo); parsedRepo != "" {
+				args["repo"] = parsedRepo
+				if _, exists := args["owner"]; !exists && hasOwnerProperty {
+					args["owner"] = parsedOwner
+				}
+			}
+		}
+	}
+}
+
+// parseRepositoryReference splits a GitHub URL or "owner/repo" string into its
+// owner and repository parts. It returns empty strings when the value is
+// neither, and an empty repo when only an owner could be identified.
+func parseRepositoryReference(value string) (string, string) {
+	reference := strings.TrimSpace(value)
+
+	if strings.HasPrefix(reference, "git@github.com:") {
+		reference = strings.TrimPrefix(reference, "git@github.com:")
+	} else if strings.Contains(reference, "://") {
+		parsed, err := url.Parse(reference)
+		if err != nil {
+			return "", ""
+		}
+		if host := strings.ToLower(parsed.Hostname()); host != "github.com" && host != "www.github.com" {
+			return "", ""
+		}
+		reference = parsed.Path
+	} else if strings.HasPrefix(reference, "github.com/") {
+		reference = strings.TrimPrefix(reference, "github.com/")
+	} else if !strings.Contains(reference, "/") {
+		return "", ""
+	}
+
+	parts := strings.Split(strings.Trim(reference, "/"), "/")
+	if len(parts) == 0 || parts[0] == "" {
+		return "", ""
+	}
+
+	owner := parts[0]
+	if len(parts) == 1 {
+		return owner, ""
+	}
+
+	repo := strings.TrimSuffix(parts[1], ".git")
+	return owner, repo
+}
@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testCoercionSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"owner":    map[string]interface{}{"type": "string"},
+			"repo":     map[string]interface{}{"type": "string"},
+			"per_page": map[string]interface{}{"type": "integer"},
+			"private":  map[string]interface{}{"type": "boolean"},
+			"name":     map[string]interface{}{"type": "string"},
+			"method":   map[string]interface{}{"type": "string", "enum": []string{"GET", "POST"}},
+			"labels":   map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			"numbers":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}},
+		},
+	}
+}
+
+func TestCoerceArguments_ScalarTypes(t *testing.T) {
+	args := map[string]interface{}{
+		"per_page": " 50 ",
+		"private":  "TRUE",
+		"name":     "  my-team\n",
+		"method":   "post",
+	}
+
+	coerced := coerceArguments(testCoercionSchema(), args)
+
+	if coerced["per_page"] != float64(50) {
+		t.Errorf("Expected per_page 50, got %v (%T)", coerced["per_page"], coerced["per_page"])
+	}
+	if coerced["private"] != true {
+		t.Errorf("Expected private true, got %v (%T)", coerced["private"], coerced["private"])
+	}
+	if coerced["name"] != "my-team" {
+		t.Errorf("Expected name 'my-team', got %q", coerced["name"])
+	}
+	if coerced["method"] != "POST" {
+		t.Errorf("Expected method 'POST', got %q", coerced["method"])
+	}
+}
+
+func TestCoerceArguments_StringsAndArrays(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]interface{}
+		field    string
+		expected interface{}
+	}{
+		{name: "number as string", args: map[string]interface{}{"name": float64(42)}, field: "name", expected: "42"},
+		{name: "yes as boolean", args: map[string]interface{}{"private": "yes"}, field: "private", expected: true},
+		{name: "comma-separated list", args: map[string]interface{}{"labels": "bug, help wanted,"}, field: "labels", expected: []interface{}{"bug", "help wanted"}},
+		{name: "JSON list", args: map[string]interface{}{"labels": `["bug", "docs"]`}, field: "labels", expected: []interface{}{"bug", "docs"}},
+		{name: "single item", args: map[string]interface{}{"numbers": float64(7)}, field: "numbers", expected: []interface{}{float64(7)}},
+		{name: "list items", args: map[string]interface{}{"numbers": []interface{}{"1", " 2 "}}, field: "numbers", expected: []interface{}{float64(1), float64(2)}},
+		{name: "numbers as string list", args: map[string]interface{}{"numbers": "1,2"}, field: "numbers", expected: []interface{}{float64(1), float64(2)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coerced := coerceArguments(testCoercionSchema(), tt.args)
+			if !reflect.DeepEqual(coerced[tt.field], tt.expected) {
+				t.Errorf("Expected %s %#v, got %#v", tt.field, tt.expected, coerced[tt.field])
+			}
+		})
+	}
+}
+
+func TestCoerceArguments_LeavesInvalidValues(t *testing.T) {
+	args := map[string]interface{}{
+		"per_page": "fifty",
+		"private":  "maybe",
+	}
+
+	coerced := coerceArguments(testCoercionSchema(), args)
+
+	if coerced["per_page"] != "fifty" {
+		t.Errorf("Expected per_page to be left untouched, got %v", coerced["per_page"])
+	}
+	if coerced["private"] != "maybe" {
+		t.Errorf("Expected private to be left untouched, got %v", coerced["private"])
+	}
+}
+
+func TestCoerceArguments_RepositoryReferences(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		expectedOwner interface{}
+		expectedRepo  interface{}
+	}{
+		{
+			name:          "repo as https URL",
+			args:          map[string]interface{}{"repo": "https://github.com/octocat/hello-world"},
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+		},
+		{
+			name:          "repo as owner/repo",
+			args:          map[string]interface{}{"repo": "octocat/hello-world"},
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+		},
+		{
+			name:          "repo as ssh URL keeps explicit owner",
+			args:          map[string]interface{}{"owner": "someone", "repo": "git@github.com:octocat/hello-world.git"},
+			expectedOwner: "someone",
+			expectedRepo:  "hello-world",
+		},
+		{
+			name:          "owner as profile URL",
+			args:          map[string]interface{}{"owner": "https://github.com/octocat/", "repo": "hello-world"},
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+		},
+		{
+			name:          "plain values untouched",
+			args:          map[string]interface{}{"owner": "octocat", "repo": "hello-world"},
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+		},
+		{
+			name:          "non-github URL untouched",
+			args:          map[string]interface{}{"repo": "https://example.com/octocat/hello-world"},
+			expectedOwner: nil,
+			expectedRepo:  "https://example.com/octocat/hello-world",
+		},
+		{
+			name:          "host ending in github.com untouched",
+			args:          map[string]interface{}{"repo": "https://evilgithub.com/octocat/hello-world"},
+			expectedOwner: nil,
+			expectedRepo:  "https://evilgithub.com/octocat/hello-world",
+		},
+		{
+			name:          "www URL",
+			args:          map[string]interface{}{"repo": "https://www.github.com/octocat/hello-world"},
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coerced := coerceArguments(testCoercionSchema(), tt.args)
+
+			if coerced["owner"] != tt.expectedOwner {
+				t.Errorf("Expected owner %v, got %v", tt.expectedOwner, coerced["owner"])
+			}
+			if coerced["repo"] != tt.expectedRepo {
+				t.Errorf("Expected repo %v, got %v", tt.expectedRepo, coerced["repo"])
+			}
+		})
+	}
+}
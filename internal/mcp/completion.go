@@ -0,0 +1,212 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// maxCompletionValues is the most values a completion returns, as the
+	// protocol allows
+	maxCompletionValues = 100
+	// completionPageSize is the page size of the listings completions are
+	// taken from; only their first page is searched
+	completionPageSize = 100
+	// completionCacheTTL is how long the values of an argument are cached,
+	// so typing does not call GitHub on every keystroke
+	completionCacheTTL = 30 * time.Second
+)
+
+// completer lists the values of an argument given the arguments the client
+// already filled in
+type completer func(h *Handler, ctx context.Context, args map[string]string) ([]string, error)
+
+// completers complete arguments by name, the same for prompts, resource
+// templates and tools
+var completers = map[string]completer{
+	"org":       completeOrganizations,
+	"owner":     completeOwners,
+	"username":  completeOwners,
+	"team_slug": completeTeams,
+	"repo":      completeRepositories,
+}
+
+// completeOrganizations lists the organizations of the authenticated user
+func completeOrganizations(h *Handler, ctx context.Context, _ map[string]string) ([]string, error) {
+	orgs, _, err := h.githubClient.ListAuthenticatedUserOrganizations(ctx, 1, completionPageSize)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(orgs))
+	for _, org := range orgs {
+		values = append(values, org.Login)
+	}
+	return values, nil
+}
+
+// completeOwners lists the authenticated user and their organizations
+func completeOwners(h *Handler, ctx context.Context, args map[string]string) ([]string, error) {
+	user, err := h.githubClient.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orgs, err := completeOrganizations(h, ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{user.Login}, orgs...), nil
+}
+
+// completeTeams lists the team slugs of the org argument
+func completeTeams(h *Handler, ctx context.Context, args map[string]string) ([]string, error) {
+	if args["org"] == "" {
+		return nil, nil
+	}
+	teams, _, err := h.githubClient.ListTeams(ctx, args["org"], 1, completionPageSize)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(teams))
+	for _, team := range teams {
+		values = append(values, team.Slug)
+	}
+	return values, nil
+}
+
+// completeRepositories lists the repositories of the owner argument, most
+// recently updated first
+func completeRepositories(h *Handler, ctx context.Context, args map[string]string) ([]string, error) {
+	if args["owner"] == "" {
+		return nil, nil
+	}
+	repos, _, err := h.githubClient.ListRepositories(ctx, args["owner"], "all", "updated", "desc", 1, completionPageSize)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		values = append(values, repo.Name)
+	}
+	return values, nil
+}
+
+// completableArgument reports whether ref names a prompt, resource template
+// or tool taking the named argument
+func (h *Handler) completableArgument(ref CompleteReference, argument string) (bool, error) {
+	switch ref.Type {
+	case RefPrompt:
+		prompt := findPrompt(ref.Name)
+		if prompt == nil {
+			return false, fmt.Errorf("unknown prompt: %s", ref.Name)
+		}
+		for _, arg := range prompt.Arguments {
+			if arg.Name == argument {
+				return true, nil
+			}
+		}
+	case RefResource:
+		for _, template := range resourceTemplates {
+			if template.URITemplate == ref.URI {
+				return strings.Contains(ref.URI, "{"+argument+"}"), nil
+			}
+		}
+		return false, fmt.Errorf("unknown resource template: %s", ref.URI)
+	case RefTool:
+		tool := h.findTool(ref.Name)
+		if tool == nil {
+			return false, fmt.Errorf("unknown tool: %s", ref.Name)
+		}
+		_, ok := schemaProperties(tool.InputSchema)[argument]
+		return ok, nil
+	default:
+		return false, fmt.Errorf("unknown reference type: %s", ref.Type)
+	}
+	return false, nil
+}
+
+// completionCacheKey identifies the values of an argument given the
+// arguments the client already filled in
+func completionCacheKey(argument string, args map[string]string) string {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString(argument)
+	for _, name := range names {
+		fmt.Fprintf(&b, "\x00%s=%s", name, strings.ToLower(args[name]))
+	}
+	return b.String()
+}
+
+// handleComplete handles the completion/complete request. Values are those
+// of the argument starting with the typed value, ignoring case, in the order
+// GitHub lists them. The argument's values are cached for completionCacheTTL
+// and filtered by each typed prefix. GitHub
+// failures leave the completion empty rather than failing the request, so
+// typing is not interrupted.
+func (h *Handler) handleComplete(ctx context.Context, msg *JSONRPCMessage) *JSONRPCMessage {
+	if !h.session(ctx).Initialized() {
+		return NewErrorResponse(msg.ID, ErrorCodeInternalError, "Server not initialized", nil)
+	}
+
+	log := h.logger.WithContext(ctx)
+
+	var req CompleteRequest
+	if err := msg.GetParams(&req); err != nil {
+		log.Error("Failed to parse complete request", "error", err)
+		return NewErrorResponse(msg.ID, ErrorCodeInvalidParams, "Invalid params", nil)
+	}
+
+	ok, err := h.completableArgument(req.Ref, req.Argument.Name)
+	if err != nil {
+		return NewErrorResponse(msg.ID, ErrorCodeInvalidParams, fmt.Sprintf("Invalid params: %v", err), nil)
+	}
+	complete := completers[req.Argument.Name]
+	result := CompleteResult{Completion: Completion{Values: []string{}}}
+	if !ok || complete == nil || h.githubClient == nil {
+		return NewResponse(msg.ID, result)
+	}
+
+	key := completionCacheKey(req.Argument.Name, req.Context.Arguments)
+	shared := sharedCache(ctx)
+	var candidates []string
+	cached := false
+	if shared {
+		candidates, cached = h.completions.get(key)
+	}
+	if !cached {
+		var err error
+		if candidates, err = complete(h, ctx, req.Context.Arguments); err != nil {
+			log.Debug("Completion unavailable", "argument", req.Argument.Name, "error", err)
+			return NewResponse(msg.ID, result)
+		}
+		if shared {
+			h.completions.set(key, candidates, completionCacheTTL)
+		}
+	}
+
+	prefix := strings.ToLower(req.Argument.Value)
+	seen := make(map[string]bool, len(candidates))
+	var values []string
+	for _, value := range candidates {
+		if strings.HasPrefix(strings.ToLower(value), prefix) && !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+
+	result.Completion.Total = len(values)
+	if len(values) > maxCompletionValues {
+		values = values[:maxCompletionValues]
+		result.Completion.HasMore = true
+	}
+	if values != nil {
+		result.Completion.Values = values
+	}
+	return NewResponse(msg.ID, result)
+}
@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestHandleComplete(t *testing.T) {
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Path {
+			case "/user":
+				return mocks.MockJSONResponse(200, `{"login": "octocat"}`), nil
+			case "/user/orgs":
+				return mocks.MockJSONResponse(200, `[{"login": "octo-org"}, {"login": "Octo-Labs"}, {"login": "github"}]`), nil
+			case "/orgs/octo-org/teams":
+				return mocks.MockJSONResponse(200, `[{"slug": "core"}, {"slug": "docs"}]`), nil
+			case "/users/octocat/repos":
+				return mocks.MockJSONResponse(200, `[{"name": "hello-world"}, {"name": "linguist"}]`), nil
+			}
+			return mocks.MockJSONResponse(404, `{"message": "Not Found"}`), nil
+		},
+	})
+
+	h := NewHandler(githubClient, createTestLogger())
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	tests := []struct {
+		name      string
+		params    map[string]interface{}
+		want      []string
+		wantError bool
+	}{
+		{name: "prompt org", params: map[string]interface{}{
+			"ref":      map[string]interface{}{"type": RefPrompt, "name": "review_org_activity"},
+			"argument": map[string]interface{}{"name": "org", "value": "octo"},
+		}, want: []string{"octo-org", "Octo-Labs"}},
+		{name: "resource owner", params: map[string]interface{}{
+			"ref":      map[string]interface{}{"type": RefResource, "uri": "github://repos/{owner}/{repo}"},
+			"argument": map[string]interface{}{"name": "owner", "value": ""},
+		}, want: []string{"octocat", "octo-org", "Octo-Labs", "github"}},
+		{name: "resource repo", params: map[string]interface{}{
+			"ref":      map[string]interface{}{"type": RefResource, "uri": "github://repos/{owner}/{repo}"},
+			"argument": map[string]interface{}{"name": "repo", "value": "h"},
+			"context":  map[string]interface{}{"arguments": map[string]interface{}{"owner": "octocat"}},
+		}, want: []string{"hello-world"}},
+		{name: "tool team slug", params: map[string]interface{}{
+			"ref":      map[string]interface{}{"type": RefTool, "name": "get_team"},
+			"argument": map[string]interface{}{"name": "team_slug", "value": ""},
+			"context":  map[string]interface{}{"arguments": map[string]interface{}{"org": "octo-org"}},
+		}, want: []string{"core", "docs"}},
+		{name: "repo without owner", params: map[string]interface{}{
+			"ref":      map[string]interface{}{"type": RefPrompt, "name": "triage_issues"},
+			"argument": map[string]interface{}{"name": "repo", "value": ""},
+		}, want: []string{}},
+		{name: "argument without completer", params: map[string]interface{}{
+			"ref":      map[string]interface{}{"type": RefPrompt, "name": "triage_issues"},
+			"argument": map[string]interface{}{"name": "label", "value": "b"},
+		}, want: []string{}},
+		{name: "unknown prompt", params: map[string]interface{}{
+			"ref":      map[string]interface{}{"type": RefPrompt, "name": "nope"},
+			"argument": map[string]interface{}{"name": "org", "value": ""},
+		}, wantError: true},
+		{name: "unknown template", params: map[string]interface{}{
+			"ref":      map[string]interface{}{"type": RefResource, "uri": "github://nope/{org}"},
+			"argument": map[string]interface{}{"name": "org", "value": ""},
+		}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := h.handleComplete(ctx, NewRequest(1, MethodComplete, tt.params))
+			if tt.wantError {
+				if resp.Error == nil || resp.Error.Code != ErrorCodeInvalidParams {
+					t.Fatalf("Expected an invalid params error, got %+v", resp.Error)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Unexpected error: %+v", resp.Error)
+			}
+			completion := resp.Result.(CompleteResult).Completion
+			if len(completion.Values) != len(tt.want) || completion.Total != len(tt.want) {
+				t.Fatalf("Expected %v, got %+v", tt.want, completion)
+			}
+			for i := range tt.want {
+				if completion.Values[i] != tt.want[i] {
+					t.Errorf("Expected %v, got %v", tt.want, completion.Values)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleComplete_Cached(t *testing.T) {
+	requests := 0
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests++
+			return mocks.MockJSONResponse(200, `[{"name": "hello-world"}, {"name": "linguist"}]`), nil
+		},
+	})
+
+	h := NewHandler(githubClient, createTestLogger())
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+	complete := func(owner, value string) []string {
+		resp := h.handleComplete(ctx, NewRequest(1, MethodComplete, map[string]interface{}{
+			"ref":      map[string]interface{}{"type": RefResource, "uri": "github://repos/{owner}/{repo}"},
+			"argument": map[string]interface{}{"name": "repo", "value": value},
+			"context":  map[string]interface{}{"arguments": map[string]interface{}{"owner": owner}},
+		}))
+		return resp.Result.(CompleteResult).Completion.Values
+	}
+
+	if values := complete("octocat", "h"); len(values) != 1 || values[0] != "hello-world" {
+		t.Fatalf("Unexpected values %v", values)
+	}
+	if values := complete("octocat", "H"); len(values) != 1 || requests != 1 {
+		t.Errorf("Expected the same prefix to be served from the cache, got %v after %d requests", values, requests)
+	}
+	if values := complete("octocat", "li"); len(values) != 1 || values[0] != "linguist" || requests != 1 {
+		t.Errorf("Expected another prefix to be filtered from the cache, got %v after %d requests", values, requests)
+	}
+	complete("octo-org", "h")
+	if requests != 2 {
+		t.Errorf("Expected a request per owner, got %d", requests)
+	}
+
+	values := h.handleComplete(client.WithToken(ctx, "user-token"), NewRequest(1, MethodComplete, map[string]interface{}{
+		"ref":      map[string]interface{}{"type": RefResource, "uri": "github://repos/{owner}/{repo}"},
+		"argument": map[string]interface{}{"name": "repo", "value": "h"},
+		"context":  map[string]interface{}{"arguments": map[string]interface{}{"owner": "octocat"}},
+	})).Result.(CompleteResult).Completion.Values
+	if len(values) != 1 || requests != 3 {
+		t.Errorf("Expected a caller's own token to bypass the cache, got %v after %d requests", values, requests)
+	}
+}
@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// degradedMetaKey is the _meta key marking results served by a read-only
+// fallback because the token lacks permission for the requested data
+const degradedMetaKey = "github-mcp/degraded"
+
+// DegradedResult describes why a result holds less than was requested
+type DegradedResult struct {
+	// Reason is the GitHub error that caused the fallback
+	Reason string `json:"reason"`
+	// Fallback names the data returned instead, such as "public_members"
+	Fallback string `json:"fallback"`
+}
+
+// isPermissionDenied reports whether err is a 403 caused by the token's
+// permissions rather than a rate limit
+func isPermissionDenied(err error) bool {
+	appErr, ok := err.(*errors.AppError)
+	if !ok || appErr.Type != errors.ErrorTypeAuthorization {
+		return false
+	}
+	return !strings.Contains(strings.ToLower(appErr.Message), "rate limit")
+}
+
+// markDegraded notes at the top of result that it holds the data named by
+// fallback, described to the user by description, because of cause, and
+// records both in its _meta
+func (h *Handler) markDegraded(result *CallToolResult, fallback, description string, cause error) *CallToolResult {
+	reason := cause.Error()
+	if appErr, ok := cause.(*errors.AppError); ok {
+		reason = appErr.Message
+	}
+
+	note := Content{
+		Type: "text",
+		Text: h.messages.Sprintf("Degraded result: the token lacks permission for the requested data (%s); showing %s instead.", reason, description),
+	}
+	result.Content = append([]Content{note}, result.Content...)
+
+	if result.Meta == nil {
+		result.Meta = make(map[string]interface{})
+	}
+	result.Meta[degradedMetaKey] = DegradedResult{Reason: reason, Fallback: fallback}
+	return result
+}
+
+// userTeamsInOrg lists the teams of org the authenticated user belongs to,
+// which remain visible when the token cannot list all of the org's teams
+func (h *Handler) userTeamsInOrg(ctx context.Context, org string) ([]client.Team, error) {
+	teams := []client.Team{}
+	for page := 1; page <= maxSnapshotPages; page++ {
+		userTeams, pageInfo, err := h.githubClient.ListAuthenticatedUserTeams(ctx, page, snapshotPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, team := range userTeams {
+			if strings.EqualFold(team.Organization.Login, org) {
+				teams = append(teams, team)
+			}
+		}
+		if pageInfo == nil || pageInfo.NextPage == 0 {
+			break
+		}
+	}
+	return teams, nil
+}
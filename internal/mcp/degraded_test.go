@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+// newDegradedTestHandler returns a handler whose GitHub API rejects
+// admin-only organization endpoints with message and serves the rest
+func newDegradedTestHandler(message string) *Handler {
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Path {
+			case "/orgs/octo-org/members", "/orgs/octo-org/teams", "/orgs/octo-org/members/octocat":
+				return mocks.MockErrorResponse(403, message), nil
+			case "/orgs/octo-org/public_members":
+				return mocks.MockJSONResponse(200, `[{"login": "octocat", "id": 1}]`), nil
+			case "/orgs/octo-org/public_members/octocat":
+				return mocks.MockJSONResponse(204, ``), nil
+			case "/user/teams":
+				return mocks.MockJSONResponse(200, `[
+					{"id": 1, "slug": "devs", "organization": {"login": "Octo-Org"}},
+					{"id": 2, "slug": "ops", "organization": {"login": "other-org"}}
+				]`), nil
+			}
+			return mocks.MockErrorResponse(404, "Not Found"), nil
+		},
+	})
+	return NewHandler(githubClient, createTestLogger())
+}
+
+func TestDegraded_FallsBackToReadOnlyData(t *testing.T) {
+	tests := []struct {
+		name     string
+		execute  func(h *Handler) (*CallToolResult, error)
+		fallback string
+		want     string
+		exclude  string
+	}{
+		{
+			name: "members",
+			execute: func(h *Handler) (*CallToolResult, error) {
+				return h.executeListOrganizationMembers(context.Background(), map[string]interface{}{"org": "octo-org", "role": "admin"})
+			},
+			fallback: "public_members",
+			want:     `"login":"octocat"`,
+		},
+		{
+			name: "membership",
+			execute: func(h *Handler) (*CallToolResult, error) {
+				return h.executeCheckOrganizationMembership(context.Background(), map[string]interface{}{"org": "octo-org", "username": "octocat"})
+			},
+			fallback: "public_membership",
+			want:     "octocat",
+		},
+		{
+			name: "teams",
+			execute: func(h *Handler) (*CallToolResult, error) {
+				return h.executeListTeams(context.Background(), map[string]interface{}{"org": "octo-org"})
+			},
+			fallback: "user_teams",
+			want:     `"slug":"devs"`,
+			exclude:  `"slug":"ops"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newDegradedTestHandler("Must have admin rights to Repository.")
+			result, err := tt.execute(h)
+			if err != nil || result.IsError {
+				t.Fatalf("Expected a degraded result, got %v %+v", err, result)
+			}
+
+			if len(result.Content) != 2 || !strings.HasPrefix(result.Content[0].Text, "Degraded result:") {
+				t.Fatalf("Expected a degraded note before the result, got %+v", result.Content)
+			}
+			if !strings.Contains(result.Content[1].Text, tt.want) {
+				t.Errorf("Expected the result to contain %s, got %q", tt.want, result.Content[1].Text)
+			}
+			if tt.exclude != "" && strings.Contains(result.Content[1].Text, tt.exclude) {
+				t.Errorf("Expected the result not to contain %s, got %q", tt.exclude, result.Content[1].Text)
+			}
+
+			degraded, ok := result.Meta[degradedMetaKey].(DegradedResult)
+			if !ok || degraded.Fallback != tt.fallback || !strings.Contains(degraded.Reason, "admin rights") {
+				t.Errorf("Expected %s fallback metadata, got %+v", tt.fallback, result.Meta)
+			}
+		})
+	}
+}
+
+func TestDegraded_RateLimitIsNotDegraded(t *testing.T) {
+	h := newDegradedTestHandler("API rate limit exceeded for user ID 1.")
+
+	result, err := h.executeListOrganizationMembers(context.Background(), map[string]interface{}{"org": "octo-org"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Errorf("Expected a rate limited request to fail, got %+v", result)
+	}
+	if _, ok := result.Meta[degradedMetaKey]; ok {
+		t.Errorf("Expected no degraded metadata, got %+v", result.Meta)
+	}
+}
@@ -0,0 +1,239 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/auth"
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/requestid"
+)
+
+const (
+	// maxDeletionRecords bounds the deletions retained in memory
+	maxDeletionRecords = 500
+	// maxSnapshotPages bounds the pages fetched to capture an object's state
+	maxSnapshotPages = 10
+	// snapshotPageSize is the page size used when capturing an object's state
+	snapshotPageSize = 100
+)
+
+// DeletionRecord is the state of an object captured before a destructive
+// tool removed it
+type DeletionRecord struct {
+	ID        uint64                 `json:"id"`
+	Tool      string                 `json:"tool"`
+	Object    string                 `json:"object"`
+	Arguments map[string]interface{} `json:"arguments"`
+	State     json.RawMessage        `json:"state"`
+	Actor     string                 `json:"actor,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+	DeletedAt time.Time              `json:"deleted_at"`
+}
+
+// deletionLog records deletions made in safe delete mode, newest last. When a
+// file is configured, each record is also appended to it as a JSON line so
+// state survives restarts.
+type deletionLog struct {
+	mu      sync.Mutex
+	nextID  uint64
+	records []DeletionRecord
+	file    string
+}
+
+// add records a completed deletion
+func (l *deletionLog) add(record *DeletionRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.nextID++
+	record.ID = l.nextID
+	record.DeletedAt = time.Now().UTC()
+
+	l.records = append(l.records, *record)
+	if len(l.records) > maxDeletionRecords {
+		l.records = l.records[len(l.records)-maxDeletionRecords:]
+	}
+
+	if l.file == "" {
+		return nil
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// recent returns up to limit records, newest first, optionally only those
+// made by tool
+func (l *deletionLog) recent(tool string, limit int) []DeletionRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	records := make([]DeletionRecord, 0, limit)
+	for i := len(l.records) - 1; i >= 0 && len(records) < limit; i-- {
+		if tool == "" || l.records[i].Tool == tool {
+			records = append(records, l.records[i])
+		}
+	}
+	return records
+}
+
+// SetSafeDelete enables or disables safe delete mode. In safe delete mode
+// destructive tools capture the current state of the object before removing
+// it; the records are listed by list_recent_deletions and, when file is not
+// empty, appended to it as JSON lines.
+func (h *Handler) SetSafeDelete(enabled bool, file string) {
+	if !enabled {
+		h.deletions = nil
+		return
+	}
+	h.deletions = &deletionLog{file: file}
+}
+
+// captureDeletion captures the state of an object about to be deleted by
+// tool. It returns a nil record when safe delete mode is disabled, and an
+// error result aborting the deletion when the state cannot be captured.
+func (h *Handler) captureDeletion(ctx context.Context, tool, object string, args map[string]interface{}, snapshot func() (interface{}, error)) (*DeletionRecord, *CallToolResult) {
+	if h.deletions == nil {
+		return nil, nil
+	}
+
+	state, err := snapshot()
+	if err == nil {
+		var data []byte
+		if data, err = json.Marshal(state); err == nil {
+			record := &DeletionRecord{
+				Tool:      tool,
+				Object:    object,
+				Arguments: args,
+				State:     data,
+				RequestID: requestid.FromContext(ctx),
+			}
+			if identity, ok := auth.IdentityFromContext(ctx); ok {
+				record.Actor = identity.Subject
+			}
+			return record, nil
+		}
+	}
+
+	return nil, &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: h.messages.Sprintf("Deletion aborted: could not export the current state of %s: %v", object, err),
+		}},
+		IsError: true,
+	}
+}
+
+// recordDeletion stores a record captured by captureDeletion once the
+// deletion succeeded; a nil record is ignored
+func (h *Handler) recordDeletion(ctx context.Context, record *DeletionRecord) {
+	if record == nil || h.deletions == nil {
+		return
+	}
+	if err := h.deletions.add(record); err != nil {
+		h.logger.WithContext(ctx).Error("Failed to export deletion record",
+			"tool", record.Tool,
+			"object", record.Object,
+			"error", err)
+	}
+}
+
+// teamSnapshot is the state of a team captured before it is deleted
+type teamSnapshot struct {
+	Team         *client.Team            `json:"team"`
+	Members      []client.TeamMember     `json:"members"`
+	Repositories []client.TeamRepository `json:"repositories"`
+}
+
+// snapshotTeam captures a team with its members and repositories
+func (h *Handler) snapshotTeam(ctx context.Context, org, teamSlug string) (interface{}, error) {
+	team, err := h.githubClient.GetTeam(ctx, org, teamSlug)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &teamSnapshot{Team: team}
+
+	for page := 1; page <= maxSnapshotPages; page++ {
+		members, pageInfo, err := h.githubClient.ListTeamMembers(ctx, org, teamSlug, "all", page, snapshotPageSize)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Members = append(snapshot.Members, members...)
+		if pageInfo == nil || pageInfo.NextPage == 0 {
+			break
+		}
+	}
+
+	for page := 1; page <= maxSnapshotPages; page++ {
+		repos, pageInfo, err := h.githubClient.ListTeamRepositories(ctx, org, teamSlug, page, snapshotPageSize)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Repositories = append(snapshot.Repositories, repos...)
+		if pageInfo == nil || pageInfo.NextPage == 0 {
+			break
+		}
+	}
+
+	return snapshot, nil
+}
+
+// snapshotTeamMembership captures a user's membership of a team
+func (h *Handler) snapshotTeamMembership(ctx context.Context, org, teamSlug, username string) (interface{}, error) {
+	return h.githubClient.GetTeamMembership(ctx, org, teamSlug, username)
+}
+
+// snapshotTeamRepository captures a team's access to a repository, including
+// its permissions
+func (h *Handler) snapshotTeamRepository(ctx context.Context, org, teamSlug, owner, repo string) (interface{}, error) {
+	for page := 1; page <= maxSnapshotPages; page++ {
+		repos, pageInfo, err := h.githubClient.ListTeamRepositories(ctx, org, teamSlug, page, snapshotPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range repos {
+			if strings.EqualFold(repos[i].Owner.Login, owner) && strings.EqualFold(repos[i].Name, repo) {
+				return &repos[i], nil
+			}
+		}
+		if pageInfo == nil || pageInfo.NextPage == 0 {
+			break
+		}
+	}
+	return nil, errors.NotFound("repository not found in team repositories").WithContext("repository", owner+"/"+repo)
+}
+
+// snapshotInstallationRepository captures a repository accessible to an installation
+func (h *Handler) snapshotInstallationRepository(ctx context.Context, installationID, repositoryID int64) (interface{}, error) {
+	for page := 1; page <= maxSnapshotPages; page++ {
+		list, pageInfo, err := h.githubClient.ListInstallationRepositories(ctx, installationID, page, snapshotPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Repositories {
+			if list.Repositories[i].ID == repositoryID {
+				return &list.Repositories[i], nil
+			}
+		}
+		if pageInfo == nil || pageInfo.NextPage == 0 {
+			break
+		}
+	}
+	return nil, errors.NotFound("repository not found in installation repositories").WithContext("repositoryId", repositoryID)
+}
@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestDeletionLog_Recent(t *testing.T) {
+	log := &deletionLog{}
+	for _, tool := range []string{"delete_team", "remove_team_membership", "delete_team"} {
+		if err := log.add(&DeletionRecord{Tool: tool}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	records := log.recent("", 2)
+	if len(records) != 2 || records[0].ID != 3 || records[1].ID != 2 {
+		t.Errorf("Expected the 2 newest records, got %+v", records)
+	}
+	if records := log.recent("delete_team", 10); len(records) != 2 || records[1].ID != 1 {
+		t.Errorf("Expected 2 delete_team records, got %+v", records)
+	}
+}
+
+func TestSafeDelete_DeleteTeam(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			mu.Unlock()
+
+			switch {
+			case req.Method == http.MethodDelete:
+				return mocks.MockJSONResponse(204, ``), nil
+			case strings.HasSuffix(req.URL.Path, "/members"):
+				return mocks.MockJSONResponse(200, `[{"login": "octocat", "id": 1}]`), nil
+			case strings.HasSuffix(req.URL.Path, "/repos"):
+				return mocks.MockJSONResponse(200, `[{"name": "hello-world", "owner": {"login": "octo-org"}, "permissions": {"admin": true}}]`), nil
+			default:
+				return mocks.MockJSONResponse(200, `{"id": 7, "slug": "devs", "name": "Devs"}`), nil
+			}
+		},
+	})
+
+	logFile := filepath.Join(t.TempDir(), "deletions.jsonl")
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetSafeDelete(true, logFile)
+
+	result, err := h.executeDeleteTeam(context.Background(), map[string]interface{}{"org": "octo-org", "team_slug": "devs"})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected failure: %v %+v", err, result)
+	}
+	if last := requests[len(requests)-1]; last != "DELETE /orgs/octo-org/teams/devs" {
+		t.Errorf("Expected the team to be deleted after its state was captured, got %v", requests)
+	}
+
+	records := h.deletions.recent("", 10)
+	if len(records) != 1 || records[0].Object != "team octo-org/devs" {
+		t.Fatalf("Expected one deletion record, got %+v", records)
+	}
+	var state teamSnapshot
+	if err := json.Unmarshal(records[0].State, &state); err != nil {
+		t.Fatalf("Failed to decode state: %v", err)
+	}
+	if state.Team == nil || state.Team.Slug != "devs" || len(state.Members) != 1 || len(state.Repositories) != 1 {
+		t.Errorf("Unexpected captured state %+v", state)
+	}
+
+	f, err := os.Open(logFile)
+	if err != nil {
+		t.Fatalf("Expected deletion log file: %v", err)
+	}
+	defer f.Close()
+	lines := 0
+	for scanner := bufio.NewScanner(f); scanner.Scan(); {
+		lines++
+	}
+	if lines != 1 {
+		t.Errorf("Expected 1 line in the deletion log file, got %d", lines)
+	}
+
+	listed, err := h.executeListRecentDeletions(context.Background(), map[string]interface{}{"tool": "delete_team"})
+	if err != nil || listed.IsError || !strings.Contains(listed.Content[0].Text, `"object":"team octo-org/devs"`) {
+		t.Errorf("Expected list_recent_deletions to include the team, got %+v", listed)
+	}
+}
+
+func TestSafeDelete_AbortsWhenStateUnavailable(t *testing.T) {
+	deleted := false
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodDelete {
+				deleted = true
+				return mocks.MockJSONResponse(204, ``), nil
+			}
+			return mocks.MockErrorResponse(500, "Internal Server Error"), nil
+		},
+	})
+
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetSafeDelete(true, "")
+
+	result, err := h.executeRemoveTeamMembership(context.Background(), map[string]interface{}{
+		"org": "octo-org", "team_slug": "devs", "username": "octocat",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "Deletion aborted") {
+		t.Errorf("Expected the deletion to be aborted, got %+v", result)
+	}
+	if deleted {
+		t.Error("Expected no DELETE request when the state could not be captured")
+	}
+}
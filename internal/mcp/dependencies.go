@@ -0,0 +1,307 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+)
+
+const (
+	// maxDependencyRepos bounds the number of repositories scanned for a dependency map
+	maxDependencyRepos = 200
+	// dependencyScanConcurrency is the number of repositories scanned in parallel
+	dependencyScanConcurrency = 5
+)
+
+// manifestFiles are the root-level manifests scanned for dependencies
+var manifestFiles = []string{"go.mod", "package.json"}
+
+// dependencyMap describes which repositories of an organization depend on each other
+type dependencyMap struct {
+	Org          string                `json:"org"`
+	Repositories []repositoryManifests `json:"repositories"`
+	Edges        []dependencyEdge      `json:"edges"`
+	Scanned      int                   `json:"scanned"`
+	GeneratedAt  string                `json:"generated_at"`
+}
+
+// repositoryManifests holds what a repository publishes and depends on, as declared in its manifests
+type repositoryManifests struct {
+	Name      string   `json:"name"`
+	Manifests []string `json:"manifests"`
+	Publishes []string `json:"publishes,omitempty"`
+	Requires  []string `json:"-"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// dependencyEdge records that one repository depends on a package published by another
+type dependencyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Via  string `json:"via"`
+}
+
+// dependencyQueryResult answers which repositories depend on, and are depended on by, a repository
+type dependencyQueryResult struct {
+	Org          string           `json:"org"`
+	Repository   string           `json:"repository"`
+	Publishes    []string         `json:"publishes"`
+	Dependents   []dependencyEdge `json:"dependents"`
+	Dependencies []dependencyEdge `json:"dependencies"`
+	GeneratedAt  string           `json:"generated_at"`
+}
+
+// query returns the edges into and out of a repository
+func (m *dependencyMap) query(repo string) (*dependencyQueryResult, error) {
+	result := &dependencyQueryResult{
+		Org:          m.Org,
+		Dependents:   []dependencyEdge{},
+		Dependencies: []dependencyEdge{},
+		GeneratedAt:  m.GeneratedAt,
+	}
+
+	found := false
+	for _, r := range m.Repositories {
+		if strings.EqualFold(r.Name, repo) {
+			result.Repository = r.Name
+			result.Publishes = r.Publishes
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.NotFound(fmt.Sprintf("repository %s was not scanned in organization %s", repo, m.Org))
+	}
+
+	for _, edge := range m.Edges {
+		if edge.To == result.Repository {
+			result.Dependents = append(result.Dependents, edge)
+		}
+		if edge.From == result.Repository {
+			result.Dependencies = append(result.Dependencies, edge)
+		}
+	}
+
+	return result, nil
+}
+
+// orgDependencyMap returns the dependency map of an organization, using the cache unless refresh is set
+func (h *Handler) orgDependencyMap(ctx context.Context, org string, repos []string, refresh bool) (*dependencyMap, error) {
+	sortedRepos := append([]string(nil), repos...)
+	sort.Strings(sortedRepos)
+	key := strings.ToLower(org + ":" + strings.Join(sortedRepos, ","))
+
+	shared := sharedCache(ctx)
+	if !refresh && shared {
+		if depMap, ok := h.dependencyMaps.get(key); ok {
+			execstats.FromContext(ctx).AddCacheHit()
+			h.logger.Debug("Serving cached dependency map", "org", org)
+			SetResultMeta(ctx, cacheMetaKey, CachedReport{Hit: true, GeneratedAt: depMap.GeneratedAt})
+			return depMap, nil
+		}
+	}
+
+	depMap, err := h.buildDependencyMap(ctx, org, sortedRepos)
+	if err != nil {
+		return nil, err
+	}
+	SetResultMeta(ctx, cacheMetaKey, CachedReport{GeneratedAt: depMap.GeneratedAt})
+
+	if shared {
+		h.dependencyMaps.set(key, depMap, h.CacheTTL())
+	}
+	return depMap, nil
+}
+
+// buildDependencyMap scans repository manifests and links dependencies to the repositories publishing them
+func (h *Handler) buildDependencyMap(ctx context.Context, org string, repos []string) (*dependencyMap, error) {
+	if len(repos) == 0 {
+		var err error
+		if repos, err = h.listScannableRepositories(ctx, org); err != nil {
+			return nil, err
+		}
+	}
+
+	scanned := make([]repositoryManifests, len(repos))
+	semaphore := make(chan struct{}, dependencyScanConcurrency)
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		wg.Add(1)
+		go func(i int, repo string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			scanned[i] = h.scanRepositoryManifests(ctx, org, repo)
+		}(i, repo)
+	}
+	wg.Wait()
+
+	return &dependencyMap{
+		Org:          org,
+		Repositories: scanned,
+		Edges:        linkDependencies(scanned),
+		Scanned:      len(scanned),
+		GeneratedAt:  time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// linkDependencies links each repository's requirements to the repositories publishing them
+func linkDependencies(repos []repositoryManifests) []dependencyEdge {
+	// Index which repository publishes each module or package
+	publishers := make(map[string]string)
+	for _, r := range repos {
+		for _, name := range r.Publishes {
+			publishers[name] = r.Name
+		}
+	}
+
+	edges := []dependencyEdge{}
+	for _, r := range repos {
+		for _, dep := range r.Requires {
+			if publisher, ok := publishers[dep]; ok && publisher != r.Name {
+				edges = append(edges, dependencyEdge{From: r.Name, To: publisher, Via: dep})
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+
+	return edges
+}
+
+// listScannableRepositories lists the organization's active, non-fork repositories
+func (h *Handler) listScannableRepositories(ctx context.Context, org string) ([]string, error) {
+	var repos []string
+	for page := 1; len(repos) < maxDependencyRepos; page++ {
+		batch, pageInfo, err := h.githubClient.ListOrganizationRepositories(ctx, org, "all", page, 100)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range batch {
+			if !repo.Archived && !repo.Fork && len(repos) < maxDependencyRepos {
+				repos = append(repos, repo.Name)
+			}
+		}
+		if !pageInfo.HasMore() {
+			break
+		}
+	}
+	return repos, nil
+}
+
+// scanRepositoryManifests reads and parses the manifests of a single repository.
+// Missing manifests are skipped; other failures are recorded on the result.
+func (h *Handler) scanRepositoryManifests(ctx context.Context, org, repo string) repositoryManifests {
+	result := repositoryManifests{Name: repo, Manifests: []string{}}
+
+	for _, manifest := range manifestFiles {
+		file, err := h.githubClient.GetFileContents(ctx, org, repo, manifest, "")
+		if err != nil {
+			if !errors.IsType(err, errors.ErrorTypeNotFound) {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", manifest, err))
+			}
+			continue
+		}
+
+		content, err := file.DecodedContent()
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", manifest, err))
+			continue
+		}
+
+		var publishes, requires []string
+		switch manifest {
+		case "go.mod":
+			publishes, requires = parseGoMod(content)
+		case "package.json":
+			publishes, requires, err = parsePackageJSON(content)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", manifest, err))
+			continue
+		}
+
+		result.Manifests = append(result.Manifests, manifest)
+		result.Publishes = append(result.Publishes, publishes...)
+		result.Requires = append(result.Requires, requires...)
+	}
+
+	return result
+}
+
+// parseGoMod extracts the module path and required modules from a go.mod file
+func parseGoMod(content []byte) (publishes, requires []string) {
+	inRequireBlock := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if comment := strings.Index(line, "//"); comment >= 0 {
+			line = strings.TrimSpace(line[:comment])
+		}
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		switch {
+		case inRequireBlock && line == ")":
+			inRequireBlock = false
+		case inRequireBlock:
+			requires = append(requires, fields[0])
+		case fields[0] == "module" && len(fields) > 1:
+			publishes = append(publishes, strings.Trim(fields[1], `"`))
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inRequireBlock = true
+		case fields[0] == "require" && len(fields) > 1:
+			requires = append(requires, fields[1])
+		}
+	}
+
+	return publishes, requires
+}
+
+// parsePackageJSON extracts the package name and dependencies from a package.json file
+func parsePackageJSON(content []byte) (publishes, requires []string, err error) {
+	var pkg struct {
+		Name                 string            `json:"name"`
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, nil, fmt.Errorf("invalid package.json: %w", err)
+	}
+
+	if pkg.Name != "" {
+		publishes = append(publishes, pkg.Name)
+	}
+
+	seen := make(map[string]bool)
+	for _, deps := range []map[string]string{pkg.Dependencies, pkg.DevDependencies, pkg.PeerDependencies, pkg.OptionalDependencies} {
+		for name := range deps {
+			if !seen[name] {
+				seen[name] = true
+				requires = append(requires, name)
+			}
+		}
+	}
+	sort.Strings(requires)
+
+	return publishes, requires, nil
+}
@@ -0,0 +1,180 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestParseGoMod(t *testing.T) {
+	content := []byte(`module github.com/octo-org/api // main service
+
+go 1.21
+
+require github.com/octo-org/logging v1.2.0
+
+require (
+	github.com/octo-org/auth v0.3.1
+	// github.com/octo-org/unused v1.0.0
+	golang.org/x/sync v0.5.0 // indirect
+)
+`)
+
+	publishes, requires := parseGoMod(content)
+
+	if !reflect.DeepEqual(publishes, []string{"github.com/octo-org/api"}) {
+		t.Errorf("Unexpected module path: %v", publishes)
+	}
+	expected := []string{"github.com/octo-org/logging", "github.com/octo-org/auth", "golang.org/x/sync"}
+	if !reflect.DeepEqual(requires, expected) {
+		t.Errorf("Expected requires %v, got %v", expected, requires)
+	}
+}
+
+func TestParsePackageJSON(t *testing.T) {
+	content := []byte(`{
+  "name": "@octo-org/web",
+  "dependencies": {"@octo-org/ui": "^2.0.0", "react": "^18.0.0"},
+  "devDependencies": {"@octo-org/lint-config": "1.0.0", "react": "^18.0.0"}
+}`)
+
+	publishes, requires, err := parsePackageJSON(content)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(publishes, []string{"@octo-org/web"}) {
+		t.Errorf("Unexpected package name: %v", publishes)
+	}
+	expected := []string{"@octo-org/lint-config", "@octo-org/ui", "react"}
+	if !reflect.DeepEqual(requires, expected) {
+		t.Errorf("Expected requires %v, got %v", expected, requires)
+	}
+
+	if _, _, err := parsePackageJSON([]byte("not json")); err == nil {
+		t.Error("Expected error for invalid package.json")
+	}
+}
+
+func TestLinkDependencies(t *testing.T) {
+	depMap := &dependencyMap{
+		Org: "octo-org",
+		Repositories: []repositoryManifests{
+			{Name: "api", Publishes: []string{"github.com/octo-org/api"}, Requires: []string{"github.com/octo-org/logging", "golang.org/x/sync"}},
+			{Name: "logging", Publishes: []string{"github.com/octo-org/logging"}},
+			{Name: "worker", Publishes: []string{"github.com/octo-org/worker"}, Requires: []string{"github.com/octo-org/logging", "github.com/octo-org/api"}},
+		},
+	}
+	depMap.Edges = linkDependencies(depMap.Repositories)
+
+	expected := []dependencyEdge{
+		{From: "api", To: "logging", Via: "github.com/octo-org/logging"},
+		{From: "worker", To: "api", Via: "github.com/octo-org/api"},
+		{From: "worker", To: "logging", Via: "github.com/octo-org/logging"},
+	}
+	if !reflect.DeepEqual(depMap.Edges, expected) {
+		t.Fatalf("Expected edges %v, got %v", expected, depMap.Edges)
+	}
+
+	result, err := depMap.query("Logging")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Dependents) != 2 || len(result.Dependencies) != 0 {
+		t.Errorf("Expected 2 dependents and no dependencies, got %d and %d", len(result.Dependents), len(result.Dependencies))
+	}
+
+	if _, err := depMap.query("missing"); err == nil {
+		t.Error("Expected error for a repository that was not scanned")
+	}
+}
+
+func TestExecuteGetDependencyMap(t *testing.T) {
+	manifests := map[string]string{
+		"/repos/octo-org/logging/contents/go.mod":      "module github.com/octo-org/logging\n",
+		"/repos/octo-org/api/contents/go.mod":          "module github.com/octo-org/api\n\nrequire github.com/octo-org/logging v1.2.0\n",
+		"/repos/octo-org/web/contents/package.json":    `{"name": "@octo-org/web", "dependencies": {"react": "^18.0.0"}}`,
+		"/repos/octo-org/ui/contents/package.json":     `{"name": "@octo-org/ui", "dependencies": {"@octo-org/web": "^1.0.0"}}`,
+		"/repos/octo-org/broken/contents/package.json": `{"name":`,
+	}
+	var requests atomic.Int32
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests.Add(1)
+			if req.URL.Path == "/orgs/octo-org/repos" {
+				return mocks.MockJSONResponse(200, `[
+					{"name": "logging"}, {"name": "api"}, {"name": "web"}, {"name": "ui"}, {"name": "broken"},
+					{"name": "legacy", "archived": true}, {"name": "api-fork", "fork": true}
+				]`), nil
+			}
+			content, ok := manifests[req.URL.Path]
+			if !ok {
+				return mocks.MockJSONResponse(404, `{"message": "Not Found"}`), nil
+			}
+			return mocks.MockJSONResponse(200, fmt.Sprintf(`{"type": "file", "encoding": "base64", "content": %q}`,
+				base64.StdEncoding.EncodeToString([]byte(content)))), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetCacheTTL(time.Minute)
+	ctx := context.Background()
+
+	result, err := h.executeGetDependencyMap(ctx, map[string]interface{}{"org": "octo-org"})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %+v", err, result)
+	}
+	var depMap dependencyMap
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &depMap); err != nil {
+		t.Fatalf("Failed to parse dependency map: %v", err)
+	}
+	if depMap.Scanned != 5 {
+		t.Errorf("Expected the 5 active, non-fork repositories to be scanned, got %d", depMap.Scanned)
+	}
+	expected := []dependencyEdge{
+		{From: "api", To: "logging", Via: "github.com/octo-org/logging"},
+		{From: "ui", To: "web", Via: "@octo-org/web"},
+	}
+	if !reflect.DeepEqual(depMap.Edges, expected) {
+		t.Errorf("Expected edges %v, got %v", expected, depMap.Edges)
+	}
+	for _, repo := range depMap.Repositories {
+		if repo.Name == "broken" && (len(repo.Errors) != 1 || !strings.HasPrefix(repo.Errors[0], "package.json: ")) {
+			t.Errorf("Expected the invalid manifest to be reported, got %v", repo.Errors)
+		}
+	}
+
+	// The repository query is answered from the cached map
+	scanned := requests.Load()
+	result, _ = h.executeGetDependencyMap(ctx, map[string]interface{}{"org": "octo-org", "repository": "LOGGING"})
+	var query dependencyQueryResult
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &query); err != nil {
+		t.Fatalf("Failed to parse query result: %v", err)
+	}
+	if query.Repository != "logging" || len(query.Dependents) != 1 || query.Dependents[0].From != "api" || len(query.Dependencies) != 0 {
+		t.Errorf("Unexpected query result %+v", query)
+	}
+	if requests.Load() != scanned {
+		t.Errorf("Expected the cached map to be used, got %d more requests", requests.Load()-scanned)
+	}
+
+	h.executeGetDependencyMap(ctx, map[string]interface{}{"org": "octo-org", "refresh": true})
+	if requests.Load() == scanned {
+		t.Error("Expected refresh to rescan the manifests")
+	}
+
+	result, _ = h.executeGetDependencyMap(ctx, map[string]interface{}{"org": "octo-org", "repository": "missing"})
+	if !result.IsError {
+		t.Error("Expected an error result for a repository that was not scanned")
+	}
+}
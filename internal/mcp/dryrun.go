@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+const (
+	// dryRunArgument is the argument name of tools that change data asking
+	// for a dry run
+	dryRunArgument = "dry_run"
+	// dryRunMetaKey is the _meta key of the requests a dry run described
+	dryRunMetaKey = "github-mcp/dry_run"
+)
+
+// DryRunResult lists the GitHub API requests a tool call would have sent
+type DryRunResult struct {
+	Requests []client.PlannedRequest `json:"requests"`
+}
+
+// SetDryRun enables or disables dry run mode. In dry run mode tools that
+// change data validate their arguments and describe the GitHub API request
+// they would send instead of sending it. Clients can also ask for a dry run
+// per call with the dry_run argument.
+func (h *Handler) SetDryRun(enabled bool) {
+	h.dryRun.Store(enabled)
+}
+
+// addDryRun adds the dry_run argument to the schema of the tools that
+// change data
+func addDryRun(tools []Tool) {
+	for _, tool := range tools {
+		if readOnlyTool(tool.Name) {
+			continue
+		}
+
+		properties := schemaProperties(tool.InputSchema)
+		if properties == nil {
+			continue
+		}
+
+		properties[dryRunArgument] = map[string]interface{}{
+			"type":        "boolean",
+			"description": "Validate the arguments and describe the GitHub API request that would be made, with its payload, without making it",
+		}
+	}
+}
+
+// dryRunContext removes the dry_run argument from args and returns a
+// context describing rather than sending the requests of the call when a
+// dry run was asked for or dry run mode is enabled
+func (h *Handler) dryRunContext(ctx context.Context, toolName string, args map[string]interface{}) (context.Context, bool) {
+	requested, _ := args[dryRunArgument].(bool)
+	delete(args, dryRunArgument)
+	if readOnlyTool(toolName) || !(requested || h.dryRun.Load()) {
+		return ctx, false
+	}
+	return client.WithDryRun(ctx), true
+}
+
+// dryRunResult returns the result of a dry run of toolName describing the
+// requests it planned. Results of calls that planned no request, such as
+// calls rejected for invalid arguments, are returned unchanged.
+func (h *Handler) dryRunResult(ctx context.Context, toolName string, result *CallToolResult) *CallToolResult {
+	planned := client.PlannedRequests(ctx)
+	if len(planned) == 0 {
+		return result
+	}
+
+	description, err := json.MarshalIndent(planned, "", "  ")
+	if err != nil {
+		description = []byte(err.Error())
+	}
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: h.messages.Sprintf("Dry run, nothing was changed. %s would make this GitHub API request:\n%s", toolName, string(description)),
+		}},
+		Meta: map[string]interface{}{dryRunMetaKey: DryRunResult{Requests: planned}},
+	}
+}
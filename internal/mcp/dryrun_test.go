@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestHandleCallTool_DryRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		global     bool
+		tool       string
+		arguments  map[string]interface{}
+		wantMethod string
+		wantURL    string
+		wantBody   string
+		wantSent   int
+		wantError  bool
+		// wantInvalid expects the arguments rejected before the tool runs
+		wantInvalid bool
+	}{
+		{name: "per call", tool: "update_authenticated_user", arguments: map[string]interface{}{"name": "Mona", "dry_run": true},
+			wantMethod: "PATCH", wantURL: client.GitHubAPIBaseURL + "/user", wantBody: `{"name":"Mona"}`},
+		{name: "global", global: true, tool: "follow_user", arguments: map[string]interface{}{"username": "octocat"},
+			wantMethod: "PUT", wantURL: client.GitHubAPIBaseURL + "/user/following/octocat"},
+		{name: "invalid arguments", global: true, tool: "follow_user", arguments: map[string]interface{}{}, wantInvalid: true},
+		{name: "disabled", tool: "follow_user", arguments: map[string]interface{}{"username": "octocat", "dry_run": false}, wantSent: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := 0
+			githubClient := client.NewGitHubClient("test-token", createTestLogger())
+			githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					sent++
+					return mocks.MockResponse(204, "", nil), nil
+				},
+			})
+
+			h := NewHandler(githubClient, createTestLogger())
+			h.SetDryRun(tt.global)
+			session := NewSession(TransportStdio)
+			session.setInitialized()
+			ctx := WithSession(context.Background(), session)
+			resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+				"name":      tt.tool,
+				"arguments": tt.arguments,
+			}})
+
+			if tt.wantInvalid {
+				if resp.Error == nil || resp.Error.Code != ErrorCodeInvalidParams || sent != 0 {
+					t.Errorf("Expected invalid params without requests, got %+v after %d requests", resp.Error, sent)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Unexpected JSON-RPC error %+v", resp.Error)
+			}
+			if sent != tt.wantSent {
+				t.Errorf("Expected %d requests sent to GitHub, got %d", tt.wantSent, sent)
+			}
+			result := resp.Result.(*CallToolResult)
+			if result.IsError != tt.wantError {
+				t.Errorf("Expected IsError %v, got %+v", tt.wantError, result)
+			}
+			dryRun, ok := result.Meta[dryRunMetaKey].(DryRunResult)
+			if tt.wantMethod == "" {
+				if ok {
+					t.Errorf("Expected no dry run metadata, got %+v", dryRun)
+				}
+				return
+			}
+			if !ok || len(dryRun.Requests) != 1 {
+				t.Fatalf("Expected one planned request, got %+v", result.Meta)
+			}
+			planned := dryRun.Requests[0]
+			if planned.Method != tt.wantMethod || planned.URL != tt.wantURL || string(planned.Body) != tt.wantBody {
+				t.Errorf("Expected %s %s %s, got %+v", tt.wantMethod, tt.wantURL, tt.wantBody, planned)
+			}
+		})
+	}
+}
+
+func TestAddDryRun(t *testing.T) {
+	h := NewHandler(client.NewGitHubClient("test-token", createTestLogger()), createTestLogger())
+
+	for name, want := range map[string]bool{"follow_user": true, "delete_team": true, "get_user": false, "list_recent_deletions": false} {
+		properties := schemaProperties(h.findTool(name).InputSchema)
+		if _, ok := properties[dryRunArgument]; ok != want {
+			t.Errorf("Expected %s to accept dry_run: %v", name, want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// elicitationTimeout bounds how long a tool call waits for the user to
+	// answer a confirmation
+	elicitationTimeout = 5 * time.Minute
+	// confirmField is the requested schema field the user confirms a call with
+	confirmField = "confirm"
+)
+
+// SetConfirmDestructive makes destructive tools ask the user, through clients
+// supporting elicitation, to confirm each call and fill in missing required
+// arguments before running. Calls from other clients are rejected, since
+// they cannot be confirmed.
+func (h *Handler) SetConfirmDestructive(enabled bool) {
+	h.confirmDestructive.Store(enabled)
+}
+
+// needsConfirmation reports whether a call to the named tool removes data
+func needsConfirmation(toolName string, args map[string]interface{}) bool {
+	switch toolName {
+	case "github_api_request":
+		method, _ := args["method"].(string)
+		return strings.EqualFold(method, http.MethodDelete)
+	case "update_ruleset":
+		return removesRulesetProtection(args)
+	}
+	return destructiveTools[toolName]
+}
+
+// canElicit reports whether the session's client can be asked for input
+func (s *Session) canElicit() bool {
+	return s.Capabilities().Elicitation != nil && s.notifier() != nil
+}
+
+// confirmCall asks the user to confirm a call to a destructive tool, filling
+// in its missing required arguments. It returns the arguments to call the
+// tool with, or the result to respond with when the call must not run.
+func (h *Handler) confirmCall(ctx context.Context, tool *Tool, args map[string]interface{}) (map[string]interface{}, *CallToolResult) {
+	if !h.confirmDestructive.Load() || !needsConfirmation(tool.Name, args) || h.dryRunRequested(args) {
+		return args, nil
+	}
+	if !h.session(ctx).canElicit() {
+		return nil, &CallToolResult{
+			Content: []Content{{Type: "text", Text: h.messages.Sprintf("%s was not run, it removes data from GitHub and this client cannot ask the user to confirm it", tool.Name)}},
+			IsError: true,
+		}
+	}
+	return h.elicitConfirmation(ctx, tool, args, h.messages.Sprintf("This removes data from GitHub and cannot be undone"), func(arguments string) string {
+		return h.messages.Sprintf("%s removes data from GitHub and cannot be undone. Run it with %s?", tool.Name, arguments)
+	}, func(missing string) string {
+		return h.messages.Sprintf("%s removes data from GitHub and cannot be undone. Provide %s to run it.", tool.Name, missing)
+	})
+}
+
+// requireConfirmation asks the user to confirm a call the tool policy holds
+// for confirmation, for reason. Calls from clients that cannot ask the user
+// are rejected.
+func (h *Handler) requireConfirmation(ctx context.Context, tool *Tool, args map[string]interface{}, reason string) (map[string]interface{}, *CallToolResult) {
+	if h.dryRunRequested(args) {
+		return args, nil
+	}
+	if !h.session(ctx).canElicit() {
+		return nil, &CallToolResult{
+			Content: []Content{{Type: "text", Text: h.messages.Sprintf("%s was not run, it needs confirming and this client cannot ask the user: %s", tool.Name, reason)}},
+			IsError: true,
+		}
+	}
+	return h.elicitConfirmation(ctx, tool, args, reason, func(arguments string) string {
+		return h.messages.Sprintf("%s needs confirming: %s. Run it with %s?", tool.Name, reason, arguments)
+	}, func(missing string) string {
+		return h.messages.Sprintf("%s needs confirming: %s. Provide %s to run it.", tool.Name, reason, missing)
+	})
+}
+
+// dryRunRequested reports whether a call runs dry, making no changes that
+// would need confirming
+func (h *Handler) dryRunRequested(args map[string]interface{}) bool {
+	requested, _ := args[dryRunArgument].(bool)
+	return requested || h.dryRun.Load()
+}
+
+// elicitConfirmation asks the user to confirm a call, described by
+// description, and to fill in its missing required arguments. The user is
+// asked with run, given the arguments, or with provide, given the names of
+// the missing ones.
+func (h *Handler) elicitConfirmation(ctx context.Context, tool *Tool, args map[string]interface{}, description string, run, provide func(string) string) (map[string]interface{}, *CallToolResult) {
+	session := h.session(ctx)
+	missing := missingArguments(tool.InputSchema, args)
+	properties := map[string]interface{}{
+		confirmField: map[string]interface{}{
+			"type":        "boolean",
+			"title":       h.messages.Sprintf("Run %s", tool.Name),
+			"description": description,
+		},
+	}
+	names := make([]string, 0, len(missing))
+	for name, schema := range missing {
+		properties[name] = schema
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	message := run(describeArguments(args))
+	if len(missing) > 0 {
+		message = provide(strings.Join(names, ", "))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, elicitationTimeout)
+	defer cancel()
+	response, err := session.request(ctx, MethodElicit, ElicitRequest{
+		Message:         message,
+		RequestedSchema: map[string]interface{}{"type": "object", "properties": properties, "required": append(names, confirmField)},
+	})
+	if err == nil && response.Error != nil {
+		err = fmt.Errorf("%s", response.Error.Message)
+	}
+	var result ElicitResult
+	if err == nil {
+		err = response.GetResult(&result)
+	}
+	if err != nil {
+		h.logger.WithContext(ctx).Warn("Failed to confirm tool call", "tool", tool.Name, "error", err)
+		return nil, &CallToolResult{
+			Content: []Content{{Type: "text", Text: h.messages.Sprintf("%s was not run, confirmation failed: %v", tool.Name, err)}},
+			IsError: true,
+		}
+	}
+
+	if confirmed, _ := result.Content[confirmField].(bool); result.Action != "accept" || !confirmed {
+		return nil, &CallToolResult{
+			Content: []Content{{Type: "text", Text: h.messages.Sprintf("%s was not run, the user did not confirm it", tool.Name)}},
+			IsError: true,
+		}
+	}
+
+	confirmed := make(map[string]interface{}, len(args)+len(missing))
+	for name, value := range args {
+		confirmed[name] = value
+	}
+	for name := range missing {
+		if value, ok := result.Content[name]; ok {
+			confirmed[name] = value
+		}
+	}
+	if !h.strictArguments.Load() {
+		confirmed = coerceArguments(tool.InputSchema, confirmed)
+	}
+	return confirmed, nil
+}
+
+// missingArguments returns the schemas of the required arguments args lacks
+// that a user can fill in, which elicitation limits to primitive types
+func missingArguments(schema interface{}, args map[string]interface{}) map[string]interface{} {
+	schemaMap, _ := schema.(map[string]interface{})
+	properties := schemaProperties(schema)
+	missing := make(map[string]interface{})
+	for _, required := range schemaList(schemaMap["required"]) {
+		name, _ := required.(string)
+		if _, ok := args[name]; ok {
+			continue
+		}
+		switch schemaType(properties[name]) {
+		case "string", "integer", "number", "boolean":
+			missing[name] = properties[name]
+		}
+	}
+	return missing
+}
+
+// describeArguments formats arguments as sorted name=value pairs
+func describeArguments(args map[string]interface{}) string {
+	pairs := make([]string, 0, len(args))
+	for name, value := range args {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", name, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestConfirmDestructive(t *testing.T) {
+	tests := []struct {
+		name        string
+		elicitation bool
+		args        map[string]interface{}
+		answer      ElicitResult
+		wantAsked   bool
+		policy      string
+		wantMissing string
+		wantDeleted string
+		wantInvalid bool
+		// wantError expects an error result without asking
+		wantError bool
+	}{
+		{
+			name:        "confirmed",
+			elicitation: true,
+			args:        map[string]interface{}{"org": "acme", "team_slug": "dev"},
+			answer:      ElicitResult{Action: "accept", Content: map[string]interface{}{"confirm": true}},
+			wantAsked:   true,
+			wantDeleted: "/orgs/acme/teams/dev",
+		},
+		{
+			name:        "declined",
+			elicitation: true,
+			args:        map[string]interface{}{"org": "acme", "team_slug": "dev"},
+			answer:      ElicitResult{Action: "decline"},
+			wantAsked:   true,
+		},
+		{
+			name:        "not confirmed",
+			elicitation: true,
+			args:        map[string]interface{}{"org": "acme", "team_slug": "dev"},
+			answer:      ElicitResult{Action: "accept", Content: map[string]interface{}{"confirm": false}},
+			wantAsked:   true,
+		},
+		{
+			name:        "missing argument",
+			elicitation: true,
+			args:        map[string]interface{}{"org": "acme"},
+			answer:      ElicitResult{Action: "accept", Content: map[string]interface{}{"confirm": true, "team_slug": "ops"}},
+			wantAsked:   true,
+			wantMissing: "team_slug",
+			wantDeleted: "/orgs/acme/teams/ops",
+		},
+		{
+			name:        "missing argument denied by policy",
+			elicitation: true,
+			args:        map[string]interface{}{"org": "acme"},
+			policy:      "rules:\n  - effect: deny\n    arguments:\n      team_slug: ops\n",
+			answer:      ElicitResult{Action: "accept", Content: map[string]interface{}{"confirm": true, "team_slug": "ops"}},
+			wantAsked:   true,
+			wantMissing: "team_slug",
+		},
+		{
+			name:        "invalid argument",
+			elicitation: true,
+			args:        map[string]interface{}{"org": "acme", "team_slug": []interface{}{"dev"}},
+			wantInvalid: true,
+		},
+		{
+			name:      "client without elicitation",
+			args:      map[string]interface{}{"org": "acme", "team_slug": "dev"},
+			wantError: true,
+		},
+		{
+			name:        "dry run",
+			elicitation: true,
+			args:        map[string]interface{}{"org": "acme", "team_slug": "dev", "dry_run": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted string
+			githubClient := client.NewGitHubClient("test-token", createTestLogger())
+			githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if req.Method == http.MethodDelete {
+						deleted = req.URL.Path
+						return mocks.MockResponse(http.StatusNoContent, "", nil), nil
+					}
+					return mocks.MockJSONResponse(http.StatusOK, `{"slug": "dev"}`), nil
+				},
+			})
+			h := NewHandler(githubClient, createTestLogger())
+			h.SetConfirmDestructive(true)
+			if tt.policy != "" {
+				policy, err := LoadToolPolicy(writePolicy(t, tt.policy))
+				if err != nil {
+					t.Fatalf("LoadToolPolicy failed: %v", err)
+				}
+				h.SetToolPolicy(policy)
+			}
+
+			session := NewSession(TransportStdio)
+			session.setInitialized()
+			if tt.elicitation {
+				session.setCapabilities(ClientCapabilities{Elicitation: map[string]interface{}{}})
+			}
+			ctx := WithSession(context.Background(), session)
+
+			var asked *ElicitRequest
+			session.setNotifier(func(msg *JSONRPCMessage) {
+				if msg.Method != MethodElicit {
+					return
+				}
+				asked = &ElicitRequest{}
+				if err := msg.GetParams(asked); err != nil {
+					t.Errorf("Invalid elicitation request: %v", err)
+				}
+				data, _ := NewResponse(msg.ID, tt.answer).ToJSON()
+				go h.HandleMessage(ctx, data)
+			})
+
+			params, _ := json.Marshal(CallToolRequest{Name: "delete_team", Arguments: tt.args})
+			var raw interface{}
+			json.Unmarshal(params, &raw)
+			response := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: raw})
+			if tt.wantInvalid {
+				if response.Error == nil || response.Error.Code != ErrorCodeInvalidParams || asked != nil {
+					t.Errorf("Expected invalid params without asking, got %+v (asked %+v)", response.Error, asked)
+				}
+				return
+			}
+			if response.Error != nil {
+				t.Fatalf("Unexpected error: %+v", response.Error)
+			}
+			result := response.Result.(*CallToolResult)
+
+			if (asked != nil) != tt.wantAsked {
+				t.Fatalf("Expected confirmation asked %v, got %+v", tt.wantAsked, asked)
+			}
+			if tt.wantMissing != "" {
+				if _, ok := asked.RequestedSchema["properties"].(map[string]interface{})[tt.wantMissing]; !ok {
+					t.Errorf("Expected the form to ask for %s, got %+v", tt.wantMissing, asked.RequestedSchema)
+				}
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("Expected DELETE %q, got %q", tt.wantDeleted, deleted)
+			}
+			if (tt.wantAsked || tt.wantError) && tt.wantDeleted == "" && !result.IsError {
+				t.Errorf("Expected an error result for an unconfirmed call, got %+v", result)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// defaultReplayBufferSize is the number of broadcast events retained for replay
+const defaultReplayBufferSize = 1000
+
+// bufferedEvent is an SSE event retained for replay to reconnecting clients
+type bufferedEvent struct {
+	ID        uint64
+	EventType string
+	Data      json.RawMessage
+	// Owned events are only replayed to the streams opened by Owner
+	Owned bool
+	Owner string
+}
+
+// eventBuffer assigns monotonically increasing IDs to SSE events and retains
+// the most recent broadcast events so clients can resume with Last-Event-ID.
+// Events sent to a single client take IDs without being retained, so the
+// IDs of retained events have gaps.
+type eventBuffer struct {
+	mu       sync.Mutex
+	lastID   uint64
+	capacity int
+	events   []bufferedEvent
+	start    int
+	// evictedID is the ID of the newest broadcast event no longer retained
+	evictedID uint64
+}
+
+// newEventBuffer creates a buffer retaining up to capacity events; a capacity
+// of zero disables replay but still assigns event IDs
+func newEventBuffer(capacity int) *eventBuffer {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &eventBuffer{capacity: capacity}
+}
+
+// nextID assigns an event ID without retaining the event
+func (b *eventBuffer) nextID() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	return b.lastID
+}
+
+// append assigns an event ID and retains the event for replay
+func (b *eventBuffer) append(eventType string, data json.RawMessage) bufferedEvent {
+	return b.retain(bufferedEvent{EventType: eventType, Data: data})
+}
+
+// appendOwned assigns an event ID and retains the event for replay to the
+// streams opened by owner
+func (b *eventBuffer) appendOwned(owner, eventType string, data json.RawMessage) bufferedEvent {
+	return b.retain(bufferedEvent{EventType: eventType, Data: data, Owned: true, Owner: owner})
+}
+
+// retain assigns event an ID and retains it
+func (b *eventBuffer) retain(event bufferedEvent) bufferedEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+
+	if b.capacity == 0 {
+		b.evictedID = event.ID
+		return event
+	}
+	if len(b.events) < b.capacity {
+		b.events = append(b.events, event)
+	} else {
+		// Overwrite the oldest event once the ring is full
+		b.evictedID = b.events[b.start].ID
+		b.events[b.start] = event
+		b.start = (b.start + 1) % b.capacity
+	}
+
+	return event
+}
+
+// since returns the retained events after lastID in order, and the ID of the
+// newest event assigned so far. complete is false when events after lastID
+// have already been evicted and cannot be replayed.
+func (b *eventBuffer) since(lastID uint64) (events []bufferedEvent, newest uint64, complete bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if lastID > b.lastID {
+		// The ID was issued before a server restart; nothing can be replayed
+		return nil, b.lastID, false
+	}
+	// Only broadcast events after lastID that were evicted are missing
+	complete = lastID >= b.evictedID
+
+	for i := 0; i < len(b.events); i++ {
+		event := b.events[(b.start+i)%len(b.events)]
+		if event.ID > lastID {
+			events = append(events, event)
+		}
+	}
+
+	return events, b.lastID, complete
+}
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEventBuffer_Since(t *testing.T) {
+	buffer := newEventBuffer(3)
+	for i := 0; i < 5; i++ {
+		buffer.append("test", json.RawMessage(`{}`))
+	}
+
+	tests := []struct {
+		name             string
+		lastID           uint64
+		expectedIDs      []uint64
+		expectedComplete bool
+	}{
+		{name: "up to date", lastID: 5, expectedIDs: nil, expectedComplete: true},
+		{name: "within buffer", lastID: 3, expectedIDs: []uint64{4, 5}, expectedComplete: true},
+		{name: "oldest retained boundary", lastID: 2, expectedIDs: []uint64{3, 4, 5}, expectedComplete: true},
+		{name: "evicted", lastID: 1, expectedIDs: []uint64{3, 4, 5}, expectedComplete: false},
+		{name: "from before restart", lastID: 42, expectedIDs: nil, expectedComplete: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, newest, complete := buffer.since(tt.lastID)
+
+			if newest != 5 {
+				t.Errorf("Expected newest ID 5, got %d", newest)
+			}
+			if complete != tt.expectedComplete {
+				t.Errorf("Expected complete %v, got %v", tt.expectedComplete, complete)
+			}
+			if len(events) != len(tt.expectedIDs) {
+				t.Fatalf("Expected %d events, got %d", len(tt.expectedIDs), len(events))
+			}
+			for i, id := range tt.expectedIDs {
+				if events[i].ID != id {
+					t.Errorf("Expected event %d to have ID %d, got %d", i, id, events[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestEventBuffer_SinceGaps(t *testing.T) {
+	buffer := newEventBuffer(2)
+	buffer.append("test", json.RawMessage(`{}`)) // 1
+	buffer.nextID()                              // 2, sent to a single client
+	buffer.append("test", json.RawMessage(`{}`)) // 3
+	buffer.nextID()                              // 4
+	buffer.append("test", json.RawMessage(`{}`)) // 5, evicts 1
+
+	// Event 2 was never retained, so a client that saw it missed nothing
+	if events, _, complete := buffer.since(2); !complete || len(events) != 2 || events[0].ID != 3 {
+		t.Errorf("Expected a gap in IDs not to be reported as truncation, got %v %v", events, complete)
+	}
+	if _, _, complete := buffer.since(0); complete {
+		t.Error("Expected an evicted event to be reported as truncation")
+	}
+
+	disabled := newEventBuffer(0)
+	disabled.append("test", json.RawMessage(`{}`))
+	if _, _, complete := disabled.since(0); complete {
+		t.Error("Expected missed events to be reported when replay is disabled")
+	}
+}
@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+	"github.com/nicholasflintwillow/github-mcp/internal/requestid"
+)
+
+// ToolExecution describes a tool call that is currently running
+type ToolExecution struct {
+	ID        uint64      `json:"id"`
+	Tool      string      `json:"tool"`
+	MessageID interface{} `json:"message_id,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	Transport string      `json:"transport"`
+	StartedAt time.Time   `json:"started_at"`
+}
+
+// executionTracker records the running tool calls
+type executionTracker struct {
+	mu      sync.Mutex
+	nextID  uint64
+	running map[uint64]ToolExecution
+}
+
+// start records a tool call as running and returns a function that removes it
+func (t *executionTracker) start(execution ToolExecution) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.nextID++
+	execution.ID = t.nextID
+	if t.running == nil {
+		t.running = make(map[uint64]ToolExecution)
+	}
+	t.running[execution.ID] = execution
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.running, execution.ID)
+	}
+}
+
+// trackExecution records a tool call of the session of ctx as running until
+// the returned function is called
+func (h *Handler) trackExecution(ctx context.Context, tool string, messageID interface{}) func() {
+	return h.executions.start(ToolExecution{
+		Tool:      tool,
+		MessageID: messageID,
+		RequestID: requestid.FromContext(ctx),
+		Transport: h.session(ctx).Transport(),
+		StartedAt: time.Now(),
+	})
+}
+
+// InFlightTools returns the running tool calls, longest running first
+func (h *Handler) InFlightTools() []ToolExecution {
+	h.executions.mu.Lock()
+	executions := make([]ToolExecution, 0, len(h.executions.running))
+	for _, execution := range h.executions.running {
+		executions = append(executions, execution)
+	}
+	h.executions.mu.Unlock()
+
+	sort.Slice(executions, func(i, j int) bool {
+		return executions[i].ID < executions[j].ID
+	})
+	return executions
+}
+
+// WaitForTools blocks until no tool calls are running or ctx is done
+func (h *Handler) WaitForTools(ctx context.Context) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		h.executions.mu.Lock()
+		running := len(h.executions.running)
+		h.executions.mu.Unlock()
+		if running == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// executionMetaKey is the tool result _meta key holding execution stats
+const executionMetaKey = "github-mcp/execution"
+
+// ExecutionMetadata is reported in the _meta of a tool result
+type ExecutionMetadata struct {
+	execstats.Snapshot
+	Cached bool `json:"cached"`
+}
+
+// executionMetadataRequested reports whether a tool call asked for execution
+// stats with "_meta": {"executionMetadata": true}
+func executionMetadataRequested(meta map[string]interface{}) bool {
+	requested, _ := meta["executionMetadata"].(bool)
+	return requested
+}
+
+// withExecutionMetadata returns a copy of result carrying the execution stats.
+// Results may be shared with the prefetch cache, so they are not modified.
+func withExecutionMetadata(result *CallToolResult, snapshot execstats.Snapshot, cached bool) *CallToolResult {
+	annotated := *result
+	annotated.Meta = make(map[string]interface{}, len(result.Meta)+1)
+	for key, value := range result.Meta {
+		annotated.Meta[key] = value
+	}
+	annotated.Meta[executionMetaKey] = ExecutionMetadata{Snapshot: snapshot, Cached: cached}
+	return &annotated
+}
@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+	"github.com/nicholasflintwillow/github-mcp/internal/requestid"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestTrackExecution(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	ctx := requestid.NewContext(WithSession(context.Background(), NewSession(TransportStdio)), "req-1")
+
+	doneFirst := h.trackExecution(ctx, "get_user", 7)
+	doneSecond := h.trackExecution(context.Background(), "list_teams", "abc")
+
+	executions := h.InFlightTools()
+	if len(executions) != 2 {
+		t.Fatalf("Expected 2 executions, got %d", len(executions))
+	}
+	first := executions[0]
+	if first.Tool != "get_user" || first.RequestID != "req-1" || first.Transport != TransportStdio || first.MessageID != 7 {
+		t.Errorf("Unexpected execution %+v", first)
+	}
+	if executions[1].Transport != TransportHTTP {
+		t.Errorf("Expected HTTP transport for calls without a session, got %q", executions[1].Transport)
+	}
+
+	doneFirst()
+	if executions := h.InFlightTools(); len(executions) != 1 || executions[0].Tool != "list_teams" {
+		t.Errorf("Expected only list_teams to be running, got %+v", executions)
+	}
+	doneSecond()
+	if executions := h.InFlightTools(); len(executions) != 0 {
+		t.Errorf("Expected no running executions, got %+v", executions)
+	}
+}
+
+func TestHandleCallTool_ExecutionMetadata(t *testing.T) {
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mocks.MockJSONResponse(200, `{"login": "octocat", "id": 1}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	call := func(params map[string]interface{}) *CallToolResult {
+		t.Helper()
+		resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: params})
+		if resp.Error != nil {
+			t.Fatalf("Unexpected error: %+v", resp.Error)
+		}
+		return resp.Result.(*CallToolResult)
+	}
+	getUser := map[string]interface{}{
+		"name":      "get_user",
+		"arguments": map[string]interface{}{"username": "octocat"},
+	}
+
+	if result := call(getUser); result.Meta != nil {
+		t.Errorf("Expected no _meta by default, got %v", result.Meta)
+	}
+
+	getUser["_meta"] = map[string]interface{}{"executionMetadata": true}
+	result := call(getUser)
+	meta, ok := result.Meta[executionMetaKey].(ExecutionMetadata)
+	if !ok {
+		t.Fatalf("Expected execution metadata in _meta, got %v", result.Meta)
+	}
+	if meta.GitHubRoundTrips != 1 || meta.Cached {
+		t.Errorf("Expected 1 uncached round trip, got %+v", meta)
+	}
+
+	delete(getUser, "_meta")
+	h.SetExecutionMetadata(true)
+	if _, ok := call(getUser).Meta[executionMetaKey]; !ok {
+		t.Error("Expected execution metadata when enabled for all calls")
+	}
+}
+
+func TestWithExecutionMetadata_CopiesResult(t *testing.T) {
+	result := &CallToolResult{Content: []Content{{Type: "text", Text: "ok"}}}
+	annotated := withExecutionMetadata(result, execstats.Snapshot{CacheHits: 1}, true)
+
+	if result.Meta != nil {
+		t.Errorf("Expected the original result to be unchanged, got %v", result.Meta)
+	}
+	meta := annotated.Meta[executionMetaKey].(ExecutionMetadata)
+	if !meta.Cached || meta.CacheHits != 1 {
+		t.Errorf("Unexpected metadata %+v", meta)
+	}
+}
+
+func TestWaitForTools(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	done := h.trackExecution(context.Background(), "get_user", 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := h.WaitForTools(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded while a tool runs, got %v", err)
+	}
+
+	time.AfterFunc(10*time.Millisecond, done)
+	if err := h.WaitForTools(context.Background()); err != nil {
+		t.Errorf("Expected wait to end once the tool finished, got %v", err)
+	}
+}
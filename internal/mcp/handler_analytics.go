@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// analyticsTools returns the organization analytics tools
+func (h *Handler) analyticsTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "get_org_activity_analytics",
+			Description: "Summarize issue, pull request and discussion activity in an organization over a time window: threads opened and closed, comments, reactions, first-response times and top participants",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"days": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of days to report on, ending now",
+						"minimum":     1,
+						"maximum":     maxAnalyticsWindowDays,
+						"default":     defaultAnalyticsWindowDays,
+					},
+				},
+				"required": []string{"org"},
+			},
+			Shaped: true,
+		}, h.executeGetOrgActivityAnalytics),
+	}
+}
+
+// analyticsResources returns the organization analytics resources
+func analyticsResources() []Resource {
+	return []Resource{
+		{
+			URI:         "github://org/{org}/analytics",
+			Name:        "GitHub Organization Activity Analytics",
+			Description: fmt.Sprintf("Issue, pull request and discussion activity summary for an organization over the last %d days", defaultAnalyticsWindowDays),
+			MimeType:    "application/json",
+		},
+	}
+}
+
+// executeGetOrgActivityAnalytics executes the get_org_activity_analytics tool
+func (h *Handler) executeGetOrgActivityAnalytics(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok || org == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: org parameter is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	days := defaultAnalyticsWindowDays
+	if d, ok := args["days"].(float64); ok {
+		days = int(d)
+	}
+	if days < 1 || days > maxAnalyticsWindowDays {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: days must be between 1 and %d", maxAnalyticsWindowDays),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	report, err := h.orgActivityReport(ctx, org, days)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error building activity analytics for organization %s: %v", org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	reportJSON, err := shapeJSON(args, report)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting analytics data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(reportJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// readOrgAnalyticsResource reads the github://org/{org}/analytics resource
+func (h *Handler) readOrgAnalyticsResource(ctx context.Context, uri, org string) (*ReadResourceResult, error) {
+	report, err := h.orgActivityReport(ctx, org, defaultAnalyticsWindowDays)
+	if err != nil {
+		return nil, err
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReadResourceResult{
+		Contents: []ResourceContent{{
+			URI:      uri,
+			MimeType: "application/json",
+			Text:     string(reportJSON),
+		}},
+	}, nil
+}
@@ -0,0 +1,246 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// apiRequestTool is the tool proxying requests to allowlisted endpoints
+const apiRequestTool = "github_api_request"
+
+// apiMethods are the methods github_api_request can send
+var apiMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// apiRule allows methods on the paths matching a pattern
+type apiRule struct {
+	methods map[string]bool
+	// pattern matches paths as path.Match does; a trailing /** matches
+	// every path below the prefix
+	pattern string
+}
+
+// apiTools returns the generic GitHub REST API tool
+func (h *Handler) apiTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        apiRequestTool,
+			Description: "Make a request to a GitHub REST API endpoint without a dedicated tool. Only the methods and paths the server allows can be requested.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"method": map[string]interface{}{
+						"type":        "string",
+						"description": "HTTP method",
+						"enum":        apiMethods,
+						"default":     http.MethodGet,
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Endpoint path, such as /repos/octocat/hello-world/labels",
+					},
+					"query": map[string]interface{}{
+						"type":                 "object",
+						"description":          "Query parameters",
+						"additionalProperties": map[string]interface{}{"type": []string{"string", "number", "boolean"}},
+					},
+					"body": map[string]interface{}{
+						"type":        "object",
+						"description": "JSON request body",
+					},
+				},
+				"required": []string{"path"},
+			},
+		}, h.executeGitHubAPIRequest),
+	}
+}
+
+// SetAPIAllowlist sets the requests github_api_request may make. Each rule
+// is a method, several joined by | or * for all of them, and a path pattern
+// separated by a space, such as "GET|POST /repos/*/*/labels". The tool is
+// only offered when rules allow something. It must be called before serving.
+func (h *Handler) SetAPIAllowlist(rules []string) error {
+	allowlist := make([]apiRule, 0, len(rules))
+	for _, rule := range rules {
+		methods, pattern, ok := strings.Cut(strings.TrimSpace(rule), " ")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || !strings.HasPrefix(pattern, "/") {
+			return fmt.Errorf("API allowlist rule %q is not a method and a path pattern", rule)
+		}
+		if _, err := path.Match(strings.TrimSuffix(pattern, "/**"), ""); err != nil {
+			return fmt.Errorf("API allowlist rule %q has an invalid path pattern", rule)
+		}
+
+		allowed := apiRule{methods: make(map[string]bool), pattern: pattern}
+		for _, method := range strings.Split(strings.ToUpper(methods), "|") {
+			switch {
+			case method == "*":
+				for _, m := range apiMethods {
+					allowed.methods[m] = true
+				}
+			case validAPIMethod(method):
+				allowed.methods[method] = true
+			default:
+				return fmt.Errorf("API allowlist rule %q has an unsupported method %q", rule, method)
+			}
+		}
+		allowlist = append(allowlist, allowed)
+	}
+	h.apiAllowlist = allowlist
+
+	h.removeTool(apiRequestTool)
+	if len(allowlist) > 0 {
+		tools := h.addProviders(h.apiTools())
+		addDryRun(tools)
+		h.addTools(tools)
+	}
+	return nil
+}
+
+// validAPIMethod reports whether github_api_request can send method
+func validAPIMethod(method string) bool {
+	for _, m := range apiMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// apiAllowed reports whether the allowlist allows method on endpoint. The
+// endpoint is matched as GitHub decodes it, so escaped slashes and dots
+// cannot reach paths outside the allowlist.
+func (h *Handler) apiAllowed(method, endpoint string) bool {
+	decoded, err := url.PathUnescape(endpoint)
+	if err != nil || path.Clean(decoded) != decoded {
+		return false
+	}
+	for _, segment := range strings.Split(decoded, "/") {
+		if segment == "." || segment == ".." {
+			return false
+		}
+	}
+	endpoint = decoded
+
+	for _, rule := range h.apiAllowlist {
+		if !rule.methods[method] {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(rule.pattern, "/**"); ok {
+			for dir := endpoint; dir != "/" && dir != "."; dir = path.Dir(dir) {
+				if matched, _ := path.Match(prefix, dir); matched {
+					return true
+				}
+			}
+			continue
+		}
+		if matched, _ := path.Match(rule.pattern, endpoint); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// executeGitHubAPIRequest executes the github_api_request tool
+func (h *Handler) executeGitHubAPIRequest(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	method := http.MethodGet
+	if m, ok := args["method"].(string); ok && m != "" {
+		method = strings.ToUpper(m)
+	}
+	if !validAPIMethod(method) {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: method must be one of %s", strings.Join(apiMethods, ", ")),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	if method != http.MethodGet && h.readOnly.Load() {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: the server is in read-only mode and only sends GET requests"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	endpoint, ok := args["path"].(string)
+	if !ok || !strings.HasPrefix(endpoint, "/") || strings.ContainsAny(endpoint, "?#") || path.Clean(endpoint) != endpoint {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: path parameter is required and must be an absolute endpoint path without a query"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	if !h.apiAllowed(method, endpoint) {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: %s %s is not allowed by the server's API allowlist", method, endpoint),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var params map[string]string
+	if query, ok := args["query"].(map[string]interface{}); ok && len(query) > 0 {
+		params = make(map[string]string, len(query))
+		for key, value := range query {
+			params[key] = fmt.Sprint(value)
+		}
+	}
+	body := args["body"]
+	if method == http.MethodGet {
+		body = nil
+	}
+
+	var record *DeletionRecord
+	if method == http.MethodDelete {
+		var errResult *CallToolResult
+		record, errResult = h.captureDeletion(ctx, apiRequestTool, endpoint, args, func() (interface{}, error) {
+			resp, err := h.githubClient.Get(ctx, endpoint, nil)
+			if err != nil {
+				return nil, err
+			}
+			return json.RawMessage(resp.Body), nil
+		})
+		if errResult != nil {
+			return errResult, nil
+		}
+	}
+
+	resp, err := h.githubClient.Request(ctx, method, endpoint, params, body)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error requesting %s %s: %v", method, endpoint, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	h.recordDeletion(ctx, record)
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: h.messages.Sprintf("%s %s returned %d:\n%s", method, endpoint, resp.StatusCode, string(resp.Body)),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
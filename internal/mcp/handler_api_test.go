@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestSetAPIAllowlist(t *testing.T) {
+	h := NewHandler(client.NewGitHubClient("test-token", createTestLogger()), createTestLogger())
+	if h.findTool(apiRequestTool) != nil {
+		t.Fatal("Expected github_api_request not to be offered without an allowlist")
+	}
+	if err := h.SetAPIAllowlist([]string{"GET /repos/*/*/labels"}); err != nil {
+		t.Fatalf("SetAPIAllowlist failed: %v", err)
+	}
+	if h.findTool(apiRequestTool) == nil {
+		t.Fatal("Expected github_api_request to be offered with an allowlist")
+	}
+
+	for _, rule := range []string{"/repos/*/*/labels", "FETCH /repos", "GET repos", "GET /repos/[a"} {
+		if err := h.SetAPIAllowlist([]string{rule}); err == nil {
+			t.Errorf("Expected rule %q to be rejected", rule)
+		}
+	}
+}
+
+func TestHandleCallTool_GitHubAPIRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		wantURL   string
+		wantBody  string
+		wantError bool
+	}{
+		{name: "allowed get", arguments: map[string]interface{}{"path": "/repos/octocat/hello-world/labels", "query": map[string]interface{}{"per_page": 5}},
+			wantURL: "/repos/octocat/hello-world/labels?per_page=5"},
+		{name: "allowed subtree", arguments: map[string]interface{}{"method": "post", "path": "/repos/octocat/hello-world/actions/workflows/ci.yml/dispatches", "body": map[string]interface{}{"ref": "main"}},
+			wantURL: "/repos/octocat/hello-world/actions/workflows/ci.yml/dispatches", wantBody: `{"ref":"main"}`},
+		{name: "method not allowed", arguments: map[string]interface{}{"method": "DELETE", "path": "/repos/octocat/hello-world/labels"}, wantError: true},
+		{name: "path not allowed", arguments: map[string]interface{}{"path": "/user/emails"}, wantError: true},
+		{name: "path traversal", arguments: map[string]interface{}{"path": "/repos/octocat/hello-world/labels/../../../../user"}, wantError: true},
+		{name: "query in path", arguments: map[string]interface{}{"path": "/repos/octocat/hello-world/labels?per_page=100"}, wantError: true},
+		{name: "escaped slash", arguments: map[string]interface{}{"method": "POST", "path": "/repos/octocat/hello-world/actions/..%2F..%2F..%2F..%2Fuser%2Frepos"}, wantError: true},
+		{name: "escaped dots", arguments: map[string]interface{}{"method": "POST", "path": "/repos/octocat/hello-world/actions/%2e%2e/%2e%2e/%2e%2e/%2e%2e/user/repos"}, wantError: true},
+		{name: "escaped segment", arguments: map[string]interface{}{"path": "/repos/octocat/hello-world%2Fissues/labels"}, wantError: true},
+		{name: "escaped name", arguments: map[string]interface{}{"path": "/repos/octocat/hello%2Dworld/labels"}, wantURL: "/repos/octocat/hello%2Dworld/labels"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotURL, gotBody string
+			githubClient := client.NewGitHubClient("test-token", createTestLogger())
+			githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					gotURL = req.URL.RequestURI()
+					if req.Body != nil {
+						body, _ := io.ReadAll(req.Body)
+						gotBody = strings.TrimSpace(string(body))
+					}
+					return mocks.MockJSONResponse(200, `[{"name":"bug"}]`), nil
+				},
+			})
+
+			h := NewHandler(githubClient, createTestLogger())
+			if err := h.SetAPIAllowlist([]string{"GET /repos/*/*/labels", "GET|POST /repos/*/*/actions/**"}); err != nil {
+				t.Fatalf("SetAPIAllowlist failed: %v", err)
+			}
+			session := NewSession(TransportStdio)
+			session.setInitialized()
+			ctx := WithSession(context.Background(), session)
+			resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+				"name":      apiRequestTool,
+				"arguments": tt.arguments,
+			}})
+
+			if resp.Error != nil {
+				t.Fatalf("Unexpected JSON-RPC error %+v", resp.Error)
+			}
+			result := resp.Result.(*CallToolResult)
+			if result.IsError != tt.wantError {
+				t.Fatalf("Expected error %v, got %+v", tt.wantError, result.Content)
+			}
+			if gotURL != tt.wantURL || gotBody != tt.wantBody {
+				t.Errorf("Expected request %q with body %q, got %q with %q", tt.wantURL, tt.wantBody, gotURL, gotBody)
+			}
+		})
+	}
+}
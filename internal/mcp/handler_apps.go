@@ -0,0 +1,314 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+// appTools returns the GitHub App installation tools
+func (h *Handler) appTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "list_app_installations",
+			Description: "List GitHub App installations in an organization, or those accessible to the authenticated user when no organization is given",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name (requires organization admin access)",
+					},
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page number of the results to fetch",
+						"minimum":     1,
+						"default":     1,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+			},
+			Shaped: true,
+		}, h.executeListAppInstallations),
+		NewTool(Tool{
+			Name:        "list_installation_repositories",
+			Description: "List repositories accessible to the authenticated user through a GitHub App installation",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"installation_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "The unique identifier of the installation",
+					},
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page number of the results to fetch",
+						"minimum":     1,
+						"default":     1,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+				"required": []string{"installation_id"},
+			},
+			Shaped: true,
+		}, h.executeListInstallationRepositories),
+		NewTool(Tool{
+			Name:        "add_installation_repository",
+			Description: "Add a repository to a GitHub App installation that uses selected repository access",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"installation_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "The unique identifier of the installation",
+					},
+					"repository_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "The unique identifier of the repository",
+					},
+				},
+				"required": []string{"installation_id", "repository_id"},
+			},
+		}, h.executeAddInstallationRepository),
+		NewTool(Tool{
+			Name:        "remove_installation_repository",
+			Description: "Remove a repository from a GitHub App installation that uses selected repository access",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"installation_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "The unique identifier of the installation",
+					},
+					"repository_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "The unique identifier of the repository",
+					},
+				},
+				"required": []string{"installation_id", "repository_id"},
+			},
+		}, h.executeRemoveInstallationRepository),
+	}
+}
+
+// GitHub Apps API execution functions
+
+// executeListAppInstallations executes the list_app_installations tool
+func (h *Handler) executeListAppInstallations(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	var org string
+	var page, perPage int
+
+	if o, ok := args["org"].(string); ok {
+		org = o
+	}
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	// Organization installations require org admin access; otherwise list what the user can see
+	scope := "authenticated user"
+	var installations *client.InstallationList
+	var pageInfo *client.PageInfo
+	var err error
+	if org != "" {
+		scope = "organization " + org
+		installations, pageInfo, err = h.githubClient.ListOrganizationInstallations(ctx, org, page, perPage)
+	} else {
+		installations, pageInfo, err = h.githubClient.ListUserInstallations(ctx, page, perPage)
+	}
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing app installations for %s: %v", scope, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	installationsJSON, err := shapeJSON(args, h.newListEnvelope(args, installations.Installations, pageInfo, listPage{page: page, perPage: perPage, total: installations.TotalCount}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting installations data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(installationsJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeListInstallationRepositories executes the list_installation_repositories tool
+func (h *Handler) executeListInstallationRepositories(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	installationID, ok := args["installation_id"].(float64)
+	if !ok || installationID <= 0 {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: installation_id parameter is required and must be a positive integer"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	repos, pageInfo, err := h.githubClient.ListInstallationRepositories(ctx, int64(installationID), page, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing repositories for installation %d: %v", int64(installationID), err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	reposJSON, err := shapeJSON(args, h.newListEnvelope(args, repos.Repositories, pageInfo, listPage{page: page, perPage: perPage, total: repos.TotalCount}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting repositories data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(reposJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeAddInstallationRepository executes the add_installation_repository tool
+func (h *Handler) executeAddInstallationRepository(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	installationID, repositoryID, errResult := h.installationRepositoryArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	if err := h.githubClient.AddInstallationRepository(ctx, installationID, repositoryID); err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error adding repository %d to installation %d: %v", repositoryID, installationID, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: h.messages.Sprintf("Successfully added repository %d to installation %d", repositoryID, installationID),
+		}},
+		IsError: false,
+	}, nil
+}
+
+// executeRemoveInstallationRepository executes the remove_installation_repository tool
+func (h *Handler) executeRemoveInstallationRepository(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	installationID, repositoryID, errResult := h.installationRepositoryArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	record, errResult := h.captureDeletion(ctx, "remove_installation_repository", fmt.Sprintf("repository %d of installation %d", repositoryID, installationID), args, func() (interface{}, error) {
+		return h.snapshotInstallationRepository(ctx, installationID, repositoryID)
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	if err := h.githubClient.RemoveInstallationRepository(ctx, installationID, repositoryID); err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error removing repository %d from installation %d: %v", repositoryID, installationID, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	h.recordDeletion(ctx, record)
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: h.messages.Sprintf("Successfully removed repository %d from installation %d", repositoryID, installationID),
+		}},
+		IsError: false,
+	}, nil
+}
+
+// installationRepositoryArgs extracts the installation and repository IDs shared by
+// the add/remove installation repository tools
+func (h *Handler) installationRepositoryArgs(args map[string]interface{}) (int64, int64, *CallToolResult) {
+	installationID, ok := args["installation_id"].(float64)
+	if !ok || installationID <= 0 {
+		return 0, 0, &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: installation_id parameter is required and must be a positive integer"),
+			}},
+			IsError: true,
+		}
+	}
+
+	repositoryID, ok := args["repository_id"].(float64)
+	if !ok || repositoryID <= 0 {
+		return 0, 0, &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: repository_id parameter is required and must be a positive integer"),
+			}},
+			IsError: true,
+		}
+	}
+
+	return int64(installationID), int64(repositoryID), nil
+}
@@ -0,0 +1,319 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// branchProtectionSettings are the protections update_branch_protection
+// changes when given, keeping the others as they are
+var branchProtectionSettings = []string{"required_status_checks", "enforce_admins", "required_pull_request_reviews",
+	"restrictions", "required_linear_history", "allow_force_pushes", "allow_deletions", "required_conversation_resolution",
+	"lock_branch"}
+
+// branchProtectionTools returns the tools reading and changing branch
+// protection
+func (h *Handler) branchProtectionTools() []ToolProvider {
+	branchProperties := func() map[string]interface{} {
+		return map[string]interface{}{
+			"owner":  stringProperty("Repository owner"),
+			"repo":   stringProperty("Repository name"),
+			"branch": stringProperty("Name of the branch"),
+		}
+	}
+
+	updateProperties := branchProperties()
+	updateProperties["required_status_checks"] = map[string]interface{}{
+		"type":        "object",
+		"description": "Status checks that must pass before merging; null to require none",
+		"properties": map[string]interface{}{
+			"strict":   booleanProperty("Require branches to be up to date with the base branch before merging"),
+			"contexts": stringArrayProperty("Names of the status checks that must pass"),
+		},
+		"required": []string{"strict", "contexts"},
+	}
+	updateProperties["enforce_admins"] = booleanProperty("Enforce the protection for repository administrators too")
+	updateProperties["required_pull_request_reviews"] = map[string]interface{}{
+		"type":        "object",
+		"description": "Reviews pull requests need before merging; null to require none",
+		"properties": map[string]interface{}{
+			"required_approving_review_count": map[string]interface{}{
+				"type":        "integer",
+				"description": "Number of approving reviews required",
+				"minimum":     0,
+				"maximum":     6,
+			},
+			"dismiss_stale_reviews":      booleanProperty("Dismiss approving reviews when new commits are pushed"),
+			"require_code_owner_reviews": booleanProperty("Require a review from a code owner"),
+			"require_last_push_approval": booleanProperty("Require the last push to be approved by someone other than its pusher"),
+		},
+	}
+	updateProperties["restrictions"] = map[string]interface{}{
+		"type":        "object",
+		"description": "Users, teams and apps allowed to push to the branch, in organization repositories; null to allow everyone with write access",
+		"properties": map[string]interface{}{
+			"users": stringArrayProperty("Logins of the users allowed to push"),
+			"teams": stringArrayProperty("Slugs of the teams allowed to push"),
+			"apps":  stringArrayProperty("Slugs of the GitHub Apps allowed to push"),
+		},
+		"required": []string{"users", "teams"},
+	}
+	updateProperties["required_linear_history"] = booleanProperty("Prevent merge commits from being pushed to the branch")
+	updateProperties["allow_force_pushes"] = booleanProperty("Allow force pushes to the branch")
+	updateProperties["allow_deletions"] = booleanProperty("Allow the branch to be deleted")
+	updateProperties["required_conversation_resolution"] = booleanProperty("Require review conversations to be resolved before merging")
+	updateProperties["lock_branch"] = booleanProperty("Make the branch read-only, so nobody can push to it")
+
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "get_branch_protection",
+			Description: "Get the protection of a branch: its required status checks, required reviews, whether admins are included, push restrictions and other rules",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": branchProperties(),
+				"required":   []string{"owner", "repo", "branch"},
+			},
+			Shaped: true,
+		}, h.executeGetBranchProtection),
+		NewTool(Tool{
+			Name:        "update_branch_protection",
+			Description: "Change the protection of a branch, protecting it if it is not yet. Protections not given are kept as they are; turn one off with false, or null for the status checks, reviews and push restrictions.",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": updateProperties,
+				"required":   []string{"owner", "repo", "branch"},
+			},
+			Shaped: true,
+		}, h.executeUpdateBranchProtection),
+		NewTool(Tool{
+			Name:        "delete_branch_protection",
+			Description: "Remove every protection of a branch",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": branchProperties(),
+				"required":   []string{"owner", "repo", "branch"},
+			},
+		}, h.executeDeleteBranchProtection),
+	}
+}
+
+// branchArgs reads the owner, repo and branch arguments, returning an error
+// result when one is missing
+func (h *Handler) branchArgs(args map[string]interface{}) (owner, repo, branch string, errResult *CallToolResult) {
+	owner, repo, errResult = h.repositoryArgs(args)
+	if errResult != nil {
+		return "", "", "", errResult
+	}
+
+	branch, ok := args["branch"].(string)
+	if !ok || branch == "" {
+		return "", "", "", &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("branch is required and must be a string"),
+			}},
+			IsError: true,
+		}
+	}
+	return owner, repo, branch, nil
+}
+
+// protectionUpdate returns the update request keeping the current protection
+// of a branch, which is nil for an unprotected branch. GitHub requires the
+// first four protections, null turning them off.
+func protectionUpdate(current *client.BranchProtection) map[string]interface{} {
+	update := map[string]interface{}{
+		"required_status_checks":        nil,
+		"enforce_admins":                nil,
+		"required_pull_request_reviews": nil,
+		"restrictions":                  nil,
+	}
+	if current == nil {
+		return update
+	}
+
+	if checks := current.RequiredStatusChecks; checks != nil {
+		statusChecks := map[string]interface{}{"strict": checks.Strict}
+		if len(checks.Checks) > 0 {
+			// Checks keep the app each status must come from
+			items := make([]map[string]interface{}, len(checks.Checks))
+			for i, check := range checks.Checks {
+				items[i] = map[string]interface{}{"context": check.Context}
+				if check.AppID != nil {
+					items[i]["app_id"] = *check.AppID
+				}
+			}
+			statusChecks["checks"] = items
+		} else {
+			statusChecks["contexts"] = append([]string{}, checks.Contexts...)
+		}
+		update["required_status_checks"] = statusChecks
+	}
+	if reviews := current.RequiredPullRequestReviews; reviews != nil {
+		reviewUpdate := map[string]interface{}{
+			"dismiss_stale_reviews":           reviews.DismissStaleReviews,
+			"require_code_owner_reviews":      reviews.RequireCodeOwnerReviews,
+			"required_approving_review_count": reviews.RequiredApprovingReviewCount,
+			"require_last_push_approval":      reviews.RequireLastPushApproval,
+		}
+		if reviews.DismissalRestrictions != nil {
+			reviewUpdate["dismissal_restrictions"] = restrictionsUpdate(reviews.DismissalRestrictions)
+		}
+		update["required_pull_request_reviews"] = reviewUpdate
+	}
+	if current.Restrictions != nil {
+		update["restrictions"] = restrictionsUpdate(current.Restrictions)
+	}
+	for field, setting := range map[string]*client.ProtectionSetting{
+		"enforce_admins":                   current.EnforceAdmins,
+		"required_linear_history":          current.RequiredLinearHistory,
+		"allow_force_pushes":               current.AllowForcePushes,
+		"allow_deletions":                  current.AllowDeletions,
+		"required_conversation_resolution": current.RequiredConversationResolution,
+		"lock_branch":                      current.LockBranch,
+	} {
+		if setting != nil {
+			update[field] = setting.Enabled
+		}
+	}
+	return update
+}
+
+// restrictionsUpdate returns branch restrictions as the logins and slugs an
+// update request names them by
+func restrictionsUpdate(restrictions *client.BranchRestrictions) map[string]interface{} {
+	users := []string{}
+	for _, user := range restrictions.Users {
+		users = append(users, user.Login)
+	}
+	teams := []string{}
+	for _, team := range restrictions.Teams {
+		teams = append(teams, team.Slug)
+	}
+	apps := []string{}
+	for _, app := range restrictions.Apps {
+		apps = append(apps, app.Slug)
+	}
+	return map[string]interface{}{"users": users, "teams": teams, "apps": apps}
+}
+
+// branchProtectionResult returns a branch protection as the result of a tool
+func (h *Handler) branchProtectionResult(args map[string]interface{}, protection interface{}) *CallToolResult {
+	protectionJSON, err := shapeJSON(args, protection)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting branch protection data: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: string(protectionJSON),
+		}},
+		IsError: false,
+	}
+}
+
+// executeGetBranchProtection executes the get_branch_protection tool
+func (h *Handler) executeGetBranchProtection(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, branch, errResult := h.branchArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	protection, err := h.githubClient.GetBranchProtection(ctx, owner, repo, branch)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting protection of branch %s in %s/%s: %v", branch, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.branchProtectionResult(args, protection), nil
+}
+
+// executeUpdateBranchProtection executes the update_branch_protection tool
+func (h *Handler) executeUpdateBranchProtection(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, branch, errResult := h.branchArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// GitHub replaces the whole protection, so start from the current one
+	current, err := h.githubClient.GetBranchProtection(ctx, owner, repo, branch)
+	if err != nil && !errors.IsType(err, errors.ErrorTypeNotFound) {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting protection of branch %s in %s/%s: %v", branch, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	protection := protectionUpdate(current)
+	for _, field := range branchProtectionSettings {
+		if value, exists := args[field]; exists {
+			protection[field] = value
+		}
+	}
+
+	updated, err := h.githubClient.UpdateBranchProtection(ctx, owner, repo, branch, protection)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error updating protection of branch %s in %s/%s: %v", branch, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.branchProtectionResult(args, updated), nil
+}
+
+// executeDeleteBranchProtection executes the delete_branch_protection tool
+func (h *Handler) executeDeleteBranchProtection(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, branch, errResult := h.branchArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	record, errResult := h.captureDeletion(ctx, "delete_branch_protection", fmt.Sprintf("protection of branch %s in %s/%s", branch, owner, repo), args, func() (interface{}, error) {
+		return h.githubClient.GetBranchProtection(ctx, owner, repo, branch)
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	if err := h.githubClient.DeleteBranchProtection(ctx, owner, repo, branch); err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error deleting protection of branch %s in %s/%s: %v", branch, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	h.recordDeletion(ctx, record)
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: h.messages.Sprintf("Successfully deleted protection of branch %s in %s/%s", branch, owner, repo),
+		}},
+		IsError: false,
+	}, nil
+}
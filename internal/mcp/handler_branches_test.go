@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestBranchProtectionTools(t *testing.T) {
+	var requests []string
+	var lastBody map[string]interface{}
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			lastBody = nil
+			if req.Body != nil {
+				json.NewDecoder(req.Body).Decode(&lastBody)
+			}
+			if req.Method == http.MethodDelete {
+				return mocks.MockResponse(http.StatusNoContent, "", nil), nil
+			}
+			return mocks.MockJSONResponse(http.StatusOK, `{"url": "https://api.github.com/repos/acme/widgets/branches/main/protection",
+				"required_status_checks": {"strict": true, "contexts": ["ci"]},
+				"enforce_admins": {"enabled": true},
+				"required_pull_request_reviews": {"required_approving_review_count": 2, "require_code_owner_reviews": true},
+				"allow_force_pushes": {"enabled": false}}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	ctx := context.Background()
+
+	result, _ := h.executeGetBranchProtection(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "branch": "main"})
+	if result.IsError {
+		t.Fatalf("Unexpected error result: %s", result.Content[0].Text)
+	}
+	var protection client.BranchProtection
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &protection); err != nil {
+		t.Fatalf("Invalid protection %s: %v", result.Content[0].Text, err)
+	}
+	if !protection.RequiredStatusChecks.Strict || !protection.EnforceAdmins.Enabled || protection.RequiredPullRequestReviews.RequiredApprovingReviewCount != 2 ||
+		protection.Restrictions != nil {
+		t.Errorf("Unexpected protection %+v", protection)
+	}
+	if requests[0] != "GET /repos/acme/widgets/branches/main/protection" {
+		t.Errorf("Unexpected request %s", requests[0])
+	}
+
+	result, _ = h.executeGetBranchProtection(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets"})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "branch") {
+		t.Errorf("Expected a call without branch to fail, got %+v", result)
+	}
+
+	result, _ = h.executeUpdateBranchProtection(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "branch": "main",
+		"enforce_admins": true, "required_linear_history": true, "verbose": true,
+		"required_pull_request_reviews": map[string]interface{}{"required_approving_review_count": float64(1)}})
+	if result.IsError {
+		t.Fatalf("Unexpected error result: %s", result.Content[0].Text)
+	}
+	if lastBody["enforce_admins"] != true || lastBody["required_linear_history"] != true || lastBody["verbose"] != nil {
+		t.Errorf("Unexpected update request %v", lastBody)
+	}
+	// The protections not given are kept as they are
+	if checks, _ := lastBody["required_status_checks"].(map[string]interface{}); checks["strict"] != true || len(checks["contexts"].([]interface{})) != 1 {
+		t.Errorf("Expected the status checks kept, got %v", lastBody)
+	}
+	if reviews, _ := lastBody["required_pull_request_reviews"].(map[string]interface{}); reviews["required_approving_review_count"] != float64(1) {
+		t.Errorf("Expected the reviews given, got %v", lastBody)
+	}
+	if value, ok := lastBody["restrictions"]; !ok || value != nil || lastBody["allow_force_pushes"] != false {
+		t.Errorf("Expected the protections not set kept off, got %v", lastBody)
+	}
+
+	result, _ = h.executeUpdateBranchProtection(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "branch": "main",
+		"required_status_checks": nil, "lock_branch": true})
+	if value, ok := lastBody["required_status_checks"]; result.IsError || !ok || value != nil || lastBody["lock_branch"] != true {
+		t.Errorf("Expected the status checks turned off and the branch locked, got %v (%+v)", lastBody, result)
+	}
+
+	requests = nil
+	result, _ = h.executeGetBranchProtection(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "branch": "release/1.x?"})
+	if result.IsError || requests[0] != "GET /repos/acme/widgets/branches/release/1.x?/protection" {
+		t.Errorf("Expected the branch name escaped, got %v (%+v)", requests, result)
+	}
+	requests = nil
+	result, _ = h.executeDeleteBranchProtection(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "branch": "../../../other/repo"})
+	if !result.IsError || len(requests) != 0 {
+		t.Errorf("Expected a branch with dot segments rejected, got %v (%+v)", requests, result)
+	}
+
+	requests = nil
+	result, _ = h.executeDeleteBranchProtection(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "branch": "main"})
+	if result.IsError || len(requests) != 1 || requests[0] != "DELETE /repos/acme/widgets/branches/main/protection" {
+		t.Errorf("Unexpected delete %v (%+v)", requests, result)
+	}
+}
@@ -0,0 +1,267 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+// commitTools returns the tools reading the commits of repositories
+func (h *Handler) commitTools() []ToolProvider {
+	includePatch := map[string]interface{}{
+		"type":        "boolean",
+		"description": "Include the diff of each file changed; false returns only the file names and line counts",
+		"default":     true,
+	}
+
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "list_commits",
+			Description: "List the commits of a repository's default branch, or of another branch, tag or commit, newest first, optionally only those touching a path, by an author or within a time range",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner":    stringProperty("Repository owner"),
+					"repo":     stringProperty("Repository name"),
+					"sha":      stringProperty("Branch, tag or commit SHA to list the commits from; the default branch when not given"),
+					"path":     stringProperty("Only commits changing this file or directory"),
+					"author":   stringProperty("Only commits by this GitHub login or email address"),
+					"since":    stringProperty("Only commits after this ISO 8601 timestamp, such as 2024-01-31T00:00:00Z"),
+					"until":    stringProperty("Only commits before this ISO 8601 timestamp"),
+					"page":     pageProperty(),
+					"per_page": perPageProperty(),
+				},
+				"required": []string{"owner", "repo"},
+			},
+			Shaped: true,
+		}, h.executeListCommits),
+		NewTool(Tool{
+			Name:        "get_commit",
+			Description: "Get a commit with its message, authors, line stats and the files it changed with their diffs",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner":         stringProperty("Repository owner"),
+					"repo":          stringProperty("Repository name"),
+					"ref":           stringProperty("Commit SHA, or a branch or tag for its latest commit"),
+					"include_patch": includePatch,
+				},
+				"required": []string{"owner", "repo", "ref"},
+			},
+			Shaped: true,
+		}, h.executeGetCommit),
+		NewTool(Tool{
+			Name:        "compare_commits",
+			Description: "Compare two branches, tags or commits as base...head: how far head is ahead of and behind base, the commits between them, and the files changed with their diffs. Commits are paginated; the files are only returned on the first page.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner":         stringProperty("Repository owner"),
+					"repo":          stringProperty("Repository name"),
+					"base":          stringProperty("Branch, tag or commit SHA to compare from"),
+					"head":          stringProperty("Branch, tag or commit SHA to compare to base; owner:branch for a branch of a fork"),
+					"include_patch": includePatch,
+					"page":          pageProperty(),
+					"per_page":      perPageProperty(),
+				},
+				"required": []string{"owner", "repo", "base", "head"},
+			},
+			Shaped: true,
+		}, h.executeCompareCommits),
+	}
+}
+
+// commitResult returns a commit or comparison as the result of a tool
+func (h *Handler) commitResult(args map[string]interface{}, value interface{}) *CallToolResult {
+	valueJSON, err := shapeJSON(args, value)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting commit data: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: string(valueJSON),
+		}},
+		IsError: false,
+	}
+}
+
+// patchTruncation tells the caller which file diffs were left out of a
+// commit or comparison to fit the result size limit
+type patchTruncation struct {
+	OmittedPatches int `json:"omitted_patches"`
+	// Files names the first files whose diff was left out
+	Files   []string `json:"files"`
+	Message string   `json:"message"`
+}
+
+// truncatedCommit is a commit with the diffs left out of it
+type truncatedCommit struct {
+	*client.Commit
+	Truncated *patchTruncation `json:"truncated,omitempty"`
+}
+
+// truncatedComparison is a comparison with the diffs left out of it
+type truncatedComparison struct {
+	*client.Comparison
+	Truncated *patchTruncation `json:"truncated,omitempty"`
+}
+
+// truncatePatches clears the diffs of files once their total size exceeds
+// the result size limit, returning nil when every diff fits
+func (h *Handler) truncatePatches(files []client.CommitFile) *patchTruncation {
+	limit := int(h.maxResultSize.Load())
+	if limit <= 0 {
+		return nil
+	}
+
+	var truncation *patchTruncation
+	size := 0
+	for i := range files {
+		if files[i].Patch == "" {
+			continue
+		}
+		if size+len(files[i].Patch) <= limit {
+			size += len(files[i].Patch)
+			continue
+		}
+		if truncation == nil {
+			truncation = &patchTruncation{}
+		}
+		truncation.OmittedPatches++
+		if len(truncation.Files) < omittedItemsMax {
+			truncation.Files = append(truncation.Files, files[i].Filename)
+		}
+		files[i].Patch = ""
+	}
+	if truncation != nil {
+		truncation.Message = h.messages.Sprintf("The diffs of %d of %d files were left out to fit the result size limit; see them on GitHub at html_url", truncation.OmittedPatches, len(files))
+	}
+	return truncation
+}
+
+// omitPatches clears the diffs of files unless args asks for them
+func omitPatches(args map[string]interface{}, files []client.CommitFile) {
+	if include, ok := args["include_patch"].(bool); !ok || include {
+		return
+	}
+	for i := range files {
+		files[i].Patch = ""
+	}
+}
+
+// executeListCommits executes the list_commits tool
+func (h *Handler) executeListCommits(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, errResult := h.repositoryArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	sha, _ := args["sha"].(string)
+	path, _ := args["path"].(string)
+	author, _ := args["author"].(string)
+	since, _ := args["since"].(string)
+	until, _ := args["until"].(string)
+
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	commits, pageInfo, err := h.githubClient.ListCommits(ctx, owner, repo, sha, path, author, since, until, page, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing commits of %s/%s: %v", owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.commitResult(args, h.newListEnvelope(args, commits, pageInfo, listPage{page: page, perPage: perPage})), nil
+}
+
+// executeGetCommit executes the get_commit tool
+func (h *Handler) executeGetCommit(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, errResult := h.repositoryArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	ref, ok := args["ref"].(string)
+	if !ok || ref == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("ref is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	commit, err := h.githubClient.GetCommit(ctx, owner, repo, ref)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting commit %s of %s/%s: %v", ref, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	omitPatches(args, commit.Files)
+	return h.commitResult(args, truncatedCommit{Commit: commit, Truncated: h.truncatePatches(commit.Files)}), nil
+}
+
+// executeCompareCommits executes the compare_commits tool
+func (h *Handler) executeCompareCommits(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, errResult := h.repositoryArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	base, _ := args["base"].(string)
+	head, _ := args["head"].(string)
+	if base == "" || head == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: base and head parameters are required"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	comparison, err := h.githubClient.CompareCommits(ctx, owner, repo, base, head, page, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error comparing %s...%s in %s/%s: %v", base, head, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	omitPatches(args, comparison.Files)
+	return h.commitResult(args, truncatedComparison{Comparison: comparison, Truncated: h.truncatePatches(comparison.Files)}), nil
+}
@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestCommitTools(t *testing.T) {
+	var requests []string
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.URL.Path+"?"+req.URL.RawQuery)
+			switch req.URL.Path {
+			case "/repos/acme/widgets/commits":
+				return mocks.MockJSONResponse(http.StatusOK, `[{"sha": "6dcb09b", "url": "https://api.github.com/repos/acme/widgets/commits/6dcb09b",
+					"commit": {"message": "Fix all the bugs"}}]`), nil
+			case "/repos/acme/widgets/commits/6dcb09b":
+				return mocks.MockJSONResponse(http.StatusOK, `{"sha": "6dcb09b", "commit": {"message": "Fix all the bugs"},
+					"stats": {"additions": 1, "deletions": 1, "total": 2},
+					"files": [{"filename": "main.go", "status": "modified", "changes": 2, "patch": "@@ -1 +1 @@\n-old\n+new"}]}`), nil
+			case "/repos/acme/widgets/compare/main...feature":
+				return mocks.MockJSONResponse(http.StatusOK, `{"status": "ahead", "ahead_by": 1, "total_commits": 1,
+					"commits": [{"sha": "6dcb09b", "commit": {"message": "Fix all the bugs"}}],
+					"files": [{"filename": "main.go", "status": "modified", "changes": 2, "patch": "@@ -1 +1 @@\n-old\n+new"}]}`), nil
+			}
+			return mocks.MockJSONResponse(http.StatusNotFound, `{"message": "Not Found"}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	ctx := context.Background()
+
+	result, _ := h.executeListCommits(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "path": "cmd", "since": "2024-01-01T00:00:00Z"})
+	if result.IsError || !strings.Contains(result.Content[0].Text, `"message":"Fix all the bugs"`) || !strings.Contains(result.Content[0].Text, `"pagination"`) {
+		t.Errorf("Expected the commits listed, got %+v", result)
+	}
+	if !strings.Contains(requests[0], "path=cmd") || !strings.Contains(requests[0], "since=2024-01-01T00%3A00%3A00Z") {
+		t.Errorf("Expected the filters sent, got %s", requests[0])
+	}
+
+	result, _ = h.executeGetCommit(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "ref": "6dcb09b"})
+	if result.IsError || !strings.Contains(result.Content[0].Text, `"total":2`) || !strings.Contains(result.Content[0].Text, `"patch"`) {
+		t.Errorf("Expected the commit with its stats and diffs, got %+v", result)
+	}
+	result, _ = h.executeGetCommit(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "ref": "6dcb09b", "include_patch": false})
+	if result.IsError || strings.Contains(result.Content[0].Text, `"patch"`) || !strings.Contains(result.Content[0].Text, `"filename":"main.go"`) {
+		t.Errorf("Expected the files without their diffs, got %+v", result)
+	}
+	result, _ = h.executeGetCommit(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets"})
+	if !result.IsError {
+		t.Error("Expected a call without ref to fail")
+	}
+
+	result, _ = h.executeCompareCommits(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "base": "main", "head": "feature"})
+	if result.IsError || !strings.Contains(result.Content[0].Text, `"ahead_by":1`) || !strings.Contains(result.Content[0].Text, `"patch"`) {
+		t.Errorf("Expected the comparison with its diffs, got %+v", result)
+	}
+	result, _ = h.executeCompareCommits(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "base": "main"})
+	if !result.IsError {
+		t.Error("Expected a comparison without head to fail")
+	}
+
+	if !readOnlyTool("compare_commits") {
+		t.Error("Expected compare_commits to be read-only")
+	}
+	if prefetchableTool("compare_commits") {
+		t.Error("Expected compare_commits not to be prefetched")
+	}
+}
+
+func TestCommitTools_TruncatesPatches(t *testing.T) {
+	var requested string
+	patch := strings.Repeat("+line", 20)
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requested = req.URL.EscapedPath()
+			return mocks.MockJSONResponse(http.StatusOK, `{"sha": "6dcb09b", "files": [
+				{"filename": "a.go", "patch": "`+patch+`"},
+				{"filename": "b.go", "patch": "`+patch+`"},
+				{"filename": "c.go", "patch": "`+patch+`"}]}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetMaxResultSize(2 * len(patch))
+
+	result, _ := h.executeGetCommit(context.Background(), map[string]interface{}{"owner": "acme", "repo": "widgets", "ref": "fix#1"})
+	if result.IsError {
+		t.Fatalf("Expected the commit, got %+v", result)
+	}
+	text := result.Content[0].Text
+	if strings.Count(text, `"patch"`) != 2 || !strings.Contains(text, `"omitted_patches":1`) || !strings.Contains(text, `"files":["c.go"]`) {
+		t.Errorf("Expected the last diff left out and reported, got %s", text)
+	}
+	if requested != "/repos/acme/widgets/commits/fix%231" {
+		t.Errorf("Expected the ref escaped, got %s", requested)
+	}
+
+	h.SetMaxResultSize(0)
+	result, _ = h.executeCompareCommits(context.Background(), map[string]interface{}{"owner": "acme", "repo": "widgets", "base": "main", "head": "fork:feature/issue#2"})
+	if result.IsError || strings.Count(result.Content[0].Text, `"patch"`) != 3 || strings.Contains(result.Content[0].Text, `"truncated"`) {
+		t.Errorf("Expected every diff without a limit, got %+v", result)
+	}
+	if requested != "/repos/acme/widgets/compare/main...fork:feature/issue%232" {
+		t.Errorf("Expected base and head escaped, got %s", requested)
+	}
+}
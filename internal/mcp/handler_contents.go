@@ -0,0 +1,482 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// contentsTools returns the tools reading and changing repository contents
+func (h *Handler) contentsTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "get_file_contents",
+			Description: "Get a file from a repository's default branch, or from another branch, tag or commit. The file is returned as an embedded github:// resource that can be read again through resources/read.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner": stringProperty("Repository owner"),
+					"repo":  stringProperty("Repository name"),
+					"path":  stringProperty("Path of the file in the repository"),
+					"ref":   stringProperty("Branch, tag or commit SHA to read the file at; the default branch when not given"),
+				},
+				"required": []string{"owner", "repo", "path"},
+			},
+		}, h.executeGetFileContents),
+		NewTool(Tool{
+			Name:        "list_directory",
+			Description: "List the files and directories in a repository directory, with their type, size and SHA, without downloading their contents. Each file and directory has the github:// resource URI it can be read through.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner": stringProperty("Repository owner"),
+					"repo":  stringProperty("Repository name"),
+					"path":  stringProperty("Path of the directory in the repository; the root directory when not given"),
+					"ref":   stringProperty("Branch, tag or commit SHA to list the directory at; the default branch when not given"),
+				},
+				"required": []string{"owner", "repo"},
+			},
+			Shaped: true,
+		}, h.executeListDirectory),
+		NewTool(Tool{
+			Name:        "create_or_update_file",
+			Description: "Create a file, or replace its contents, in a single commit on a branch. To replace a file, give the SHA of the file read last to make sure it was not changed since, or set overwrite to replace it whatever its contents.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner":   stringProperty("Repository owner"),
+					"repo":    stringProperty("Repository name"),
+					"path":    stringProperty("Path of the file in the repository"),
+					"content": stringProperty("New contents of the file"),
+					"encoding": map[string]interface{}{
+						"type":        "string",
+						"description": "Encoding of content: utf-8 for text, or base64 for binary files",
+						"enum":        []string{"utf-8", "base64"},
+						"default":     "utf-8",
+					},
+					"message": stringProperty("Commit message"),
+					"branch":  stringProperty("Branch to commit to; the default branch when not given"),
+					"sha":     stringProperty("Blob SHA of the file being replaced, as returned by get_file_contents"),
+					"overwrite": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Replace the file when it exists and sha is not given",
+						"default":     false,
+					},
+				},
+				"required": []string{"owner", "repo", "path", "content", "message"},
+			},
+			Shaped: true,
+		}, h.executeCreateOrUpdateFile),
+		NewTool(Tool{
+			Name:        "delete_file",
+			Description: "Delete a file in a single commit on a branch",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner":   stringProperty("Repository owner"),
+					"repo":    stringProperty("Repository name"),
+					"path":    stringProperty("Path of the file in the repository"),
+					"message": stringProperty("Commit message"),
+					"branch":  stringProperty("Branch to commit to; the default branch when not given"),
+					"sha":     stringProperty("Blob SHA of the file being deleted, to make sure it was not changed since it was read"),
+				},
+				"required": []string{"owner", "repo", "path", "message"},
+			},
+			Shaped: true,
+		}, h.executeDeleteFile),
+	}
+}
+
+// fileResourceURI returns the github:// URI of a file on a repository's
+// default branch, or at ref when it is not empty
+func fileResourceURI(owner, repo, filePath, ref string) string {
+	segments := strings.Split(strings.Trim(filePath, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	uri := fmt.Sprintf("github://repos/%s/%s/contents/%s", url.PathEscape(owner), url.PathEscape(repo), strings.Join(segments, "/"))
+	if ref != "" {
+		uri += "?ref=" + url.QueryEscape(ref)
+	}
+	return uri
+}
+
+// fileResourcePath returns the repository, path and ref of a file resource
+// URI
+func fileResourcePath(uri string) (owner, repo, filePath, ref string, ok bool) {
+	uri, query, _ := strings.Cut(uri, "?")
+	template, values, matched := matchResourceTemplate(uri)
+	if !matched || !strings.HasSuffix(template.URITemplate, "/contents/{path}") {
+		return "", "", "", "", false
+	}
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return "", "", "", "", false
+	}
+	return values["owner"], values["repo"], values["path"], params.Get("ref"), true
+}
+
+// fileResourceContent returns the resource contents of a file: its text when
+// it is UTF-8 and its base64-encoded bytes otherwise
+func fileResourceContent(uri string, file *client.FileContent) (ResourceContent, error) {
+	data, err := file.DecodedContent()
+	if err != nil {
+		return ResourceContent{}, err
+	}
+	return dataResourceContent(uri, file.Name, data), nil
+}
+
+// dataResourceContent returns the resource contents of the data of the
+// named file, typed by its extension
+func dataResourceContent(uri, name string, data []byte) ResourceContent {
+	mimeType := mime.TypeByExtension(path.Ext(name))
+	if !utf8.Valid(data) {
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		return ResourceContent{URI: uri, MimeType: mimeType, Blob: base64.StdEncoding.EncodeToString(data)}
+	}
+	if mimeType == "" {
+		mimeType = "text/plain"
+	}
+	return ResourceContent{URI: uri, MimeType: mimeType, Text: string(data)}
+}
+
+// readFileResource reads a github://repos/{owner}/{repo}/contents/{path}
+// resource, which is a file or a directory, at ref or on the default branch
+func (h *Handler) readFileResource(ctx context.Context, uri, owner, repo, filePath, ref string) (*ReadResourceResult, error) {
+	file, entries, err := h.githubClient.GetContents(ctx, owner, repo, filePath, ref)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return h.readDirectoryResource(ctx, uri, owner, repo, ref, entries)
+	}
+	if file.Size > largeFileResourceSize || file.Encoding == "none" {
+		return h.readLargeFileResource(ctx, uri, owner, repo, ref, file)
+	}
+	content, err := fileResourceContent(uri, file)
+	if err != nil {
+		return nil, err
+	}
+	return &ReadResourceResult{Contents: []ResourceContent{content}}, nil
+}
+
+// fileArgs reads the owner, repo and path arguments, returning an error
+// result when one is missing
+func (h *Handler) fileArgs(args map[string]interface{}) (owner, repo, filePath string, errResult *CallToolResult) {
+	owner, _ = args["owner"].(string)
+	repo, _ = args["repo"].(string)
+	filePath, _ = args["path"].(string)
+	filePath = strings.Trim(filePath, "/")
+	if owner == "" || repo == "" || filePath == "" {
+		return "", "", "", &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: owner, repo and path parameters are required"),
+			}},
+			IsError: true,
+		}
+	}
+	for _, segment := range strings.Split(filePath, "/") {
+		if segment == "." || segment == ".." {
+			return "", "", "", &CallToolResult{
+				Content: []Content{{
+					Type: "text",
+					Text: h.messages.Sprintf("path must not contain . or .. segments"),
+				}},
+				IsError: true,
+			}
+		}
+	}
+	return owner, repo, filePath, nil
+}
+
+// executeGetFileContents executes the get_file_contents tool
+func (h *Handler) executeGetFileContents(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, filePath, errResult := h.fileArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	ref, _ := args["ref"].(string)
+
+	file, err := h.githubClient.GetFileContents(ctx, owner, repo, filePath, ref)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting %s from %s/%s: %v", filePath, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content, err := fileResourceContent(fileResourceURI(owner, repo, filePath, ref), file)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting %s from %s/%s: %v", filePath, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	text := h.messages.Sprintf("%s from %s/%s (%d bytes, SHA %s)", file.Path, owner, repo, file.Size, file.SHA)
+	if ref != "" {
+		text = h.messages.Sprintf("%s from %s/%s at %s (%d bytes, SHA %s)", file.Path, owner, repo, ref, file.Size, file.SHA)
+	}
+	return &CallToolResult{
+		Content: []Content{
+			{
+				Type: "text",
+				Text: text,
+			},
+			embeddedResource(content),
+		},
+	}, nil
+}
+
+// executeListDirectory executes the list_directory tool
+func (h *Handler) executeListDirectory(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, errResult := h.repositoryArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	dirPath, _ := args["path"].(string)
+	dirPath = strings.Trim(dirPath, "/")
+	ref, _ := args["ref"].(string)
+
+	file, entries, err := h.githubClient.GetContents(ctx, owner, repo, dirPath, ref)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing /%s in %s/%s: %v", dirPath, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+	if file != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("%s is a file, not a directory; read it with get_file_contents", dirPath),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	listing := make([]directoryEntry, len(entries))
+	for i, entry := range entries {
+		listing[i] = directoryEntry{Name: entry.Name, Path: entry.Path, Type: entry.Type, Size: entry.Size, SHA: entry.SHA}
+		if entry.Type == "file" || entry.Type == "dir" {
+			listing[i].URI = fileResourceURI(owner, repo, entry.Path, ref)
+		}
+	}
+
+	// GitHub returns a directory in one response, so there is no next page
+	envelope := h.newListEnvelope(args, listing, nil, listPage{perPage: len(listing), total: len(listing)})
+	listingJSON, err := shapeJSON(args, envelope)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting directory data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: string(listingJSON),
+		}},
+		IsError: false,
+	}, nil
+}
+
+// currentFileSHA returns the blob SHA of a file on branch, or "" when the
+// file does not exist
+func (h *Handler) currentFileSHA(ctx context.Context, owner, repo, filePath, branch string) (string, error) {
+	file, err := h.githubClient.GetFileContents(ctx, owner, repo, filePath, branch)
+	if errors.IsType(err, errors.ErrorTypeNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return file.SHA, nil
+}
+
+// fileCommitResult returns the commit changing a file as the result of a tool
+func (h *Handler) fileCommitResult(args map[string]interface{}, commit *client.FileCommit) *CallToolResult {
+	commitJSON, err := shapeJSON(args, commit)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting commit data: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: string(commitJSON),
+		}},
+		IsError: false,
+	}
+}
+
+// executeCreateOrUpdateFile executes the create_or_update_file tool
+func (h *Handler) executeCreateOrUpdateFile(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, filePath, errResult := h.fileArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	text, ok := args["content"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("content is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+	message, ok := args["message"].(string)
+	if !ok || message == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("message is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+	branch, _ := args["branch"].(string)
+
+	content := []byte(text)
+	if encoding, _ := args["encoding"].(string); encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return &CallToolResult{
+				Content: []Content{{
+					Type: "text",
+					Text: h.messages.Sprintf("content is not valid base64: %v", err),
+				}},
+				IsError: true,
+			}, nil
+		}
+		content = decoded
+	}
+
+	// Without a SHA, only create the file, unless asked to replace it as it
+	// is on the branch
+	sha, _ := args["sha"].(string)
+	if sha == "" {
+		current, err := h.currentFileSHA(ctx, owner, repo, filePath, branch)
+		if err != nil {
+			return &CallToolResult{
+				Content: []Content{{
+					Type: "text",
+					Text: h.messages.Sprintf("Error getting %s from %s/%s: %v", filePath, owner, repo, err),
+				}},
+				IsError: true,
+			}, nil
+		}
+		if overwrite, _ := args["overwrite"].(bool); current != "" && !overwrite {
+			return &CallToolResult{
+				Content: []Content{{
+					Type: "text",
+					Text: h.messages.Sprintf("%s already exists in %s/%s; pass its sha from get_file_contents, or overwrite: true to replace it", filePath, owner, repo),
+				}},
+				IsError: true,
+			}, nil
+		}
+		sha = current
+	}
+
+	commit, err := h.githubClient.CreateOrUpdateFile(ctx, owner, repo, filePath, content, message, branch, sha)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error writing %s in %s/%s: %v", filePath, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.fileCommitResult(args, commit), nil
+}
+
+// executeDeleteFile executes the delete_file tool
+func (h *Handler) executeDeleteFile(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, filePath, errResult := h.fileArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	message, ok := args["message"].(string)
+	if !ok || message == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("message is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+	branch, _ := args["branch"].(string)
+	sha, _ := args["sha"].(string)
+
+	record, errResult := h.captureDeletion(ctx, "delete_file", fmt.Sprintf("file %s in %s/%s", filePath, owner, repo), args, func() (interface{}, error) {
+		return h.githubClient.GetFileContents(ctx, owner, repo, filePath, branch)
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	if sha == "" {
+		file, err := h.githubClient.GetFileContents(ctx, owner, repo, filePath, branch)
+		if err != nil {
+			return &CallToolResult{
+				Content: []Content{{
+					Type: "text",
+					Text: h.messages.Sprintf("Error getting %s from %s/%s: %v", filePath, owner, repo, err),
+				}},
+				IsError: true,
+			}, nil
+		}
+		sha = file.SHA
+	}
+
+	commit, err := h.githubClient.DeleteFile(ctx, owner, repo, filePath, message, branch, sha)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error deleting %s from %s/%s: %v", filePath, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	h.recordDeletion(ctx, record)
+
+	return h.fileCommitResult(args, commit), nil
+}
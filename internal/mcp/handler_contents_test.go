@@ -0,0 +1,388 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestExecuteGetFileContents(t *testing.T) {
+	files := map[string]string{
+		"/repos/octocat/hello-world/contents/docs/README.md": "# Hello\n",
+		"/repos/octocat/hello-world/contents/logo.png":       "\x89PNG\r\n\x1a\n\xff",
+	}
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			data, ok := files[req.URL.Path]
+			if !ok {
+				return mocks.MockJSONResponse(404, `{"message": "Not Found"}`), nil
+			}
+			name := req.URL.Path[len("/repos/octocat/hello-world/contents/"):]
+			return mocks.MockJSONResponse(200, fmt.Sprintf(`{"type": "file", "encoding": "base64", "size": %d, "name": %q, "path": %q, "sha": "abc123", "content": %q}`,
+				len(data), path.Base(name), name, base64.StdEncoding.EncodeToString([]byte(data)))), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	ctx := context.Background()
+
+	result, err := h.executeGetFileContents(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "/docs/README.md"})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %+v", err, result)
+	}
+	if len(result.Content) != 2 || result.Content[1].Type != "resource" {
+		t.Fatalf("Expected a summary and an embedded resource, got %+v", result.Content)
+	}
+	embedded := result.Content[1].Resource
+	if embedded.URI != "github://repos/octocat/hello-world/contents/docs/README.md" || embedded.Text != "# Hello\n" || embedded.MimeType != "text/markdown; charset=utf-8" {
+		t.Errorf("Unexpected embedded resource %+v", embedded)
+	}
+
+	// The embedded URI reads the same contents through the resources API
+	read, err := h.readResource(ctx, embedded.URI)
+	if err != nil || len(read.Contents) != 1 || *embedded != read.Contents[0] {
+		t.Errorf("Expected resources/read to return the embedded resource, got %+v (%v)", read, err)
+	}
+
+	result, _ = h.executeGetFileContents(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "logo.png"})
+	if embedded := result.Content[1].Resource; embedded.Text != "" || embedded.MimeType != "image/png" ||
+		embedded.Blob != base64.StdEncoding.EncodeToString([]byte(files["/repos/octocat/hello-world/contents/logo.png"])) {
+		t.Errorf("Expected a binary file embedded as a blob, got %+v", embedded)
+	}
+
+	result, _ = h.executeGetFileContents(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "missing.txt"})
+	if !result.IsError {
+		t.Error("Expected an error result for a missing file")
+	}
+}
+
+func TestReadFileResource_Directory(t *testing.T) {
+	large := strings.Repeat("x", largeFileResourceSize+1)
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Path {
+			case "/repos/octocat/hello-world/contents/docs":
+				return mocks.MockJSONResponse(200, `[
+					{"type": "file", "size": 8, "name": "README.md", "path": "docs/README.md", "sha": "a1"},
+					{"type": "dir", "size": 0, "name": "guides", "path": "docs/guides", "sha": "b2"},
+					{"type": "file", "size": 2000000, "name": "big.bin", "path": "docs/big.bin", "sha": "c3"},
+					{"type": "submodule", "size": 0, "name": "vendor", "path": "docs/vendor", "sha": "d4"}
+				]`), nil
+			case "/repos/octocat/hello-world/contents/docs/README.md":
+				return mocks.MockJSONResponse(200, fmt.Sprintf(`{"type": "file", "encoding": "base64", "size": 8, "name": "README.md", "path": "docs/README.md", "sha": "a1", "content": %q}`,
+					base64.StdEncoding.EncodeToString([]byte("# Hello\n")))), nil
+			case "/repos/octocat/hello-world/contents/data.csv":
+				if req.Header.Get("Accept") == client.RawMediaType {
+					return mocks.MockResponse(200, large, map[string]string{"Content-Type": "application/octet-stream"}), nil
+				}
+				return mocks.MockJSONResponse(200, fmt.Sprintf(`{"type": "file", "encoding": "none", "size": %d, "name": "data.csv", "path": "data.csv", "sha": "e5", "content": ""}`, len(large))), nil
+			}
+			return mocks.MockJSONResponse(404, `{"message": "Not Found"}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	ctx := context.Background()
+
+	read, err := h.readResource(ctx, "github://repos/octocat/hello-world/contents/docs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(read.Contents) != 2 || read.Contents[0].MimeType != "application/json" {
+		t.Fatalf("Expected a listing and one file, got %+v", read.Contents)
+	}
+	var listing []directoryEntry
+	if err := json.Unmarshal([]byte(read.Contents[0].Text), &listing); err != nil || len(listing) != 4 {
+		t.Fatalf("Expected a listing of 4 entries, got %s (%v)", read.Contents[0].Text, err)
+	}
+	if !listing[0].Included || listing[1].URI != "github://repos/octocat/hello-world/contents/docs/guides" || listing[2].Included || listing[3].URI != "" {
+		t.Errorf("Unexpected listing %+v", listing)
+	}
+	if file := read.Contents[1]; file.URI != "github://repos/octocat/hello-world/contents/docs/README.md" || file.Text != "# Hello\n" {
+		t.Errorf("Expected the README contents after the listing, got %+v", file)
+	}
+
+	read, err = h.readResource(ctx, "github://repos/octocat/hello-world/contents/data.csv")
+	if err != nil || len(read.Contents) != 2 {
+		t.Fatalf("Expected metadata and contents, got %+v (%v)", read, err)
+	}
+	var metadata fileMetadata
+	if err := json.Unmarshal([]byte(read.Contents[0].Text), &metadata); err != nil || metadata.Size != len(large) || metadata.Omitted {
+		t.Errorf("Unexpected metadata %s (%v)", read.Contents[0].Text, err)
+	}
+	if read.Contents[1].Text != large {
+		t.Errorf("Expected the downloaded contents, got %d bytes", len(read.Contents[1].Text))
+	}
+}
+
+func TestReadFileResource_DirectoryFilesReadConcurrently(t *testing.T) {
+	var entries []string
+	for i := 0; i < directoryResourceMaxFiles+5; i++ {
+		entries = append(entries, fmt.Sprintf(`{"type": "file", "size": 4, "name": "f%02d.txt", "path": "docs/f%02d.txt", "sha": "s%d"}`, i, i, i))
+	}
+	var mu sync.Mutex
+	inFlight, maxInFlight, requests := 0, 0, 0
+	// The first requests wait for each other, so they only complete when
+	// they are made in parallel
+	ready := make(chan struct{})
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/repos/octocat/hello-world/contents/docs" {
+				return mocks.MockJSONResponse(200, "["+strings.Join(entries, ",")+"]"), nil
+			}
+			mu.Lock()
+			inFlight++
+			requests++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			if inFlight == directoryResourceConcurrency {
+				select {
+				case <-ready:
+				default:
+					close(ready)
+				}
+			}
+			mu.Unlock()
+			select {
+			case <-ready:
+			case <-time.After(5 * time.Second):
+				t.Error("Expected the directory files to be read in parallel")
+			}
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+
+			name := path.Base(req.URL.Path)
+			return mocks.MockJSONResponse(200, fmt.Sprintf(`{"type": "file", "encoding": "base64", "size": 4, "name": %q, "path": "docs/%s", "sha": "x", "content": %q}`,
+				name, name, base64.StdEncoding.EncodeToString([]byte(name[:3]+"\n")))), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+
+	read, err := h.readResource(context.Background(), "github://repos/octocat/hello-world/contents/docs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests != directoryResourceMaxFiles || maxInFlight > directoryResourceConcurrency {
+		t.Errorf("Expected %d file requests at most %d at a time, got %d at most %d", directoryResourceMaxFiles, directoryResourceConcurrency, requests, maxInFlight)
+	}
+	if len(read.Contents) != directoryResourceMaxFiles+1 {
+		t.Fatalf("Expected a listing and %d files, got %d contents", directoryResourceMaxFiles, len(read.Contents))
+	}
+	for i, file := range read.Contents[1:] {
+		if expected := fmt.Sprintf("f%02d\n", i); file.Text != expected {
+			t.Errorf("Expected file %d to hold %q, got %q", i, expected, file.Text)
+		}
+	}
+}
+
+func TestExecuteGetFileContents_Ref(t *testing.T) {
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			content := "main\n"
+			if ref := req.URL.Query().Get("ref"); ref != "" {
+				content = ref + "\n"
+			}
+			return mocks.MockJSONResponse(200, fmt.Sprintf(`{"type": "file", "encoding": "base64", "size": %d, "name": "VERSION", "path": "VERSION", "sha": "abc123", "content": %q}`,
+				len(content), base64.StdEncoding.EncodeToString([]byte(content)))), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	ctx := context.Background()
+
+	result, err := h.executeGetFileContents(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "VERSION", "ref": "release/v1"})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %+v", err, result)
+	}
+	embedded := result.Content[1].Resource
+	if embedded.URI != "github://repos/octocat/hello-world/contents/VERSION?ref=release%2Fv1" || embedded.Text != "release/v1\n" {
+		t.Errorf("Unexpected embedded resource %+v", embedded)
+	}
+
+	read, err := h.readResource(ctx, embedded.URI)
+	if err != nil || len(read.Contents) != 1 || read.Contents[0].Text != "release/v1\n" {
+		t.Errorf("Expected resources/read to read the file at the ref, got %+v (%v)", read, err)
+	}
+}
+
+func TestExecuteCreateOrUpdateFile(t *testing.T) {
+	var requests []string
+	var body map[string]interface{}
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			switch {
+			case req.Method == http.MethodGet && req.URL.Path == "/repos/octocat/hello-world/contents/README.md":
+				return mocks.MockJSONResponse(200, `{"type": "file", "encoding": "base64", "size": 2, "name": "README.md", "path": "README.md", "sha": "old123", "content": "aGkK"}`), nil
+			case req.Method == http.MethodGet:
+				return mocks.MockJSONResponse(404, `{"message": "Not Found"}`), nil
+			}
+			body = nil
+			json.NewDecoder(req.Body).Decode(&body)
+			return mocks.MockJSONResponse(200, `{"content": {"type": "file", "name": "README.md", "path": "README.md", "sha": "new456",
+				"url": "https://api.github.com/repos/octocat/hello-world/contents/README.md"}, "commit": {"sha": "c0ffee", "message": "Update README"}}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	ctx := context.Background()
+
+	result, _ := h.executeCreateOrUpdateFile(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "README.md",
+		"content": "# Hello\n", "message": "Update README", "branch": "docs"})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "already exists") || body != nil {
+		t.Fatalf("Expected an existing file not to be replaced without a SHA or overwrite, got %+v", result)
+	}
+
+	result, err := h.executeCreateOrUpdateFile(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "README.md",
+		"content": "# Hello\n", "message": "Update README", "branch": "docs", "overwrite": true})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %+v", err, result)
+	}
+	if body["sha"] != "old123" || body["branch"] != "docs" || body["content"] != base64.StdEncoding.EncodeToString([]byte("# Hello\n")) {
+		t.Errorf("Expected the file replaced at its current SHA, got %v", body)
+	}
+	if text := result.Content[0].Text; !strings.Contains(text, `"sha":"c0ffee"`) || strings.Contains(text, "api.github.com") {
+		t.Errorf("Expected the trimmed commit, got %s", text)
+	}
+
+	result, _ = h.executeCreateOrUpdateFile(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "logo.png",
+		"content": base64.StdEncoding.EncodeToString([]byte{0x89, 'P'}), "encoding": "base64", "message": "Add logo"})
+	if result.IsError || body["sha"] != nil || body["content"] != base64.StdEncoding.EncodeToString([]byte{0x89, 'P'}) {
+		t.Errorf("Expected a new binary file created without a SHA, got %v (%+v)", body, result)
+	}
+
+	requests = nil
+	result, _ = h.executeCreateOrUpdateFile(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "README.md",
+		"content": "# Hi\n", "message": "Update README", "sha": "given789"})
+	if result.IsError || body["sha"] != "given789" || len(requests) != 1 {
+		t.Errorf("Expected the given SHA sent without a lookup, got %v after %v", body, requests)
+	}
+
+	result, _ = h.executeCreateOrUpdateFile(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "README.md", "content": "x"})
+	if !result.IsError {
+		t.Error("Expected a change without a commit message to fail")
+	}
+
+	for _, path := range []string{"a/../../../../other/repo/contents/x", "./README.md"} {
+		requests = nil
+		result, _ = h.executeCreateOrUpdateFile(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": path,
+			"content": "x", "message": "Escape", "sha": "given789"})
+		if !result.IsError || len(requests) != 0 {
+			t.Errorf("Expected path %s rejected before reaching GitHub, got %+v after %v", path, result, requests)
+		}
+	}
+
+	requests = nil
+	result, _ = h.executeCreateOrUpdateFile(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "notes/what?#1.md",
+		"content": "x", "message": "Add notes", "sha": "given789"})
+	if result.IsError || len(requests) != 1 || requests[0] != "PUT /repos/octocat/hello-world/contents/notes/what?#1.md" {
+		t.Errorf("Expected ? and # escaped in the file name, got %v (%+v)", requests, result)
+	}
+}
+
+func TestExecuteDeleteFile(t *testing.T) {
+	var requests []string
+	var body map[string]interface{}
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			if req.Method == http.MethodGet {
+				return mocks.MockJSONResponse(200, `{"type": "file", "encoding": "base64", "size": 2, "name": "old.txt", "path": "old.txt", "sha": "old123", "content": "aGkK"}`), nil
+			}
+			json.NewDecoder(req.Body).Decode(&body)
+			return mocks.MockJSONResponse(200, `{"content": null, "commit": {"sha": "c0ffee", "message": "Remove old.txt"}}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetSafeDelete(true, "")
+
+	result, err := h.executeDeleteFile(context.Background(), map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "old.txt", "message": "Remove old.txt"})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %+v", err, result)
+	}
+	if body["sha"] != "old123" || body["message"] != "Remove old.txt" || requests[len(requests)-1] != "DELETE /repos/octocat/hello-world/contents/old.txt" {
+		t.Errorf("Expected the file deleted at its current SHA, got %v after %v", body, requests)
+	}
+	if records := h.deletions.recent("", 10); len(records) != 1 || !strings.Contains(string(records[0].State), "old123") {
+		t.Errorf("Expected the file captured before its deletion, got %+v", records)
+	}
+}
+
+func TestExecuteListDirectory(t *testing.T) {
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Path {
+			case "/repos/octocat/hello-world/contents":
+				if req.URL.Query().Get("ref") != "v1.0" {
+					t.Errorf("Expected the ref in the query, got %q", req.URL.RawQuery)
+				}
+				return mocks.MockJSONResponse(200, `[
+					{"type": "file", "size": 8, "name": "README.md", "path": "README.md", "sha": "a1", "url": "https://api.github.com/repos/octocat/hello-world/contents/README.md"},
+					{"type": "dir", "size": 0, "name": "docs", "path": "docs", "sha": "b2"},
+					{"type": "submodule", "size": 0, "name": "vendor", "path": "vendor", "sha": "d4"}
+				]`), nil
+			case "/repos/octocat/hello-world/contents/README.md":
+				return mocks.MockJSONResponse(200, `{"type": "file", "encoding": "base64", "size": 8, "name": "README.md", "path": "README.md", "sha": "a1", "content": ""}`), nil
+			}
+			return mocks.MockJSONResponse(404, `{"message": "Not Found"}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	ctx := context.Background()
+
+	result, _ := h.executeListDirectory(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "ref": "v1.0"})
+	if result.IsError {
+		t.Fatalf("Unexpected error result: %s", result.Content[0].Text)
+	}
+	var envelope struct {
+		Items      []directoryEntry `json:"items"`
+		Pagination paginationInfo   `json:"pagination"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &envelope); err != nil || len(envelope.Items) != 3 {
+		t.Fatalf("Expected a listing of 3 entries, got %s (%v)", result.Content[0].Text, err)
+	}
+	listing := envelope.Items
+	if envelope.Pagination.HasNext || envelope.Pagination.NextCursor != "" {
+		t.Errorf("Expected no next page, got %+v", envelope.Pagination)
+	}
+	if listing[0].Name != "README.md" || listing[0].Size != 8 || listing[0].SHA != "a1" || listing[0].URI != "github://repos/octocat/hello-world/contents/README.md?ref=v1.0" {
+		t.Errorf("Unexpected file entry %+v", listing[0])
+	}
+	if listing[1].Type != "dir" || listing[1].URI == "" || listing[2].URI != "" {
+		t.Errorf("Unexpected listing %+v", listing)
+	}
+
+	h.SetMaxResultSize(200)
+	result, _ = h.executeListDirectory(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "ref": "v1.0"})
+	if result.IsError || !strings.Contains(result.Content[0].Text, `"truncated"`) || strings.Contains(result.Content[0].Text, `"next_cursor"`) {
+		t.Errorf("Expected the listing truncated without a cursor, got %+v", result)
+	}
+	if schema := schemaProperties(h.findTool("list_directory").InputSchema); schema[cursorArgument] != nil {
+		t.Error("Expected list_directory to take no cursor")
+	}
+
+	result, _ = h.executeListDirectory(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "README.md"})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "get_file_contents") {
+		t.Errorf("Expected an error result for a file, got %+v", result)
+	}
+
+	result, _ = h.executeListDirectory(ctx, map[string]interface{}{"owner": "octocat", "repo": "hello-world", "path": "missing"})
+	if !result.IsError {
+		t.Error("Expected an error result for a missing directory")
+	}
+}
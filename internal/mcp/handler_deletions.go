@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"context"
+)
+
+const (
+	// defaultDeletionsLimit is the number of deletions listed when no limit is given
+	defaultDeletionsLimit = 20
+	// maxDeletionsLimit bounds the number of deletions listed at once
+	maxDeletionsLimit = 100
+)
+
+// deletionTools returns the tools for investigating deletions made in safe delete mode
+func (h *Handler) deletionTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "list_recent_deletions",
+			Description: "List objects removed by destructive tools (delete_team, remove_team_membership, remove_team_repository, remove_installation_repository) while safe delete mode is enabled, newest first, with the state captured before each deletion to aid recovery.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"tool": map[string]interface{}{
+						"type":        "string",
+						"description": "Only list deletions made by this tool",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of deletions to list",
+						"default":     defaultDeletionsLimit,
+						"minimum":     1,
+						"maximum":     maxDeletionsLimit,
+					},
+				},
+			},
+			Shaped: true,
+		}, h.executeListRecentDeletions),
+	}
+}
+
+// executeListRecentDeletions executes the list_recent_deletions tool
+func (h *Handler) executeListRecentDeletions(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	if h.deletions == nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Safe delete mode is disabled; no deletions are recorded"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	tool, _ := args["tool"].(string)
+
+	limit := defaultDeletionsLimit
+	if l, ok := args["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+		if limit > maxDeletionsLimit {
+			limit = maxDeletionsLimit
+		}
+	}
+
+	records := h.deletions.recent(tool, limit)
+	recordsJSON, err := shapeJSON(args, h.newListEnvelope(args, records, nil, listPage{perPage: limit}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting deletion data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: string(recordsJSON),
+		}},
+		IsError: false,
+	}, nil
+}
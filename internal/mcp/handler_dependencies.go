@@ -0,0 +1,137 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// dependencyTools returns the cross-repository dependency tools
+func (h *Handler) dependencyTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "get_dependency_map",
+			Description: "Scan go.mod and package.json manifests across an organization's repositories and map which repositories depend on modules or packages published by other repositories in the organization. Pass repository to see who depends on it and what it depends on.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"repos": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": fmt.Sprintf("Repositories to scan (default: up to %d non-archived, non-fork repositories in the organization)", maxDependencyRepos),
+						"maxItems":    maxDependencyRepos,
+					},
+					"repository": map[string]interface{}{
+						"type":        "string",
+						"description": "Return only the dependents and dependencies of this repository",
+					},
+					"refresh": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Rescan manifests instead of using a cached map",
+						"default":     false,
+					},
+				},
+				"required": []string{"org"},
+			},
+			Shaped: true,
+		}, h.executeGetDependencyMap),
+	}
+}
+
+// executeGetDependencyMap executes the get_dependency_map tool
+func (h *Handler) executeGetDependencyMap(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok || org == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: org parameter is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	repos := stringSliceArg(args, "repos")
+	if len(repos) > maxDependencyRepos {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: at most %d repositories can be scanned at once", maxDependencyRepos),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	refresh, _ := args["refresh"].(bool)
+
+	depMap, err := h.orgDependencyMap(ctx, org, repos, refresh)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error building dependency map for organization %s: %v", org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	repository, _ := args["repository"].(string)
+	if repository == "" {
+		mapJSON, err := shapeJSON(args, depMap)
+		if err != nil {
+			return &CallToolResult{
+				Content: []Content{{
+					Type: "text",
+					Text: h.messages.Sprintf("Error formatting dependency data: %v", err),
+				}},
+				IsError: true,
+			}, nil
+		}
+
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: string(mapJSON),
+			}},
+			IsError: false,
+		}, nil
+	}
+
+	result, err := depMap.query(repository)
+	if err != nil {
+		if errors.IsType(err, errors.ErrorTypeNotFound) {
+			return &CallToolResult{
+				Content: []Content{{
+					Type: "text",
+					Text: h.messages.Sprintf("Error: repository %s was not scanned in organization %s", repository, org),
+				}},
+				IsError: true,
+			}, nil
+		}
+		return nil, err
+	}
+
+	resultJSON, err := shapeJSON(args, result)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting dependency data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: string(resultJSON),
+		}},
+		IsError: false,
+	}, nil
+}
@@ -0,0 +1,376 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	// maxBulkIssues bounds the number of issues a single bulk operation may touch
+	maxBulkIssues = 100
+	// defaultBulkConcurrency is the number of issues updated in parallel by default
+	defaultBulkConcurrency = 5
+	// maxBulkConcurrency bounds the number of issues updated in parallel
+	maxBulkConcurrency = 10
+)
+
+// issueTools returns the GitHub Issues tools
+func (h *Handler) issueTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "bulk_update_issues",
+			Description: "Apply the same label, assignee, milestone or state change to many issues in a repository, selected by number or by search query. Reports success or failure per issue; use dry_run to preview.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository owner",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository name",
+					},
+					"issue_numbers": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "integer"},
+						"description": fmt.Sprintf("Issue or pull request numbers to update (max %d)", maxBulkIssues),
+						"maxItems":    maxBulkIssues,
+					},
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub search query selecting the issues to update, scoped to the repository (e.g. \"is:open label:stale\")",
+					},
+					"add_labels":       stringArrayProperty("Labels to add"),
+					"remove_labels":    stringArrayProperty("Labels to remove"),
+					"add_assignees":    stringArrayProperty("Usernames to assign"),
+					"remove_assignees": stringArrayProperty("Usernames to unassign"),
+					"milestone": map[string]interface{}{
+						"type":        "integer",
+						"description": "Milestone number to set, or 0 to clear the milestone",
+						"minimum":     0,
+					},
+					"state": map[string]interface{}{
+						"type":        "string",
+						"description": "New state",
+						"enum":        []string{"open", "closed"},
+					},
+					"state_reason": map[string]interface{}{
+						"type":        "string",
+						"description": "Reason for the state change",
+						"enum":        []string{"completed", "not_planned", "reopened"},
+					},
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Report the issues and changes that would be applied without modifying anything",
+						"default":     false,
+					},
+					"concurrency": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of issues updated in parallel",
+						"minimum":     1,
+						"maximum":     maxBulkConcurrency,
+						"default":     defaultBulkConcurrency,
+					},
+				},
+				"required": []string{"owner", "repo"},
+			},
+			Shaped: true,
+		}, h.executeBulkUpdateIssues),
+	}
+}
+
+// bulkIssueChange is the change applied to every issue in a bulk operation
+type bulkIssueChange struct {
+	AddLabels       []string `json:"add_labels,omitempty"`
+	RemoveLabels    []string `json:"remove_labels,omitempty"`
+	AddAssignees    []string `json:"add_assignees,omitempty"`
+	RemoveAssignees []string `json:"remove_assignees,omitempty"`
+	Milestone       *int     `json:"milestone,omitempty"`
+	State           string   `json:"state,omitempty"`
+	StateReason     string   `json:"state_reason,omitempty"`
+}
+
+// isEmpty returns true if the change does not modify anything
+func (c *bulkIssueChange) isEmpty() bool {
+	return len(c.AddLabels) == 0 && len(c.RemoveLabels) == 0 &&
+		len(c.AddAssignees) == 0 && len(c.RemoveAssignees) == 0 &&
+		c.Milestone == nil && c.State == "" && c.StateReason == ""
+}
+
+// issueUpdates returns the fields changed through the issue update endpoint
+func (c *bulkIssueChange) issueUpdates() map[string]interface{} {
+	updates := make(map[string]interface{})
+	if c.Milestone != nil {
+		if *c.Milestone == 0 {
+			updates["milestone"] = nil
+		} else {
+			updates["milestone"] = *c.Milestone
+		}
+	}
+	if c.State != "" {
+		updates["state"] = c.State
+	}
+	if c.StateReason != "" {
+		updates["state_reason"] = c.StateReason
+	}
+	return updates
+}
+
+// bulkIssueResult reports the outcome of a bulk operation for a single issue
+type bulkIssueResult struct {
+	Number int    `json:"number"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// bulkIssueReport summarizes a bulk operation
+type bulkIssueReport struct {
+	Repository string            `json:"repository"`
+	DryRun     bool              `json:"dry_run"`
+	Change     bulkIssueChange   `json:"change"`
+	Total      int               `json:"total"`
+	Succeeded  int               `json:"succeeded"`
+	Failed     int               `json:"failed"`
+	Results    []bulkIssueResult `json:"results"`
+}
+
+// executeBulkUpdateIssues executes the bulk_update_issues tool
+func (h *Handler) executeBulkUpdateIssues(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, ok := args["owner"].(string)
+	if !ok || owner == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("owner is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	repo, ok := args["repo"].(string)
+	if !ok || repo == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("repo is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	change := bulkIssueChange{
+		AddLabels:       stringSliceArg(args, "add_labels"),
+		RemoveLabels:    stringSliceArg(args, "remove_labels"),
+		AddAssignees:    stringSliceArg(args, "add_assignees"),
+		RemoveAssignees: stringSliceArg(args, "remove_assignees"),
+	}
+	if m, ok := args["milestone"].(float64); ok {
+		milestone := int(m)
+		change.Milestone = &milestone
+	}
+	if state, ok := args["state"].(string); ok {
+		change.State = state
+	}
+	if reason, ok := args["state_reason"].(string); ok {
+		change.StateReason = reason
+	}
+	if change.isEmpty() {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("No valid fields provided for update"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	dryRun, _ := args["dry_run"].(bool)
+
+	concurrency := defaultBulkConcurrency
+	if c, ok := args["concurrency"].(float64); ok && c >= 1 {
+		concurrency = int(c)
+	}
+	if concurrency > maxBulkConcurrency {
+		concurrency = maxBulkConcurrency
+	}
+
+	numbers, errResult := h.resolveBulkIssueNumbers(ctx, owner, repo, args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	report := bulkIssueReport{
+		Repository: owner + "/" + repo,
+		DryRun:     dryRun,
+		Change:     change,
+		Total:      len(numbers),
+		Results:    make([]bulkIssueResult, len(numbers)),
+	}
+
+	if dryRun {
+		for i, number := range numbers {
+			report.Results[i] = bulkIssueResult{Number: number, Status: "planned"}
+		}
+	} else {
+		// Update issues with bounded concurrency, keeping results in input order
+		semaphore := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for i, number := range numbers {
+			wg.Add(1)
+			go func(i, number int) {
+				defer wg.Done()
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+
+				result := bulkIssueResult{Number: number, Status: "updated"}
+				if err := h.applyBulkIssueChange(ctx, owner, repo, number, &change); err != nil {
+					result.Status = "failed"
+					result.Error = err.Error()
+				}
+				report.Results[i] = result
+			}(i, number)
+		}
+		wg.Wait()
+
+		for _, result := range report.Results {
+			if result.Status == "failed" {
+				report.Failed++
+			} else {
+				report.Succeeded++
+			}
+		}
+	}
+
+	// Format response as JSON
+	reportJSON, err := shapeJSON(args, report)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting bulk update data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: string(reportJSON),
+		}},
+		IsError: report.Failed > 0 && report.Succeeded == 0,
+	}, nil
+}
+
+// resolveBulkIssueNumbers returns the issue numbers selected by issue_numbers or query
+func (h *Handler) resolveBulkIssueNumbers(ctx context.Context, owner, repo string, args map[string]interface{}) ([]int, *CallToolResult) {
+	seen := make(map[int]bool)
+	var numbers []int
+	add := func(number int) {
+		if number > 0 && !seen[number] {
+			seen[number] = true
+			numbers = append(numbers, number)
+		}
+	}
+
+	if items, ok := args["issue_numbers"].([]interface{}); ok {
+		for _, item := range items {
+			if n, ok := item.(float64); ok {
+				add(int(n))
+			}
+		}
+	}
+
+	if query, ok := args["query"].(string); ok && query != "" {
+		scoped := fmt.Sprintf("repo:%s/%s %s", owner, repo, query)
+		for page := 1; len(numbers) <= maxBulkIssues; page++ {
+			result, pageInfo, err := h.githubClient.SearchIssues(ctx, scoped, "", "", page, 100)
+			if err != nil {
+				return nil, &CallToolResult{
+					Content: []Content{{
+						Type: "text",
+						Text: h.messages.Sprintf("Error searching issues in %s/%s: %v", owner, repo, err),
+					}},
+					IsError: true,
+				}
+			}
+			for _, issue := range result.Items {
+				add(issue.Number)
+			}
+			if !pageInfo.HasMore() {
+				break
+			}
+		}
+	}
+
+	if len(numbers) == 0 {
+		return nil, &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: no issues selected; provide issue_numbers or a query matching at least one issue"),
+			}},
+			IsError: true,
+		}
+	}
+	if len(numbers) > maxBulkIssues {
+		return nil, &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: %d issues selected, at most %d can be updated at once", len(numbers), maxBulkIssues),
+			}},
+			IsError: true,
+		}
+	}
+
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+// applyBulkIssueChange applies the change to a single issue
+func (h *Handler) applyBulkIssueChange(ctx context.Context, owner, repo string, number int, change *bulkIssueChange) error {
+	if updates := change.issueUpdates(); len(updates) > 0 {
+		if _, err := h.githubClient.UpdateIssue(ctx, owner, repo, number, updates); err != nil {
+			return err
+		}
+	}
+	if len(change.AddLabels) > 0 {
+		if _, err := h.githubClient.AddIssueLabels(ctx, owner, repo, number, change.AddLabels); err != nil {
+			return err
+		}
+	}
+	for _, label := range change.RemoveLabels {
+		if err := h.githubClient.RemoveIssueLabel(ctx, owner, repo, number, label); err != nil {
+			return err
+		}
+	}
+	if len(change.AddAssignees) > 0 {
+		if _, err := h.githubClient.AddIssueAssignees(ctx, owner, repo, number, change.AddAssignees); err != nil {
+			return err
+		}
+	}
+	if len(change.RemoveAssignees) > 0 {
+		if _, err := h.githubClient.RemoveIssueAssignees(ctx, owner, repo, number, change.RemoveAssignees); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stringSliceArg reads an array of strings argument, skipping empty and non-string items
+func stringSliceArg(args map[string]interface{}, key string) []string {
+	items, ok := args[key].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok && s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
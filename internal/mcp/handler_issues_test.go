@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestExecuteBulkUpdateIssues(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			mu.Unlock()
+
+			if strings.Contains(req.URL.Path, "/issues/2/") {
+				return mocks.MockErrorResponse(404, "Not Found"), nil
+			}
+			return mocks.MockJSONResponse(200, `[{"name": "triage"}]`), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(mockClient)
+	h := NewHandler(githubClient, createTestLogger())
+
+	args := map[string]interface{}{
+		"owner":         "octo-org",
+		"repo":          "octo-repo",
+		"issue_numbers": []interface{}{float64(3), float64(1), float64(2), float64(1)},
+		"add_labels":    []interface{}{"triage"},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		dryRunArgs := map[string]interface{}{"dry_run": true}
+		for k, v := range args {
+			dryRunArgs[k] = v
+		}
+
+		result, err := h.executeBulkUpdateIssues(context.Background(), dryRunArgs)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("Unexpected error result: %s", result.Content[0].Text)
+		}
+		if len(requests) != 0 {
+			t.Errorf("Expected no API requests during dry run, got %v", requests)
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		result, err := h.executeBulkUpdateIssues(context.Background(), args)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		text := result.Content[0].Text
+		var report bulkIssueReport
+		if err := json.Unmarshal([]byte(text), &report); err != nil {
+			t.Fatalf("Failed to parse report: %v", err)
+		}
+
+		if report.Total != 3 || report.Succeeded != 2 || report.Failed != 1 {
+			t.Errorf("Expected 3 total, 2 succeeded, 1 failed; got %d, %d, %d", report.Total, report.Succeeded, report.Failed)
+		}
+		for i, expected := range []int{1, 2, 3} {
+			if report.Results[i].Number != expected {
+				t.Errorf("Expected result %d for issue %d, got %d", i, expected, report.Results[i].Number)
+			}
+		}
+		if report.Results[1].Status != "failed" || report.Results[1].Error == "" {
+			t.Errorf("Expected issue 2 to fail with an error, got %+v", report.Results[1])
+		}
+		if result.IsError {
+			t.Error("Expected partial failure not to mark the whole result as an error")
+		}
+	})
+}
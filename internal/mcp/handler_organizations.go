@@ -0,0 +1,718 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+// organizationTools returns the GitHub Organizations tools
+func (h *Handler) organizationTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "get_organization",
+			Description: "Get information about a GitHub organization",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+				},
+				"required": []string{"org"},
+			},
+			Shaped: true,
+		}, h.executeGetOrganization),
+		NewTool(Tool{
+			Name:        "update_organization",
+			Description: "Update an organization's profile",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "The organization's display name",
+					},
+					"description": map[string]interface{}{
+						"type":        "string",
+						"description": "The organization's description",
+					},
+					"company": map[string]interface{}{
+						"type":        "string",
+						"description": "The organization's company name",
+					},
+					"blog": map[string]interface{}{
+						"type":        "string",
+						"description": "The organization's blog URL",
+					},
+					"location": map[string]interface{}{
+						"type":        "string",
+						"description": "The organization's location",
+					},
+					"email": map[string]interface{}{
+						"type":        "string",
+						"description": "The organization's email",
+					},
+					"twitter_username": map[string]interface{}{
+						"type":        "string",
+						"description": "The organization's Twitter username",
+					},
+					"billing_email": map[string]interface{}{
+						"type":        "string",
+						"description": "The organization's billing email",
+					},
+					"has_organization_projects": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Whether organization projects are enabled",
+					},
+					"has_repository_projects": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Whether repository projects are enabled",
+					},
+					"default_repository_permission": map[string]interface{}{
+						"type":        "string",
+						"description": "Default permission level members have for organization repositories",
+						"enum":        []string{"read", "write", "admin", "none"},
+					},
+					"members_can_create_repositories": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Whether members can create repositories",
+					},
+				},
+				"required": []string{"org"},
+			},
+			Shaped: true,
+		}, h.executeUpdateOrganization),
+		NewTool(Tool{
+			Name:        "list_organizations",
+			Description: "List all GitHub organizations",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"since": map[string]interface{}{
+						"type":        "integer",
+						"description": "An organization ID. Only return organizations with an ID greater than this ID",
+						"minimum":     0,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+			},
+			Shaped: true,
+		}, h.executeListOrganizations),
+		NewTool(Tool{
+			Name:        "list_user_organizations",
+			Description: "List organizations for a user",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username",
+					},
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page number of the results to fetch",
+						"minimum":     1,
+						"default":     1,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+				"required": []string{"username"},
+			},
+			Shaped: true,
+		}, h.executeListUserOrganizations),
+		NewTool(Tool{
+			Name:        "list_authenticated_user_organizations",
+			Description: "List organizations for the authenticated user",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page number of the results to fetch",
+						"minimum":     1,
+						"default":     1,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+			},
+			Shaped: true,
+		}, h.executeListAuthenticatedUserOrganizations),
+		NewTool(Tool{
+			Name:        "list_organization_members",
+			Description: "List members of an organization",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"filter": map[string]interface{}{
+						"type":        "string",
+						"description": "Filter members returned in the list",
+						"enum":        []string{"2fa_disabled", "all"},
+						"default":     "all",
+					},
+					"role": map[string]interface{}{
+						"type":        "string",
+						"description": "Filter members returned by their role",
+						"enum":        []string{"all", "admin", "member"},
+						"default":     "all",
+					},
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page number of the results to fetch",
+						"minimum":     1,
+						"default":     1,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+				"required": []string{"org"},
+			},
+			Shaped: true,
+		}, h.executeListOrganizationMembers),
+		NewTool(Tool{
+			Name:        "check_organization_membership",
+			Description: "Check if a user is a member of an organization",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username to check",
+					},
+				},
+				"required": []string{"org", "username"},
+			},
+		}, h.executeCheckOrganizationMembership),
+		NewTool(Tool{
+			Name:        "check_public_organization_membership",
+			Description: "Check if a user is a public member of an organization",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username to check",
+					},
+				},
+				"required": []string{"org", "username"},
+			},
+		}, h.executeCheckPublicOrganizationMembership),
+	}
+}
+
+// executeGetOrganization executes the get_organization tool
+func (h *Handler) executeGetOrganization(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the client function
+	organization, err := h.githubClient.GetOrganization(ctx, org)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting organization %s: %v", org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	orgJSON, err := shapeJSON(args, organization)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting organization data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(orgJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeUpdateOrganization executes the update_organization tool
+func (h *Handler) executeUpdateOrganization(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Build updates map from args
+	updates := make(map[string]interface{})
+
+	// Copy valid fields from args to updates
+	validFields := []string{
+		"name", "description", "company", "blog", "location", "email", "twitter_username",
+		"billing_email", "has_organization_projects", "has_repository_projects",
+		"default_repository_permission", "members_can_create_repositories",
+	}
+	for _, field := range validFields {
+		if value, exists := args[field]; exists {
+			updates[field] = value
+		}
+	}
+
+	if len(updates) == 0 {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("No valid fields provided for update"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the client function
+	organization, err := h.githubClient.UpdateOrganization(ctx, org, updates)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error updating organization %s: %v", org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	orgJSON, err := shapeJSON(args, organization)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting organization data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(orgJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeListOrganizations executes the list_organizations tool
+func (h *Handler) executeListOrganizations(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	var since int64
+	var perPage int
+
+	if s, ok := args["since"].(float64); ok {
+		since = int64(s)
+	}
+	if p, ok := args["per_page"].(float64); ok {
+		perPage = int(p)
+	}
+
+	// Make GitHub API request using the client function
+	organizations, pageInfo, err := h.githubClient.ListOrganizations(ctx, since, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing organizations: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	orgsJSON, err := shapeJSON(args, h.newListEnvelope(args, organizations, pageInfo, listPage{perPage: perPage, since: true}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting organizations data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(orgsJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeListUserOrganizations executes the list_user_organizations tool
+func (h *Handler) executeListUserOrganizations(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	// Make GitHub API request using the client function
+	organizations, pageInfo, err := h.githubClient.ListUserOrganizations(ctx, username, page, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing organizations for %s: %v", username, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	orgsJSON, err := shapeJSON(args, h.newListEnvelope(args, organizations, pageInfo, listPage{page: page, perPage: perPage}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting organizations data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(orgsJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeListAuthenticatedUserOrganizations executes the list_authenticated_user_organizations tool
+func (h *Handler) executeListAuthenticatedUserOrganizations(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	// Make GitHub API request using the client function
+	organizations, pageInfo, err := h.githubClient.ListAuthenticatedUserOrganizations(ctx, page, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing authenticated user organizations: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	orgsJSON, err := shapeJSON(args, h.newListEnvelope(args, organizations, pageInfo, listPage{page: page, perPage: perPage}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting organizations data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(orgsJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeListOrganizationMembers executes the list_organization_members tool
+func (h *Handler) executeListOrganizationMembers(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var filter, role string
+	var page, perPage int
+
+	if f, ok := args["filter"].(string); ok {
+		filter = f
+	}
+	if r, ok := args["role"].(string); ok {
+		role = r
+	}
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+	fetchAll, perPage := fetchAllArguments(args, perPage)
+
+	// Make GitHub API request using the client function
+	members, pageInfo, err := fetchPages(ctx, h, fetchAll, page, func(ctx context.Context, page int) ([]client.OrganizationMember, *client.PageInfo, error) {
+		return h.githubClient.ListOrganizationMembers(ctx, org, filter, role, page, perPage)
+	})
+	var denied error
+	if isPermissionDenied(err) {
+		// Public members are visible to any token
+		denied = err
+		members, pageInfo, err = fetchPages(ctx, h, fetchAll, page, func(ctx context.Context, page int) ([]client.OrganizationMember, *client.PageInfo, error) {
+			return h.githubClient.ListOrganizationPublicMembers(ctx, org, page, perPage)
+		})
+	}
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing members for organization %s: %v", org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	membersJSON, err := shapeJSON(args, h.newListEnvelope(args, members, pageInfo, listPage{page: page, perPage: perPage}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting members data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(membersJSON),
+		},
+	}
+
+	result := &CallToolResult{
+		Content: content,
+		IsError: false,
+	}
+	if denied != nil {
+		return h.markDegraded(result, "public_members", h.messages.Sprintf("public members only, without the filter and role"), denied), nil
+	}
+	return result, nil
+}
+
+// executeCheckOrganizationMembership executes the check_organization_membership tool
+func (h *Handler) executeCheckOrganizationMembership(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the client function
+	isMember, err := h.githubClient.CheckOrganizationMembership(ctx, org, username)
+	var denied error
+	if isPermissionDenied(err) {
+		denied = err
+		isMember, err = h.githubClient.CheckPublicOrganizationMembership(ctx, org, username)
+	}
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error checking membership for %s in organization %s: %v", username, org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	status := h.messages.Sprintf("not a member")
+	if isMember {
+		status = h.messages.Sprintf("is a member")
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: h.messages.Sprintf("Membership status for %s in organization %s: %s", username, org, status),
+		},
+	}
+
+	result := &CallToolResult{
+		Content: content,
+		IsError: false,
+	}
+	if denied != nil {
+		return h.markDegraded(result, "public_membership", h.messages.Sprintf("public membership only; private members are reported as not a member"), denied), nil
+	}
+	return result, nil
+}
+
+// executeCheckPublicOrganizationMembership executes the check_public_organization_membership tool
+func (h *Handler) executeCheckPublicOrganizationMembership(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the client function
+	isPublicMember, err := h.githubClient.CheckPublicOrganizationMembership(ctx, org, username)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error checking public membership for %s in organization %s: %v", username, org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	status := h.messages.Sprintf("not a public member")
+	if isPublicMember {
+		status = h.messages.Sprintf("is a public member")
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: h.messages.Sprintf("Public membership status for %s in organization %s: %s", username, org, status),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
@@ -0,0 +1,416 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+)
+
+// repositorySettings are the settings create_repository and
+// update_repository both accept
+var repositorySettings = []string{"description", "homepage", "private", "visibility", "has_issues", "has_projects",
+	"has_wiki", "has_discussions", "is_template"}
+
+// repositoryUpdateSettings are the settings only update_repository accepts
+var repositoryUpdateSettings = []string{"name", "default_branch", "allow_squash_merge", "allow_merge_commit",
+	"allow_rebase_merge", "allow_auto_merge", "delete_branch_on_merge", "allow_forking", "archived"}
+
+// repositoryTools returns the GitHub Repositories tools
+func (h *Handler) repositoryTools() []ToolProvider {
+	settings := func(properties map[string]interface{}) map[string]interface{} {
+		properties["description"] = stringProperty("A short description of the repository")
+		properties["homepage"] = stringProperty("URL with more information about the repository")
+		properties["private"] = booleanProperty("Whether the repository is private")
+		properties["visibility"] = map[string]interface{}{
+			"type":        "string",
+			"description": "Visibility of the repository; internal is only available to organizations of enterprises",
+			"enum":        []string{"public", "private", "internal"},
+		}
+		properties["has_issues"] = booleanProperty("Whether issues are enabled")
+		properties["has_projects"] = booleanProperty("Whether projects are enabled")
+		properties["has_wiki"] = booleanProperty("Whether the wiki is enabled")
+		properties["has_discussions"] = booleanProperty("Whether discussions are enabled")
+		properties["is_template"] = booleanProperty("Whether the repository can be used as a template")
+		return properties
+	}
+
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "get_repository",
+			Description: "Get a repository by owner and name, with its settings",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner": stringProperty("Repository owner"),
+					"repo":  stringProperty("Repository name"),
+				},
+				"required": []string{"owner", "repo"},
+			},
+			Shaped: true,
+		}, h.executeGetRepository),
+		NewTool(Tool{
+			Name:        "create_repository",
+			Description: "Create a repository in an organization, or for the authenticated user when org is not given",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": settings(map[string]interface{}{
+					"name":               stringProperty("The name of the repository"),
+					"org":                stringProperty("Organization to create the repository in; the authenticated user when not given"),
+					"auto_init":          booleanProperty("Create an initial commit with an empty README"),
+					"gitignore_template": stringProperty("Name of the .gitignore template to apply, such as Go"),
+					"license_template":   stringProperty("Keyword of the license to apply, such as mit"),
+				}),
+				"required": []string{"name"},
+			},
+			Shaped: true,
+		}, h.executeCreateRepository),
+		NewTool(Tool{
+			Name:        "update_repository",
+			Description: "Update a repository's name, description, visibility and feature and merge settings",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": settings(map[string]interface{}{
+					"owner":                  stringProperty("Repository owner"),
+					"repo":                   stringProperty("Repository name"),
+					"name":                   stringProperty("New name of the repository"),
+					"default_branch":         stringProperty("Name of the default branch"),
+					"allow_squash_merge":     booleanProperty("Whether pull requests can be squash-merged"),
+					"allow_merge_commit":     booleanProperty("Whether pull requests can be merged with a merge commit"),
+					"allow_rebase_merge":     booleanProperty("Whether pull requests can be rebase-merged"),
+					"allow_auto_merge":       booleanProperty("Whether pull requests can be set to merge automatically"),
+					"delete_branch_on_merge": booleanProperty("Whether head branches are deleted when pull requests are merged"),
+					"allow_forking":          booleanProperty("Whether private repositories can be forked"),
+					"archived":               booleanProperty("Whether the repository is archived, making it read-only"),
+				}),
+				"required": []string{"owner", "repo"},
+			},
+			Shaped: true,
+		}, h.executeUpdateRepository),
+		NewTool(Tool{
+			Name:        "delete_repository",
+			Description: "Delete a repository. This cannot be undone.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner": stringProperty("Repository owner"),
+					"repo":  stringProperty("Repository name"),
+				},
+				"required": []string{"owner", "repo"},
+			},
+		}, h.executeDeleteRepository),
+		NewTool(Tool{
+			Name:        "fork_repository",
+			Description: "Fork a repository into an organization, or for the authenticated user when organization is not given. The fork is created in the background, so its contents can take a moment to appear.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner":               stringProperty("Owner of the repository to fork"),
+					"repo":                stringProperty("Name of the repository to fork"),
+					"organization":        stringProperty("Organization to fork the repository into"),
+					"name":                stringProperty("Name of the fork; the repository's name when not given"),
+					"default_branch_only": booleanProperty("Fork only the default branch"),
+				},
+				"required": []string{"owner", "repo"},
+			},
+			Shaped: true,
+		}, h.executeForkRepository),
+		NewTool(Tool{
+			Name:        "transfer_repository",
+			Description: "Transfer a repository to another user or organization. A transfer to a user completes once they accept it.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner":     stringProperty("Repository owner"),
+					"repo":      stringProperty("Repository name"),
+					"new_owner": stringProperty("User or organization to transfer the repository to"),
+					"new_name":  stringProperty("New name of the repository"),
+					"team_ids": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "integer"},
+						"description": "IDs of the teams of the new organization owner to give access to the repository",
+					},
+				},
+				"required": []string{"owner", "repo", "new_owner"},
+			},
+			Shaped: true,
+		}, h.executeTransferRepository),
+	}
+}
+
+// repositoryArgs reads the owner and repo arguments, returning an error
+// result when either is missing
+func (h *Handler) repositoryArgs(args map[string]interface{}) (owner, repo string, errResult *CallToolResult) {
+	owner, ok := args["owner"].(string)
+	if !ok || owner == "" {
+		return "", "", &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("owner is required and must be a string"),
+			}},
+			IsError: true,
+		}
+	}
+
+	repo, ok = args["repo"].(string)
+	if !ok || repo == "" {
+		return "", "", &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("repo is required and must be a string"),
+			}},
+			IsError: true,
+		}
+	}
+	return owner, repo, nil
+}
+
+// repositoryResult returns a repository as the result of a tool
+func (h *Handler) repositoryResult(args map[string]interface{}, repository interface{}) *CallToolResult {
+	repoJSON, err := shapeJSON(args, repository)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting repository data: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: string(repoJSON),
+		}},
+		IsError: false,
+	}
+}
+
+// executeGetRepository executes the get_repository tool
+func (h *Handler) executeGetRepository(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, errResult := h.repositoryArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	repository, err := h.githubClient.GetRepository(ctx, owner, repo)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting repository %s/%s: %v", owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.repositoryResult(args, repository), nil
+}
+
+// executeCreateRepository executes the create_repository tool
+func (h *Handler) executeCreateRepository(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("name is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+	org, _ := args["org"].(string)
+
+	// Build repository data from args
+	repoData := map[string]interface{}{
+		"name": name,
+	}
+	for _, field := range append(append([]string{}, repositorySettings...), "auto_init", "gitignore_template", "license_template") {
+		if value, exists := args[field]; exists {
+			repoData[field] = value
+		}
+	}
+
+	repository, err := h.githubClient.CreateRepository(ctx, org, repoData)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error creating repository %s: %v", name, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.repositoryResult(args, repository), nil
+}
+
+// executeUpdateRepository executes the update_repository tool
+func (h *Handler) executeUpdateRepository(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, errResult := h.repositoryArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Copy valid fields from args to updates
+	updates := make(map[string]interface{})
+	for _, field := range append(append([]string{}, repositorySettings...), repositoryUpdateSettings...) {
+		if value, exists := args[field]; exists {
+			updates[field] = value
+		}
+	}
+
+	if len(updates) == 0 {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("No valid fields provided for update"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	repository, err := h.githubClient.UpdateRepository(ctx, owner, repo, updates)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error updating repository %s/%s: %v", owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.repositoryResult(args, repository), nil
+}
+
+// executeDeleteRepository executes the delete_repository tool
+func (h *Handler) executeDeleteRepository(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, errResult := h.repositoryArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	record, errResult := h.captureDeletion(ctx, "delete_repository", fmt.Sprintf("repository %s/%s", owner, repo), args, func() (interface{}, error) {
+		return h.githubClient.GetRepository(ctx, owner, repo)
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	if err := h.githubClient.DeleteRepository(ctx, owner, repo); err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error deleting repository %s/%s: %v", owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	h.recordDeletion(ctx, record)
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: h.messages.Sprintf("Successfully deleted repository %s/%s", owner, repo),
+		}},
+		IsError: false,
+	}, nil
+}
+
+// executeForkRepository executes the fork_repository tool
+func (h *Handler) executeForkRepository(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, errResult := h.repositoryArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	org, _ := args["organization"].(string)
+	name, _ := args["name"].(string)
+	defaultBranchOnly, _ := args["default_branch_only"].(bool)
+
+	// Forks go to the authenticated user unless an organization is given
+	if org != "" {
+		forkName := name
+		if forkName == "" {
+			forkName = repo
+		}
+		if err := h.githubClient.CheckAccess(org, forkName); err != nil {
+			return &CallToolResult{
+				Content: []Content{{
+					Type: "text",
+					Text: h.messages.Sprintf("Error forking repository %s/%s: %v", owner, repo, err),
+				}},
+				IsError: true,
+			}, nil
+		}
+	}
+
+	repository, err := h.githubClient.ForkRepository(ctx, owner, repo, org, name, defaultBranchOnly)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error forking repository %s/%s: %v", owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.repositoryResult(args, repository), nil
+}
+
+// executeTransferRepository executes the transfer_repository tool
+func (h *Handler) executeTransferRepository(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, errResult := h.repositoryArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	newOwner, ok := args["new_owner"].(string)
+	if !ok || newOwner == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("new_owner is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+	newName, _ := args["new_name"].(string)
+
+	var teamIDs []int64
+	if items, ok := args["team_ids"].([]interface{}); ok {
+		for _, item := range items {
+			if id, ok := item.(float64); ok {
+				teamIDs = append(teamIDs, int64(id))
+			}
+		}
+	}
+
+	newRepo := newName
+	if newRepo == "" {
+		newRepo = repo
+	}
+	if err := h.githubClient.CheckAccess(newOwner, newRepo); err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error transferring repository %s/%s to %s: %v", owner, repo, newOwner, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	repository, err := h.githubClient.TransferRepository(ctx, owner, repo, newOwner, newName, teamIDs)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error transferring repository %s/%s to %s: %v", owner, repo, newOwner, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.repositoryResult(args, repository), nil
+}
@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestRepositoryTools(t *testing.T) {
+	var requests []string
+	var lastBody map[string]interface{}
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			lastBody = nil
+			if req.Body != nil {
+				json.NewDecoder(req.Body).Decode(&lastBody)
+			}
+			return mocks.MockJSONResponse(http.StatusOK, `{"id": 1, "name": "widgets", "full_name": "acme/widgets",
+				"owner": {"login": "acme", "url": "https://api.github.com/users/acme"}, "url": "https://api.github.com/repos/acme/widgets"}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	ctx := context.Background()
+
+	result, err := h.executeGetRepository(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets"})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %+v", err, result)
+	}
+	if text := result.Content[0].Text; !strings.Contains(text, `"full_name":"acme/widgets"`) || strings.Contains(text, "api.github.com") {
+		t.Errorf("Expected the trimmed repository, got %s", text)
+	}
+
+	result, _ = h.executeUpdateRepository(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "has_wiki": false, "verbose": true})
+	if result.IsError || lastBody["has_wiki"] != false || lastBody["verbose"] != nil {
+		t.Errorf("Expected only the repository settings sent, got %v (%+v)", lastBody, result)
+	}
+	result, _ = h.executeUpdateRepository(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets"})
+	if !result.IsError {
+		t.Error("Expected an update without settings to fail")
+	}
+
+	result, _ = h.executeCreateRepository(ctx, map[string]interface{}{"name": "widgets", "org": "acme", "private": true, "auto_init": true})
+	if result.IsError || lastBody["name"] != "widgets" || lastBody["private"] != true || lastBody["org"] != nil {
+		t.Errorf("Unexpected create request %v (%+v)", lastBody, result)
+	}
+
+	result, _ = h.executeTransferRepository(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets"})
+	if !result.IsError {
+		t.Error("Expected a transfer without new_owner to fail")
+	}
+	result, _ = h.executeTransferRepository(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "new_owner": "octo-org",
+		"team_ids": []interface{}{float64(7)}})
+	if result.IsError || lastBody["new_owner"] != "octo-org" {
+		t.Errorf("Unexpected transfer request %v (%+v)", lastBody, result)
+	}
+
+	result, _ = h.executeDeleteRepository(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets"})
+	if result.IsError || !strings.Contains(result.Content[0].Text, "acme/widgets") {
+		t.Errorf("Unexpected delete result %+v", result)
+	}
+
+	want := []string{"GET /repos/acme/widgets", "PATCH /repos/acme/widgets", "POST /orgs/acme/repos",
+		"POST /repos/acme/widgets/transfer", "DELETE /repos/acme/widgets"}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected requests %v, got %v", want, requests)
+	}
+}
+
+func TestRepositoryTools_Catalog(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	for _, name := range []string{"delete_repository", "transfer_repository"} {
+		if !destructiveTools[name] || h.findTool(name) == nil {
+			t.Errorf("Expected %s to be a destructive tool", name)
+		}
+	}
+	if !readOnlyTool("get_repository") || readOnlyTool("fork_repository") {
+		t.Error("Expected only get_repository to be read-only")
+	}
+	if set := toolsetOf("fork_repository"); set == nil || set.name != "repositories" {
+		t.Errorf("Expected fork_repository in the repositories toolset, got %+v", set)
+	}
+}
+
+func TestRepositoryTools_AccessPolicy(t *testing.T) {
+	var requests []string
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			if strings.HasSuffix(req.URL.Path, "/repos") {
+				return mocks.MockJSONResponse(http.StatusOK, `[]`), nil
+			}
+			return mocks.MockJSONResponse(http.StatusOK, `{"id": 1, "name": "widgets", "full_name": "acme/widgets"}`), nil
+		},
+	})
+	githubClient.SetAccessPolicy(client.AccessPolicy{AllowedOwners: []string{"acme"}})
+	h := NewHandler(githubClient, createTestLogger())
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	for _, tt := range []struct {
+		tool    string
+		args    map[string]interface{}
+		allowed bool
+	}{
+		{"get_repository", map[string]interface{}{"owner": "acme", "repo": "widgets"}, true},
+		{"list_repositories", map[string]interface{}{"owner": "acme"}, true},
+		{"list_repositories", map[string]interface{}{"owner": "other"}, false},
+		{"get_repository", map[string]interface{}{"owner": "acme", "repo": "widgets%2F..%2F..%2Fother%2Fwidgets"}, false},
+		{"get_repository", map[string]interface{}{"owner": "acme", "repo": "widgets/../../other/widgets"}, false},
+		{"add_installation_repository", map[string]interface{}{"installation_id": 1, "repository_id": 42}, false},
+		{"transfer_repository", map[string]interface{}{"owner": "acme", "repo": "widgets", "new_owner": "other"}, false},
+		{"fork_repository", map[string]interface{}{"owner": "acme", "repo": "widgets", "organization": "other"}, false},
+		{"fork_repository", map[string]interface{}{"owner": "acme", "repo": "widgets", "organization": "acme", "name": "widgets-fork"}, true},
+	} {
+		requests = nil
+		resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+			"name":      tt.tool,
+			"arguments": tt.args,
+		}})
+		if resp.Error != nil {
+			t.Fatalf("Unexpected JSON-RPC error %+v", resp.Error)
+		}
+		result := resp.Result.(*CallToolResult)
+		if tt.allowed && result.IsError {
+			t.Errorf("Expected %s %v allowed, got %s", tt.tool, tt.args, result.Content[0].Text)
+		}
+		if !tt.allowed && (!result.IsError || len(requests) != 0) {
+			t.Errorf("Expected %s %v denied before reaching GitHub, got %+v after %v", tt.tool, tt.args, result, requests)
+		}
+	}
+}
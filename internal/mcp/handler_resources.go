@@ -0,0 +1,299 @@
+package mcp
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ownerPlaceholders are the URI template variables naming a user or organization
+var ownerPlaceholders = []string{"{owner}", "{org}", "{username}"}
+
+// resourceType returns the type of a resource: the first segment of its
+// github:// URI, such as "user", "repos" or "org"
+func resourceType(uri string) string {
+	rest := strings.TrimPrefix(uri, "github://")
+	kind, _, _ := strings.Cut(rest, "/")
+	return kind
+}
+
+// filterResources returns the resources matching the filters of req.
+// Resources are kept when their URI or name contains the pattern, or matches
+// it as a glob; when an owner is given, only resources naming a user or
+// organization are kept, with the owner substituted into their URI.
+func filterResources(resources []Resource, req *ListResourcesRequest) []Resource {
+	pattern := strings.ToLower(req.Pattern)
+	filtered := make([]Resource, 0, len(resources))
+	for _, resource := range resources {
+		if req.Type != "" && !strings.EqualFold(resourceType(resource.URI), req.Type) {
+			continue
+		}
+		if pattern != "" && !matchesPattern(resource, pattern) {
+			continue
+		}
+		if req.Owner != "" {
+			uri, ok := expandOwner(resource.URI, req.Owner)
+			if !ok {
+				continue
+			}
+			resource.URI = uri
+		}
+		filtered = append(filtered, resource)
+	}
+	return filtered
+}
+
+// matchesPattern reports whether a resource's URI or name contains pattern,
+// or matches it as a glob; pattern must be lower case
+func matchesPattern(resource Resource, pattern string) bool {
+	for _, field := range []string{strings.ToLower(resource.URI), strings.ToLower(resource.Name)} {
+		if strings.Contains(field, pattern) {
+			return true
+		}
+		if matched, _ := path.Match(pattern, field); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// expandOwner substitutes owner for the user or organization placeholder of
+// a URI template. ok is false when the URI names no user or organization.
+func expandOwner(uri, owner string) (string, bool) {
+	for _, placeholder := range ownerPlaceholders {
+		if strings.Contains(uri, placeholder) {
+			return strings.Replace(uri, placeholder, url.PathEscape(owner), 1), true
+		}
+	}
+	return uri, false
+}
+
+// resourceTemplates lists the URI templates of the resources that can be read
+var resourceTemplates = []ResourceTemplate{
+	{URITemplate: "github://user/{username}", Name: "GitHub User", Description: "Profile of a GitHub user", MimeType: "application/json"},
+	{URITemplate: "github://user/{username}/orgs", Name: "User Organizations", Description: "Organizations a user belongs to publicly", MimeType: "application/json"},
+	{URITemplate: "github://repos/{owner}", Name: "GitHub Repositories", Description: "Repositories of a user or organization", MimeType: "application/json"},
+	{URITemplate: "github://repos/{owner}/{repo}", Name: "GitHub Repository", Description: "A repository and its settings", MimeType: "application/json"},
+	{URITemplate: "github://repos/{owner}/{repo}/contents/{path}", Name: "Repository File", Description: "Contents of a file or directory in a repository's default branch, or at the branch, tag or commit of a ?ref= query; path may span several segments", MimeType: "application/json"},
+	{URITemplate: "github://repos/{owner}/{repo}/issues/{number}", Name: "GitHub Issue", Description: "An issue with its labels, assignees and state", MimeType: "application/json"},
+	{URITemplate: "github://repos/{owner}/{repo}/pulls/{number}", Name: "GitHub Pull Request", Description: "A pull request with its branches and review state", MimeType: "application/json"},
+	{URITemplate: "github://org/{org}", Name: "GitHub Organization", Description: "Profile of a GitHub organization", MimeType: "application/json"},
+	{URITemplate: "github://org/{org}/members", Name: "GitHub Organization Members", Description: "Members of an organization", MimeType: "application/json"},
+	{URITemplate: "github://org/{org}/analytics", Name: "GitHub Organization Activity Analytics", Description: "Issue and pull request activity summary of an organization", MimeType: "application/json"},
+}
+
+// matchResourceTemplate returns the template uri is an instance of and the
+// values of its variables. Variables match one path segment, except {path}
+// which matches the rest of the URI, and {number} must be a positive number.
+func matchResourceTemplate(uri string) (*ResourceTemplate, map[string]string, bool) {
+	rest, ok := strings.CutPrefix(uri, "github://")
+	if !ok {
+		return nil, nil, false
+	}
+	segments := strings.Split(rest, "/")
+
+	for i := range resourceTemplates {
+		parts := strings.Split(strings.TrimPrefix(resourceTemplates[i].URITemplate, "github://"), "/")
+		if values, ok := matchSegments(parts, segments); ok {
+			return &resourceTemplates[i], values, true
+		}
+	}
+	return nil, nil, false
+}
+
+// matchSegments matches the segments of a URI against those of a template
+func matchSegments(parts, segments []string) (map[string]string, bool) {
+	values := make(map[string]string)
+	for i, part := range parts {
+		if i >= len(segments) {
+			return nil, false
+		}
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			if segments[i] != part {
+				return nil, false
+			}
+			continue
+		}
+
+		name := strings.Trim(part, "{}")
+		raw := segments[i]
+		isPath := name == "path" && i == len(parts)-1
+		if isPath {
+			raw = strings.Join(segments[i:], "/")
+		}
+		value, err := url.PathUnescape(raw)
+		if err != nil || !validSegmentValue(value, isPath) {
+			return nil, false
+		}
+		if name == "number" && !positiveNumber(value) {
+			return nil, false
+		}
+		values[name] = value
+		if name == "path" && i == len(parts)-1 {
+			return values, true
+		}
+	}
+	return values, len(parts) == len(segments)
+}
+
+// validSegmentValue reports whether the unescaped value of a template
+// variable stays inside its segment, or for a path inside the repository:
+// it has no empty, . or .. segments, and only a path has several segments
+func validSegmentValue(value string, isPath bool) bool {
+	segments := strings.Split(value, "/")
+	if len(segments) > 1 && !isPath {
+		return false
+	}
+	for _, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// positiveNumber reports whether s is a positive decimal number
+func positiveNumber(s string) bool {
+	if s == "" || strings.Trim(s, "0123456789") != "" {
+		return false
+	}
+	return strings.TrimLeft(s, "0") != ""
+}
+
+// validResourceURI reports whether uri names a resource the server lists or
+// an instance of one of its templates
+func (h *Handler) validResourceURI(uri string) bool {
+	for _, resource := range h.resources {
+		if resource.URI == uri && !strings.Contains(uri, "{") {
+			return true
+		}
+	}
+	_, _, ok := matchResourceTemplate(uri)
+	return ok
+}
+
+// resourceTools returns the tools for discovering resources
+func (h *Handler) resourceTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "find_resource",
+			Description: "Resolve a natural identifier to its github:// resource URI: owner/repo#123 or an issue or pull request URL for an issue, owner/repo for a repository, and a login (optionally prefixed with @) for a user or organization.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"identifier": map[string]interface{}{
+						"type":        "string",
+						"description": "Identifier such as owner/repo#123, owner/repo, @login or https://github.com/owner/repo/pull/1",
+					},
+				},
+				"required": []string{"identifier"},
+			},
+			Shaped: true,
+		}, h.executeFindResource),
+	}
+}
+
+// ResolvedResource is the result of find_resource
+type ResolvedResource struct {
+	URI  string `json:"uri"`
+	Type string `json:"type"`
+	// Alternatives are other resources the identifier may refer to, such as
+	// the organization of the same name as a user
+	Alternatives []string `json:"alternatives,omitempty"`
+}
+
+var (
+	// issueIdentifier matches owner/repo#number
+	issueIdentifier = regexp.MustCompile(`^([\w.-]+)/([\w.-]+)#(\d+)$`)
+	// repoIdentifier matches owner/repo
+	repoIdentifier = regexp.MustCompile(`^([\w.-]+)/([\w.-]+)$`)
+	// loginIdentifier matches a user or organization login
+	loginIdentifier = regexp.MustCompile(`^@?([A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)$`)
+	// githubURL matches github.com URLs of repositories, issues and pull requests
+	githubURL = regexp.MustCompile(`^https?://(?:www\.)?github\.com/([\w.-]+)(?:/([\w.-]+)(?:/(issues|pull)/(\d+))?)?/?$`)
+)
+
+// resolveIdentifier resolves an identifier to a github:// URI
+func resolveIdentifier(identifier string) (*ResolvedResource, bool) {
+	identifier = strings.TrimSpace(identifier)
+
+	if m := githubURL.FindStringSubmatch(identifier); m != nil {
+		switch {
+		case m[4] != "" && m[3] == "pull":
+			return &ResolvedResource{URI: "github://repos/" + m[1] + "/" + m[2] + "/pulls/" + m[4], Type: "pull_request"}, true
+		case m[4] != "":
+			return &ResolvedResource{URI: "github://repos/" + m[1] + "/" + m[2] + "/issues/" + m[4], Type: "issue"}, true
+		case m[2] != "":
+			identifier = m[1] + "/" + m[2]
+		default:
+			identifier = m[1]
+		}
+	}
+
+	if m := issueIdentifier.FindStringSubmatch(identifier); m != nil {
+		// GitHub numbers issues and pull requests together, and serves
+		// pull requests from the issues API too
+		return &ResolvedResource{
+			URI:          "github://repos/" + m[1] + "/" + m[2] + "/issues/" + m[3],
+			Type:         "issue",
+			Alternatives: []string{"github://repos/" + m[1] + "/" + m[2] + "/pulls/" + m[3]},
+		}, true
+	}
+	if m := repoIdentifier.FindStringSubmatch(identifier); m != nil {
+		return &ResolvedResource{URI: "github://repos/" + m[1] + "/" + m[2], Type: "repository"}, true
+	}
+	if m := loginIdentifier.FindStringSubmatch(identifier); m != nil {
+		return &ResolvedResource{
+			URI:          "github://user/" + m[1],
+			Type:         "user",
+			Alternatives: []string{"github://org/" + m[1]},
+		}, true
+	}
+	return nil, false
+}
+
+// executeFindResource executes the find_resource tool
+func (h *Handler) executeFindResource(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	identifier, ok := args["identifier"].(string)
+	if !ok || identifier == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: identifier parameter is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	resolved, ok := resolveIdentifier(identifier)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: %s is not a recognized identifier; use owner/repo#123, owner/repo or a login", identifier),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	resolvedJSON, err := shapeJSON(args, resolved)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting resource data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: string(resolvedJSON),
+		}},
+		IsError: false,
+	}, nil
+}
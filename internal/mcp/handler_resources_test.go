@@ -0,0 +1,195 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFilterResources(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+
+	tests := []struct {
+		name string
+		req  ListResourcesRequest
+		want []string
+	}{
+		{
+			name: "type",
+			req:  ListResourcesRequest{Type: "user"},
+			want: []string{"github://user/{username}", "github://user/{username}/orgs"},
+		},
+		{
+			name: "pattern substring",
+			req:  ListResourcesRequest{Pattern: "Members"},
+			want: []string{"github://org/{org}/members"},
+		},
+		{
+			name: "pattern glob",
+			req:  ListResourcesRequest{Pattern: "github://org/*/analytics"},
+			want: []string{"github://org/{org}/analytics"},
+		},
+		{
+			name: "owner",
+			req:  ListResourcesRequest{Owner: "octo-org", Type: "org"},
+			want: []string{"github://org/octo-org", "github://org/octo-org/members", "github://org/octo-org/analytics"},
+		},
+		{
+			name: "owner excludes resources without one",
+			req:  ListResourcesRequest{Owner: "octocat", Pattern: "github://organizations"},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, resource := range filterResources(h.resources, &tt.req) {
+				got = append(got, resource.URI)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHandleListResources_Filters(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.httpSession.setInitialized()
+
+	msg := NewRequest(1, MethodListResources, map[string]interface{}{"type": "repos", "owner": "octocat"})
+	resp := h.handleListResources(context.Background(), msg)
+	if resp.Error != nil {
+		t.Fatalf("Unexpected error: %+v", resp.Error)
+	}
+	result := resp.Result.(ResourcesListResult)
+	if len(result.Resources) != 1 || result.Resources[0].URI != "github://repos/octocat" {
+		t.Errorf("Expected the expanded repositories resource, got %+v", result.Resources)
+	}
+
+	// Without params every resource is listed
+	resp = h.handleListResources(context.Background(), NewRequest(2, MethodListResources, nil))
+	if got := len(resp.Result.(ResourcesListResult).Resources); got != len(h.resources) {
+		t.Errorf("Expected %d resources, got %d", len(h.resources), got)
+	}
+}
+
+func TestResolveIdentifier(t *testing.T) {
+	tests := []struct {
+		identifier string
+		want       *ResolvedResource
+	}{
+		{"octo-org/octo-repo#123", &ResolvedResource{
+			URI:          "github://repos/octo-org/octo-repo/issues/123",
+			Type:         "issue",
+			Alternatives: []string{"github://repos/octo-org/octo-repo/pulls/123"},
+		}},
+		{"octo-org/octo.repo", &ResolvedResource{URI: "github://repos/octo-org/octo.repo", Type: "repository"}},
+		{"@octocat", &ResolvedResource{URI: "github://user/octocat", Type: "user", Alternatives: []string{"github://org/octocat"}}},
+		{"https://github.com/octo-org/octo-repo/pull/7", &ResolvedResource{URI: "github://repos/octo-org/octo-repo/pulls/7", Type: "pull_request"}},
+		{"https://github.com/octo-org/octo-repo/issues/8", &ResolvedResource{URI: "github://repos/octo-org/octo-repo/issues/8", Type: "issue"}},
+		{"https://github.com/octo-org/octo-repo", &ResolvedResource{URI: "github://repos/octo-org/octo-repo", Type: "repository"}},
+		{"not an identifier", nil},
+		{"a/b/c", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.identifier, func(t *testing.T) {
+			got, ok := resolveIdentifier(tt.identifier)
+			if tt.want == nil {
+				if ok {
+					t.Fatalf("Expected no resolution, got %+v", got)
+				}
+				return
+			}
+			if !ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExecuteFindResource(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+
+	result, err := h.executeFindResource(context.Background(), map[string]interface{}{"identifier": "octo-org/octo-repo#1"})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %+v", err, result)
+	}
+	text := result.Content[0].Text
+	var resolved ResolvedResource
+	if err := json.Unmarshal([]byte(text), &resolved); err != nil {
+		t.Fatalf("Expected JSON in the result, got %q", text)
+	}
+	if resolved.URI != "github://repos/octo-org/octo-repo/issues/1" {
+		t.Errorf("Unexpected URI %s", resolved.URI)
+	}
+
+	result, _ = h.executeFindResource(context.Background(), map[string]interface{}{"identifier": "???"})
+	if !result.IsError {
+		t.Error("Expected an error for an unrecognized identifier")
+	}
+}
+
+func TestMatchResourceTemplate(t *testing.T) {
+	tests := []struct {
+		uri      string
+		template string
+		values   map[string]string
+	}{
+		{"github://user/octocat", "github://user/{username}", map[string]string{"username": "octocat"}},
+		{"github://repos/octo-org/octo-repo/issues/12", "github://repos/{owner}/{repo}/issues/{number}",
+			map[string]string{"owner": "octo-org", "repo": "octo-repo", "number": "12"}},
+		{"github://repos/octo-org/octo-repo/contents/docs/guide%20one.md", "github://repos/{owner}/{repo}/contents/{path}",
+			map[string]string{"owner": "octo-org", "repo": "octo-repo", "path": "docs/guide one.md"}},
+		{"github://org/octo-org/analytics", "github://org/{org}/analytics", map[string]string{"org": "octo-org"}},
+		{"github://repos/octo-org/octo-repo/issues/latest", "", nil},
+		{"github://repos/octo-org/octo-repo/issues/0", "", nil},
+		{"github://user//orgs", "", nil},
+		{"github://repos/octo-org/octo-repo/contents/", "", nil},
+		{"github://repos/octo-org%2Fother/octo-repo", "", nil},
+		{"github://repos/octo-org/%2E%2E/issues/1", "", nil},
+		{"github://repos/octo-org/./issues/1", "", nil},
+		{"github://repos/octo-org/octo-repo/contents/docs/../../../other/repo", "", nil},
+		{"github://repos/octo-org/octo-repo/contents/docs%2F%2E%2E%2Fsecret", "", nil},
+		{"github://gists/octocat", "", nil},
+		{"https://github.com/octocat", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			template, values, ok := matchResourceTemplate(tt.uri)
+			if !ok {
+				if tt.template != "" {
+					t.Fatalf("Expected %s to match %s", tt.uri, tt.template)
+				}
+				return
+			}
+			if template.URITemplate != tt.template || !reflect.DeepEqual(values, tt.values) {
+				t.Errorf("Expected %s with %v, got %s with %v", tt.template, tt.values, template.URITemplate, values)
+			}
+		})
+	}
+}
+
+func TestHandleReadResource_UnknownURI(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.httpSession.setInitialized()
+
+	resp := h.handleReadResource(context.Background(), NewRequest(1, MethodReadResource, map[string]interface{}{"uri": "github://gists/octocat"}))
+	if resp.Error == nil || resp.Error.Code != ErrorCodeResourceNotFound {
+		t.Errorf("Expected a resource not found error, got %+v", resp.Error)
+	}
+
+	resp = h.handleReadResource(context.Background(), NewRequest(2, MethodReadResource, map[string]interface{}{"uri": "github://organizations"}))
+	if resp.Error != nil {
+		t.Errorf("Expected a listed resource to be read, got %+v", resp.Error)
+	}
+
+	resp = h.handleListResourceTemplates(context.Background(), NewRequest(3, MethodListResourceTemplates, nil))
+	if got := len(resp.Result.(ResourceTemplatesListResult).ResourceTemplates); got != len(resourceTemplates) {
+		t.Errorf("Expected %d resource templates, got %d", len(resourceTemplates), got)
+	}
+}
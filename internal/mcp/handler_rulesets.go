@@ -0,0 +1,465 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+)
+
+// rulesetSettings are the ruleset fields create_ruleset and update_ruleset
+// send to GitHub
+var rulesetSettings = []string{"name", "target", "enforcement", "bypass_actors", "conditions", "rules"}
+
+// rulesetTools returns the tools reading and changing repository and
+// organization rulesets
+func (h *Handler) rulesetTools() []ToolProvider {
+	rulesetID := func() map[string]interface{} {
+		return map[string]interface{}{"type": "integer", "description": "ID of the ruleset", "minimum": 1}
+	}
+	// target adds the arguments naming a repository, or an organization,
+	// whose rulesets a tool works on
+	target := func(properties map[string]interface{}) map[string]interface{} {
+		properties["owner"] = stringProperty("Repository owner, for the rulesets of a repository")
+		properties["repo"] = stringProperty("Repository name, for the rulesets of a repository")
+		properties["org"] = stringProperty("Organization name, for the rulesets of an organization instead of a repository")
+		return properties
+	}
+	settings := func(properties map[string]interface{}) map[string]interface{} {
+		properties["name"] = stringProperty("Name of the ruleset")
+		properties["target"] = map[string]interface{}{
+			"type":        "string",
+			"description": "What the ruleset applies to",
+			"enum":        []string{"branch", "tag", "push"},
+			"default":     "branch",
+		}
+		properties["enforcement"] = map[string]interface{}{
+			"type":        "string",
+			"description": "Whether the ruleset is enforced; evaluate only reports what it would block, for organizations on GitHub Enterprise",
+			"enum":        []string{"disabled", "active", "evaluate"},
+		}
+		properties["bypass_actors"] = map[string]interface{}{
+			"type":        "array",
+			"description": "Actors allowed to bypass the ruleset",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"actor_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "ID of the team, app or repository role; 1 for the OrganizationAdmin actor",
+					},
+					"actor_type": map[string]interface{}{
+						"type": "string",
+						"enum": []string{"Integration", "OrganizationAdmin", "RepositoryRole", "Team", "DeployKey"},
+					},
+					"bypass_mode": map[string]interface{}{
+						"type":        "string",
+						"description": "Whether the actor always bypasses the ruleset, or only through pull requests",
+						"enum":        []string{"always", "pull_request"},
+					},
+				},
+				"required": []string{"actor_type"},
+			},
+		}
+		properties["conditions"] = map[string]interface{}{
+			"type":        "object",
+			"description": `Refs and, for organization rulesets, repositories the ruleset applies to, such as {"ref_name": {"include": ["~DEFAULT_BRANCH", "refs/heads/release/*"], "exclude": []}}`,
+		}
+		properties["rules"] = map[string]interface{}{
+			"type":        "array",
+			"description": "Rules of the ruleset, replacing its current rules",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"type": stringProperty("Type of the rule, such as deletion, non_fast_forward, required_linear_history, required_signatures, pull_request or required_status_checks"),
+					"parameters": map[string]interface{}{
+						"type":        "object",
+						"description": `Parameters of the rule, such as {"required_approving_review_count": 1} for pull_request`,
+					},
+				},
+				"required": []string{"type"},
+			},
+		}
+		return properties
+	}
+
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "list_rulesets",
+			Description: "List the rulesets of a repository, including those it inherits from its organization, or of an organization when org is given",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": target(map[string]interface{}{
+					"page":     pageProperty(),
+					"per_page": perPageProperty(),
+				}),
+			},
+			Shaped: true,
+		}, h.executeListRulesets),
+		NewTool(Tool{
+			Name:        "get_ruleset",
+			Description: "Get a ruleset of a repository, or of an organization when org is given, with its bypass actors, conditions and rules",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": target(map[string]interface{}{
+					"ruleset_id": rulesetID(),
+				}),
+				"required": []string{"ruleset_id"},
+			},
+			Shaped: true,
+		}, h.executeGetRuleset),
+		NewTool(Tool{
+			Name:        "create_ruleset",
+			Description: "Create a ruleset for a repository, or for an organization when org is given",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": settings(target(map[string]interface{}{})),
+				"required":   []string{"name", "enforcement"},
+			},
+			Shaped: true,
+		}, h.executeCreateRuleset),
+		NewTool(Tool{
+			Name:        "update_ruleset",
+			Description: "Update a ruleset of a repository, or of an organization when org is given. Fields not given are kept; bypass_actors, conditions and rules replace the current ones when given.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": settings(target(map[string]interface{}{
+					"ruleset_id": rulesetID(),
+				})),
+				"required": []string{"ruleset_id"},
+			},
+			Shaped: true,
+		}, h.executeUpdateRuleset),
+		NewTool(Tool{
+			Name:        "delete_ruleset",
+			Description: "Delete a ruleset of a repository, or of an organization when org is given",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": target(map[string]interface{}{
+					"ruleset_id": rulesetID(),
+				}),
+				"required": []string{"ruleset_id"},
+			},
+		}, h.executeDeleteRuleset),
+		NewTool(Tool{
+			Name:        "list_branch_rules",
+			Description: "List the rules that apply to a branch from every active ruleset of its repository and organization, with the ruleset each rule comes from",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner":    stringProperty("Repository owner"),
+					"repo":     stringProperty("Repository name"),
+					"branch":   stringProperty("Name of the branch"),
+					"page":     pageProperty(),
+					"per_page": perPageProperty(),
+				},
+				"required": []string{"owner", "repo", "branch"},
+			},
+			Shaped: true,
+		}, h.executeListBranchRules),
+	}
+}
+
+// rulesetTargetArgs reads the org argument, or the owner and repo
+// arguments, naming whose rulesets a tool works on. repo is empty for an
+// organization. name is the organization or owner/repo, for messages.
+func (h *Handler) rulesetTargetArgs(args map[string]interface{}) (owner, repo, name string, errResult *CallToolResult) {
+	org, _ := args["org"].(string)
+	owner, _ = args["owner"].(string)
+	repo, _ = args["repo"].(string)
+	if org != "" && (owner != "" || repo != "") {
+		return "", "", "", &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: give either org, or owner and repo parameters, not both"),
+			}},
+			IsError: true,
+		}
+	}
+	if org != "" {
+		return org, "", org, nil
+	}
+	if owner == "" {
+		return "", "", "", &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: either org, or owner and repo parameters are required"),
+			}},
+			IsError: true,
+		}
+	}
+
+	owner, repo, errResult = h.repositoryArgs(args)
+	if errResult != nil {
+		return "", "", "", errResult
+	}
+	return owner, repo, owner + "/" + repo, nil
+}
+
+// removesRulesetProtection reports whether an update_ruleset call turns the
+// ruleset off or removes all of its rules
+func removesRulesetProtection(args map[string]interface{}) bool {
+	if enforcement, _ := args["enforcement"].(string); enforcement == "disabled" {
+		return true
+	}
+	rules, given := args["rules"]
+	if !given {
+		return false
+	}
+	list, _ := rules.([]interface{})
+	return len(list) == 0
+}
+
+// rulesetIDArg reads the ruleset_id argument, returning an error result when
+// it is missing
+func (h *Handler) rulesetIDArg(args map[string]interface{}) (int64, *CallToolResult) {
+	id, ok := args["ruleset_id"].(float64)
+	if !ok || id <= 0 {
+		return 0, &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: ruleset_id parameter is required and must be a positive integer"),
+			}},
+			IsError: true,
+		}
+	}
+	return int64(id), nil
+}
+
+// rulesetResult returns a ruleset, or a list of rulesets or rules, as the
+// result of a tool
+func (h *Handler) rulesetResult(args map[string]interface{}, value interface{}) *CallToolResult {
+	valueJSON, err := shapeJSON(args, value)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting ruleset data: %v", err),
+			}},
+			IsError: true,
+		}
+	}
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: string(valueJSON),
+		}},
+		IsError: false,
+	}
+}
+
+// executeListRulesets executes the list_rulesets tool
+func (h *Handler) executeListRulesets(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, name, errResult := h.rulesetTargetArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	rulesets, pageInfo, err := h.githubClient.ListRulesets(ctx, owner, repo, page, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing rulesets of %s: %v", name, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.rulesetResult(args, h.newListEnvelope(args, rulesets, pageInfo, listPage{page: page, perPage: perPage})), nil
+}
+
+// executeGetRuleset executes the get_ruleset tool
+func (h *Handler) executeGetRuleset(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, name, errResult := h.rulesetTargetArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	id, errResult := h.rulesetIDArg(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	ruleset, err := h.githubClient.GetRuleset(ctx, owner, repo, id)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting ruleset %d of %s: %v", id, name, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.rulesetResult(args, ruleset), nil
+}
+
+// executeCreateRuleset executes the create_ruleset tool
+func (h *Handler) executeCreateRuleset(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, name, errResult := h.rulesetTargetArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	rulesetName, ok := args["name"].(string)
+	if !ok || rulesetName == "" {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("name is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	rulesetData := make(map[string]interface{})
+	for _, field := range rulesetSettings {
+		if value, exists := args[field]; exists {
+			rulesetData[field] = value
+		}
+	}
+
+	ruleset, err := h.githubClient.CreateRuleset(ctx, owner, repo, rulesetData)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error creating ruleset %s for %s: %v", rulesetName, name, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.rulesetResult(args, ruleset), nil
+}
+
+// executeUpdateRuleset executes the update_ruleset tool
+func (h *Handler) executeUpdateRuleset(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, name, errResult := h.rulesetTargetArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	id, errResult := h.rulesetIDArg(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	updates := make(map[string]interface{})
+	for _, field := range rulesetSettings {
+		if value, exists := args[field]; exists {
+			updates[field] = value
+		}
+	}
+
+	if len(updates) == 0 {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("No valid fields provided for update"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Turning the ruleset off or clearing its rules removes the protection,
+	// so it is captured as a deletion would be
+	var record *DeletionRecord
+	if removesRulesetProtection(args) {
+		record, errResult = h.captureDeletion(ctx, "update_ruleset", fmt.Sprintf("ruleset %d of %s", id, name), args, func() (interface{}, error) {
+			return h.githubClient.GetRuleset(ctx, owner, repo, id)
+		})
+		if errResult != nil {
+			return errResult, nil
+		}
+	}
+
+	ruleset, err := h.githubClient.UpdateRuleset(ctx, owner, repo, id, updates)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error updating ruleset %d of %s: %v", id, name, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	h.recordDeletion(ctx, record)
+
+	return h.rulesetResult(args, ruleset), nil
+}
+
+// executeDeleteRuleset executes the delete_ruleset tool
+func (h *Handler) executeDeleteRuleset(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, name, errResult := h.rulesetTargetArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	id, errResult := h.rulesetIDArg(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	record, errResult := h.captureDeletion(ctx, "delete_ruleset", fmt.Sprintf("ruleset %d of %s", id, name), args, func() (interface{}, error) {
+		return h.githubClient.GetRuleset(ctx, owner, repo, id)
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	if err := h.githubClient.DeleteRuleset(ctx, owner, repo, id); err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error deleting ruleset %d of %s: %v", id, name, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	h.recordDeletion(ctx, record)
+
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: h.messages.Sprintf("Successfully deleted ruleset %d of %s", id, name),
+		}},
+		IsError: false,
+	}, nil
+}
+
+// executeListBranchRules executes the list_branch_rules tool
+func (h *Handler) executeListBranchRules(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, repo, branch, errResult := h.branchArgs(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	rules, pageInfo, err := h.githubClient.GetBranchRules(ctx, owner, repo, branch, page, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting rules of branch %s in %s/%s: %v", branch, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return h.rulesetResult(args, h.newListEnvelope(args, rules, pageInfo, listPage{page: page, perPage: perPage})), nil
+}
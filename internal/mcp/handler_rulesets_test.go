@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestRulesetTools(t *testing.T) {
+	var requests []string
+	var lastBody map[string]interface{}
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			lastBody = nil
+			if req.Body != nil {
+				json.NewDecoder(req.Body).Decode(&lastBody)
+			}
+			switch {
+			case req.Method == http.MethodDelete:
+				return mocks.MockResponse(http.StatusNoContent, "", nil), nil
+			case req.URL.Path == "/repos/acme/widgets/rulesets" && req.Method == http.MethodGet:
+				return mocks.MockJSONResponse(http.StatusOK, `[{"id": 42, "name": "main", "target": "branch", "source_type": "Repository", "source": "acme/widgets", "enforcement": "active"}]`), nil
+			case strings.HasPrefix(req.URL.Path, "/repos/acme/widgets/rules/branches/"):
+				return mocks.MockJSONResponse(http.StatusOK, `[{"type": "pull_request", "parameters": {"required_approving_review_count": 1},
+					"ruleset_source_type": "Organization", "ruleset_source": "acme", "ruleset_id": 7}]`), nil
+			}
+			return mocks.MockJSONResponse(http.StatusOK, `{"id": 42, "name": "main", "target": "branch", "enforcement": "active",
+				"conditions": {"ref_name": {"include": ["~DEFAULT_BRANCH"], "exclude": []}}, "rules": [{"type": "deletion"}]}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	ctx := context.Background()
+
+	result, _ := h.executeListRulesets(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets"})
+	if result.IsError || !strings.Contains(result.Content[0].Text, `"enforcement":"active"`) || !strings.Contains(result.Content[0].Text, `"pagination"`) {
+		t.Errorf("Expected the rulesets listed, got %+v", result)
+	}
+
+	result, _ = h.executeListRulesets(ctx, map[string]interface{}{})
+	if !result.IsError {
+		t.Error("Expected a call without org or repository to fail")
+	}
+
+	result, _ = h.executeGetRuleset(ctx, map[string]interface{}{"org": "acme", "ruleset_id": float64(42)})
+	if result.IsError || !strings.Contains(result.Content[0].Text, "~DEFAULT_BRANCH") || requests[len(requests)-1] != "GET /orgs/acme/rulesets/42" {
+		t.Errorf("Expected the organization ruleset, got %v (%+v)", requests, result)
+	}
+
+	result, _ = h.executeCreateRuleset(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "name": "main", "enforcement": "active",
+		"rules": []interface{}{map[string]interface{}{"type": "deletion"}}, "verbose": true})
+	if result.IsError || requests[len(requests)-1] != "POST /repos/acme/widgets/rulesets" || lastBody["name"] != "main" || lastBody["rules"] == nil || lastBody["verbose"] != nil {
+		t.Errorf("Unexpected create request %v %v (%+v)", requests, lastBody, result)
+	}
+
+	result, _ = h.executeUpdateRuleset(ctx, map[string]interface{}{"org": "acme", "ruleset_id": float64(42)})
+	if !result.IsError {
+		t.Error("Expected an update without fields to fail")
+	}
+	result, _ = h.executeUpdateRuleset(ctx, map[string]interface{}{"org": "acme", "ruleset_id": float64(42), "enforcement": "disabled"})
+	if result.IsError || requests[len(requests)-1] != "PUT /orgs/acme/rulesets/42" || lastBody["enforcement"] != "disabled" || lastBody["name"] != nil {
+		t.Errorf("Unexpected update request %v %v (%+v)", requests, lastBody, result)
+	}
+
+	requests = nil
+	result, _ = h.executeGetRuleset(ctx, map[string]interface{}{"org": "acme", "owner": "acme", "repo": "widgets", "ruleset_id": float64(42)})
+	if !result.IsError || len(requests) != 0 {
+		t.Errorf("Expected a call naming both an organization and a repository to fail, got %+v", result)
+	}
+
+	// Turning a ruleset off or clearing its rules is captured in safe delete
+	// mode, and needs confirming
+	h.SetSafeDelete(true, "")
+	result, _ = h.executeUpdateRuleset(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "ruleset_id": float64(42), "rules": []interface{}{}})
+	if records := h.deletions.recent("update_ruleset", 10); result.IsError || len(records) != 1 || !strings.Contains(string(records[0].State), `"deletion"`) {
+		t.Errorf("Expected the ruleset captured before its rules were cleared, got %+v (%+v)", records, result)
+	}
+	h.executeUpdateRuleset(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "ruleset_id": float64(42), "name": "renamed"})
+	if records := h.deletions.recent("update_ruleset", 10); len(records) != 1 {
+		t.Errorf("Expected a rename not to be captured, got %+v", records)
+	}
+	h.SetSafeDelete(false, "")
+	for args, want := range map[string]bool{`{"enforcement": "disabled"}`: true, `{"rules": []}`: true, `{"enforcement": "active"}`: false,
+		`{"rules": [{"type": "deletion"}]}`: false} {
+		var parsed map[string]interface{}
+		json.Unmarshal([]byte(args), &parsed)
+		if needsConfirmation("update_ruleset", parsed) != want {
+			t.Errorf("Expected update_ruleset with %s to need confirming: %v", args, want)
+		}
+	}
+
+	result, _ = h.executeDeleteRuleset(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "ruleset_id": float64(42)})
+	if result.IsError || requests[len(requests)-1] != "DELETE /repos/acme/widgets/rulesets/42" {
+		t.Errorf("Unexpected delete %v (%+v)", requests, result)
+	}
+
+	result, _ = h.executeListBranchRules(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "branch": "main"})
+	if result.IsError || !strings.Contains(result.Content[0].Text, `"ruleset_source":"acme"`) || !strings.Contains(result.Content[0].Text, `"type":"pull_request"`) {
+		t.Errorf("Expected the branch rules with their rulesets, got %+v", result)
+	}
+	requests = nil
+	h.executeListBranchRules(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "branch": "release/1.x#2"})
+	if len(requests) != 1 || requests[0] != "GET /repos/acme/widgets/rules/branches/release/1.x#2" {
+		t.Errorf("Expected the branch name escaped, got %v", requests)
+	}
+}
@@ -0,0 +1,189 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+const (
+	// summaryMaxComments is the most comments of a thread sent to be summarized
+	summaryMaxComments = 300
+
+	issueThreadPrompt = "You summarize GitHub issue threads for a developer. Give the problem, the " +
+		"positions and decisions reached in the discussion, and what remains open, in a few short paragraphs."
+	pullDiffPrompt = "You summarize GitHub pull request diffs for a reviewer. Describe what the change " +
+		"does, the files and areas it touches, and anything that deserves a closer look, in a few short paragraphs."
+)
+
+// summaryTools returns the tools summarizing GitHub data with the client's model
+func (h *Handler) summaryTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "summarize_issue_thread",
+			Description: "Summarize an issue or pull request and its comments. The thread is fetched server-side and summarized by the client's model through sampling; clients without sampling get the thread to summarize.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository owner",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository name",
+					},
+					"issue_number": map[string]interface{}{
+						"type":        "integer",
+						"description": "Issue or pull request number",
+						"minimum":     1,
+					},
+				},
+				"required": []string{"owner", "repo", "issue_number"},
+			},
+		}, h.executeSummarizeIssueThread),
+		NewTool(Tool{
+			Name:        "summarize_pr_diff",
+			Description: "Summarize the changes of a pull request. The diff is fetched server-side and summarized by the client's model through sampling; clients without sampling get the diff to summarize.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository owner",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository name",
+					},
+					"pull_number": map[string]interface{}{
+						"type":        "integer",
+						"description": "Pull request number",
+						"minimum":     1,
+					},
+				},
+				"required": []string{"owner", "repo", "pull_number"},
+			},
+		}, h.executeSummarizePRDiff),
+	}
+}
+
+// executeSummarizeIssueThread executes the summarize_issue_thread tool
+func (h *Handler) executeSummarizeIssueThread(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, _ := args["owner"].(string)
+	repo, _ := args["repo"].(string)
+	number, _ := args["issue_number"].(float64)
+	if owner == "" || repo == "" || number < 1 {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: owner, repo and issue_number parameters are required"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	issue, err := h.githubClient.GetIssue(ctx, owner, repo, int(number))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting issue %s/%s#%d: %v", owner, repo, int(number), err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var comments []client.IssueComment
+	for page := 1; len(comments) < summaryMaxComments; page++ {
+		batch, pageInfo, err := h.githubClient.ListIssueComments(ctx, owner, repo, int(number), page, 100)
+		if err != nil {
+			return &CallToolResult{
+				Content: []Content{{
+					Type: "text",
+					Text: h.messages.Sprintf("Error listing comments of %s/%s#%d: %v", owner, repo, int(number), err),
+				}},
+				IsError: true,
+			}, nil
+		}
+		comments = append(comments, batch...)
+		if !pageInfo.HasMore() {
+			break
+		}
+	}
+	if len(comments) > summaryMaxComments {
+		comments = comments[:summaryMaxComments]
+	}
+
+	subject := fmt.Sprintf("%s/%s#%d", owner, repo, int(number))
+	return h.summarize(ctx, subject, issueThreadPrompt, issueThread(subject, issue, comments)), nil
+}
+
+// issueThread formats an issue and its comments as plain text
+func issueThread(subject string, issue *client.Issue, comments []client.IssueComment) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s (%s)\n", subject, issue.Title, issue.State)
+	fmt.Fprintf(&b, "Opened by @%s on %s\n", issue.User.Login, issue.CreatedAt)
+	if len(issue.Labels) > 0 {
+		labels := make([]string, len(issue.Labels))
+		for i, label := range issue.Labels {
+			labels[i] = label.Name
+		}
+		fmt.Fprintf(&b, "Labels: %s\n", strings.Join(labels, ", "))
+	}
+	if issue.Body != nil {
+		fmt.Fprintf(&b, "\n%s\n", *issue.Body)
+	}
+	for _, comment := range comments {
+		fmt.Fprintf(&b, "\n--- @%s on %s\n%s\n", comment.User.Login, comment.CreatedAt, comment.Body)
+	}
+	return b.String()
+}
+
+// executeSummarizePRDiff executes the summarize_pr_diff tool
+func (h *Handler) executeSummarizePRDiff(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, _ := args["owner"].(string)
+	repo, _ := args["repo"].(string)
+	number, _ := args["pull_number"].(float64)
+	if owner == "" || repo == "" || number < 1 {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error: owner, repo and pull_number parameters are required"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	pull, err := h.githubClient.GetPullRequest(ctx, owner, repo, int(number))
+	if err == nil {
+		var diff string
+		// Only the start of the diff can be summarized
+		diff, err = h.githubClient.GetPullRequestDiff(ctx, owner, repo, int(number), summaryMaxInput)
+		if err == nil {
+			subject := fmt.Sprintf("%s/%s#%d", owner, repo, int(number))
+			return h.summarize(ctx, subject, pullDiffPrompt, pullDiff(subject, pull, diff)), nil
+		}
+	}
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: h.messages.Sprintf("Error getting pull request %s/%s#%d: %v", owner, repo, int(number), err),
+		}},
+		IsError: true,
+	}, nil
+}
+
+// pullDiff formats a pull request and its diff as plain text
+func pullDiff(subject string, pull *client.PullRequest, diff string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s\n", subject, pull.Title)
+	fmt.Fprintf(&b, "%s into %s, %d files changed, +%d -%d\n", pull.Head.Label, pull.Base.Ref, pull.ChangedFiles, pull.Additions, pull.Deletions)
+	if pull.Body != nil && *pull.Body != "" {
+		fmt.Fprintf(&b, "\n%s\n", *pull.Body)
+	}
+	fmt.Fprintf(&b, "\n%s", diff)
+	return b.String()
+}
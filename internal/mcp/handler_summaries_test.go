@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestSummarizeIssueThread(t *testing.T) {
+	tests := []struct {
+		name     string
+		sampling bool
+		wantText string
+	}{
+		{name: "with sampling", sampling: true, wantText: "A summary"},
+		{name: "without sampling", wantText: "The client does not support sampling"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			githubClient := client.NewGitHubClient("test-token", createTestLogger())
+			githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if strings.HasSuffix(req.URL.Path, "/comments") {
+						return mocks.MockJSONResponse(200, `[{"user": {"login": "hubot"}, "body": "Same here"}]`), nil
+					}
+					return mocks.MockJSONResponse(200, `{"number": 7, "title": "Crash on start", "state": "open", "user": {"login": "octocat"}, "body": "It crashes"}`), nil
+				},
+			})
+			h := NewHandler(githubClient, createTestLogger())
+
+			session := NewSession(TransportStdio)
+			session.setInitialized()
+			if tt.sampling {
+				session.setCapabilities(ClientCapabilities{Sampling: map[string]interface{}{}})
+			}
+			ctx := WithSession(context.Background(), session)
+
+			var prompt string
+			session.setNotifier(func(msg *JSONRPCMessage) {
+				var req CreateMessageRequest
+				if msg.Method != MethodCreateMessage || msg.GetParams(&req) != nil {
+					return
+				}
+				prompt = req.Messages[0].Content.Text
+				data, _ := NewResponse(msg.ID, CreateMessageResult{Role: "assistant", Content: Content{Type: "text", Text: "A summary"}, Model: "test-model"}).ToJSON()
+				go h.HandleMessage(ctx, data)
+			})
+
+			ctx, meta := withResultMeta(ctx)
+			result, err := h.executeSummarizeIssueThread(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "issue_number": float64(7)})
+			if err != nil || result.IsError {
+				t.Fatalf("Unexpected error: %v %+v", err, result)
+			}
+			result = meta.apply(result)
+
+			if !strings.HasPrefix(result.Content[0].Text, tt.wantText) {
+				t.Errorf("Expected text starting with %q, got %q", tt.wantText, result.Content[0].Text)
+			}
+			thread := prompt
+			if !tt.sampling {
+				thread = result.Content[1].Text
+			}
+			for _, want := range []string{"Crash on start", "It crashes", "@hubot", "Same here"} {
+				if !strings.Contains(thread, want) {
+					t.Errorf("Expected the thread to contain %q, got %q", want, thread)
+				}
+			}
+			if tt.sampling && result.Meta[samplingMetaKey] == nil {
+				t.Errorf("Expected the sampling model in _meta, got %+v", result.Meta)
+			}
+		})
+	}
+}
+
+func TestSummarizePRDiff(t *testing.T) {
+	diff := "diff --git a/main.go b/main.go\n" + strings.Repeat("+line\n", summaryMaxInput)
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Accept") == client.DiffMediaType {
+				return mocks.MockResponse(200, diff, map[string]string{"Content-Type": client.DiffMediaType}), nil
+			}
+			return mocks.MockJSONResponse(200, `{"number": 9, "title": "Add logging", "head": {"label": "octocat:logging"}, "base": {"ref": "main"}}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	result, err := h.executeSummarizePRDiff(ctx, map[string]interface{}{"owner": "acme", "repo": "widgets", "pull_number": float64(9)})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %+v", err, result)
+	}
+	material := result.Content[1].Text
+	if !strings.Contains(material, "Add logging") || !strings.Contains(material, "diff --git a/main.go") {
+		t.Errorf("Expected the pull request and its diff, got %.200q", material)
+	}
+	if !strings.HasSuffix(material, "\n[truncated]") || len(material) > summaryMaxInput+len("\n[truncated]") {
+		t.Errorf("Expected the diff cut to %d bytes, got %d", summaryMaxInput, len(material))
+	}
+	if !readOnlyTool("summarize_pr_diff") || prefetchableTool("summarize_pr_diff") {
+		t.Error("Expected summarize_pr_diff to be read-only and not prefetched")
+	}
+}
+
+func TestTruncateSummaryInput(t *testing.T) {
+	material := strings.Repeat("é", summaryMaxInput)
+	truncated := truncateSummaryInput(material)
+	if !strings.HasSuffix(truncated, "\n[truncated]") || len(truncated) > summaryMaxInput+len("\n[truncated]") {
+		t.Errorf("Expected material cut to %d bytes, got %d", summaryMaxInput, len(truncated))
+	}
+	if !utf8.ValidString(truncated) {
+		t.Error("Expected the cut to fall on a character boundary")
+	}
+}
@@ -0,0 +1,1340 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+// teamTools returns the GitHub Teams tools
+func (h *Handler) teamTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "list_teams",
+			Description: "List teams in an organization",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page number of the results to fetch",
+						"minimum":     1,
+						"default":     1,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+				"required": []string{"org"},
+			},
+			Shaped: true,
+		}, h.executeListTeams),
+		NewTool(Tool{
+			Name:        "get_team",
+			Description: "Get a team by organization and team slug",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"team_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Team slug",
+					},
+				},
+				"required": []string{"org", "team_slug"},
+			},
+			Shaped: true,
+		}, h.executeGetTeam),
+		NewTool(Tool{
+			Name:        "create_team",
+			Description: "Create a new team in an organization",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "The name of the team",
+					},
+					"description": map[string]interface{}{
+						"type":        "string",
+						"description": "The description of the team",
+					},
+					"privacy": map[string]interface{}{
+						"type":        "string",
+						"description": "The level of privacy this team should have",
+						"enum":        []string{"secret", "closed"},
+						"default":     "secret",
+					},
+					"permission": map[string]interface{}{
+						"type":        "string",
+						"description": "The permission that new repositories will be added to the team with",
+						"enum":        []string{"pull", "push", "admin"},
+						"default":     "pull",
+					},
+					"parent_team_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "The ID of a team to set as the parent team",
+					},
+				},
+				"required": []string{"org", "name"},
+			},
+			Shaped: true,
+		}, h.executeCreateTeam),
+		NewTool(Tool{
+			Name:        "update_team",
+			Description: "Update a team",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"team_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Team slug",
+					},
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "The name of the team",
+					},
+					"description": map[string]interface{}{
+						"type":        "string",
+						"description": "The description of the team",
+					},
+					"privacy": map[string]interface{}{
+						"type":        "string",
+						"description": "The level of privacy this team should have",
+						"enum":        []string{"secret", "closed"},
+					},
+					"permission": map[string]interface{}{
+						"type":        "string",
+						"description": "The permission that new repositories will be added to the team with",
+						"enum":        []string{"pull", "push", "admin"},
+					},
+					"parent_team_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "The ID of a team to set as the parent team",
+					},
+				},
+				"required": []string{"org", "team_slug"},
+			},
+			Shaped: true,
+		}, h.executeUpdateTeam),
+		NewTool(Tool{
+			Name:        "delete_team",
+			Description: "Delete a team",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"team_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Team slug",
+					},
+				},
+				"required": []string{"org", "team_slug"},
+			},
+		}, h.executeDeleteTeam),
+		NewTool(Tool{
+			Name:        "list_team_members",
+			Description: "List members of a team",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"team_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Team slug",
+					},
+					"role": map[string]interface{}{
+						"type":        "string",
+						"description": "Filter members returned by their role",
+						"enum":        []string{"member", "maintainer", "all"},
+						"default":     "all",
+					},
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page number of the results to fetch",
+						"minimum":     1,
+						"default":     1,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+				"required": []string{"org", "team_slug"},
+			},
+			Shaped: true,
+		}, h.executeListTeamMembers),
+		NewTool(Tool{
+			Name:        "get_team_membership",
+			Description: "Get team membership for a user",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"team_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Team slug",
+					},
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username",
+					},
+				},
+				"required": []string{"org", "team_slug", "username"},
+			},
+			Shaped: true,
+		}, h.executeGetTeamMembership),
+		NewTool(Tool{
+			Name:        "add_team_membership",
+			Description: "Add or update team membership for a user",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"team_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Team slug",
+					},
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username",
+					},
+					"role": map[string]interface{}{
+						"type":        "string",
+						"description": "The role to give the user in the team",
+						"enum":        []string{"member", "maintainer"},
+						"default":     "member",
+					},
+				},
+				"required": []string{"org", "team_slug", "username"},
+			},
+			Shaped: true,
+		}, h.executeAddTeamMembership),
+		NewTool(Tool{
+			Name:        "remove_team_membership",
+			Description: "Remove a user from a team",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"team_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Team slug",
+					},
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username",
+					},
+				},
+				"required": []string{"org", "team_slug", "username"},
+			},
+		}, h.executeRemoveTeamMembership),
+		NewTool(Tool{
+			Name:        "list_team_repositories",
+			Description: "List repositories for a team",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"team_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Team slug",
+					},
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page number of the results to fetch",
+						"minimum":     1,
+						"default":     1,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+				"required": []string{"org", "team_slug"},
+			},
+			Shaped: true,
+		}, h.executeListTeamRepositories),
+		NewTool(Tool{
+			Name:        "check_team_repository",
+			Description: "Check if a team has access to a repository",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"team_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Team slug",
+					},
+					"owner": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository owner",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository name",
+					},
+				},
+				"required": []string{"org", "team_slug", "owner", "repo"},
+			},
+		}, h.executeCheckTeamRepository),
+		NewTool(Tool{
+			Name:        "add_team_repository",
+			Description: "Add a repository to a team",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"team_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Team slug",
+					},
+					"owner": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository owner",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository name",
+					},
+					"permission": map[string]interface{}{
+						"type":        "string",
+						"description": "The permission to grant the team on this repository",
+						"enum":        []string{"pull", "triage", "push", "maintain", "admin"},
+						"default":     "pull",
+					},
+				},
+				"required": []string{"org", "team_slug", "owner", "repo"},
+			},
+		}, h.executeAddTeamRepository),
+		NewTool(Tool{
+			Name:        "remove_team_repository",
+			Description: "Remove a repository from a team",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"org": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization name",
+					},
+					"team_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Team slug",
+					},
+					"owner": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository owner",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository name",
+					},
+				},
+				"required": []string{"org", "team_slug", "owner", "repo"},
+			},
+		}, h.executeRemoveTeamRepository),
+	}
+}
+
+// executeListTeams executes the list_teams tool
+func (h *Handler) executeListTeams(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+	fetchAll, perPage := fetchAllArguments(args, perPage)
+
+	// Make GitHub API request using the client function
+	teams, pageInfo, err := fetchPages(ctx, h, fetchAll, page, func(ctx context.Context, page int) ([]client.Team, *client.PageInfo, error) {
+		return h.githubClient.ListTeams(ctx, org, page, perPage)
+	})
+	var denied error
+	if isPermissionDenied(err) {
+		denied = err
+		teams, err = h.userTeamsInOrg(ctx, org)
+		pageInfo = nil
+	}
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing teams for organization %s: %v", org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	teamsJSON, err := shapeJSON(args, h.newListEnvelope(args, teams, pageInfo, listPage{page: page, perPage: perPage}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting teams data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(teamsJSON),
+		},
+	}
+
+	result := &CallToolResult{
+		Content: content,
+		IsError: false,
+	}
+	if denied != nil {
+		return h.markDegraded(result, "user_teams", h.messages.Sprintf("only the teams you belong to"), denied), nil
+	}
+	return result, nil
+}
+
+// executeGetTeam executes the get_team tool
+func (h *Handler) executeGetTeam(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	teamSlug, ok := args["team_slug"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("team_slug is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the client function
+	team, err := h.githubClient.GetTeam(ctx, org, teamSlug)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting team %s in organization %s: %v", teamSlug, org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	teamJSON, err := shapeJSON(args, team)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting team data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(teamJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeCreateTeam executes the create_team tool
+func (h *Handler) executeCreateTeam(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	name, ok := args["name"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("name is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Build team data from args
+	teamData := map[string]interface{}{
+		"name": name,
+	}
+
+	// Add optional fields
+	if description, ok := args["description"].(string); ok {
+		teamData["description"] = description
+	}
+	if privacy, ok := args["privacy"].(string); ok {
+		teamData["privacy"] = privacy
+	}
+	if permission, ok := args["permission"].(string); ok {
+		teamData["permission"] = permission
+	}
+	if parentTeamID, ok := args["parent_team_id"].(float64); ok {
+		teamData["parent_team_id"] = int(parentTeamID)
+	}
+
+	// Make GitHub API request using the client function
+	team, err := h.githubClient.CreateTeam(ctx, org, teamData)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error creating team %s in organization %s: %v", name, org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	teamJSON, err := shapeJSON(args, team)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting team data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(teamJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeUpdateTeam executes the update_team tool
+func (h *Handler) executeUpdateTeam(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	teamSlug, ok := args["team_slug"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("team_slug is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Build updates map from args
+	updates := make(map[string]interface{})
+
+	// Copy valid fields from args to updates
+	validFields := []string{"name", "description", "privacy", "permission", "parent_team_id"}
+	for _, field := range validFields {
+		if value, exists := args[field]; exists {
+			if field == "parent_team_id" {
+				if parentTeamID, ok := value.(float64); ok {
+					updates[field] = int(parentTeamID)
+				}
+			} else {
+				updates[field] = value
+			}
+		}
+	}
+
+	if len(updates) == 0 {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("No valid fields provided for update"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the client function
+	team, err := h.githubClient.UpdateTeam(ctx, org, teamSlug, updates)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error updating team %s in organization %s: %v", teamSlug, org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	teamJSON, err := shapeJSON(args, team)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting team data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(teamJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeDeleteTeam executes the delete_team tool
+func (h *Handler) executeDeleteTeam(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	teamSlug, ok := args["team_slug"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("team_slug is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	record, errResult := h.captureDeletion(ctx, "delete_team", fmt.Sprintf("team %s/%s", org, teamSlug), args, func() (interface{}, error) {
+		return h.snapshotTeam(ctx, org, teamSlug)
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Make GitHub API request using the client function
+	err := h.githubClient.DeleteTeam(ctx, org, teamSlug)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error deleting team %s in organization %s: %v", teamSlug, org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	h.recordDeletion(ctx, record)
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: h.messages.Sprintf("Successfully deleted team %s in organization %s", teamSlug, org),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeListTeamMembers executes the list_team_members tool
+func (h *Handler) executeListTeamMembers(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	teamSlug, ok := args["team_slug"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("team_slug is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var role string
+	var page, perPage int
+	if r, ok := args["role"].(string); ok {
+		role = r
+	}
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+	fetchAll, perPage := fetchAllArguments(args, perPage)
+
+	// Make GitHub API request using the client function
+	members, pageInfo, err := fetchPages(ctx, h, fetchAll, page, func(ctx context.Context, page int) ([]client.TeamMember, *client.PageInfo, error) {
+		return h.githubClient.ListTeamMembers(ctx, org, teamSlug, role, page, perPage)
+	})
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing members for team %s in organization %s: %v", teamSlug, org, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	membersJSON, err := shapeJSON(args, h.newListEnvelope(args, members, pageInfo, listPage{page: page, perPage: perPage}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting members data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(membersJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeGetTeamMembership executes the get_team_membership tool
+func (h *Handler) executeGetTeamMembership(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	teamSlug, ok := args["team_slug"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("team_slug is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the client function
+	membership, err := h.githubClient.GetTeamMembership(ctx, org, teamSlug, username)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting team membership for %s in team %s/%s: %v", username, org, teamSlug, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	membershipJSON, err := shapeJSON(args, membership)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting membership data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(membershipJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeAddTeamMembership executes the add_team_membership tool
+func (h *Handler) executeAddTeamMembership(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	teamSlug, ok := args["team_slug"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("team_slug is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var role string
+	if r, ok := args["role"].(string); ok {
+		role = r
+	}
+
+	// Make GitHub API request using the client function
+	membership, err := h.githubClient.AddTeamMembership(ctx, org, teamSlug, username, role)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error adding %s to team %s/%s: %v", username, org, teamSlug, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	membershipJSON, err := shapeJSON(args, membership)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting membership data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(membershipJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeRemoveTeamMembership executes the remove_team_membership tool
+func (h *Handler) executeRemoveTeamMembership(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	teamSlug, ok := args["team_slug"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("team_slug is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	record, errResult := h.captureDeletion(ctx, "remove_team_membership", fmt.Sprintf("membership of %s in team %s/%s", username, org, teamSlug), args, func() (interface{}, error) {
+		return h.snapshotTeamMembership(ctx, org, teamSlug, username)
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Make GitHub API request using the client function
+	err := h.githubClient.RemoveTeamMembership(ctx, org, teamSlug, username)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error removing %s from team %s/%s: %v", username, org, teamSlug, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	h.recordDeletion(ctx, record)
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: h.messages.Sprintf("Successfully removed %s from team %s/%s", username, org, teamSlug),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeListTeamRepositories executes the list_team_repositories tool
+func (h *Handler) executeListTeamRepositories(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	teamSlug, ok := args["team_slug"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("team_slug is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	// Make GitHub API request using the client function
+	repositories, pageInfo, err := h.githubClient.ListTeamRepositories(ctx, org, teamSlug, page, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing repositories for team %s/%s: %v", org, teamSlug, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	repositoriesJSON, err := shapeJSON(args, h.newListEnvelope(args, repositories, pageInfo, listPage{page: page, perPage: perPage}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting repositories data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(repositoriesJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeCheckTeamRepository executes the check_team_repository tool
+func (h *Handler) executeCheckTeamRepository(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	teamSlug, ok := args["team_slug"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("team_slug is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	owner, ok := args["owner"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("owner is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	repo, ok := args["repo"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("repo is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the client function
+	hasAccess, err := h.githubClient.CheckTeamRepository(ctx, org, teamSlug, owner, repo)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error checking team repository access for %s/%s to %s/%s: %v", org, teamSlug, owner, repo, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	status := h.messages.Sprintf("no access")
+	if hasAccess {
+		status = h.messages.Sprintf("has access")
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: h.messages.Sprintf("Team %s/%s repository access to %s/%s: %s", org, teamSlug, owner, repo, status),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeAddTeamRepository executes the add_team_repository tool
+func (h *Handler) executeAddTeamRepository(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	teamSlug, ok := args["team_slug"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("team_slug is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	owner, ok := args["owner"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("owner is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	repo, ok := args["repo"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("repo is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var permission string
+	if p, ok := args["permission"].(string); ok {
+		permission = p
+	}
+
+	// Make GitHub API request using the client function
+	err := h.githubClient.AddTeamRepository(ctx, org, teamSlug, owner, repo, permission)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error adding repository %s/%s to team %s/%s: %v", owner, repo, org, teamSlug, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: h.messages.Sprintf("Successfully added repository %s/%s to team %s/%s with permission: %s", owner, repo, org, teamSlug, permission),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeRemoveTeamRepository executes the remove_team_repository tool
+func (h *Handler) executeRemoveTeamRepository(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	org, ok := args["org"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("org is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	teamSlug, ok := args["team_slug"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("team_slug is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	owner, ok := args["owner"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("owner is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	repo, ok := args["repo"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("repo is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	record, errResult := h.captureDeletion(ctx, "remove_team_repository", fmt.Sprintf("access of team %s/%s to repository %s/%s", org, teamSlug, owner, repo), args, func() (interface{}, error) {
+		return h.snapshotTeamRepository(ctx, org, teamSlug, owner, repo)
+	})
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Make GitHub API request using the client function
+	err := h.githubClient.RemoveTeamRepository(ctx, org, teamSlug, owner, repo)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error removing repository %s/%s from team %s/%s: %v", owner, repo, org, teamSlug, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	h.recordDeletion(ctx, record)
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: h.messages.Sprintf("Successfully removed repository %s/%s from team %s/%s", owner, repo, org, teamSlug),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
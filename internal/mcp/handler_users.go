@@ -0,0 +1,759 @@
+package mcp
+
+import (
+	"context"
+)
+
+// avatarSize is the width and height in pixels of avatars attached to results
+const avatarSize = 128
+
+// userTools returns the GitHub Users tools
+func (h *Handler) userTools() []ToolProvider {
+	return []ToolProvider{
+		NewTool(Tool{
+			Name:        "get_user",
+			Description: "Get information about a GitHub user by username",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username",
+					},
+					"include_avatar": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Attach the user's avatar as an image (default: false)",
+					},
+				},
+				"required": []string{"username"},
+			},
+			Shaped: true,
+		}, h.executeGetUser),
+		NewTool(Tool{
+			Name:        "get_authenticated_user",
+			Description: "Get information about the authenticated user",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+			Shaped: true,
+		}, h.executeGetAuthenticatedUser),
+		NewTool(Tool{
+			Name:        "update_authenticated_user",
+			Description: "Update the authenticated user's profile",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "The new name of the user",
+					},
+					"email": map[string]interface{}{
+						"type":        "string",
+						"description": "The publicly visible email address of the user",
+					},
+					"blog": map[string]interface{}{
+						"type":        "string",
+						"description": "The new blog URL of the user",
+					},
+					"company": map[string]interface{}{
+						"type":        "string",
+						"description": "The new company of the user",
+					},
+					"location": map[string]interface{}{
+						"type":        "string",
+						"description": "The new location of the user",
+					},
+					"hireable": map[string]interface{}{
+						"type":        "boolean",
+						"description": "The new hiring availability of the user",
+					},
+					"bio": map[string]interface{}{
+						"type":        "string",
+						"description": "The new short biography of the user",
+					},
+					"twitter_username": map[string]interface{}{
+						"type":        "string",
+						"description": "The new Twitter username of the user",
+					},
+				},
+			},
+			Shaped: true,
+		}, h.executeUpdateAuthenticatedUser),
+		NewTool(Tool{
+			Name:        "list_users",
+			Description: "List all GitHub users",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"since": map[string]interface{}{
+						"type":        "integer",
+						"description": "A user ID. Only return users with an ID greater than this ID",
+						"minimum":     0,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+			},
+			Shaped: true,
+		}, h.executeListUsers),
+		NewTool(Tool{
+			Name:        "list_user_followers",
+			Description: "List followers of a user",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username",
+					},
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page number of the results to fetch",
+						"minimum":     1,
+						"default":     1,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+				"required": []string{"username"},
+			},
+			Shaped: true,
+		}, h.executeListUserFollowers),
+		NewTool(Tool{
+			Name:        "list_user_following",
+			Description: "List users followed by a user",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username",
+					},
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page number of the results to fetch",
+						"minimum":     1,
+						"default":     1,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+				"required": []string{"username"},
+			},
+			Shaped: true,
+		}, h.executeListUserFollowing),
+		NewTool(Tool{
+			Name:        "check_user_following",
+			Description: "Check if the authenticated user follows another user",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username to check",
+					},
+				},
+				"required": []string{"username"},
+			},
+		}, h.executeCheckUserFollowing),
+		NewTool(Tool{
+			Name:        "follow_user",
+			Description: "Follow a user",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username to follow",
+					},
+				},
+				"required": []string{"username"},
+			},
+		}, h.executeFollowUser),
+		NewTool(Tool{
+			Name:        "unfollow_user",
+			Description: "Unfollow a user",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"username": map[string]interface{}{
+						"type":        "string",
+						"description": "GitHub username to unfollow",
+					},
+				},
+				"required": []string{"username"},
+			},
+		}, h.executeUnfollowUser),
+		NewTool(Tool{
+			Name:        "list_repositories",
+			Description: "List repositories for a user or organization",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"owner": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository owner (username or organization)",
+					},
+					"type": map[string]interface{}{
+						"type":        "string",
+						"description": "Repository type (all, owner, member)",
+						"enum":        []string{"all", "owner", "member"},
+						"default":     "owner",
+					},
+					"sort": map[string]interface{}{
+						"type":        "string",
+						"description": "Property to sort the repositories by",
+						"enum":        []string{"created", "updated", "pushed", "full_name"},
+						"default":     "full_name",
+					},
+					"direction": map[string]interface{}{
+						"type":        "string",
+						"description": "Sort direction (default: asc when sorting by full_name, otherwise desc)",
+						"enum":        []string{"asc", "desc"},
+					},
+					"page": map[string]interface{}{
+						"type":        "integer",
+						"description": "Page number of the results to fetch",
+						"minimum":     1,
+						"default":     1,
+					},
+					"per_page": map[string]interface{}{
+						"type":        "integer",
+						"description": "The number of results per page (max 100)",
+						"minimum":     1,
+						"maximum":     100,
+						"default":     30,
+					},
+				},
+				"required": []string{"owner"},
+			},
+			Shaped: true,
+		}, h.executeListRepositories),
+	}
+}
+
+// executeGetUser executes the get_user tool
+func (h *Handler) executeGetUser(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the new client function
+	user, err := h.githubClient.GetUser(ctx, username)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting user %s: %v", username, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	userJSON, err := shapeJSON(args, user)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting user data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(userJSON),
+		},
+	}
+
+	// A missing avatar does not fail the call, the user data is still useful
+	if includeAvatar, _ := args["include_avatar"].(bool); includeAvatar {
+		image, mimeType, err := h.githubClient.GetAvatar(ctx, user.AvatarURL, avatarSize)
+		if err != nil {
+			h.logger.WithContext(ctx).Warn("Failed to get avatar", "username", username, "error", err)
+			content = append(content, Content{Type: "text", Text: h.messages.Sprintf("Avatar unavailable: %v", err)})
+		} else {
+			content = append(content, imageContent(image, mimeType))
+		}
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeGetAuthenticatedUser executes the get_authenticated_user tool
+func (h *Handler) executeGetAuthenticatedUser(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	// Make GitHub API request using the new client function
+	user, err := h.githubClient.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error getting authenticated user: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	userJSON, err := shapeJSON(args, user)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting user data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(userJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeUpdateAuthenticatedUser executes the update_authenticated_user tool
+func (h *Handler) executeUpdateAuthenticatedUser(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	// Build updates map from args
+	updates := make(map[string]interface{})
+
+	// Copy valid fields from args to updates
+	validFields := []string{"name", "email", "blog", "company", "location", "hireable", "bio", "twitter_username"}
+	for _, field := range validFields {
+		if value, exists := args[field]; exists {
+			updates[field] = value
+		}
+	}
+
+	if len(updates) == 0 {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("No valid fields provided for update"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the new client function
+	user, err := h.githubClient.UpdateAuthenticatedUser(ctx, updates)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error updating authenticated user: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	userJSON, err := shapeJSON(args, user)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting user data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(userJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeListUsers executes the list_users tool
+func (h *Handler) executeListUsers(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	var since int64
+	var perPage int
+
+	if s, ok := args["since"].(float64); ok {
+		since = int64(s)
+	}
+	if p, ok := args["per_page"].(float64); ok {
+		perPage = int(p)
+	}
+
+	// Make GitHub API request using the new client function
+	users, pageInfo, err := h.githubClient.ListUsers(ctx, since, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing users: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	usersJSON, err := shapeJSON(args, h.newListEnvelope(args, users, pageInfo, listPage{perPage: perPage, since: true}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting users data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(usersJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeListUserFollowers executes the list_user_followers tool
+func (h *Handler) executeListUserFollowers(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	// Make GitHub API request using the new client function
+	followers, pageInfo, err := h.githubClient.ListUserFollowers(ctx, username, page, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing followers for %s: %v", username, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	followersJSON, err := shapeJSON(args, h.newListEnvelope(args, followers, pageInfo, listPage{page: page, perPage: perPage}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting followers data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(followersJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeListUserFollowing executes the list_user_following tool
+func (h *Handler) executeListUserFollowing(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	// Make GitHub API request using the new client function
+	following, pageInfo, err := h.githubClient.ListUserFollowing(ctx, username, page, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing following for %s: %v", username, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	followingJSON, err := shapeJSON(args, h.newListEnvelope(args, following, pageInfo, listPage{page: page, perPage: perPage}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting following data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(followingJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeCheckUserFollowing executes the check_user_following tool
+func (h *Handler) executeCheckUserFollowing(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the new client function
+	isFollowing, err := h.githubClient.CheckUserFollowing(ctx, username)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error checking if following %s: %v", username, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	status := h.messages.Sprintf("not following")
+	if isFollowing {
+		status = h.messages.Sprintf("following")
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: h.messages.Sprintf("Following status for %s: %s", username, status),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeFollowUser executes the follow_user tool
+func (h *Handler) executeFollowUser(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the new client function
+	err := h.githubClient.FollowUser(ctx, username)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error following %s: %v", username, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: h.messages.Sprintf("Successfully followed %s", username),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeUnfollowUser executes the unfollow_user tool
+func (h *Handler) executeUnfollowUser(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	username, ok := args["username"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("username is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Make GitHub API request using the new client function
+	err := h.githubClient.UnfollowUser(ctx, username)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error unfollowing %s: %v", username, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	content := []Content{
+		{
+			Type: "text",
+			Text: h.messages.Sprintf("Successfully unfollowed %s", username),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
+
+// executeListRepositories executes the list_repositories tool
+func (h *Handler) executeListRepositories(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	owner, ok := args["owner"].(string)
+	if !ok {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("owner is required and must be a string"),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	repoType := "owner"
+	if t, ok := args["type"].(string); ok {
+		repoType = t
+	}
+	sort, _ := args["sort"].(string)
+	direction, _ := args["direction"].(string)
+
+	var page, perPage int
+	if p, ok := args["page"].(float64); ok {
+		page = int(p)
+	}
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	// Make GitHub API request using the client function
+	repos, pageInfo, err := h.githubClient.ListRepositories(ctx, owner, repoType, sort, direction, page, perPage)
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error listing repositories for %s: %v", owner, err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response as JSON
+	reposJSON, err := shapeJSON(args, h.newListEnvelope(args, repos, pageInfo, listPage{page: page, perPage: perPage}))
+	if err != nil {
+		return &CallToolResult{
+			Content: []Content{{
+				Type: "text",
+				Text: h.messages.Sprintf("Error formatting repositories data: %v", err),
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Format response
+	content := []Content{
+		{
+			Type: "text",
+			Text: string(reposJSON),
+		},
+	}
+
+	return &CallToolResult{
+		Content: content,
+		IsError: false,
+	}, nil
+}
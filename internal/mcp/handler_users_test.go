@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestExecuteGetUser_Avatar(t *testing.T) {
+	avatar := "\x89PNG\r\n\x1a\n"
+	tests := []struct {
+		name       string
+		avatarURL  string
+		args       map[string]interface{}
+		wantImage  bool
+		wantBlocks int
+	}{
+		{name: "without avatar", avatarURL: "https://avatars.githubusercontent.com/u/1?v=4", args: map[string]interface{}{"username": "octocat"}, wantBlocks: 1},
+		{name: "with avatar", avatarURL: "https://avatars.githubusercontent.com/u/1?v=4", args: map[string]interface{}{"username": "octocat", "include_avatar": true}, wantImage: true, wantBlocks: 2},
+		{name: "avatar on another host", avatarURL: "https://example.com/u/1", args: map[string]interface{}{"username": "octocat", "include_avatar": true}, wantBlocks: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			githubClient := client.NewGitHubClient("test-token", createTestLogger())
+			githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if req.URL.Host == "avatars.githubusercontent.com" {
+						return mocks.MockResponse(200, avatar, map[string]string{"Content-Type": "image/png"}), nil
+					}
+					return mocks.MockJSONResponse(200, `{"login": "octocat", "avatar_url": "`+tt.avatarURL+`"}`), nil
+				},
+			})
+			h := NewHandler(githubClient, createTestLogger())
+
+			result, err := h.executeGetUser(context.Background(), tt.args)
+			if err != nil || result.IsError {
+				t.Fatalf("Unexpected error: %v %+v", err, result)
+			}
+			if len(result.Content) != tt.wantBlocks {
+				t.Fatalf("Expected %d content blocks, got %+v", tt.wantBlocks, result.Content)
+			}
+			last := result.Content[len(result.Content)-1]
+			if tt.wantImage != (last.Type == "image") {
+				t.Fatalf("Expected image %v, got %+v", tt.wantImage, last)
+			}
+			if tt.wantImage && (last.MimeType != "image/png" || last.Data != base64.StdEncoding.EncodeToString([]byte(avatar))) {
+				t.Errorf("Unexpected image content %+v", last)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// SessionIDHeader carries the ID of an HTTP client's session. It is returned
+// with the initialize response, and clients send it with every later request.
+const SessionIDHeader = "Mcp-Session-Id"
+
+const (
+	// defaultSessionIdleTimeout is how long an HTTP session is kept without requests
+	defaultSessionIdleTimeout = time.Hour
+	// defaultMaxSessions bounds the number of open HTTP sessions
+	defaultMaxSessions = 10000
+	// sessionExpiryInterval is the longest time between sweeps of idle
+	// sessions
+	sessionExpiryInterval = time.Minute
+)
+
+var (
+	// ErrSessionNotFound is returned for a session ID naming no session,
+	// because it expired or was closed
+	ErrSessionNotFound = errors.New("session not found")
+	// ErrTooManySessions is returned when an initialize request would open
+	// more sessions than allowed
+	ErrTooManySessions = errors.New("too many sessions")
+)
+
+// httpSessions holds the sessions of HTTP clients by ID
+type httpSessions struct {
+	mu       sync.Mutex
+	sessions map[string]*httpSessionEntry
+	// legacy holds the sessions of requests without Mcp-Session-Id, sent
+	// by older clients, by stream owner
+	legacy map[string]*httpSessionEntry
+	// idleTimeout is how long a session is kept without requests; zero
+	// keeps sessions until they are closed
+	idleTimeout time.Duration
+	// maxSessions bounds the number of open sessions, and separately of
+	// legacy sessions; zero is unbounded
+	maxSessions int
+
+	// stop and done end the expiry loop, once started
+	stop chan struct{}
+	done chan struct{}
+}
+
+// httpSessionEntry is an HTTP client's session and when it was last used
+type httpSessionEntry struct {
+	session  *Session
+	lastSeen time.Time
+}
+
+// SetSessionIdleTimeout sets how long an HTTP session is kept without
+// requests; zero keeps sessions until the client closes them. It must be
+// called before StartSessionExpiry.
+func (h *Handler) SetSessionIdleTimeout(timeout time.Duration) {
+	h.httpSessions.mu.Lock()
+	defer h.httpSessions.mu.Unlock()
+	h.httpSessions.idleTimeout = timeout
+}
+
+// SetMaxSessions bounds the number of open HTTP sessions; initialize
+// requests beyond it are rejected until sessions end. The legacy sessions
+// of older clients, one per owner, are bounded by it separately. Zero is
+// unbounded.
+func (h *Handler) SetMaxSessions(max int) {
+	h.httpSessions.mu.Lock()
+	defer h.httpSessions.mu.Unlock()
+	h.httpSessions.maxSessions = max
+}
+
+// StartSessionExpiry ends idle HTTP sessions in the background, checking
+// them at a fraction of the idle timeout
+func (h *Handler) StartSessionExpiry() {
+	s := &h.httpSessions
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.idleTimeout <= 0 || s.stop != nil {
+		return
+	}
+
+	interval := min(s.idleTimeout/2, sessionExpiryInterval)
+	s.stop, s.done = make(chan struct{}), make(chan struct{})
+	go func(stop, done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				h.expireSessions()
+			case <-stop:
+				return
+			}
+		}
+	}(s.stop, s.done)
+}
+
+// StopSessionExpiry stops ending idle HTTP sessions
+func (h *Handler) StopSessionExpiry() {
+	s := &h.httpSessions
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+// HTTPSession returns the session of an HTTP message from owner: the
+// session named by sessionID, or a new session when the message is an
+// initialize request without one. Other messages without a session ID are
+// handled in the owner's legacy session, kept for older clients. It fails
+// with ErrSessionNotFound when sessionID names no session of owner, because
+// it expired, was closed or was started by another owner, and with
+// ErrTooManySessions when no more may be opened.
+func (h *Handler) HTTPSession(sessionID, owner string, body []byte) (*Session, error) {
+	s := &h.httpSessions
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sessionID != "" {
+		entry, exists := s.sessions[sessionID]
+		// Another owner's session is not revealed to exist
+		if !exists || entry.session.owner != owner {
+			return nil, ErrSessionNotFound
+		}
+		// Sessions are swept periodically, so one may have just expired
+		if s.expired(entry) {
+			delete(s.sessions, sessionID)
+			h.closeSession(entry.session)
+			return nil, ErrSessionNotFound
+		}
+		entry.lastSeen = time.Now()
+		return entry.session, nil
+	}
+
+	var msg struct {
+		Method string `json:"method"`
+	}
+	initialize := json.Unmarshal(body, &msg) == nil && msg.Method == MethodInitialize
+
+	legacy, exists := s.legacy[owner]
+	if exists && s.expired(legacy) {
+		delete(s.legacy, owner)
+		h.closeSession(legacy.session)
+		exists = false
+	}
+	if s.maxSessions > 0 && (initialize && len(s.sessions) >= s.maxSessions || !exists && len(s.legacy) >= s.maxSessions) {
+		return nil, ErrTooManySessions
+	}
+
+	if !exists {
+		session := NewSession(TransportHTTP)
+		session.owner = owner
+		legacy = &httpSessionEntry{session: session}
+		if s.legacy == nil {
+			s.legacy = make(map[string]*httpSessionEntry)
+		}
+		s.legacy[owner] = legacy
+	}
+	legacy.lastSeen = time.Now()
+	if !initialize {
+		return legacy.session, nil
+	}
+
+	session := NewSession(TransportHTTP)
+	session.id = newClientID()
+	session.owner = owner
+	session.legacy = legacy.session
+	if s.sessions == nil {
+		s.sessions = make(map[string]*httpSessionEntry)
+	}
+	s.sessions[session.id] = &httpSessionEntry{session: session, lastSeen: time.Now()}
+	return session, nil
+}
+
+// CloseSession ends the HTTP session with the given ID, reporting whether
+// owner had one
+func (h *Handler) CloseSession(sessionID, owner string) bool {
+	s := &h.httpSessions
+	s.mu.Lock()
+	entry, exists := s.sessions[sessionID]
+	exists = exists && entry.session.owner == owner
+	if exists {
+		delete(s.sessions, sessionID)
+	}
+	s.mu.Unlock()
+
+	if exists {
+		h.closeSession(entry.session)
+	}
+	return exists
+}
+
+// SessionCount returns the number of open HTTP sessions
+func (h *Handler) SessionCount() int {
+	h.httpSessions.mu.Lock()
+	defer h.httpSessions.mu.Unlock()
+	return len(h.httpSessions.sessions)
+}
+
+// expireSessions ends the HTTP sessions idle for longer than the idle timeout
+func (h *Handler) expireSessions() {
+	s := &h.httpSessions
+	s.mu.Lock()
+	var expired []*Session
+	for id, entry := range s.sessions {
+		if s.expired(entry) {
+			expired = append(expired, entry.session)
+			delete(s.sessions, id)
+		}
+	}
+	for owner, entry := range s.legacy {
+		if s.expired(entry) {
+			expired = append(expired, entry.session)
+			delete(s.legacy, owner)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, session := range expired {
+		h.logger.Info("HTTP session expired", "session", session.ID())
+		h.closeSession(session)
+	}
+}
+
+// expired reports whether entry was idle for longer than the idle timeout
+func (s *httpSessions) expired(entry *httpSessionEntry) bool {
+	return s.idleTimeout > 0 && time.Since(entry.lastSeen) > s.idleTimeout
+}
+
+// closeSession releases the state an ended session holds
+func (h *Handler) closeSession(session *Session) {
+	h.unsubscribeSession(session)
+	h.removeLiveSession(session)
+}
@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHTTPSession(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	initialize := []byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+
+	legacy, err := h.HTTPSession("", "alice", []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	if err != nil || legacy == nil || legacy.ID() != "" {
+		t.Fatalf("Expected a request without a session ID to use the owner's legacy session, got %+v (%v)", legacy, err)
+	}
+	if other, _ := h.HTTPSession("", "bob", nil); other == legacy {
+		t.Error("Expected another owner not to share the legacy session")
+	}
+
+	session, err := h.HTTPSession("", "alice", initialize)
+	if err != nil || session == nil || session.ID() == "" || session.Transport() != TransportHTTP {
+		t.Fatalf("Expected initialize to start an HTTP session, got %+v (%v)", session, err)
+	}
+	if found, err := h.HTTPSession(session.ID(), "alice", nil); err != nil || found != session {
+		t.Errorf("Expected the session by its ID, got %v (%v)", found, err)
+	}
+	if _, err := h.HTTPSession("unknown", "alice", nil); err != ErrSessionNotFound {
+		t.Errorf("Expected an unknown session ID to be rejected, got %v", err)
+	}
+	if _, err := h.HTTPSession(session.ID(), "bob", nil); err != ErrSessionNotFound {
+		t.Errorf("Expected another owner's session to be rejected, got %v", err)
+	}
+	if h.CloseSession(session.ID(), "bob") || h.SessionCount() != 1 {
+		t.Error("Expected another owner not to end the session")
+	}
+
+	// An idle session is rejected even before it is swept
+	h.SetSessionIdleTimeout(time.Hour)
+	h.httpSessions.sessions[session.ID()].lastSeen = time.Now().Add(-2 * time.Hour)
+	if _, err := h.HTTPSession(session.ID(), "alice", nil); err != ErrSessionNotFound || h.SessionCount() != 0 {
+		t.Errorf("Expected an idle session to expire, got %v", err)
+	}
+}
+
+func TestHTTPSession_Max(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.SetMaxSessions(1)
+	initialize := []byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+
+	session, err := h.HTTPSession("", "alice", initialize)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := h.HTTPSession("", "alice", initialize); err != ErrTooManySessions {
+		t.Errorf("Expected a session beyond the limit to be rejected, got %v", err)
+	}
+	h.CloseSession(session.ID(), "alice")
+	if _, err := h.HTTPSession("", "alice", initialize); err != nil {
+		t.Errorf("Expected a session once another ended, got %v", err)
+	}
+}
+
+func TestSessionExpiry(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.SetSessionIdleTimeout(time.Hour)
+	session, _ := h.HTTPSession("", "alice", []byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`))
+	h.httpSessions.sessions[session.ID()].lastSeen = time.Now().Add(-2 * time.Hour)
+
+	h.StartSessionExpiry()
+	h.StopSessionExpiry()
+	h.expireSessions()
+	if h.SessionCount() != 0 {
+		t.Error("Expected the idle session to be swept")
+	}
+}
+
+func TestHandleInitialized_WithoutInitialize(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	initialized := []byte(`{"jsonrpc":"2.0","method":"initialized"}`)
+
+	// The shared session of older clients is not unlocked by initialized alone
+	h.HandleMessage(context.Background(), initialized)
+	if h.httpSession.Initialized() {
+		t.Fatal("Expected initialized without initialize to be ignored")
+	}
+
+	initialize := []byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+	session, _ := h.HTTPSession("", "alice", initialize)
+	h.HandleMessage(WithSession(context.Background(), session), initialize)
+	h.HandleMessage(WithSession(context.Background(), session), initialized)
+	if !session.Initialized() {
+		t.Error("Expected initialized to complete the handshake after initialize")
+	}
+
+	// Older clients send initialized in their owner's legacy session, which
+	// another owner's initialize does not unlock
+	other, _ := h.HTTPSession("", "bob", nil)
+	h.HandleMessage(WithSession(context.Background(), other), initialized)
+	if other.Initialized() {
+		t.Error("Expected another owner's initialize not to unlock the legacy session")
+	}
+	legacy, _ := h.HTTPSession("", "alice", nil)
+	h.HandleMessage(WithSession(context.Background(), legacy), initialized)
+	if !legacy.Initialized() {
+		t.Error("Expected the owner's initialize to unlock its legacy session")
+	}
+}
@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/version"
+)
+
+// defaultInstructions are the instructions sent to clients unless configured
+const defaultInstructions = "GitHub MCP Server - Provides access to GitHub API through MCP protocol"
+
+// InstructionsData is what a server instructions template can refer to
+type InstructionsData struct {
+	// ServerName is the name the server reports to clients
+	ServerName string
+	// Version is the server version
+	Version string
+	// Toolsets are the names of the enabled toolsets
+	Toolsets []string
+	// DefaultOrg is the configured default organization
+	DefaultOrg string
+	// DefaultRepo is the configured default repository, as owner/repo
+	DefaultRepo string
+}
+
+// SetServerInfo sets the name the server reports to clients and the
+// instructions sent with the initialize result, a text/template executed
+// with InstructionsData. Empty values keep the defaults. It fails when the
+// template does not parse.
+func (h *Handler) SetServerInfo(name, instructions string) error {
+	if name != "" {
+		h.serverName = name
+	}
+	if instructions == "" {
+		return nil
+	}
+	tmpl, err := template.New("instructions").Option("missingkey=error").Parse(instructions)
+	if err != nil {
+		return fmt.Errorf("invalid server instructions template: %w", err)
+	}
+	h.instructions = tmpl
+	return nil
+}
+
+// SetDefaultContext sets the organization and repository, as owner/repo,
+// whose owner, org and repo fill in the arguments tool calls lack when the
+// client declares no roots
+func (h *Handler) SetDefaultContext(org, repo string) {
+	h.defaultOrg, h.defaultRepo = org, repo
+	h.defaultRoots = nil
+	if owner, name := parseRepositoryReference(repo); owner != "" && name != "" {
+		h.defaultRoots = append(h.defaultRoots, rootRepository{owner: owner, repo: name})
+	}
+	if org != "" {
+		h.defaultRoots = append(h.defaultRoots, rootRepository{owner: org})
+	}
+}
+
+// contextRoots returns the roots tool call arguments are filled in from: the
+// session's roots, or else the configured defaults
+func (h *Handler) contextRoots(session *Session) []rootRepository {
+	if roots := session.rootRepositories(); len(roots) > 0 {
+		return roots
+	}
+	return h.defaultRoots
+}
+
+// renderInstructions returns the server instructions, falling back to the
+// default when the template fails
+func (h *Handler) renderInstructions() string {
+	if h.instructions == nil {
+		return defaultInstructions + h.defaultContextInstructions()
+	}
+
+	data := InstructionsData{
+		ServerName:  h.serverName,
+		Version:     version.Version,
+		DefaultOrg:  h.defaultOrg,
+		DefaultRepo: h.defaultRepo,
+	}
+	for _, set := range h.Toolsets() {
+		if set.Enabled {
+			data.Toolsets = append(data.Toolsets, set.Name)
+		}
+	}
+
+	var b strings.Builder
+	if err := h.instructions.Execute(&b, data); err != nil {
+		h.logger.Warn("Failed to render server instructions", "error", err)
+		return defaultInstructions + h.defaultContextInstructions()
+	}
+	return b.String()
+}
+
+// defaultContextInstructions describes the configured default repository and
+// organization, each of which fills in different arguments
+func (h *Handler) defaultContextInstructions() string {
+	var b strings.Builder
+	if owner, name := parseRepositoryReference(h.defaultRepo); owner != "" && name != "" {
+		fmt.Fprintf(&b, ". Tool calls without owner and repo default to the repository %s/%s", owner, name)
+	}
+	if h.defaultOrg != "" {
+		fmt.Fprintf(&b, ". Tool calls without org default to the organization %s", h.defaultOrg)
+	}
+	return b.String()
+}
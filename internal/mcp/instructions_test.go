@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestServerInfo(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	if err := h.SetServerInfo("acme-github", "Work in {{.DefaultRepo}} of {{.DefaultOrg}} with {{join .Toolsets \", \"}}"); err == nil {
+		t.Fatal("Expected a template calling an undefined function to be rejected")
+	}
+	if err := h.SetServerInfo("acme-github", "{{.ServerName}}: work in {{.DefaultRepo}}, toolsets {{range .Toolsets}}{{.}} {{end}}"); err != nil {
+		t.Fatalf("SetServerInfo failed: %v", err)
+	}
+	h.SetDefaultContext("acme", "acme/widgets")
+	h.SetToolsetEnabled("analytics", false)
+
+	response := h.handleInitialize(context.Background(), &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodInitialize, Params: map[string]interface{}{}})
+	result := response.Result.(InitializeResult)
+	if result.ServerInfo.Name != "acme-github" {
+		t.Errorf("Expected server name acme-github, got %q", result.ServerInfo.Name)
+	}
+	if !strings.HasPrefix(result.Instructions, "acme-github: work in acme/widgets, toolsets ") {
+		t.Errorf("Unexpected instructions %q", result.Instructions)
+	}
+	if !strings.Contains(result.Instructions, "teams ") || strings.Contains(result.Instructions, "analytics") {
+		t.Errorf("Expected only enabled toolsets in the instructions, got %q", result.Instructions)
+	}
+}
+
+func TestDefaultContext(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.SetDefaultContext("acme", "acme/widgets")
+
+	args := map[string]interface{}{}
+	applyRootDefaults(h.contextRoots(NewSession(TransportStdio)), h.findTool("get_file_contents").InputSchema, args)
+	if args["owner"] != "acme" || args["repo"] != "widgets" {
+		t.Errorf("Expected the default repository, got %v", args)
+	}
+
+	session := NewSession(TransportStdio)
+	session.setRoots([]rootRepository{{owner: "octocat", repo: "hello"}})
+	args = map[string]interface{}{}
+	applyRootDefaults(h.contextRoots(session), h.findTool("get_file_contents").InputSchema, args)
+	if args["owner"] != "octocat" || args["repo"] != "hello" {
+		t.Errorf("Expected the client's roots to take precedence, got %v", args)
+	}
+}
+
+func TestDefaultContext_OrgDiffersFromRepositoryOwner(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.SetDefaultContext("acme", "octocat/hello")
+
+	instructions := h.renderInstructions()
+	if !strings.Contains(instructions, "octocat/hello") || !strings.Contains(instructions, "organization acme") {
+		t.Errorf("Expected both defaults in the instructions, got %q", instructions)
+	}
+
+	orgSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"org": map[string]interface{}{"type": "string"}},
+		"required":   []string{"org"},
+	}
+	args := map[string]interface{}{}
+	applyRootDefaults(h.contextRoots(NewSession(TransportStdio)), orgSchema, args)
+	if args["org"] != "acme" {
+		t.Errorf("Expected the default organization, got %v", args)
+	}
+
+	args = map[string]interface{}{}
+	applyRootDefaults(h.contextRoots(NewSession(TransportStdio)), h.findTool("get_file_contents").InputSchema, args)
+	if args["owner"] != "octocat" || args["repo"] != "hello" {
+		t.Errorf("Expected the default repository, got %v", args)
+	}
+}
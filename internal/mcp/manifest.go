@@ -0,0 +1,265 @@
+package mcp
+
+import (
+	"github.com/nicholasflintwillow/github-mcp/internal/version"
+)
+
+// serverName is the name the server reports to MCP clients by default
+const serverName = "github-mcp-server"
+
+// serverCapabilities returns the MCP capabilities the server supports
+func (h *Handler) serverCapabilities() ServerCapabilities {
+	return ServerCapabilities{
+		Tools: &ToolsCapability{
+			ListChanged: true,
+		},
+		Resources: &ResourcesCapability{
+			Subscribe:   h.subscriptionInterval > 0,
+			ListChanged: false,
+		},
+		Prompts: &PromptsCapability{
+			ListChanged: false,
+		},
+		Completions: &CompletionsCapability{},
+	}
+}
+
+// toolset groups related tools with the classic OAuth scopes they need
+type toolset struct {
+	name        string
+	description string
+	// readScopes are needed by the read-only tools and writeScopes by the others
+	readScopes  []string
+	writeScopes []string
+	tools       []string
+}
+
+// toolsets lists every tool by the toolset it belongs to
+var toolsets = []toolset{
+	{
+		name:        "users",
+		description: "GitHub users, followers and repositories",
+		writeScopes: []string{"user"},
+		tools: []string{"get_user", "get_authenticated_user", "update_authenticated_user", "list_users",
+			"list_user_followers", "list_user_following", "check_user_following", "follow_user", "unfollow_user",
+			"list_repositories"},
+	},
+	{
+		name:        "organizations",
+		description: "Organizations and their membership",
+		readScopes:  []string{"read:org"},
+		writeScopes: []string{"admin:org"},
+		tools: []string{"get_organization", "update_organization", "list_organizations", "list_user_organizations",
+			"list_authenticated_user_organizations", "list_organization_members", "check_organization_membership",
+			"check_public_organization_membership"},
+	},
+	{
+		name:        "teams",
+		description: "Teams, their members and repositories",
+		readScopes:  []string{"read:org"},
+		writeScopes: []string{"admin:org"},
+		tools: []string{"list_teams", "get_team", "create_team", "update_team", "delete_team", "list_team_members",
+			"get_team_membership", "add_team_membership", "remove_team_membership", "list_team_repositories",
+			"check_team_repository", "add_team_repository", "remove_team_repository"},
+	},
+	{
+		name:        "repositories",
+		description: "Repositories, their settings, forks and transfers",
+		readScopes:  []string{"repo"},
+		writeScopes: []string{"repo", "delete_repo"},
+		tools: []string{"get_repository", "create_repository", "update_repository", "delete_repository",
+			"fork_repository", "transfer_repository"},
+	},
+	{
+		name:        "branch_protection",
+		description: "Protection of repository branches",
+		readScopes:  []string{"repo"},
+		writeScopes: []string{"repo"},
+		tools:       []string{"get_branch_protection", "update_branch_protection", "delete_branch_protection"},
+	},
+	{
+		name:        "rulesets",
+		description: "Repository and organization rulesets, and the rules applying to branches",
+		readScopes:  []string{"repo"},
+		writeScopes: []string{"repo", "admin:org"},
+		tools: []string{"list_rulesets", "get_ruleset", "create_ruleset", "update_ruleset", "delete_ruleset",
+			"list_branch_rules"},
+	},
+	{
+		name:        "commits",
+		description: "Commits of repositories and comparisons between them",
+		readScopes:  []string{"repo"},
+		tools:       []string{"list_commits", "get_commit", "compare_commits"},
+	},
+	{
+		name:        "apps",
+		description: "GitHub App installations and their repositories",
+		readScopes:  []string{"read:org"},
+		writeScopes: []string{"admin:org"},
+		tools: []string{"list_app_installations", "list_installation_repositories", "add_installation_repository",
+			"remove_installation_repository"},
+	},
+	{
+		name:        "analytics",
+		description: "Organization activity reports",
+		readScopes:  []string{"read:org", "repo"},
+		tools:       []string{"get_org_activity_analytics"},
+	},
+	{
+		name:        "issues",
+		description: "Bulk issue management",
+		writeScopes: []string{"repo"},
+		tools:       []string{"bulk_update_issues"},
+	},
+	{
+		name:        "contents",
+		description: "Files in repositories",
+		readScopes:  []string{"repo"},
+		writeScopes: []string{"repo"},
+		tools:       []string{"get_file_contents", "list_directory", "create_or_update_file", "delete_file"},
+	},
+	{
+		name:        "summaries",
+		description: "Summaries of issue threads and pull requests written by the client's model",
+		readScopes:  []string{"repo"},
+		tools:       []string{"summarize_issue_thread", "summarize_pr_diff"},
+	},
+	{
+		name:        "dependencies",
+		description: "Dependency maps of repositories",
+		readScopes:  []string{"repo"},
+		tools:       []string{"get_dependency_map"},
+	},
+	{
+		name:        "api",
+		description: "Requests to allowlisted GitHub REST API endpoints",
+		tools:       []string{"github_api_request"},
+	},
+	{
+		name:        "server",
+		description: "Tools answered from server state",
+		tools:       []string{"list_recent_deletions", "find_resource"},
+	},
+}
+
+// destructiveTools remove data from GitHub; update_ruleset only when it turns
+// a ruleset off or clears its rules, which needsConfirmation checks
+var destructiveTools = map[string]bool{
+	"delete_team":                    true,
+	"delete_repository":              true,
+	"delete_file":                    true,
+	"delete_branch_protection":       true,
+	"delete_ruleset":                 true,
+	"update_ruleset":                 true,
+	"transfer_repository":            true,
+	"remove_team_membership":         true,
+	"remove_team_repository":         true,
+	"remove_installation_repository": true,
+	"unfollow_user":                  true,
+	"github_api_request":             true,
+}
+
+// Manifest describes the capabilities of a server deployment
+type Manifest struct {
+	Name            string             `json:"name"`
+	Version         version.Info       `json:"version"`
+	ProtocolVersion string             `json:"protocolVersion"`
+	Transports      []string           `json:"transports"`
+	Toolsets        []ToolsetManifest  `json:"toolsets"`
+	Tools           []ToolManifest     `json:"tools"`
+	Resources       []Resource         `json:"resources"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+}
+
+// ToolsetManifest describes an enabled toolset
+type ToolsetManifest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tools       []string `json:"tools"`
+}
+
+// ToolManifest describes a tool, the scopes it needs and its behavior
+type ToolManifest struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Toolset     string          `json:"toolset"`
+	Scopes      []string        `json:"scopes"`
+	Annotations ToolAnnotations `json:"annotations"`
+}
+
+// ToolAnnotations are hints about a tool's behavior, named as in MCP
+type ToolAnnotations struct {
+	ReadOnlyHint    bool `json:"readOnlyHint"`
+	DestructiveHint bool `json:"destructiveHint"`
+	IdempotentHint  bool `json:"idempotentHint"`
+	OpenWorldHint   bool `json:"openWorldHint"`
+}
+
+// readOnlyTool reports whether a tool only reads data
+func readOnlyTool(name string) bool {
+	return localTools[name] || unprefetchedTools[name] || prefetchableTool(name)
+}
+
+// Manifest describes the tools and resources the handler serves on transports
+func (h *Handler) Manifest(transports []string) Manifest {
+	toolsetOf := make(map[string]*toolset)
+	for i := range toolsets {
+		for _, name := range toolsets[i].tools {
+			toolsetOf[name] = &toolsets[i]
+		}
+	}
+
+	tools := h.Tools()
+	manifest := Manifest{
+		Name:            h.serverName,
+		Version:         version.Get(),
+		ProtocolVersion: MCPVersion,
+		Transports:      transports,
+		Toolsets:        []ToolsetManifest{},
+		Tools:           make([]ToolManifest, 0, len(tools)),
+		Resources:       h.resources,
+		Capabilities:    h.serverCapabilities(),
+	}
+
+	enabled := make(map[string]bool)
+	for _, tool := range tools {
+		set, ok := toolsetOf[tool.Name]
+		if !ok {
+			set = &toolset{name: "other"}
+		}
+		if !enabled[set.name] {
+			manifest.Toolsets = append(manifest.Toolsets, ToolsetManifest{Name: set.name, Description: set.description})
+			enabled[set.name] = true
+		}
+
+		readOnly := readOnlyTool(tool.Name)
+		scopes := set.writeScopes
+		if readOnly {
+			scopes = set.readScopes
+		}
+		if scopes == nil {
+			scopes = []string{}
+		}
+		manifest.Tools = append(manifest.Tools, ToolManifest{
+			Name:        h.names.served(tool.Name),
+			Description: tool.Description,
+			Toolset:     set.name,
+			Scopes:      scopes,
+			Annotations: ToolAnnotations{
+				ReadOnlyHint:    readOnly,
+				DestructiveHint: destructiveTools[tool.Name],
+				IdempotentHint:  readOnly || destructiveTools[tool.Name],
+				OpenWorldHint:   !localTools[tool.Name],
+			},
+		})
+	}
+
+	for i := range manifest.Toolsets {
+		for _, tool := range manifest.Tools {
+			if tool.Toolset == manifest.Toolsets[i].Name {
+				manifest.Toolsets[i].Tools = append(manifest.Toolsets[i].Tools, tool.Name)
+			}
+		}
+	}
+	return manifest
+}
@@ -0,0 +1,37 @@
+package mcp
+
+import "testing"
+
+func TestManifest(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	manifest := h.Manifest([]string{"http"})
+
+	if len(manifest.Tools) != len(h.Tools()) {
+		t.Fatalf("Expected %d tools, got %d", len(h.Tools()), len(manifest.Tools))
+	}
+
+	listed := 0
+	for _, toolset := range manifest.Toolsets {
+		if toolset.Name == "other" {
+			t.Errorf("Expected every tool to belong to a toolset, got other: %v", toolset.Tools)
+		}
+		listed += len(toolset.Tools)
+	}
+	if listed != len(manifest.Tools) {
+		t.Errorf("Expected toolsets to list %d tools, got %d", len(manifest.Tools), listed)
+	}
+
+	tools := make(map[string]ToolManifest)
+	for _, tool := range manifest.Tools {
+		tools[tool.Name] = tool
+	}
+	if tool := tools["list_teams"]; !tool.Annotations.ReadOnlyHint || tool.Toolset != "teams" || len(tool.Scopes) != 1 || tool.Scopes[0] != "read:org" {
+		t.Errorf("Unexpected list_teams manifest %+v", tool)
+	}
+	if tool := tools["delete_team"]; tool.Annotations.ReadOnlyHint || !tool.Annotations.DestructiveHint || tool.Scopes[0] != "admin:org" {
+		t.Errorf("Unexpected delete_team manifest %+v", tool)
+	}
+	if tool := tools["find_resource"]; tool.Annotations.OpenWorldHint || len(tool.Scopes) != 0 {
+		t.Errorf("Unexpected find_resource manifest %+v", tool)
+	}
+}
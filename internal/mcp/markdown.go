@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// formatArgument is the argument name of list tools choosing the format
+	// of their result
+	formatArgument = "format"
+	// formatMarkdown renders list results as a Markdown table
+	formatMarkdown = "markdown"
+	// markdownMaxColumns bounds the columns of a table of items of a tool
+	// without default columns
+	markdownMaxColumns = 6
+)
+
+// markdownColumns are the default table columns of the list tools, as
+// field paths
+var markdownColumns = map[string][]string{
+	"list_users":                            {"login", "id", "type", "html_url"},
+	"list_user_followers":                   {"login", "id", "html_url"},
+	"list_user_following":                   {"login", "id", "html_url"},
+	"list_repositories":                     {"full_name", "description", "private", "language", "stargazers_count", "html_url"},
+	"list_organizations":                    {"login", "id", "description"},
+	"list_user_organizations":               {"login", "id", "description"},
+	"list_authenticated_user_organizations": {"login", "id", "description"},
+	"list_organization_members":             {"login", "id", "html_url"},
+	"list_teams":                            {"slug", "name", "privacy", "permission", "html_url"},
+	"list_team_members":                     {"login", "id", "html_url"},
+	"list_team_repositories":                {"full_name", "private", "permissions", "html_url"},
+	"list_app_installations":                {"id", "account", "app_slug", "target_type", "repository_selection"},
+	"list_installation_repositories":        {"full_name", "private", "html_url"},
+	"list_recent_deletions":                 {"id", "tool", "object", "actor", "deleted_at"},
+}
+
+// addFormat adds the format argument to the schema of the list tools whose
+// results are shaped
+func addFormat(tools []Tool) {
+	for _, tool := range tools {
+		if !tool.Shaped || !strings.HasPrefix(tool.Name, "list_") {
+			continue
+		}
+
+		properties := schemaProperties(tool.InputSchema)
+		if properties == nil {
+			continue
+		}
+
+		properties[formatArgument] = map[string]interface{}{
+			"type":        "string",
+			"enum":        []string{"json", formatMarkdown},
+			"description": "Format of the result: json (default) or markdown, a table of the items for clients showing results to users",
+		}
+	}
+}
+
+// formatResult renders the result of a list tool as a Markdown table when
+// args ask for it. Error results and results that are not lists are
+// returned unchanged.
+func (h *Handler) formatResult(toolName string, args map[string]interface{}, result *CallToolResult) *CallToolResult {
+	if format, _ := args[formatArgument].(string); format != formatMarkdown {
+		return result
+	}
+	if result == nil || result.IsError || len(result.Content) == 0 || result.Content[0].Type != "text" {
+		return result
+	}
+
+	var envelope struct {
+		Items      []json.RawMessage `json:"items"`
+		Pagination paginationInfo    `json:"pagination"`
+		Truncated  *truncationInfo   `json:"truncated"`
+	}
+	text := []byte(result.Content[0].Text)
+	if err := json.Unmarshal(text, &envelope.Items); err != nil {
+		if err := json.Unmarshal(text, &envelope); err != nil || envelope.Items == nil {
+			return result
+		}
+	}
+
+	columns := markdownColumns[toolName]
+	if paths := stringSliceArg(args, fieldsArgument); len(paths) > 0 {
+		columns = paths
+	} else if columns == nil && len(envelope.Items) > 0 {
+		columns = scalarFields(envelope.Items[0])
+	}
+
+	var b strings.Builder
+	if len(envelope.Items) == 0 {
+		b.WriteString(h.messages.Sprintf("No items."))
+	} else {
+		writeMarkdownTable(&b, columns, envelope.Items)
+	}
+	if envelope.Truncated != nil {
+		b.WriteString("\n\n" + envelope.Truncated.Message)
+	} else if envelope.Pagination.HasNext {
+		b.WriteString("\n\n" + h.messages.Sprintf("More items are available, call the tool again with cursor %s.", envelope.Pagination.NextCursor))
+	}
+
+	formatted := *result
+	formatted.Content = append([]Content{{Type: "text", Text: b.String()}}, result.Content[1:]...)
+	return &formatted
+}
+
+// writeMarkdownTable writes items as a Markdown table of columns
+func writeMarkdownTable(b *strings.Builder, columns []string, items []json.RawMessage) {
+	b.WriteString("| " + strings.Join(columns, " | ") + " |\n|")
+	b.WriteString(strings.Repeat(" --- |", len(columns)))
+	for _, item := range items {
+		var fields map[string]interface{}
+		dec := newNumberDecoder(item)
+		if dec.Decode(&fields) != nil {
+			continue
+		}
+		b.WriteString("\n|")
+		for _, column := range columns {
+			b.WriteString(" " + markdownCell(fieldAt(fields, column)) + " |")
+		}
+	}
+}
+
+// fieldAt returns the field of fields at the dot-separated path, or nil
+func fieldAt(fields map[string]interface{}, path string) interface{} {
+	var value interface{} = fields
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[name]
+	}
+	return value
+}
+
+// markdownCell renders a field value as the text of a table cell: objects by
+// their identifier field, or the names of their true fields such as
+// permissions, and arrays as the list of their values
+func markdownCell(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(value)
+	case map[string]interface{}:
+		for _, name := range identifierFields {
+			if field, ok := value[name]; ok {
+				return markdownCell(field)
+			}
+		}
+		if title, ok := value["title"]; ok {
+			return markdownCell(title)
+		}
+		var enabled []string
+		for name, field := range value {
+			if field == true {
+				enabled = append(enabled, name)
+			}
+		}
+		sort.Strings(enabled)
+		return strings.Join(enabled, ", ")
+	case []interface{}:
+		cells := make([]string, 0, len(value))
+		for _, item := range value {
+			cells = append(cells, markdownCell(item))
+		}
+		return strings.Join(cells, ", ")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// scalarFields returns the names of the first scalar fields of item, in
+// their order
+func scalarFields(item json.RawMessage) []string {
+	dec := newNumberDecoder(item)
+	if token, err := dec.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+
+	var names []string
+	for dec.More() && len(names) < markdownMaxColumns {
+		key, err := dec.Token()
+		if err != nil {
+			break
+		}
+		var field json.RawMessage
+		if dec.Decode(&field) != nil {
+			break
+		}
+		if field = bytes.TrimSpace(field); len(field) > 0 && field[0] != '{' && field[0] != '[' {
+			names = append(names, key.(string))
+		}
+	}
+	return names
+}
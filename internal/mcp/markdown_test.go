@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatResult_Markdown(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	list := `{"items":[{"slug":"devs","name":"Dev | Ops","privacy":"closed","permission":"pull","parent":{"slug":"eng"},"labels":[{"name":"bug"},{"name":"ui"}]}],"pagination":{"has_next":true,"next_page":2,"next_cursor":"cGFnZT0y"}}`
+	result := &CallToolResult{Content: []Content{{Type: "text", Text: list}}}
+
+	tests := []struct {
+		name string
+		tool string
+		args map[string]interface{}
+		want string
+	}{
+		{
+			name: "json",
+			tool: "list_teams",
+			args: map[string]interface{}{},
+			want: list,
+		},
+		{
+			name: "default columns",
+			tool: "list_teams",
+			args: map[string]interface{}{formatArgument: formatMarkdown},
+			want: "| slug | name | privacy | permission | html_url |\n| --- | --- | --- | --- | --- |\n| devs | Dev \\| Ops | closed | pull |  |\n\nMore items are available, call the tool again with cursor cGFnZT0y.",
+		},
+		{
+			name: "fields",
+			tool: "list_teams",
+			args: map[string]interface{}{formatArgument: formatMarkdown, fieldsArgument: []interface{}{"slug", "parent", "labels"}},
+			want: "| slug | parent | labels |\n| --- | --- | --- |\n| devs | eng | bug, ui |",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := h.formatResult(tt.tool, tt.args, result).Content[0].Text
+			if !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	empty := &CallToolResult{Content: []Content{{Type: "text", Text: `{"items":[],"pagination":{"has_next":false}}`}}}
+	if got := h.formatResult("list_teams", map[string]interface{}{formatArgument: formatMarkdown}, empty).Content[0].Text; got != "No items." {
+		t.Errorf("Expected an empty list to say so, got %q", got)
+	}
+	failed := &CallToolResult{Content: []Content{{Type: "text", Text: "Error listing teams"}}, IsError: true}
+	if got := h.formatResult("list_teams", map[string]interface{}{formatArgument: formatMarkdown}, failed); got != failed {
+		t.Errorf("Expected error results unchanged, got %+v", got)
+	}
+
+	if _, ok := schemaProperties(h.findTool("list_teams").InputSchema)[formatArgument]; !ok {
+		t.Error("Expected list_teams to accept format")
+	}
+}
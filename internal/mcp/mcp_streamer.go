@@ -1,18 +1,27 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/requestid"
 )
 
 // StreamHandlerInterface defines the interface for stream handler operations
 type StreamHandlerInterface interface {
 	BroadcastMessage(eventType string, data interface{})
-	SendToClient(clientID, eventType string, data interface{})
+	SendToClient(clientID, owner, eventType string, data interface{})
+	SendToOwner(owner, eventType string, data interface{})
 	GetConnectedClients() int
 }
 
+// distributor is implemented by stream handlers that also deliver events to
+// clients connected to other replicas
+type distributor interface {
+	Distributed() bool
+}
+
 // MCPStreamer handles formatting and pushing MCP messages as SSE events to connected clients
 type MCPStreamer struct {
 	logger        *logger.Logger
@@ -29,13 +38,20 @@ func NewMCPStreamer(logger *logger.Logger, streamHandler StreamHandlerInterface)
 
 // StreamMessage sends an MCP message to all connected clients
 func (ms *MCPStreamer) StreamMessage(message *JSONRPCMessage) error {
+	return ms.StreamMessageWithContext(context.Background(), message)
+}
+
+// StreamMessageWithContext sends an MCP message to all connected clients,
+// tagged with the ID of the request that produced it
+func (ms *MCPStreamer) StreamMessageWithContext(ctx context.Context, message *JSONRPCMessage) error {
 	if ms.streamHandler == nil {
 		ms.logger.Warn("No stream handler available for streaming message")
 		return nil
 	}
 
-	// Check if there are any connected clients
-	if ms.streamHandler.GetConnectedClients() == 0 {
+	// Check if there are any connected clients; those of other replicas are
+	// not counted
+	if ms.streamHandler.GetConnectedClients() == 0 && !ms.distributed() {
 		ms.logger.Debug("No connected clients to stream message to")
 		return nil
 	}
@@ -46,6 +62,9 @@ func (ms *MCPStreamer) StreamMessage(message *JSONRPCMessage) error {
 		ms.logger.Error("Failed to format MCP message for SSE", "error", err)
 		return err
 	}
+	if id := requestid.FromContext(ctx); id != "" {
+		eventData["request_id"] = id
+	}
 
 	// Determine event type based on message type
 	eventType := ms.getEventType(message)
@@ -62,8 +81,48 @@ func (ms *MCPStreamer) StreamMessage(message *JSONRPCMessage) error {
 	return nil
 }
 
-// StreamMessageToClient sends an MCP message to a specific client
-func (ms *MCPStreamer) StreamMessageToClient(clientID string, message *JSONRPCMessage) error {
+// StreamMessageToOwner sends an MCP message to the clients whose streams
+// were opened by the owner of the request of ctx, tagged with its ID. Tool
+// results may hold data only that owner can see, such as that fetched with
+// its own GitHub token, so they are never broadcast.
+func (ms *MCPStreamer) StreamMessageToOwner(ctx context.Context, message *JSONRPCMessage) error {
+	if ms.streamHandler == nil {
+		ms.logger.Warn("No stream handler available for streaming message")
+		return nil
+	}
+	if ms.streamHandler.GetConnectedClients() == 0 && !ms.distributed() {
+		ms.logger.Debug("No connected clients to stream message to")
+		return nil
+	}
+
+	eventData, err := ms.formatMessageForSSE(message)
+	if err != nil {
+		ms.logger.Error("Failed to format MCP message for SSE", "error", err)
+		return err
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		eventData["request_id"] = id
+	}
+
+	eventType := ms.getEventType(message)
+	ms.streamHandler.SendToOwner(streamOwner(ctx), eventType, eventData)
+
+	ms.logger.Debug("Streamed MCP message to the owner's clients",
+		"eventType", eventType,
+		"messageID", message.ID)
+
+	return nil
+}
+
+// distributed reports whether the stream handler delivers to other replicas
+func (ms *MCPStreamer) distributed() bool {
+	d, ok := ms.streamHandler.(distributor)
+	return ok && d.Distributed()
+}
+
+// StreamMessageToClient sends an MCP message to a specific client, if its
+// stream was opened by owner
+func (ms *MCPStreamer) StreamMessageToClient(clientID, owner string, message *JSONRPCMessage) error {
 	if ms.streamHandler == nil {
 		ms.logger.Warn("No stream handler available for streaming message")
 		return nil
@@ -80,7 +139,7 @@ func (ms *MCPStreamer) StreamMessageToClient(clientID string, message *JSONRPCMe
 	eventType := ms.getEventType(message)
 
 	// Send to specific client
-	ms.streamHandler.SendToClient(clientID, eventType, eventData)
+	ms.streamHandler.SendToClient(clientID, owner, eventType, eventData)
 
 	ms.logger.Debug("Streamed MCP message to specific client",
 		"eventType", eventType,
@@ -91,6 +150,27 @@ func (ms *MCPStreamer) StreamMessageToClient(clientID string, message *JSONRPCMe
 	return nil
 }
 
+// ownerChecker is implemented by stream handlers that know who opened the
+// streams of their clients
+type ownerChecker interface {
+	ClientOwner(clientID string) (string, bool)
+}
+
+// clientOwnedBy reports whether a client may be sent messages for owner:
+// false when its stream, connected to this replica, was opened by another
+// owner, or when it is not connected at all and no other replica could have
+// it. Clients of other replicas are checked there on delivery.
+func (ms *MCPStreamer) clientOwnedBy(clientID, owner string) bool {
+	checker, ok := ms.streamHandler.(ownerChecker)
+	if !ok {
+		return true
+	}
+	if streamOwner, connected := checker.ClientOwner(clientID); connected {
+		return streamOwner == owner
+	}
+	return ms.distributed()
+}
+
 // StreamNotification sends a notification message to all connected clients
 func (ms *MCPStreamer) StreamNotification(method string, params interface{}) error {
 	// Create notification message
@@ -1,17 +1,24 @@
 package mcp
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
 	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
 )
 
 // mockStreamHandler implements StreamHandlerInterface for testing
 type mockStreamHandler struct {
 	broadcastCalls []broadcastCall
 	clientCalls    []clientCall
+	ownerCalls     []ownerCall
 	clientCount    int
 	mu             sync.Mutex
 }
@@ -21,6 +28,12 @@ type broadcastCall struct {
 	data      interface{}
 }
 
+type ownerCall struct {
+	owner     string
+	eventType string
+	data      interface{}
+}
+
 type clientCall struct {
 	clientID  string
 	eventType string
@@ -44,7 +57,7 @@ func (m *mockStreamHandler) BroadcastMessage(eventType string, data interface{})
 	})
 }
 
-func (m *mockStreamHandler) SendToClient(clientID, eventType string, data interface{}) {
+func (m *mockStreamHandler) SendToClient(clientID, owner, eventType string, data interface{}) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.clientCalls = append(m.clientCalls, clientCall{
@@ -54,6 +67,16 @@ func (m *mockStreamHandler) SendToClient(clientID, eventType string, data interf
 	})
 }
 
+func (m *mockStreamHandler) SendToOwner(owner, eventType string, data interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ownerCalls = append(m.ownerCalls, ownerCall{
+		owner:     owner,
+		eventType: eventType,
+		data:      data,
+	})
+}
+
 func (m *mockStreamHandler) GetConnectedClients() int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -232,7 +255,7 @@ func TestStreamMessageToClient_Request(t *testing.T) {
 	clientID := "test-client-123"
 	message := NewRequest(1, "tools/list", map[string]interface{}{})
 
-	err := streamer.StreamMessageToClient(clientID, message)
+	err := streamer.StreamMessageToClient(clientID, "", message)
 	if err != nil {
 		t.Fatalf("StreamMessageToClient failed: %v", err)
 	}
@@ -260,7 +283,7 @@ func TestStreamMessageToClient_Response(t *testing.T) {
 	clientID := "test-client-456"
 	message := NewResponse(1, map[string]interface{}{"tools": []interface{}{}})
 
-	err := streamer.StreamMessageToClient(clientID, message)
+	err := streamer.StreamMessageToClient(clientID, "", message)
 	if err != nil {
 		t.Fatalf("StreamMessageToClient failed: %v", err)
 	}
@@ -467,8 +490,51 @@ func TestNilStreamHandler(t *testing.T) {
 	}
 
 	// Should not panic with nil stream handler
-	err = streamer.StreamMessageToClient("test", message)
+	err = streamer.StreamMessageToClient("test", "", message)
 	if err != nil {
 		t.Fatalf("StreamMessageToClient failed with nil handler: %v", err)
 	}
 }
+
+func TestHandleCallTool_ResultStreamedToOwner(t *testing.T) {
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mocks.MockJSONResponse(http.StatusOK, `{"id": 1, "name": "secret-plans", "full_name": "alice/secret-plans", "private": true}`), nil
+		},
+	})
+	sh := NewStreamHandler(createTestLogger())
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetStreamer(sh.GetStreamer())
+
+	alice, bob := newMockResponseWriter(), newMockResponseWriter()
+	sh.addClient(&ClientConnection{Writer: alice, Flusher: alice, Done: make(chan struct{}), owner: "alice"})
+	sh.addClient(&ClientConnection{Writer: bob, Flusher: bob, Done: make(chan struct{}), owner: "bob"})
+
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := client.WithToken(WithStreamOwner(WithSession(context.Background(), session), "alice"), "gho_alice")
+	resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+		"name":      "get_repository",
+		"arguments": map[string]interface{}{"owner": "alice", "repo": "secret-plans"},
+	}})
+	if resp.Error != nil {
+		t.Fatalf("Unexpected error: %+v", resp.Error)
+	}
+
+	alice.waitForBody(t, "secret-plans")
+	if body := bob.GetBody(); strings.Contains(body, "secret-plans") {
+		t.Errorf("Expected another owner's stream not to receive the result, got %q", body)
+	}
+
+	// Nor can the other owner read it back from the replay buffer
+	replayed := newMockResponseWriter()
+	req := httptest.NewRequest(http.MethodGet, "/mcp/stream", nil)
+	req.Header.Set("Last-Event-ID", "0")
+	reqCtx, cancel := context.WithTimeout(WithStreamOwner(context.Background(), "bob"), 100*time.Millisecond)
+	defer cancel()
+	sh.HandleSSE(replayed, req.WithContext(reqCtx))
+	if body := replayed.GetBody(); strings.Contains(body, "secret-plans") {
+		t.Errorf("Expected the result not to be replayed to another owner, got %q", body)
+	}
+}
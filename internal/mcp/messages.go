@@ -0,0 +1,100 @@
+package mcp
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// defaultLocale is the locale tool result text is written in
+const defaultLocale = "en"
+
+// localeFS holds the message catalogs for locales other than the default.
+// Each catalog maps an English format string to its translation.
+//
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// messageFormatter builds the human-readable text of tool results in the
+// configured locale. Messages are identified by their English format string,
+// so untranslated messages fall back to English.
+type messageFormatter struct {
+	locale  string
+	catalog map[string]string
+}
+
+// newMessageFormatter creates a formatter for the given locale. Regional
+// variants such as "es-MX" fall back to their base language.
+func newMessageFormatter(locale string) (*messageFormatter, error) {
+	locale = normalizeLocale(locale)
+	if locale == "" || locale == defaultLocale {
+		return &messageFormatter{locale: defaultLocale}, nil
+	}
+
+	candidates := []string{locale}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		candidates = append(candidates, base)
+	}
+
+	for _, candidate := range candidates {
+		if candidate == defaultLocale {
+			return &messageFormatter{locale: defaultLocale}, nil
+		}
+
+		data, err := localeFS.ReadFile(path.Join("locales", candidate+".json"))
+		if err != nil {
+			continue
+		}
+
+		var catalog map[string]string
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("invalid message catalog for locale %s: %w", candidate, err)
+		}
+		return &messageFormatter{locale: candidate, catalog: catalog}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported locale %q (supported: %s)", locale, strings.Join(SupportedLocales(), ", "))
+}
+
+// Sprintf formats a message in the formatter's locale
+func (f *messageFormatter) Sprintf(format string, args ...interface{}) string {
+	if translated, ok := f.catalog[format]; ok && translated != "" {
+		format = translated
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Locale returns the locale messages are formatted in
+func (f *messageFormatter) Locale() string {
+	return f.locale
+}
+
+// SupportedLocales returns the locales tool result text can be written in
+func SupportedLocales() []string {
+	locales := []string{defaultLocale}
+
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		return locales
+	}
+	for _, entry := range entries {
+		if name, found := strings.CutSuffix(entry.Name(), ".json"); found {
+			locales = append(locales, name)
+		}
+	}
+
+	sort.Strings(locales)
+	return locales
+}
+
+// normalizeLocale converts locale identifiers such as "es_MX.UTF-8" to "es-mx"
+func normalizeLocale(locale string) string {
+	locale, _, _ = strings.Cut(strings.TrimSpace(locale), ".")
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
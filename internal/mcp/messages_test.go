@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// formatVerbPattern matches fmt verbs, skipping escaped percent signs
+var formatVerbPattern = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+
+func formatVerbs(format string) []string {
+	var verbs []string
+	for _, verb := range formatVerbPattern.FindAllString(format, -1) {
+		if verb != "%%" {
+			verbs = append(verbs, verb)
+		}
+	}
+	return verbs
+}
+
+func TestMessageFormatter_CatalogsMatchFormats(t *testing.T) {
+	for _, locale := range SupportedLocales() {
+		if locale == defaultLocale {
+			continue
+		}
+
+		t.Run(locale, func(t *testing.T) {
+			formatter, err := newMessageFormatter(locale)
+			if err != nil {
+				t.Fatalf("Failed to load locale: %v", err)
+			}
+
+			for format, translated := range formatter.catalog {
+				if !reflect.DeepEqual(formatVerbs(format), formatVerbs(translated)) {
+					t.Errorf("Translation of %q uses verbs %v, expected %v", format, formatVerbs(translated), formatVerbs(format))
+				}
+			}
+		})
+	}
+}
+
+func TestNewMessageFormatter(t *testing.T) {
+	tests := []struct {
+		locale         string
+		expectedLocale string
+		expectError    bool
+	}{
+		{locale: "", expectedLocale: "en"},
+		{locale: "en_US.UTF-8", expectedLocale: "en"},
+		{locale: "es", expectedLocale: "es"},
+		{locale: "es-MX", expectedLocale: "es"},
+		{locale: "xx", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			formatter, err := newMessageFormatter(tt.locale)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if formatter.Locale() != tt.expectedLocale {
+				t.Errorf("Expected locale %s, got %s", tt.expectedLocale, formatter.Locale())
+			}
+		})
+	}
+}
+
+func TestMessageFormatter_Sprintf(t *testing.T) {
+	formatter, err := newMessageFormatter("es")
+	if err != nil {
+		t.Fatalf("Failed to load locale: %v", err)
+	}
+
+	if got := formatter.Sprintf("Successfully followed %s", "octocat"); got != "Ahora sigues a octocat" {
+		t.Errorf("Unexpected translation: %s", got)
+	}
+	if got := formatter.Sprintf("Untranslated message for %s", "octocat"); got != "Untranslated message for octocat" {
+		t.Errorf("Expected untranslated message to fall back to English, got: %s", got)
+	}
+}
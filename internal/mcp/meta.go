@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// echoedMetaKeys are the request _meta fields copied to the _meta of the
+// response, so clients can correlate them
+var echoedMetaKeys = []string{"progressToken", "correlationId"}
+
+// cacheMetaKey is the _meta key telling whether a report was served from
+// the handler's cache
+const cacheMetaKey = "github-mcp/cache"
+
+// CachedReport tells whether a report was served from the cache and when
+// it was generated
+type CachedReport struct {
+	Hit         bool   `json:"hit"`
+	GeneratedAt string `json:"generated_at"`
+}
+
+// requestMetaContextKey is the context key for the _meta of the request
+// being handled
+type requestMetaContextKey struct{}
+
+// RequestMeta returns the _meta of the request being handled, or nil
+func RequestMeta(ctx context.Context) map[string]interface{} {
+	meta, _ := ctx.Value(requestMetaContextKey{}).(map[string]interface{})
+	return meta
+}
+
+// messageMeta returns the _meta of a request's params, or nil
+func messageMeta(msg *JSONRPCMessage) map[string]interface{} {
+	if msg.Params == nil {
+		return nil
+	}
+	var params struct {
+		Meta map[string]interface{} `json:"_meta"`
+	}
+	if err := msg.GetParams(&params); err != nil {
+		return nil
+	}
+	return params.Meta
+}
+
+// resultMeta collects the _meta a tool executor attaches to its result
+type resultMeta struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+// resultMetaContextKey is the context key for the _meta of the result of
+// the tool call being executed
+type resultMetaContextKey struct{}
+
+// withResultMeta returns a context collecting the _meta of a tool result
+func withResultMeta(ctx context.Context) (context.Context, *resultMeta) {
+	meta := &resultMeta{}
+	return context.WithValue(ctx, resultMetaContextKey{}, meta), meta
+}
+
+// SetResultMeta attaches value to the _meta of the result of the tool call
+// executing in ctx under key, so metadata such as where data came from does
+// not have to be mixed into the result text. Keys should be namespaced, like
+// "github-mcp/...". It does nothing outside a tool call.
+func SetResultMeta(ctx context.Context, key string, value interface{}) {
+	meta, ok := ctx.Value(resultMetaContextKey{}).(*resultMeta)
+	if !ok {
+		return
+	}
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	if meta.values == nil {
+		meta.values = make(map[string]interface{})
+	}
+	meta.values[key] = value
+}
+
+// apply returns a copy of result carrying the collected _meta. Fields the
+// executor set on the result itself take precedence.
+func (m *resultMeta) apply(result *CallToolResult) *CallToolResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if result == nil || len(m.values) == 0 {
+		return result
+	}
+	annotated := *result
+	annotated.Meta = mergeMeta(m.values, result.Meta)
+	return &annotated
+}
+
+// mergeMeta returns a new _meta holding the fields of base overridden by
+// those of overrides
+func mergeMeta(base, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overrides))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
+// echoMeta copies the echoed fields of a request's _meta to the _meta of
+// its successful response
+func echoMeta(response *JSONRPCMessage, requestMeta map[string]interface{}) *JSONRPCMessage {
+	echoed := make(map[string]interface{})
+	for _, key := range echoedMetaKeys {
+		if value, ok := requestMeta[key]; ok {
+			echoed[key] = value
+		}
+	}
+	if len(echoed) == 0 || response == nil || response.Result == nil {
+		return response
+	}
+
+	switch result := response.Result.(type) {
+	case *CallToolResult:
+		// Tool results may be shared with the prefetch cache, so they are copied
+		annotated := *result
+		annotated.Meta = mergeMeta(result.Meta, echoed)
+		response.Result = &annotated
+	default:
+		data, err := json.Marshal(result)
+		if err != nil {
+			return response
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return response
+		}
+		existing, _ := fields["_meta"].(map[string]interface{})
+		fields["_meta"] = mergeMeta(existing, echoed)
+		response.Result = fields
+	}
+	return response
+}
@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestMetaPropagation(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	var seen map[string]interface{}
+	err := h.RegisterTool(Tool{Name: "annotated", InputSchema: map[string]interface{}{"type": "object"}},
+		func(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+			seen = RequestMeta(ctx)
+			SetResultMeta(ctx, "github-mcp/source", "test")
+			return &CallToolResult{Content: []Content{{Type: "text", Text: "ok"}}}, nil
+		})
+	if err != nil {
+		t.Fatalf("RegisterTool failed: %v", err)
+	}
+
+	call, _ := json.Marshal(NewRequest(1, MethodCallTool, map[string]interface{}{
+		"name":  "annotated",
+		"_meta": map[string]interface{}{"progressToken": "p1", "other": true},
+	}))
+	data, err := h.HandleMessage(ctx, call)
+	if err != nil {
+		t.Fatalf("HandleMessage failed: %v", err)
+	}
+	var resp struct {
+		Result struct {
+			Meta map[string]interface{} `json:"_meta"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if seen["other"] != true {
+		t.Errorf("Expected the executor to see the request _meta, got %v", seen)
+	}
+	meta := resp.Result.Meta
+	if meta["progressToken"] != "p1" || meta["github-mcp/source"] != "test" || meta["other"] != nil {
+		t.Errorf("Expected the echoed progress token and executor metadata, got %v", meta)
+	}
+
+	// Results of other methods get the echoed fields too
+	list, _ := json.Marshal(NewRequest(2, MethodListPrompts, map[string]interface{}{"_meta": map[string]interface{}{"correlationId": "c1"}}))
+	data, _ = h.HandleMessage(ctx, list)
+	if err := json.Unmarshal(data, &resp); err != nil || resp.Result.Meta["correlationId"] != "c1" {
+		t.Errorf("Expected the correlation ID echoed, got %s", data)
+	}
+}
@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+)
+
+// ToolCall is a tool call on its way to the tool
+type ToolCall struct {
+	Name      string
+	Arguments map[string]interface{}
+}
+
+// ToolHandler executes a tool call
+type ToolHandler func(ctx context.Context, call ToolCall) (*CallToolResult, error)
+
+// ToolMiddleware wraps the execution of tool calls, as HTTP middleware wraps
+// a handler. It can check or change the call before passing it to next,
+// answer it without calling next, and transform the result next returns.
+type ToolMiddleware func(next ToolHandler) ToolHandler
+
+// UseToolMiddleware adds middleware around the execution of every tool
+// call. Middleware runs in the order added, the first outermost, after the
+// built-in middleware timing the call, within the call's timeout.
+func (h *Handler) UseToolMiddleware(middleware ...ToolMiddleware) {
+	h.toolsMu.Lock()
+	defer h.toolsMu.Unlock()
+	h.middleware = append(h.middleware, middleware...)
+}
+
+// toolChain returns the handler running a tool call through the middleware
+// before serving it from the prefetch cache or executing it with its provider
+func (h *Handler) toolChain() ToolHandler {
+	h.toolsMu.RLock()
+	middleware := h.middleware
+	h.toolsMu.RUnlock()
+
+	next := h.executeCached
+	for i := len(middleware) - 1; i >= 0; i-- {
+		next = middleware[i](next)
+	}
+	return h.timingMiddleware(next)
+}
+
+// executeCached serves read-only tool calls from the prefetch cache when
+// warm, and otherwise executes them with their provider, caching the result
+func (h *Handler) executeCached(ctx context.Context, call ToolCall) (*CallToolResult, error) {
+	if h.prefetch == nil || !prefetchableTool(call.Name) || !sharedCache(ctx) {
+		return h.executeProvider(ctx, call)
+	}
+
+	if result, ok := h.prefetch.lookup(call.Name, call.Arguments); ok {
+		execstats.FromContext(ctx).AddCacheHit()
+		h.logger.WithContext(ctx).Debug("Serving cached tool result", "tool", call.Name)
+		return result, nil
+	}
+
+	result, err := h.executeProvider(ctx, call)
+	if err == nil {
+		// Cache the result with its _meta, which cache hits do not set again
+		stored := result
+		if meta, ok := ctx.Value(resultMetaContextKey{}).(*resultMeta); ok {
+			stored = meta.apply(result)
+		}
+		h.prefetch.store(call.Name, call.Arguments, stored)
+	}
+	return result, err
+}
+
+// executeProvider executes a tool call with the provider of the tool
+func (h *Handler) executeProvider(ctx context.Context, call ToolCall) (*CallToolResult, error) {
+	provider := h.toolProvider(call.Name)
+	if provider == nil {
+		return nil, fmt.Errorf("unknown tool: %s", call.Name)
+	}
+	return provider.Execute(ctx, call.Arguments)
+}
+
+// timingMiddleware logs how long each tool call took and how it ended
+func (h *Handler) timingMiddleware(next ToolHandler) ToolHandler {
+	return func(ctx context.Context, call ToolCall) (*CallToolResult, error) {
+		start := time.Now()
+		result, err := next(ctx, call)
+		h.logger.WithContext(ctx).Debug("Tool call finished", "tool", call.Name, "duration", time.Since(start),
+			"is_error", err != nil || (result != nil && result.IsError))
+		return result, err
+	}
+}
@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestUseToolMiddleware(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	tool := Tool{Name: "echo", InputSchema: map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}}
+	if err := h.RegisterTool(tool, func(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+		text, _ := args["text"].(string)
+		return &CallToolResult{Content: []Content{{Type: "text", Text: text}}}, nil
+	}); err != nil {
+		t.Fatalf("RegisterTool failed: %v", err)
+	}
+
+	var order []string
+	h.UseToolMiddleware(
+		func(next ToolHandler) ToolHandler {
+			return func(ctx context.Context, call ToolCall) (*CallToolResult, error) {
+				order = append(order, "outer")
+				if call.Arguments["text"] == "forbidden" {
+					return &CallToolResult{Content: []Content{{Type: "text", Text: "rejected"}}, IsError: true}, nil
+				}
+				return next(ctx, call)
+			}
+		},
+		func(next ToolHandler) ToolHandler {
+			return func(ctx context.Context, call ToolCall) (*CallToolResult, error) {
+				order = append(order, "inner")
+				call.Arguments["text"] = strings.ToUpper(call.Arguments["text"].(string))
+				result, err := next(ctx, call)
+				if result != nil {
+					result.Content[0].Text += "!"
+				}
+				return result, err
+			}
+		},
+	)
+
+	result, err := h.executeTool(context.Background(), "echo", map[string]interface{}{"text": "hello"})
+	if err != nil || result.Content[0].Text != "HELLO!" {
+		t.Errorf("Expected the middleware to change the call and its result, got %+v (%v)", result, err)
+	}
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Errorf("Expected middleware to run in the order added, got %v", order)
+	}
+
+	order = nil
+	result, err = h.executeTool(context.Background(), "echo", map[string]interface{}{"text": "forbidden"})
+	if err != nil || !result.IsError || strings.Join(order, ",") != "outer" {
+		t.Errorf("Expected the outer middleware to answer the call, got %+v (%v) after %v", result, err, order)
+	}
+}
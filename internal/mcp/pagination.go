@@ -0,0 +1,265 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+const (
+	// cursorArgument is the argument name list tools accept for pagination cursors
+	cursorArgument = "cursor"
+	// fetchAllArgument is the argument name of list tools that can return
+	// every page at once
+	fetchAllArgument = "fetch_all"
+	// defaultFetchAllMaxPages is the default cap on pages fetched with fetch_all
+	defaultFetchAllMaxPages = 10
+	// fetchAllPageSize is the page size used with fetch_all unless per_page is given
+	fetchAllPageSize = 100
+	// defaultListPageSize is the default page size of tools/list and resources/list
+	defaultListPageSize = 100
+)
+
+// fetchAllTools are the list tools accepting fetch_all
+var fetchAllTools = map[string]bool{
+	"list_organization_members": true,
+	"list_teams":                true,
+	"list_team_members":         true,
+}
+
+// unpaginatedTools are the list tools GitHub returns in a single response,
+// which take no cursor
+var unpaginatedTools = map[string]bool{
+	"list_directory": true,
+}
+
+// listEnvelope is the consistent result shape returned by every list tool
+type listEnvelope struct {
+	Items      interface{}    `json:"items"`
+	Pagination paginationInfo `json:"pagination"`
+	// Truncated is set when items were left out to fit the result size limit
+	Truncated *truncationInfo `json:"truncated,omitempty"`
+}
+
+// paginationInfo describes the page a list tool returned and how to fetch
+// the next one
+type paginationInfo struct {
+	// Page is the page returned, unset for lists paginated by the ID of
+	// their last item
+	Page int `json:"page,omitempty"`
+	// PerPage is the page size. When the result was truncated it is the
+	// number of items kept, and Page and NextPage count pages of that size.
+	PerPage    int    `json:"per_page"`
+	HasNext    bool   `json:"has_next"`
+	NextPage   int    `json:"next_page,omitempty"`
+	LastPage   int    `json:"last_page,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Total is the number of items of all the pages, when GitHub reports it
+	Total int `json:"total,omitempty"`
+}
+
+// listPage is the page a list tool asked for
+type listPage struct {
+	// page is zero for the first page
+	page    int
+	perPage int
+	// since is set for lists paginated by the ID of their last item
+	// rather than by page number
+	since bool
+	// total is the number of items of all the pages, or zero when unknown
+	total int
+}
+
+// newListEnvelope wraps list items together with their pagination metadata,
+// parsed from the Link header of the response, truncating them to the
+// result size limit once shaped as args ask
+func (h *Handler) newListEnvelope(args map[string]interface{}, items interface{}, pageInfo *client.PageInfo, page listPage) listEnvelope {
+	envelope := listEnvelope{Items: items}
+	envelope.Pagination = paginationInfo{PerPage: page.perPage, Total: page.total}
+	if envelope.Pagination.PerPage <= 0 {
+		envelope.Pagination.PerPage = githubDefaultPerPage
+	}
+	if !page.since {
+		envelope.Pagination.Page = max(page.page, 1)
+	}
+	if pageInfo != nil {
+		envelope.Pagination.LastPage = pageInfo.LastPage
+	}
+
+	if pageInfo.HasMore() {
+		envelope.Pagination.HasNext = true
+		envelope.Pagination.NextPage = pageInfo.NextPage
+		envelope.Pagination.NextCursor = encodeCursor(pageInfo.NextParams)
+	}
+
+	return h.truncateList(args, envelope, pageInfo, page)
+}
+
+// encodeCursor turns the next-page query parameters into an opaque cursor.
+// per_page is left out so callers can change the page size between calls.
+func encodeCursor(params map[string]string) string {
+	values := url.Values{}
+	for key, value := range params {
+		if key == "per_page" {
+			continue
+		}
+		values.Set(key, value)
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(values.Encode()))
+}
+
+// decodeCursor restores the query parameters encoded in a cursor
+func decodeCursor(cursor string) (map[string]string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	values, err := url.ParseQuery(string(raw))
+	if err != nil || len(values) == 0 {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	params := make(map[string]string, len(values))
+	for key := range values {
+		params[key] = values.Get(key)
+	}
+
+	return params, nil
+}
+
+// applyPaginationCursor replaces a cursor argument with the page arguments it
+// encodes, so list executors only need to understand page/since.
+func applyPaginationCursor(args map[string]interface{}) error {
+	raw, exists := args[cursorArgument]
+	if !exists {
+		return nil
+	}
+	delete(args, cursorArgument)
+
+	cursor, ok := raw.(string)
+	if !ok {
+		return fmt.Errorf("cursor must be a string")
+	}
+	if cursor == "" {
+		return nil
+	}
+
+	params, err := decodeCursor(cursor)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range params {
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			args[key] = n
+		} else {
+			args[key] = value
+		}
+	}
+
+	return nil
+}
+
+// addPaginationCursor adds the cursor argument to the schema of every list tool
+func addPaginationCursor(tools []Tool) {
+	for _, tool := range tools {
+		if !strings.HasPrefix(tool.Name, "list_") || unpaginatedTools[tool.Name] {
+			continue
+		}
+
+		properties := schemaProperties(tool.InputSchema)
+		if properties == nil {
+			continue
+		}
+
+		properties[cursorArgument] = map[string]interface{}{
+			"type":        "string",
+			"description": "Opaque pagination cursor returned as pagination.next_cursor by a previous call; overrides page/since",
+		}
+	}
+}
+
+// addFetchAll adds the fetch_all argument to the schema of the tools in fetchAllTools
+func addFetchAll(tools []Tool) {
+	for _, tool := range tools {
+		if !fetchAllTools[tool.Name] {
+			continue
+		}
+
+		properties := schemaProperties(tool.InputSchema)
+		if properties == nil {
+			continue
+		}
+
+		properties[fetchAllArgument] = map[string]interface{}{
+			"type":        "boolean",
+			"description": "Follow the pages after page and return them all at once, up to the server's page cap; pagination reports whether more remain",
+		}
+	}
+}
+
+// fetchAllArguments returns whether args sets fetch_all, and the page size
+// to use: pages are as large as possible when fetching them all
+func fetchAllArguments(args map[string]interface{}, perPage int) (bool, int) {
+	fetchAll, _ := args[fetchAllArgument].(bool)
+	if fetchAll && perPage <= 0 {
+		perPage = fetchAllPageSize
+	}
+	return fetchAll, perPage
+}
+
+// fetchPages calls fetch for page or, with fetchAll, for page and the pages
+// following it up to the handler's cap
+func fetchPages[T any](ctx context.Context, h *Handler, fetchAll bool, page int, fetch func(ctx context.Context, page int) ([]T, *client.PageInfo, error)) ([]T, *client.PageInfo, error) {
+	if !fetchAll {
+		return fetch(ctx, page)
+	}
+	return client.GetAllPages(ctx, page, int(h.fetchAllMaxPages.Load()), fetch)
+}
+
+// SetFetchAllMaxPages sets the cap on pages fetched by list tools called with
+// fetch_all
+func (h *Handler) SetFetchAllMaxPages(pages int) {
+	h.fetchAllMaxPages.Store(int64(pages))
+}
+
+// SetListPageSize sets the most entries a tools/list or resources/list
+// response holds; zero returns every entry at once
+func (h *Handler) SetListPageSize(size int) {
+	h.listPageSize = size
+}
+
+// listPage returns the bounds of the page of a list of n entries that cursor
+// starts, and the cursor of the next page. Cursors hold an offset, so a list
+// changing between requests may shift entries across pages.
+func (h *Handler) listPage(n int, cursor string) (start, end int, next string, err error) {
+	if cursor != "" {
+		params, err := decodeCursor(cursor)
+		if err != nil {
+			return 0, 0, "", err
+		}
+		start, err = strconv.Atoi(params["offset"])
+		if err != nil || start < 0 {
+			return 0, 0, "", fmt.Errorf("malformed cursor")
+		}
+		start = min(start, n)
+	}
+
+	end = n
+	if h.listPageSize > 0 && n-start > h.listPageSize {
+		end = start + h.listPageSize
+		next = encodeCursor(map[string]string{"offset": strconv.Itoa(end)})
+	}
+	return start, end, next, nil
+}
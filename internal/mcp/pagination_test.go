@@ -0,0 +1,166 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestApplyPaginationCursor(t *testing.T) {
+	cursor := encodeCursor(map[string]string{"page": "3", "per_page": "50"})
+	if cursor == "" {
+		t.Fatal("Expected non-empty cursor")
+	}
+
+	args := map[string]interface{}{
+		"username": "octocat",
+		"page":     float64(1),
+		"cursor":   cursor,
+	}
+
+	if err := applyPaginationCursor(args); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if args["page"] != float64(3) {
+		t.Errorf("Expected page 3, got %v", args["page"])
+	}
+	if _, exists := args["per_page"]; exists {
+		t.Error("Expected per_page not to be carried by the cursor")
+	}
+	if _, exists := args["cursor"]; exists {
+		t.Error("Expected cursor argument to be removed")
+	}
+}
+
+func TestApplyPaginationCursor_Malformed(t *testing.T) {
+	args := map[string]interface{}{"cursor": "not a cursor!"}
+
+	if err := applyPaginationCursor(args); err == nil {
+		t.Error("Expected error for malformed cursor")
+	}
+}
+
+func TestListEnvelope_Pagination(t *testing.T) {
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mocks.MockResponse(http.StatusOK, `{"total_count":95,"installations":[{"id":1}]}`, map[string]string{
+				"Content-Type": "application/json",
+				"Link":         `<https://api.github.com/user/installations?page=3&per_page=10>; rel="next", <https://api.github.com/user/installations?page=10&per_page=10>; rel="last"`,
+			}), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+
+	result, err := h.executeListAppInstallations(context.Background(), map[string]interface{}{"page": float64(2), "per_page": float64(10)})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected failure: %v %+v", err, result)
+	}
+	var envelope struct {
+		Pagination paginationInfo `json:"pagination"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &envelope); err != nil {
+		t.Fatalf("Expected a list envelope, got %q", result.Content[0].Text)
+	}
+	want := paginationInfo{Page: 2, PerPage: 10, HasNext: true, NextPage: 3, LastPage: 10, Total: 95, NextCursor: encodeCursor(map[string]string{"page": "3"})}
+	if envelope.Pagination != want {
+		t.Errorf("Expected %+v, got %+v", want, envelope.Pagination)
+	}
+
+	last := h.newListEnvelope(nil, []int{1}, &client.PageInfo{}, listPage{})
+	if last.Pagination != (paginationInfo{Page: 1, PerPage: githubDefaultPerPage}) {
+		t.Errorf("Expected the first and last page of the default size, got %+v", last.Pagination)
+	}
+}
+
+func TestFetchAll_ListTeams(t *testing.T) {
+	var pages []string
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			page := req.URL.Query().Get("page")
+			pages = append(pages, page+"/"+req.URL.Query().Get("per_page"))
+			resp := mocks.MockJSONResponse(200, `[{"id": `+page+`, "slug": "team-`+page+`"}]`)
+			if page != "3" {
+				next, _ := strconv.Atoi(page)
+				resp.Header.Set("Link", `<https://api.github.com/orgs/octo-org/teams?page=`+strconv.Itoa(next+1)+`&per_page=100>; rel="next"`)
+			}
+			return resp, nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetFetchAllMaxPages(2)
+
+	result, err := h.executeListTeams(context.Background(), map[string]interface{}{"org": "octo-org", "fetch_all": true})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected failure: %v %+v", err, result)
+	}
+	if strings.Join(pages, ",") != "1/100,2/100" {
+		t.Errorf("Expected the first 2 pages of 100, got %v", pages)
+	}
+	text := result.Content[0].Text
+	if !strings.Contains(text, `"slug":"team-1"`) || !strings.Contains(text, `"slug":"team-2"`) {
+		t.Errorf("Expected the teams of both pages, got %q", text)
+	}
+	if !strings.Contains(text, `"has_next":true,"next_page":3`) {
+		t.Errorf("Expected pagination to report the page after the cap, got %q", text)
+	}
+
+	tool := h.findTool("list_teams")
+	if _, ok := schemaProperties(tool.InputSchema)[fetchAllArgument]; !ok {
+		t.Error("Expected list_teams to accept fetch_all")
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.httpSession.setInitialized()
+	h.SetListPageSize(10)
+
+	var names []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > len(h.Tools()) {
+			t.Fatal("Expected the tool list to end")
+		}
+		params := map[string]interface{}{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		resp := h.handleListTools(context.Background(), NewRequest(1, MethodListTools, params))
+		if resp.Error != nil {
+			t.Fatalf("Unexpected error: %+v", resp.Error)
+		}
+		result := resp.Result.(ToolsListResult)
+		if len(result.Tools) > 10 {
+			t.Fatalf("Expected at most 10 tools per page, got %d", len(result.Tools))
+		}
+		for _, tool := range result.Tools {
+			names = append(names, tool.Name)
+		}
+		if cursor = result.NextCursor; cursor == "" {
+			break
+		}
+	}
+	if len(names) != len(h.Tools()) {
+		t.Errorf("Expected every tool across pages, got %d of %d", len(names), len(h.Tools()))
+	}
+
+	resp := h.handleListResources(context.Background(), NewRequest(2, MethodListResources, map[string]interface{}{"cursor": "not a cursor"}))
+	if resp.Error == nil || resp.Error.Code != ErrorCodeInvalidParams {
+		t.Errorf("Expected an invalid cursor to be rejected, got %+v", resp.Error)
+	}
+
+	h.SetListPageSize(0)
+	resp = h.handleListResources(context.Background(), NewRequest(3, MethodListResources, nil))
+	if result := resp.Result.(ResourcesListResult); len(result.Resources) != len(h.resources) || result.NextCursor != "" {
+		t.Errorf("Expected every resource in one page, got %d with cursor %q", len(result.Resources), result.NextCursor)
+	}
+}
@@ -0,0 +1,257 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/auth"
+)
+
+// PolicyEffect is what a tool policy decides for a call
+type PolicyEffect string
+
+const (
+	// PolicyAllow runs the call
+	PolicyAllow PolicyEffect = "allow"
+	// PolicyDeny rejects the call
+	PolicyDeny PolicyEffect = "deny"
+	// PolicyConfirm runs the call once the user confirms it, through
+	// clients supporting elicitation, and rejects it for other clients
+	PolicyConfirm PolicyEffect = "confirm"
+)
+
+// ToolPolicy decides whether tool calls may run. The first rule matching a
+// call decides it; calls no rule matches get the default effect, allow
+// unless set.
+type ToolPolicy struct {
+	Rules   []PolicyRule `yaml:"rules"`
+	Default PolicyEffect `yaml:"default"`
+}
+
+// PolicyRule matches tool calls. Every condition set must hold; unset ones
+// match every call. Patterns use path.Match syntax, such as delete_*.
+type PolicyRule struct {
+	Effect PolicyEffect `yaml:"effect"`
+	// Tools are patterns of the tool names, or names of toolsets
+	Tools []string `yaml:"tools"`
+	// Access is read for the tools that only read data and write for the
+	// others
+	Access string `yaml:"access"`
+	// Subjects are patterns of the authenticated caller's identity; calls
+	// without one match anonymous
+	Subjects []string `yaml:"subjects"`
+	// Clients are patterns of the MCP client names sent on initialize.
+	// Clients name themselves, so these only tell honest clients apart.
+	Clients []string `yaml:"clients"`
+	// Arguments map argument names to patterns of their values, matched
+	// regardless of case as GitHub names are
+	Arguments map[string]string `yaml:"arguments"`
+	// Reason is told to the caller when the rule denies or asks to
+	// confirm a call
+	Reason string `yaml:"reason"`
+}
+
+// PolicyInput is what a tool policy knows of a call
+type PolicyInput struct {
+	Tool      string
+	Access    string
+	Subject   string
+	Client    string
+	Arguments map[string]interface{}
+}
+
+// PolicyDecision is the effect a tool policy decided for a call and why
+type PolicyDecision struct {
+	Effect PolicyEffect
+	Reason string
+}
+
+// LoadToolPolicy reads a tool policy from a YAML file
+func LoadToolPolicy(file string) (*ToolPolicy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var policy ToolPolicy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", file, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", file, err)
+	}
+	return &policy, nil
+}
+
+// validate checks the effects, access classes and patterns of the policy
+func (p *ToolPolicy) validate() error {
+	if p.Default == "" {
+		p.Default = PolicyAllow
+	}
+	if !validEffect(p.Default) {
+		return fmt.Errorf("unknown default effect %q", p.Default)
+	}
+
+	for i, rule := range p.Rules {
+		if !validEffect(rule.Effect) {
+			return fmt.Errorf("rule %d: unknown effect %q", i+1, rule.Effect)
+		}
+		if rule.Access != "" && rule.Access != "read" && rule.Access != "write" {
+			return fmt.Errorf("rule %d: access must be read or write, got %q", i+1, rule.Access)
+		}
+
+		patterns := append(append(append([]string{}, rule.Tools...), rule.Subjects...), rule.Clients...)
+		for _, pattern := range rule.Arguments {
+			patterns = append(patterns, pattern)
+		}
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("rule %d: invalid pattern %q", i+1, pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// validEffect reports whether effect is a known policy effect
+func validEffect(effect PolicyEffect) bool {
+	return effect == PolicyAllow || effect == PolicyDeny || effect == PolicyConfirm
+}
+
+// Decide returns the effect of the first rule matching the call, or the
+// default effect
+func (p *ToolPolicy) Decide(input PolicyInput) PolicyDecision {
+	for _, rule := range p.Rules {
+		if rule.matches(input) {
+			return PolicyDecision{Effect: rule.Effect, Reason: rule.Reason}
+		}
+	}
+	return PolicyDecision{Effect: p.Default}
+}
+
+// matches reports whether every condition of the rule holds for the call
+func (r *PolicyRule) matches(input PolicyInput) bool {
+	if len(r.Tools) > 0 && !matchesToolPattern(r.Tools, input.Tool) {
+		return false
+	}
+	if r.Access != "" && r.Access != input.Access {
+		return false
+	}
+	if len(r.Subjects) > 0 && !matchesAnyPattern(r.Subjects, input.Subject) {
+		return false
+	}
+	if len(r.Clients) > 0 && !matchesAnyPattern(r.Clients, input.Client) {
+		return false
+	}
+	for name, pattern := range r.Arguments {
+		value, ok := input.Arguments[name]
+		if !ok {
+			return false
+		}
+		if matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(fmt.Sprint(value))); !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesToolPattern reports whether the named tool matches a pattern or
+// belongs to a toolset of patterns
+func matchesToolPattern(patterns []string, tool string) bool {
+	if matchesAnyPattern(patterns, tool) {
+		return true
+	}
+	set := toolsetOf(tool)
+	return set != nil && matchesAnyPattern(patterns, set.name)
+}
+
+// matchesAnyPattern reports whether value matches one of patterns
+func matchesAnyPattern(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// SetToolPolicy makes policy decide whether each tool call may run, from
+// the tool, its arguments, whether it changes data and who calls it. A nil
+// policy allows every call.
+func (h *Handler) SetToolPolicy(policy *ToolPolicy) {
+	h.policy.Store(policy)
+}
+
+// policyInput describes a call for the tool policy
+func (h *Handler) policyInput(ctx context.Context, toolName string, args map[string]interface{}) PolicyInput {
+	input := PolicyInput{Tool: toolName, Access: "write", Subject: "anonymous", Arguments: args}
+	if readOnlyTool(toolName) {
+		input.Access = "read"
+	}
+	if toolName == apiRequestTool {
+		// github_api_request sends a GET when no method is given
+		if method, _ := args["method"].(string); method == "" || strings.EqualFold(method, "GET") {
+			input.Access = "read"
+		}
+	}
+	if identity, ok := auth.IdentityFromContext(ctx); ok && identity.Subject != "" {
+		input.Subject = identity.Subject
+	}
+	input.Client = h.session(ctx).ClientInfo().Name
+	return input
+}
+
+// authorizeCall applies the tool policy to a call, then asks to confirm
+// the calls it allows to destructive tools as confirmCall does. It returns
+// the arguments to call the tool with, or the result to respond with when
+// the call must not run.
+func (h *Handler) authorizeCall(ctx context.Context, tool *Tool, args map[string]interface{}) (map[string]interface{}, *CallToolResult) {
+	policy := h.policy.Load()
+	if policy == nil {
+		return h.confirmCall(ctx, tool, args)
+	}
+
+	var confirmed map[string]interface{}
+	var declined *CallToolResult
+	decision := policy.Decide(h.policyInput(ctx, tool.Name, args))
+	switch decision.Effect {
+	case PolicyDeny:
+		return nil, h.policyDenial(ctx, tool, decision)
+	case PolicyConfirm:
+		reason := decision.Reason
+		if reason == "" {
+			reason = h.messages.Sprintf("The server's policy requires confirming this call")
+		}
+		confirmed, declined = h.requireConfirmation(ctx, tool, args, reason)
+	default:
+		confirmed, declined = h.confirmCall(ctx, tool, args)
+	}
+	if declined != nil || len(confirmed) == len(args) {
+		return confirmed, declined
+	}
+
+	// The user filled in arguments the policy has not seen yet
+	if decision := policy.Decide(h.policyInput(ctx, tool.Name, confirmed)); decision.Effect == PolicyDeny {
+		return nil, h.policyDenial(ctx, tool, decision)
+	}
+	return confirmed, nil
+}
+
+// policyDenial is the result of a call the tool policy denies
+func (h *Handler) policyDenial(ctx context.Context, tool *Tool, decision PolicyDecision) *CallToolResult {
+	h.logger.WithContext(ctx).Info("Tool call denied by policy", "name", tool.Name, "reason", decision.Reason)
+	text := h.messages.Sprintf("%s was not run, the server's policy denies it", tool.Name)
+	if decision.Reason != "" {
+		text = h.messages.Sprintf("%s was not run, the server's policy denies it: %s", tool.Name, decision.Reason)
+	}
+	return &CallToolResult{Content: []Content{{Type: "text", Text: text}}, IsError: true}
+}
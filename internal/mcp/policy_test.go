@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/auth"
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+const testPolicy = `
+default: allow
+rules:
+  - effect: allow
+    subjects: ["release-*"]
+  - effect: deny
+    tools: ["delete_*", "apps"]
+    reason: Deletions go through the platform team
+  - effect: confirm
+    access: write
+    arguments:
+      org: "prod-*"
+  - effect: deny
+    clients: ["untrusted-*"]
+`
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+	return file
+}
+
+func TestToolPolicy_Decide(t *testing.T) {
+	policy, err := LoadToolPolicy(writePolicy(t, testPolicy))
+	if err != nil {
+		t.Fatalf("LoadToolPolicy failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input PolicyInput
+		want  PolicyEffect
+	}{
+		{name: "default", input: PolicyInput{Tool: "get_user", Access: "read", Subject: "anonymous"}, want: PolicyAllow},
+		{name: "tool pattern", input: PolicyInput{Tool: "delete_team", Access: "write", Subject: "anonymous"}, want: PolicyDeny},
+		{name: "toolset", input: PolicyInput{Tool: "list_app_installations", Access: "read", Subject: "anonymous"}, want: PolicyDeny},
+		{name: "first rule wins", input: PolicyInput{Tool: "delete_team", Access: "write", Subject: "release-bot"}, want: PolicyAllow},
+		{name: "arguments", input: PolicyInput{Tool: "update_team", Access: "write", Subject: "anonymous", Arguments: map[string]interface{}{"org": "prod-eu"}}, want: PolicyConfirm},
+		{name: "arguments case", input: PolicyInput{Tool: "update_team", Access: "write", Subject: "anonymous", Arguments: map[string]interface{}{"org": "Prod-EU"}}, want: PolicyConfirm},
+		{name: "arguments read", input: PolicyInput{Tool: "get_team", Access: "read", Subject: "anonymous", Arguments: map[string]interface{}{"org": "prod-eu"}}, want: PolicyAllow},
+		{name: "missing argument", input: PolicyInput{Tool: "update_team", Access: "write", Subject: "anonymous"}, want: PolicyAllow},
+		{name: "client", input: PolicyInput{Tool: "get_user", Access: "read", Subject: "anonymous", Client: "untrusted-cli"}, want: PolicyDeny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Decide(tt.input); got.Effect != tt.want {
+				t.Errorf("Expected %s, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPolicyInput_APIRequestAccess(t *testing.T) {
+	h := NewHandler(client.NewGitHubClient("test-token", createTestLogger()), createTestLogger())
+	ctx := WithSession(context.Background(), NewSession(TransportStdio))
+
+	for method, want := range map[string]string{"": "read", "get": "read", "POST": "write", "DELETE": "write"} {
+		args := map[string]interface{}{"path": "/user"}
+		if method != "" {
+			args["method"] = method
+		}
+		if got := h.policyInput(ctx, apiRequestTool, args).Access; got != want {
+			t.Errorf("Expected method %q to be %s access, got %s", method, want, got)
+		}
+	}
+}
+
+func TestLoadToolPolicy_Invalid(t *testing.T) {
+	for _, content := range []string{
+		"rules:\n  - effect: maybe\n",
+		"rules:\n  - effect: deny\n    access: admin\n",
+		"rules:\n  - effect: deny\n    tools: [\"[\"]\n",
+		"rules:\n  - effect: deny\n    tool: delete_team\n",
+		"default: block\n",
+	} {
+		if _, err := LoadToolPolicy(writePolicy(t, content)); err == nil {
+			t.Errorf("Expected policy %q to be rejected", content)
+		}
+	}
+
+	policy, err := LoadToolPolicy(writePolicy(t, ""))
+	if err != nil || policy.Decide(PolicyInput{Tool: "delete_team"}).Effect != PolicyAllow {
+		t.Errorf("Expected an empty policy to allow every call, got %+v (%v)", policy, err)
+	}
+}
+
+func TestAuthorizeCall(t *testing.T) {
+	var requests []string
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			return mocks.MockJSONResponse(http.StatusOK, `{"slug": "dev"}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	policy, err := LoadToolPolicy(writePolicy(t, testPolicy))
+	if err != nil {
+		t.Fatalf("LoadToolPolicy failed: %v", err)
+	}
+	h.SetToolPolicy(policy)
+
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	call := func(ctx context.Context, name string, args map[string]interface{}) *CallToolResult {
+		params, _ := json.Marshal(CallToolRequest{Name: name, Arguments: args})
+		var raw interface{}
+		json.Unmarshal(params, &raw)
+		response := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: raw})
+		if response.Error != nil {
+			t.Fatalf("Unexpected error: %+v", response.Error)
+		}
+		return response.Result.(*CallToolResult)
+	}
+
+	result := call(ctx, "delete_team", map[string]interface{}{"org": "acme", "team_slug": "dev"})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "platform team") || len(requests) != 0 {
+		t.Errorf("Expected the policy to deny the call with its reason, got %+v after %v", result, requests)
+	}
+
+	result = call(ctx, "update_team", map[string]interface{}{"org": "prod-eu", "team_slug": "dev", "name": "Dev"})
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "cannot ask the user") || len(requests) != 0 {
+		t.Errorf("Expected a call needing confirmation to fail without elicitation, got %+v after %v", result, requests)
+	}
+
+	botCtx := auth.WithIdentity(ctx, &auth.Identity{Subject: "release-bot", Method: "token"})
+	result = call(botCtx, "delete_team", map[string]interface{}{"org": "acme", "team_slug": "dev"})
+	if result.IsError || len(requests) == 0 {
+		t.Errorf("Expected the policy to allow the release bot, got %+v after %v", result, requests)
+	}
+}
@@ -0,0 +1,238 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// prefetchUsageWindow is how long a tool call counts towards its popularity
+	prefetchUsageWindow = 10 * time.Minute
+	// prefetchMinHits is the number of calls within the window that makes a call hot
+	prefetchMinHits = 2
+	// prefetchMaxEntries bounds the number of calls refreshed per cycle
+	prefetchMaxEntries = 10
+	// prefetchMinBudget is the fraction of the rate limit that must remain for prefetching to run
+	prefetchMinBudget = 0.5
+	// prefetchCallTimeout bounds a single background refresh
+	prefetchCallTimeout = 30 * time.Second
+)
+
+// localTools answer from server state rather than GitHub
+var localTools = map[string]bool{
+	"list_recent_deletions": true,
+	"find_resource":         true,
+}
+
+// unprefetchedTools only read data from GitHub, but their results are not
+// worth caching and refreshing in the background: too large, or written by
+// the client's model
+var unprefetchedTools = map[string]bool{
+	"compare_commits":        true,
+	"summarize_issue_thread": true,
+	"summarize_pr_diff":      true,
+}
+
+// prefetchableTool reports whether a tool only reads data from GitHub, so its
+// results can be cached and refreshed in the background
+func prefetchableTool(name string) bool {
+	if localTools[name] || unprefetchedTools[name] {
+		return false
+	}
+	return strings.HasPrefix(name, "get_") || strings.HasPrefix(name, "list_") || strings.HasPrefix(name, "check_")
+}
+
+// toolUsage tracks how often a tool has been called with the same arguments
+type toolUsage struct {
+	tool     string
+	args     map[string]interface{}
+	hits     int
+	lastUsed time.Time
+}
+
+// prefetcher caches read-only tool results and keeps the most frequently
+// used ones warm by refreshing them while the rate limit budget allows
+type prefetcher struct {
+	handler  *Handler
+	interval time.Duration
+	results  *ttlCache[*CallToolResult]
+
+	mu    sync.Mutex
+	usage map[string]*toolUsage
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newPrefetcher creates a prefetcher refreshing hot calls every interval
+func newPrefetcher(h *Handler, interval time.Duration) *prefetcher {
+	return &prefetcher{
+		handler:  h,
+		interval: interval,
+		results:  newTTLCache[*CallToolResult](),
+		usage:    make(map[string]*toolUsage),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// prefetchKey identifies a tool call; map keys are marshaled in sorted order
+func prefetchKey(tool string, args map[string]interface{}) string {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	return tool + " " + string(data)
+}
+
+// lookup records a call and returns its cached result, if any
+func (p *prefetcher) lookup(tool string, args map[string]interface{}) (*CallToolResult, bool) {
+	key := prefetchKey(tool, args)
+	if key == "" {
+		return nil, false
+	}
+
+	p.mu.Lock()
+	usage, ok := p.usage[key]
+	if !ok {
+		usage = &toolUsage{tool: tool, args: args}
+		p.usage[key] = usage
+	}
+	usage.hits++
+	usage.lastUsed = time.Now()
+	p.mu.Unlock()
+
+	return p.results.get(key)
+}
+
+// cached reports whether a result is cached, without recording a call
+func (p *prefetcher) cached(tool string, args map[string]interface{}) bool {
+	key := prefetchKey(tool, args)
+	if key == "" {
+		return false
+	}
+	_, ok := p.results.get(key)
+	return ok
+}
+
+// store caches a successful result
+func (p *prefetcher) store(tool string, args map[string]interface{}, result *CallToolResult) {
+	if result == nil || result.IsError {
+		return
+	}
+	if key := prefetchKey(tool, args); key != "" {
+		p.results.set(key, result, p.handler.CacheTTL())
+	}
+}
+
+// hotCalls returns the most frequently used calls within the usage window,
+// forgetting calls that fell out of it
+func (p *prefetcher) hotCalls(now time.Time) []*toolUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var hot []*toolUsage
+	for key, usage := range p.usage {
+		if now.Sub(usage.lastUsed) > prefetchUsageWindow {
+			delete(p.usage, key)
+			continue
+		}
+		if usage.hits >= prefetchMinHits {
+			hot = append(hot, &toolUsage{tool: usage.tool, args: usage.args, hits: usage.hits, lastUsed: usage.lastUsed})
+		}
+	}
+
+	sort.Slice(hot, func(i, j int) bool {
+		if hot[i].hits != hot[j].hits {
+			return hot[i].hits > hot[j].hits
+		}
+		return hot[i].lastUsed.After(hot[j].lastUsed)
+	})
+	if len(hot) > prefetchMaxEntries {
+		hot = hot[:prefetchMaxEntries]
+	}
+	return hot
+}
+
+// hasBudget reports whether enough of the rate limit remains to spend on prefetching
+func (p *prefetcher) hasBudget() bool {
+	remaining, limit, ok := p.handler.githubClient.RateLimitBudget()
+	if !ok || limit <= 0 {
+		// Nothing is known about the budget until the first interactive call
+		return false
+	}
+	return float64(remaining)/float64(limit) >= prefetchMinBudget
+}
+
+// refresh re-executes the hot calls and caches their results
+func (p *prefetcher) refresh() {
+	if !p.hasBudget() {
+		p.handler.logger.Debug("Skipping prefetch, rate limit budget is low")
+		return
+	}
+
+	for _, usage := range p.hotCalls(time.Now()) {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), prefetchCallTimeout)
+		result, err := p.handler.refreshTool(ctx, usage.tool, usage.args)
+		cancel()
+		if err != nil {
+			p.handler.logger.Debug("Prefetch failed", "tool", usage.tool, "error", err)
+			continue
+		}
+		p.store(usage.tool, usage.args, result)
+
+		if !p.hasBudget() {
+			return
+		}
+	}
+}
+
+// run refreshes hot calls every interval until stopped
+func (p *prefetcher) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.refresh()
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// StartPrefetcher caches read-only tool results and refreshes frequently used
+// calls in the background every interval while at least half of the GitHub
+// rate limit remains. It does nothing if caching is disabled.
+func (h *Handler) StartPrefetcher(interval time.Duration) {
+	if interval <= 0 || h.CacheTTL() <= 0 || h.prefetch != nil {
+		return
+	}
+
+	h.prefetch = newPrefetcher(h, interval)
+	go h.prefetch.run()
+	h.logger.Info("Background prefetching enabled", "interval", interval, "cache_ttl", h.CacheTTL())
+}
+
+// StopPrefetcher stops background prefetching
+func (h *Handler) StopPrefetcher() {
+	if h.prefetch == nil {
+		return
+	}
+
+	close(h.prefetch.stop)
+	<-h.prefetch.done
+}
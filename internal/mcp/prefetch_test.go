@@ -0,0 +1,172 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestPrefetcherHotCalls(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	p := newPrefetcher(h, time.Minute)
+
+	orgArgs := map[string]interface{}{"org": "octo-org"}
+	userArgs := map[string]interface{}{"username": "octocat"}
+	p.lookup("get_organization", orgArgs)
+	p.lookup("get_organization", map[string]interface{}{"org": "octo-org"})
+	p.lookup("get_user", userArgs)
+
+	hot := p.hotCalls(time.Now())
+	if len(hot) != 1 || hot[0].tool != "get_organization" || hot[0].hits != 2 {
+		t.Fatalf("Expected get_organization to be the only hot call, got %+v", hot)
+	}
+
+	if hot := p.hotCalls(time.Now().Add(prefetchUsageWindow + time.Minute)); len(hot) != 0 {
+		t.Errorf("Expected calls outside the usage window to be forgotten, got %+v", hot)
+	}
+	if len(p.usage) != 0 {
+		t.Errorf("Expected usage to be pruned, got %d entries", len(p.usage))
+	}
+}
+
+func TestPrefetcherRefresh(t *testing.T) {
+	var requests atomic.Int32
+	remaining := "4000"
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests.Add(1)
+			return mocks.MockResponse(200, `{"login": "octo-org"}`, map[string]string{
+				"Content-Type":          "application/json",
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": remaining,
+			}), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(mockClient)
+	h := NewHandler(githubClient, createTestLogger())
+	p := newPrefetcher(h, time.Minute)
+
+	args := map[string]interface{}{"org": "octo-org"}
+	p.lookup("get_organization", args)
+	p.lookup("get_organization", args)
+
+	// Without a known rate limit budget nothing is prefetched
+	p.refresh()
+	if requests.Load() != 0 {
+		t.Fatalf("Expected no requests before the budget is known, got %d", requests.Load())
+	}
+
+	if _, err := githubClient.Get(t.Context(), "/rate_limit", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	requests.Store(0)
+
+	p.refresh()
+	if requests.Load() != 1 {
+		t.Fatalf("Expected hot call to be refreshed once, got %d requests", requests.Load())
+	}
+	if result, ok := p.lookup("get_organization", args); !ok || result.IsError {
+		t.Errorf("Expected refreshed result to be cached, got %+v", result)
+	}
+
+	// Refreshing stops once less than half the budget remains
+	remaining = "100"
+	if _, err := githubClient.Get(t.Context(), "/rate_limit", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	requests.Store(0)
+
+	p.refresh()
+	if requests.Load() != 0 {
+		t.Errorf("Expected no prefetching with a low budget, got %d requests", requests.Load())
+	}
+}
+
+func TestPrefetcherMiddleware(t *testing.T) {
+	var requests atomic.Int32
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests.Add(1)
+			return mocks.MockResponse(200, `{"login": "octo-org"}`, map[string]string{
+				"Content-Type":          "application/json",
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "4000",
+			}), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetCacheTTL(time.Minute)
+	h.prefetch = newPrefetcher(h, time.Minute)
+
+	var calls atomic.Int32
+	h.UseToolMiddleware(func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, call ToolCall) (*CallToolResult, error) {
+			calls.Add(1)
+			return next(ctx, call)
+		}
+	})
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	for i := 0; i < 2; i++ {
+		resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: i, Method: MethodCallTool, Params: map[string]interface{}{
+			"name":      "get_organization",
+			"arguments": map[string]interface{}{"org": "octo-org"},
+		}})
+		if result, ok := resp.Result.(*CallToolResult); !ok || result.IsError {
+			t.Fatalf("Expected the organization, got %+v", resp)
+		}
+	}
+	if requests.Load() != 1 || calls.Load() != 2 {
+		t.Errorf("Expected the cache hit to pass the middleware, got %d requests and %d middleware calls", requests.Load(), calls.Load())
+	}
+
+	h.prefetch.refresh()
+	if requests.Load() != 2 || calls.Load() != 2 {
+		t.Errorf("Expected the refresh to skip the middleware, got %d requests and %d middleware calls", requests.Load(), calls.Load())
+	}
+}
+
+func TestPrefetcherSkipsLocalTools(t *testing.T) {
+	if prefetchableTool("list_recent_deletions") {
+		t.Error("Expected list_recent_deletions not to be prefetchable")
+	}
+
+	h := NewHandler(client.NewGitHubClient("test-token", createTestLogger()), createTestLogger())
+	h.SetCacheTTL(time.Minute)
+	h.prefetch = newPrefetcher(h, time.Minute)
+	h.SetSafeDelete(true, "")
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	list := func(id int) string {
+		resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: id, Method: MethodCallTool, Params: map[string]interface{}{
+			"name":      "list_recent_deletions",
+			"arguments": map[string]interface{}{},
+		}})
+		result, ok := resp.Result.(*CallToolResult)
+		if !ok || result.IsError {
+			t.Fatalf("Expected the deletions, got %+v", resp)
+		}
+		return result.Content[0].Text
+	}
+
+	list(1)
+	if err := h.deletions.add(&DeletionRecord{Tool: "delete_file", Object: "octocat/hello-world/README.md"}); err != nil {
+		t.Fatalf("Failed to record deletion: %v", err)
+	}
+	if text := list(2); !strings.Contains(text, "octocat/hello-world/README.md") {
+		t.Errorf("Expected the new deletion rather than a cached listing, got %s", text)
+	}
+}
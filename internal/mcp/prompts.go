@@ -0,0 +1,178 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// builtinPrompt is a prompt template served by prompts/get
+type builtinPrompt struct {
+	Prompt
+	// render fills the template in with arguments, which hold every
+	// required argument, naming tools by the names tool serves them under
+	render func(args map[string]string, tool func(string) string) (string, error)
+}
+
+// prompts lists the built-in prompts
+var prompts = []builtinPrompt{
+	{
+		Prompt: Prompt{
+			Name:        "summarize_pull_request",
+			Description: "Summarize the purpose, changes, risks and review status of a pull request",
+			Arguments: []PromptArgument{
+				{Name: "owner", Description: "Repository owner", Required: true},
+				{Name: "repo", Description: "Repository name", Required: true},
+				{Name: "pull_number", Description: "Pull request number", Required: true},
+			},
+		},
+		render: func(args map[string]string, tool func(string) string) (string, error) {
+			if _, err := strconv.Atoi(args["pull_number"]); err != nil {
+				return "", fmt.Errorf("pull_number must be a number")
+			}
+			return fmt.Sprintf(`Summarize pull request #%s in %s/%s.
+
+Read the pull request, its changed files, its commits and its review comments. Then write:
+1. One or two sentences on what the pull request does and why.
+2. The main changes, grouped by area of the codebase.
+3. Risks: behavior changes, missing tests, migrations or breaking changes.
+4. Review status: approvals, requested changes and open discussion.
+
+Keep it short, and quote file paths rather than whole diffs.`, args["pull_number"], args["owner"], args["repo"]), nil
+		},
+	},
+	{
+		Prompt: Prompt{
+			Name:        "triage_issues",
+			Description: "Triage the open issues of a repository, proposing labels, duplicates and issues to close",
+			Arguments: []PromptArgument{
+				{Name: "owner", Description: "Repository owner", Required: true},
+				{Name: "repo", Description: "Repository name", Required: true},
+				{Name: "label", Description: "Only triage open issues with this label"},
+			},
+		},
+		render: func(args map[string]string, tool func(string) string) (string, error) {
+			scope := "the open issues"
+			if label := args["label"]; label != "" {
+				scope = fmt.Sprintf("the open issues labeled %q", label)
+			}
+			return fmt.Sprintf(`Triage %s in %s/%s.
+
+For every issue, propose:
+- labels for its kind (bug, feature, question, documentation) and priority
+- whether it duplicates another issue, naming it
+- whether it is stale, already fixed or lacking the information needed to act on it
+
+Present the proposals as a table grouped by action. Do not change any issue until I confirm; then apply the confirmed changes with %s.`, scope, args["owner"], args["repo"], tool("bulk_update_issues")), nil
+		},
+	},
+	{
+		Prompt: Prompt{
+			Name:        "draft_release_notes",
+			Description: "Draft release notes from the pull requests merged since a tag or date",
+			Arguments: []PromptArgument{
+				{Name: "owner", Description: "Repository owner", Required: true},
+				{Name: "repo", Description: "Repository name", Required: true},
+				{Name: "since", Description: "Tag or date (YYYY-MM-DD) of the previous release", Required: true},
+				{Name: "version", Description: "Version being released"},
+			},
+		},
+		render: func(args map[string]string, tool func(string) string) (string, error) {
+			title := "the next release"
+			if version := args["version"]; version != "" {
+				title = version
+			}
+			return fmt.Sprintf(`Draft release notes for %s of %s/%s from the pull requests merged since %s.
+
+Group the changes under "Breaking changes", "Features", "Fixes" and "Other", leaving out empty groups. Write one line per change in the user's terms, ending with the pull request number and author, such as "(#123, @octocat)". Mention every breaking change with what users need to do. Leave out changes that only touch tests, CI or dependencies, and list first-time contributors at the end.`, title, args["owner"], args["repo"], args["since"]), nil
+		},
+	},
+	{
+		Prompt: Prompt{
+			Name:        "review_org_activity",
+			Description: "Review recent activity across an organization and point out what needs attention",
+			Arguments: []PromptArgument{
+				{Name: "org", Description: "Organization name", Required: true},
+				{Name: "days", Description: "Number of days to review (default: 30)"},
+			},
+		},
+		render: func(args map[string]string, tool func(string) string) (string, error) {
+			days := args["days"]
+			if days == "" {
+				days = "30"
+			}
+			if n, err := strconv.Atoi(days); err != nil || n <= 0 {
+				return "", fmt.Errorf("days must be a positive number")
+			}
+			return fmt.Sprintf(`Review the activity of the %s organization over the last %s days using %s.
+
+Report the most and least active repositories, how quickly issues get a first response, and contributors carrying an outsized share of the work. End with up to three concrete suggestions for what needs attention.`, args["org"], days, tool("get_org_activity_analytics")), nil
+		},
+	},
+}
+
+// findPrompt returns the built-in prompt with the given name, or nil
+func findPrompt(name string) *builtinPrompt {
+	for i := range prompts {
+		if prompts[i].Name == name {
+			return &prompts[i]
+		}
+	}
+	return nil
+}
+
+// handleListPrompts handles the prompts/list request
+func (h *Handler) handleListPrompts(ctx context.Context, msg *JSONRPCMessage) *JSONRPCMessage {
+	if !h.session(ctx).Initialized() {
+		return NewErrorResponse(msg.ID, ErrorCodeInternalError, "Server not initialized", nil)
+	}
+
+	result := PromptsListResult{Prompts: make([]Prompt, 0, len(prompts))}
+	for _, prompt := range prompts {
+		result.Prompts = append(result.Prompts, prompt.Prompt)
+	}
+
+	return NewResponse(msg.ID, result)
+}
+
+// handleGetPrompt handles the prompts/get request
+func (h *Handler) handleGetPrompt(ctx context.Context, msg *JSONRPCMessage) *JSONRPCMessage {
+	if !h.session(ctx).Initialized() {
+		return NewErrorResponse(msg.ID, ErrorCodeInternalError, "Server not initialized", nil)
+	}
+
+	var req GetPromptRequest
+	if err := msg.GetParams(&req); err != nil {
+		h.logger.WithContext(ctx).Error("Failed to parse get prompt request", "error", err)
+		return NewErrorResponse(msg.ID, ErrorCodeInvalidParams, "Invalid params", nil)
+	}
+
+	prompt := findPrompt(req.Name)
+	if prompt == nil {
+		return NewErrorResponse(msg.ID, ErrorCodeInvalidParams, fmt.Sprintf("Unknown prompt: %s", req.Name), nil)
+	}
+
+	args := make(map[string]string, len(req.Arguments))
+	for name, value := range req.Arguments {
+		args[name] = strings.TrimSpace(value)
+	}
+	for _, argument := range prompt.Arguments {
+		if argument.Required && args[argument.Name] == "" {
+			return NewErrorResponse(msg.ID, ErrorCodeInvalidParams, fmt.Sprintf("Missing required argument: %s", argument.Name), nil)
+		}
+	}
+
+	text, err := prompt.render(args, h.names.served)
+	if err != nil {
+		return NewErrorResponse(msg.ID, ErrorCodeInvalidParams, fmt.Sprintf("Invalid arguments: %v", err), nil)
+	}
+
+	return NewResponse(msg.ID, GetPromptResult{
+		Description: prompt.Description,
+		Messages: []PromptMessage{{
+			Role:    "user",
+			Content: Content{Type: "text", Text: text},
+		}},
+	})
+}
@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHandleListPrompts(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.httpSession.setInitialized()
+
+	resp := h.handleListPrompts(context.Background(), NewRequest(1, MethodListPrompts, nil))
+	if resp.Error != nil {
+		t.Fatalf("Unexpected error: %+v", resp.Error)
+	}
+	if got := len(resp.Result.(PromptsListResult).Prompts); got != len(prompts) {
+		t.Errorf("Expected %d prompts, got %d", len(prompts), got)
+	}
+}
+
+func TestHandleGetPrompt(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.httpSession.setInitialized()
+
+	tests := []struct {
+		name      string
+		params    map[string]interface{}
+		wantText  []string
+		wantError string
+	}{
+		{
+			name: "templated",
+			params: map[string]interface{}{"name": "summarize_pull_request",
+				"arguments": map[string]string{"owner": "octocat", "repo": "hello-world", "pull_number": "42"}},
+			wantText: []string{"pull request #42 in octocat/hello-world"},
+		},
+		{
+			name: "optional argument",
+			params: map[string]interface{}{"name": "triage_issues",
+				"arguments": map[string]string{"owner": "octocat", "repo": "hello-world", "label": "bug"}},
+			wantText: []string{`labeled "bug" in octocat/hello-world`, "bulk_update_issues"},
+		},
+		{
+			name:     "tool name",
+			params:   map[string]interface{}{"name": "review_org_activity", "arguments": map[string]string{"org": "octo-org"}},
+			wantText: []string{"last 30 days using get_org_activity_analytics"},
+		},
+		{
+			name:      "missing argument",
+			params:    map[string]interface{}{"name": "draft_release_notes", "arguments": map[string]string{"owner": "octocat", "repo": "hello-world"}},
+			wantError: "Missing required argument: since",
+		},
+		{
+			name: "invalid argument",
+			params: map[string]interface{}{"name": "summarize_pull_request",
+				"arguments": map[string]string{"owner": "octocat", "repo": "hello-world", "pull_number": "latest"}},
+			wantError: "pull_number must be a number",
+		},
+		{
+			name:      "unknown prompt",
+			params:    map[string]interface{}{"name": "write_code"},
+			wantError: "Unknown prompt: write_code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := h.handleGetPrompt(context.Background(), NewRequest(1, MethodGetPrompt, tt.params))
+			if tt.wantError != "" {
+				if resp.Error == nil || !strings.Contains(resp.Error.Message, tt.wantError) {
+					t.Fatalf("Expected error %q, got %+v", tt.wantError, resp.Error)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Unexpected error: %+v", resp.Error)
+			}
+			result := resp.Result.(GetPromptResult)
+			if len(result.Messages) != 1 || result.Messages[0].Role != "user" {
+				t.Fatalf("Expected one user message, got %+v", result.Messages)
+			}
+			for _, want := range tt.wantText {
+				if !strings.Contains(result.Messages[0].Content.Text, want) {
+					t.Errorf("Expected the prompt to contain %q, got %q", want, result.Messages[0].Content.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleGetPrompt_ServedToolNames(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.httpSession.setInitialized()
+	if err := h.SetToolNames("gh_", map[string]string{"bulk_update_issues": "github_bulk_update"}); err != nil {
+		t.Fatalf("SetToolNames failed: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"review_org_activity": "using gh_get_org_activity_analytics.",
+		"triage_issues":       "changes with github_bulk_update.",
+	} {
+		resp := h.handleGetPrompt(context.Background(), NewRequest(1, MethodGetPrompt, map[string]interface{}{"name": name,
+			"arguments": map[string]string{"org": "octo-org", "owner": "octocat", "repo": "hello-world"}}))
+		if resp.Error != nil {
+			t.Fatalf("Unexpected error: %+v", resp.Error)
+		}
+		if text := resp.Result.(GetPromptResult).Messages[0].Content.Text; !strings.Contains(text, want) {
+			t.Errorf("Expected %s to name the served tool, %q, got %q", name, want, text)
+		}
+	}
+}
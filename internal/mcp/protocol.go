@@ -1,6 +1,7 @@
 package mcp
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 )
@@ -26,6 +27,18 @@ const (
 	MethodListResources         = "resources/list"
 	MethodReadResource          = "resources/read"
 	MethodListResourceTemplates = "resources/templates/list"
+	MethodSubscribeResource     = "resources/subscribe"
+	MethodUnsubscribeResource   = "resources/unsubscribe"
+	MethodResourceUpdated       = "notifications/resources/updated"
+	MethodToolListChanged       = "notifications/tools/list_changed"
+	MethodCancelled             = "notifications/cancelled"
+	MethodListPrompts           = "prompts/list"
+	MethodGetPrompt             = "prompts/get"
+	MethodComplete              = "completion/complete"
+	MethodElicit                = "elicitation/create"
+	MethodListRoots             = "roots/list"
+	MethodCreateMessage         = "sampling/createMessage"
+	MethodRootsListChanged      = "notifications/roots/list_changed"
 	MethodPing                  = "ping"
 )
 
@@ -60,6 +73,8 @@ const (
 	ErrorCodeResourceNotFound = -32001
 	ErrorCodeToolNotFound     = -32002
 	ErrorCodeInvalidTool      = -32003
+	ErrorCodeServerBusy       = -32004
+	ErrorCodeDegraded         = -32005
 )
 
 // InitializeRequest represents the initialize request
@@ -74,6 +89,8 @@ type InitializeRequest struct {
 type ClientCapabilities struct {
 	Experimental map[string]interface{} `json:"experimental,omitempty"`
 	Sampling     map[string]interface{} `json:"sampling,omitempty"`
+	Elicitation  map[string]interface{} `json:"elicitation,omitempty"`
+	Roots        map[string]interface{} `json:"roots,omitempty"`
 }
 
 // ClientInfo represents client information
@@ -84,18 +101,20 @@ type ClientInfo struct {
 
 // InitializeResult represents the initialize response
 type InitializeResult struct {
-	ProtocolVersion string             `json:"protocolVersion"`
-	Capabilities    ServerCapabilities `json:"capabilities"`
-	ServerInfo      ServerInfo         `json:"serverInfo"`
-	Instructions    string             `json:"instructions,omitempty"`
+	ProtocolVersion string                 `json:"protocolVersion"`
+	Capabilities    ServerCapabilities     `json:"capabilities"`
+	ServerInfo      ServerInfo             `json:"serverInfo"`
+	Instructions    string                 `json:"instructions,omitempty"`
+	Meta            map[string]interface{} `json:"_meta,omitempty"`
 }
 
 // ServerCapabilities represents server capabilities
 type ServerCapabilities struct {
-	Tools     *ToolsCapability     `json:"tools,omitempty"`
-	Resources *ResourcesCapability `json:"resources,omitempty"`
-	Prompts   *PromptsCapability   `json:"prompts,omitempty"`
-	Logging   *LoggingCapability   `json:"logging,omitempty"`
+	Tools       *ToolsCapability       `json:"tools,omitempty"`
+	Resources   *ResourcesCapability   `json:"resources,omitempty"`
+	Prompts     *PromptsCapability     `json:"prompts,omitempty"`
+	Logging     *LoggingCapability     `json:"logging,omitempty"`
+	Completions *CompletionsCapability `json:"completions,omitempty"`
 }
 
 // ToolsCapability represents tools capability
@@ -117,6 +136,9 @@ type PromptsCapability struct {
 // LoggingCapability represents logging capability
 type LoggingCapability struct{}
 
+// CompletionsCapability represents argument completion capability
+type CompletionsCapability struct{}
+
 // ServerInfo represents server information
 type ServerInfo struct {
 	Name    string `json:"name"`
@@ -128,23 +150,36 @@ type Tool struct {
 	Name        string      `json:"name"`
 	Description string      `json:"description,omitempty"`
 	InputSchema interface{} `json:"inputSchema"`
+	// Shaped is set for tools returning their results through shapeJSON,
+	// which take the verbose argument, and the fields argument when they
+	// only read data
+	Shaped bool `json:"-"`
+}
+
+// ListToolsRequest represents a tools/list request
+type ListToolsRequest struct {
+	// Cursor is the nextCursor of the previous page
+	Cursor string `json:"cursor,omitempty"`
 }
 
 // ToolsListResult represents the result of tools/list
 type ToolsListResult struct {
-	Tools []Tool `json:"tools"`
+	Tools      []Tool `json:"tools"`
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // CallToolRequest represents a tool call request
 type CallToolRequest struct {
 	Name      string                 `json:"name"`
 	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Meta      map[string]interface{} `json:"_meta,omitempty"`
 }
 
 // CallToolResult represents the result of a tool call
 type CallToolResult struct {
-	Content []Content `json:"content"`
-	IsError bool      `json:"isError,omitempty"`
+	Content []Content              `json:"content"`
+	IsError bool                   `json:"isError,omitempty"`
+	Meta    map[string]interface{} `json:"_meta,omitempty"`
 }
 
 // Content represents content in MCP responses
@@ -153,6 +188,19 @@ type Content struct {
 	Text     string `json:"text,omitempty"`
 	Data     string `json:"data,omitempty"`
 	MimeType string `json:"mimeType,omitempty"`
+	// Resource is the embedded resource of content of type "resource"
+	Resource *ResourceContent `json:"resource,omitempty"`
+}
+
+// imageContent returns content holding an image for multimodal clients
+func imageContent(data []byte, mimeType string) Content {
+	return Content{Type: "image", Data: base64.StdEncoding.EncodeToString(data), MimeType: mimeType}
+}
+
+// embeddedResource returns content embedding a resource, so clients can pin
+// it and read it again through resources/read
+func embeddedResource(resource ResourceContent) Content {
+	return Content{Type: "resource", Resource: &resource}
 }
 
 // Resource represents an MCP resource
@@ -163,9 +211,23 @@ type Resource struct {
 	MimeType    string `json:"mimeType,omitempty"`
 }
 
+// ListResourcesRequest represents the optional filters of a resources/list
+// request
+type ListResourcesRequest struct {
+	// Pattern keeps resources whose URI or name contains it or matches it as a glob
+	Pattern string `json:"pattern,omitempty"`
+	// Owner keeps resources naming a user or organization, expanded for this owner
+	Owner string `json:"owner,omitempty"`
+	// Type keeps resources whose URI starts with github://<type>/
+	Type string `json:"type,omitempty"`
+	// Cursor is the nextCursor of the previous page
+	Cursor string `json:"cursor,omitempty"`
+}
+
 // ResourcesListResult represents the result of resources/list
 type ResourcesListResult struct {
-	Resources []Resource `json:"resources"`
+	Resources  []Resource `json:"resources"`
+	NextCursor string     `json:"nextCursor,omitempty"`
 }
 
 // ReadResourceRequest represents a resource read request
@@ -178,6 +240,23 @@ type ReadResourceResult struct {
 	Contents []ResourceContent `json:"contents"`
 }
 
+// SubscribeResourceRequest represents a resources/subscribe or
+// resources/unsubscribe request
+type SubscribeResourceRequest struct {
+	URI string `json:"uri"`
+}
+
+// CancelledParams represents the params of a cancelled notification
+type CancelledParams struct {
+	RequestID interface{} `json:"requestId"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// ResourceUpdatedParams represents the params of a resource updated notification
+type ResourceUpdatedParams struct {
+	URI string `json:"uri"`
+}
+
 // ResourceContent represents resource content
 type ResourceContent struct {
 	URI      string `json:"uri"`
@@ -199,6 +278,136 @@ type ResourceTemplatesListResult struct {
 	ResourceTemplates []ResourceTemplate `json:"resourceTemplates"`
 }
 
+// Prompt represents an MCP prompt template
+type Prompt struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+// PromptArgument represents an argument a prompt is filled in with
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// PromptsListResult represents the result of prompts/list
+type PromptsListResult struct {
+	Prompts []Prompt `json:"prompts"`
+}
+
+// GetPromptRequest represents a prompts/get request
+type GetPromptRequest struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// GetPromptResult represents the result of prompts/get
+type GetPromptResult struct {
+	Description string          `json:"description,omitempty"`
+	Messages    []PromptMessage `json:"messages"`
+}
+
+// PromptMessage represents a message of a filled in prompt
+type PromptMessage struct {
+	Role    string  `json:"role"`
+	Content Content `json:"content"`
+}
+
+// Completion reference types
+const (
+	RefPrompt   = "ref/prompt"
+	RefResource = "ref/resource"
+	// RefTool completes tool arguments; it is an extension of the protocol
+	RefTool = "ref/tool"
+)
+
+// CompleteRequest represents the completion/complete request
+type CompleteRequest struct {
+	Ref      CompleteReference `json:"ref"`
+	Argument CompleteArgument  `json:"argument"`
+	Context  CompleteContext   `json:"context,omitempty"`
+}
+
+// CompleteReference names the prompt, resource template or tool whose
+// argument is completed
+type CompleteReference struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	URI  string `json:"uri,omitempty"`
+}
+
+// CompleteArgument represents the argument being completed
+type CompleteArgument struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CompleteContext holds the arguments the client already filled in
+type CompleteContext struct {
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// CompleteResult represents the result of completion/complete
+type CompleteResult struct {
+	Completion Completion `json:"completion"`
+}
+
+// ElicitRequest represents the elicitation/create request the server sends
+// to ask the user for input through the client
+type ElicitRequest struct {
+	Message         string                 `json:"message"`
+	RequestedSchema map[string]interface{} `json:"requestedSchema"`
+}
+
+// ElicitResult represents the result of elicitation/create
+type ElicitResult struct {
+	// Action is "accept", "decline" or "cancel"
+	Action  string                 `json:"action"`
+	Content map[string]interface{} `json:"content,omitempty"`
+}
+
+// Root is a directory or repository the client works in
+type Root struct {
+	URI  string `json:"uri"`
+	Name string `json:"name,omitempty"`
+}
+
+// ListRootsResult represents the result of roots/list
+type ListRootsResult struct {
+	Roots []Root `json:"roots"`
+}
+
+// SamplingMessage is a message of a sampling conversation
+type SamplingMessage struct {
+	Role    string  `json:"role"`
+	Content Content `json:"content"`
+}
+
+// CreateMessageRequest represents the sampling/createMessage request the
+// server sends to have the client's model generate a message
+type CreateMessageRequest struct {
+	Messages     []SamplingMessage `json:"messages"`
+	SystemPrompt string            `json:"systemPrompt,omitempty"`
+	MaxTokens    int               `json:"maxTokens"`
+}
+
+// CreateMessageResult represents the result of sampling/createMessage
+type CreateMessageResult struct {
+	Role       string  `json:"role"`
+	Content    Content `json:"content"`
+	Model      string  `json:"model"`
+	StopReason string  `json:"stopReason,omitempty"`
+}
+
+// Completion lists the values an argument may be completed to
+type Completion struct {
+	Values  []string `json:"values"`
+	Total   int      `json:"total,omitempty"`
+	HasMore bool     `json:"hasMore,omitempty"`
+}
+
 // NewRequest creates a new JSON-RPC request
 func NewRequest(id interface{}, method string, params interface{}) *JSONRPCMessage {
 	return &JSONRPCMessage{
@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+)
+
+// rateLimitMetaKey is the _meta key of tool results that failed because a
+// GitHub rate limit was hit
+const rateLimitMetaKey = "github-mcp/rate_limit"
+
+// MethodRateLimitWarning notifies clients that the GitHub rate limit budget
+// of the server runs low
+const MethodRateLimitWarning = "notifications/github/rate_limit"
+
+// RateLimitedResult tells agents how long to back off after a rate limit
+type RateLimitedResult struct {
+	// Type is "primary" for an exhausted rate limit budget and "secondary"
+	// for GitHub asking clients to slow down
+	Type string `json:"type"`
+	// RetryAfterSeconds is how long to wait before calling GitHub tools
+	// again; zero when GitHub did not say
+	RetryAfterSeconds int `json:"retry_after_seconds,omitempty"`
+}
+
+// rateLimitedResult returns the error result of a tool call that failed
+// because of err, or nil when err is not a rate limit. Rate limits are
+// reported as tool errors stating the wait, so agents back off instead of
+// retrying immediately.
+func (h *Handler) rateLimitedResult(err error) *CallToolResult {
+	appErr, ok := err.(*errors.AppError)
+	if !ok || (appErr.Type != errors.ErrorTypeRateLimit && appErr.Type != errors.ErrorTypeSecondaryRateLimit) {
+		return nil
+	}
+
+	limited := RateLimitedResult{Type: "primary"}
+	if wait, ok := appErr.Context["retry_after_seconds"].(int); ok {
+		limited.RetryAfterSeconds = wait
+	}
+
+	var text string
+	switch {
+	case appErr.Type == errors.ErrorTypeSecondaryRateLimit:
+		limited.Type = "secondary"
+		text = h.messages.Sprintf("GitHub secondary rate limit exceeded (%s). Wait %d seconds before calling GitHub tools again; retrying sooner extends the limit.", appErr.Message, limited.RetryAfterSeconds)
+	case limited.RetryAfterSeconds > 0:
+		text = h.messages.Sprintf("GitHub rate limit exhausted (%s). Wait %d seconds before retrying.", appErr.Message, limited.RetryAfterSeconds)
+	default:
+		text = h.messages.Sprintf("GitHub rate limit exhausted (%s). Retry later.", appErr.Message)
+	}
+
+	return &CallToolResult{
+		Content: []Content{{Type: "text", Text: text}},
+		IsError: true,
+		Meta:    map[string]interface{}{rateLimitMetaKey: limited},
+	}
+}
+
+// RateLimitWarning is the params of a rate limit warning notification
+type RateLimitWarning struct {
+	// Resource is the budget running low, such as core or search
+	Resource  string `json:"resource"`
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	// Threshold is the percentage of the budget left that was crossed
+	Threshold int       `json:"threshold"`
+	Reset     time.Time `json:"reset"`
+	// ResetInSeconds is how long until the budget resets
+	ResetInSeconds int    `json:"reset_in_seconds"`
+	Message        string `json:"message"`
+}
+
+// rateLimitWarnings tracks the warning thresholds crossed in the current
+// window of each rate limit resource, so each is notified once
+type rateLimitWarnings struct {
+	// thresholds are percentages of the budget left, highest first
+	thresholds []int
+
+	mu     sync.Mutex
+	warned map[string]rateLimitWarned
+}
+
+// rateLimitWarned is the lowest threshold warned about in a window
+type rateLimitWarned struct {
+	reset     time.Time
+	threshold int
+}
+
+// SetRateLimitWarnings notifies clients when the percentage of the GitHub
+// rate limit budget left drops to one of thresholds; none disables it
+func (h *Handler) SetRateLimitWarnings(thresholds []int) {
+	if len(thresholds) == 0 {
+		h.rateWarnings = nil
+		return
+	}
+	sorted := append([]int(nil), thresholds...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	h.rateWarnings = &rateLimitWarnings{thresholds: sorted, warned: make(map[string]rateLimitWarned)}
+}
+
+// crossed returns the lowest threshold state reaches that was not warned
+// about in its window yet, or zero
+func (w *rateLimitWarnings) crossed(state client.RateLimitState) int {
+	if state.Limit <= 0 {
+		return 0
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	warned, ok := w.warned[state.Resource]
+	if ok && state.Reset.Before(warned.reset) {
+		return 0
+	}
+	if !state.Reset.Equal(warned.reset) {
+		warned = rateLimitWarned{reset: state.Reset}
+	}
+
+	crossed := 0
+	for _, threshold := range w.thresholds {
+		if state.Remaining*100 <= threshold*state.Limit && (warned.threshold == 0 || threshold < warned.threshold) {
+			crossed = threshold
+		}
+	}
+	if crossed == 0 {
+		return 0
+	}
+	warned.threshold = crossed
+	w.warned[state.Resource] = warned
+	return crossed
+}
+
+// warnRateLimit notifies clients when the budget of the resource a tool
+// call spent crossed a warning threshold. Only the server's budget is
+// tracked, so calls made with a caller's token never cross one.
+func (h *Handler) warnRateLimit(budget *execstats.RateLimit) {
+	warnings := h.rateWarnings
+	if warnings == nil || budget == nil || h.githubClient == nil {
+		return
+	}
+	state, ok := h.githubClient.RateLimitState(budget.Resource)
+	if !ok {
+		return
+	}
+	threshold := warnings.crossed(state)
+	if threshold == 0 {
+		return
+	}
+
+	resetIn := waitSeconds(time.Until(state.Reset))
+	warning := RateLimitWarning{
+		Resource:       state.Resource,
+		Limit:          state.Limit,
+		Remaining:      state.Remaining,
+		Threshold:      threshold,
+		Reset:          state.Reset,
+		ResetInSeconds: resetIn,
+		Message: h.messages.Sprintf("GitHub %s rate limit is below %d%%: %d of %d requests left, resetting in %d seconds.",
+			state.Resource, threshold, state.Remaining, state.Limit, resetIn),
+	}
+	h.logger.Warn("GitHub rate limit budget running low",
+		"resource", state.Resource, "remaining", state.Remaining, "limit", state.Limit, "threshold", threshold)
+	h.broadcast(NewNotification(MethodRateLimitWarning, warning))
+}
+
+// waitSeconds rounds a wait up to whole seconds, or zero when it has passed
+func waitSeconds(wait time.Duration) int {
+	if wait <= 0 {
+		return 0
+	}
+	return int((wait + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestHandleCallTool_RateLimited(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		headers  map[string]string
+		wantType string
+		wantText string
+	}{
+		{name: "secondary", message: "You have exceeded a secondary rate limit.", headers: map[string]string{"Retry-After": "45"},
+			wantType: "secondary", wantText: "Wait 45 seconds"},
+		{name: "primary", message: "API rate limit exceeded.", headers: map[string]string{"X-RateLimit-Remaining": "0"},
+			wantType: "primary", wantText: "Retry later"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			githubClient := client.NewGitHubClient("test-token", createTestLogger())
+			githubClient.SetRetryPolicy(client.RetryPolicy{MaxAttempts: 1})
+			githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					headers := map[string]string{"Content-Type": "application/json"}
+					for name, value := range tt.headers {
+						headers[name] = value
+					}
+					return mocks.MockResponse(403, `{"message": "`+tt.message+`"}`, headers), nil
+				},
+			})
+
+			h := NewHandler(githubClient, createTestLogger())
+			session := NewSession(TransportStdio)
+			session.setInitialized()
+			ctx := WithSession(context.Background(), session)
+			resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+				"name":      "get_user",
+				"arguments": map[string]interface{}{"username": "octocat"},
+			}})
+
+			if resp.Error != nil {
+				t.Fatalf("Expected a tool error result, got JSON-RPC error %+v", resp.Error)
+			}
+			result := resp.Result.(*CallToolResult)
+			if !result.IsError || !strings.Contains(result.Content[0].Text, tt.wantText) {
+				t.Errorf("Expected an error result saying %q, got %+v", tt.wantText, result)
+			}
+			limited, ok := result.Meta[rateLimitMetaKey].(RateLimitedResult)
+			if !ok || limited.Type != tt.wantType {
+				t.Errorf("Expected %s rate limit metadata, got %+v", tt.wantType, result.Meta)
+			}
+		})
+	}
+}
+
+func TestWarnRateLimit(t *testing.T) {
+	reset := time.Now().Add(30 * time.Minute)
+	remaining := 5000
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mocks.MockResponse(200, `{"login": "octocat"}`, map[string]string{
+				"Content-Type":          "application/json",
+				"X-RateLimit-Limit":     "1000",
+				"X-RateLimit-Remaining": fmt.Sprint(remaining),
+				"X-RateLimit-Reset":     fmt.Sprint(reset.Unix()),
+			}), nil
+		},
+	})
+
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetRateLimitWarnings([]int{5, 20, 10})
+	var warnings []RateLimitWarning
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	session.setNotifier(func(msg *JSONRPCMessage) {
+		if msg.Method == MethodRateLimitWarning {
+			warnings = append(warnings, msg.Params.(RateLimitWarning))
+		}
+	})
+	h.addLiveSession(session)
+	ctx := WithSession(context.Background(), session)
+
+	for _, left := range []int{500, 200, 150, 40, 30} {
+		remaining = left
+		resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+			"name":      "get_user",
+			"arguments": map[string]interface{}{"username": "octocat"},
+		}})
+		if resp.Error != nil {
+			t.Fatalf("Unexpected error: %+v", resp.Error)
+		}
+	}
+	if len(warnings) != 2 || warnings[0].Threshold != 20 || warnings[1].Threshold != 5 {
+		t.Fatalf("Expected warnings at 20%% and 5%%, got %+v", warnings)
+	}
+	if w := warnings[1]; w.Resource != client.RateLimitCore || w.Remaining != 40 || w.Limit != 1000 || w.ResetInSeconds <= 0 || !strings.Contains(w.Message, "40 of 1000") {
+		t.Errorf("Expected the budget left in the warning, got %+v", w)
+	}
+
+	// A new window warns again
+	reset = reset.Add(time.Hour)
+	remaining = 100
+	h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 2, Method: MethodCallTool, Params: map[string]interface{}{
+		"name":      "get_user",
+		"arguments": map[string]interface{}{"username": "octocat"},
+	}})
+	if len(warnings) != 3 || warnings[2].Threshold != 10 {
+		t.Errorf("Expected a warning in the new window, got %+v", warnings)
+	}
+}
@@ -0,0 +1,52 @@
+package mcp
+
+// SetReadOnly enables read-only mode, in which the tools that change data
+// are removed from the catalog and calls to them are rejected, and
+// github_api_request only sends GET requests. Read-only mode stays enabled
+// once enabled.
+func (h *Handler) SetReadOnly(enabled bool) {
+	if !enabled || h.readOnly.Swap(true) {
+		return
+	}
+
+	h.toolsMu.Lock()
+	h.tools = writableFiltered(h.tools)
+	for name, hidden := range h.hiddenToolsets {
+		h.hiddenToolsets[name] = writableFiltered(hidden)
+	}
+	h.toolsMu.Unlock()
+	h.notifyToolsChanged()
+}
+
+// ReadOnly reports whether read-only mode is enabled
+func (h *Handler) ReadOnly() bool {
+	return h.readOnly.Load()
+}
+
+// readOnlyRejects reports whether read-only mode rejects calls to the named tool
+func (h *Handler) readOnlyRejects(name string) bool {
+	return h.readOnly.Load() && !readOnlyTool(name) && name != apiRequestTool
+}
+
+// readOnlyResult returns the error result of a call rejected in read-only mode
+func (h *Handler) readOnlyResult(name string) *CallToolResult {
+	return &CallToolResult{
+		Content: []Content{{
+			Type: "text",
+			Text: h.messages.Sprintf("Error: %s changes data and the server is in read-only mode", name),
+		}},
+		IsError: true,
+	}
+}
+
+// writableFiltered returns a copy of tools without the tools read-only
+// mode rejects
+func writableFiltered(tools []Tool) []Tool {
+	kept := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		if readOnlyTool(tool.Name) || tool.Name == apiRequestTool {
+			kept = append(kept, tool)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSetReadOnly(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	if err := h.SetToolsetEnabled("teams", false); err != nil {
+		t.Fatalf("SetToolsetEnabled failed: %v", err)
+	}
+	if err := h.SetAPIAllowlist([]string{"* /repos/**"}); err != nil {
+		t.Fatalf("SetAPIAllowlist failed: %v", err)
+	}
+	h.SetReadOnly(true)
+	if !h.ReadOnly() {
+		t.Fatal("Expected read-only mode enabled")
+	}
+
+	for _, name := range []string{"update_authenticated_user", "follow_user", "create_team", "bulk_update_issues"} {
+		if h.findTool(name) != nil {
+			t.Errorf("Expected %s removed from the catalog", name)
+		}
+	}
+	for _, name := range []string{"get_user", "list_recent_deletions", "summarize_pr_diff", apiRequestTool} {
+		if h.findTool(name) == nil {
+			t.Errorf("Expected %s kept in the catalog", name)
+		}
+	}
+	if err := h.SetToolsetEnabled("teams", true); err != nil {
+		t.Fatalf("SetToolsetEnabled failed: %v", err)
+	}
+	if h.findTool("list_teams") == nil || h.findTool("delete_team") != nil {
+		t.Error("Expected a toolset enabled again to bring back only its read-only tools")
+	}
+
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+	for name, args := range map[string]map[string]interface{}{
+		"follow_user":  {"username": "octocat"},
+		apiRequestTool: {"method": "DELETE", "path": "/repos/octocat/hello-world"},
+	} {
+		resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+			"name": name, "arguments": args,
+		}})
+		result, ok := resp.Result.(*CallToolResult)
+		if !ok || !result.IsError || !strings.Contains(result.Content[0].Text, "read-only mode") {
+			t.Errorf("Expected %s rejected in read-only mode, got %+v", name, resp)
+		}
+	}
+
+	err := h.RegisterTool(Tool{Name: "reset_cache", InputSchema: map[string]interface{}{"type": "object"}}, func(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+		return &CallToolResult{}, nil
+	})
+	if err == nil {
+		t.Error("Expected a tool changing data to be refused in read-only mode")
+	}
+}
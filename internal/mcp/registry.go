@@ -0,0 +1,283 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ToolFunc executes a tool
+type ToolFunc func(ctx context.Context, args map[string]interface{}) (*CallToolResult, error)
+
+// ToolProvider is a tool the handler serves: its definition, as listed to
+// clients, and its execution
+type ToolProvider interface {
+	Definition() Tool
+	Execute(ctx context.Context, args map[string]interface{}) (*CallToolResult, error)
+}
+
+// funcTool is a ToolProvider executed by a function
+type funcTool struct {
+	tool Tool
+	fn   ToolFunc
+}
+
+// NewTool returns a ToolProvider defining tool and executed by fn
+func NewTool(tool Tool, fn ToolFunc) ToolProvider {
+	return funcTool{tool: tool, fn: fn}
+}
+
+// Definition returns the definition of the tool
+func (t funcTool) Definition() Tool {
+	return t.tool
+}
+
+// Execute runs the tool
+func (t funcTool) Execute(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	return t.fn(ctx, args)
+}
+
+// builtinTools build the tools of each toolset file, in catalog order. A
+// toolset file adds its tools by adding its builder here.
+var builtinTools = []func(h *Handler) []ToolProvider{
+	(*Handler).userTools,
+	(*Handler).organizationTools,
+	(*Handler).teamTools,
+	(*Handler).repositoryTools,
+	(*Handler).branchProtectionTools,
+	(*Handler).rulesetTools,
+	(*Handler).commitTools,
+	(*Handler).appTools,
+	(*Handler).analyticsTools,
+	(*Handler).issueTools,
+	(*Handler).dependencyTools,
+	(*Handler).deletionTools,
+	(*Handler).resourceTools,
+	(*Handler).contentsTools,
+	(*Handler).summaryTools,
+}
+
+// ToolsetState describes a toolset and whether its tools are offered
+type ToolsetState struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Enabled     bool     `json:"enabled"`
+	Tools       []string `json:"tools"`
+}
+
+// RegisterTool adds a tool executed by fn, such as one a plugin provides,
+// and notifies connected clients that the tool list changed. Tools that
+// change data get the dry_run argument like built-in ones.
+func (h *Handler) RegisterTool(tool Tool, fn ToolFunc) error {
+	if fn == nil {
+		return fmt.Errorf("a tool needs a name and a function")
+	}
+	return h.RegisterToolProvider(NewTool(tool, fn))
+}
+
+// RegisterToolProvider adds the tool of provider, as RegisterTool does
+func (h *Handler) RegisterToolProvider(provider ToolProvider) error {
+	tool := provider.Definition()
+	if tool.Name == "" {
+		return fmt.Errorf("a tool needs a name and a function")
+	}
+	if toolsetOf(tool.Name) != nil {
+		return fmt.Errorf("tool %s already exists", tool.Name)
+	}
+	if h.readOnlyRejects(tool.Name) {
+		return fmt.Errorf("tool %s changes data and the server is in read-only mode", tool.Name)
+	}
+	tools := []Tool{tool}
+	addDryRun(tools)
+
+	// Check and add under one lock, so concurrent registrations of a name
+	// cannot both succeed
+	h.toolsMu.Lock()
+	if _, exists := h.providers[tool.Name]; exists || catalogHas(h.tools, tool.Name) {
+		h.toolsMu.Unlock()
+		return fmt.Errorf("tool %s already exists", tool.Name)
+	}
+	if h.providers == nil {
+		h.providers = make(map[string]ToolProvider)
+	}
+	h.providers[tool.Name] = provider
+	h.tools = append(append([]Tool{}, h.tools...), tools...)
+	h.toolsMu.Unlock()
+
+	h.logger.Info("Tool registered", "name", tool.Name)
+	h.notifyToolsChanged()
+	return nil
+}
+
+// UnregisterTool removes a tool added with RegisterTool, reporting whether
+// it was registered
+func (h *Handler) UnregisterTool(name string) bool {
+	if toolsetOf(name) != nil || name == apiRequestTool {
+		return false
+	}
+	h.toolsMu.Lock()
+	_, ok := h.providers[name]
+	delete(h.providers, name)
+	h.toolsMu.Unlock()
+	if !ok {
+		return false
+	}
+
+	h.removeTool(name)
+	h.logger.Info("Tool unregistered", "name", name)
+	h.notifyToolsChanged()
+	return true
+}
+
+// addProviders records the providers executing tools and returns their
+// definitions, to be added to the catalog
+func (h *Handler) addProviders(providers []ToolProvider) []Tool {
+	h.toolsMu.Lock()
+	defer h.toolsMu.Unlock()
+	if h.providers == nil {
+		h.providers = make(map[string]ToolProvider)
+	}
+	tools := make([]Tool, 0, len(providers))
+	for _, provider := range providers {
+		tool := provider.Definition()
+		h.providers[tool.Name] = provider
+		tools = append(tools, tool)
+	}
+	return tools
+}
+
+// toolProvider returns the provider executing the named tool, or nil
+func (h *Handler) toolProvider(name string) ToolProvider {
+	h.toolsMu.RLock()
+	defer h.toolsMu.RUnlock()
+	return h.providers[name]
+}
+
+// SetToolsetEnabled offers or hides the tools of a toolset and notifies
+// connected clients when the tool list changed. Calls to hidden tools fail
+// as calls to unknown tools.
+func (h *Handler) SetToolsetEnabled(name string, enabled bool) error {
+	set := toolsetNamed(name)
+	if set == nil {
+		return fmt.Errorf("unknown toolset %q", name)
+	}
+
+	h.toolsMu.Lock()
+	hidden, isHidden := h.hiddenToolsets[name]
+	changed := false
+	switch {
+	case enabled && isHidden:
+		delete(h.hiddenToolsets, name)
+		h.tools = append(append([]Tool{}, h.tools...), hidden...)
+		changed = true
+	case !enabled && !isHidden:
+		var kept []Tool
+		for _, tool := range h.tools {
+			if toolsetOf(tool.Name) == set {
+				hidden = append(hidden, tool)
+			} else {
+				kept = append(kept, tool)
+			}
+		}
+		if h.hiddenToolsets == nil {
+			h.hiddenToolsets = make(map[string][]Tool)
+		}
+		h.hiddenToolsets[name] = hidden
+		h.tools = kept
+		changed = true
+	}
+	h.toolsMu.Unlock()
+
+	if changed {
+		h.logger.Info("Toolset changed", "name", name, "enabled", enabled)
+		h.notifyToolsChanged()
+	}
+	return nil
+}
+
+// Toolsets returns every toolset and whether its tools are offered
+func (h *Handler) Toolsets() []ToolsetState {
+	h.toolsMu.RLock()
+	defer h.toolsMu.RUnlock()
+
+	states := make([]ToolsetState, 0, len(toolsets))
+	for _, set := range toolsets {
+		_, hidden := h.hiddenToolsets[set.name]
+		states = append(states, ToolsetState{Name: set.name, Description: set.description, Enabled: !hidden, Tools: set.tools})
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
+
+// catalogHas reports whether tools has a tool with the given name
+func catalogHas(tools []Tool, name string) bool {
+	for _, tool := range tools {
+		if tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addTools appends tools to the catalog. The catalog is copied, so readers
+// holding the previous one are unaffected.
+func (h *Handler) addTools(tools []Tool) {
+	h.toolsMu.Lock()
+	defer h.toolsMu.Unlock()
+	h.tools = append(append([]Tool{}, h.tools...), tools...)
+}
+
+// removeTool removes a tool from the catalog, copying it
+func (h *Handler) removeTool(name string) {
+	h.toolsMu.Lock()
+	defer h.toolsMu.Unlock()
+	kept := make([]Tool, 0, len(h.tools))
+	for _, tool := range h.tools {
+		if tool.Name != name {
+			kept = append(kept, tool)
+		}
+	}
+	h.tools = kept
+}
+
+// addLiveSession registers a session whose transport delivers notifications
+func (h *Handler) addLiveSession(session *Session) {
+	h.liveSessionsMu.Lock()
+	defer h.liveSessionsMu.Unlock()
+	if h.liveSessions == nil {
+		h.liveSessions = make(map[*Session]struct{})
+	}
+	h.liveSessions[session] = struct{}{}
+}
+
+// removeLiveSession unregisters a session added with addLiveSession
+func (h *Handler) removeLiveSession(session *Session) {
+	h.liveSessionsMu.Lock()
+	defer h.liveSessionsMu.Unlock()
+	delete(h.liveSessions, session)
+}
+
+// notifyToolsChanged tells connected clients to list tools again
+func (h *Handler) notifyToolsChanged() {
+	h.broadcast(NewNotification(MethodToolListChanged, nil))
+}
+
+// broadcast sends a notification to the initialized sessions whose
+// transport delivers notifications and to every SSE client
+func (h *Handler) broadcast(msg *JSONRPCMessage) {
+	h.liveSessionsMu.Lock()
+	var notify []func(*JSONRPCMessage)
+	for session := range h.liveSessions {
+		if send := session.notifier(); send != nil && session.Initialized() {
+			notify = append(notify, send)
+		}
+	}
+	h.liveSessionsMu.Unlock()
+
+	for _, send := range notify {
+		send(msg)
+	}
+	if h.streamer != nil {
+		h.streamer.StreamMessage(msg)
+	}
+}
@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRegisterTool(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	var notified []*JSONRPCMessage
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	session.setNotifier(func(msg *JSONRPCMessage) { notified = append(notified, msg) })
+	h.addLiveSession(session)
+	ctx := WithSession(context.Background(), session)
+
+	tool := Tool{Name: "echo", Description: "Echo a message", InputSchema: map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"message": map[string]interface{}{"type": "string"}},
+	}}
+	echo := func(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+		return &CallToolResult{Content: []Content{{Type: "text", Text: args["message"].(string)}}}, nil
+	}
+	if err := h.RegisterTool(tool, echo); err != nil {
+		t.Fatalf("RegisterTool failed: %v", err)
+	}
+	if err := h.RegisterTool(Tool{Name: "get_user"}, echo); err == nil {
+		t.Error("Expected a built-in tool name to be rejected")
+	}
+	if len(notified) != 1 || notified[0].Method != MethodToolListChanged {
+		t.Fatalf("Expected a list_changed notification, got %+v", notified)
+	}
+
+	call := &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+		"name": "echo", "arguments": map[string]interface{}{"message": "hi"},
+	}}
+	resp := h.handleCallTool(ctx, call)
+	if resp.Error != nil {
+		t.Fatalf("Unexpected error: %+v", resp.Error)
+	}
+	if result := resp.Result.(*CallToolResult); result.Content[0].Text != "hi" {
+		t.Errorf("Expected the registered function to run, got %+v", result)
+	}
+
+	if !h.UnregisterTool("echo") || h.UnregisterTool("echo") {
+		t.Error("Expected the tool to be unregistered once")
+	}
+	if h.findTool("echo") != nil || len(notified) != 2 {
+		t.Errorf("Expected the tool removed with a notification, got %d notifications", len(notified))
+	}
+	if resp := h.handleCallTool(ctx, call); resp.Error == nil || resp.Error.Code != ErrorCodeToolNotFound {
+		t.Errorf("Expected an unregistered tool to be unknown, got %+v", resp.Error)
+	}
+}
+
+func TestRegisterTool_Concurrent(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	echo := func(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+		return &CallToolResult{}, nil
+	}
+
+	var registered atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if h.RegisterTool(Tool{Name: "echo"}, echo) == nil {
+				registered.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	count := 0
+	for _, tool := range h.Tools() {
+		if tool.Name == "echo" {
+			count++
+		}
+	}
+	if registered.Load() != 1 || count != 1 {
+		t.Errorf("Expected one registration to succeed, got %d succeeding and %d tools", registered.Load(), count)
+	}
+}
+
+func TestSetToolsetEnabled(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	var notified int
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	session.setNotifier(func(msg *JSONRPCMessage) { notified++ })
+	h.addLiveSession(session)
+	ctx := WithSession(context.Background(), session)
+
+	if err := h.SetToolsetEnabled("nope", false); err == nil {
+		t.Error("Expected an unknown toolset to be rejected")
+	}
+	if err := h.SetToolsetEnabled("issues", false); err != nil {
+		t.Fatalf("SetToolsetEnabled failed: %v", err)
+	}
+	h.SetToolsetEnabled("issues", false)
+	if h.findTool("bulk_update_issues") != nil || h.findTool("get_user") == nil || notified != 1 {
+		t.Fatalf("Expected only the issue tools hidden with one notification, got %d", notified)
+	}
+	resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+		"name": "bulk_update_issues", "arguments": map[string]interface{}{"owner": "octocat", "repo": "hello-world"},
+	}})
+	if resp.Error == nil || resp.Error.Code != ErrorCodeToolNotFound {
+		t.Errorf("Expected a call to a hidden tool to fail, got %+v", resp.Error)
+	}
+	for _, state := range h.Toolsets() {
+		if state.Enabled != (state.Name != "issues") {
+			t.Errorf("Unexpected state of toolset %s: %v", state.Name, state.Enabled)
+		}
+	}
+
+	if err := h.SetToolsetEnabled("issues", true); err != nil {
+		t.Fatalf("SetToolsetEnabled failed: %v", err)
+	}
+	if h.findTool("bulk_update_issues") == nil || notified != 2 {
+		t.Errorf("Expected the issue tools offered again, got %d notifications", notified)
+	}
+}
+
+// staticTool is a ToolProvider returning a fixed text
+type staticTool struct{ text string }
+
+func (t staticTool) Definition() Tool {
+	return Tool{Name: "static", Description: "Return a fixed text", InputSchema: map[string]interface{}{"type": "object"}}
+}
+
+func (t staticTool) Execute(ctx context.Context, args map[string]interface{}) (*CallToolResult, error) {
+	return &CallToolResult{Content: []Content{{Type: "text", Text: t.text}}}, nil
+}
+
+func TestToolProviders(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	for _, tool := range h.Tools() {
+		if h.toolProvider(tool.Name) == nil {
+			t.Errorf("Tool %s has no provider", tool.Name)
+		}
+	}
+
+	if err := h.RegisterToolProvider(staticTool{text: "fixed"}); err != nil {
+		t.Fatalf("RegisterToolProvider failed: %v", err)
+	}
+	if err := h.RegisterToolProvider(staticTool{}); err == nil {
+		t.Error("Expected a tool registered twice to be rejected")
+	}
+	result, err := h.executeTool(context.Background(), "static", nil)
+	if err != nil || result.Content[0].Text != "fixed" {
+		t.Errorf("Expected the provider to execute the tool, got %+v (%v)", result, err)
+	}
+	if h.UnregisterTool("get_user") {
+		t.Error("Expected built-in tools to stay registered")
+	}
+}
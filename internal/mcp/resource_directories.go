@@ -0,0 +1,149 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime"
+	"path"
+	"sync"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+const (
+	// directoryResourceMaxFiles bounds how many files of a directory are
+	// read along with its listing
+	directoryResourceMaxFiles = 20
+	// directoryResourceMaxBytes bounds the total size of the files read
+	// along with a directory listing
+	directoryResourceMaxBytes = 512 * 1024
+	// directoryResourceConcurrency is the number of files of a directory
+	// read in parallel
+	directoryResourceConcurrency = 5
+	// largeFileResourceSize is the size above which a file is read as a
+	// metadata entry followed by its contents
+	largeFileResourceSize = 1024 * 1024
+	// fileResourceMaxSize is the size above which a file is only described
+	// by its metadata
+	fileResourceMaxSize = 10 * 1024 * 1024
+)
+
+// directoryEntry is an entry of a directory resource listing
+type directoryEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Type is file, dir, symlink or submodule
+	Type string `json:"type"`
+	Size int    `json:"size"`
+	SHA  string `json:"sha"`
+	// URI is the resource of a file or directory entry
+	URI string `json:"uri,omitempty"`
+	// Included is set for the files whose contents follow the listing
+	Included bool `json:"included,omitempty"`
+}
+
+// fileMetadata describes a file whose contents are a separate entry
+type fileMetadata struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Size        int    `json:"size"`
+	SHA         string `json:"sha"`
+	MimeType    string `json:"mime_type,omitempty"`
+	HTMLURL     string `json:"html_url,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+	// Omitted is set when the file is too large for its contents to follow
+	Omitted bool `json:"omitted,omitempty"`
+}
+
+// readDirectoryResource returns a JSON listing of a directory followed by
+// the contents of its first files, as long as they stay within
+// directoryResourceMaxFiles and directoryResourceMaxBytes. The files are read
+// directoryResourceConcurrency at a time, and those that cannot be read are
+// listed without their contents. Entries are read at ref, or on the default
+// branch when ref is empty.
+func (h *Handler) readDirectoryResource(ctx context.Context, uri, owner, repo, ref string, entries []client.FileContent) (*ReadResourceResult, error) {
+	listing := make([]directoryEntry, len(entries))
+	var selected []int
+	budget := directoryResourceMaxBytes
+	for i, entry := range entries {
+		listing[i] = directoryEntry{Name: entry.Name, Path: entry.Path, Type: entry.Type, Size: entry.Size, SHA: entry.SHA}
+		if entry.Type != "file" && entry.Type != "dir" {
+			continue
+		}
+		listing[i].URI = fileResourceURI(owner, repo, entry.Path, ref)
+		if entry.Type != "file" || len(selected) >= directoryResourceMaxFiles || entry.Size > budget {
+			continue
+		}
+		selected = append(selected, i)
+		budget -= entry.Size
+	}
+
+	read := make([]*ResourceContent, len(selected))
+	semaphore := make(chan struct{}, directoryResourceConcurrency)
+	var wg sync.WaitGroup
+	for n, i := range selected {
+		wg.Add(1)
+		go func(n, i int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			file, err := h.githubClient.GetFileContents(ctx, owner, repo, entries[i].Path, ref)
+			if err != nil {
+				h.logger.Debug("Skipping directory file", "path", entries[i].Path, "error", err)
+				return
+			}
+			if content, err := fileResourceContent(listing[i].URI, file); err == nil {
+				read[n] = &content
+			}
+		}(n, i)
+	}
+	wg.Wait()
+
+	var files []ResourceContent
+	for n, i := range selected {
+		if read[n] != nil {
+			files = append(files, *read[n])
+			listing[i].Included = true
+		}
+	}
+
+	listingJSON, err := json.Marshal(listing)
+	if err != nil {
+		return nil, err
+	}
+	contents := append([]ResourceContent{{URI: uri, MimeType: "application/json", Text: string(listingJSON)}}, files...)
+	return &ReadResourceResult{Contents: contents}, nil
+}
+
+// readLargeFileResource returns the metadata of a file too large to be
+// returned by the contents API, followed by its contents downloaded in full
+// unless it is larger than fileResourceMaxSize
+func (h *Handler) readLargeFileResource(ctx context.Context, uri, owner, repo, ref string, file *client.FileContent) (*ReadResourceResult, error) {
+	metadata := fileMetadata{
+		Name:        file.Name,
+		Path:        file.Path,
+		Size:        file.Size,
+		SHA:         file.SHA,
+		MimeType:    mime.TypeByExtension(path.Ext(file.Name)),
+		HTMLURL:     file.HTMLURL,
+		DownloadURL: file.DownloadURL,
+		Omitted:     file.Size > fileResourceMaxSize,
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	contents := []ResourceContent{{URI: uri, MimeType: "application/json", Text: string(metadataJSON)}}
+	if metadata.Omitted {
+		return &ReadResourceResult{Contents: contents}, nil
+	}
+
+	var data bytes.Buffer
+	if _, err := h.githubClient.DownloadFileContents(ctx, owner, repo, file.Path, ref, &data); err != nil {
+		return nil, err
+	}
+	contents = append(contents, dataResourceContent(uri, file.Name, data.Bytes()))
+	return &ReadResourceResult{Contents: contents}, nil
+}
@@ -0,0 +1,184 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// rootsTimeout bounds how long the server waits for the client's roots
+const rootsTimeout = 30 * time.Second
+
+// rootRepository is a client root naming a GitHub owner and, optionally,
+// one of its repositories
+type rootRepository struct {
+	owner string
+	repo  string
+}
+
+// rootRepositories returns the owners and repositories of the client's roots
+func (s *Session) rootRepositories() []rootRepository {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.roots
+}
+
+// setRoots records the owners and repositories of the client's roots
+func (s *Session) setRoots(roots []rootRepository) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roots = roots
+}
+
+// refreshRoots asks the client for its roots and keeps those that are GitHub
+// URLs or owner/repo identifiers. Clients without the roots capability, or
+// whose transport cannot carry requests from the server, are not asked.
+func (h *Handler) refreshRoots(ctx context.Context, session *Session) {
+	if session.Capabilities().Roots == nil || session.notifier() == nil {
+		return
+	}
+	log := h.logger.WithContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, rootsTimeout)
+	defer cancel()
+	response, err := session.request(ctx, MethodListRoots, nil)
+	if err == nil && response.Error != nil {
+		err = fmt.Errorf("%s", response.Error.Message)
+	}
+	var result ListRootsResult
+	if err == nil {
+		err = response.GetResult(&result)
+	}
+	if err != nil {
+		log.Warn("Failed to list client roots", "error", err)
+		return
+	}
+
+	roots := parseRoots(result.Roots)
+	session.setRoots(roots)
+	log.Info("Client roots updated", "roots", len(result.Roots), "repositories", len(roots))
+}
+
+// parseRoots returns the owners and repositories roots name, skipping local
+// directories and duplicates
+func parseRoots(roots []Root) []rootRepository {
+	var parsed []rootRepository
+	seen := make(map[rootRepository]bool)
+	for _, root := range roots {
+		owner, repo := parseRepositoryReference(root.URI)
+		if owner == "" {
+			continue
+		}
+		key := rootRepository{owner: owner, repo: repo}
+		if !seen[key] {
+			seen[key] = true
+			parsed = append(parsed, key)
+		}
+	}
+	return parsed
+}
+
+// applyRootDefaults fills in the missing required owner, repo and org
+// arguments of a tool call when the client's roots determine them: owner and
+// repo from the only root repository, or from the only root repository of the
+// given repo, org from the only root naming just an owner, and owner or org
+// from the only owner of the roots
+func applyRootDefaults(roots []rootRepository, schema interface{}, args map[string]interface{}) {
+	if len(roots) == 0 {
+		return
+	}
+	schemaMap, _ := schema.(map[string]interface{})
+	required := make(map[string]bool)
+	for _, name := range schemaList(schemaMap["required"]) {
+		if name, ok := name.(string); ok {
+			required[name] = true
+		}
+	}
+	missing := func(name string) bool {
+		_, set := args[name]
+		return required[name] && !set
+	}
+
+	if missing("owner") && missing("repo") {
+		if root, ok := onlyRoot(roots, func(root rootRepository) bool { return root.repo != "" }); ok {
+			args["owner"], args["repo"] = root.owner, root.repo
+		}
+	}
+	if repo, ok := args["repo"].(string); ok && missing("owner") {
+		if root, ok := onlyRoot(roots, func(root rootRepository) bool { return strings.EqualFold(root.repo, repo) }); ok {
+			args["owner"] = root.owner
+		}
+	}
+	if missing("org") {
+		if root, ok := onlyRoot(roots, func(root rootRepository) bool { return root.repo == "" }); ok {
+			args["org"] = root.owner
+		}
+	}
+	for _, name := range []string{"owner", "org"} {
+		if missing(name) {
+			if owner := onlyOwner(roots); owner != "" {
+				args[name] = owner
+			}
+		}
+	}
+}
+
+// onlyRoot returns the root matching match when exactly one does
+func onlyRoot(roots []rootRepository, match func(rootRepository) bool) (rootRepository, bool) {
+	var found []rootRepository
+	for _, root := range roots {
+		if match(root) {
+			found = append(found, root)
+		}
+	}
+	if len(found) != 1 {
+		return rootRepository{}, false
+	}
+	return found[0], true
+}
+
+// onlyOwner returns the owner of every root, or "" when they differ
+func onlyOwner(roots []rootRepository) string {
+	owner := roots[0].owner
+	for _, root := range roots[1:] {
+		if !strings.EqualFold(root.owner, owner) {
+			return ""
+		}
+	}
+	return owner
+}
+
+// scopeResources returns the resources of the owners and repositories of the
+// client's roots: the resource templates naming a user or organization
+// expanded for each owner, and each root repository
+func scopeResources(resources []Resource, roots []rootRepository) []Resource {
+	var scoped []Resource
+	seen := make(map[string]bool)
+	add := func(resource Resource) {
+		if !seen[resource.URI] {
+			seen[resource.URI] = true
+			scoped = append(scoped, resource)
+		}
+	}
+
+	for _, root := range roots {
+		if root.repo != "" {
+			add(Resource{
+				URI:         fmt.Sprintf("github://repos/%s/%s", root.owner, root.repo),
+				Name:        fmt.Sprintf("GitHub Repository %s/%s", root.owner, root.repo),
+				Description: "A repository of the client's roots and its settings",
+				MimeType:    "application/json",
+			})
+		}
+	}
+	for _, root := range roots {
+		for _, resource := range resources {
+			if uri, ok := expandOwner(resource.URI, root.owner); ok {
+				resource.URI = uri
+				add(resource)
+			}
+		}
+	}
+	return scoped
+}
@@ -0,0 +1,144 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestRefreshRoots(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	session.setCapabilities(ClientCapabilities{Roots: map[string]interface{}{"listChanged": true}})
+	ctx := WithSession(context.Background(), session)
+
+	session.setNotifier(func(msg *JSONRPCMessage) {
+		if msg.Method != MethodListRoots {
+			return
+		}
+		data, _ := NewResponse(msg.ID, ListRootsResult{Roots: []Root{
+			{URI: "file:///home/octocat/widgets", Name: "widgets"},
+			{URI: "https://github.com/acme/widgets"},
+			{URI: "git@github.com:acme/gadgets.git"},
+			{URI: "acme/widgets"},
+		}}).ToJSON()
+		go h.HandleMessage(ctx, data)
+	})
+
+	h.refreshRoots(ctx, session)
+
+	want := []rootRepository{{owner: "acme", repo: "widgets"}, {owner: "acme", repo: "gadgets"}}
+	if got := session.rootRepositories(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected roots %+v, got %+v", want, got)
+	}
+}
+
+func TestApplyRootDefaults(t *testing.T) {
+	repoSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"owner": map[string]interface{}{"type": "string"}, "repo": map[string]interface{}{"type": "string"}},
+		"required":   []string{"owner", "repo"},
+	}
+	orgSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"org": map[string]interface{}{"type": "string"}},
+		"required":   []string{"org"},
+	}
+	optionalSchema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"owner": map[string]interface{}{"type": "string"}},
+	}
+	one := []rootRepository{{owner: "acme", repo: "widgets"}}
+	two := []rootRepository{{owner: "acme", repo: "widgets"}, {owner: "acme", repo: "gadgets"}}
+	mixed := []rootRepository{{owner: "acme", repo: "widgets"}, {owner: "octocat", repo: "gadgets"}}
+
+	tests := []struct {
+		name     string
+		roots    []rootRepository
+		schema   map[string]interface{}
+		args     map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{name: "only repository", roots: one, schema: repoSchema, args: map[string]interface{}{}, expected: map[string]interface{}{"owner": "acme", "repo": "widgets"}},
+		{name: "owner of the named repository", roots: mixed, schema: repoSchema, args: map[string]interface{}{"repo": "gadgets"}, expected: map[string]interface{}{"owner": "octocat", "repo": "gadgets"}},
+		{name: "only owner", roots: two, schema: repoSchema, args: map[string]interface{}{"repo": "other"}, expected: map[string]interface{}{"owner": "acme", "repo": "other"}},
+		{name: "ambiguous repository", roots: mixed, schema: repoSchema, args: map[string]interface{}{}, expected: map[string]interface{}{}},
+		{name: "explicit arguments", roots: one, schema: repoSchema, args: map[string]interface{}{"owner": "octocat", "repo": "hello"}, expected: map[string]interface{}{"owner": "octocat", "repo": "hello"}},
+		{name: "org", roots: two, schema: orgSchema, args: map[string]interface{}{}, expected: map[string]interface{}{"org": "acme"}},
+		{name: "optional owner", roots: one, schema: optionalSchema, args: map[string]interface{}{}, expected: map[string]interface{}{}},
+		{name: "no roots", schema: repoSchema, args: map[string]interface{}{}, expected: map[string]interface{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applyRootDefaults(tt.roots, tt.schema, tt.args)
+			if !reflect.DeepEqual(tt.args, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, tt.args)
+			}
+		})
+	}
+}
+
+func TestListResources_ScopedToRoots(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	session.setRoots([]rootRepository{{owner: "acme", repo: "widgets"}})
+	ctx := WithSession(context.Background(), session)
+
+	response := h.handleListResources(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodListResources})
+	result := response.Result.(ResourcesListResult)
+	uris := make(map[string]bool)
+	for _, resource := range result.Resources {
+		uris[resource.URI] = true
+	}
+	for _, uri := range []string{"github://repos/acme/widgets", "github://repos/acme", "github://org/acme/members"} {
+		if !uris[uri] {
+			t.Errorf("Expected %s to be listed, got %v", uri, uris)
+		}
+	}
+	if uris["github://organizations"] {
+		t.Error("Expected resources unrelated to the roots to be left out")
+	}
+
+	response = h.handleListResources(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 2, Method: MethodListResources, Params: map[string]interface{}{"owner": "octocat"}})
+	for _, resource := range response.Result.(ResourcesListResult).Resources {
+		if resource.URI == "github://repos/acme/widgets" {
+			t.Error("Expected an explicit owner to replace the roots")
+		}
+	}
+}
+
+func TestHandleCallTool_NoRootDefaultsForDestructiveTools(t *testing.T) {
+	var requests []string
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			return mocks.MockResponse(http.StatusNoContent, "", nil), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetDefaultContext("acme", "acme/widgets")
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	for _, name := range []string{"delete_repository", "transfer_repository"} {
+		resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+			"name":      name,
+			"arguments": map[string]interface{}{"new_owner": "octocat"},
+		}})
+		if resp.Error == nil || resp.Error.Code != ErrorCodeInvalidParams {
+			t.Errorf("Expected %s without owner and repo to be rejected, got %+v", name, resp)
+		}
+	}
+	if len(requests) != 0 {
+		t.Errorf("Expected no requests, got %v", requests)
+	}
+}
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// samplingTimeout bounds how long the server waits for the client's
+	// model to respond
+	samplingTimeout = 2 * time.Minute
+	// samplingMetaKey is the _meta key naming the model that wrote a result
+	samplingMetaKey = "github-mcp/sampling"
+	// summaryMaxTokens is the most tokens a summary may take
+	summaryMaxTokens = 1024
+	// summaryMaxInput is the most bytes of GitHub data sent to be summarized
+	summaryMaxInput = 100 * 1024
+)
+
+// canSample reports whether the session's client can generate messages with
+// its model
+func (s *Session) canSample() bool {
+	return s.Capabilities().Sampling != nil && s.notifier() != nil
+}
+
+// sample has the client's model respond to prompt, returning the text of
+// the response and the model that wrote it
+func (h *Handler) sample(ctx context.Context, systemPrompt, prompt string, maxTokens int) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, samplingTimeout)
+	defer cancel()
+	response, err := h.session(ctx).request(ctx, MethodCreateMessage, CreateMessageRequest{
+		Messages:     []SamplingMessage{{Role: "user", Content: Content{Type: "text", Text: prompt}}},
+		SystemPrompt: systemPrompt,
+		MaxTokens:    maxTokens,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if response.Error != nil {
+		return "", "", fmt.Errorf("%s", response.Error.Message)
+	}
+
+	var result CreateMessageResult
+	if err := response.GetResult(&result); err != nil {
+		return "", "", err
+	}
+	if result.Content.Type != "text" {
+		return "", "", fmt.Errorf("the client's model returned %s content instead of text", result.Content.Type)
+	}
+	return result.Content.Text, result.Model, nil
+}
+
+// summarize has the client's model summarize material as instructed by
+// systemPrompt. Clients that cannot sample get the material back to
+// summarize themselves.
+func (h *Handler) summarize(ctx context.Context, subject, systemPrompt, material string) *CallToolResult {
+	material = truncateSummaryInput(material)
+	if !h.session(ctx).canSample() {
+		return &CallToolResult{
+			Content: []Content{
+				{Type: "text", Text: h.messages.Sprintf("The client does not support sampling; summarize %s from the following:", subject)},
+				{Type: "text", Text: material},
+			},
+		}
+	}
+
+	summary, model, err := h.sample(ctx, systemPrompt, material, summaryMaxTokens)
+	if err != nil {
+		h.logger.WithContext(ctx).Warn("Sampling failed", "subject", subject, "error", err)
+		return &CallToolResult{
+			Content: []Content{{Type: "text", Text: h.messages.Sprintf("Error summarizing %s: %v", subject, err)}},
+			IsError: true,
+		}
+	}
+	SetResultMeta(ctx, samplingMetaKey, map[string]interface{}{"model": model})
+	return &CallToolResult{Content: []Content{{Type: "text", Text: summary}}}
+}
+
+// truncateSummaryInput cuts material to summaryMaxInput bytes, on a
+// character boundary
+func truncateSummaryInput(material string) string {
+	if len(material) <= summaryMaxInput {
+		return material
+	}
+	cut := summaryMaxInput
+	for cut > 0 && !utf8.RuneStart(material[cut]) {
+		cut--
+	}
+	return material[:cut] + "\n[truncated]"
+}
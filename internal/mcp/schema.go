@@ -0,0 +1,43 @@
+package mcp
+
+// Builders of the JSON schemas of common tool arguments
+
+// stringProperty returns the schema of a string argument
+func stringProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": description}
+}
+
+// booleanProperty returns the schema of a boolean argument
+func booleanProperty(description string) map[string]interface{} {
+	return map[string]interface{}{"type": "boolean", "description": description}
+}
+
+// stringArrayProperty returns the schema of an argument listing strings
+func stringArrayProperty(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"items":       map[string]interface{}{"type": "string"},
+		"description": description,
+	}
+}
+
+// pageProperty returns the schema of the page argument of list tools
+func pageProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "integer",
+		"description": "Page number of the results to fetch",
+		"minimum":     1,
+		"default":     1,
+	}
+}
+
+// perPageProperty returns the schema of the per_page argument of list tools
+func perPageProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "integer",
+		"description": "The number of results per page (max 100)",
+		"minimum":     1,
+		"maximum":     100,
+		"default":     30,
+	}
+}
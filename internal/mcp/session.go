@@ -0,0 +1,224 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Transport names identify how a session's client is connected
+const (
+	TransportHTTP  = "http"
+	TransportStdio = "stdio"
+)
+
+// Session holds the protocol state of one MCP client connection. Transports
+// that serve a single client, such as stdio, get their own session, and HTTP
+// clients get one per Mcp-Session-Id, so that clients cannot observe or
+// change each other's state.
+type Session struct {
+	transport string
+	// id identifies HTTP sessions in the Mcp-Session-Id header; it is empty
+	// for sessions of other transports and of older HTTP clients
+	id string
+	// owner is the stream owner of the HTTP client that started the
+	// session; requests of other owners cannot use or end it
+	owner string
+	// legacy is the session of the same owner's requests without
+	// Mcp-Session-Id, in which older clients send their initialized
+	// notification
+	legacy *Session
+
+	mu sync.RWMutex
+	// initializing is set by the initialize request, which must come before
+	// the initialized notification sets initialized
+	initializing bool
+	initialized  bool
+	clientInfo   ClientInfo
+	// protocolVersion is the protocol version the client asked for
+	protocolVersion string
+	// capabilities are the client capabilities sent with the initialize request
+	capabilities ClientCapabilities
+	// roots are the repositories and owners of the client's roots
+	roots []rootRepository
+	tools *toolLimiter
+	// notify sends a message to the client outside of a response, when the
+	// transport can
+	notify func(*JSONRPCMessage)
+
+	// pending holds the requests sent to the client awaiting a response, by ID
+	pendingMu   sync.Mutex
+	pending     map[string]chan *JSONRPCMessage
+	nextRequest int64
+}
+
+// NewSession creates an uninitialized session for the given transport
+func NewSession(transport string) *Session {
+	return &Session{transport: transport}
+}
+
+// Transport returns the name of the transport the session belongs to
+func (s *Session) Transport() string {
+	return s.transport
+}
+
+// ID returns the ID of an HTTP session, or "" for other sessions
+func (s *Session) ID() string {
+	return s.id
+}
+
+// ProtocolVersion returns the protocol version sent with the initialize request
+func (s *Session) ProtocolVersion() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.protocolVersion
+}
+
+// Initialized returns true once the client has completed the initialize handshake
+func (s *Session) Initialized() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.initialized
+}
+
+// ClientInfo returns the client information sent with the initialize request
+func (s *Session) ClientInfo() ClientInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clientInfo
+}
+
+// setClientInfo records the client information and protocol version sent
+// with the initialize request
+func (s *Session) setClientInfo(info ClientInfo, protocolVersion string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clientInfo = info
+	s.protocolVersion = protocolVersion
+}
+
+// Capabilities returns the client capabilities sent with the initialize request
+func (s *Session) Capabilities() ClientCapabilities {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.capabilities
+}
+
+// setCapabilities records the client capabilities sent with the initialize request
+func (s *Session) setCapabilities(capabilities ClientCapabilities) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.capabilities = capabilities
+}
+
+// setInitializing records that the client sent its initialize request
+func (s *Session) setInitializing() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.initializing = true
+}
+
+// initializeSent reports whether the client sent its initialize request
+func (s *Session) initializeSent() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.initializing
+}
+
+// setInitialized marks the handshake as complete
+func (s *Session) setInitialized() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.initialized = true
+}
+
+// setNotifier sets how messages are sent to the client outside of a response
+func (s *Session) setNotifier(notify func(*JSONRPCMessage)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notify = notify
+}
+
+// notifier returns how messages are sent to the client outside of a
+// response, or nil when the transport cannot
+func (s *Session) notifier() func(*JSONRPCMessage) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.notify
+}
+
+// request sends a request to the client and waits for its response, until
+// ctx is done. It fails when the transport cannot send messages outside of
+// a response.
+func (s *Session) request(ctx context.Context, method string, params interface{}) (*JSONRPCMessage, error) {
+	notify := s.notifier()
+	if notify == nil {
+		return nil, fmt.Errorf("the %s transport cannot send requests to the client", s.transport)
+	}
+
+	response := make(chan *JSONRPCMessage, 1)
+	s.pendingMu.Lock()
+	if s.pending == nil {
+		s.pending = make(map[string]chan *JSONRPCMessage)
+	}
+	s.nextRequest++
+	id := fmt.Sprintf("server-%d", s.nextRequest)
+	s.pending[id] = response
+	s.pendingMu.Unlock()
+
+	defer func() {
+		s.pendingMu.Lock()
+		delete(s.pending, id)
+		s.pendingMu.Unlock()
+	}()
+
+	notify(NewRequest(id, method, params))
+	select {
+	case msg := <-response:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// resolve delivers a response from the client to the request awaiting it,
+// reporting whether one was
+func (s *Session) resolve(msg *JSONRPCMessage) bool {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+	response, ok := s.pending[fmt.Sprint(msg.ID)]
+	if ok {
+		delete(s.pending, fmt.Sprint(msg.ID))
+		response <- msg
+	}
+	return ok
+}
+
+// toolLimiter returns the session's tool call limiter, creating it with the
+// given limits on first use
+func (s *Session) toolLimiter(maxConcurrent, maxQueued int) *toolLimiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tools == nil {
+		s.tools = newToolLimiter(maxConcurrent, maxQueued)
+	}
+	return s.tools
+}
+
+// sessionContextKey is the context key for the current session
+type sessionContextKey struct{}
+
+// WithSession returns a context whose messages are handled in the given session
+func WithSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, session)
+}
+
+// session returns the session a message belongs to. Messages without an
+// explicit session, such as those of HTTP clients not sending
+// Mcp-Session-Id, share the handler's HTTP session.
+func (h *Handler) session(ctx context.Context) *Session {
+	if session, ok := ctx.Value(sessionContextKey{}).(*Session); ok && session != nil {
+		return session
+	}
+	return h.httpSession
+}
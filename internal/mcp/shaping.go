@@ -0,0 +1,224 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// verboseArgument is the argument name of tools asking for the full
+	// GitHub objects instead of their trimmed form
+	verboseArgument = "verbose"
+	// fieldsArgument is the argument name of read tools selecting the
+	// fields of the objects they return
+	fieldsArgument = "fields"
+)
+
+// fieldSelection is a tree of selected fields by name. A field mapped to nil
+// is selected with all its fields.
+type fieldSelection map[string]fieldSelection
+
+// trimmedFields are left out of tool results unless the caller asks for
+// verbose output: API links, which html_url makes redundant, and internal
+// identifiers
+var trimmedFields = map[string]bool{
+	"url":         true,
+	"node_id":     true,
+	"gravatar_id": true,
+	"_links":      true,
+}
+
+// keptLinks are the *_url fields kept in trimmed results, linking to what
+// a caller opens or downloads rather than to more of the API
+var keptLinks = map[string]bool{
+	"html_url":     true,
+	"download_url": true,
+}
+
+// addVerbose adds the verbose argument to the schema of the tools whose
+// results are shaped, and the fields argument to those that read data
+func addVerbose(tools []Tool) {
+	for _, tool := range tools {
+		if !tool.Shaped {
+			continue
+		}
+
+		properties := schemaProperties(tool.InputSchema)
+		if properties == nil {
+			continue
+		}
+
+		properties[verboseArgument] = map[string]interface{}{
+			"type":        "boolean",
+			"description": "Return the full GitHub objects, with their API links and empty fields, instead of the trimmed ones",
+		}
+		if !readOnlyTool(tool.Name) {
+			continue
+		}
+		properties[fieldsArgument] = map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "Return only these fields of each object, as dot-separated paths such as login or owner.login; list pagination is always returned",
+		}
+	}
+}
+
+// shapeJSON returns value as minified JSON for a tool result. Unless args
+// ask for verbose output, API links, internal identifiers, nulls and empty
+// strings are left out, keeping the fields a caller acts on such as login,
+// id, html_url and the counts, in their order. When args select fields, only
+// those are kept, of the items of a list.
+func shapeJSON(args map[string]interface{}, value interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	selection, err := selectedFields(args)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := value.(listEnvelope); ok && selection != nil {
+		selection = fieldSelection{"items": selection, "pagination": nil, "truncated": nil}
+	}
+	verbose, _ := args[verboseArgument].(bool)
+	if verbose && selection == nil {
+		return data, nil
+	}
+
+	var shaped bytes.Buffer
+	if err := shapeValue(newNumberDecoder(data), &shaped, selection, !verbose); err != nil {
+		return nil, err
+	}
+	return shaped.Bytes(), nil
+}
+
+// selectedFields returns the fields args select, or nil to keep them all
+func selectedFields(args map[string]interface{}) (fieldSelection, error) {
+	paths, _ := args[fieldsArgument].([]interface{})
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	selection := fieldSelection{}
+	for _, p := range paths {
+		path, _ := p.(string)
+		node := selection
+		names := strings.Split(path, ".")
+		for i, name := range names {
+			if name == "" {
+				return nil, fmt.Errorf("invalid field path %q", path)
+			}
+			child, ok := node[name]
+			switch {
+			case i == len(names)-1:
+				node[name] = nil
+			case ok && child == nil:
+				// The parent is already selected whole
+			case !ok:
+				child = fieldSelection{}
+				node[name] = child
+			}
+			if child == nil {
+				break
+			}
+			node = child
+		}
+	}
+	return selection, nil
+}
+
+// shapeValue copies the next value of dec to out, keeping the fields of its
+// objects in selection, or all of them when selection is nil, and leaving
+// nulls, empty strings and the trimmed fields not selected by name out when
+// trim is set
+func shapeValue(dec *json.Decoder, out *bytes.Buffer, selection fieldSelection, trim bool) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return writeToken(out, token)
+	}
+
+	out.WriteRune(rune(delim))
+	first := true
+	for dec.More() {
+		if delim == '[' {
+			if !first {
+				out.WriteByte(',')
+			}
+			first = false
+			if err := shapeValue(dec, out, selection, trim); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var field json.RawMessage
+		if err := dec.Decode(&field); err != nil {
+			return err
+		}
+		name := key.(string)
+		fields, selected := selection[name]
+		if selection != nil && !selected {
+			continue
+		}
+		if trim && ((trimmedField(name) && !selected) || string(field) == "null" || string(field) == `""`) {
+			continue
+		}
+		if !first {
+			out.WriteByte(',')
+		}
+		first = false
+		if err := writeToken(out, key); err != nil {
+			return err
+		}
+		out.WriteByte(':')
+		if err := shapeValue(newNumberDecoder(field), out, fields, trim); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	if delim == '[' {
+		out.WriteByte(']')
+	} else {
+		out.WriteByte('}')
+	}
+	return nil
+}
+
+// newNumberDecoder returns a decoder of data keeping numbers as written
+func newNumberDecoder(data []byte) *json.Decoder {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec
+}
+
+// writeToken writes a scalar JSON token to out
+func writeToken(out *bytes.Buffer, token json.Token) error {
+	if number, ok := token.(json.Number); ok {
+		out.WriteString(number.String())
+		return nil
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	out.Write(data)
+	return nil
+}
+
+// trimmedField reports whether the field named key is left out of trimmed
+// results
+func trimmedField(key string) bool {
+	return trimmedFields[key] || (strings.HasSuffix(key, "_url") && !keptLinks[key])
+}
@@ -0,0 +1,118 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestShapeJSON(t *testing.T) {
+	value := map[string]interface{}{
+		"login":        "octocat",
+		"id":           1,
+		"url":          "https://api.github.com/users/octocat",
+		"html_url":     "https://github.com/octocat",
+		"repos_url":    "https://api.github.com/users/octocat/repos",
+		"node_id":      "MDQ6VXNlcjE=",
+		"bio":          nil,
+		"company":      "",
+		"public_repos": 0,
+		"site_admin":   false,
+		"plan":         map[string]interface{}{"name": "pro", "url": "x"},
+		"teams":        []interface{}{map[string]interface{}{"slug": "devs", "members_url": "x"}},
+		"files":        []interface{}{map[string]interface{}{"name": "README.md", "git_url": "x", "download_url": "https://raw.githubusercontent.com/octocat/README.md"}},
+	}
+
+	trimmed, err := shapeJSON(map[string]interface{}{}, value)
+	if err != nil {
+		t.Fatalf("shapeJSON failed: %v", err)
+	}
+	want := `{"files":[{"download_url":"https://raw.githubusercontent.com/octocat/README.md","name":"README.md"}],"html_url":"https://github.com/octocat","id":1,"login":"octocat","plan":{"name":"pro"},"public_repos":0,"site_admin":false,"teams":[{"slug":"devs"}]}`
+	if string(trimmed) != want {
+		t.Errorf("Expected %s, got %s", want, trimmed)
+	}
+
+	verbose, err := shapeJSON(map[string]interface{}{verboseArgument: true}, value)
+	if err != nil {
+		t.Fatalf("shapeJSON failed: %v", err)
+	}
+	if len(verbose) <= len(trimmed) {
+		t.Errorf("Expected the verbose object to keep every field, got %s", verbose)
+	}
+}
+
+func TestExecuteGetUser_Shaped(t *testing.T) {
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mocks.MockJSONResponse(http.StatusOK, `{"login":"octocat","id":1,"url":"https://api.github.com/users/octocat","html_url":"https://github.com/octocat","name":"The Octocat","public_repos":8}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+
+	result, err := h.executeGetUser(context.Background(), map[string]interface{}{"username": "octocat"})
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected failure: %v %+v", err, result)
+	}
+	if text := result.Content[0].Text; text[0] != '{' || !json.Valid([]byte(text)) || strings.Contains(text, "api.github.com") {
+		t.Errorf("Expected compact trimmed JSON, got %q", text)
+	}
+	if !strings.HasPrefix(result.Content[0].Text, `{"login":"octocat","id":1,`) {
+		t.Errorf("Expected the fields in their order, got %q", result.Content[0].Text)
+	}
+
+	tool := h.findTool("get_user")
+	if _, ok := schemaProperties(tool.InputSchema)[verboseArgument]; !ok {
+		t.Error("Expected get_user to accept verbose")
+	}
+}
+
+func TestShapeJSON_Fields(t *testing.T) {
+	user := map[string]interface{}{
+		"login": "octocat",
+		"id":    1,
+		"url":   "https://api.github.com/users/octocat",
+		"plan":  map[string]interface{}{"name": "pro", "space": 100},
+	}
+	envelope := listEnvelope{Items: []interface{}{user, user}, Pagination: paginationInfo{Page: 1, PerPage: 2, HasNext: true, NextPage: 2}}
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		fields []interface{}
+		want   string
+	}{
+		{name: "object", value: user, fields: []interface{}{"login", "plan.name"}, want: `{"login":"octocat","plan":{"name":"pro"}}`},
+		{name: "whole parent", value: user, fields: []interface{}{"plan.name", "plan"}, want: `{"plan":{"name":"pro","space":100}}`},
+		{name: "trimmed field by name", value: user, fields: []interface{}{"url"}, want: `{"url":"https://api.github.com/users/octocat"}`},
+		{name: "list items", value: envelope, fields: []interface{}{"id"}, want: `{"items":[{"id":1},{"id":1}],"pagination":{"page":1,"per_page":2,"has_next":true,"next_page":2}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shaped, err := shapeJSON(map[string]interface{}{fieldsArgument: tt.fields}, tt.value)
+			if err != nil {
+				t.Fatalf("shapeJSON failed: %v", err)
+			}
+			if string(shaped) != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, shaped)
+			}
+		})
+	}
+
+	if _, err := shapeJSON(map[string]interface{}{fieldsArgument: []interface{}{"plan..name"}}, user); err == nil {
+		t.Error("Expected an invalid field path to be rejected")
+	}
+
+	h := NewHandler(nil, createTestLogger())
+	if _, ok := schemaProperties(h.findTool("list_teams").InputSchema)[fieldsArgument]; !ok {
+		t.Error("Expected list_teams to accept fields")
+	}
+	if _, ok := schemaProperties(h.findTool("create_team").InputSchema)[fieldsArgument]; ok {
+		t.Error("Expected create_team not to accept fields")
+	}
+}
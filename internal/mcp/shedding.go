@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"math"
+)
+
+// SetLoadShedding enables or disables rejecting tool calls while GitHub is
+// degraded. Shed calls fail immediately with ErrorCodeDegraded instead of
+// queueing and timing out; cached results are still served.
+func (h *Handler) SetLoadShedding(enabled bool) {
+	h.loadShedding.Store(enabled)
+}
+
+// ShedToolCall returns the error response for a tools/call message that
+// would be shed because GitHub is degraded, or nil if it should proceed.
+// Transports use it to reject calls before they take a request slot.
+func (h *Handler) ShedToolCall(msg *JSONRPCMessage) *JSONRPCMessage {
+	if msg.Method != MethodCallTool {
+		return nil
+	}
+
+	var req CallToolRequest
+	if err := msg.GetParams(&req); err != nil {
+		return nil
+	}
+	tool := h.findTool(req.Name)
+	if tool == nil {
+		return nil
+	}
+	args, err := h.normalizeArguments(tool, req.Arguments)
+	if err != nil {
+		return nil
+	}
+	return h.shedError(msg.ID, req.Name, args)
+}
+
+// shedError returns the error response for a tool call that cannot be served
+// while GitHub is degraded, or nil
+func (h *Handler) shedError(id interface{}, name string, args map[string]interface{}) *JSONRPCMessage {
+	if !h.loadShedding.Load() || h.githubClient == nil || localTools[name] {
+		return nil
+	}
+
+	health := h.githubClient.Health()
+	if !health.Degraded {
+		return nil
+	}
+	if h.prefetch != nil && prefetchableTool(name) && h.prefetch.cached(name, args) {
+		return nil
+	}
+
+	h.toolCalls.shed.Add(1)
+	return NewErrorResponse(id, ErrorCodeDegraded, "GitHub is unavailable, tool call rejected",
+		map[string]interface{}{
+			"status":              "degraded",
+			"reason":              health.Reason,
+			"retry_after_seconds": int(math.Ceil(health.RetryAfter.Seconds())),
+		})
+}
@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestHandleCallTool_LoadShedding(t *testing.T) {
+	requests := 0
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests++
+			return mocks.MockErrorResponse(503, "Service Unavailable"), nil
+		},
+	})
+	for i := 0; i < 5; i++ {
+		githubClient.Get(context.Background(), "/user", nil)
+	}
+
+	h := NewHandler(githubClient, createTestLogger())
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+	call := &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+		"name":      "get_user",
+		"arguments": map[string]interface{}{"username": "octocat"},
+	}}
+
+	before := requests
+	if resp := h.handleCallTool(ctx, call); resp.Error != nil {
+		t.Fatalf("Expected the call to proceed with load shedding disabled, got %+v", resp.Error)
+	}
+	if requests == before {
+		t.Error("Expected a GitHub request with load shedding disabled")
+	}
+
+	h.SetLoadShedding(true)
+	before = requests
+	resp := h.handleCallTool(ctx, call)
+	if resp.Error == nil || resp.Error.Code != ErrorCodeDegraded {
+		t.Fatalf("Expected degraded error, got %+v", resp)
+	}
+	data := resp.Error.Data.(map[string]interface{})
+	if data["reason"] != client.DegradedCircuitOpen || data["retry_after_seconds"].(int) <= 0 {
+		t.Errorf("Unexpected error data %v", data)
+	}
+	if requests != before {
+		t.Error("Expected no GitHub request for a shed call")
+	}
+	if shed := h.ShedToolCall(call); shed == nil || shed.Error.Code != ErrorCodeDegraded {
+		t.Errorf("Expected ShedToolCall to reject the call, got %+v", shed)
+	}
+	if h.ToolCallStats().Shed != 2 {
+		t.Errorf("Expected 2 shed calls, got %d", h.ToolCallStats().Shed)
+	}
+
+	local := &JSONRPCMessage{JSONRPC: "2.0", ID: 2, Method: MethodCallTool, Params: map[string]interface{}{"name": "list_recent_deletions"}}
+	if shed := h.ShedToolCall(local); shed != nil {
+		t.Errorf("Expected tools not using GitHub to proceed, got %+v", shed)
+	}
+}
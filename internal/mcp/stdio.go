@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/requestid"
+)
+
+// StdioTransport serves a single MCP client over newline-delimited JSON-RPC
+// messages on an input and output stream, typically the process's stdin and
+// stdout when launched by an MCP host
+type StdioTransport struct {
+	handler *Handler
+	logger  *logger.Logger
+	in      io.Reader
+	out     io.Writer
+	session *Session
+
+	writeMu sync.Mutex
+}
+
+// NewStdioTransport creates a stdio transport with its own session
+func NewStdioTransport(handler *Handler, logger *logger.Logger, in io.Reader, out io.Writer) *StdioTransport {
+	t := &StdioTransport{
+		handler: handler,
+		logger:  logger,
+		in:      in,
+		out:     out,
+		session: NewSession(TransportStdio),
+	}
+	t.session.setNotifier(t.notify)
+	return t
+}
+
+// Session returns the session of the stdio client
+func (t *StdioTransport) Session() *Session {
+	return t.session
+}
+
+// Serve reads messages until the input is closed or the context is cancelled.
+// Messages are handled concurrently and responses are written as they
+// complete. It returns nil when the input reaches EOF.
+func (t *StdioTransport) Serve(ctx context.Context) error {
+	ctx = WithSession(ctx, t.session)
+	reader := bufio.NewReader(t.in)
+	t.handler.addLiveSession(t.session)
+	defer t.handler.removeLiveSession(t.session)
+	defer t.handler.unsubscribeSession(t.session)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			wg.Add(1)
+			go func(data []byte) {
+				defer wg.Done()
+				t.handle(ctx, data)
+			}(line)
+		}
+
+		if err == io.EOF {
+			t.logger.Info("Stdio transport input closed")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// handle processes a single message and writes its response, if any
+func (t *StdioTransport) handle(ctx context.Context, data []byte) {
+	ctx = requestid.NewContext(ctx, requestid.New())
+
+	response, err := t.handler.HandleMessage(ctx, data)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("Failed to process stdio MCP message", "error", err)
+		return
+	}
+	if response == nil {
+		return
+	}
+
+	t.write(response)
+}
+
+// notify writes a message sent outside of a response, such as a notification
+func (t *StdioTransport) notify(msg *JSONRPCMessage) {
+	data, err := msg.ToJSON()
+	if err != nil {
+		t.logger.Error("Failed to marshal stdio MCP notification", "error", err)
+		return
+	}
+	t.write(data)
+}
+
+// write writes a message followed by a newline
+func (t *StdioTransport) write(data []byte) {
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+
+	if _, err := t.out.Write(append(data, '\n')); err != nil {
+		t.logger.Error("Failed to write stdio MCP response", "error", err)
+	}
+}
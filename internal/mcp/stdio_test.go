@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func TestStdioTransportSessionIsolation(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"host","version":"1.0"}}}`,
+		`{"jsonrpc":"2.0","method":"initialized"}`,
+	}, "\n") + "\n"
+
+	var output syncBuffer
+	transport := NewStdioTransport(h, createTestLogger(), strings.NewReader(input), &output)
+	if err := transport.Serve(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected one response for the initialize request, got %d: %q", len(lines), output.buf.String())
+	}
+	var msg JSONRPCMessage
+	if err := json.Unmarshal([]byte(lines[0]), &msg); err != nil {
+		t.Fatalf("Expected JSON-RPC response: %v", err)
+	}
+	if msg.Error != nil {
+		t.Fatalf("Unexpected error response: %+v", msg.Error)
+	}
+
+	if !transport.Session().Initialized() {
+		t.Error("Expected stdio session to be initialized")
+	}
+	if transport.Session().ClientInfo().Name != "host" {
+		t.Errorf("Expected client name host, got %q", transport.Session().ClientInfo().Name)
+	}
+
+	// The HTTP session is unaffected by the stdio handshake
+	response, err := h.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(response, &msg); err != nil {
+		t.Fatalf("Expected JSON-RPC response: %v", err)
+	}
+	if msg.Error == nil {
+		t.Error("Expected tools/list over HTTP to fail before the HTTP session is initialized")
+	}
+
+	// Subsequent stdio requests use the initialized stdio session
+	output.buf.Reset()
+	transport.in = strings.NewReader(`{"jsonrpc":"2.0","id":3,"method":"tools/list"}` + "\n")
+	if err := transport.Serve(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var listMsg JSONRPCMessage
+	if err := json.Unmarshal(bytes.TrimSpace(output.buf.Bytes()), &listMsg); err != nil {
+		t.Fatalf("Expected JSON-RPC response: %v", err)
+	}
+	if listMsg.Error != nil {
+		t.Errorf("Unexpected error response: %+v", listMsg.Error)
+	}
+}
@@ -0,0 +1,63 @@
+package mcp
+
+import (
+	"sort"
+	"time"
+)
+
+// StreamClient describes a connected SSE client
+type StreamClient struct {
+	ID            string    `json:"id"`
+	RemoteAddr    string    `json:"remote_addr"`
+	ConnectedAt   time.Time `json:"connected_at"`
+	LastEventAt   time.Time `json:"last_event_at"`
+	LastEventType string    `json:"last_event_type,omitempty"`
+	LastEventID   uint64    `json:"last_event_id,omitempty"`
+	Events        []string  `json:"events,omitempty"`
+}
+
+// Clients returns the connected SSE clients, oldest connection first
+func (sh *StreamHandler) Clients() []StreamClient {
+	sh.clientsMux.RLock()
+	connections := make([]*ClientConnection, 0, len(sh.clients))
+	for _, client := range sh.clients {
+		connections = append(connections, client)
+	}
+	sh.clientsMux.RUnlock()
+
+	clients := make([]StreamClient, 0, len(connections))
+	for _, client := range connections {
+		client.mu.Lock()
+		clients = append(clients, StreamClient{
+			ID:            client.ID,
+			RemoteAddr:    client.RemoteAddr,
+			ConnectedAt:   client.ConnectedAt,
+			LastEventAt:   client.LastSeen,
+			LastEventType: client.lastEventType,
+			LastEventID:   client.lastEventID,
+			Events:        client.filter.types(),
+		})
+		client.mu.Unlock()
+	}
+
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].ConnectedAt.Before(clients[j].ConnectedAt)
+	})
+	return clients
+}
+
+// DisconnectClient closes the stream of a connected client. It returns false
+// if no client has the given ID.
+func (sh *StreamHandler) DisconnectClient(clientID string) bool {
+	sh.clientsMux.RLock()
+	client, exists := sh.clients[clientID]
+	sh.clientsMux.RUnlock()
+
+	if !exists {
+		return false
+	}
+
+	sh.logger.Info("Disconnecting SSE client", "clientID", clientID)
+	client.close()
+	return true
+}
@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// eventFilter selects the SSE event types a client receives. A nil filter
+// allows every event; a type ending in "*" matches by prefix, e.g. "mcp_*".
+type eventFilter map[string]bool
+
+// parseEventFilter builds a filter from comma-separated event types, or
+// returns nil if no types are given
+func parseEventFilter(values []string) eventFilter {
+	var filter eventFilter
+	for _, value := range values {
+		for _, eventType := range strings.Split(value, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				if filter == nil {
+					filter = make(eventFilter)
+				}
+				filter[eventType] = true
+			}
+		}
+	}
+	return filter
+}
+
+// allows reports whether events of the given type pass the filter
+func (f eventFilter) allows(eventType string) bool {
+	if f == nil || f[eventType] {
+		return true
+	}
+	for pattern := range f {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// types returns the filtered event types in sorted order, or nil for all events
+func (f eventFilter) types() []string {
+	if f == nil {
+		return nil
+	}
+	types := make([]string, 0, len(f))
+	for eventType := range f {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// SetClientFilter changes the event types a connected client receives; an
+// empty list restores all events. It returns false if the client is not
+// connected with a stream opened by owner, in the session with sessionID
+// when the stream was opened in one.
+func (sh *StreamHandler) SetClientFilter(clientID, owner, sessionID string, eventTypes []string) bool {
+	sh.clientsMux.RLock()
+	client, exists := sh.clients[clientID]
+	sh.clientsMux.RUnlock()
+
+	if !exists || client.owner != owner || (client.session != "" && client.session != sessionID) {
+		return false
+	}
+
+	client.mu.Lock()
+	client.filter = parseEventFilter(eventTypes)
+	client.mu.Unlock()
+
+	sh.logger.Info("SSE client event filter updated", "clientID", clientID, "events", eventTypes)
+	return true
+}
+
+// streamSubscribeRequest is the body of a request changing a client's event filter
+type streamSubscribeRequest struct {
+	ClientID string   `json:"clientId"`
+	Events   []string `json:"events"`
+}
+
+// HandleSubscribe changes the event filter of a connected SSE client. The
+// client ID is the one sent in the stream's "connected" event; the request
+// must come from whoever opened the stream, in the same session.
+func (sh *StreamHandler) HandleSubscribe(w http.ResponseWriter, r *http.Request) {
+	var req streamSubscribeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ClientID == "" {
+		writeSubscribeResponse(w, http.StatusBadRequest, map[string]interface{}{
+			"error": "request body must be a JSON object with clientId and events",
+		})
+		return
+	}
+
+	if !sh.SetClientFilter(req.ClientID, streamOwner(r.Context()), r.Header.Get(SessionIDHeader), req.Events) {
+		writeSubscribeResponse(w, http.StatusNotFound, map[string]interface{}{
+			"error": "SSE client is not connected",
+		})
+		return
+	}
+
+	writeSubscribeResponse(w, http.StatusOK, map[string]interface{}{
+		"clientId": req.ClientID,
+		"events":   parseEventFilter(req.Events).types(),
+	})
+}
+
+// writeSubscribeResponse writes a JSON response to a subscribe request
+func writeSubscribeResponse(w http.ResponseWriter, statusCode int, body map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
@@ -1,42 +1,128 @@
 package mcp
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/pubsub"
+)
+
+// ClientIDHeader is the response header carrying an SSE client's ID, which is
+// also sent in the connected event. Clients send it back with requests to
+// /mcp/request to receive the notifications of their subscriptions.
+const ClientIDHeader = "X-MCP-Client-ID"
+
+// streamClosedEvent is published to other replicas when a client's stream
+// closes, so they drop what they hold for it; it is never sent to clients
+const streamClosedEvent = "stream_closed"
+
+const (
+	// publishTimeout bounds publishing an event to other replicas
+	publishTimeout = 2 * time.Second
+	// publishQueueSize bounds the events waiting to be published to other
+	// replicas; events beyond it are dropped rather than delay callers
+	publishQueueSize = 1024
 )
 
 // ClientConnection represents an active SSE client connection
 type ClientConnection struct {
-	ID       string
-	Writer   http.ResponseWriter
-	Flusher  http.Flusher
-	Done     chan struct{}
-	LastSeen time.Time
+	ID          string
+	RemoteAddr  string
+	ConnectedAt time.Time
+	Writer      http.ResponseWriter
+	Flusher     http.Flusher
+	Done        chan struct{}
+	LastSeen    time.Time
+
+	// mu serializes writes; lastEventID and lastEventType describe the last event written
+	mu            sync.Mutex
+	lastEventID   uint64
+	lastEventType string
+	closeOnce     sync.Once
+
+	// filter selects the event types sent to the client; nil sends all events
+	filter eventFilter
+
+	// owner identifies who opened the stream; messages addressed to the
+	// client are only delivered for the same owner
+	owner string
+	// session is the Mcp-Session-Id the stream was opened with, if any,
+	// which requests changing its filter must send too
+	session string
+}
+
+// close ends the client's stream; it is safe to call more than once
+func (c *ClientConnection) close() {
+	c.closeOnce.Do(func() { close(c.Done) })
 }
 
+// Heartbeat formats
+const (
+	// HeartbeatEvent sends heartbeats as JSON "heartbeat" events
+	HeartbeatEvent = "event"
+	// HeartbeatComment sends heartbeats as ": keepalive" SSE comments, which
+	// clients ignore
+	HeartbeatComment = "comment"
+)
+
 // StreamHandler manages SSE connections and handles streaming MCP messages to clients
 type StreamHandler struct {
 	logger     *logger.Logger
 	clients    map[string]*ClientConnection
 	clientsMux sync.RWMutex
 	streamer   *MCPStreamer
-	heartbeat  time.Duration
-	stopCh     chan struct{}
-	wg         sync.WaitGroup
+	events     *eventBuffer
+
+	// heartbeat is the interval between heartbeats; zero disables them
+	heartbeat       time.Duration
+	heartbeatFormat string
+
+	// broker fans events out to other replicas; origin identifies this
+	// replica's messages so they are not delivered twice
+	broker        pubsub.Broker
+	origin        string
+	stopSubscribe context.CancelFunc
+	// outbox queues the events published to other replicas in the
+	// background, so broadcasts never wait on the broker
+	outbox chan *pubsub.Message
+
+	// generateID returns new client IDs; idCollisions counts IDs drawn again
+	// because they were already in use
+	generateID   func() string
+	idCollisions atomic.Int64
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+
+	// draining is set once clients have been told the server is shutting down
+	draining atomic.Bool
+
+	// onClose is called with the ID of every client whose stream closed,
+	// on this replica or another
+	onClose func(clientID string)
 }
 
 // NewStreamHandler creates a new StreamHandler instance
 func NewStreamHandler(logger *logger.Logger) *StreamHandler {
 	sh := &StreamHandler{
-		logger:    logger,
-		clients:   make(map[string]*ClientConnection),
-		heartbeat: 30 * time.Second, // Send heartbeat every 30 seconds
-		stopCh:    make(chan struct{}),
+		logger:          logger,
+		clients:         make(map[string]*ClientConnection),
+		heartbeat:       30 * time.Second, // Send heartbeat every 30 seconds
+		heartbeatFormat: HeartbeatEvent,
+		events:          newEventBuffer(defaultReplayBufferSize),
+		generateID:      newClientID,
+		stopCh:          make(chan struct{}),
 	}
 
 	// Create MCPStreamer with reference to this handler
@@ -45,23 +131,110 @@ func NewStreamHandler(logger *logger.Logger) *StreamHandler {
 	return sh
 }
 
+// SetReplayBufferSize sets how many broadcast events are retained for clients
+// resuming with Last-Event-ID; zero disables replay. It must be called before
+// any events are sent.
+func (sh *StreamHandler) SetReplayBufferSize(size int) {
+	sh.events = newEventBuffer(size)
+}
+
+// SetHeartbeat sets the interval and format of heartbeats; an interval of
+// zero disables them for clients that manage their own keepalive. It must be
+// called before Start.
+func (sh *StreamHandler) SetHeartbeat(interval time.Duration, format string) {
+	sh.heartbeat = interval
+	sh.heartbeatFormat = format
+}
+
+// SetBroker fans broadcasts out to the clients of every replica subscribed
+// to broker, and delivers messages for clients connected to another replica
+// there. It must be called before Start.
+func (sh *StreamHandler) SetBroker(broker pubsub.Broker) {
+	sh.broker = broker
+	sh.origin = newReplicaID()
+	sh.outbox = make(chan *pubsub.Message, publishQueueSize)
+}
+
+// Distributed reports whether events are also delivered to the clients of
+// other replicas
+func (sh *StreamHandler) Distributed() bool {
+	return sh.broker != nil
+}
+
+// OnClientClosed sets the function called with the ID of every client whose
+// stream closes, including the clients of other replicas when a broker is
+// set. It must be called before Start.
+func (sh *StreamHandler) OnClientClosed(onClose func(clientID string)) {
+	sh.onClose = onClose
+}
+
 // Start begins the background processes for the stream handler
 func (sh *StreamHandler) Start() {
-	sh.wg.Add(1)
-	go sh.heartbeatLoop()
+	if sh.broker != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		sh.stopSubscribe = cancel
+		sh.wg.Add(2)
+		go sh.subscribeLoop(ctx)
+		go sh.publishLoop()
+	}
+	if sh.heartbeat > 0 {
+		sh.wg.Add(1)
+		go sh.heartbeatLoop()
+	}
 }
 
 // Stop gracefully stops the stream handler
 func (sh *StreamHandler) Stop() {
 	close(sh.stopCh)
+	if sh.stopSubscribe != nil {
+		sh.stopSubscribe()
+	}
 	sh.wg.Wait()
+	if sh.broker != nil {
+		if err := sh.broker.Close(); err != nil {
+			sh.logger.Warn("Failed to close pub/sub broker", "error", err)
+		}
+	}
+	sh.CloseClients()
+}
+
+// BeginShutdown sends every client a server_shutdown event asking it to
+// reconnect after retry, and rejects new connections from then on. Streams
+// stay open so results of in-flight tool calls can still be delivered.
+func (sh *StreamHandler) BeginShutdown(retry time.Duration) {
+	if sh.draining.Swap(true) {
+		return
+	}
+
+	sh.clientsMux.RLock()
+	clients := make([]*ClientConnection, 0, len(sh.clients))
+	for _, client := range sh.clients {
+		clients = append(clients, client)
+	}
+	sh.clientsMux.RUnlock()
 
-	// Close all client connections
+	for _, client := range clients {
+		client.mu.Lock()
+		// The retry field sets the delay EventSource clients wait before reconnecting
+		if _, err := fmt.Fprintf(client.Writer, "retry: %d\n", retry.Milliseconds()); err == nil {
+			sh.writeEvent(client, 0, "server_shutdown", map[string]interface{}{
+				"message":          "Server is shutting down",
+				"reconnectAfterMs": retry.Milliseconds(),
+			})
+		}
+		client.mu.Unlock()
+	}
+
+	sh.logger.Info("Notified SSE clients of shutdown", "count", len(clients))
+}
+
+// CloseClients closes all client connections
+func (sh *StreamHandler) CloseClients() {
 	sh.clientsMux.Lock()
 	defer sh.clientsMux.Unlock()
 
 	for _, client := range sh.clients {
-		close(client.Done)
+		client.close()
 	}
 	sh.clients = make(map[string]*ClientConnection)
 }
@@ -76,37 +249,60 @@ func (sh *StreamHandler) HandleSSE(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if sh.draining.Load() {
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
 	// Set SSE headers
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Cache-Control")
-
-	// Generate unique client ID
-	clientID := sh.generateClientID()
+	w.Header().Set("Access-Control-Allow-Headers", "Cache-Control, Last-Event-ID")
+	w.Header().Set("Access-Control-Expose-Headers", ClientIDHeader)
 
-	// Create client connection
+	// Create client connection; its ID is assigned when it is registered
+	now := time.Now()
 	client := &ClientConnection{
-		ID:       clientID,
-		Writer:   w,
-		Flusher:  flusher,
-		Done:     make(chan struct{}),
-		LastSeen: time.Now(),
+		RemoteAddr:  r.RemoteAddr,
+		ConnectedAt: now,
+		Writer:      w,
+		Flusher:     flusher,
+		Done:        make(chan struct{}),
+		LastSeen:    now,
+		filter:      parseEventFilter(r.URL.Query()["events"]),
+		owner:       streamOwner(r.Context()),
+		session:     r.Header.Get(SessionIDHeader),
 	}
 
-	// Register client
+	lastEventID, resuming := parseLastEventID(r)
+
+	// Hold the client's write lock until replay completes so live events
+	// broadcast in the meantime are delivered after the replayed ones
+	client.mu.Lock()
 	sh.addClient(client)
-	defer sh.removeClient(clientID)
+	clientID := client.ID
+	defer sh.closeClient(clientID)
+	w.Header().Set(ClientIDHeader, clientID)
 
-	sh.logger.Info("SSE client connected", "clientID", clientID, "remoteAddr", r.RemoteAddr)
+	sh.logger.Info("SSE client connected", "clientID", clientID, "remoteAddr", r.RemoteAddr, "lastEventID", lastEventID)
 
 	// Send initial connection event
-	sh.sendEvent(client, "connected", map[string]interface{}{
+	sh.writeEvent(client, 0, "connected", map[string]interface{}{
 		"clientId": clientID,
 		"message":  "Connected to MCP stream",
+		"events":   client.filter.types(),
 	})
 
+	if resuming {
+		sh.replayEvents(client, lastEventID)
+	} else {
+		_, client.lastEventID, _ = sh.events.since(0)
+	}
+	client.mu.Unlock()
+
 	// Keep connection alive until client disconnects or context is cancelled
 	select {
 	case <-r.Context().Done():
@@ -121,8 +317,19 @@ func (sh *StreamHandler) GetStreamer() *MCPStreamer {
 	return sh.streamer
 }
 
-// BroadcastMessage sends a message to all connected clients
+// BroadcastMessage sends a message to all connected clients, including those
+// of other replicas when a broker is set, and retains it for replay to
+// clients that reconnect
 func (sh *StreamHandler) BroadcastMessage(eventType string, data interface{}) {
+	encoded := marshalEventData(data)
+	sh.broadcastLocal(eventType, encoded)
+	sh.publish(&pubsub.Message{EventType: eventType, Data: encoded})
+}
+
+// broadcastLocal sends an event to the clients connected to this replica
+func (sh *StreamHandler) broadcastLocal(eventType string, data json.RawMessage) {
+	event := sh.events.append(eventType, data)
+
 	sh.clientsMux.RLock()
 	clients := make([]*ClientConnection, 0, len(sh.clients))
 	for _, client := range sh.clients {
@@ -131,22 +338,155 @@ func (sh *StreamHandler) BroadcastMessage(eventType string, data interface{}) {
 	sh.clientsMux.RUnlock()
 
 	for _, client := range clients {
-		sh.sendEvent(client, eventType, data)
+		sh.sendEvent(client, event.ID, event.EventType, event.Data)
 	}
 }
 
-// SendToClient sends a message to a specific client
-func (sh *StreamHandler) SendToClient(clientID, eventType string, data interface{}) {
+// SendToOwner sends a message to the clients whose streams were opened by
+// owner, including those of other replicas when a broker is set, and retains
+// it for replay to them only
+func (sh *StreamHandler) SendToOwner(owner, eventType string, data interface{}) {
+	encoded := marshalEventData(data)
+	sh.sendLocalToOwner(owner, eventType, encoded)
+	sh.publish(&pubsub.Message{Owner: owner, OwnerOnly: true, EventType: eventType, Data: encoded})
+}
+
+// sendLocalToOwner sends an event to the clients connected to this replica
+// whose streams were opened by owner
+func (sh *StreamHandler) sendLocalToOwner(owner, eventType string, data json.RawMessage) {
+	event := sh.events.appendOwned(owner, eventType, data)
+
+	sh.clientsMux.RLock()
+	var clients []*ClientConnection
+	for _, client := range sh.clients {
+		if client.owner == owner {
+			clients = append(clients, client)
+		}
+	}
+	sh.clientsMux.RUnlock()
+
+	for _, client := range clients {
+		sh.sendEvent(client, event.ID, event.EventType, event.Data)
+	}
+}
+
+// SendToClient sends a message to a specific client, if its stream was
+// opened by owner
+func (sh *StreamHandler) SendToClient(clientID, owner, eventType string, data interface{}) {
 	sh.clientsMux.RLock()
 	client, exists := sh.clients[clientID]
 	sh.clientsMux.RUnlock()
 
 	if !exists {
+		// The client may be connected to another replica
+		if sh.broker != nil {
+			sh.publish(&pubsub.Message{ClientID: clientID, Owner: owner, EventType: eventType, Data: marshalEventData(data)})
+			return
+		}
 		sh.logger.Warn("Attempted to send message to non-existent client", "clientID", clientID)
 		return
 	}
+	if client.owner != owner {
+		sh.logger.Warn("Attempted to send message to a client of another owner", "clientID", clientID)
+		return
+	}
 
-	sh.sendEvent(client, eventType, data)
+	sh.sendEvent(client, sh.events.nextID(), eventType, data)
+}
+
+// ClientOwner returns who opened the stream of a client, and whether the
+// client is connected to this replica
+func (sh *StreamHandler) ClientOwner(clientID string) (string, bool) {
+	sh.clientsMux.RLock()
+	defer sh.clientsMux.RUnlock()
+	client, exists := sh.clients[clientID]
+	if !exists {
+		return "", false
+	}
+	return client.owner, true
+}
+
+// publish queues msg to be sent to the other replicas when a broker is set,
+// dropping it when the queue is full
+func (sh *StreamHandler) publish(msg *pubsub.Message) {
+	if sh.broker == nil {
+		return
+	}
+	msg.Origin = sh.origin
+
+	select {
+	case sh.outbox <- msg:
+	default:
+		sh.logger.Warn("Pub/sub queue full, SSE event not sent to other replicas", "eventType", msg.EventType)
+	}
+}
+
+// publishLoop sends queued events to the other replicas in order until the
+// handler stops, then sends those still queued
+func (sh *StreamHandler) publishLoop() {
+	defer sh.wg.Done()
+
+	send := func(msg *pubsub.Message) {
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		defer cancel()
+		if err := sh.broker.Publish(ctx, msg); err != nil {
+			sh.logger.Error("Failed to publish SSE event to other replicas", "eventType", msg.EventType, "error", err)
+		}
+	}
+	for {
+		select {
+		case msg := <-sh.outbox:
+			send(msg)
+		case <-sh.stopCh:
+			for {
+				select {
+				case msg := <-sh.outbox:
+					send(msg)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// subscribeLoop delivers messages published by other replicas until ctx is
+// cancelled
+func (sh *StreamHandler) subscribeLoop(ctx context.Context) {
+	defer sh.wg.Done()
+
+	if err := sh.broker.Subscribe(ctx, sh.deliverRemote); err != nil && !errors.Is(err, context.Canceled) {
+		sh.logger.Error("Pub/sub subscription ended", "error", err)
+	}
+}
+
+// deliverRemote delivers a message published by another replica to the
+// clients connected to this one
+func (sh *StreamHandler) deliverRemote(msg *pubsub.Message) {
+	if msg.Origin == sh.origin {
+		return
+	}
+	if msg.ClientID == "" && msg.OwnerOnly {
+		sh.sendLocalToOwner(msg.Owner, msg.EventType, msg.Data)
+		return
+	}
+	if msg.ClientID == "" {
+		sh.broadcastLocal(msg.EventType, msg.Data)
+		return
+	}
+	if msg.EventType == streamClosedEvent {
+		if sh.onClose != nil {
+			sh.onClose(msg.ClientID)
+		}
+		return
+	}
+
+	sh.clientsMux.RLock()
+	client, exists := sh.clients[msg.ClientID]
+	sh.clientsMux.RUnlock()
+	if exists && client.owner == msg.Owner {
+		sh.sendEvent(client, sh.events.nextID(), msg.EventType, msg.Data)
+	}
 }
 
 // GetConnectedClients returns the number of connected clients
@@ -156,13 +496,29 @@ func (sh *StreamHandler) GetConnectedClients() int {
 	return len(sh.clients)
 }
 
-// addClient adds a new client connection
+// addClient adds a new client connection under a newly generated ID, drawing
+// another if the ID is already in use so no connection is ever replaced
 func (sh *StreamHandler) addClient(client *ClientConnection) {
 	sh.clientsMux.Lock()
 	defer sh.clientsMux.Unlock()
+
+	for {
+		client.ID = sh.generateID()
+		if _, taken := sh.clients[client.ID]; !taken {
+			break
+		}
+		sh.idCollisions.Add(1)
+		sh.logger.Warn("SSE client ID collision, generating a new ID", "clientID", client.ID)
+	}
 	sh.clients[client.ID] = client
 }
 
+// ClientIDCollisions returns how many generated client IDs were discarded
+// because they were already in use
+func (sh *StreamHandler) ClientIDCollisions() int64 {
+	return sh.idCollisions.Load()
+}
+
 // removeClient removes a client connection
 func (sh *StreamHandler) removeClient(clientID string) {
 	sh.clientsMux.Lock()
@@ -170,8 +526,71 @@ func (sh *StreamHandler) removeClient(clientID string) {
 	delete(sh.clients, clientID)
 }
 
-// sendEvent sends an SSE event to a specific client
-func (sh *StreamHandler) sendEvent(client *ClientConnection, eventType string, data interface{}) {
+// closeClient removes a client whose stream closed and tells this replica
+// and the others it is gone
+func (sh *StreamHandler) closeClient(clientID string) {
+	sh.removeClient(clientID)
+	if sh.onClose != nil {
+		sh.onClose(clientID)
+	}
+	sh.publish(&pubsub.Message{ClientID: clientID, EventType: streamClosedEvent})
+}
+
+// sendEvent sends an SSE event to a specific client. Events with an ID the
+// client has already received, e.g. during replay, and events excluded by
+// the client's filter are skipped.
+func (sh *StreamHandler) sendEvent(client *ClientConnection, id uint64, eventType string, data interface{}) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	if id != 0 && id <= client.lastEventID {
+		return
+	}
+	if !client.filter.allows(eventType) {
+		return
+	}
+
+	sh.writeEvent(client, id, eventType, data)
+}
+
+// replayEvents resends retained events after lastEventID to a reconnecting client.
+// The caller must hold the client's write lock.
+func (sh *StreamHandler) replayEvents(client *ClientConnection, lastEventID uint64) {
+	events, newest, complete := sh.events.since(lastEventID)
+
+	if !complete {
+		sh.logger.Warn("SSE replay incomplete, some events are no longer buffered",
+			"clientID", client.ID,
+			"lastEventID", lastEventID,
+			"newestEventID", newest)
+		sh.writeEvent(client, 0, "replay_truncated", map[string]interface{}{
+			"lastEventId":   lastEventID,
+			"newestEventId": newest,
+			"message":       "Some events since the last received event are no longer available",
+		})
+	}
+
+	replayed := 0
+	for _, event := range events {
+		if event.Owned && event.Owner != client.owner {
+			continue
+		}
+		if client.filter.allows(event.EventType) {
+			sh.writeEvent(client, event.ID, event.EventType, event.Data)
+			replayed++
+		}
+	}
+	client.lastEventID = newest
+
+	sh.logger.Debug("Replayed SSE events to client",
+		"clientID", client.ID,
+		"lastEventID", lastEventID,
+		"replayed", replayed)
+}
+
+// writeEvent writes an SSE event to a client. The caller must hold the client's
+// write lock; an id of zero writes the event without an id field.
+func (sh *StreamHandler) writeEvent(client *ClientConnection, id uint64, eventType string, data interface{}) {
 	// Check if client connection is still active
 	select {
 	case <-client.Done:
@@ -181,11 +600,15 @@ func (sh *StreamHandler) sendEvent(client *ClientConnection, eventType string, d
 
 	// Format SSE event
 	event := formatSSEEvent(eventType, data)
+	if id != 0 {
+		event = fmt.Sprintf("id: %d\n", id) + event
+		client.lastEventID = id
+	}
 
 	// Write event to client
 	if _, err := fmt.Fprint(client.Writer, event); err != nil {
 		sh.logger.Error("Failed to write SSE event to client", "clientID", client.ID, "error", err)
-		close(client.Done)
+		client.close()
 		return
 	}
 
@@ -194,6 +617,28 @@ func (sh *StreamHandler) sendEvent(client *ClientConnection, eventType string, d
 
 	// Update last seen time
 	client.LastSeen = time.Now()
+	client.lastEventType = eventType
+}
+
+// sendComment writes an SSE comment line to a client. Comments are not
+// events, so they bypass the client's filter and are not replayed.
+func (sh *StreamHandler) sendComment(client *ClientConnection, text string) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	select {
+	case <-client.Done:
+		return
+	default:
+	}
+
+	if _, err := fmt.Fprintf(client.Writer, ": %s\n\n", text); err != nil {
+		sh.logger.Error("Failed to write SSE comment to client", "clientID", client.ID, "error", err)
+		client.close()
+		return
+	}
+	client.Flusher.Flush()
+	client.LastSeen = time.Now()
 }
 
 // heartbeatLoop sends periodic heartbeat messages to keep connections alive
@@ -223,8 +668,11 @@ func (sh *StreamHandler) sendHeartbeat() {
 	sh.clientsMux.RUnlock()
 
 	for _, client := range clients {
-		// Send heartbeat event
-		sh.sendEvent(client, "heartbeat", map[string]interface{}{
+		if sh.heartbeatFormat == HeartbeatComment {
+			sh.sendComment(client, "keepalive")
+			continue
+		}
+		sh.sendEvent(client, 0, "heartbeat", map[string]interface{}{
 			"timestamp": time.Now().Unix(),
 		})
 	}
@@ -232,9 +680,45 @@ func (sh *StreamHandler) sendHeartbeat() {
 	sh.logger.Debug("Sent heartbeat to clients", "count", len(clients))
 }
 
-// generateClientID generates a unique client ID
-func (sh *StreamHandler) generateClientID() string {
-	return fmt.Sprintf("client_%d", time.Now().UnixNano())
+// newClientID returns a random (version 4) UUID
+func newClientID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// newReplicaID returns a random identifier for this replica's pub/sub messages
+func newReplicaID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("replica_%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// parseLastEventID reads the Last-Event-ID header sent by reconnecting clients
+func parseLastEventID(r *http.Request) (uint64, bool) {
+	header := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	if header == "" {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(header, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// marshalEventData encodes event data once so it can be retained and sent to many clients
+func marshalEventData(data interface{}) json.RawMessage {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		jsonData = []byte(fmt.Sprintf(`{"error": "failed to marshal data: %v"}`, err))
+	}
+	return jsonData
 }
 
 // formatSSEEvent formats data as an SSE event
@@ -9,6 +9,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/pubsub"
 )
 
 // mockResponseWriter implements http.ResponseWriter and http.Flusher for testing
@@ -18,6 +20,8 @@ type mockResponseWriter struct {
 	body       []byte
 	flushed    bool
 	mu         sync.Mutex
+	// written is signalled after each write, for waitForBody
+	written chan struct{}
 }
 
 // Ensure mockResponseWriter implements http.Flusher
@@ -29,6 +33,7 @@ func newMockResponseWriter() *mockResponseWriter {
 		statusCode: 200,
 		body:       make([]byte, 0),
 		flushed:    false,
+		written:    make(chan struct{}, 1),
 	}
 }
 
@@ -40,9 +45,27 @@ func (m *mockResponseWriter) Write(data []byte) (int, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.body = append(m.body, data...)
+	select {
+	case m.written <- struct{}{}:
+	default:
+	}
 	return len(data), nil
 }
 
+// waitForBody waits until the body contains want, failing the test if it
+// does not within a second
+func (m *mockResponseWriter) waitForBody(t *testing.T, want string) {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for !strings.Contains(m.GetBody(), want) {
+		select {
+		case <-m.written:
+		case <-timeout:
+			t.Fatalf("Expected the body to contain %q, got %q", want, m.GetBody())
+		}
+	}
+}
+
 func (m *mockResponseWriter) WriteHeader(statusCode int) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -211,7 +234,7 @@ func TestSendToClient(t *testing.T) {
 
 	// Since we can't easily get the client ID in this test setup,
 	// we'll test the method exists and doesn't panic
-	sh.SendToClient("non-existent-client", "test", testData)
+	sh.SendToClient("non-existent-client", "", "test", testData)
 
 	// Give some time for processing
 	time.Sleep(50 * time.Millisecond)
@@ -389,3 +412,414 @@ func TestMultipleBroadcasts(t *testing.T) {
 		}
 	}
 }
+
+func TestHandleSSE_ResumeWithLastEventID(t *testing.T) {
+	logger := createTestLogger()
+	sh := NewStreamHandler(logger)
+
+	// Broadcast events while no clients are connected
+	for i := 1; i <= 3; i++ {
+		sh.BroadcastMessage("test", map[string]interface{}{
+			"message": fmt.Sprintf("missed %d", i),
+		})
+	}
+
+	w := newMockResponseWriter()
+	req := httptest.NewRequest("GET", "/mcp/stream", nil)
+	req.Header.Set("Last-Event-ID", "1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	go sh.HandleSSE(w, req)
+	time.Sleep(50 * time.Millisecond)
+
+	sh.BroadcastMessage("test", map[string]interface{}{"message": "live 4"})
+	time.Sleep(50 * time.Millisecond)
+
+	body := w.GetBody()
+	if strings.Contains(body, "missed 1") {
+		t.Error("Expected event 1 not to be replayed")
+	}
+
+	missed2 := strings.Index(body, "id: 2\nevent: test")
+	missed3 := strings.Index(body, "id: 3\nevent: test")
+	live4 := strings.Index(body, "id: 4\nevent: test")
+	if missed2 == -1 || missed3 == -1 || live4 == -1 {
+		t.Fatalf("Expected replayed events 2 and 3 followed by live event 4, got:\n%s", body)
+	}
+	if !(missed2 < missed3 && missed3 < live4) {
+		t.Errorf("Expected events in ID order, got:\n%s", body)
+	}
+}
+
+func TestHandleSSE_EventFilter(t *testing.T) {
+	logger := createTestLogger()
+	sh := NewStreamHandler(logger)
+
+	w := newMockResponseWriter()
+	req := httptest.NewRequest("GET", "/mcp/stream?events=mcp_notification,tool_*", nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	go sh.HandleSSE(w, req)
+	time.Sleep(50 * time.Millisecond)
+
+	sh.BroadcastMessage("mcp_notification", map[string]interface{}{"message": "wanted notification"})
+	sh.BroadcastMessage("tool_progress", map[string]interface{}{"message": "wanted progress"})
+	sh.BroadcastMessage("mcp_response", map[string]interface{}{"message": "unwanted response"})
+	time.Sleep(50 * time.Millisecond)
+
+	body := w.GetBody()
+	if !strings.Contains(body, "wanted notification") || !strings.Contains(body, "wanted progress") {
+		t.Errorf("Expected filtered event types to be delivered, got:\n%s", body)
+	}
+	if strings.Contains(body, "unwanted response") {
+		t.Errorf("Expected other event types to be filtered out, got:\n%s", body)
+	}
+
+	// Subscribing with an empty list restores all events
+	sh.clientsMux.RLock()
+	var clientID string
+	for id := range sh.clients {
+		clientID = id
+	}
+	sh.clientsMux.RUnlock()
+
+	rec := httptest.NewRecorder()
+	sh.HandleSubscribe(rec, httptest.NewRequest("POST", "/mcp/stream/subscribe",
+		strings.NewReader(fmt.Sprintf(`{"clientId": %q, "events": []}`, clientID))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	sh.BroadcastMessage("mcp_response", map[string]interface{}{"message": "now wanted"})
+	time.Sleep(50 * time.Millisecond)
+	if !strings.Contains(w.GetBody(), "now wanted") {
+		t.Error("Expected all events after clearing the filter")
+	}
+
+	rec = httptest.NewRecorder()
+	sh.HandleSubscribe(rec, httptest.NewRequest("POST", "/mcp/stream/subscribe",
+		strings.NewReader(`{"clientId": "missing", "events": ["heartbeat"]}`)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown client, got %d", rec.Code)
+	}
+}
+
+func TestClientsAndDisconnect(t *testing.T) {
+	sh := NewStreamHandler(createTestLogger())
+
+	w := newMockResponseWriter()
+	req := httptest.NewRequest("GET", "/mcp/stream?events=tool_*", nil)
+	req.RemoteAddr = "192.0.2.10:5000"
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		sh.HandleSSE(w, req)
+	}()
+	waitFor(t, func() bool { return sh.GetConnectedClients() == 1 })
+
+	clients := sh.Clients()
+	if len(clients) != 1 {
+		t.Fatalf("Expected 1 client, got %d", len(clients))
+	}
+	client := clients[0]
+	if client.RemoteAddr != "192.0.2.10:5000" || client.LastEventType != "connected" {
+		t.Errorf("Unexpected client %+v", client)
+	}
+	if len(client.Events) != 1 || client.Events[0] != "tool_*" {
+		t.Errorf("Expected event filter tool_*, got %v", client.Events)
+	}
+
+	if sh.DisconnectClient("missing") {
+		t.Error("Expected disconnecting an unknown client to fail")
+	}
+	if !sh.DisconnectClient(client.ID) {
+		t.Fatal("Expected client to be disconnected")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected SSE handler to return after disconnect")
+	}
+	if sh.GetConnectedClients() != 0 {
+		t.Errorf("Expected 0 connected clients, got %d", sh.GetConnectedClients())
+	}
+
+	// Closing an already closed client must not panic
+	sh.Stop()
+}
+
+func TestBeginShutdown(t *testing.T) {
+	sh := NewStreamHandler(createTestLogger())
+
+	w := newMockResponseWriter()
+	done := make(chan struct{})
+	go func() {
+		sh.HandleSSE(w, httptest.NewRequest("GET", "/mcp/stream?events=tool_progress", nil))
+		close(done)
+	}()
+	w.waitForBody(t, "event: connected\n")
+
+	sh.BeginShutdown(2 * time.Second)
+
+	body := w.GetBody()
+	if !strings.Contains(body, "retry: 2000\nevent: server_shutdown\n") {
+		t.Errorf("Expected server_shutdown event with retry hint despite the event filter, got %q", body)
+	}
+	if !strings.Contains(body, `"reconnectAfterMs":2000`) {
+		t.Errorf("Expected reconnect hint in event data, got %q", body)
+	}
+
+	// Existing streams stay open while draining, new ones are refused
+	if sh.GetConnectedClients() != 1 {
+		t.Errorf("Expected the client to stay connected, got %d clients", sh.GetConnectedClients())
+	}
+	rejected := httptest.NewRecorder()
+	sh.HandleSSE(rejected, httptest.NewRequest("GET", "/mcp/stream", nil))
+	if rejected.Code != http.StatusServiceUnavailable || rejected.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected 503 with Retry-After while draining, got %d", rejected.Code)
+	}
+
+	sh.CloseClients()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the stream to end after CloseClients")
+	}
+}
+
+func TestHeartbeat_Formats(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{HeartbeatEvent, "event: heartbeat\n"},
+		{HeartbeatComment, ": keepalive\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			sh := NewStreamHandler(createTestLogger())
+			sh.SetHeartbeat(20*time.Millisecond, tt.format)
+			sh.Start()
+			defer sh.Stop()
+
+			w := newMockResponseWriter()
+			go sh.HandleSSE(w, httptest.NewRequest("GET", "/mcp/stream", nil))
+			w.waitForBody(t, tt.want)
+		})
+	}
+}
+
+func TestHeartbeat_Disabled(t *testing.T) {
+	sh := NewStreamHandler(createTestLogger())
+	sh.SetHeartbeat(0, HeartbeatEvent)
+	sh.Start()
+	defer sh.Stop()
+
+	// A stream with heartbeats enabled marks the time a heartbeat was due
+	reference := NewStreamHandler(createTestLogger())
+	reference.SetHeartbeat(10*time.Millisecond, HeartbeatEvent)
+	reference.Start()
+	defer reference.Stop()
+
+	w := newMockResponseWriter()
+	go sh.HandleSSE(w, httptest.NewRequest("GET", "/mcp/stream", nil))
+	w.waitForBody(t, "event: connected\n")
+	referenceWriter := newMockResponseWriter()
+	go reference.HandleSSE(referenceWriter, httptest.NewRequest("GET", "/mcp/stream", nil))
+	referenceWriter.waitForBody(t, "event: heartbeat\n")
+
+	if body := w.GetBody(); strings.Contains(body, "heartbeat") {
+		t.Errorf("Expected no heartbeats when disabled, got %q", body)
+	}
+}
+
+func TestBroker_FansOutBetweenReplicas(t *testing.T) {
+	logger := createTestLogger()
+	broker := pubsub.NewLocal()
+
+	replica1 := NewStreamHandler(logger)
+	replica1.SetBroker(broker)
+	replica1.Start()
+	defer replica1.Stop()
+
+	replica2 := NewStreamHandler(logger)
+	replica2.SetBroker(broker)
+	replica2.Start()
+	defer replica2.Stop()
+
+	w1 := newMockResponseWriter()
+	w2 := newMockResponseWriter()
+	go replica1.HandleSSE(w1, httptest.NewRequest("GET", "/mcp/stream", nil))
+	go replica2.HandleSSE(w2, httptest.NewRequest("GET", "/mcp/stream", nil))
+	time.Sleep(50 * time.Millisecond)
+
+	replica1.BroadcastMessage("notification", map[string]interface{}{"message": "fan out"})
+	time.Sleep(50 * time.Millisecond)
+
+	if n := strings.Count(w1.GetBody(), "fan out"); n != 1 {
+		t.Errorf("Expected the publishing replica's client to receive the event once, got %d", n)
+	}
+	if n := strings.Count(w2.GetBody(), "fan out"); n != 1 {
+		t.Errorf("Expected the other replica's client to receive the event once, got %d", n)
+	}
+
+	// A client of replica2 is addressed through replica1
+	var clientID string
+	for _, client := range replica2.Clients() {
+		clientID = client.ID
+	}
+	replica1.SendToClient(clientID, "", "response", map[string]interface{}{"message": "targeted"})
+	time.Sleep(50 * time.Millisecond)
+
+	if !strings.Contains(w2.GetBody(), "targeted") {
+		t.Error("Expected the message to reach the client connected to the other replica")
+	}
+	if strings.Contains(w1.GetBody(), "targeted") {
+		t.Error("Expected the message to reach only the addressed client")
+	}
+}
+
+func TestSendToClient_Owner(t *testing.T) {
+	sh := NewStreamHandler(createTestLogger())
+	w := newMockResponseWriter()
+	client := &ClientConnection{Writer: w, Flusher: w, Done: make(chan struct{}), owner: "alice"}
+	sh.addClient(client)
+
+	sh.SendToClient(client.ID, "mallory", "notification", map[string]interface{}{"message": "forged"})
+	sh.SendToClient(client.ID, "alice", "notification", map[string]interface{}{"message": "owned"})
+
+	if body := w.GetBody(); strings.Contains(body, "forged") || !strings.Contains(body, "owned") {
+		t.Errorf("Expected only the owner's message to be delivered, got %q", body)
+	}
+	if sh.SetClientFilter(client.ID, "mallory", "", []string{"notification"}) {
+		t.Error("Expected another owner not to change the client's filter")
+	}
+
+	// A stream opened in a session only takes filters from that session
+	client.session = "session-1"
+	if sh.SetClientFilter(client.ID, "alice", "session-2", []string{"notification"}) {
+		t.Error("Expected another session not to change the client's filter")
+	}
+	if !sh.SetClientFilter(client.ID, "alice", "session-1", []string{"notification"}) {
+		t.Error("Expected the client's session to change its filter")
+	}
+}
+
+func TestHandleSSE_ClientID(t *testing.T) {
+	logger := createTestLogger()
+	sh := NewStreamHandler(logger)
+
+	// The second draw repeats the first ID and must be discarded
+	ids := []string{"id-1", "id-1", "id-2"}
+	var mu sync.Mutex
+	sh.generateID = func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		id := ids[0]
+		ids = ids[1:]
+		return id
+	}
+
+	w1 := newMockResponseWriter()
+	go sh.HandleSSE(w1, httptest.NewRequest("GET", "/mcp/stream", nil))
+	w1.waitForBody(t, "event: connected\n")
+	w2 := newMockResponseWriter()
+	go sh.HandleSSE(w2, httptest.NewRequest("GET", "/mcp/stream", nil))
+	w2.waitForBody(t, "event: connected\n")
+
+	if sh.GetConnectedClients() != 2 {
+		t.Fatalf("Expected 2 connected clients, got %d", sh.GetConnectedClients())
+	}
+	if sh.ClientIDCollisions() != 1 {
+		t.Errorf("Expected 1 collision, got %d", sh.ClientIDCollisions())
+	}
+	for i, tt := range []struct {
+		w  *mockResponseWriter
+		id string
+	}{{w1, "id-1"}, {w2, "id-2"}} {
+		w := tt.w
+		w.mu.Lock()
+		header := w.headers.Get(ClientIDHeader)
+		w.mu.Unlock()
+		if header != tt.id {
+			t.Errorf("Client %d: expected %s header %q, got %q", i+1, ClientIDHeader, tt.id, header)
+		}
+		if !strings.Contains(w.GetBody(), `"clientId":"`+tt.id+`"`) {
+			t.Errorf("Client %d: expected the connected event to carry ID %q", i+1, tt.id)
+		}
+	}
+}
+
+func TestNewClientID(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		id := newClientID()
+		if len(id) != 36 || id[14] != '4' || !strings.ContainsAny(id[19:20], "89ab") {
+			t.Fatalf("Expected a version 4 UUID, got %q", id)
+		}
+		if seen[id] {
+			t.Fatalf("Duplicate client ID %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+// blockingBroker is a broker whose publishes wait until released
+type blockingBroker struct {
+	release   chan struct{}
+	published chan *pubsub.Message
+}
+
+func (b *blockingBroker) Publish(ctx context.Context, msg *pubsub.Message) error {
+	<-b.release
+	b.published <- msg
+	return nil
+}
+
+func (b *blockingBroker) Subscribe(ctx context.Context, handler func(*pubsub.Message)) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (b *blockingBroker) Close() error { return nil }
+
+func TestBroadcastMessage_PublishesInBackground(t *testing.T) {
+	broker := &blockingBroker{release: make(chan struct{}), published: make(chan *pubsub.Message, 2)}
+	sh := NewStreamHandler(createTestLogger())
+	sh.SetBroker(broker)
+	sh.Start()
+
+	done := make(chan struct{})
+	go func() {
+		sh.BroadcastMessage("notification", map[string]interface{}{"message": "first"})
+		sh.BroadcastMessage("notification", map[string]interface{}{"message": "second"})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected broadcasts not to wait for the broker")
+	}
+
+	close(broker.release)
+	for _, want := range []string{"first", "second"} {
+		select {
+		case msg := <-broker.published:
+			if !strings.Contains(string(msg.Data), want) {
+				t.Errorf("Expected %s to be published in order, got %s", want, msg.Data)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected %s to be published", want)
+		}
+	}
+	sh.Stop()
+}
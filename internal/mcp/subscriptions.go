@@ -0,0 +1,369 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/auth"
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+// subscriptionPollTimeout bounds the GitHub request polling a resource
+const subscriptionPollTimeout = 30 * time.Second
+
+// pollEndpoints maps resource URI templates and resources to the GitHub
+// endpoints polled for changes, with the same variables. Computed resources
+// such as analytics cannot be subscribed to.
+var pollEndpoints = map[string]string{
+	"github://user/{username}":                      "/users/{username}",
+	"github://user/{username}/orgs":                 "/users/{username}/orgs",
+	"github://repos/{owner}":                        "/users/{owner}/repos",
+	"github://repos/{owner}/{repo}":                 "/repos/{owner}/{repo}",
+	"github://repos/{owner}/{repo}/contents/{path}": "/repos/{owner}/{repo}/contents/{path}",
+	"github://repos/{owner}/{repo}/issues/{number}": "/repos/{owner}/{repo}/issues/{number}",
+	"github://repos/{owner}/{repo}/pulls/{number}":  "/repos/{owner}/{repo}/pulls/{number}",
+	"github://org/{org}":                            "/orgs/{org}",
+	"github://org/{org}/members":                    "/orgs/{org}/members",
+	"github://organizations":                        "/organizations",
+}
+
+// streamClientContextKey is the context key for the SSE client a message
+// was sent by
+type streamClientContextKey struct{}
+
+// WithStreamClient returns a context whose messages were sent by the SSE
+// client with the given ID, which receives their notifications
+func WithStreamClient(ctx context.Context, clientID string) context.Context {
+	if clientID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, streamClientContextKey{}, clientID)
+}
+
+// streamClient returns the ID of the SSE client a message was sent by
+func streamClient(ctx context.Context) string {
+	clientID, _ := ctx.Value(streamClientContextKey{}).(string)
+	return clientID
+}
+
+// streamOwnerContextKey is the context key for who makes a request to the
+// stream endpoints
+type streamOwnerContextKey struct{}
+
+// WithStreamOwner returns a context whose request was made by owner, such
+// as a hash of the authenticated identity. SSE streams belong to the owner
+// who opened them, and messages sent by another owner are not delivered to
+// them.
+func WithStreamOwner(ctx context.Context, owner string) context.Context {
+	return context.WithValue(ctx, streamOwnerContextKey{}, owner)
+}
+
+// streamOwner returns who made the request of ctx, empty when unknown
+func streamOwner(ctx context.Context) string {
+	owner, _ := ctx.Value(streamOwnerContextKey{}).(string)
+	return owner
+}
+
+// resourceSubscriptions tracks the subscribers of each resource and the
+// state last polled
+type resourceSubscriptions struct {
+	mu      sync.Mutex
+	watched map[watchKey]*watchedResource
+}
+
+// watchKey identifies a subscribed resource polled with one GitHub token;
+// subscribers passing their own token each have theirs polled with it
+type watchKey struct {
+	uri string
+	// credential is a hash of the subscribers' token, empty for the
+	// server's
+	credential string
+}
+
+// watchedResource is a subscribed resource
+type watchedResource struct {
+	endpoint string
+	// ctx carries the GitHub token and identity of the subscribers, which
+	// polls are made with
+	ctx context.Context
+	// subscribers holds how to notify each subscriber by its ID
+	subscribers map[string]func(*JSONRPCMessage)
+	// fingerprint is the hash of the response last polled, once polled
+	fingerprint [sha256.Size]byte
+	polled      bool
+}
+
+// resourcePoller polls subscribed resources in the background
+type resourcePoller struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+// SetSubscriptionInterval enables resource subscriptions, polling subscribed
+// resources for changes every interval; zero disables them. It must be
+// called before serving.
+func (h *Handler) SetSubscriptionInterval(interval time.Duration) {
+	h.subscriptionInterval = interval
+}
+
+// StartResourcePoller polls subscribed resources every subscription
+// interval and notifies their subscribers of changes. Polls are conditional
+// requests, so unchanged resources cost no rate limit when the response
+// cache is enabled.
+func (h *Handler) StartResourcePoller() {
+	if h.subscriptionInterval <= 0 || h.poller != nil {
+		return
+	}
+
+	h.poller = &resourcePoller{stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(h.poller.done)
+		ticker := time.NewTicker(h.subscriptionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				h.pollSubscriptions()
+			case <-h.poller.stop:
+				return
+			}
+		}
+	}()
+	h.logger.Info("Resource subscriptions enabled", "interval", h.subscriptionInterval)
+}
+
+// StopResourcePoller stops polling subscribed resources
+func (h *Handler) StopResourcePoller() {
+	if h.poller == nil {
+		return
+	}
+
+	close(h.poller.stop)
+	<-h.poller.done
+}
+
+// subscriber returns the ID of the client a message belongs to and how to
+// send it notifications: its session when the transport delivers them, such
+// as stdio, or otherwise the SSE stream it names, which only receives them
+// if it was opened by the same owner
+func (h *Handler) subscriber(ctx context.Context) (string, func(*JSONRPCMessage), bool) {
+	session := h.session(ctx)
+	if notify := session.notifier(); notify != nil {
+		return fmt.Sprintf("session:%p", session), notify, true
+	}
+	if clientID := streamClient(ctx); clientID != "" && h.streamer != nil {
+		owner := streamOwner(ctx)
+		if !h.streamer.clientOwnedBy(clientID, owner) {
+			return "", nil, false
+		}
+		return streamSubscriberID(clientID) + owner, func(msg *JSONRPCMessage) {
+			h.streamer.StreamMessageToClient(clientID, owner, msg)
+		}, true
+	}
+	return "", nil, false
+}
+
+// streamSubscriberID returns the start of the subscriber IDs of an SSE
+// client, which end with the owner subscribing
+func streamSubscriberID(clientID string) string {
+	return "client:" + clientID + ":"
+}
+
+// pollContext returns the context a resource subscribed with ctx is polled
+// with, keeping the subscriber's GitHub token and identity but not its
+// deadline
+func pollContext(ctx context.Context) (context.Context, string) {
+	poll := context.Background()
+	if identity, ok := auth.IdentityFromContext(ctx); ok {
+		poll = auth.WithIdentity(poll, identity)
+	}
+	token, ok := client.TokenFromContext(ctx)
+	if !ok {
+		return poll, ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	return client.WithToken(poll, token), hex.EncodeToString(sum[:])
+}
+
+// pollEndpoint returns the GitHub endpoint polled for changes of uri
+func (h *Handler) pollEndpoint(uri string) (string, bool) {
+	if endpoint, ok := pollEndpoints[uri]; ok && h.validResourceURI(uri) {
+		return endpoint, true
+	}
+	template, values, ok := matchResourceTemplate(uri)
+	if !ok {
+		return "", false
+	}
+	endpoint, ok := pollEndpoints[template.URITemplate]
+	if !ok {
+		return "", false
+	}
+	for name, value := range values {
+		segments := strings.Split(value, "/")
+		for i := range segments {
+			segments[i] = url.PathEscape(segments[i])
+		}
+		endpoint = strings.Replace(endpoint, "{"+name+"}", strings.Join(segments, "/"), 1)
+	}
+	return endpoint, true
+}
+
+// handleSubscribeResource handles the resources/subscribe request
+func (h *Handler) handleSubscribeResource(ctx context.Context, msg *JSONRPCMessage) *JSONRPCMessage {
+	if !h.session(ctx).Initialized() {
+		return NewErrorResponse(msg.ID, ErrorCodeInternalError, "Server not initialized", nil)
+	}
+	if h.subscriptionInterval <= 0 {
+		return NewErrorResponse(msg.ID, ErrorCodeMethodNotFound, "Resource subscriptions are disabled", nil)
+	}
+
+	var req SubscribeResourceRequest
+	if err := msg.GetParams(&req); err != nil || req.URI == "" {
+		return NewErrorResponse(msg.ID, ErrorCodeInvalidParams, "Invalid params", nil)
+	}
+	endpoint, ok := h.pollEndpoint(req.URI)
+	if !ok {
+		return NewErrorResponse(msg.ID, ErrorCodeInvalidParams, fmt.Sprintf("Resource cannot be subscribed to: %s", req.URI), nil)
+	}
+	id, notify, ok := h.subscriber(ctx)
+	if !ok {
+		return NewErrorResponse(msg.ID, ErrorCodeInvalidRequest, "Notifications cannot be delivered to this client; connect to /mcp/stream and send its client ID with requests", nil)
+	}
+
+	pollCtx, credential := pollContext(ctx)
+	key := watchKey{uri: req.URI, credential: credential}
+	h.subscriptions.mu.Lock()
+	if h.subscriptions.watched == nil {
+		h.subscriptions.watched = make(map[watchKey]*watchedResource)
+	}
+	watched, exists := h.subscriptions.watched[key]
+	if !exists {
+		watched = &watchedResource{endpoint: endpoint, ctx: pollCtx, subscribers: make(map[string]func(*JSONRPCMessage))}
+		h.subscriptions.watched[key] = watched
+	}
+	watched.subscribers[id] = notify
+	h.subscriptions.mu.Unlock()
+
+	h.logger.WithContext(ctx).Info("Subscribed to resource", "uri", req.URI, "subscriber", id)
+	if !exists {
+		// Record the current state, so the first change is noticed
+		go h.pollResource(key)
+	}
+	return NewResponse(msg.ID, map[string]interface{}{})
+}
+
+// handleUnsubscribeResource handles the resources/unsubscribe request
+func (h *Handler) handleUnsubscribeResource(ctx context.Context, msg *JSONRPCMessage) *JSONRPCMessage {
+	if !h.session(ctx).Initialized() {
+		return NewErrorResponse(msg.ID, ErrorCodeInternalError, "Server not initialized", nil)
+	}
+
+	var req SubscribeResourceRequest
+	if err := msg.GetParams(&req); err != nil || req.URI == "" {
+		return NewErrorResponse(msg.ID, ErrorCodeInvalidParams, "Invalid params", nil)
+	}
+	if id, _, ok := h.subscriber(ctx); ok {
+		h.unsubscribe(func(subscriber string) bool { return subscriber == id }, req.URI)
+	}
+	return NewResponse(msg.ID, map[string]interface{}{})
+}
+
+// unsubscribe removes the subscribers matching from uri, or from every
+// resource when uri is empty, and stops watching resources left without
+// subscribers
+func (h *Handler) unsubscribe(matching func(id string) bool, uri string) {
+	h.subscriptions.mu.Lock()
+	defer h.subscriptions.mu.Unlock()
+	for key, watched := range h.subscriptions.watched {
+		if uri != "" && key.uri != uri {
+			continue
+		}
+		for id := range watched.subscribers {
+			if matching(id) {
+				delete(watched.subscribers, id)
+			}
+		}
+		if len(watched.subscribers) == 0 {
+			delete(h.subscriptions.watched, key)
+		}
+	}
+}
+
+// unsubscribeSession removes the subscriptions of a session's client
+func (h *Handler) unsubscribeSession(session *Session) {
+	id := fmt.Sprintf("session:%p", session)
+	h.unsubscribe(func(subscriber string) bool { return subscriber == id }, "")
+}
+
+// StreamClosed removes the subscriptions of an SSE client whose stream
+// closed
+func (h *Handler) StreamClosed(clientID string) {
+	prefix := streamSubscriberID(clientID)
+	h.unsubscribe(func(subscriber string) bool { return strings.HasPrefix(subscriber, prefix) }, "")
+}
+
+// pollSubscriptions polls every subscribed resource
+func (h *Handler) pollSubscriptions() {
+	h.subscriptions.mu.Lock()
+	keys := make([]watchKey, 0, len(h.subscriptions.watched))
+	for key := range h.subscriptions.watched {
+		keys = append(keys, key)
+	}
+	h.subscriptions.mu.Unlock()
+
+	for _, key := range keys {
+		if h.poller != nil {
+			select {
+			case <-h.poller.stop:
+				return
+			default:
+			}
+		}
+		h.pollResource(key)
+	}
+}
+
+// pollResource fetches a subscribed resource with its subscribers' token and
+// notifies them when it changed since the last poll
+func (h *Handler) pollResource(key watchKey) {
+	uri := key.uri
+	h.subscriptions.mu.Lock()
+	watched, ok := h.subscriptions.watched[key]
+	h.subscriptions.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(watched.ctx, subscriptionPollTimeout)
+	resp, err := h.githubClient.Get(ctx, watched.endpoint, nil)
+	cancel()
+	if err != nil {
+		h.logger.Debug("Resource poll failed", "uri", uri, "error", err)
+		return
+	}
+	fingerprint := sha256.Sum256(resp.Body)
+
+	h.subscriptions.mu.Lock()
+	changed := watched.polled && watched.fingerprint != fingerprint
+	watched.fingerprint, watched.polled = fingerprint, true
+	var notify []func(*JSONRPCMessage)
+	if changed && h.subscriptions.watched[key] == watched {
+		for _, send := range watched.subscribers {
+			notify = append(notify, send)
+		}
+	}
+	h.subscriptions.mu.Unlock()
+
+	if len(notify) > 0 {
+		h.logger.Debug("Subscribed resource changed", "uri", uri, "subscribers", len(notify))
+	}
+	for _, send := range notify {
+		send(NewNotification(MethodResourceUpdated, ResourceUpdatedParams{URI: uri}))
+	}
+}
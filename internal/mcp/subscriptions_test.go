@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestResourceSubscriptions(t *testing.T) {
+	var mu sync.Mutex
+	body := `{"number": 12, "state": "open"}`
+	polled := make(chan string, 10)
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			polled <- req.URL.Path
+			mu.Lock()
+			defer mu.Unlock()
+			return mocks.MockJSONResponse(200, body), nil
+		},
+	})
+
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetSubscriptionInterval(time.Minute)
+	var notified []*JSONRPCMessage
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	session.setNotifier(func(msg *JSONRPCMessage) { notified = append(notified, msg) })
+	ctx := WithSession(context.Background(), session)
+
+	uri := "github://repos/octo-org/octo-repo/issues/12"
+	resp := h.handleSubscribeResource(ctx, NewRequest(1, MethodSubscribeResource, map[string]interface{}{"uri": uri}))
+	if resp.Error != nil {
+		t.Fatalf("Unexpected error: %+v", resp.Error)
+	}
+	select {
+	case path := <-polled:
+		if path != "/repos/octo-org/octo-repo/issues/12" {
+			t.Errorf("Expected the issue to be polled, got %s", path)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the resource to be polled on subscription")
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	h.pollSubscriptions()
+	if len(notified) != 0 {
+		t.Fatalf("Expected no notification while unchanged, got %d", len(notified))
+	}
+
+	mu.Lock()
+	body = `{"number": 12, "state": "closed"}`
+	mu.Unlock()
+	h.pollSubscriptions()
+	if len(notified) != 1 || notified[0].Method != MethodResourceUpdated || notified[0].Params.(ResourceUpdatedParams).URI != uri {
+		t.Fatalf("Expected one update notification, got %+v", notified)
+	}
+
+	h.handleUnsubscribeResource(ctx, NewRequest(2, MethodUnsubscribeResource, map[string]interface{}{"uri": uri}))
+	mu.Lock()
+	body = `{"number": 12, "state": "open"}`
+	mu.Unlock()
+	h.pollSubscriptions()
+	if len(notified) != 1 {
+		t.Errorf("Expected no notification after unsubscribing, got %d", len(notified))
+	}
+}
+
+func TestHandleSubscribeResource_Rejected(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.httpSession.setInitialized()
+	params := map[string]interface{}{"uri": "github://user/octocat"}
+
+	if resp := h.handleSubscribeResource(context.Background(), NewRequest(1, MethodSubscribeResource, params)); resp.Error == nil {
+		t.Error("Expected subscriptions to be disabled by default")
+	}
+	if h.serverCapabilities().Resources.Subscribe {
+		t.Error("Expected subscribe not to be advertised while disabled")
+	}
+
+	h.SetSubscriptionInterval(time.Minute)
+	if resp := h.handleSubscribeResource(context.Background(), NewRequest(2, MethodSubscribeResource, params)); resp.Error == nil || resp.Error.Code != ErrorCodeInvalidRequest {
+		t.Errorf("Expected an HTTP client without an SSE stream to be rejected, got %+v", resp.Error)
+	}
+
+	analytics := map[string]interface{}{"uri": "github://org/octo-org/analytics"}
+	if resp := h.handleSubscribeResource(context.Background(), NewRequest(3, MethodSubscribeResource, analytics)); resp.Error == nil || resp.Error.Code != ErrorCodeInvalidParams {
+		t.Errorf("Expected a computed resource to be rejected, got %+v", resp.Error)
+	}
+}
+
+func TestResourceSubscriptions_Stream(t *testing.T) {
+	polled := make(chan string, 10)
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			polled <- req.Header.Get("Authorization")
+			return mocks.MockJSONResponse(200, `{"login": "octocat"}`), nil
+		},
+	})
+
+	sh := NewStreamHandler(createTestLogger())
+	h := NewHandler(githubClient, createTestLogger())
+	h.SetSubscriptionInterval(time.Minute)
+	h.SetStreamer(sh.GetStreamer())
+	sh.OnClientClosed(h.StreamClosed)
+	h.httpSession.setInitialized()
+
+	w := newMockResponseWriter()
+	stream := &ClientConnection{Writer: w, Flusher: w, Done: make(chan struct{}), owner: "alice"}
+	sh.addClient(stream)
+
+	subscribe := func(owner, token string) *JSONRPCMessage {
+		ctx := WithStreamOwner(WithStreamClient(context.Background(), stream.ID), owner)
+		if token != "" {
+			ctx = client.WithToken(ctx, token)
+		}
+		return h.handleSubscribeResource(ctx, NewRequest(1, MethodSubscribeResource, map[string]interface{}{"uri": "github://user/octocat"}))
+	}
+
+	if resp := subscribe("mallory", ""); resp.Error == nil || resp.Error.Code != ErrorCodeInvalidRequest {
+		t.Fatalf("Expected another owner not to attach to the stream, got %+v", resp.Error)
+	}
+
+	// Each subscriber's resources are polled with its own token
+	for _, token := range []string{"alice-token", "other-token"} {
+		if resp := subscribe("alice", token); resp.Error != nil {
+			t.Fatalf("Unexpected error: %+v", resp.Error)
+		}
+		select {
+		case authorization := <-polled:
+			if !strings.HasSuffix(authorization, token) {
+				t.Errorf("Expected the poll to use %s, got %q", token, authorization)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the resource to be polled on subscription")
+		}
+	}
+
+	sh.closeClient(stream.ID)
+	h.subscriptions.mu.Lock()
+	defer h.subscriptions.mu.Unlock()
+	if len(h.subscriptions.watched) != 0 {
+		t.Errorf("Expected the subscriptions to end with the stream, got %d", len(h.subscriptions.watched))
+	}
+}
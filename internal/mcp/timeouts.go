@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// toolTimeouts bound how long tool calls may take
+type toolTimeouts struct {
+	// fallback applies to tools without an override; zero means no limit
+	fallback time.Duration
+	// byName holds the overrides by tool or toolset name
+	byName map[string]time.Duration
+}
+
+// SetToolTimeouts bounds how long a tool call, including its GitHub
+// requests and their retries, may take. fallback applies to every tool;
+// overrides set the timeout by tool name, or by toolset name for all the
+// tools of a toolset, with tool names taking precedence. Zero means no
+// limit. It fails when an override names no tool or toolset.
+func (h *Handler) SetToolTimeouts(fallback time.Duration, overrides map[string]time.Duration) error {
+	for name := range overrides {
+		if h.findTool(name) == nil && toolsetNamed(name) == nil {
+			return fmt.Errorf("unknown tool or toolset %q", name)
+		}
+	}
+	h.timeouts = toolTimeouts{fallback: fallback, byName: overrides}
+	return nil
+}
+
+// toolTimeout returns how long a call to the named tool may take
+func (h *Handler) toolTimeout(name string) time.Duration {
+	if timeout, ok := h.timeouts.byName[name]; ok {
+		return timeout
+	}
+	if set := toolsetOf(name); set != nil {
+		if timeout, ok := h.timeouts.byName[set.name]; ok {
+			return timeout
+		}
+	}
+	return h.timeouts.fallback
+}
+
+// runWithTimeout runs a tool call within the timeout of its tool. When the
+// timeout expires the call ends with an error result saying so, without
+// waiting for a read-only tool that does not stop with its context. Calls of
+// tools making changes are always waited for, so they keep their tool slot
+// and are never left running unseen.
+func (h *Handler) runWithTimeout(ctx context.Context, run ToolHandler, call ToolCall) (*CallToolResult, error) {
+	timeout := h.toolTimeout(call.Name)
+	if timeout <= 0 {
+		return run(ctx, call)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		result *CallToolResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := run(ctx, call)
+		done <- outcome{result, err}
+	}()
+
+	var o outcome
+	select {
+	case o = <-done:
+	case <-ctx.Done():
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) || !readOnlyTool(call.Name) {
+			// The call was cancelled, or may be making changes; the tool
+			// returns once it sees its context end
+			o = <-done
+		}
+	}
+	failed := o.err != nil || o.result == nil || o.result.IsError
+	if failed && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		h.logger.WithContext(ctx).Warn("Tool call timed out", "tool", call.Name, "timeout", timeout)
+		return h.timeoutResult(call.Name, timeout), nil
+	}
+	return o.result, o.err
+}
+
+// timeoutResult is the error result of a call to the named tool that took
+// longer than timeout
+func (h *Handler) timeoutResult(name string, timeout time.Duration) *CallToolResult {
+	text := h.messages.Sprintf("%s timed out after %s; try again, or narrow the request, such as with fewer items per page", name, timeout)
+	if !readOnlyTool(name) {
+		text = h.messages.Sprintf("%s timed out after %s; its changes may have been made in part, so check them before calling it again", name, timeout)
+	}
+	return &CallToolResult{Content: []Content{{Type: "text", Text: text}}, IsError: true}
+}
+
+// toolsetNamed returns the toolset with the given name, or nil
+func toolsetNamed(name string) *toolset {
+	for i := range toolsets {
+		if toolsets[i].name == name {
+			return &toolsets[i]
+		}
+	}
+	return nil
+}
+
+// toolsetOf returns the toolset the named tool belongs to, or nil
+func toolsetOf(name string) *toolset {
+	for i := range toolsets {
+		for _, tool := range toolsets[i].tools {
+			if tool == name {
+				return &toolsets[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestSetToolTimeouts(t *testing.T) {
+	h := NewHandler(client.NewGitHubClient("test-token", createTestLogger()), createTestLogger())
+	if err := h.SetToolTimeouts(time.Minute, map[string]time.Duration{"users": 10 * time.Second, "get_user": time.Second}); err != nil {
+		t.Fatalf("SetToolTimeouts failed: %v", err)
+	}
+
+	for name, want := range map[string]time.Duration{
+		"get_user":   time.Second,
+		"list_users": 10 * time.Second,
+		"list_teams": time.Minute,
+	} {
+		if got := h.toolTimeout(name); got != want {
+			t.Errorf("Expected %s to time out after %v, got %v", name, want, got)
+		}
+	}
+
+	if err := h.SetToolTimeouts(0, map[string]time.Duration{"userz": time.Second}); err == nil {
+		t.Error("Expected an unknown tool or toolset to be rejected")
+	}
+}
+
+func TestHandleCallTool_Timeout(t *testing.T) {
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetRetryPolicy(client.RetryPolicy{MaxAttempts: 1})
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		},
+	})
+
+	h := NewHandler(githubClient, createTestLogger())
+	if err := h.SetToolTimeouts(time.Hour, map[string]time.Duration{"get_user": 50 * time.Millisecond}); err != nil {
+		t.Fatalf("SetToolTimeouts failed: %v", err)
+	}
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	start := time.Now()
+	resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+		"name":      "get_user",
+		"arguments": map[string]interface{}{"username": "octocat"},
+	}})
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Expected the call to time out, took %v", elapsed)
+	}
+	if resp.Error == nil {
+		if result, ok := resp.Result.(*CallToolResult); !ok || !result.IsError {
+			t.Errorf("Expected an error result, got %+v", resp.Result)
+		} else if !strings.Contains(result.Content[0].Text, "timed out after 50ms") {
+			t.Errorf("Expected a timeout error result, got %q", result.Content[0].Text)
+		}
+	}
+}
+
+func TestHandleCallTool_TimeoutIgnoredContext(t *testing.T) {
+	h := NewHandler(client.NewGitHubClient("test-token", createTestLogger()), createTestLogger())
+	if err := h.SetToolTimeouts(50*time.Millisecond, nil); err != nil {
+		t.Fatalf("SetToolTimeouts failed: %v", err)
+	}
+	release := make(chan struct{})
+	defer close(release)
+	h.UseToolMiddleware(func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, call ToolCall) (*CallToolResult, error) {
+			<-release
+			return next(ctx, call)
+		}
+	})
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	done := make(chan *JSONRPCMessage, 1)
+	go func() {
+		done <- h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+			"name":      "get_user",
+			"arguments": map[string]interface{}{"username": "octocat"},
+		}})
+	}()
+
+	select {
+	case resp := <-done:
+		result, ok := resp.Result.(*CallToolResult)
+		if !ok || !result.IsError || !strings.Contains(result.Content[0].Text, "timed out") {
+			t.Errorf("Expected a timeout error result, got %+v", resp)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the call to time out while the tool ignores its context")
+	}
+}
+
+func TestHandleCallTool_TimeoutWaitsForChanges(t *testing.T) {
+	h := NewHandler(client.NewGitHubClient("test-token", createTestLogger()), createTestLogger())
+	if err := h.SetToolTimeouts(50*time.Millisecond, nil); err != nil {
+		t.Fatalf("SetToolTimeouts failed: %v", err)
+	}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	h.UseToolMiddleware(func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, call ToolCall) (*CallToolResult, error) {
+			close(started)
+			<-ctx.Done()
+			<-release
+			return &CallToolResult{Content: []Content{{Type: "text", Text: "failed"}}, IsError: true}, nil
+		}
+	})
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	done := make(chan *JSONRPCMessage, 1)
+	go func() {
+		done <- h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodCallTool, Params: map[string]interface{}{
+			"name":      "create_repository",
+			"arguments": map[string]interface{}{"name": "hello-world"},
+		}})
+	}()
+	select {
+	case <-started:
+	case resp := <-done:
+		t.Fatalf("Expected the tool to run, got %+v", resp)
+	}
+
+	select {
+	case resp := <-done:
+		t.Fatalf("Expected the call to be waited for, got %+v", resp)
+	case <-time.After(200 * time.Millisecond):
+	}
+	if running := h.toolCalls.running.Load(); running != 1 {
+		t.Errorf("Expected the call to keep its tool slot, got %d running", running)
+	}
+
+	close(release)
+	resp := <-done
+	result, ok := resp.Result.(*CallToolResult)
+	if !ok || !result.IsError || !strings.Contains(result.Content[0].Text, "check them before calling it again") {
+		t.Errorf("Expected a timeout error result without a retry hint, got %+v", resp)
+	}
+}
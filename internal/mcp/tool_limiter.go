@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// toolRetryAfterSeconds is the retry hint sent when a session's tool queue is full
+const toolRetryAfterSeconds = 1
+
+// errToolQueueFull is returned when a session already has the maximum number
+// of tool calls waiting for a slot
+var errToolQueueFull = stderrors.New("tool call queue is full")
+
+// toolLimiter bounds the tool calls one session runs at once so that a
+// session issuing many parallel calls cannot starve the others
+type toolLimiter struct {
+	slots     chan struct{}
+	maxQueued int
+	queued    atomic.Int64
+}
+
+// newToolLimiter creates a limiter running at most maxConcurrent calls, with
+// at most maxQueued calls waiting; zero maxQueued lets any number wait
+func newToolLimiter(maxConcurrent, maxQueued int) *toolLimiter {
+	return &toolLimiter{
+		slots:     make(chan struct{}, maxConcurrent),
+		maxQueued: maxQueued,
+	}
+}
+
+// ToolCallStats reports tool calls across all sessions
+type ToolCallStats struct {
+	// Running is the number of tool calls currently executing
+	Running int64
+	// Queued is the number of tool calls waiting for a session slot
+	Queued int64
+	// Rejected is the number of tool calls refused because a session queue was full
+	Rejected int64
+	// Shed is the number of tool calls refused because GitHub was degraded
+	Shed int64
+}
+
+// toolCallCounters tracks the handler-wide tool call stats
+type toolCallCounters struct {
+	running  atomic.Int64
+	queued   atomic.Int64
+	rejected atomic.Int64
+	shed     atomic.Int64
+}
+
+// SetSessionToolLimits limits each session to maxConcurrent tool calls at
+// once; further calls wait in a per-session queue of at most maxQueued calls
+// and are rejected when it is full. Zero maxConcurrent disables the limit and
+// zero maxQueued lets any number of calls wait.
+func (h *Handler) SetSessionToolLimits(maxConcurrent, maxQueued int) {
+	h.sessionMaxTools = maxConcurrent
+	h.sessionMaxQueuedTools = maxQueued
+}
+
+// ToolCallStats returns the current tool call counts
+func (h *Handler) ToolCallStats() ToolCallStats {
+	return ToolCallStats{
+		Running:  h.toolCalls.running.Load(),
+		Queued:   h.toolCalls.queued.Load(),
+		Rejected: h.toolCalls.rejected.Load(),
+		Shed:     h.toolCalls.shed.Load(),
+	}
+}
+
+// acquireToolSlot waits for the session of ctx to have a free tool slot. The
+// returned function releases the slot. It fails with errToolQueueFull when the
+// session's queue is full, or with the context error if ctx ends while waiting.
+func (h *Handler) acquireToolSlot(ctx context.Context) (func(), error) {
+	if h.sessionMaxTools <= 0 {
+		h.toolCalls.running.Add(1)
+		return func() { h.toolCalls.running.Add(-1) }, nil
+	}
+
+	limiter := h.session(ctx).toolLimiter(h.sessionMaxTools, h.sessionMaxQueuedTools)
+	release := func() {
+		<-limiter.slots
+		h.toolCalls.running.Add(-1)
+	}
+
+	select {
+	case limiter.slots <- struct{}{}:
+		h.toolCalls.running.Add(1)
+		return release, nil
+	default:
+	}
+
+	if queued := limiter.queued.Add(1); limiter.maxQueued > 0 && queued > int64(limiter.maxQueued) {
+		limiter.queued.Add(-1)
+		h.toolCalls.rejected.Add(1)
+		return nil, errToolQueueFull
+	}
+	h.toolCalls.queued.Add(1)
+	defer func() {
+		limiter.queued.Add(-1)
+		h.toolCalls.queued.Add(-1)
+	}()
+
+	select {
+	case limiter.slots <- struct{}{}:
+		h.toolCalls.running.Add(1)
+		return release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// toolSlotError converts an acquireToolSlot error into a JSON-RPC error response
+func (h *Handler) toolSlotError(id interface{}, err error) *JSONRPCMessage {
+	if stderrors.Is(err, errToolQueueFull) {
+		return NewErrorResponse(id, ErrorCodeServerBusy, "session is busy, too many concurrent tool calls",
+			map[string]interface{}{
+				"max_concurrent_tools": h.sessionMaxTools,
+				"max_queued_tools":     h.sessionMaxQueuedTools,
+				"retry_after_seconds":  toolRetryAfterSeconds,
+			})
+	}
+	return NewErrorResponse(id, ErrorCodeInternalError, fmt.Sprintf("Tool call cancelled while queued: %v", err), nil)
+}
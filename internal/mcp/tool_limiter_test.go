@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+)
+
+func TestAcquireToolSlotPerSession(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.SetSessionToolLimits(1, 1)
+
+	busy := WithSession(context.Background(), NewSession(TransportStdio))
+	other := WithSession(context.Background(), NewSession(TransportHTTP))
+
+	release, err := h.acquireToolSlot(busy)
+	if err != nil {
+		t.Fatalf("Expected first call to get a slot, got %v", err)
+	}
+
+	// The second call of the busy session queues until the first finishes
+	queued := make(chan error, 1)
+	go func() {
+		releaseQueued, err := h.acquireToolSlot(busy)
+		if err == nil {
+			releaseQueued()
+		}
+		queued <- err
+	}()
+	waitFor(t, func() bool { return h.ToolCallStats().Queued == 1 })
+
+	// The queue is full, so a third call is rejected
+	if _, err := h.acquireToolSlot(busy); !stderrors.Is(err, errToolQueueFull) {
+		t.Fatalf("Expected errToolQueueFull, got %v", err)
+	}
+
+	// Other sessions are not affected
+	releaseOther, err := h.acquireToolSlot(other)
+	if err != nil {
+		t.Fatalf("Expected another session to get a slot, got %v", err)
+	}
+	releaseOther()
+
+	stats := h.ToolCallStats()
+	if stats.Running != 1 || stats.Queued != 1 || stats.Rejected != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	release()
+	if err := <-queued; err != nil {
+		t.Fatalf("Expected queued call to run, got %v", err)
+	}
+	if stats := h.ToolCallStats(); stats.Running != 0 || stats.Queued != 0 {
+		t.Errorf("Expected no running or queued calls, got %+v", stats)
+	}
+}
+
+func TestAcquireToolSlotCancelled(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	h.SetSessionToolLimits(1, 0)
+
+	session := NewSession(TransportStdio)
+	release, err := h.acquireToolSlot(WithSession(context.Background(), session))
+	if err != nil {
+		t.Fatalf("Expected first call to get a slot, got %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(WithSession(context.Background(), session), 20*time.Millisecond)
+	defer cancel()
+	if _, err := h.acquireToolSlot(ctx); !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected queued call to end with its context, got %v", err)
+	}
+
+	resp := h.toolSlotError(1, errToolQueueFull)
+	if resp.Error == nil || resp.Error.Code != ErrorCodeServerBusy {
+		t.Errorf("Expected server busy error, got %+v", resp.Error)
+	}
+}
+
+// waitFor polls cond until it holds or a second has passed
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
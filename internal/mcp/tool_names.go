@@ -0,0 +1,100 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// toolNames are the names tools are served under to clients, when they
+// differ from the tools' own names
+type toolNames struct {
+	prefix string
+	// aliases map tool names to the names they are served under, and
+	// aliased back
+	aliases map[string]string
+	aliased map[string]string
+}
+
+// SetToolNames serves tools under other names, so the server can run next to
+// MCP servers with tools of the same names: a tool in aliases under its
+// alias, and every other tool under its name with prefix. Calls by the
+// tools' own names keep working. Policies, timeouts and logs keep using the
+// tools' own names. It fails when an alias names no tool, a served name is
+// not made of letters, digits, _ and -, or two tools would be served under
+// the same name.
+func (h *Handler) SetToolNames(prefix string, aliases map[string]string) error {
+	names := toolNames{prefix: prefix, aliases: aliases, aliased: make(map[string]string, len(aliases))}
+	for name, alias := range aliases {
+		if h.findTool(name) == nil && toolsetOf(name) == nil {
+			return fmt.Errorf("unknown tool %q", name)
+		}
+		names.aliased[alias] = name
+	}
+
+	served := make(map[string]string)
+	for _, tool := range h.Tools() {
+		name := names.served(tool.Name)
+		if !validToolName(name) {
+			return fmt.Errorf("invalid tool name %q", name)
+		}
+		if other, ok := served[name]; ok {
+			return fmt.Errorf("tools %q and %q would both be served as %q", other, tool.Name, name)
+		}
+		served[name] = tool.Name
+	}
+
+	h.names = names
+	return nil
+}
+
+// validToolName reports whether name is a non-empty name of letters, digits,
+// _ and -
+func validToolName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// served returns the name the named tool is served under
+func (n *toolNames) served(name string) string {
+	if alias, ok := n.aliases[name]; ok {
+		return alias
+	}
+	return n.prefix + name
+}
+
+// tool returns the name of the tool served under name
+func (n *toolNames) tool(name string) string {
+	if tool, ok := n.aliased[name]; ok {
+		return tool
+	}
+	if n.prefix != "" && strings.HasPrefix(name, n.prefix) {
+		return strings.TrimPrefix(name, n.prefix)
+	}
+	return name
+}
+
+// ServedTools returns the tools of the catalog with the names they are
+// served under
+func (h *Handler) ServedTools() []Tool {
+	return h.servedTools(h.Tools())
+}
+
+// servedTools returns tools with the names they are served under
+func (h *Handler) servedTools(tools []Tool) []Tool {
+	if h.names.prefix == "" && len(h.names.aliases) == 0 {
+		return tools
+	}
+	served := make([]Tool, len(tools))
+	for i, tool := range tools {
+		tool.Name = h.names.served(tool.Name)
+		served[i] = tool
+	}
+	return served
+}
@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestSetToolNames(t *testing.T) {
+	githubClient := client.NewGitHubClient("test-token", createTestLogger())
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mocks.MockJSONResponse(http.StatusOK, `{"login": "octocat", "id": 1}`), nil
+		},
+	})
+	h := NewHandler(githubClient, createTestLogger())
+	if err := h.SetToolNames("gh_", map[string]string{"get_user": "github_user"}); err != nil {
+		t.Fatalf("SetToolNames failed: %v", err)
+	}
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	resp := h.handleListTools(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 1, Method: MethodListTools})
+	served := make(map[string]bool)
+	for _, tool := range resp.Result.(ToolsListResult).Tools {
+		served[tool.Name] = true
+	}
+	if !served["github_user"] || !served["gh_list_teams"] || served["get_user"] || served["gh_get_user"] {
+		t.Errorf("Expected prefixed and aliased tool names, got %v", served)
+	}
+	if h.findTool("get_user") == nil {
+		t.Error("Expected the catalog to keep the tools' own names")
+	}
+	manifested := make(map[string]bool)
+	for _, tool := range h.Manifest(nil).Tools {
+		manifested[tool.Name] = true
+	}
+	if !manifested["github_user"] || !manifested["gh_list_teams"] {
+		t.Errorf("Expected the manifest to list the served names, got %v", manifested)
+	}
+
+	for _, name := range []string{"github_user", "gh_get_user", "get_user"} {
+		resp := h.handleCallTool(ctx, &JSONRPCMessage{JSONRPC: "2.0", ID: 2, Method: MethodCallTool, Params: map[string]interface{}{
+			"name":      name,
+			"arguments": map[string]interface{}{"username": "octocat"},
+		}})
+		if resp.Error != nil || resp.Result.(*CallToolResult).IsError {
+			t.Errorf("Expected a call to %s to run get_user, got %+v", name, resp)
+		}
+	}
+}
+
+func TestSetToolNames_Invalid(t *testing.T) {
+	h := NewHandler(client.NewGitHubClient("test-token", createTestLogger()), createTestLogger())
+	for name, aliases := range map[string]map[string]string{
+		"unknown tool":  {"get_usr": "user"},
+		"invalid alias": {"get_user": "get user"},
+		"clash":         {"get_user": "list_teams"},
+	} {
+		if err := h.SetToolNames("", aliases); err == nil {
+			t.Errorf("%s: expected %v to be rejected", name, aliases)
+		}
+	}
+	if err := h.SetToolNames("gh.", nil); err == nil {
+		t.Error("Expected an invalid prefix to be rejected")
+	}
+	if h.names.served("get_user") != "get_user" {
+		t.Error("Expected rejected names to leave the tool names unchanged")
+	}
+}
@@ -0,0 +1,41 @@
+package mcp
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of MCP messages and tool executions
+var tracer = otel.Tracer("github.com/nicholasflintwillow/github-mcp/internal/mcp")
+
+// startMessageSpan starts the span covering the handling of an MCP message
+func (h *Handler) startMessageSpan(ctx context.Context, msg *JSONRPCMessage) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "mcp "+msg.Method,
+		trace.WithAttributes(
+			attribute.String("rpc.system", "jsonrpc"),
+			attribute.String("rpc.method", msg.Method),
+			attribute.String("mcp.transport", h.session(ctx).Transport()),
+		))
+}
+
+// startToolSpan starts the span covering a tool execution
+func startToolSpan(ctx context.Context, toolName string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "tool "+toolName,
+		trace.WithAttributes(attribute.String("mcp.tool.name", toolName)))
+}
+
+// endToolSpan records the outcome of a tool execution and ends its span
+func endToolSpan(span trace.Span, result *CallToolResult, err error) {
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case result != nil && result.IsError:
+		span.SetStatus(codes.Error, "tool returned an error result")
+	}
+	span.End()
+}
@@ -0,0 +1,135 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+const (
+	// defaultMaxResultSize is the default limit, in bytes, of the items of
+	// a list tool result
+	defaultMaxResultSize = 100 * 1024
+	// githubDefaultPerPage is the page size GitHub uses when none is given
+	githubDefaultPerPage = 30
+	// omittedItemsMax bounds how many omitted items a truncated result names
+	omittedItemsMax = 50
+)
+
+// identifierFields name list items in the summary of omitted items, in
+// order of preference
+var identifierFields = []string{"full_name", "login", "slug", "name", "id"}
+
+// truncationInfo tells the caller which list items were left out and how
+// to fetch them
+type truncationInfo struct {
+	Returned int `json:"returned"`
+	Omitted  int `json:"omitted"`
+	// OmittedItems names the first omitted items
+	OmittedItems []string `json:"omitted_items,omitempty"`
+	Message      string   `json:"message"`
+}
+
+// SetMaxResultSize limits the size, in bytes, of the items of a list tool
+// result. Longer lists are cut after the items that fit, and the result
+// says what was left out and how to continue. Zero disables the limit.
+func (h *Handler) SetMaxResultSize(size int) {
+	h.maxResultSize.Store(int64(size))
+}
+
+// truncateList cuts the items of envelope, shaped as args ask, to the result
+// size limit. Its continuation fetches the items following the last one
+// kept: the next page of the size kept for lists paginated by page, chosen
+// so the pages line up with the page asked for, or the items after the ID of
+// the last one kept.
+func (h *Handler) truncateList(args map[string]interface{}, envelope listEnvelope, pageInfo *client.PageInfo, page listPage) listEnvelope {
+	limit := int(h.maxResultSize.Load())
+	if limit <= 0 {
+		return envelope
+	}
+	// Measure the items as the result returns them
+	data, err := shapeJSON(args, envelope.Items)
+	if err != nil || len(data) <= limit {
+		return envelope
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil || len(items) < 2 {
+		return envelope
+	}
+
+	// Keep at least one item, so every call makes progress
+	kept, size := 1, len(items[0])+2
+	for kept < len(items) && size+len(items[kept])+1 <= limit {
+		size += len(items[kept]) + 1
+		kept++
+	}
+
+	var next url.Values
+	switch {
+	case page.since:
+		var last struct {
+			ID int64 `json:"id"`
+		}
+		if json.Unmarshal(items[kept-1], &last) == nil && last.ID > 0 {
+			next = url.Values{"since": {strconv.FormatInt(last.ID, 10)}}
+		}
+	case pageInfo != nil:
+		perPage := page.perPage
+		if perPage <= 0 {
+			perPage = githubDefaultPerPage
+		}
+		offset := (max(page.page, 1) - 1) * perPage
+		for offset%kept != 0 {
+			kept--
+		}
+		next = url.Values{"page": {strconv.Itoa(offset/kept + 2)}, "per_page": {strconv.Itoa(kept)}}
+	}
+
+	truncated := &truncationInfo{Returned: kept, Omitted: len(items) - kept}
+	for _, item := range items[kept:min(len(items), kept+omittedItemsMax)] {
+		truncated.OmittedItems = append(truncated.OmittedItems, itemIdentifier(item))
+	}
+	envelope.Items = items[:kept]
+	envelope.Truncated = truncated
+	if next == nil {
+		truncated.Message = h.messages.Sprintf("Truncated to %d of %d items to fit the result size limit.", kept, len(items))
+		return envelope
+	}
+
+	cursor := base64.RawURLEncoding.EncodeToString([]byte(next.Encode()))
+	envelope.Pagination.HasNext = true
+	envelope.Pagination.NextCursor = cursor
+	if !page.since {
+		// Restate the page returned at the size kept, so page, per_page and
+		// next_page all count pages of that size
+		envelope.Pagination.NextPage, _ = strconv.Atoi(next.Get("page"))
+		envelope.Pagination.Page = envelope.Pagination.NextPage - 1
+		envelope.Pagination.PerPage = kept
+		envelope.Pagination.LastPage = 0
+	}
+	truncated.Message = h.messages.Sprintf("Truncated to %d of %d items to fit the result size limit. Call the tool again with cursor %s to continue.", kept, len(items), cursor)
+	return envelope
+}
+
+// itemIdentifier names a list item by its first identifier field, or by
+// the start of its JSON when it has none
+func itemIdentifier(item json.RawMessage) string {
+	var fields map[string]interface{}
+	if json.Unmarshal(item, &fields) == nil {
+		for _, name := range identifierFields {
+			switch value := fields[name].(type) {
+			case string:
+				if value != "" {
+					return value
+				}
+			case float64:
+				return strconv.FormatFloat(value, 'f', -1, 64)
+			}
+		}
+	}
+	return fmt.Sprintf("%.40s", string(item))
+}
@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+func TestTruncateList(t *testing.T) {
+	type item struct {
+		ID    int    `json:"id"`
+		Login string `json:"login"`
+		Bio   string `json:"bio"`
+	}
+	items := make([]item, 10)
+	for i := range items {
+		items[i] = item{ID: 100 + i, Login: fmt.Sprintf("user%d", i), Bio: strings.Repeat("x", 80)}
+	}
+	itemSize := len(`{"id":100,"login":"user0","bio":""}`) + 80
+	more := &client.PageInfo{NextPage: 4, NextParams: map[string]string{"page": "4"}}
+
+	tests := []struct {
+		name         string
+		limit        int
+		page         listPage
+		pageInfo     *client.PageInfo
+		wantReturned int
+		wantCursor   map[string]string
+	}{
+		{name: "fits", limit: 10 * 1024, page: listPage{page: 3, perPage: 10}, pageInfo: more, wantReturned: 10},
+		{name: "first page", limit: 4*itemSize + 5, page: listPage{perPage: 10}, pageInfo: more, wantReturned: 4,
+			wantCursor: map[string]string{"page": "2", "per_page": "4"}},
+		{name: "pages line up", limit: 4*itemSize + 5, page: listPage{page: 3, perPage: 10}, pageInfo: more, wantReturned: 4,
+			wantCursor: map[string]string{"page": "7", "per_page": "4"}},
+		{name: "pages shrink to line up", limit: 3*itemSize + 5, page: listPage{page: 2, perPage: 10}, pageInfo: more, wantReturned: 2,
+			wantCursor: map[string]string{"page": "7", "per_page": "2"}},
+		{name: "since", limit: 3*itemSize + 5, page: listPage{perPage: 10, since: true}, pageInfo: more, wantReturned: 3,
+			wantCursor: map[string]string{"since": "102"}},
+		{name: "no pagination", limit: 3*itemSize + 5, page: listPage{perPage: 10}, wantReturned: 3},
+		{name: "one item at least", limit: 10, page: listPage{perPage: 10}, pageInfo: more, wantReturned: 1,
+			wantCursor: map[string]string{"page": "2", "per_page": "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(nil, createTestLogger())
+			h.SetMaxResultSize(tt.limit)
+			envelope := h.newListEnvelope(nil, items, tt.pageInfo, tt.page)
+
+			data, _ := json.Marshal(envelope.Items)
+			var returned []item
+			json.Unmarshal(data, &returned)
+			if len(returned) != tt.wantReturned || returned[0].ID != 100 {
+				t.Fatalf("Expected the first %d items, got %+v", tt.wantReturned, returned)
+			}
+			if tt.wantReturned == len(items) {
+				if envelope.Truncated != nil || envelope.Pagination.NextPage != 4 {
+					t.Errorf("Expected the list unchanged, got %+v", envelope)
+				}
+				return
+			}
+
+			truncated := envelope.Truncated
+			if truncated == nil || truncated.Returned != tt.wantReturned || truncated.Omitted != len(items)-tt.wantReturned ||
+				truncated.OmittedItems[0] != fmt.Sprintf("user%d", tt.wantReturned) {
+				t.Fatalf("Unexpected truncation %+v", truncated)
+			}
+			if tt.wantCursor == nil {
+				if envelope.Pagination.NextCursor != "" || strings.Contains(truncated.Message, "cursor") {
+					t.Errorf("Expected no continuation, got %+v", envelope.Pagination)
+				}
+				return
+			}
+			cursor, err := decodeCursor(envelope.Pagination.NextCursor)
+			if err != nil || fmt.Sprint(cursor) != fmt.Sprint(tt.wantCursor) || !strings.Contains(truncated.Message, envelope.Pagination.NextCursor) {
+				t.Errorf("Expected cursor %v, got %v (%v) and message %q", tt.wantCursor, cursor, err, truncated.Message)
+			}
+			if tt.page.since {
+				return
+			}
+			// The page returned holds the items kept at the page size kept
+			pagination := envelope.Pagination
+			if pagination.PerPage != tt.wantReturned || pagination.NextPage != pagination.Page+1 ||
+				(pagination.Page-1)*pagination.PerPage != (max(tt.page.page, 1)-1)*tt.page.perPage {
+				t.Errorf("Expected the page of the items returned, got %+v", pagination)
+			}
+		})
+	}
+}
+
+func TestTruncateList_Shaped(t *testing.T) {
+	type item struct {
+		Login   string `json:"login"`
+		URL     string `json:"url"`
+		NodeID  string `json:"node_id"`
+		HTMLURL string `json:"html_url"`
+	}
+	items := make([]item, 10)
+	for i := range items {
+		items[i] = item{Login: fmt.Sprintf("user%d", i), URL: strings.Repeat("u", 200), NodeID: strings.Repeat("n", 200), HTMLURL: "https://github.com/user"}
+	}
+	h := NewHandler(nil, createTestLogger())
+	h.SetMaxResultSize(1024)
+
+	if envelope := h.newListEnvelope(map[string]interface{}{}, items, nil, listPage{perPage: 10}); envelope.Truncated != nil {
+		t.Errorf("Expected the trimmed items to fit, got %+v", envelope.Truncated)
+	}
+	if envelope := h.newListEnvelope(map[string]interface{}{verboseArgument: true}, items, nil, listPage{perPage: 10}); envelope.Truncated == nil {
+		t.Error("Expected the verbose items to be truncated")
+	}
+}
@@ -0,0 +1,230 @@
+package mcp
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// argumentError describes an argument that does not match a tool's schema
+type argumentError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// argumentErrors are the schema violations of a tool call's arguments
+type argumentErrors []argumentError
+
+// Error joins the violations into one message
+func (e argumentErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, violation := range e {
+		messages = append(messages, violation.Field+": "+violation.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// except drops the errors of the required arguments named in missing,
+// returning nil when none are left
+func (e argumentErrors) except(missing map[string]interface{}) argumentErrors {
+	var kept argumentErrors
+	for _, violation := range e {
+		if _, ok := missing[violation.Field]; ok && violation.Message == "is required" {
+			continue
+		}
+		kept = append(kept, violation)
+	}
+	return kept
+}
+
+// validateArguments checks args against a tool's input schema: required
+// properties, types, enums, bounds and array items. Arguments the schema
+// does not declare are left for the tool to ignore. It returns nil when the
+// arguments are valid.
+func validateArguments(schema interface{}, args map[string]interface{}) argumentErrors {
+	var errs argumentErrors
+	validateValue("", schema, args, &errs)
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
+}
+
+// validateValue checks a value against its schema, adding violations to errs
+// under the field name path
+func validateValue(path string, schema interface{}, value interface{}, errs *argumentErrors) {
+	schemaMap, ok := schema.(map[string]interface{})
+	if !ok {
+		return
+	}
+	fail := func(format string, args ...interface{}) {
+		field := path
+		if field == "" {
+			field = "arguments"
+		}
+		*errs = append(*errs, argumentError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	types := schemaList(schemaMap["type"])
+	if len(types) > 0 && !matchesAnyType(types, value) {
+		fail("must be of type %s", joinList(types, " or "))
+		return
+	}
+
+	if enum := schemaList(schemaMap["enum"]); len(enum) > 0 && !inList(enum, value) {
+		fail("must be one of %s", joinList(enum, ", "))
+	}
+
+	if n, isNumber := toFloat(value); isNumber {
+		if minimum, ok := toFloat(schemaMap["minimum"]); ok && n < minimum {
+			fail("must be at least %v", minimum)
+		}
+		if maximum, ok := toFloat(schemaMap["maximum"]); ok && n > maximum {
+			fail("must be at most %v", maximum)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		if minLength, ok := toFloat(schemaMap["minLength"]); ok && float64(len([]rune(v))) < minLength {
+			fail("must be at least %v characters", minLength)
+		}
+		if maxLength, ok := toFloat(schemaMap["maxLength"]); ok && float64(len([]rune(v))) > maxLength {
+			fail("must be at most %v characters", maxLength)
+		}
+	case []interface{}:
+		if minItems, ok := toFloat(schemaMap["minItems"]); ok && float64(len(v)) < minItems {
+			fail("must have at least %v items", minItems)
+		}
+		if maxItems, ok := toFloat(schemaMap["maxItems"]); ok && float64(len(v)) > maxItems {
+			fail("must have at most %v items", maxItems)
+		}
+		for i, item := range v {
+			validateValue(fmt.Sprintf("%s[%d]", path, i), schemaMap["items"], item, errs)
+		}
+	case map[string]interface{}:
+		for _, name := range schemaList(schemaMap["required"]) {
+			if v[name.(string)] == nil {
+				*errs = append(*errs, argumentError{Field: joinField(path, name.(string)), Message: "is required"})
+			}
+		}
+		properties, _ := schemaMap["properties"].(map[string]interface{})
+		for name, property := range v {
+			if property == nil {
+				continue
+			}
+			if propertySchema, declared := properties[name]; declared {
+				validateValue(joinField(path, name), propertySchema, property, errs)
+			} else if additional, ok := schemaMap["additionalProperties"].(map[string]interface{}); ok {
+				validateValue(joinField(path, name), additional, property, errs)
+			}
+		}
+	}
+}
+
+// joinField returns the field name of a property of the object at path
+func joinField(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// matchesAnyType reports whether value is of one of the JSON schema types
+func matchesAnyType(types []interface{}, value interface{}) bool {
+	for _, t := range types {
+		if matchesType(fmt.Sprint(t), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesType reports whether value is of the JSON schema type t
+func matchesType(t string, value interface{}) bool {
+	switch t {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "integer":
+		n, ok := toFloat(value)
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "null":
+		return value == nil
+	}
+	return true
+}
+
+// toFloat returns a numeric value as a float64, reporting whether it is one
+func toFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// schemaList returns a schema keyword holding a value or a list of values,
+// as the tool schemas declare them in Go, as a list
+func schemaList(value interface{}) []interface{} {
+	switch list := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return list
+	case []string:
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items
+	case []int:
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items
+	}
+	return []interface{}{value}
+}
+
+// inList reports whether value equals an item of list, comparing numbers by value
+func inList(list []interface{}, value interface{}) bool {
+	n, isNumber := toFloat(value)
+	for _, item := range list {
+		if m, ok := toFloat(item); ok && isNumber {
+			if m == n {
+				return true
+			}
+		} else if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// joinList formats the items of list separated by sep
+func joinList(list []interface{}, sep string) string {
+	items := make([]string, len(list))
+	for i, item := range list {
+		items[i] = fmt.Sprint(item)
+	}
+	return strings.Join(items, sep)
+}
@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidateArguments(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"owner":    map[string]interface{}{"type": "string"},
+			"state":    map[string]interface{}{"type": "string", "enum": []string{"open", "closed"}},
+			"per_page": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 100},
+			"numbers":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}, "maxItems": 2},
+			"query":    map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": []string{"string", "number"}}},
+		},
+		"required": []string{"owner"},
+	}
+
+	tests := []struct {
+		name string
+		args map[string]interface{}
+		want []string
+	}{
+		{name: "valid", args: map[string]interface{}{"owner": "octocat", "state": "open", "per_page": float64(30), "numbers": []interface{}{float64(1)}, "unknown": true}},
+		{name: "missing required", args: map[string]interface{}{"owner": nil}, want: []string{"owner"}},
+		{name: "wrong types", args: map[string]interface{}{"owner": float64(1), "per_page": 2.5}, want: []string{"owner", "per_page"}},
+		{name: "enum and bounds", args: map[string]interface{}{"owner": "octocat", "state": "merged", "per_page": float64(500)}, want: []string{"per_page", "state"}},
+		{name: "array items", args: map[string]interface{}{"owner": "octocat", "numbers": []interface{}{float64(1), "two", float64(3)}}, want: []string{"numbers", "numbers[1]"}},
+		{name: "additional properties", args: map[string]interface{}{"owner": "octocat", "query": map[string]interface{}{"sort": "created", "nested": map[string]interface{}{}}}, want: []string{"query.nested"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateArguments(schema, tt.args)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("Expected violations of %v, got %v", tt.want, errs)
+			}
+			for i, field := range tt.want {
+				if errs[i].Field != field {
+					t.Errorf("Expected violations of %v, got %v", tt.want, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleCallTool_InvalidArguments(t *testing.T) {
+	h := NewHandler(nil, createTestLogger())
+	session := NewSession(TransportStdio)
+	session.setInitialized()
+	ctx := WithSession(context.Background(), session)
+
+	resp := h.handleCallTool(ctx, NewRequest(1, MethodCallTool, map[string]interface{}{
+		"name":      "list_repositories",
+		"arguments": map[string]interface{}{"owner": "octocat", "sort": "stars", "per_page": float64(0)},
+	}))
+	if resp.Error == nil || resp.Error.Code != ErrorCodeInvalidParams {
+		t.Fatalf("Expected invalid params, got %+v", resp.Error)
+	}
+	data := resp.Error.Data.(map[string]interface{})
+	if errs := data["errors"].(argumentErrors); len(errs) != 2 || errs[0].Field != "per_page" || errs[1].Field != "sort" {
+		t.Errorf("Expected per-field details, got %+v", errs)
+	}
+}
@@ -0,0 +1,77 @@
+// Package pubsub fans stream notifications out to every server replica, so
+// clients connected to any replica behind a load balancer receive them
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// Message is a notification published by a replica
+type Message struct {
+	// Origin identifies the publishing replica so it can skip its own messages
+	Origin string `json:"origin"`
+	// ClientID addresses a single stream client; empty broadcasts to all
+	ClientID string `json:"client_id,omitempty"`
+	// Owner is who the addressed client's stream must have been opened by
+	Owner string `json:"owner,omitempty"`
+	// OwnerOnly broadcasts only to the streams opened by Owner
+	OwnerOnly bool            `json:"owner_only,omitempty"`
+	EventType string          `json:"event_type"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Broker delivers messages between replicas
+type Broker interface {
+	// Publish sends msg to the subscribers of every replica, including this one
+	Publish(ctx context.Context, msg *Message) error
+	// Subscribe calls handler with every published message until ctx is done
+	Subscribe(ctx context.Context, handler func(*Message)) error
+	// Close releases the broker's connections
+	Close() error
+}
+
+// Local is a Broker delivering messages within the process, for a single
+// replica or tests
+type Local struct {
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[int]func(*Message)
+}
+
+// NewLocal creates an in-process broker
+func NewLocal() *Local {
+	return &Local{handlers: make(map[int]func(*Message))}
+}
+
+// Publish calls every subscribed handler with msg
+func (l *Local) Publish(ctx context.Context, msg *Message) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, handler := range l.handlers {
+		handler(msg)
+	}
+	return nil
+}
+
+// Subscribe registers handler until ctx is done
+func (l *Local) Subscribe(ctx context.Context, handler func(*Message)) error {
+	l.mu.Lock()
+	id := l.nextID
+	l.nextID++
+	l.handlers[id] = handler
+	l.mu.Unlock()
+
+	<-ctx.Done()
+
+	l.mu.Lock()
+	delete(l.handlers, id)
+	l.mu.Unlock()
+	return ctx.Err()
+}
+
+// Close is a no-op for the in-process broker
+func (l *Local) Close() error {
+	return nil
+}
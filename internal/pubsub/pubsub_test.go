@@ -0,0 +1,48 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLocal(t *testing.T) {
+	broker := NewLocal()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan *Message, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- broker.Subscribe(ctx, func(msg *Message) { received <- msg })
+	}()
+
+	// Wait for the subscription to be registered
+	deadline := time.Now().Add(time.Second)
+	for {
+		broker.mu.RLock()
+		n := len(broker.handlers)
+		broker.mu.RUnlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the subscription")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := broker.Publish(context.Background(), &Message{Origin: "a", EventType: "notification"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if msg := <-received; msg.Origin != "a" || msg.EventType != "notification" {
+		t.Errorf("Unexpected message %+v", msg)
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(broker.handlers) != 0 {
+		t.Error("Expected the handler to be removed once the context is done")
+	}
+}
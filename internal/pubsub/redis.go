@@ -0,0 +1,148 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/redis"
+)
+
+// DefaultChannel is the Redis channel messages are published on
+const DefaultChannel = "github-mcp:stream"
+
+const (
+	// minResubscribeDelay and maxResubscribeDelay bound the backoff between
+	// attempts to restore a lost subscription
+	minResubscribeDelay = 500 * time.Millisecond
+	maxResubscribeDelay = 30 * time.Second
+)
+
+// Redis is a Broker publishing messages on a Redis channel
+type Redis struct {
+	opts    *redis.Options
+	channel string
+	logger  *logger.Logger
+
+	// mu guards conn, the connection used to publish; it is dialed lazily,
+	// without holding mu, and replaced after a failure
+	mu   sync.Mutex
+	conn *redis.Conn
+}
+
+// NewRedis creates a broker for the server at rawURL, a redis:// or
+// rediss:// URL, publishing on channel
+func NewRedis(rawURL, channel string, logger *logger.Logger) (*Redis, error) {
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if channel == "" {
+		channel = DefaultChannel
+	}
+	return &Redis{opts: opts, channel: channel, logger: logger}, nil
+}
+
+// Publish publishes msg on the channel, redialing once if the connection
+// was lost
+func (r *Redis) Publish(ctx context.Context, msg *Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		conn, err := r.connection(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = conn.Do(ctx, "PUBLISH", r.channel, string(payload))
+		if _, isReply := err.(redis.Error); err == nil || isReply || attempt > 0 {
+			return err
+		}
+		r.discard(conn)
+	}
+}
+
+// connection returns the publishing connection, dialing it when there is
+// none. The dial happens without holding mu, so a slow server does not
+// block Close.
+func (r *Redis) connection(ctx context.Context) (*redis.Conn, error) {
+	r.mu.Lock()
+	conn := r.conn
+	r.mu.Unlock()
+	if conn != nil {
+		return conn, nil
+	}
+
+	conn, err := redis.Dial(ctx, r.opts)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conn != nil {
+		// Another publish dialed first
+		conn.Close()
+		return r.conn, nil
+	}
+	r.conn = conn
+	return conn, nil
+}
+
+// discard closes a publishing connection that failed, unless it was already
+// replaced
+func (r *Redis) discard(conn *redis.Conn) {
+	r.mu.Lock()
+	if r.conn == conn {
+		r.conn = nil
+	}
+	r.mu.Unlock()
+	conn.Close()
+}
+
+// Subscribe calls handler with every message on the channel until ctx is
+// done, resubscribing with backoff when the connection is lost
+func (r *Redis) Subscribe(ctx context.Context, handler func(*Message)) error {
+	delay := minResubscribeDelay
+	for {
+		conn, err := redis.Dial(ctx, r.opts)
+		if err == nil {
+			delay = minResubscribeDelay
+			err = conn.Subscribe(ctx, r.channel, func(payload []byte) {
+				var msg Message
+				if err := json.Unmarshal(payload, &msg); err != nil {
+					r.logger.Warn("Ignoring malformed pub/sub message", "error", err)
+					return
+				}
+				handler(&msg)
+			})
+			conn.Close()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		r.logger.Warn("Pub/sub subscription lost, retrying", "error", err, "retryIn", delay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxResubscribeDelay)
+	}
+}
+
+// Close closes the publishing connection
+func (r *Redis) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn = nil
+	return err
+}
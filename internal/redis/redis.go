@@ -0,0 +1,251 @@
+// Package redis is a minimal client for the Redis serialization protocol
+// (RESP2), covering the commands the server needs without a dependency
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// dialTimeout bounds connecting to the server when the context has no deadline
+const dialTimeout = 5 * time.Second
+
+// Error is an error reply from the server
+type Error string
+
+func (e Error) Error() string { return string(e) }
+
+// Options describe how to reach a server
+type Options struct {
+	Addr     string
+	Username string
+	Password string
+	DB       int
+	TLS      bool
+}
+
+// ParseURL parses a redis:// or rediss:// URL of the form
+// redis://[user:password@]host[:port][/db]
+func ParseURL(rawURL string) (*Options, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	opts := &Options{}
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		opts.TLS = true
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q (must be redis or rediss)", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("missing host")
+	}
+	opts.Addr = u.Host
+	if u.Port() == "" {
+		opts.Addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		opts.Username = u.User.Username()
+		opts.Password, _ = u.User.Password()
+		// redis://:password@host has no user
+		if _, hasPassword := u.User.Password(); !hasPassword {
+			opts.Password, opts.Username = opts.Username, ""
+		}
+	}
+	if db := u.Path; len(db) > 1 {
+		if opts.DB, err = strconv.Atoi(db[1:]); err != nil || opts.DB < 0 {
+			return nil, fmt.Errorf("invalid database %q", db[1:])
+		}
+	}
+	return opts, nil
+}
+
+// Conn is a connection to a server. It is safe for concurrent use; commands
+// are sent one at a time.
+type Conn struct {
+	mu   sync.Mutex
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+// Dial connects to the server described by opts, authenticating and
+// selecting the database
+func Dial(ctx context.Context, opts *Options) (*Conn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if opts.TLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", opts.Addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", opts.Addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Conn{conn: conn, rd: bufio.NewReader(conn)}
+	if opts.Password != "" {
+		args := []string{"AUTH", opts.Password}
+		if opts.Username != "" {
+			args = []string{"AUTH", opts.Username, opts.Password}
+		}
+		if _, err := c.Do(ctx, args...); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	if opts.DB != 0 {
+		if _, err := c.Do(ctx, "SELECT", strconv.Itoa(opts.DB)); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Do sends a command and returns its reply: a string for simple and bulk
+// strings, an int64 for integers, a []interface{} for arrays and nil for
+// null replies. Error replies are returned as an Error.
+func (c *Conn) Do(ctx context.Context, args ...string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetDeadline(deadline)
+		defer c.conn.SetDeadline(time.Time{})
+	}
+	if err := c.send(args); err != nil {
+		return nil, err
+	}
+	return c.receive()
+}
+
+// Subscribe subscribes the connection to channel and calls handler with the
+// payload of every message published to it. It blocks until ctx is done or
+// the connection fails, and the connection cannot be used afterwards.
+func (c *Conn) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.send([]string{"SUBSCRIBE", channel}); err != nil {
+		return err
+	}
+
+	// Unblock the read below once the context is done
+	stop := context.AfterFunc(ctx, func() { c.conn.Close() })
+	defer stop()
+
+	for {
+		reply, err := c.receive()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		// Messages are ["message", channel, payload]; subscription
+		// confirmations and other pushes are ignored
+		if push, ok := reply.([]interface{}); ok && len(push) == 3 && push[0] == "message" {
+			if payload, ok := push[2].(string); ok {
+				handler([]byte(payload))
+			}
+		}
+	}
+}
+
+// Close closes the connection
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// send writes a command as an array of bulk strings
+func (c *Conn) send(args []string) error {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	_, err := c.conn.Write(buf)
+	return err
+}
+
+// receive reads one reply
+func (c *Conn) receive() (interface{}, error) {
+	reply, err := readReply(c.rd)
+	if replyErr, ok := reply.(Error); ok {
+		return nil, replyErr
+	}
+	return reply, err
+}
+
+// readReply reads a RESP2 value. Error replies nested in arrays are returned
+// as Error values rather than failing the whole reply.
+func readReply(rd *bufio.Reader) (interface{}, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return Error(body), nil
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("malformed bulk string length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(rd, data); err != nil {
+			return nil, err
+		}
+		return string(data[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("malformed array length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			if items[i], err = readReply(rd); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unknown reply type %q", kind)
+	}
+}
@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// storePoolSize is the number of idle connections a store keeps
+const storePoolSize = 8
+
+// ErrStoreClosed is returned by the commands of a closed store
+var ErrStoreClosed = errors.New("redis: store closed")
+
+// Store is a key-value store on a server. Commands run concurrently on a
+// pool of connections dialed lazily; a command failing on an idle connection
+// that was lost is retried once on a new one.
+type Store struct {
+	opts   *Options
+	prefix string
+
+	// idle holds the connections not running a command
+	idle chan *Conn
+
+	// mu guards closed
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewStore creates a store on the server at rawURL, a redis:// or rediss://
+// URL, prefixing every key with prefix
+func NewStore(rawURL, prefix string) (*Store, error) {
+	opts, err := ParseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{opts: opts, prefix: prefix, idle: make(chan *Conn, storePoolSize)}, nil
+}
+
+// Get returns the value of key, reporting whether it is set
+func (s *Store) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := s.do(ctx, "GET", s.prefix+key)
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+	value, _ := reply.(string)
+	return []byte(value), true, nil
+}
+
+// Set sets key to value, expiring it after ttl
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.do(ctx, "SET", s.prefix+key, string(value), "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	return err
+}
+
+// Close closes the idle connections; connections running a command are
+// closed when it completes
+func (s *Store) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	var err error
+	for {
+		select {
+		case conn := <-s.idle:
+			if closeErr := conn.Close(); err == nil {
+				err = closeErr
+			}
+		default:
+			return err
+		}
+	}
+}
+
+// do sends a command on a pooled connection, retrying once on a new
+// connection if an idle one was lost
+func (s *Store) do(ctx context.Context, args ...string) (interface{}, error) {
+	for {
+		conn, reused, err := s.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		reply, err := conn.Do(ctx, args...)
+		if _, isReply := err.(Error); err == nil || isReply {
+			s.release(conn)
+			return reply, err
+		}
+		conn.Close()
+		if !reused {
+			return nil, err
+		}
+	}
+}
+
+// acquire returns an idle connection, reporting it was reused, or dials a
+// new one
+func (s *Store) acquire(ctx context.Context) (*Conn, bool, error) {
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return nil, false, ErrStoreClosed
+	}
+
+	select {
+	case conn := <-s.idle:
+		return conn, true, nil
+	default:
+	}
+	conn, err := Dial(ctx, s.opts)
+	return conn, false, err
+}
+
+// release returns a connection to the pool, closing it when the pool is
+// full or the store closed
+func (s *Store) release(conn *Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		conn.Close()
+		return
+	}
+	select {
+	case s.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
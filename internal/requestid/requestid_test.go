@@ -0,0 +1,40 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"", false},
+		{"abc-123", true},
+		{New(), true},
+		{"with space", false},
+		{"line\nbreak", false},
+		{"ünicode", false},
+		{strings.Repeat("a", maxLength), true},
+		{strings.Repeat("a", maxLength+1), false},
+	}
+
+	for _, tt := range tests {
+		if got := Valid(tt.id); got != tt.valid {
+			t.Errorf("Valid(%q) = %v, want %v", tt.id, got, tt.valid)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	if id := FromContext(context.Background()); id != "" {
+		t.Errorf("Expected empty request ID, got %q", id)
+	}
+
+	ctx := NewContext(context.Background(), "req-1")
+	if id := FromContext(ctx); id != "req-1" {
+		t.Errorf("Expected request ID req-1, got %q", id)
+	}
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// adminClientsPath lists connected SSE clients; a client ID appended to it
+// addresses a single client
+const adminClientsPath = "/admin/clients"
+
+// adminToolsetsPath lists toolsets; a toolset name appended to it addresses
+// a single toolset
+const adminToolsetsPath = "/admin/toolsets"
+
+// setupAdminRoutes registers the admin API when admin tokens are configured
+func (s *Server) setupAdminRoutes() {
+	if len(s.adminTokens) == 0 {
+		return
+	}
+
+	s.mux.Handle(adminClientsPath, s.adminAuth(http.HandlerFunc(s.handleAdminClients)))
+	s.mux.Handle(adminClientsPath+"/", s.adminAuth(http.HandlerFunc(s.handleAdminClient)))
+	s.mux.Handle("/admin/tools", s.adminAuth(http.HandlerFunc(s.handleAdminTools)))
+	s.mux.Handle(adminToolsetsPath, s.adminAuth(http.HandlerFunc(s.handleAdminToolsets)))
+	s.mux.Handle(adminToolsetsPath+"/", s.adminAuth(http.HandlerFunc(s.handleAdminToolset)))
+	s.mux.Handle("/admin/reload", s.adminAuth(http.HandlerFunc(s.handleAdminReload)))
+}
+
+// adminAuth requires one of the configured admin tokens. Admin tokens are
+// separate from MCP tokens so that MCP clients cannot manage the server.
+func (s *Server) adminAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented, ok := extractBearerToken(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="github-mcp-admin"`)
+			s.writeErrorResponse(w, errors.Authentication("missing bearer token"))
+			return
+		}
+
+		token := matchBearerToken(s.adminTokens, presented)
+		if token == nil {
+			s.logger.Warn("Rejected admin request with invalid bearer token",
+				"path", r.URL.Path,
+				"remoteAddr", r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="github-mcp-admin", error="invalid_token"`)
+			s.writeErrorResponse(w, errors.Authentication("invalid bearer token"))
+			return
+		}
+
+		s.logger.WithContext(r.Context()).Info("Admin request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"token", token.name)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleAdminClients lists the connected SSE clients
+func (s *Server) handleAdminClients(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeErrorResponse(w, errors.Validation("method not allowed"))
+		return
+	}
+
+	clients := s.streamHandler.Clients()
+	s.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"clients": clients,
+		"count":   len(clients),
+	})
+}
+
+// handleAdminClient disconnects a single SSE client
+func (s *Server) handleAdminClient(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		s.writeErrorResponse(w, errors.Validation("method not allowed"))
+		return
+	}
+
+	clientID := strings.TrimPrefix(r.URL.Path, adminClientsPath+"/")
+	if clientID == "" || strings.Contains(clientID, "/") {
+		s.writeErrorResponse(w, errors.Validation("client ID is required"))
+		return
+	}
+
+	if !s.streamHandler.DisconnectClient(clientID) {
+		s.writeErrorResponse(w, errors.NotFound("client not connected").WithContext("clientId", clientID))
+		return
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"clientId":     clientID,
+		"disconnected": true,
+	})
+}
+
+// handleAdminTools lists the tool calls currently executing
+func (s *Server) handleAdminTools(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeErrorResponse(w, errors.Validation("method not allowed"))
+		return
+	}
+
+	executions := s.mcpHandler.InFlightTools()
+	stats := s.mcpHandler.ToolCallStats()
+	s.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"executions": executions,
+		"running":    stats.Running,
+		"queued":     stats.Queued,
+	})
+}
+
+// handleAdminToolsets lists the toolsets and whether their tools are offered
+func (s *Server) handleAdminToolsets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeErrorResponse(w, errors.Validation("method not allowed"))
+		return
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"toolsets": s.mcpHandler.Toolsets(),
+	})
+}
+
+// handleAdminToolset enables a toolset with PUT and disables it with DELETE
+func (s *Server) handleAdminToolset(w http.ResponseWriter, r *http.Request) {
+	var enabled bool
+	switch r.Method {
+	case http.MethodPut:
+		enabled = true
+	case http.MethodDelete:
+		enabled = false
+	default:
+		s.writeErrorResponse(w, errors.Validation("method not allowed"))
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, adminToolsetsPath+"/")
+	if err := s.mcpHandler.SetToolsetEnabled(name, enabled); err != nil {
+		s.writeErrorResponse(w, errors.NotFound("unknown toolset").WithContext("toolset", name))
+		return
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"toolset": name,
+		"enabled": enabled,
+	})
+}
+
+// handleAdminReload reloads the configuration like SIGHUP
+func (s *Server) handleAdminReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeErrorResponse(w, errors.Validation("method not allowed"))
+		return
+	}
+
+	if err := s.Reload(); err != nil {
+		appErr, ok := err.(*errors.AppError)
+		if !ok {
+			appErr = errors.Wrap(err, errors.ErrorTypeInternal, "failed to reload configuration")
+		}
+		s.writeErrorResponse(w, appErr)
+		return
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, map[string]interface{}{"reloaded": true})
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+)
+
+func TestAdminAPI(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	s := &Server{
+		logger:        testLogger,
+		mux:           http.NewServeMux(),
+		mcpHandler:    mcp.NewHandler(nil, testLogger),
+		streamHandler: mcp.NewStreamHandler(testLogger),
+		adminTokens:   parseBearerTokens([]string{"ops:admin-secret"}),
+		bearerTokens:  parseBearerTokens([]string{"mcp-secret"}),
+	}
+	s.setupAdminRoutes()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		wantStatus int
+	}{
+		{"missing token", http.MethodGet, "/admin/clients", "", http.StatusUnauthorized},
+		{"MCP token is not an admin token", http.MethodGet, "/admin/clients", "mcp-secret", http.StatusUnauthorized},
+		{"list clients", http.MethodGet, "/admin/clients", "admin-secret", http.StatusOK},
+		{"disconnect unknown client", http.MethodDelete, "/admin/clients/client_1", "admin-secret", http.StatusNotFound},
+		{"list tools", http.MethodGet, "/admin/tools", "admin-secret", http.StatusOK},
+		{"list toolsets", http.MethodGet, "/admin/toolsets", "admin-secret", http.StatusOK},
+		{"disable toolset", http.MethodDelete, "/admin/toolsets/issues", "admin-secret", http.StatusOK},
+		{"enable toolset", http.MethodPut, "/admin/toolsets/issues", "admin-secret", http.StatusOK},
+		{"unknown toolset", http.MethodDelete, "/admin/toolsets/nope", "admin-secret", http.StatusNotFound},
+		{"reload without loader", http.MethodPost, "/admin/reload", "admin-secret", http.StatusInternalServerError},
+		{"wrong method", http.MethodPost, "/admin/clients", "admin-secret", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			s.mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/tools", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, req)
+
+	var body struct {
+		Data struct {
+			Executions []mcp.ToolExecution `json:"executions"`
+			Running    int64               `json:"running"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode tools response: %v", err)
+	}
+	if body.Data.Executions == nil || body.Data.Running != 0 {
+		t.Errorf("Expected an empty execution list, got %s", rec.Body.String())
+	}
+}
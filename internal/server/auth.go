@@ -0,0 +1,188 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/auth"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// bearerToken is a configured static token accepted on MCP endpoints
+type bearerToken struct {
+	name string
+	hash [sha256.Size]byte
+}
+
+// parseBearerTokens converts configured token entries into bearer tokens.
+// Entries may be plain tokens or "name:token" pairs; unnamed tokens are
+// identified by a short fingerprint so they can be told apart in logs.
+func parseBearerTokens(entries []string) []bearerToken {
+	tokens := make([]bearerToken, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, token, found := strings.Cut(entry, ":")
+		if !found {
+			token = entry
+			name = ""
+		}
+
+		hash := sha256.Sum256([]byte(token))
+		if name == "" {
+			name = "token-" + hex.EncodeToString(hash[:4])
+		}
+
+		tokens = append(tokens, bearerToken{name: name, hash: hash})
+	}
+	return tokens
+}
+
+// isProtectedPath reports whether the path requires MCP authentication
+func isProtectedPath(path string) bool {
+	return path == "/mcp" || strings.HasPrefix(path, "/mcp/")
+}
+
+// bearerAuthMiddleware requires a valid bearer token on all MCP endpoints.
+// Static tokens are checked first, then OAuth access tokens when a token
+// validator is configured. Health and readiness endpoints remain unauthenticated.
+func (s *Server) bearerAuthMiddleware(next http.Handler) http.Handler {
+	if len(s.bearerTokens) == 0 && s.tokenValidator == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isProtectedPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		presented, ok := extractBearerToken(r)
+		if !ok {
+			s.writeUnauthorized(w, r, "", "missing bearer token")
+			return
+		}
+
+		if token := matchBearerToken(s.bearerTokens, presented); token != nil {
+			identity := &auth.Identity{
+				Subject: token.name,
+				Method:  auth.MethodBearerToken,
+			}
+			next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+			return
+		}
+
+		if s.tokenValidator == nil {
+			s.logger.Warn("Rejected MCP request with invalid bearer token",
+				"path", r.URL.Path,
+				"remoteAddr", r.RemoteAddr)
+			s.writeUnauthorized(w, r, "invalid_token", "invalid bearer token")
+			return
+		}
+
+		identity, err := s.tokenValidator.Validate(r.Context(), presented)
+		if err != nil {
+			s.logger.Warn("Rejected MCP request with invalid access token",
+				"path", r.URL.Path,
+				"remoteAddr", r.RemoteAddr,
+				"error", err)
+			appErr, ok := err.(*errors.AppError)
+			if !ok {
+				appErr = errors.Wrap(err, errors.ErrorTypeAuthentication, "invalid access token")
+			}
+			if appErr.Type == errors.ErrorTypeAuthorization {
+				s.writeInsufficientScope(w, r, appErr)
+				return
+			}
+			s.writeUnauthorized(w, r, "invalid_token", appErr.Message)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+	})
+}
+
+// matchBearerToken finds the token matching the presented value
+func matchBearerToken(tokens []bearerToken, presented string) *bearerToken {
+	hash := sha256.Sum256([]byte(presented))
+
+	var matched *bearerToken
+	for i := range tokens {
+		// Compare against every token so timing does not reveal the match position
+		if subtle.ConstantTimeCompare(hash[:], tokens[i].hash[:]) == 1 {
+			matched = &tokens[i]
+		}
+	}
+	return matched
+}
+
+// extractBearerToken reads the bearer token from the Authorization header
+func extractBearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// writeUnauthorized writes a 401 response with an RFC 6750 bearer challenge.
+// When OAuth is enabled the challenge points clients at the protected
+// resource metadata so they can discover the authorization server.
+func (s *Server) writeUnauthorized(w http.ResponseWriter, r *http.Request, errorCode, message string) {
+	w.Header().Set("WWW-Authenticate", s.bearerChallenge(r, errorCode, message, ""))
+	s.writeErrorResponse(w, errors.Authentication(message))
+}
+
+// writeInsufficientScope writes a 403 response asking for additional scopes
+func (s *Server) writeInsufficientScope(w http.ResponseWriter, r *http.Request, err *errors.AppError) {
+	scope := strings.Join(s.tokenValidator.RequiredScopes(), " ")
+	w.Header().Set("WWW-Authenticate", s.bearerChallenge(r, "insufficient_scope", err.Message, scope))
+	s.writeErrorResponse(w, err)
+}
+
+// bearerChallenge builds the WWW-Authenticate header value
+func (s *Server) bearerChallenge(r *http.Request, errorCode, description, scope string) string {
+	params := []string{`realm="github-mcp"`}
+
+	if s.tokenValidator != nil {
+		params = append(params, fmt.Sprintf(`resource_metadata="%s"`, s.resourceMetadataURL()))
+	}
+	if errorCode != "" {
+		params = append(params, fmt.Sprintf(`error="%s"`, errorCode))
+		params = append(params, fmt.Sprintf(`error_description="%s"`, strings.ReplaceAll(description, `"`, "'")))
+	}
+	if scope != "" {
+		params = append(params, fmt.Sprintf(`scope="%s"`, scope))
+	}
+
+	return "Bearer " + strings.Join(params, ", ")
+}
+
+// streamOwner identifies who makes r, so the SSE streams a client opens only
+// receive the messages of requests made the same way: by the authenticated
+// identity and, with token passthrough, the GitHub token sent. It is empty
+// when neither is known.
+func (s *Server) streamOwner(r *http.Request) string {
+	var parts []string
+	if identity, ok := auth.IdentityFromContext(r.Context()); ok {
+		parts = append(parts, identity.Method, identity.Subject)
+	}
+	if token := s.passthroughToken(r); token != "" {
+		parts = append(parts, token)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
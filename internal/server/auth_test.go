@@ -0,0 +1,100 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/auth"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+)
+
+func TestParseBearerTokens(t *testing.T) {
+	tokens := parseBearerTokens([]string{"ci:ci-secret", " plain-secret ", ""})
+	if len(tokens) != 2 {
+		t.Fatalf("Expected 2 tokens, got %d", len(tokens))
+	}
+	if tokens[0].name != "ci" {
+		t.Errorf("Expected the named token to be called ci, got %q", tokens[0].name)
+	}
+	if !strings.HasPrefix(tokens[1].name, "token-") || len(tokens[1].name) != len("token-")+8 {
+		t.Errorf("Expected the unnamed token to be named by its fingerprint, got %q", tokens[1].name)
+	}
+
+	if token := matchBearerToken(tokens, "ci-secret"); token == nil || token.name != "ci" {
+		t.Errorf("Expected ci-secret to match the ci token, got %+v", token)
+	}
+	if token := matchBearerToken(tokens, "plain-secret"); token == nil || token.name != tokens[1].name {
+		t.Errorf("Expected plain-secret to match the unnamed token, got %+v", token)
+	}
+	if token := matchBearerToken(tokens, "ci:ci-secret"); token != nil {
+		t.Errorf("Expected the whole entry not to match, got %+v", token)
+	}
+}
+
+func TestBearerAuthMiddleware(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	unprotected := (&Server{logger: testLogger}).bearerAuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	rec := httptest.NewRecorder()
+	unprotected.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp/request", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected MCP endpoints to be open without configured tokens, got %d", rec.Code)
+	}
+
+	s := &Server{logger: testLogger, bearerTokens: parseBearerTokens([]string{"ci:ci-secret"})}
+	var subject string
+	handler := s.bearerAuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject = ""
+		if identity, ok := auth.IdentityFromContext(r.Context()); ok && identity.Method == auth.MethodBearerToken {
+			subject = identity.Subject
+		}
+	}))
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		wantStatus    int
+		wantError     string
+		wantSubject   string
+	}{
+		{name: "valid token", path: "/mcp/request", authorization: "Bearer ci-secret", wantStatus: http.StatusOK, wantSubject: "ci"},
+		{name: "scheme is case insensitive", path: "/mcp", authorization: "bearer ci-secret", wantStatus: http.StatusOK, wantSubject: "ci"},
+		{name: "missing token", path: "/mcp/request", wantStatus: http.StatusUnauthorized},
+		{name: "other scheme", path: "/mcp/request", authorization: "Basic Y2k6Y2ktc2VjcmV0", wantStatus: http.StatusUnauthorized},
+		{name: "invalid token", path: "/mcp/stream", authorization: "Bearer wrong", wantStatus: http.StatusUnauthorized, wantError: `error="invalid_token"`},
+		{name: "health is public", path: "/health", wantStatus: http.StatusOK},
+		{name: "prefix is not a protected path", path: "/mcpx", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject = ""
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if subject != tt.wantSubject {
+				t.Errorf("Expected identity %q, got %q", tt.wantSubject, subject)
+			}
+			challenge := rec.Header().Get("WWW-Authenticate")
+			if tt.wantStatus == http.StatusUnauthorized && !strings.HasPrefix(challenge, `Bearer realm="github-mcp"`) {
+				t.Errorf("Expected a bearer challenge, got %q", challenge)
+			}
+			if tt.wantError != "" && !strings.Contains(challenge, tt.wantError) {
+				t.Errorf("Expected the challenge to contain %s, got %q", tt.wantError, challenge)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package server
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// minCompressSize is the smallest response body worth compressing
+const minCompressSize = 1024
+
+// compressor is implemented by the gzip and zlib writers
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// compressorPools reuses compressors per content coding
+var compressorPools = map[string]*sync.Pool{
+	"gzip": {New: func() interface{} {
+		return gzip.NewWriter(io.Discard)
+	}},
+	// The "deflate" content coding is the zlib format (RFC 9110 section 8.4.1.2)
+	"deflate": {New: func() interface{} {
+		return zlib.NewWriter(io.Discard)
+	}},
+}
+
+// compressibleContentTypes are the media types that are compressed
+var compressibleContentTypes = map[string]bool{
+	"application/json":  true,
+	"text/event-stream": true,
+}
+
+// compressionMiddleware compresses JSON and SSE responses with gzip or deflate
+// when the client advertises support in Accept-Encoding. SSE events are
+// flushed as complete compressed blocks so clients can decode each event as
+// it arrives.
+func (s *Server) compressionMiddleware(next http.Handler) http.Handler {
+	if !s.config.CompressionEnabled {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressResponseWriter{
+			ResponseWriter: w,
+			encoding:       encoding,
+			statusCode:     http.StatusOK,
+		}
+		defer cw.Close()
+
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding picks the preferred supported content coding from an
+// Accept-Encoding header, or "" if the response should not be compressed
+func negotiateEncoding(header string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+
+		quality := 1.0
+		if name, value, found := strings.Cut(strings.TrimSpace(params), "="); found && strings.TrimSpace(name) == "q" {
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				quality = q
+			}
+		}
+		accepted[coding] = quality > 0
+	}
+
+	for _, coding := range []string{"gzip", "deflate"} {
+		if enabled, listed := accepted[coding]; listed {
+			if enabled {
+				return coding
+			}
+			continue
+		}
+		if accepted["*"] {
+			return coding
+		}
+	}
+
+	return ""
+}
+
+// compressResponseWriter buffers the start of a response to decide whether it
+// is worth compressing, then streams it through a pooled compressor
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding    string
+	statusCode  int
+	wroteHeader bool
+	decided     bool
+	buf         []byte
+	writer      compressor
+}
+
+// WriteHeader records the status code; headers are sent once the response
+// has been classified
+func (cw *compressResponseWriter) WriteHeader(code int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.statusCode = code
+
+	if !bodyAllowed(code) {
+		cw.start(false)
+	}
+}
+
+// Write buffers small responses and compresses larger ones
+func (cw *compressResponseWriter) Write(p []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+
+	if !cw.decided {
+		if !cw.eligible() {
+			cw.start(false)
+		} else {
+			cw.buf = append(cw.buf, p...)
+			if len(cw.buf) >= minCompressSize {
+				if err := cw.start(true); err != nil {
+					return 0, err
+				}
+			}
+			return len(p), nil
+		}
+	}
+
+	if cw.writer != nil {
+		return cw.writer.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// Flush sends buffered data to the client. Streaming responses are compressed
+// regardless of size since more data is expected to follow.
+func (cw *compressResponseWriter) Flush() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			cw.WriteHeader(http.StatusOK)
+		}
+		cw.start(cw.eligible())
+	}
+
+	if cw.writer != nil {
+		cw.writer.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close completes the response, compressing it only if it was large enough
+func (cw *compressResponseWriter) Close() error {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			// Nothing was written; let the server send its default response
+			return nil
+		}
+		cw.start(cw.eligible() && len(cw.buf) >= minCompressSize)
+	}
+
+	if cw.writer == nil {
+		return nil
+	}
+
+	err := cw.writer.Close()
+	cw.writer.Reset(io.Discard)
+	compressorPools[cw.encoding].Put(cw.writer)
+	cw.writer = nil
+	return err
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController
+func (cw *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// eligible reports whether the response headers allow compression
+func (cw *compressResponseWriter) eligible() bool {
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" || !bodyAllowed(cw.statusCode) {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return compressibleContentTypes[mediaType]
+}
+
+// start sends the response headers and any buffered body, optionally
+// switching to a compressed stream
+func (cw *compressResponseWriter) start(compress bool) error {
+	cw.decided = true
+
+	if compress {
+		cw.Header().Set("Content-Encoding", cw.encoding)
+		cw.Header().Del("Content-Length")
+	}
+	cw.ResponseWriter.WriteHeader(cw.statusCode)
+
+	if compress {
+		cw.writer = compressorPools[cw.encoding].Get().(compressor)
+		cw.writer.Reset(cw.ResponseWriter)
+	}
+
+	if len(cw.buf) == 0 {
+		return nil
+	}
+
+	buffered := cw.buf
+	cw.buf = nil
+	if cw.writer != nil {
+		_, err := cw.writer.Write(buffered)
+		return err
+	}
+	_, err := cw.ResponseWriter.Write(buffered)
+	return err
+}
+
+// bodyAllowed reports whether a response with the given status may have a body
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/config"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{header: "", expected: ""},
+		{header: "gzip, deflate, br", expected: "gzip"},
+		{header: "deflate", expected: "deflate"},
+		{header: "gzip;q=0, deflate;q=0.5", expected: "deflate"},
+		{header: "*", expected: "gzip"},
+		{header: "*, gzip;q=0", expected: "deflate"},
+		{header: "br, identity", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := negotiateEncoding(tt.header); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	largeBody := `{"items":"` + strings.Repeat("a", 2*minCompressSize) + `"}`
+
+	tests := []struct {
+		name             string
+		contentType      string
+		body             string
+		flush            bool
+		expectCompressed bool
+	}{
+		{name: "large JSON", contentType: "application/json", body: largeBody, expectCompressed: true},
+		{name: "small JSON", contentType: "application/json", body: `{"status":"ok"}`, expectCompressed: false},
+		{name: "streamed events", contentType: "text/event-stream", body: "event: test\ndata: {}\n\n", flush: true, expectCompressed: true},
+		{name: "other content type", contentType: "text/html", body: largeBody, expectCompressed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{config: &config.Config{CompressionEnabled: true}}
+			handler := s.compressionMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				io.WriteString(w, tt.body)
+				if tt.flush {
+					w.(http.Flusher).Flush()
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/mcp/request", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			compressed := rec.Header().Get("Content-Encoding") == "gzip"
+			if compressed != tt.expectCompressed {
+				t.Fatalf("Expected compressed %v, got Content-Encoding %q", tt.expectCompressed, rec.Header().Get("Content-Encoding"))
+			}
+			if rec.Header().Get("Vary") != "Accept-Encoding" {
+				t.Errorf("Expected Vary: Accept-Encoding, got %q", rec.Header().Get("Vary"))
+			}
+
+			body := rec.Body.String()
+			if compressed {
+				reader, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("Failed to read gzip body: %v", err)
+				}
+				decoded, err := io.ReadAll(reader)
+				if err != nil {
+					t.Fatalf("Failed to decompress body: %v", err)
+				}
+				body = string(decoded)
+			}
+			if body != tt.body {
+				t.Errorf("Body mismatch: got %d bytes, expected %d", len(body), len(tt.body))
+			}
+		})
+	}
+}
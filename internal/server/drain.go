@@ -0,0 +1,27 @@
+package server
+
+import (
+	"context"
+	"time"
+)
+
+// shutdownReconnectDelay is how long SSE clients are asked to wait before
+// reconnecting to a replacement instance
+const shutdownReconnectDelay = time.Second
+
+// drainStreams tells SSE clients the server is going away, waits up to the
+// configured drain period for in-flight tool calls so their results can still
+// be streamed, then closes the streams. Without this, open SSE connections
+// would hold up http.Server.Shutdown until its timeout.
+func (s *Server) drainStreams(ctx context.Context) {
+	s.streamHandler.BeginShutdown(shutdownReconnectDelay)
+
+	drainCtx, cancel := context.WithTimeout(ctx, time.Duration(s.config.SSEDrainPeriod)*time.Second)
+	defer cancel()
+
+	if err := s.mcpHandler.WaitForTools(drainCtx); err != nil {
+		s.logger.Warn("Closing SSE connections with tool calls still running",
+			"running", len(s.mcpHandler.InFlightTools()))
+	}
+	s.streamHandler.CloseClients()
+}
@@ -1,14 +1,26 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
 	"github.com/nicholasflintwillow/github-mcp/internal/errors"
 	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+	"github.com/nicholasflintwillow/github-mcp/internal/version"
 )
 
+// githubAPIVersionHeader lets MCP clients override the GitHub REST API version per request
+const githubAPIVersionHeader = "X-GitHub-Api-Version"
+
+// githubTokenHeader carries the GitHub token of an MCP client when token
+// passthrough is enabled
+const githubTokenHeader = "X-GitHub-Token"
+
 // handleHealth handles health check requests
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -19,12 +31,32 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	response := map[string]interface{}{
 		"status":  "healthy",
 		"service": "github-mcp-server",
-		"version": "1.0.0",
+		"version": version.Version,
 	}
 
 	s.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// handleVersion reports the version, commit and build date of the running build
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeErrorResponse(w, errors.Validation("method not allowed"))
+		return
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, version.Get())
+}
+
+// handleManifest describes the tools, resources and transports of the deployment
+func (s *Server) handleManifest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeErrorResponse(w, errors.Validation("method not allowed"))
+		return
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, s.Manifest())
+}
+
 // handleReady handles readiness check requests
 func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -70,25 +102,31 @@ func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
 
 // handleMCP handles MCP protocol requests
 func (s *Server) handleMCP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodDelete {
+		s.handleMCPSessionDelete(w, r)
+		return
+	}
 	if r.Method != http.MethodPost {
 		s.writeErrorResponse(w, errors.Validation("only POST method is allowed for MCP requests"))
 		return
 	}
 
-	s.logger.Info("MCP request received", "method", r.Method, "path", r.URL.Path)
+	s.logger.WithContext(r.Context()).Info("MCP request received", "method", r.Method, "path", r.URL.Path)
 
 	// Read request body
-	body := make([]byte, r.ContentLength)
-	if _, err := r.Body.Read(body); err != nil && err.Error() != "EOF" {
-		s.logger.Error("Failed to read MCP request body", "error", err)
-		s.writeErrorResponse(w, errors.Validation("failed to read request body"))
+	body, ok := s.readMCPRequestBody(w, r)
+	if !ok {
 		return
 	}
 
 	// Process MCP message
-	responseData, err := s.mcpHandler.HandleMessage(r.Context(), body)
+	ctx, ok := s.mcpRequestContext(w, r, body)
+	if !ok {
+		return
+	}
+	responseData, err := s.mcpHandler.HandleMessage(ctx, body)
 	if err != nil {
-		s.logger.Error("Failed to process MCP message", "error", err)
+		s.logger.WithContext(r.Context()).Error("Failed to process MCP message", "error", err)
 		s.writeErrorResponse(w, errors.Internal("failed to process MCP message"))
 		return
 	}
@@ -109,24 +147,24 @@ func (s *Server) handleMCP(w http.ResponseWriter, r *http.Request) {
 
 // handleMCPRequest handles MCP protocol requests (new dedicated endpoint)
 func (s *Server) handleMCPRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodDelete {
+		s.handleMCPSessionDelete(w, r)
+		return
+	}
 	if r.Method != http.MethodPost {
 		s.writeErrorResponse(w, errors.Validation("only POST method is allowed for MCP requests"))
 		return
 	}
 
-	s.logger.Info("MCP request received", "method", r.Method, "path", r.URL.Path)
-
-	s.logger.Info("MCP request received", "method", r.Method, "path", r.URL.Path)
+	s.logger.WithContext(r.Context()).Info("MCP request received", "method", r.Method, "path", r.URL.Path)
 
 	var body []byte
 	var msg *mcp.JSONRPCMessage
 	var err error
 
 	if r.Method == http.MethodPost {
-		body = make([]byte, r.ContentLength)
-		if _, err := r.Body.Read(body); err != nil && err != io.EOF {
-			s.logger.Error("Failed to read MCP request body", "error", err)
-			s.writeErrorResponse(w, errors.Validation("failed to read request body"))
+		var ok bool
+		if body, ok = s.readMCPRequestBody(w, r); !ok {
 			return
 		}
 		msg, err = mcp.FromJSON(body)
@@ -155,9 +193,13 @@ func (s *Server) handleMCPRequest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Process MCP message
-	responseData, err := s.mcpHandler.HandleMessage(r.Context(), body)
+	ctx, ok := s.mcpRequestContext(w, r, body)
+	if !ok {
+		return
+	}
+	responseData, err := s.mcpHandler.HandleMessage(ctx, body)
 	if err != nil {
-		s.logger.Error("Failed to process MCP message", "error", err)
+		s.logger.WithContext(r.Context()).Error("Failed to process MCP message", "error", err)
 		s.writeErrorResponse(w, errors.Internal("failed to process MCP message"))
 		return
 	}
@@ -176,6 +218,124 @@ func (s *Server) handleMCPRequest(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleMCPSessionDelete ends the session named by the Mcp-Session-Id header
+func (s *Server) handleMCPSessionDelete(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.Header.Get(mcp.SessionIDHeader)
+	if sessionID == "" {
+		s.writeErrorResponse(w, errors.Validation(mcp.SessionIDHeader+" header is required to end a session"))
+		return
+	}
+	if !s.mcpHandler.CloseSession(sessionID, s.streamOwner(r)) {
+		s.writeErrorResponse(w, errors.NotFound("session not found"))
+		return
+	}
+	s.logger.WithContext(r.Context()).Info("MCP session ended by client", "session", sessionID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readMCPRequestBody reads the request body, enforcing the configured maximum
+// request size. On failure it writes the error response and returns false.
+func (s *Server) readMCPRequestBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.config.MaxRequestSize))
+	if err == nil {
+		return body, true
+	}
+
+	if _, tooLarge := err.(*http.MaxBytesError); tooLarge {
+		s.logger.Warn("MCP request body exceeds size limit",
+			"path", r.URL.Path,
+			"remoteAddr", r.RemoteAddr,
+			"maxBytes", s.config.MaxRequestSize)
+		s.writeJSONRPCError(w, http.StatusRequestEntityTooLarge, mcp.NewErrorResponse(nil, mcp.ErrorCodeInvalidRequest,
+			fmt.Sprintf("request body exceeds the maximum size of %d bytes", s.config.MaxRequestSize),
+			map[string]interface{}{"max_bytes": s.config.MaxRequestSize}))
+		return nil, false
+	}
+
+	s.logger.Error("Failed to read MCP request body", "error", err)
+	s.writeErrorResponse(w, errors.Validation("failed to read request body"))
+	return nil, false
+}
+
+// mcpRequestContext returns the context for processing an MCP request. A
+// client may pin the GitHub REST API version of its tool calls with the
+// X-GitHub-Api-Version header and, with token passthrough, make them with
+// its own GitHub token. Messages are handled in the session named by the
+// Mcp-Session-Id header, which only the identity that started it may use.
+// An initialize request without one starts a new session whose ID is
+// returned in that header, and other messages without one share a session
+// per identity. On failure it writes the error response and returns false.
+func (s *Server) mcpRequestContext(w http.ResponseWriter, r *http.Request, body []byte) (context.Context, bool) {
+	owner := s.streamOwner(r)
+	ctx := mcp.WithStreamClient(r.Context(), r.Header.Get(mcp.ClientIDHeader))
+	ctx = mcp.WithStreamOwner(ctx, owner)
+
+	session, err := s.mcpHandler.HTTPSession(r.Header.Get(mcp.SessionIDHeader), owner, body)
+	if err == mcp.ErrTooManySessions {
+		w.Header().Set("Retry-After", "60")
+		s.writeJSONRPCError(w, http.StatusServiceUnavailable, mcp.NewErrorResponse(nil, mcp.ErrorCodeInternalError,
+			"too many sessions are open; try again later", nil))
+		return nil, false
+	}
+	if err != nil {
+		s.writeJSONRPCError(w, http.StatusNotFound, mcp.NewErrorResponse(nil, mcp.ErrorCodeInvalidRequest,
+			"session not found; send an initialize request without "+mcp.SessionIDHeader+" to start a new session", nil))
+		return nil, false
+	}
+	ctx = mcp.WithSession(ctx, session)
+	if session.ID() != "" {
+		w.Header().Set(mcp.SessionIDHeader, session.ID())
+	}
+
+	if token := s.passthroughToken(r); token != "" {
+		ctx = client.WithToken(ctx, token)
+	}
+
+	if version := r.Header.Get(githubAPIVersionHeader); version != "" {
+		if !client.ValidAPIVersion(version) {
+			s.writeErrorResponse(w, errors.Validation(fmt.Sprintf("invalid %s header: %s (expected YYYY-MM-DD)", githubAPIVersionHeader, version)))
+			return nil, false
+		}
+		ctx = client.WithAPIVersion(ctx, version)
+	}
+
+	return ctx, true
+}
+
+// passthroughToken returns the GitHub token an MCP client sent with r when
+// token passthrough is enabled. The Authorization header is only taken as a
+// GitHub token when it does not authenticate the client to this server.
+func (s *Server) passthroughToken(r *http.Request) string {
+	if !s.config.TokenPassthrough {
+		return ""
+	}
+	if token := strings.TrimSpace(r.Header.Get(githubTokenHeader)); token != "" {
+		return token
+	}
+	if len(s.bearerTokens) == 0 && s.tokenValidator == nil {
+		if token, ok := extractBearerToken(r); ok {
+			return token
+		}
+	}
+	return ""
+}
+
+// writeJSONRPCError writes a JSON-RPC error response with the given HTTP status
+func (s *Server) writeJSONRPCError(w http.ResponseWriter, statusCode int, msg *mcp.JSONRPCMessage) {
+	data, err := msg.ToJSON()
+	if err != nil {
+		s.logger.Error("Failed to encode JSON-RPC error response", "error", err)
+		http.Error(w, msg.Error.Message, statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(data); err != nil {
+		s.logger.Error("Failed to write JSON-RPC error response", "error", err)
+	}
+}
+
 // handleMCPStream handles SSE connections for streaming MCP messages
 func (s *Server) handleMCPStream(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -185,8 +345,19 @@ func (s *Server) handleMCPStream(w http.ResponseWriter, r *http.Request) {
 
 	s.logger.Info("MCP stream connection requested", "remoteAddr", r.RemoteAddr)
 
-	// Delegate to the stream handler
-	s.streamHandler.HandleSSE(w, r)
+	// Delegate to the stream handler; the stream belongs to whoever opened it
+	s.streamHandler.HandleSSE(w, r.WithContext(mcp.WithStreamOwner(r.Context(), s.streamOwner(r))))
+}
+
+// handleMCPStreamSubscribe changes the event types an SSE client receives
+func (s *Server) handleMCPStreamSubscribe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeErrorResponse(w, errors.Validation("only POST method is allowed for MCP stream subscriptions"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, s.config.MaxRequestSize)
+	s.streamHandler.HandleSubscribe(w, r.WithContext(mcp.WithStreamOwner(r.Context(), s.streamOwner(r))))
 }
 
 // handleNotFound handles requests to undefined routes
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/config"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+	"github.com/nicholasflintwillow/github-mcp/internal/version"
+)
+
+func TestReadMCPRequestBody(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	s := &Server{config: &config.Config{MaxRequestSize: 64}, logger: testLogger}
+
+	t.Run("chunked body within limit", func(t *testing.T) {
+		payload := `{"jsonrpc":"2.0","id":1,"method":"ping"}`
+		req := httptest.NewRequest(http.MethodPost, "/mcp/request", strings.NewReader(payload))
+		req.ContentLength = -1 // unknown length, as with chunked transfer encoding
+		rec := httptest.NewRecorder()
+
+		body, ok := s.readMCPRequestBody(rec, req)
+		if !ok {
+			t.Fatalf("Expected body to be read, got status %d", rec.Code)
+		}
+		if string(body) != payload {
+			t.Errorf("Expected body %q, got %q", payload, string(body))
+		}
+	})
+
+	t.Run("body exceeds limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/mcp/request", strings.NewReader(strings.Repeat("x", 65)))
+		rec := httptest.NewRecorder()
+
+		if _, ok := s.readMCPRequestBody(rec, req); ok {
+			t.Fatal("Expected oversized body to be rejected")
+		}
+		if rec.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("Expected status 413, got %d", rec.Code)
+		}
+
+		var msg mcp.JSONRPCMessage
+		if err := json.Unmarshal(rec.Body.Bytes(), &msg); err != nil {
+			t.Fatalf("Expected JSON-RPC error response: %v", err)
+		}
+		if msg.Error == nil || msg.Error.Code != mcp.ErrorCodeInvalidRequest {
+			t.Errorf("Expected JSON-RPC invalid request error, got %+v", msg.Error)
+		}
+	})
+}
+
+func TestHandleVersion(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	s := &Server{config: &config.Config{}, logger: testLogger}
+
+	rec := httptest.NewRecorder()
+	s.handleVersion(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var body struct {
+		Data version.Info `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body.Data.Version != version.Version || body.Data.GoVersion == "" {
+		t.Errorf("Unexpected build information %+v", body.Data)
+	}
+}
+
+func TestHandleManifest(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	s := &Server{
+		config:     &config.Config{Transports: []string{"http", "stdio"}},
+		logger:     testLogger,
+		mcpHandler: mcp.NewHandler(nil, testLogger),
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleManifest(rec, httptest.NewRequest(http.MethodGet, "/manifest", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var body struct {
+		Data mcp.Manifest `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if strings.Join(body.Data.Transports, ",") != "http,stdio" {
+		t.Errorf("Expected both transports, got %v", body.Data.Transports)
+	}
+	if body.Data.Version.Version != version.Version || len(body.Data.Tools) == 0 || len(body.Data.Resources) == 0 {
+		t.Errorf("Unexpected manifest %+v", body.Data)
+	}
+}
+
+func TestMCPRequestContext_TokenPassthrough(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	tests := []struct {
+		name        string
+		passthrough bool
+		mcpAuth     bool
+		headers     map[string]string
+		want        string
+	}{
+		{name: "disabled", headers: map[string]string{"X-GitHub-Token": "gho_caller"}},
+		{name: "token header", passthrough: true, headers: map[string]string{"X-GitHub-Token": "gho_caller"}, want: "gho_caller"},
+		{name: "authorization header", passthrough: true, headers: map[string]string{"Authorization": "Bearer gho_caller"}, want: "gho_caller"},
+		{name: "authorization authenticates to the server", passthrough: true, mcpAuth: true,
+			headers: map[string]string{"Authorization": "Bearer mcp-token"}},
+		{name: "token header with server authentication", passthrough: true, mcpAuth: true,
+			headers: map[string]string{"Authorization": "Bearer mcp-token", "X-GitHub-Token": "gho_caller"}, want: "gho_caller"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{config: &config.Config{TokenPassthrough: tt.passthrough}, mcpHandler: mcp.NewHandler(nil, testLogger)}
+			if tt.mcpAuth {
+				s.bearerTokens = parseBearerTokens([]string{"mcp-token"})
+			}
+			req := httptest.NewRequest(http.MethodPost, "/mcp/request", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+
+			ctx, ok := s.mcpRequestContext(httptest.NewRecorder(), req, nil)
+			if !ok {
+				t.Fatal("Expected the request context to be created")
+			}
+			if token, _ := client.TokenFromContext(ctx); token != tt.want {
+				t.Errorf("Expected passthrough token %q, got %q", tt.want, token)
+			}
+		})
+	}
+}
+
+func TestHTTPSessions(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	s := &Server{
+		config:     &config.Config{MaxRequestSize: 1 << 20, TokenPassthrough: true},
+		logger:     testLogger,
+		mcpHandler: mcp.NewHandler(nil, testLogger),
+	}
+	sendAs := func(token, method, sessionID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/mcp/request", strings.NewReader(body))
+		if sessionID != "" {
+			req.Header.Set(mcp.SessionIDHeader, sessionID)
+		}
+		if token != "" {
+			req.Header.Set(githubTokenHeader, token)
+		}
+		rec := httptest.NewRecorder()
+		s.handleMCPRequest(rec, req)
+		return rec
+	}
+	send := func(method, sessionID, body string) *httptest.ResponseRecorder {
+		return sendAs("", method, sessionID, body)
+	}
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","clientInfo":{"name":"test"}}}`
+	initialized := `{"jsonrpc":"2.0","method":"initialized"}`
+	listTools := `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`
+
+	rec := send(http.MethodPost, "", initialize)
+	sessionID := rec.Header().Get(mcp.SessionIDHeader)
+	if rec.Code != http.StatusOK || sessionID == "" {
+		t.Fatalf("Expected initialize to start a session, got %d with %q", rec.Code, sessionID)
+	}
+	if rec := send(http.MethodPost, sessionID, initialized); rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected the initialized notification to be accepted, got %d", rec.Code)
+	}
+	if rec := send(http.MethodPost, sessionID, listTools); strings.Contains(rec.Body.String(), "not initialized") {
+		t.Errorf("Expected the session to be initialized, got %s", rec.Body.String())
+	}
+
+	if rec := send(http.MethodPost, "", listTools); !strings.Contains(rec.Body.String(), "not initialized") {
+		t.Errorf("Expected a request without a session not to share the initialized one, got %s", rec.Body.String())
+	}
+	other := send(http.MethodPost, "", initialize).Header().Get(mcp.SessionIDHeader)
+	if rec := send(http.MethodPost, other, listTools); !strings.Contains(rec.Body.String(), "not initialized") {
+		t.Errorf("Expected another client's session to need its own handshake, got %s", rec.Body.String())
+	}
+
+	// Sessions belong to the identity that started them
+	if rec := sendAs("gho_mallory", http.MethodPost, sessionID, listTools); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected another identity's request in the session to be rejected with 404, got %d", rec.Code)
+	}
+	if rec := sendAs("gho_mallory", http.MethodDelete, sessionID, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected another identity not to end the session, got %d", rec.Code)
+	}
+
+	// Requests without a session share one per identity, which only that
+	// identity's initialize unlocks
+	sendAs("gho_mallory", http.MethodPost, "", initialized)
+	if rec := sendAs("gho_mallory", http.MethodPost, "", listTools); !strings.Contains(rec.Body.String(), "not initialized") {
+		t.Errorf("Expected another identity's initialize not to unlock its requests without a session, got %s", rec.Body.String())
+	}
+	send(http.MethodPost, "", initialized)
+	if rec := send(http.MethodPost, "", listTools); strings.Contains(rec.Body.String(), "not initialized") {
+		t.Errorf("Expected an older client's initialized without a session to complete its handshake, got %s", rec.Body.String())
+	}
+
+	if rec := send(http.MethodDelete, sessionID, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected the session to end, got %d", rec.Code)
+	}
+	if rec := send(http.MethodPost, sessionID, listTools); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected an ended session to be rejected with 404, got %d", rec.Code)
+	}
+}
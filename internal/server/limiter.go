@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+)
+
+// requestQueueTimeout is how long a request waits for a free slot before it is rejected
+const requestQueueTimeout = 500 * time.Millisecond
+
+// retryAfterSeconds is the Retry-After hint sent with rejected requests
+const retryAfterSeconds = 1
+
+// requestLimiter caps the number of MCP requests processed at once
+type requestLimiter struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+}
+
+// newRequestLimiter creates a limiter allowing max requests in flight
+func newRequestLimiter(max int, queueTimeout time.Duration) *requestLimiter {
+	return &requestLimiter{
+		slots:        make(chan struct{}, max),
+		queueTimeout: queueTimeout,
+	}
+}
+
+// acquire takes a slot, waiting up to the queue timeout for one to free up.
+// It returns false if no slot became available or the context was cancelled.
+func (l *requestLimiter) acquire(ctx context.Context) bool {
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees a slot taken by acquire
+func (l *requestLimiter) release() {
+	<-l.slots
+}
+
+// capacity returns the maximum number of requests in flight
+func (l *requestLimiter) capacity() int {
+	return cap(l.slots)
+}
+
+// inFlight returns the number of requests currently holding a slot
+func (l *requestLimiter) inFlight() int {
+	return len(l.slots)
+}
+
+// concurrencyLimitMiddleware rejects MCP requests with 429 Too Many Requests
+// when MaxConcurrentRequests are already in flight and none finishes within
+// the queue timeout
+func (s *Server) concurrencyLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.limiter.acquire(r.Context()) {
+			s.logger.Warn("Rejecting MCP request, server is at its concurrency limit",
+				"path", r.URL.Path,
+				"remoteAddr", r.RemoteAddr,
+				"maxConcurrentRequests", s.limiter.capacity())
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+			s.writeJSONRPCError(w, http.StatusTooManyRequests, mcp.NewErrorResponse(nil, mcp.ErrorCodeServerBusy,
+				"server is busy, too many concurrent requests",
+				map[string]interface{}{
+					"max_concurrent_requests": s.limiter.capacity(),
+					"retry_after_seconds":     retryAfterSeconds,
+				}))
+			return
+		}
+		defer s.limiter.release()
+
+		next.ServeHTTP(w, r)
+	})
+}
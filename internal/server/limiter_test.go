@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/config"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+)
+
+func TestConcurrencyLimitMiddleware(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	s := &Server{
+		config:  &config.Config{MaxConcurrentRequests: 1},
+		logger:  testLogger,
+		limiter: newRequestLimiter(1, 20*time.Millisecond),
+	}
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	handler := s.concurrencyLimitMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("block") != "" {
+			close(started)
+			<-unblock
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Occupy the only slot
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/mcp/request?block=1", nil))
+	}()
+	<-started
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp/request", nil))
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("Expected Retry-After header")
+	}
+	var msg mcp.JSONRPCMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &msg); err != nil {
+		t.Fatalf("Expected JSON-RPC error response: %v", err)
+	}
+	if msg.Error == nil || msg.Error.Code != mcp.ErrorCodeServerBusy {
+		t.Errorf("Expected JSON-RPC server busy error, got %+v", msg.Error)
+	}
+
+	close(unblock)
+	<-done
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp/request", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200 once the slot is free, got %d", rec.Code)
+	}
+	if s.limiter.inFlight() != 0 {
+		t.Errorf("Expected no requests in flight, got %d", s.limiter.inFlight())
+	}
+}
+
+func TestRequestLimiterQueues(t *testing.T) {
+	limiter := newRequestLimiter(1, time.Second)
+	if !limiter.acquire(t.Context()) {
+		t.Fatal("Expected first acquire to succeed")
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		limiter.release()
+	}()
+
+	if !limiter.acquire(t.Context()) {
+		t.Error("Expected queued acquire to succeed once a slot is released")
+	}
+}
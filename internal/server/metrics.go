@@ -0,0 +1,60 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// metric is a single sample exposed on /metrics
+type metric struct {
+	name  string
+	kind  string
+	help  string
+	value int64
+}
+
+// handleMetrics serves load metrics in the Prometheus text exposition format
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeErrorResponse(w, errors.Validation("method not allowed"))
+		return
+	}
+
+	tools := s.mcpHandler.ToolCallStats()
+	metrics := []metric{
+		{"github_mcp_http_requests_in_flight", "gauge", "MCP HTTP requests currently holding a concurrency slot.", int64(s.limiter.inFlight())},
+		{"github_mcp_tool_calls_running", "gauge", "Tool calls currently executing.", tools.Running},
+		{"github_mcp_tool_calls_queued", "gauge", "Tool calls waiting for a free slot in their session.", tools.Queued},
+		{"github_mcp_tool_calls_rejected_total", "counter", "Tool calls rejected because their session queue was full.", tools.Rejected},
+		{"github_mcp_tool_calls_shed_total", "counter", "Tool calls rejected because GitHub was degraded.", tools.Shed},
+		{"github_mcp_sse_clients", "gauge", "Connected SSE clients.", int64(s.streamHandler.GetConnectedClients())},
+		{"github_mcp_sse_client_id_collisions_total", "counter", "Generated SSE client IDs discarded because they were already in use.", s.streamHandler.ClientIDCollisions()},
+	}
+	if s.githubClient != nil {
+		metrics = append(metrics, metric{"github_mcp_github_deprecated_responses_total", "counter",
+			"GitHub API responses carrying Deprecation or Sunset headers.", s.githubClient.DeprecatedResponses()},
+			metric{"github_mcp_github_cached_responses_total", "counter",
+				"GitHub GET responses served from the cache after a 304 Not Modified.", s.githubClient.CachedResponses()},
+			metric{"github_mcp_github_rate_limit_rejections_total", "counter",
+				"GitHub requests rejected because the rate limit budget was below its floor.", s.githubClient.RateLimitRejections()},
+			metric{"github_mcp_github_requests_in_flight", "gauge",
+				"GitHub requests holding one of the MAX_CONCURRENT_REQUESTS outbound slots.", int64(s.githubClient.RequestsInFlight())})
+		if state, ok := s.githubClient.RateLimitState(client.RateLimitCore); ok {
+			metrics = append(metrics, metric{"github_mcp_github_rate_limit_remaining", "gauge",
+				"Requests left in the GitHub core rate limit window.", int64(state.Remaining)})
+		}
+	}
+
+	var b strings.Builder
+	for _, m := range metrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n%s %d\n", m.name, m.help, m.name, m.kind, m.name, m.value)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(b.String()))
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+)
+
+func TestHandleMetrics(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	s := &Server{
+		logger:        testLogger,
+		mcpHandler:    mcp.NewHandler(nil, testLogger),
+		streamHandler: mcp.NewStreamHandler(testLogger),
+		limiter:       newRequestLimiter(2, time.Millisecond),
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleMetrics(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE github_mcp_tool_calls_queued gauge",
+		"github_mcp_tool_calls_queued 0",
+		"github_mcp_tool_calls_rejected_total 0",
+		"github_mcp_http_requests_in_flight 0",
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/auth"
+	"github.com/nicholasflintwillow/github-mcp/internal/config"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// protectedResourceMetadataPath is the RFC 9728 well-known metadata location
+const protectedResourceMetadataPath = "/.well-known/oauth-protected-resource"
+
+// newTokenValidator creates the OAuth access token validator, or nil when
+// OAuth is not configured
+func newTokenValidator(cfg *config.Config) *auth.TokenValidator {
+	if !cfg.OAuthEnabled() {
+		return nil
+	}
+
+	keys := auth.NewJWKSCache(cfg.OAuthJWKSURL, nil)
+	return auth.NewTokenValidator(cfg.OAuthIssuer, cfg.OAuthResource, cfg.OAuthRequiredScopes, keys)
+}
+
+// handleProtectedResourceMetadata serves the OAuth protected resource metadata
+// document so MCP clients can discover which authorization server to use
+func (s *Server) handleProtectedResourceMetadata(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeErrorResponse(w, errors.Validation("method not allowed"))
+		return
+	}
+
+	metadata := map[string]interface{}{
+		"resource":                 s.config.OAuthResource,
+		"authorization_servers":    []string{s.config.OAuthIssuer},
+		"bearer_methods_supported": []string{"header"},
+		"resource_name":            "GitHub MCP Server",
+	}
+	if len(s.config.OAuthRequiredScopes) > 0 {
+		metadata["scopes_supported"] = s.config.OAuthRequiredScopes
+	}
+
+	// The metadata document is defined by RFC 9728 and must not be wrapped
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(metadata); err != nil {
+		s.logger.Error("Failed to encode protected resource metadata", "error", err)
+	}
+}
+
+// resourceMetadataURL returns the absolute URL of the metadata document of
+// the configured resource, as placed by RFC 9728 for resources with a path
+func (s *Server) resourceMetadataURL() string {
+	base := strings.TrimSuffix(s.config.OAuthResource, "/")
+	scheme, rest, _ := strings.Cut(base, "://")
+	host, path, _ := strings.Cut(rest, "/")
+	if path != "" {
+		return scheme + "://" + host + protectedResourceMetadataPath + "/" + path
+	}
+	return scheme + "://" + host + protectedResourceMetadataPath
+}
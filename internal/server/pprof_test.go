@@ -0,0 +1,27 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPprofServer(t *testing.T) {
+	srv := newPprofServer("localhost:0")
+
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/pprof/goroutine?debug=1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "goroutine profile") {
+		t.Errorf("Expected goroutine profile, got %q", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/mcp/request", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected MCP endpoints to be absent from the pprof server, got status %d", rec.Code)
+	}
+}
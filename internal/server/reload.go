@@ -0,0 +1,74 @@
+package server
+
+import (
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/config"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// reloadableSettings are the settings Reload applies to the running server;
+// changes to any other setting take effect after a restart
+var reloadableSettings = map[string]bool{
+	"log_level":          true,
+	"cache_ttl":          true,
+	"strict_arguments":   true,
+	"execution_metadata": true,
+	"load_shedding":      true,
+}
+
+// SetConfigLoader sets how Reload reads the configuration, typically the same
+// way it was loaded at startup
+func (s *Server) SetConfigLoader(load func() (*config.Config, error)) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	s.loadConfig = load
+}
+
+// Reload reads the configuration again and applies the reloadable settings
+// without restarting listeners or dropping SSE connections. Other changed
+// settings are logged as requiring a restart.
+func (s *Server) Reload() error {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	if s.loadConfig == nil {
+		return errors.Internal("configuration reload is not available")
+	}
+
+	cfg, err := s.loadConfig()
+	if err != nil {
+		return errors.Wrap(err, errors.ErrorTypeValidation, "failed to reload configuration")
+	}
+	if err := cfg.Validate(); err != nil {
+		return errors.Wrap(err, errors.ErrorTypeValidation, "invalid configuration")
+	}
+
+	var applied, restart []string
+	for _, name := range s.applied.Changed(cfg) {
+		if reloadableSettings[name] {
+			applied = append(applied, name)
+		}
+	}
+	for _, name := range s.config.Changed(cfg) {
+		if !reloadableSettings[name] {
+			restart = append(restart, name)
+		}
+	}
+
+	if err := s.logger.SetLevel(cfg.LogLevel); err != nil {
+		return errors.Wrap(err, errors.ErrorTypeValidation, "invalid configuration")
+	}
+	s.mcpHandler.SetCacheTTL(time.Duration(cfg.CacheTTL) * time.Second)
+	s.mcpHandler.SetStrictArguments(cfg.StrictArguments)
+	s.mcpHandler.SetExecutionMetadata(cfg.ExecutionMetadata)
+	s.mcpHandler.SetLoadShedding(cfg.LoadShedding)
+	s.applied = cfg
+
+	s.logger.Info("Configuration reloaded", "changed", applied)
+	if len(restart) > 0 {
+		s.logger.Warn("Configuration changes take effect after a restart", "settings", restart)
+	}
+
+	return nil
+}
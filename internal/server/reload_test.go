@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/config"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+)
+
+func TestReload(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	startup := &config.Config{
+		GitHubToken:           "test-token",
+		Port:                  8080,
+		LogLevel:              "ERROR",
+		LogFormat:             "json",
+		CacheTTL:              60,
+		MaxConcurrentRequests: 100,
+	}
+	s := &Server{
+		config:     startup,
+		applied:    startup,
+		logger:     testLogger,
+		mcpHandler: mcp.NewHandler(nil, testLogger),
+	}
+
+	if err := s.Reload(); err == nil {
+		t.Fatal("Expected reload without a config loader to fail")
+	}
+
+	next := *startup
+	next.LogLevel = "DEBUG"
+	next.CacheTTL = 5
+	next.StrictArguments = true
+	next.Port = 9090
+	s.SetConfigLoader(func() (*config.Config, error) { return &next, nil })
+
+	if err := s.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if !testLogger.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected log level to be lowered to DEBUG")
+	}
+	if ttl := s.mcpHandler.CacheTTL(); ttl != 5*time.Second {
+		t.Errorf("Expected cache TTL of 5s, got %s", ttl)
+	}
+	if s.applied != &next {
+		t.Error("Expected reloaded configuration to be recorded")
+	}
+
+	// An invalid configuration leaves the running settings untouched
+	s.SetConfigLoader(func() (*config.Config, error) { return nil, fmt.Errorf("bad file") })
+	if err := s.Reload(); err == nil {
+		t.Fatal("Expected reload with a failing loader to fail")
+	}
+	if ttl := s.mcpHandler.CacheTTL(); ttl != 5*time.Second {
+		t.Errorf("Expected cache TTL to be unchanged, got %s", ttl)
+	}
+}
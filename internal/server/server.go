@@ -3,25 +3,60 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"sync"
 	"time"
 
+	"github.com/nicholasflintwillow/github-mcp/internal/auth"
 	"github.com/nicholasflintwillow/github-mcp/internal/client"
 	"github.com/nicholasflintwillow/github-mcp/internal/config"
 	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/lifecycle"
 	"github.com/nicholasflintwillow/github-mcp/internal/logger"
 	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+	"github.com/nicholasflintwillow/github-mcp/internal/pubsub"
+	"github.com/nicholasflintwillow/github-mcp/internal/redis"
+	"github.com/nicholasflintwillow/github-mcp/internal/requestid"
 )
 
 // Server represents the HTTP server
 type Server struct {
-	config        *config.Config
-	logger        *logger.Logger
-	httpServer    *http.Server
-	mux           *http.ServeMux
-	githubClient  *client.GitHubClient
-	mcpHandler    *mcp.Handler
-	streamHandler *mcp.StreamHandler
+	config         *config.Config
+	logger         *logger.Logger
+	httpServer     *http.Server
+	mux            *http.ServeMux
+	githubClient   *client.GitHubClient
+	mcpHandler     *mcp.Handler
+	streamHandler  *mcp.StreamHandler
+	bearerTokens   []bearerToken
+	adminTokens    []bearerToken
+	tokenValidator *auth.TokenValidator
+	limiter        *requestLimiter
+	pprofServer    *http.Server
+
+	// stdio serves a parent MCP host alongside the HTTP endpoints, sharing the MCP handler
+	stdio       *mcp.StdioTransport
+	stdioCtx    context.Context
+	stdioCancel context.CancelFunc
+
+	// loadConfig reads the configuration again for Reload; applied is the
+	// configuration most recently applied
+	reloadMu   sync.Mutex
+	loadConfig func() (*config.Config, error)
+	applied    *config.Config
+}
+
+// GitHubTransport returns how connections to GitHub are made as configured
+// by cfg
+func GitHubTransport(cfg *config.Config) client.TransportConfig {
+	return client.TransportConfig{
+		ProxyURL:           cfg.GitHubProxyURL,
+		NoProxy:            cfg.GitHubNoProxy,
+		CAFile:             cfg.GitHubCAFile,
+		InsecureSkipVerify: cfg.GitHubTLSInsecureSkipVerify,
+	}
 }
 
 // New creates a new server instance
@@ -30,8 +65,71 @@ func New(cfg *config.Config, log *logger.Logger) (*Server, error) {
 		return nil, errors.Wrap(err, errors.ErrorTypeValidation, "invalid configuration")
 	}
 
+	if cfg.MaxRequestSize == 0 {
+		cfg.MaxRequestSize = config.DefaultMaxRequestSize
+	}
+
 	// Create GitHub client
 	githubClient := client.NewGitHubClient(cfg.GitHubToken, log)
+	if err := githubClient.SetTransport(GitHubTransport(cfg)); err != nil {
+		return nil, err
+	}
+	if cfg.GitHubTLSInsecureSkipVerify {
+		log.Warn("GitHub TLS certificate verification is disabled")
+	}
+	if pool := cfg.TokenPool(); len(pool) > 1 && cfg.GitHubAppID == 0 {
+		if err := githubClient.SetTokenPool(pool); err != nil {
+			return nil, err
+		}
+		log.Info("Rotating requests between GitHub tokens", "tokens", len(pool))
+	}
+	if cfg.GitHubTokenFile != "" {
+		if err := githubClient.SetTokenFile(cfg.GitHubTokenFile, 0); err != nil {
+			return nil, err
+		}
+		log.Info("Reading the GitHub token from its file", "path", cfg.GitHubTokenFile)
+	}
+	if cfg.GitHubAppID != 0 {
+		if err := githubClient.SetAppAuth(client.AppAuthConfig{
+			AppID:          cfg.GitHubAppID,
+			PrivateKey:     []byte(cfg.GitHubAppPrivateKey),
+			PrivateKeyFile: cfg.GitHubAppPrivateKeyFile,
+			InstallationID: cfg.GitHubAppInstallationID,
+		}); err != nil {
+			return nil, err
+		}
+		log.Info("Authenticating as a GitHub App", "app_id", cfg.GitHubAppID, "installation_id", cfg.GitHubAppInstallationID)
+	}
+	if cfg.GitHubAPIVersion != "" {
+		githubClient.SetAPIVersion(cfg.GitHubAPIVersion)
+	}
+	githubClient.SetMediaTypes(cfg.GitHubMediaTypes)
+	githubClient.SetResponseCache(cfg.ResponseCacheSize)
+	if cfg.SharedStoreURL != "" {
+		store, err := redis.NewStore(cfg.SharedStoreURL, cfg.SharedStorePrefix)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrorTypeValidation, "invalid shared store URL")
+		}
+		githubClient.SetSharedStore(store)
+		log.Info("Sharing the GitHub response cache and rate limit budget over Redis", "prefix", cfg.SharedStorePrefix)
+	}
+	retry := client.DefaultRetryPolicy()
+	retry.MaxAttempts = cfg.RetryMaxAttempts
+	retry.MaxDelay = time.Duration(cfg.RetryMaxDelay) * time.Second
+	githubClient.SetRetryPolicy(retry)
+	githubClient.SetTimeout(time.Duration(cfg.GitHubTimeout) * time.Second)
+	githubClient.SetMaxConcurrency(cfg.MaxConcurrentRequests)
+	githubClient.SetCallLogSampleRate(float64(cfg.GitHubAPILogSamplePercent) / 100)
+	githubClient.SetRateLimitPolicy(client.RateLimitPolicy{
+		Floor:   cfg.RateLimitFloor,
+		MaxWait: time.Duration(cfg.RateLimitMaxWait) * time.Second,
+	})
+	githubClient.SetAccessPolicy(client.AccessPolicy{
+		AllowedOwners: cfg.AllowedOrgs,
+		AllowedRepos:  cfg.AllowedRepos,
+		DeniedOwners:  cfg.DeniedOrgs,
+		DeniedRepos:   cfg.DeniedRepos,
+	})
 
 	// Validate GitHub token
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -43,31 +141,91 @@ func New(cfg *config.Config, log *logger.Logger) (*Server, error) {
 	}
 	log.Info("GitHub Personal Access Token validated successfully")
 
+	if cfg.GitHubAppCommitAsBot {
+		app, err := githubClient.GetAuthenticatedApp(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrorTypeAuthentication, "failed to get the GitHub App")
+		}
+		identity, err := githubClient.GetAppBotCommitIdentity(ctx, app.Slug)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrorTypeAuthentication, "failed to get the GitHub App bot user")
+		}
+		githubClient.SetCommitAuthor(identity)
+		log.Info("Authoring commits as the GitHub App bot user", "name", identity.Name, "email", identity.Email)
+	}
+
 	// Create MCP handler
 	mcpHandler := mcp.NewHandler(githubClient, log)
+	if err := ConfigureHandler(mcpHandler, cfg); err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeValidation, "invalid configuration")
+	}
 
 	// Create stream handler
 	streamHandler := mcp.NewStreamHandler(log)
+	streamHandler.SetReplayBufferSize(cfg.SSEReplayBufferSize)
+	streamHandler.SetHeartbeat(time.Duration(cfg.SSEHeartbeatInterval)*time.Second, cfg.SSEHeartbeatFormat)
+	if cfg.PubSubURL != "" {
+		broker, err := pubsub.NewRedis(cfg.PubSubURL, cfg.PubSubChannel, log)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrorTypeValidation, "invalid pub/sub URL")
+		}
+		streamHandler.SetBroker(broker)
+		log.Info("Stream events fan out over Redis pub/sub", "channel", cfg.PubSubChannel)
+	}
 
 	// Connect MCP handler with the streamer
 	mcpHandler.SetStreamer(streamHandler.GetStreamer())
+	streamHandler.OnClientClosed(mcpHandler.StreamClosed)
 
 	s := &Server{
-		config:        cfg,
-		logger:        log,
-		mux:           http.NewServeMux(),
-		githubClient:  githubClient,
-		mcpHandler:    mcpHandler,
-		streamHandler: streamHandler,
+		config:         cfg,
+		logger:         log,
+		mux:            http.NewServeMux(),
+		githubClient:   githubClient,
+		mcpHandler:     mcpHandler,
+		streamHandler:  streamHandler,
+		bearerTokens:   parseBearerTokens(cfg.MCPAuthTokens),
+		adminTokens:    parseBearerTokens(cfg.AdminTokens),
+		tokenValidator: newTokenValidator(cfg),
+		limiter:        newRequestLimiter(cfg.MaxConcurrentRequests, requestQueueTimeout),
+		applied:        cfg,
+	}
+
+	if len(s.bearerTokens) > 0 {
+		log.Info("Bearer token authentication enabled for MCP endpoints", "tokens", len(s.bearerTokens))
+	}
+	if len(s.adminTokens) > 0 {
+		log.Info("Admin API enabled", "tokens", len(s.adminTokens))
+	}
+	if s.tokenValidator != nil {
+		log.Info("OAuth access token validation enabled for MCP endpoints",
+			"issuer", cfg.OAuthIssuer,
+			"jwks_url", cfg.OAuthJWKSURL)
+	}
+
+	if cfg.PprofAddr != "" {
+		s.pprofServer = newPprofServer(cfg.PprofAddr)
+	}
+
+	if cfg.StdioEnabled() {
+		s.stdio = mcp.NewStdioTransport(mcpHandler, log, os.Stdin, os.Stdout)
+		s.stdioCtx, s.stdioCancel = context.WithCancel(context.Background())
 	}
 
 	// Setup routes
 	s.setupRoutes()
 
+	// Build TLS configuration (including optional client certificate verification)
+	tlsConfig, err := buildTLSConfig(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create HTTP server
 	s.httpServer = &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
 		Handler:      s.middlewareChain(s.mux),
+		TLSConfig:    tlsConfig,
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  120 * time.Second,
@@ -76,32 +234,223 @@ func New(cfg *config.Config, log *logger.Logger) (*Server, error) {
 	return s, nil
 }
 
-// Start starts the HTTP server
-func (s *Server) Start() error {
-	// Start the stream handler
-	s.streamHandler.Start()
-
-	s.logger.Info("Starting HTTP server", "address", s.httpServer.Addr)
-
-	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to start HTTP server")
+// ConfigureHandler applies the tool, naming and result settings of cfg to an
+// MCP handler, so commands describing the server list the tools it serves
+func ConfigureHandler(mcpHandler *mcp.Handler, cfg *config.Config) error {
+	mcpHandler.SetStrictArguments(cfg.StrictArguments)
+	mcpHandler.SetExecutionMetadata(cfg.ExecutionMetadata)
+	mcpHandler.SetLoadShedding(cfg.LoadShedding)
+	mcpHandler.SetSafeDelete(cfg.SafeDelete, cfg.DeletionLogFile)
+	mcpHandler.SetDryRun(cfg.DryRun)
+	mcpHandler.SetReadOnly(cfg.ReadOnly)
+	mcpHandler.SetConfirmDestructive(cfg.ConfirmDestructive)
+	mcpHandler.SetSubscriptionInterval(time.Duration(cfg.SubscriptionInterval) * time.Second)
+	mcpHandler.SetListPageSize(cfg.ListPageSize)
+	if cfg.PolicyFile != "" {
+		policy, err := mcp.LoadToolPolicy(cfg.PolicyFile)
+		if err != nil {
+			return err
+		}
+		mcpHandler.SetToolPolicy(policy)
+	}
+	if err := mcpHandler.SetAuditLog(cfg.AuditLog); err != nil {
+		return err
+	}
+	if err := mcpHandler.SetAPIAllowlist(cfg.APIAllowlist); err != nil {
+		return err
+	}
+	toolTimeouts := make(map[string]time.Duration, len(cfg.ToolTimeouts))
+	for name, seconds := range cfg.ToolTimeouts {
+		toolTimeouts[name] = time.Duration(seconds) * time.Second
+	}
+	if err := mcpHandler.SetToolTimeouts(time.Duration(cfg.ToolTimeout)*time.Second, toolTimeouts); err != nil {
+		return err
+	}
+	mcpHandler.SetCacheTTL(time.Duration(cfg.CacheTTL) * time.Second)
+	mcpHandler.SetSessionToolLimits(cfg.SessionMaxConcurrentTools, cfg.SessionMaxQueuedTools)
+	mcpHandler.SetSessionIdleTimeout(time.Duration(cfg.SessionIdleTimeout) * time.Second)
+	mcpHandler.SetMaxSessions(cfg.MaxSessions)
+	mcpHandler.SetRateLimitWarnings(cfg.RateLimitWarnings)
+	mcpHandler.SetMaxResultSize(cfg.MaxResultSize)
+	if cfg.FetchAllMaxPages > 0 {
+		mcpHandler.SetFetchAllMaxPages(cfg.FetchAllMaxPages)
+	}
+	if err := mcpHandler.SetToolNames(cfg.ToolPrefix, cfg.ToolAliases); err != nil {
+		return err
+	}
+	if err := mcpHandler.SetServerInfo(cfg.ServerName, cfg.ServerInstructions); err != nil {
+		return err
+	}
+	mcpHandler.SetDefaultContext(cfg.DefaultOrg, cfg.DefaultRepo)
+	if err := mcpHandler.SetLocale(cfg.Locale); err != nil {
+		return err
 	}
-
 	return nil
 }
 
-// Shutdown gracefully shuts down the server
-func (s *Server) Shutdown(ctx context.Context) error {
-	s.logger.Info("Shutting down HTTP server")
+// Manifest describes the capabilities of the server
+func (s *Server) Manifest() mcp.Manifest {
+	return s.mcpHandler.Manifest(s.config.EnabledTransports())
+}
 
-	// Stop the stream handler
-	s.streamHandler.Stop()
+// UseToolMiddleware adds middleware around the execution of every tool call,
+// such as checks or transformations of an embedding program
+func (s *Server) UseToolMiddleware(middleware ...mcp.ToolMiddleware) {
+	s.mcpHandler.UseToolMiddleware(middleware...)
+}
 
-	if err := s.httpServer.Shutdown(ctx); err != nil {
-		return errors.Wrap(err, errors.ErrorTypeInternal, "failed to shutdown HTTP server")
+// Register adds the server's subsystems to the lifecycle manager. They start
+// in dependency order and stop in reverse: the listeners stop accepting new
+// work before the stream handler and background jobs they rely on shut down.
+func (s *Server) Register(m *lifecycle.Manager) {
+	m.Append(lifecycle.Hook{
+		Name: "shared store",
+		OnStop: func(ctx context.Context) error {
+			return s.githubClient.CloseSharedStore(ctx)
+		},
+	})
+	m.Append(lifecycle.Hook{
+		Name: "audit log",
+		OnStart: func(ctx context.Context) error {
+			s.mcpHandler.StartAuditLog()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			s.mcpHandler.StopAuditLog()
+			return nil
+		},
+	})
+
+	m.Append(lifecycle.Hook{
+		Name: "stream handler",
+		OnStart: func(ctx context.Context) error {
+			s.streamHandler.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			s.streamHandler.Stop()
+			return nil
+		},
+	})
+
+	m.Append(lifecycle.Hook{
+		Name: "session expiry",
+		OnStart: func(ctx context.Context) error {
+			s.mcpHandler.StartSessionExpiry()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			s.mcpHandler.StopSessionExpiry()
+			return nil
+		},
+	})
+
+	m.Append(lifecycle.Hook{
+		Name: "resource poller",
+		OnStart: func(ctx context.Context) error {
+			s.mcpHandler.StartResourcePoller()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			s.mcpHandler.StopResourcePoller()
+			return nil
+		},
+	})
+
+	m.Append(lifecycle.Hook{
+		Name: "prefetcher",
+		OnStart: func(ctx context.Context) error {
+			s.mcpHandler.StartPrefetcher(time.Duration(s.config.PrefetchInterval) * time.Second)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			s.mcpHandler.StopPrefetcher()
+			return nil
+		},
+	})
+
+	if s.pprofServer != nil {
+		m.Append(lifecycle.Hook{
+			Name: "pprof server",
+			OnStart: func(ctx context.Context) error {
+				listener, err := net.Listen("tcp", s.pprofServer.Addr)
+				if err != nil {
+					return err
+				}
+				s.logger.Warn("pprof debug endpoints enabled", "address", listener.Addr().String())
+				m.Go("pprof server", func() error {
+					return ignoreServerClosed(s.pprofServer.Serve(listener))
+				})
+				return nil
+			},
+			OnStop:      s.pprofServer.Shutdown,
+			StopTimeout: 5 * time.Second,
+		})
 	}
 
-	return nil
+	if s.stdio != nil {
+		m.Append(lifecycle.Hook{
+			Name: "stdio transport",
+			OnStart: func(ctx context.Context) error {
+				s.logger.Info("Serving MCP on stdio")
+				m.Go("stdio transport", func() error {
+					if err := s.stdio.Serve(s.stdioCtx); err != nil && err != context.Canceled {
+						return err
+					}
+					return nil
+				})
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				s.stdioCancel()
+				return nil
+			},
+		})
+	}
+
+	if s.config.HTTPEnabled() {
+		m.Append(lifecycle.Hook{
+			Name: "http server",
+			OnStart: func(ctx context.Context) error {
+				listener, err := net.Listen("tcp", s.httpServer.Addr)
+				if err != nil {
+					return errors.Wrap(err, errors.ErrorTypeInternal, "failed to start HTTP server")
+				}
+
+				if s.config.TLSEnabled() {
+					s.logger.Info("Starting HTTPS server",
+						"address", s.httpServer.Addr,
+						"client_auth", s.config.TLSClientCAFile != "")
+					m.Go("http server", func() error {
+						return ignoreServerClosed(s.httpServer.ServeTLS(listener, s.config.TLSCertFile, s.config.TLSKeyFile))
+					})
+				} else {
+					s.logger.Info("Starting HTTP server", "address", s.httpServer.Addr)
+					m.Go("http server", func() error {
+						return ignoreServerClosed(s.httpServer.Serve(listener))
+					})
+				}
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				s.drainStreams(ctx)
+				s.logger.Info("Shutting down HTTP server")
+				if err := s.httpServer.Shutdown(ctx); err != nil {
+					return errors.Wrap(err, errors.ErrorTypeInternal, "failed to shutdown HTTP server")
+				}
+				return nil
+			},
+			StopTimeout: 20 * time.Second,
+		})
+	}
+}
+
+// ignoreServerClosed treats the error returned after a graceful shutdown as success
+func ignoreServerClosed(err error) error {
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
 }
 
 // setupRoutes configures the HTTP routes
@@ -112,12 +461,31 @@ func (s *Server) setupRoutes() {
 	// Ready check endpoint
 	s.mux.HandleFunc("/ready", s.handleReady)
 
-	// MCP endpoints
-	s.mux.HandleFunc("/mcp/request", s.handleMCPRequest)
+	// Build information endpoint
+	s.mux.HandleFunc("/version", s.handleVersion)
+
+	// Capability manifest endpoint
+	s.mux.HandleFunc("/manifest", s.handleManifest)
+
+	// Load metrics endpoint
+	s.mux.HandleFunc("/metrics", s.handleMetrics)
+
+	// OAuth protected resource metadata (RFC 9728)
+	if s.tokenValidator != nil {
+		s.mux.HandleFunc(protectedResourceMetadataPath, s.handleProtectedResourceMetadata)
+		s.mux.HandleFunc(protectedResourceMetadataPath+"/", s.handleProtectedResourceMetadata)
+	}
+
+	// MCP endpoints; long-lived streams are not counted against the request limit
+	s.mux.Handle("/mcp/request", s.loadSheddingMiddleware(s.concurrencyLimitMiddleware(http.HandlerFunc(s.handleMCPRequest))))
 	s.mux.HandleFunc("/mcp/stream", s.handleMCPStream)
+	s.mux.HandleFunc("/mcp/stream/subscribe", s.handleMCPStreamSubscribe)
 
 	// Legacy MCP endpoint (for backward compatibility)
-	s.mux.HandleFunc("/mcp/", s.handleMCP)
+	s.mux.Handle("/mcp/", s.loadSheddingMiddleware(s.concurrencyLimitMiddleware(http.HandlerFunc(s.handleMCP))))
+
+	// Admin API, enabled by admin tokens
+	s.setupAdminRoutes()
 
 	// Catch-all for undefined routes
 	s.mux.HandleFunc("/", s.handleNotFound)
@@ -125,13 +493,37 @@ func (s *Server) setupRoutes() {
 
 // middlewareChain applies middleware to the handler
 func (s *Server) middlewareChain(next http.Handler) http.Handler {
-	return s.loggingMiddleware(
-		s.recoveryMiddleware(
-			s.corsMiddleware(next),
+	return s.requestIDMiddleware(
+		s.loggingMiddleware(
+			s.tracingMiddleware(
+				s.recoveryMiddleware(
+					s.clientCertMiddleware(
+						s.corsMiddleware(
+							s.bearerAuthMiddleware(
+								s.compressionMiddleware(next),
+							),
+						),
+					),
+				),
+			),
 		),
 	)
 }
 
+// requestIDMiddleware accepts the caller's X-Request-ID or generates one,
+// echoes it in the response and stores it in the request context
+func (s *Server) requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		w.Header().Set(requestid.Header, id)
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
+
 // loggingMiddleware logs HTTP requests
 func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -145,7 +537,7 @@ func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
 
 		// Log request
 		duration := time.Since(start)
-		s.logger.LogRequest(
+		s.logger.WithContext(r.Context()).LogRequest(
 			r.Method,
 			r.URL.Path,
 			r.UserAgent(),
@@ -161,7 +553,7 @@ func (s *Server) recoveryMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				s.logger.Error("Panic recovered", "error", err, "path", r.URL.Path)
+				s.logger.WithContext(r.Context()).Error("Panic recovered", "error", err, "path", r.URL.Path)
 				s.writeErrorResponse(w, errors.Internal("internal server error"))
 			}
 		}()
@@ -175,7 +567,8 @@ func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Last-Event-ID, X-Request-ID, X-GitHub-Api-Version, X-GitHub-Token, Mcp-Session-Id")
+		w.Header().Set("Access-Control-Expose-Headers", "WWW-Authenticate, X-Request-ID, Mcp-Session-Id")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -196,3 +589,15 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Flush forwards to the underlying writer so streaming responses keep working
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
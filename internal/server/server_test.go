@@ -0,0 +1,60 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/requestid"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	s := &Server{logger: testLogger}
+
+	var contextID string
+	handler := s.requestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contextID = requestid.FromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name     string
+		header   string
+		expectID string
+	}{
+		{name: "generated when missing"},
+		{name: "accepted from client", header: "client-req-42", expectID: "client-req-42"},
+		{name: "replaced when invalid", header: "bad id\n"},
+		{name: "replaced when too long", header: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/mcp/request", nil)
+			if tt.header != "" {
+				req.Header.Set(requestid.Header, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			responseID := rec.Header().Get(requestid.Header)
+			if responseID == "" {
+				t.Fatal("Expected X-Request-ID response header")
+			}
+			if responseID != contextID {
+				t.Errorf("Expected context ID %q to match response header %q", contextID, responseID)
+			}
+			if tt.expectID != "" && responseID != tt.expectID {
+				t.Errorf("Expected request ID %q, got %q", tt.expectID, responseID)
+			}
+			if tt.expectID == "" && responseID == tt.header {
+				t.Errorf("Expected invalid request ID %q to be replaced", tt.header)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+)
+
+// loadSheddingMiddleware rejects tool calls that cannot be served from cache
+// with 503 Service Unavailable and a Retry-After header while GitHub is
+// degraded, before they take a request slot and queue until they time out
+func (s *Server) loadSheddingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || s.githubClient == nil || !s.githubClient.Health().Degraded {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Peek at the message and hand the full body on to the next handler
+		peeked, err := io.ReadAll(io.LimitReader(r.Body, s.config.MaxRequestSize+1))
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(peeked), r.Body), r.Body}
+		if err != nil || int64(len(peeked)) > s.config.MaxRequestSize {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		msg, err := mcp.FromJSON(peeked)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		shed := s.mcpHandler.ShedToolCall(msg)
+		if shed == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if data, ok := shed.Error.Data.(map[string]interface{}); ok {
+			if seconds, ok := data["retry_after_seconds"].(int); ok {
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			}
+		}
+		s.logger.WithContext(r.Context()).Warn("Shedding tool call, GitHub is degraded",
+			"path", r.URL.Path,
+			"remoteAddr", r.RemoteAddr)
+		s.writeJSONRPCError(w, http.StatusServiceUnavailable, shed)
+	})
+}
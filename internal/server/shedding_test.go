@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/config"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/internal/mcp"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestLoadSheddingMiddleware(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mocks.MockErrorResponse(500, "Internal Server Error"), nil
+		},
+	})
+	for i := 0; i < 5; i++ {
+		githubClient.Get(context.Background(), "/user", nil)
+	}
+
+	mcpHandler := mcp.NewHandler(githubClient, testLogger)
+	mcpHandler.SetLoadShedding(true)
+	s := &Server{
+		config:       &config.Config{MaxRequestSize: config.DefaultMaxRequestSize},
+		logger:       testLogger,
+		githubClient: githubClient,
+		mcpHandler:   mcpHandler,
+	}
+
+	var passedBody string
+	handler := s.loadSheddingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		passedBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	toolCall := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_user","arguments":{"username":"octocat"}}}`
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp/request", strings.NewReader(toolCall)))
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("Expected 503 with Retry-After, got %d %v", rec.Code, rec.Header())
+	}
+	var msg mcp.JSONRPCMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &msg); err != nil {
+		t.Fatalf("Expected JSON-RPC error response: %v", err)
+	}
+	if msg.Error == nil || msg.Error.Code != mcp.ErrorCodeDegraded {
+		t.Errorf("Expected JSON-RPC degraded error, got %+v", msg.Error)
+	}
+
+	// Other messages pass through with their body intact
+	list := `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp/request", strings.NewReader(list)))
+	if rec.Code != http.StatusOK || passedBody != list {
+		t.Errorf("Expected tools/list to pass through unchanged, got %d %q", rec.Code, passedBody)
+	}
+}
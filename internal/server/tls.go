@@ -0,0 +1,90 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"os"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/auth"
+	"github.com/nicholasflintwillow/github-mcp/internal/config"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+)
+
+// buildTLSConfig creates the TLS configuration for the HTTP server. It returns
+// nil when TLS is not configured. When a client CA bundle is configured, every
+// connection must present a certificate signed by one of those CAs.
+func buildTLSConfig(cfg *config.Config, log *logger.Logger) (*tls.Config, error) {
+	if !cfg.TLSEnabled() {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cfg.TLSClientCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	caBundle, err := os.ReadFile(cfg.TLSClientCAFile)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeValidation, "failed to read TLS client CA bundle")
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caBundle) {
+		return nil, errors.Validation("TLS client CA bundle contains no valid certificates").
+			WithContext("file", cfg.TLSClientCAFile)
+	}
+
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+		if identity := identityFromTLSState(&state); identity != nil {
+			log.Info("TLS client authenticated",
+				"subject", identity.Subject,
+				"serial", identity.Attributes["serial"],
+				"issuer", identity.Attributes["issuer"])
+		}
+		return nil
+	}
+
+	return tlsConfig, nil
+}
+
+// identityFromTLSState builds an identity from the verified client certificate
+func identityFromTLSState(state *tls.ConnectionState) *auth.Identity {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	cert := state.VerifiedChains[0][0]
+
+	subject := cert.Subject.CommonName
+	if subject == "" {
+		subject = cert.Subject.String()
+	}
+
+	return &auth.Identity{
+		Subject: subject,
+		Method:  auth.MethodMTLS,
+		Attributes: map[string]string{
+			"serial": hex.EncodeToString(cert.SerialNumber.Bytes()),
+			"issuer": cert.Issuer.String(),
+		},
+	}
+}
+
+// clientCertMiddleware attaches the verified client certificate identity to the request context
+func (s *Server) clientCertMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if identity := identityFromTLSState(r.TLS); identity != nil {
+			r = r.WithContext(auth.WithIdentity(r.Context(), identity))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
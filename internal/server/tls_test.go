@@ -0,0 +1,144 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/auth"
+	"github.com/nicholasflintwillow/github-mcp/internal/config"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+)
+
+// newTestCertificate creates a certificate for commonName, self-signed when
+// parent is nil
+func newTestCertificate(t *testing.T, commonName string, serial int64, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestBuildTLSConfig(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	dir := t.TempDir()
+	_, _, caPEM := newTestCertificate(t, "Test CA", 1, nil, nil)
+	caFile := filepath.Join(dir, "clients.pem")
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalidFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if tlsConfig, err := buildTLSConfig(&config.Config{}, testLogger); err != nil || tlsConfig != nil {
+		t.Errorf("Expected no TLS configuration without a certificate, got %+v (%v)", tlsConfig, err)
+	}
+
+	tlsConfig, err := buildTLSConfig(&config.Config{TLSCertFile: "server.pem", TLSKeyFile: "server.key"}, testLogger)
+	if err != nil || tlsConfig.ClientAuth != tls.NoClientCert || tlsConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("Expected TLS 1.2 without client certificates, got %+v (%v)", tlsConfig, err)
+	}
+
+	tlsConfig, err = buildTLSConfig(&config.Config{TLSCertFile: "server.pem", TLSKeyFile: "server.key", TLSClientCAFile: caFile}, testLogger)
+	if err != nil || tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert || tlsConfig.ClientCAs == nil {
+		t.Errorf("Expected client certificates to be required, got %+v (%v)", tlsConfig, err)
+	}
+
+	for _, file := range []string{invalidFile, filepath.Join(dir, "missing.pem")} {
+		if _, err := buildTLSConfig(&config.Config{TLSCertFile: "server.pem", TLSKeyFile: "server.key", TLSClientCAFile: file}, testLogger); err == nil {
+			t.Errorf("Expected the client CA bundle %s to be rejected", filepath.Base(file))
+		}
+	}
+}
+
+func TestClientCertMiddleware(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	ca, caKey, caPEM := newTestCertificate(t, "Test CA", 1, nil, nil)
+	clientCert, clientKey, _ := newTestCertificate(t, "deploy-bot", 42, ca, caKey)
+	caFile := filepath.Join(t.TempDir(), "clients.pem")
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tlsConfig, err := buildTLSConfig(&config.Config{TLSCertFile: "server.pem", TLSKeyFile: "server.key", TLSClientCAFile: caFile}, testLogger)
+	if err != nil {
+		t.Fatalf("buildTLSConfig failed: %v", err)
+	}
+	s := &Server{logger: testLogger}
+
+	identities := make(chan *auth.Identity, 1)
+	server := httptest.NewUnstartedServer(s.clientCertMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, _ := auth.IdentityFromContext(r.Context())
+		identities <- identity
+	})))
+	server.TLS = tlsConfig
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs}}}
+	}
+
+	resp, err := newClient(tls.Certificate{Certificate: [][]byte{clientCert.Raw}, PrivateKey: clientKey}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected a client certificate signed by the CA to be accepted: %v", err)
+	}
+	resp.Body.Close()
+	identity := <-identities
+	if identity == nil || identity.Subject != "deploy-bot" || identity.Method != auth.MethodMTLS || identity.Attributes["serial"] != "2a" {
+		t.Errorf("Expected the client certificate identity, got %+v", identity)
+	}
+
+	if resp, err := newClient().Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Error("Expected a connection without a client certificate to be refused")
+	}
+}
@@ -0,0 +1,58 @@
+// Package version describes the running build. Version, Commit and BuildDate
+// are set at build time, e.g.
+//
+//	go build -ldflags "-X github.com/nicholasflintwillow/github-mcp/internal/version.Version=v1.2.3"
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Build information injected with -ldflags -X
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildDate string `json:"buildDate,omitempty"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get returns the build information. Values not injected at build time are
+// filled in from the module and VCS information embedded by the Go toolchain
+// where available.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && build.Main.Version != "" && build.Main.Version != "(devel)" {
+		info.Version = build.Main.Version
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			if info.BuildDate == "" {
+				info.BuildDate = setting.Value
+			}
+		}
+	}
+	return info
+}
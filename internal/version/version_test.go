@@ -0,0 +1,22 @@
+package version
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestGet_InjectedValues(t *testing.T) {
+	defer func(version, commit, date string) {
+		Version, Commit, BuildDate = version, commit, date
+	}(Version, Commit, BuildDate)
+
+	Version, Commit, BuildDate = "v1.2.3", "abc123", "2024-01-02T03:04:05Z"
+
+	info := Get()
+	if info.Version != "v1.2.3" || info.Commit != "abc123" || info.BuildDate != "2024-01-02T03:04:05Z" {
+		t.Errorf("Expected injected values, got %+v", info)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("Expected Go version %s, got %s", runtime.Version(), info.GoVersion)
+	}
+}
@@ -0,0 +1,232 @@
+// Package webhook decodes GitHub webhook payloads into typed events
+package webhook
+
+import (
+	"encoding/json"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+)
+
+// EventHeader is the HTTP header naming the webhook event type
+const EventHeader = "X-GitHub-Event"
+
+// Event is a decoded webhook payload. Consumers switch on the concrete type
+// to format notifications.
+type Event interface {
+	// EventType returns the X-GitHub-Event value of the payload
+	EventType() string
+	// Common returns the fields shared by all event payloads
+	Common() *CommonFields
+}
+
+// CommonFields are the fields present on most webhook payloads
+type CommonFields struct {
+	Action       string               `json:"action,omitempty"`
+	Repository   *client.Repository   `json:"repository,omitempty"`
+	Organization *client.Organization `json:"organization,omitempty"`
+	Sender       *client.User         `json:"sender,omitempty"`
+	Installation *struct {
+		ID     int64  `json:"id"`
+		NodeID string `json:"node_id"`
+	} `json:"installation,omitempty"`
+}
+
+// Common returns the fields shared by all event payloads
+func (c *CommonFields) Common() *CommonFields {
+	return c
+}
+
+// PingEvent is sent when a webhook is created
+type PingEvent struct {
+	CommonFields
+	Zen    string `json:"zen"`
+	HookID int64  `json:"hook_id"`
+}
+
+// EventType returns "ping"
+func (e *PingEvent) EventType() string { return "ping" }
+
+// CommitAuthor identifies the author or committer of a pushed commit
+type CommitAuthor struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username,omitempty"`
+}
+
+// PushCommit is a commit included in a push
+type PushCommit struct {
+	ID        string       `json:"id"`
+	TreeID    string       `json:"tree_id"`
+	Distinct  bool         `json:"distinct"`
+	Message   string       `json:"message"`
+	Timestamp string       `json:"timestamp"`
+	URL       string       `json:"url"`
+	Author    CommitAuthor `json:"author"`
+	Committer CommitAuthor `json:"committer"`
+	Added     []string     `json:"added"`
+	Removed   []string     `json:"removed"`
+	Modified  []string     `json:"modified"`
+}
+
+// PushEvent is sent when commits or tags are pushed
+type PushEvent struct {
+	CommonFields
+	Ref        string       `json:"ref"`
+	Before     string       `json:"before"`
+	After      string       `json:"after"`
+	Created    bool         `json:"created"`
+	Deleted    bool         `json:"deleted"`
+	Forced     bool         `json:"forced"`
+	BaseRef    *string      `json:"base_ref"`
+	Compare    string       `json:"compare"`
+	Commits    []PushCommit `json:"commits"`
+	HeadCommit *PushCommit  `json:"head_commit"`
+	Pusher     CommitAuthor `json:"pusher"`
+}
+
+// EventType returns "push"
+func (e *PushEvent) EventType() string { return "push" }
+
+// PullRequestBranch is the head or base of a pull request
+type PullRequestBranch struct {
+	Label string             `json:"label"`
+	Ref   string             `json:"ref"`
+	SHA   string             `json:"sha"`
+	User  *client.User       `json:"user,omitempty"`
+	Repo  *client.Repository `json:"repo,omitempty"`
+}
+
+// PullRequest is the pull request of a pull_request event
+type PullRequest struct {
+	ID                 int64             `json:"id"`
+	NodeID             string            `json:"node_id"`
+	Number             int               `json:"number"`
+	State              string            `json:"state"`
+	Title              string            `json:"title"`
+	Body               *string           `json:"body"`
+	User               client.User       `json:"user"`
+	HTMLURL            string            `json:"html_url"`
+	Draft              bool              `json:"draft"`
+	Merged             bool              `json:"merged"`
+	MergedAt           *string           `json:"merged_at"`
+	MergedBy           *client.User      `json:"merged_by,omitempty"`
+	MergeCommitSHA     *string           `json:"merge_commit_sha"`
+	Head               PullRequestBranch `json:"head"`
+	Base               PullRequestBranch `json:"base"`
+	Labels             []client.Label    `json:"labels"`
+	Assignees          []client.User     `json:"assignees"`
+	RequestedReviewers []client.User     `json:"requested_reviewers"`
+	Commits            int               `json:"commits"`
+	Additions          int               `json:"additions"`
+	Deletions          int               `json:"deletions"`
+	ChangedFiles       int               `json:"changed_files"`
+	CreatedAt          string            `json:"created_at"`
+	UpdatedAt          string            `json:"updated_at"`
+	ClosedAt           *string           `json:"closed_at"`
+}
+
+// PullRequestEvent is sent when a pull request is opened, closed, edited and so on
+type PullRequestEvent struct {
+	CommonFields
+	Number      int             `json:"number"`
+	PullRequest PullRequest     `json:"pull_request"`
+	Label       *client.Label   `json:"label,omitempty"`
+	Changes     json.RawMessage `json:"changes,omitempty"`
+}
+
+// EventType returns "pull_request"
+func (e *PullRequestEvent) EventType() string { return "pull_request" }
+
+// IssuesEvent is sent when an issue is opened, closed, labeled and so on
+type IssuesEvent struct {
+	CommonFields
+	Issue    client.Issue    `json:"issue"`
+	Label    *client.Label   `json:"label,omitempty"`
+	Assignee *client.User    `json:"assignee,omitempty"`
+	Changes  json.RawMessage `json:"changes,omitempty"`
+}
+
+// EventType returns "issues"
+func (e *IssuesEvent) EventType() string { return "issues" }
+
+// Workflow is the workflow of a workflow run
+type Workflow struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	State string `json:"state"`
+}
+
+// WorkflowRun is a GitHub Actions workflow run
+type WorkflowRun struct {
+	ID         int64        `json:"id"`
+	Name       string       `json:"name"`
+	WorkflowID int64        `json:"workflow_id"`
+	HeadBranch string       `json:"head_branch"`
+	HeadSHA    string       `json:"head_sha"`
+	Event      string       `json:"event"`
+	Status     string       `json:"status"`
+	Conclusion *string      `json:"conclusion"`
+	RunNumber  int          `json:"run_number"`
+	RunAttempt int          `json:"run_attempt"`
+	HTMLURL    string       `json:"html_url"`
+	Actor      *client.User `json:"actor,omitempty"`
+	CreatedAt  string       `json:"created_at"`
+	UpdatedAt  string       `json:"updated_at"`
+}
+
+// WorkflowRunEvent is sent when a workflow run is requested, in progress or completed
+type WorkflowRunEvent struct {
+	CommonFields
+	Workflow    Workflow    `json:"workflow"`
+	WorkflowRun WorkflowRun `json:"workflow_run"`
+}
+
+// EventType returns "workflow_run"
+func (e *WorkflowRunEvent) EventType() string { return "workflow_run" }
+
+// ReleaseAsset is a file attached to a release
+type ReleaseAsset struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	ContentType        string `json:"content_type"`
+	Size               int64  `json:"size"`
+	DownloadCount      int    `json:"download_count"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// Release is the release of a release event
+type Release struct {
+	ID              int64          `json:"id"`
+	TagName         string         `json:"tag_name"`
+	TargetCommitish string         `json:"target_commitish"`
+	Name            *string        `json:"name"`
+	Body            *string        `json:"body"`
+	Draft           bool           `json:"draft"`
+	Prerelease      bool           `json:"prerelease"`
+	HTMLURL         string         `json:"html_url"`
+	Author          client.User    `json:"author"`
+	Assets          []ReleaseAsset `json:"assets"`
+	CreatedAt       string         `json:"created_at"`
+	PublishedAt     *string        `json:"published_at"`
+}
+
+// ReleaseEvent is sent when a release is published, edited, deleted and so on
+type ReleaseEvent struct {
+	CommonFields
+	Release Release `json:"release"`
+}
+
+// EventType returns "release"
+func (e *ReleaseEvent) EventType() string { return "release" }
+
+// RawEvent is an event type without a registered decoder. Only the common
+// fields are decoded; the full payload is kept as received.
+type RawEvent struct {
+	CommonFields
+	Type    string          `json:"-"`
+	Payload json.RawMessage `json:"-"`
+}
+
+// EventType returns the X-GitHub-Event value of the payload
+func (e *RawEvent) EventType() string { return e.Type }
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+)
+
+// Decoder decodes the payload of one event type
+type Decoder func(payload []byte) (Event, error)
+
+// registry maps X-GitHub-Event values to decoders
+var registry = struct {
+	sync.RWMutex
+	decoders map[string]Decoder
+}{
+	decoders: map[string]Decoder{
+		"ping":         decoderFor[PingEvent](),
+		"push":         decoderFor[PushEvent](),
+		"pull_request": decoderFor[PullRequestEvent](),
+		"issues":       decoderFor[IssuesEvent](),
+		"workflow_run": decoderFor[WorkflowRunEvent](),
+		"release":      decoderFor[ReleaseEvent](),
+	},
+}
+
+// decoderFor returns a decoder unmarshalling payloads into *T
+func decoderFor[T any, PT interface {
+	*T
+	Event
+}]() Decoder {
+	return func(payload []byte) (Event, error) {
+		event := PT(new(T))
+		if err := json.Unmarshal(payload, event); err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+}
+
+// Register adds or replaces the decoder of an event type
+func Register(eventType string, decoder Decoder) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.decoders[eventType] = decoder
+}
+
+// EventTypes returns the event types with a registered decoder
+func EventTypes() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	types := make([]string, 0, len(registry.decoders))
+	for eventType := range registry.decoders {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Decode decodes a webhook payload using the decoder registered for its
+// X-GitHub-Event type. Payloads of other types are returned as a *RawEvent.
+func Decode(eventType string, payload []byte) (Event, error) {
+	if eventType == "" {
+		return nil, errors.Validation("webhook event type is required")
+	}
+
+	registry.RLock()
+	decoder, ok := registry.decoders[eventType]
+	registry.RUnlock()
+
+	if !ok {
+		raw := &RawEvent{Type: eventType, Payload: json.RawMessage(payload)}
+		if err := json.Unmarshal(payload, &raw.CommonFields); err != nil {
+			return nil, errors.Wrap(err, errors.ErrorTypeValidation, "invalid webhook payload").WithContext("event", eventType)
+		}
+		return raw, nil
+	}
+
+	event, err := decoder(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorTypeValidation, "invalid webhook payload").WithContext("event", eventType)
+	}
+	return event, nil
+}
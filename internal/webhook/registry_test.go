@@ -0,0 +1,118 @@
+package webhook
+
+import (
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		eventType string
+		payload   string
+		check     func(t *testing.T, event Event)
+	}{
+		{
+			eventType: "push",
+			payload: `{"ref": "refs/heads/main", "after": "abc123", "forced": true,
+				"commits": [{"id": "abc123", "message": "Fix bug", "author": {"name": "Mona", "username": "octocat"}}],
+				"pusher": {"name": "octocat"}, "repository": {"full_name": "octo-org/hello"}, "sender": {"login": "octocat"}}`,
+			check: func(t *testing.T, event Event) {
+				push := event.(*PushEvent)
+				if push.Ref != "refs/heads/main" || !push.Forced || len(push.Commits) != 1 || push.Commits[0].Author.Username != "octocat" {
+					t.Errorf("Unexpected push event %+v", push)
+				}
+			},
+		},
+		{
+			eventType: "pull_request",
+			payload: `{"action": "opened", "number": 7, "pull_request": {"number": 7, "title": "Add feature",
+				"head": {"ref": "feature", "sha": "def456"}, "base": {"ref": "main"}}, "repository": {"full_name": "octo-org/hello"}}`,
+			check: func(t *testing.T, event Event) {
+				pr := event.(*PullRequestEvent)
+				if pr.Action != "opened" || pr.PullRequest.Head.Ref != "feature" || pr.PullRequest.Base.Ref != "main" {
+					t.Errorf("Unexpected pull request event %+v", pr)
+				}
+			},
+		},
+		{
+			eventType: "issues",
+			payload:   `{"action": "labeled", "issue": {"number": 3, "title": "Bug"}, "label": {"name": "bug"}}`,
+			check: func(t *testing.T, event Event) {
+				issue := event.(*IssuesEvent)
+				if issue.Issue.Number != 3 || issue.Label == nil || issue.Label.Name != "bug" {
+					t.Errorf("Unexpected issues event %+v", issue)
+				}
+			},
+		},
+		{
+			eventType: "workflow_run",
+			payload:   `{"action": "completed", "workflow": {"name": "CI"}, "workflow_run": {"id": 1, "status": "completed", "conclusion": "failure"}}`,
+			check: func(t *testing.T, event Event) {
+				run := event.(*WorkflowRunEvent)
+				if run.Workflow.Name != "CI" || run.WorkflowRun.Conclusion == nil || *run.WorkflowRun.Conclusion != "failure" {
+					t.Errorf("Unexpected workflow run event %+v", run)
+				}
+			},
+		},
+		{
+			eventType: "release",
+			payload:   `{"action": "published", "release": {"tag_name": "v1.0.0", "assets": [{"name": "bin.tar.gz", "size": 1024}]}}`,
+			check: func(t *testing.T, event Event) {
+				release := event.(*ReleaseEvent)
+				if release.Release.TagName != "v1.0.0" || len(release.Release.Assets) != 1 {
+					t.Errorf("Unexpected release event %+v", release)
+				}
+			},
+		},
+		{
+			eventType: "star",
+			payload:   `{"action": "created", "repository": {"full_name": "octo-org/hello"}}`,
+			check: func(t *testing.T, event Event) {
+				raw := event.(*RawEvent)
+				if raw.Action != "created" || raw.Repository.FullName != "octo-org/hello" || len(raw.Payload) == 0 {
+					t.Errorf("Unexpected raw event %+v", raw)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			event, err := Decode(tt.eventType, []byte(tt.payload))
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if event.EventType() != tt.eventType {
+				t.Errorf("Expected event type %s, got %s", tt.eventType, event.EventType())
+			}
+			tt.check(t, event)
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	if _, err := Decode("", []byte(`{}`)); err == nil {
+		t.Error("Expected an error for a missing event type")
+	}
+	if _, err := Decode("push", []byte(`{"ref": 1}`)); err == nil {
+		t.Error("Expected an error for an invalid payload")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("star", func(payload []byte) (Event, error) {
+		return &RawEvent{Type: "star-custom"}, nil
+	})
+	defer func() {
+		registry.Lock()
+		delete(registry.decoders, "star")
+		registry.Unlock()
+	}()
+
+	event, err := Decode("star", []byte(`{}`))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if event.EventType() != "star-custom" {
+		t.Errorf("Expected registered decoder to be used, got %s", event.EventType())
+	}
+}
@@ -131,6 +131,34 @@ const TeamResponse = `{
   }
 }`
 
+// InstallationRepositoriesResponse represents a sample GitHub installation repositories response
+const InstallationRepositoriesResponse = `{
+  "total_count": 1,
+  "repository_selection": "selected",
+  "repositories": [
+    {
+      "id": 1296269,
+      "node_id": "MDEwOlJlcG9zaXRvcnkxMjk2MjY5",
+      "name": "testrepo",
+      "full_name": "testorg/testrepo",
+      "private": false,
+      "owner": {
+        "login": "testorg",
+        "id": 54321,
+        "type": "Organization",
+        "site_admin": false
+      },
+      "html_url": "https://github.com/testorg/testrepo",
+      "description": "Test repository",
+      "fork": false,
+      "default_branch": "main",
+      "created_at": "2020-01-01T00:00:00Z",
+      "updated_at": "2023-01-01T00:00:00Z",
+      "pushed_at": "2023-01-01T00:00:00Z"
+    }
+  ]
+}`
+
 // ErrorResponse represents a sample GitHub API error response
 const ErrorResponse = `{
   "message": "Not Found",
@@ -0,0 +1,98 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestGitHubClient_APIVersion(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	var version string
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			version = req.Header.Get("X-GitHub-Api-Version")
+			return mocks.MockJSONResponse(200, `{"login": "octocat"}`), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+
+	if _, err := githubClient.Get(context.Background(), "/user", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if version != client.GitHubAPIVersion {
+		t.Errorf("Expected default version %s, got %s", client.GitHubAPIVersion, version)
+	}
+
+	githubClient.SetAPIVersion("2026-03-10")
+	if _, err := githubClient.Get(context.Background(), "/user", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if version != "2026-03-10" {
+		t.Errorf("Expected configured version, got %s", version)
+	}
+
+	ctx := client.WithAPIVersion(context.Background(), "2022-11-28")
+	if _, err := githubClient.Get(ctx, "/user", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if version != "2022-11-28" {
+		t.Errorf("Expected per-request version, got %s", version)
+	}
+}
+
+func TestGitHubClient_DeprecationHeaders(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/user" {
+				return mocks.MockJSONResponse(200, `{"login": "octocat"}`), nil
+			}
+			return mocks.MockResponse(200, `[]`, map[string]string{
+				"Content-Type": "application/json",
+				"Deprecation":  "@1735689600",
+				"Sunset":       "Wed, 31 Dec 2026 23:59:59 GMT",
+			}), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+
+	for i := 0; i < 2; i++ {
+		if _, err := githubClient.Get(context.Background(), "/orgs/octo-org/legacy", nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if _, err := githubClient.Get(context.Background(), "/user", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if n := githubClient.DeprecatedResponses(); n != 2 {
+		t.Errorf("Expected 2 deprecated responses, got %d", n)
+	}
+	endpoints := githubClient.DeprecatedEndpoints()
+	if len(endpoints) != 1 {
+		t.Fatalf("Expected 1 deprecated endpoint, got %d", len(endpoints))
+	}
+	if endpoints[0].Endpoint != "/orgs/octo-org/legacy" || endpoints[0].Responses != 2 {
+		t.Errorf("Unexpected deprecated endpoint %+v", endpoints[0])
+	}
+	if endpoints[0].Sunset != "Wed, 31 Dec 2026 23:59:59 GMT" {
+		t.Errorf("Expected sunset date to be recorded, got %q", endpoints[0].Sunset)
+	}
+}
@@ -0,0 +1,293 @@
+package test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+// appServer answers GitHub App authentication requests, minting tokens that
+// expire after tokenLifetime, and records the requests it receives
+type appServer struct {
+	key           *rsa.PrivateKey
+	tokenLifetime time.Duration
+
+	mu       sync.Mutex
+	requests []string
+	minted   int
+	// revoked is an installation token answered with 401
+	revoked string
+}
+
+func newAppServer(t *testing.T, tokenLifetime time.Duration) *appServer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	return &appServer{key: key, tokenLifetime: tokenLifetime}
+}
+
+// privateKeyPEM returns the app's private key in PKCS #1 PEM encoding
+func (s *appServer) privateKeyPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(s.key)})
+}
+
+// verifyJWT checks that a bearer token is an app JWT signed by the app's key
+func (s *appServer) verifyJWT(authorization string) bool {
+	parts := strings.Split(strings.TrimPrefix(authorization, "Bearer "), ".")
+	if len(parts) != 3 {
+		return false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if rsa.VerifyPKCS1v15(&s.key.PublicKey, crypto.SHA256, digest[:], signature) != nil {
+		return false
+	}
+
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims struct {
+		Issuer    string `json:"iss"`
+		ExpiresAt int64  `json:"exp"`
+	}
+	json.Unmarshal(payload, &claims)
+	return claims.Issuer == "42" && time.Unix(claims.ExpiresAt, 0).Sub(time.Now()) <= 10*time.Minute
+}
+
+func (s *appServer) do(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req.Method+" "+req.URL.Path)
+	authorization := req.Header.Get("Authorization")
+
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/app") || strings.HasSuffix(req.URL.Path, "/installation"):
+		if !s.verifyJWT(authorization) {
+			return mocks.MockErrorResponse(401, "A JSON web token could not be decoded"), nil
+		}
+	case authorization == "Bearer "+s.revoked:
+		return mocks.MockErrorResponse(401, "Bad credentials"), nil
+	case strings.HasPrefix(authorization, "Bearer inst-"):
+	default:
+		return mocks.MockErrorResponse(401, "Bad credentials"), nil
+	}
+
+	switch {
+	case req.URL.Path == "/users/octo-org/installation":
+		return mocks.MockJSONResponse(200, `{"id": 7}`), nil
+	case strings.HasSuffix(req.URL.Path, "/installation"):
+		return mocks.MockErrorResponse(404, "Not Found"), nil
+	case strings.HasSuffix(req.URL.Path, "/access_tokens"):
+		s.minted++
+		id := strings.Split(req.URL.Path, "/")[3]
+		expires := time.Now().Add(s.tokenLifetime).UTC().Format(time.RFC3339)
+		return mocks.MockJSONResponse(201, fmt.Sprintf(`{"token": "inst-%s-%d", "expires_at": %q}`, id, s.minted, expires)), nil
+	case req.URL.Path == "/app":
+		return mocks.MockJSONResponse(200, `{"slug": "octo-app"}`), nil
+	default:
+		return mocks.MockJSONResponse(200, fmt.Sprintf(`{"authorization": %q}`, authorization)), nil
+	}
+}
+
+// newAppClient returns a client authenticated as app 42 of server
+func newAppClient(t *testing.T, server *appServer, installationID int64) *client.GitHubClient {
+	t.Helper()
+	return newAppClientWithConfig(t, server, client.AppAuthConfig{InstallationID: installationID})
+}
+
+// newAppClientWithConfig returns a client authenticated as app 42 of server
+// with the other settings of cfg
+func newAppClientWithConfig(t *testing.T, server *appServer, cfg client.AppAuthConfig) *client.GitHubClient {
+	t.Helper()
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	githubClient := client.NewGitHubClient("", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: server.do})
+	cfg.AppID, cfg.PrivateKey = 42, server.privateKeyPEM()
+	if err := githubClient.SetAppAuth(cfg); err != nil {
+		t.Fatalf("SetAppAuth failed: %v", err)
+	}
+	return githubClient
+}
+
+// authorizationOf returns the Authorization header a request to endpoint was sent with
+func authorizationOf(t *testing.T, githubClient *client.GitHubClient, endpoint string) string {
+	t.Helper()
+	resp, err := githubClient.Get(context.Background(), endpoint, nil)
+	if err != nil {
+		t.Fatalf("Request to %s failed: %v", endpoint, err)
+	}
+	var body struct {
+		Authorization string `json:"authorization"`
+	}
+	if err := resp.GetJSON(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return body.Authorization
+}
+
+// installationLookups counts the installation lookups server received
+func (s *appServer) installationLookups() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lookups := 0
+	for _, request := range s.requests {
+		if strings.HasSuffix(request, "/installation") {
+			lookups++
+		}
+	}
+	return lookups
+}
+
+func TestAppAuth_InstallationTokenPerOwner(t *testing.T) {
+	server := newAppServer(t, time.Hour)
+	githubClient := newAppClient(t, server, 9)
+
+	if err := githubClient.ValidateToken(context.Background()); err != nil {
+		t.Fatalf("Expected the app credentials to validate, got %v", err)
+	}
+
+	if got := authorizationOf(t, githubClient, "/orgs/octo-org/members"); got != "Bearer inst-7-1" {
+		t.Errorf("Expected the octo-org installation token, got %q", got)
+	}
+	if got := authorizationOf(t, githubClient, "/repos/octo-org/hello-world"); got != "Bearer inst-7-1" {
+		t.Errorf("Expected the cached octo-org installation token, got %q", got)
+	}
+	if got := authorizationOf(t, githubClient, "/users/stranger"); got != "Bearer inst-9-2" {
+		t.Errorf("Expected the default installation token for an owner without an installation, got %q", got)
+	}
+	if got := authorizationOf(t, githubClient, "/user/orgs"); got != "Bearer inst-9-2" {
+		t.Errorf("Expected the default installation token for a request naming no owner, got %q", got)
+	}
+	if got := authorizationOf(t, githubClient, "/users/octo-app[bot]"); got != "Bearer inst-9-2" {
+		t.Errorf("Expected the default installation token for a bot user, got %q", got)
+	}
+
+	lookups := server.installationLookups()
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.minted != 2 || lookups != 2 {
+		t.Errorf("Expected 2 tokens and 2 installation lookups, got %d and %d: %v", server.minted, lookups, server.requests)
+	}
+}
+
+func TestAppAuth_RefreshesExpiringTokens(t *testing.T) {
+	server := newAppServer(t, time.Minute)
+	githubClient := newAppClient(t, server, 0)
+
+	first := authorizationOf(t, githubClient, "/orgs/octo-org")
+	second := authorizationOf(t, githubClient, "/orgs/octo-org")
+	if first == second || second != "Bearer inst-7-2" {
+		t.Errorf("Expected a token expiring soon to be replaced, got %q then %q", first, second)
+	}
+}
+
+func TestAppAuth_NoInstallation(t *testing.T) {
+	server := newAppServer(t, time.Hour)
+	githubClient := newAppClient(t, server, 0)
+
+	if _, err := githubClient.Get(context.Background(), "/orgs/elsewhere", nil); err == nil || !strings.Contains(err.Error(), "not installed on elsewhere") {
+		t.Errorf("Expected an error for an owner without an installation, got %v", err)
+	}
+	if _, err := githubClient.Get(context.Background(), "/user/orgs", nil); err == nil {
+		t.Error("Expected an error for a request naming no owner without a default installation")
+	}
+}
+
+func TestAppAuth_InvalidKey(t *testing.T) {
+	githubClient := client.NewGitHubClient("", nil)
+	if err := githubClient.SetAppAuth(client.AppAuthConfig{AppID: 42, PrivateKey: []byte("not a key")}); err == nil {
+		t.Error("Expected an invalid private key to be rejected")
+	}
+}
+
+func TestAppAuth_RejectedTokenIsReplaced(t *testing.T) {
+	server := newAppServer(t, time.Hour)
+	githubClient := newAppClient(t, server, 0)
+
+	if got := authorizationOf(t, githubClient, "/orgs/octo-org"); got != "Bearer inst-7-1" {
+		t.Fatalf("Expected the octo-org installation token, got %q", got)
+	}
+	server.mu.Lock()
+	server.revoked = "inst-7-1"
+	server.mu.Unlock()
+
+	if _, err := githubClient.Get(context.Background(), "/orgs/octo-org", nil); err == nil {
+		t.Fatal("Expected the revoked token to be rejected")
+	}
+	if got := authorizationOf(t, githubClient, "/orgs/octo-org"); got != "Bearer inst-7-2" {
+		t.Errorf("Expected a new token after the cached one was rejected, got %q", got)
+	}
+}
+
+func TestAppAuth_MissingInstallationExpires(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		lookups int
+	}{
+		{name: "remembered", ttl: 0, lookups: 1},
+		{name: "expired", ttl: time.Nanosecond, lookups: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newAppServer(t, time.Hour)
+			githubClient := newAppClientWithConfig(t, server, client.AppAuthConfig{InstallationMissTTL: tt.ttl})
+
+			for i := 0; i < 2; i++ {
+				if _, err := githubClient.Get(context.Background(), "/orgs/elsewhere", nil); err == nil {
+					t.Fatal("Expected an error for an owner without an installation")
+				}
+			}
+			if got := server.installationLookups(); got != tt.lookups {
+				t.Errorf("Expected %d installation lookups, got %d", tt.lookups, got)
+			}
+		})
+	}
+}
+
+func TestAppAuth_ConcurrentRequestsShareLookups(t *testing.T) {
+	server := newAppServer(t, time.Hour)
+	githubClient := newAppClient(t, server, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := githubClient.Get(context.Background(), "/orgs/octo-org", nil); err != nil {
+				t.Errorf("Request failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	server.mu.Lock()
+	minted := server.minted
+	server.mu.Unlock()
+	if lookups := server.installationLookups(); minted != 1 || lookups != 1 {
+		t.Errorf("Expected 1 token and 1 installation lookup, got %d and %d", minted, lookups)
+	}
+}
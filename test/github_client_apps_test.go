@@ -0,0 +1,128 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/fixtures"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestGitHubClient_ListInstallationRepositories(t *testing.T) {
+	testLogger, err := logger.New("DEBUG", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/user/installations/42/repositories" {
+				t.Errorf("Unexpected request path: %s", req.URL.Path)
+			}
+			return mocks.MockJSONResponse(200, fixtures.InstallationRepositoriesResponse), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+
+	repos, pageInfo, err := githubClient.ListInstallationRepositories(context.Background(), 42, 1, 30)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if repos.TotalCount != 1 || len(repos.Repositories) != 1 {
+		t.Fatalf("Expected 1 repository, got total %d and %d items", repos.TotalCount, len(repos.Repositories))
+	}
+	if repos.Repositories[0].FullName != "testorg/testrepo" {
+		t.Errorf("Expected full name testorg/testrepo, got %s", repos.Repositories[0].FullName)
+	}
+	if pageInfo.HasMore() {
+		t.Error("Expected no further pages")
+	}
+}
+
+func TestGitHubClient_InstallationRepositoryMembership(t *testing.T) {
+	tests := []struct {
+		name           string
+		expectedMethod string
+		call           func(c *client.GitHubClient) error
+	}{
+		{
+			name:           "add repository",
+			expectedMethod: http.MethodPut,
+			call: func(c *client.GitHubClient) error {
+				return c.AddInstallationRepository(context.Background(), 42, 1296269)
+			},
+		},
+		{
+			name:           "remove repository",
+			expectedMethod: http.MethodDelete,
+			call: func(c *client.GitHubClient) error {
+				return c.RemoveInstallationRepository(context.Background(), 42, 1296269)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testLogger, err := logger.New("DEBUG", "text")
+			if err != nil {
+				t.Fatalf("Failed to create test logger: %v", err)
+			}
+
+			mockClient := &mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if req.Method != tt.expectedMethod {
+						t.Errorf("Expected method %s, got %s", tt.expectedMethod, req.Method)
+					}
+					if req.URL.Path != "/user/installations/42/repositories/1296269" {
+						t.Errorf("Unexpected request path: %s", req.URL.Path)
+					}
+					return mocks.MockResponse(204, "", nil), nil
+				},
+			}
+
+			githubClient := client.NewGitHubClient("test-token", testLogger)
+			githubClient.SetHTTPClient(mockClient)
+
+			if err := tt.call(githubClient); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGitHubClient_GetAppBotCommitIdentity(t *testing.T) {
+	testLogger, err := logger.New("DEBUG", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/users/test-app[bot]" {
+				t.Errorf("Unexpected request path: %s", req.URL.Path)
+			}
+			return mocks.MockJSONResponse(200, `{"login": "test-app[bot]", "id": 41898282, "type": "Bot"}`), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+
+	identity, err := githubClient.GetAppBotCommitIdentity(context.Background(), "test-app")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if identity.Name != "test-app[bot]" {
+		t.Errorf("Expected name test-app[bot], got %s", identity.Name)
+	}
+	if identity.Email != "41898282+test-app[bot]@users.noreply.github.com" {
+		t.Errorf("Unexpected email: %s", identity.Email)
+	}
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestGitHubClient_GetAvatar(t *testing.T) {
+	tests := []struct {
+		name        string
+		avatarURL   string
+		contentType string
+		wantURL     string
+		wantError   bool
+	}{
+		{name: "avatar host", avatarURL: "https://avatars.githubusercontent.com/u/583231?v=4", contentType: "image/png",
+			wantURL: "https://avatars.githubusercontent.com/u/583231?s=64&v=4"},
+		{name: "other host", avatarURL: "https://example.com/u/583231", wantError: true},
+		{name: "plain http", avatarURL: "http://avatars.githubusercontent.com/u/583231", wantError: true},
+		{name: "not an image", avatarURL: "https://avatars.githubusercontent.com/u/583231", contentType: "text/html", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testLogger, err := logger.New("ERROR", "text")
+			if err != nil {
+				t.Fatalf("Failed to create test logger: %v", err)
+			}
+			var gotURL, gotAuth string
+			githubClient := client.NewGitHubClient("test-token", testLogger)
+			githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+				gotURL = req.URL.String()
+				gotAuth = req.Header.Get("Authorization")
+				return mocks.MockResponse(http.StatusOK, "image", map[string]string{"Content-Type": tt.contentType}), nil
+			}})
+
+			image, mediaType, err := githubClient.GetAvatar(context.Background(), tt.avatarURL, 64)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("Expected error %v, got %v", tt.wantError, err)
+			}
+			if tt.wantError {
+				return
+			}
+			if string(image) != "image" || mediaType != tt.contentType {
+				t.Errorf("Expected the image and its media type, got %q %q", image, mediaType)
+			}
+			if gotURL != tt.wantURL || gotAuth != "" {
+				t.Errorf("Expected an unauthenticated request to %s, got %s (Authorization %q)", tt.wantURL, gotURL, gotAuth)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestGitHubClient_ResponseCache(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	var ifNoneMatch []string
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			ifNoneMatch = append(ifNoneMatch, req.Header.Get("If-None-Match"))
+			if req.Header.Get("If-None-Match") == `"v1"` {
+				return mocks.MockResponse(http.StatusNotModified, "", map[string]string{
+					"ETag":                  `"v1"`,
+					"X-RateLimit-Limit":     "5000",
+					"X-RateLimit-Remaining": "4999",
+				}), nil
+			}
+			return mocks.MockResponse(http.StatusOK, `{"login": "octocat"}`, map[string]string{
+				"Content-Type":          "application/json",
+				"ETag":                  `"v1"`,
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "5000",
+			}), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+	githubClient.SetResponseCache(10)
+
+	for i := 0; i < 2; i++ {
+		user, err := githubClient.GetUser(context.Background(), "octocat")
+		if err != nil {
+			t.Fatalf("Request %d: unexpected error: %v", i+1, err)
+		}
+		if user.Login != "octocat" {
+			t.Errorf("Request %d: expected login octocat, got %s", i+1, user.Login)
+		}
+	}
+
+	if len(ifNoneMatch) != 2 || ifNoneMatch[0] != "" || ifNoneMatch[1] != `"v1"` {
+		t.Errorf("Expected the second request to revalidate the ETag, got If-None-Match %q", ifNoneMatch)
+	}
+	if githubClient.CachedResponses() != 1 {
+		t.Errorf("Expected 1 cached response, got %d", githubClient.CachedResponses())
+	}
+	if remaining, _, _ := githubClient.RateLimitBudget(); remaining != 4999 {
+		t.Errorf("Expected the rate limit of the 304 reply, got %d", remaining)
+	}
+
+	// Other requests and methods are not served from the cache
+	if _, err := githubClient.GetUser(context.Background(), "hubot"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := githubClient.Delete(context.Background(), "/users/octocat"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ifNoneMatch[2] != "" || ifNoneMatch[3] != "" {
+		t.Errorf("Expected no If-None-Match for uncached requests, got %q", ifNoneMatch[2:])
+	}
+}
+
+func TestGitHubClient_ResponseCacheDisabled(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("If-None-Match") != "" {
+				t.Error("Expected no conditional requests without a cache")
+			}
+			return mocks.MockResponse(http.StatusOK, `{"login": "octocat"}`, map[string]string{
+				"Content-Type": "application/json",
+				"ETag":         `"v1"`,
+			}), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+
+	for i := 0; i < 2; i++ {
+		if _, err := githubClient.GetUser(context.Background(), "octocat"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+// apiCallLogs makes a request for the get_user tool and returns the GitHub
+// API call lines logged at INFO with the given sample rate
+func apiCallLogs(t *testing.T, sampleRate float64) []map[string]interface{} {
+	t.Helper()
+	var buf bytes.Buffer
+	testLogger, err := logger.NewWithWriter("INFO", "json", &buf)
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		return mocks.MockResponse(200, `{"login": "octocat"}`, map[string]string{
+			"Content-Type":          "application/json",
+			"X-RateLimit-Remaining": "4999",
+		}), nil
+	}})
+	githubClient.SetCallLogSampleRate(sampleRate)
+
+	ctx, stats := execstats.NewContext(context.Background())
+	stats.SetTool("get_user")
+	if _, err := githubClient.Get(ctx, "/users/octocat", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if json.Unmarshal([]byte(line), &entry) == nil && entry["msg"] == "GitHub API call" {
+			lines = append(lines, entry)
+		}
+	}
+	return lines
+}
+
+func TestAPICallLogging(t *testing.T) {
+	lines := apiCallLogs(t, 1)
+	if len(lines) != 1 {
+		t.Fatalf("Expected one GitHub API call line, got %d", len(lines))
+	}
+	entry := lines[0]
+	if entry["method"] != "GET" || entry["endpoint"] != "/users/octocat" || entry["tool"] != "get_user" {
+		t.Errorf("Unexpected call fields %v", entry)
+	}
+	if entry["status_code"] != float64(200) || entry["rate_limit_remaining"] != float64(4999) || entry["duration"] == "" {
+		t.Errorf("Unexpected response fields %v", entry)
+	}
+
+	if lines := apiCallLogs(t, 0); len(lines) != 0 {
+		t.Errorf("Expected unsampled calls to be logged at DEBUG only, got %v", lines)
+	}
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestGitHubClient_Commits(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Path {
+			case "/repos/octocat/hello-world/commits":
+				query := req.URL.Query()
+				if query.Get("path") != "docs" || query.Get("author") != "octocat" || query.Get("since") != "2024-01-01T00:00:00Z" || query.Has("until") || query.Get("per_page") != "10" {
+					t.Errorf("Unexpected list query %q", req.URL.RawQuery)
+				}
+				return mocks.MockJSONResponse(200, `[{"sha": "6dcb09b", "html_url": "https://github.com/octocat/hello-world/commit/6dcb09b",
+					"commit": {"message": "Fix all the bugs", "author": {"name": "Monalisa Octocat", "email": "octocat@github.com", "date": "2024-01-02T10:00:00Z"}},
+					"author": {"login": "octocat", "id": 1}, "committer": null, "parents": [{"sha": "7638417"}]}]`), nil
+			case "/repos/octocat/hello-world/commits/6dcb09b":
+				return mocks.MockJSONResponse(200, `{"sha": "6dcb09b", "commit": {"message": "Fix all the bugs"},
+					"stats": {"additions": 104, "deletions": 4, "total": 108},
+					"files": [{"filename": "file1.txt", "status": "modified", "additions": 103, "deletions": 21, "changes": 124, "patch": "@@ -29,7 +29,7 @@"}]}`), nil
+			case "/repos/octocat/hello-world/compare/main...feature":
+				return mocks.MockJSONResponse(200, `{"status": "ahead", "ahead_by": 1, "behind_by": 0, "total_commits": 1,
+					"base_commit": {"sha": "7638417"}, "merge_base_commit": {"sha": "7638417"},
+					"commits": [{"sha": "6dcb09b", "commit": {"message": "Fix all the bugs"}}],
+					"files": [{"filename": "file1.txt", "status": "renamed", "previous_filename": "file0.txt", "changes": 0}]}`), nil
+			}
+			t.Errorf("Unexpected request %s", req.URL.Path)
+			return mocks.MockJSONResponse(404, `{"message": "Not Found"}`), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+	ctx := context.Background()
+
+	commits, _, err := githubClient.ListCommits(ctx, "octocat", "hello-world", "", "docs", "octocat", "2024-01-01T00:00:00Z", "", 0, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(commits) != 1 || commits[0].Commit.Author.Date != "2024-01-02T10:00:00Z" || commits[0].Author.Login != "octocat" || commits[0].Committer != nil ||
+		len(commits[0].Parents) != 1 {
+		t.Errorf("Unexpected commits %+v", commits)
+	}
+
+	commit, err := githubClient.GetCommit(ctx, "octocat", "hello-world", "6dcb09b")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if commit.Stats == nil || commit.Stats.Total != 108 || len(commit.Files) != 1 || commit.Files[0].Patch == "" {
+		t.Errorf("Unexpected commit %+v", commit)
+	}
+
+	comparison, err := githubClient.CompareCommits(ctx, "octocat", "hello-world", "main", "feature", 0, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if comparison.Status != "ahead" || comparison.AheadBy != 1 || comparison.MergeBaseCommit.SHA != "7638417" || len(comparison.Commits) != 1 ||
+		comparison.Files[0].PreviousFilename != "file0.txt" {
+		t.Errorf("Unexpected comparison %+v", comparison)
+	}
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestGitHubClient_CreateOrUpdateFile(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodPut || req.URL.Path != "/repos/octocat/hello-world/contents/docs/README.md" {
+				t.Errorf("Unexpected request: %s %s", req.Method, req.URL.Path)
+			}
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			if body["content"] != "IyBIZWxsbwo=" || body["message"] != "Add README" || body["branch"] != "docs" || body["sha"] != nil {
+				t.Errorf("Unexpected request body: %v", body)
+			}
+			return mocks.MockJSONResponse(201, `{"content": {"name": "README.md", "path": "docs/README.md", "sha": "95b966ae"},
+				"commit": {"sha": "7638417d", "message": "Add README", "author": {"name": "Monalisa Octocat", "email": "octocat@github.com"}}}`), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+
+	commit, err := githubClient.CreateOrUpdateFile(context.Background(), "octocat", "hello-world", "/docs/README.md", []byte("# Hello\n"), "Add README", "docs", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if commit.Content == nil || commit.Content.SHA != "95b966ae" || commit.Commit.SHA != "7638417d" || commit.Commit.Author.Name != "Monalisa Octocat" {
+		t.Errorf("Unexpected file commit: %+v", commit)
+	}
+}
+
+func TestGitHubClient_DeleteFile(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodDelete || req.URL.Path != "/repos/octocat/hello-world/contents/old.txt" {
+				t.Errorf("Unexpected request: %s %s", req.Method, req.URL.Path)
+			}
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			if body["sha"] != "329688480" || body["message"] != "Remove old.txt" || body["branch"] != nil {
+				t.Errorf("Unexpected request body: %v", body)
+			}
+			return mocks.MockJSONResponse(200, `{"content": null, "commit": {"sha": "7638417d", "message": "Remove old.txt"}}`), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+
+	commit, err := githubClient.DeleteFile(context.Background(), "octocat", "hello-world", "old.txt", "Remove old.txt", "", "329688480")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if commit.Content != nil || commit.Commit.SHA != "7638417d" {
+		t.Errorf("Unexpected file commit: %+v", commit)
+	}
+}
+
+func TestGitHubClient_CommitAuthor(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	var authors []interface{}
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			authors = append(authors, body["author"])
+			if body["committer"] != nil {
+				t.Errorf("Expected the committer to be left to GitHub, got %v", body["committer"])
+			}
+			return mocks.MockJSONResponse(200, `{"commit": {"sha": "7638417d"}}`), nil
+		},
+	})
+	githubClient.SetCommitAuthor(&client.CommitIdentity{Name: "octo-app[bot]", Email: "7+octo-app[bot]@users.noreply.github.com"})
+
+	ctx := context.Background()
+	if _, err := githubClient.CreateOrUpdateFile(ctx, "octocat", "hello-world", "README.md", []byte("# Hello\n"), "Add README", "", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := githubClient.DeleteFile(ctx, "octocat", "hello-world", "old.txt", "Remove old.txt", "", "329688480"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, author := range authors {
+		identity, _ := author.(map[string]interface{})
+		if identity["name"] != "octo-app[bot]" || identity["email"] != "7+octo-app[bot]@users.noreply.github.com" {
+			t.Errorf("Expected the app bot to author the commit, got %v", author)
+		}
+	}
+	if len(authors) != 2 {
+		t.Errorf("Expected 2 commits, got %d", len(authors))
+	}
+}
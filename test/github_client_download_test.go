@@ -0,0 +1,87 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func newDownloadClient(t *testing.T, doFunc func(req *http.Request) (*http.Response, error)) *client.GitHubClient {
+	t.Helper()
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: doFunc})
+	return githubClient
+}
+
+func TestDownloadFileContents(t *testing.T) {
+	content := strings.Repeat("large file line\n", 100000)
+	githubClient := newDownloadClient(t, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/repos/octocat/hello/contents/data/big.txt" || req.URL.Query().Get("ref") != "main" {
+			t.Errorf("Unexpected request %s", req.URL)
+		}
+		if accept := req.Header.Get("Accept"); accept != "application/vnd.github.raw+json" {
+			t.Errorf("Expected the raw media type, got %q", accept)
+		}
+		return mocks.MockResponse(200, content, map[string]string{"Content-Type": "application/octet-stream", "X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "4999"}), nil
+	})
+
+	var buf bytes.Buffer
+	n, err := githubClient.DownloadFileContents(context.Background(), "octocat", "hello", "/data/big.txt", "main", &buf)
+	if err != nil {
+		t.Fatalf("DownloadFileContents failed: %v", err)
+	}
+	if n != int64(len(content)) || buf.String() != content {
+		t.Errorf("Expected %d bytes streamed, got %d", len(content), n)
+	}
+	if remaining, _, ok := githubClient.RateLimitBudget(); !ok || remaining != 4999 {
+		t.Errorf("Expected the rate limit of the download to be recorded, got %d", remaining)
+	}
+}
+
+func TestDownload_Errors(t *testing.T) {
+	githubClient := newDownloadClient(t, func(req *http.Request) (*http.Response, error) {
+		return mocks.MockErrorResponse(404, "Not Found"), nil
+	})
+
+	var buf bytes.Buffer
+	_, err := githubClient.DownloadWorkflowRunLogs(context.Background(), "octocat", "hello", 42, &buf)
+	if !errors.IsType(err, errors.ErrorTypeNotFound) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing written for a failed download, got %q", buf.String())
+	}
+
+	if _, err := githubClient.DownloadArchive(context.Background(), "octocat", "hello", "rar", "", &buf); !errors.IsType(err, errors.ErrorTypeValidation) {
+		t.Errorf("Expected an unsupported archive format to be rejected, got %v", err)
+	}
+}
+
+func TestDownloadArchive(t *testing.T) {
+	var paths []string
+	githubClient := newDownloadClient(t, func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		return mocks.MockResponse(200, "archive", map[string]string{"Content-Type": "application/x-gzip"}), nil
+	})
+
+	var buf bytes.Buffer
+	for _, ref := range []string{"", "v1.0.0"} {
+		if _, err := githubClient.DownloadArchive(context.Background(), "octocat", "hello", "tarball", ref, &buf); err != nil {
+			t.Fatalf("DownloadArchive failed: %v", err)
+		}
+	}
+	if got := strings.Join(paths, ","); got != "/repos/octocat/hello/tarball,/repos/octocat/hello/tarball/v1.0.0" {
+		t.Errorf("Unexpected archive paths %s", got)
+	}
+}
@@ -0,0 +1,165 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/client/graphql"
+	apperrors "github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+// newGraphQLClient returns a GraphQL client answering every operation with
+// body, recording the requests it receives
+func newGraphQLClient(t *testing.T, body string) (*graphql.Client, *[]graphql.Request) {
+	t.Helper()
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	var requests []graphql.Request
+	rest := client.NewGitHubClient("test-token", testLogger)
+	rest.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodPost || req.URL.Path != "/graphql" {
+				t.Errorf("Unexpected request %s %s", req.Method, req.URL.Path)
+			}
+			data, _ := io.ReadAll(req.Body)
+			var request graphql.Request
+			if err := json.Unmarshal(data, &request); err != nil {
+				t.Errorf("Failed to decode request: %v", err)
+			}
+			requests = append(requests, request)
+			return mocks.MockResponse(http.StatusOK, body, map[string]string{
+				"Content-Type":          "application/json",
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "4990",
+				"X-RateLimit-Reset":     "1640995200",
+				"X-RateLimit-Resource":  "graphql",
+			}), nil
+		},
+	})
+	return graphql.New(rest), &requests
+}
+
+func TestGraphQL_Query(t *testing.T) {
+	gql, requests := newGraphQLClient(t, `{"data": {
+		"organization": {"login": "octo-org", "membersWithRole": {"totalCount": 42}},
+		"rateLimit": {"cost": 3, "remaining": 4987, "resetAt": "2026-01-01T00:00:00Z"}
+	}}`)
+
+	var result struct {
+		Organization struct {
+			Login           string
+			MembersWithRole struct {
+				TotalCount int
+			}
+		}
+	}
+	query := `query($login: String!) { organization(login: $login) { login membersWithRole { totalCount } } rateLimit { cost remaining resetAt } }`
+	if err := gql.Query(context.Background(), query, map[string]interface{}{"login": "octo-org"}, &result); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.Organization.Login != "octo-org" || result.Organization.MembersWithRole.TotalCount != 42 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if len(*requests) != 1 || (*requests)[0].Variables["login"] != "octo-org" || (*requests)[0].Query != query {
+		t.Errorf("Unexpected requests %+v", *requests)
+	}
+	if cost := gql.Cost(); cost.Operations != 1 || cost.Total != 3 || cost.Last != 3 || cost.Remaining != 4987 {
+		t.Errorf("Unexpected cost %+v", cost)
+	}
+}
+
+func TestGraphQL_MutationWithoutReportedCost(t *testing.T) {
+	gql, requests := newGraphQLClient(t, `{"data": {"addStar": {"starrable": {"id": "R_1"}}}}`)
+
+	input := struct {
+		StarrableID string `json:"starrableId"`
+	}{StarrableID: "R_1"}
+	if err := gql.Mutate(context.Background(), `mutation($input: AddStarInput!) { addStar(input: $input) { starrable { id } } }`, input, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sent, _ := (*requests)[0].Variables["input"].(map[string]interface{})
+	if sent["starrableId"] != "R_1" {
+		t.Errorf("Expected the input variable to be marshaled, got %+v", (*requests)[0].Variables)
+	}
+	if cost := gql.Cost(); cost.Total != 1 || cost.Remaining != 4990 {
+		t.Errorf("Expected the minimum cost and the header rate limit, got %+v", cost)
+	}
+}
+
+func TestGraphQL_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		errType  string
+		expected apperrors.ErrorType
+	}{
+		{name: "not found", errType: "NOT_FOUND", expected: apperrors.ErrorTypeNotFound},
+		{name: "forbidden", errType: "FORBIDDEN", expected: apperrors.ErrorTypeAuthorization},
+		{name: "rate limited", errType: "RATE_LIMITED", expected: apperrors.ErrorTypeRateLimit},
+		{name: "invalid argument", errType: "ARGUMENT_ERROR", expected: apperrors.ErrorTypeValidation},
+		{name: "untyped", errType: "", expected: apperrors.ErrorTypeGitHubAPI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gql, _ := newGraphQLClient(t, `{"data": {"repository": null, "viewer": {"login": "octocat"}}, "errors": [
+				{"type": "`+tt.errType+`", "path": ["repository"], "message": "Could not resolve to a Repository"}
+			]}`)
+
+			var result struct {
+				Viewer struct{ Login string }
+			}
+			err := gql.Query(context.Background(), `{ repository(owner: "x", name: "y") { id } viewer { login } }`, nil, &result)
+			appErr, ok := err.(*apperrors.AppError)
+			if !ok || appErr.Type != tt.expected || appErr.Message != "Could not resolve to a Repository" {
+				t.Fatalf("Expected a %s error, got %v", tt.expected, err)
+			}
+			if result.Viewer.Login != "octocat" {
+				t.Errorf("Expected partial data to be decoded, got %+v", result)
+			}
+		})
+	}
+}
+
+func TestGraphQL_EnterpriseServerURL(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	tests := []struct {
+		baseURL  string
+		expected string
+	}{
+		{baseURL: client.GitHubAPIBaseURL, expected: "https://api.github.com/graphql"},
+		{baseURL: "https://github.example.com/api/v3/", expected: "https://github.example.com/api/graphql"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.baseURL, func(t *testing.T) {
+			var requested string
+			rest := client.NewGitHubClient("test-token", testLogger)
+			rest.SetBaseURL(tt.baseURL)
+			rest.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+				requested = req.URL.String()
+				return mocks.MockJSONResponse(http.StatusOK, `{"data": {"viewer": {"login": "octocat"}}}`), nil
+			}})
+
+			if err := graphql.New(rest).Query(context.Background(), `{ viewer { login } }`, nil, nil); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if requested != tt.expected {
+				t.Errorf("Expected the operation to be posted to %s, got %s", tt.expected, requested)
+			}
+		})
+	}
+}
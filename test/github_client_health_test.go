@@ -0,0 +1,105 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestGitHubClient_HealthCircuitBreaker(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	status := http.StatusBadGateway
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mocks.MockErrorResponse(status, "Bad Gateway"), nil
+		},
+	})
+
+	for i := 0; i < 4; i++ {
+		githubClient.Get(context.Background(), "/user", nil)
+	}
+	if health := githubClient.Health(); health.Degraded {
+		t.Fatalf("Expected healthy below the failure threshold, got %+v", health)
+	}
+
+	githubClient.Get(context.Background(), "/user", nil)
+	health := githubClient.Health()
+	if !health.Degraded || health.Reason != client.DegradedCircuitOpen || health.RetryAfter <= 0 {
+		t.Errorf("Expected open circuit after consecutive failures, got %+v", health)
+	}
+}
+
+func TestGitHubClient_HealthRateLimited(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	reset := time.Now().Add(time.Minute).Unix()
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mocks.MockResponse(200, `{"login": "octocat"}`, map[string]string{
+				"Content-Type":          "application/json",
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(reset, 10),
+			}), nil
+		},
+	})
+
+	if _, err := githubClient.Get(context.Background(), "/user", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	health := githubClient.Health()
+	if !health.Degraded || health.Reason != client.DegradedRateLimited {
+		t.Errorf("Expected rate limited health, got %+v", health)
+	}
+	if health.RetryAfter <= 0 || health.RetryAfter > time.Minute {
+		t.Errorf("Expected retry within the rate limit window, got %v", health.RetryAfter)
+	}
+}
+
+func TestGitHubClient_HealthIgnoresOtherResources(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	reset := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			headers := map[string]string{
+				"Content-Type":          "application/json",
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "4000",
+				"X-RateLimit-Reset":     reset,
+				"X-RateLimit-Resource":  "core",
+			}
+			if req.URL.Path == "/search/code" {
+				headers["X-RateLimit-Limit"] = "10"
+				headers["X-RateLimit-Remaining"] = "0"
+				headers["X-RateLimit-Resource"] = "code_search"
+			}
+			return mocks.MockResponse(200, `{}`, headers), nil
+		},
+	})
+
+	githubClient.Get(context.Background(), "/user", nil)
+	githubClient.Get(context.Background(), "/search/code", map[string]string{"q": "x"})
+	if health := githubClient.Health(); health.Degraded {
+		t.Errorf("Expected an exhausted search budget not to degrade health, got %+v", health)
+	}
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestMaxConcurrency(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	var inFlight, peak atomic.Int32
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return mocks.MockJSONResponse(200, `{}`), nil
+	}})
+	githubClient.SetMaxConcurrency(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := githubClient.Get(context.Background(), "/user", nil); err != nil {
+				t.Errorf("Request failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak.Load() != 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", peak.Load())
+	}
+	if githubClient.RequestsInFlight() != 0 {
+		t.Errorf("Expected every slot to be released, got %d in flight", githubClient.RequestsInFlight())
+	}
+}
+
+func TestMaxConcurrency_WaitCancelled(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	started, unblock := make(chan struct{}), make(chan struct{})
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		close(started)
+		<-unblock
+		return mocks.MockJSONResponse(200, `{}`), nil
+	}})
+	githubClient.SetMaxConcurrency(1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		githubClient.Get(context.Background(), "/user", nil)
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := githubClient.Get(ctx, "/user", nil); err == nil {
+		t.Error("Expected a request waiting for a slot to fail when its context ends")
+	}
+	close(unblock)
+	<-done
+}
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestMediaTypes(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	var accept string
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		accept = req.Header.Get("Accept")
+		return mocks.MockJSONResponse(200, `{}`), nil
+	}})
+
+	tests := []struct {
+		name       string
+		mediaTypes []string
+		ctx        context.Context
+		want       string
+	}{
+		{name: "default", ctx: context.Background(), want: "application/vnd.github+json"},
+		{name: "previews", mediaTypes: []string{"application/vnd.github.mercy-preview+json"}, ctx: context.Background(),
+			want: "application/vnd.github+json, application/vnd.github.mercy-preview+json"},
+		{name: "per request", mediaTypes: []string{"application/vnd.github.mercy-preview+json"},
+			ctx: client.WithMediaType(context.Background(), client.DiffMediaType), want: "application/vnd.github.diff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			githubClient.SetMediaTypes(tt.mediaTypes)
+			if _, err := githubClient.Get(tt.ctx, "/repos/octocat/hello/pulls/1", nil); err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			if accept != tt.want {
+				t.Errorf("Expected Accept %q, got %q", tt.want, accept)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/fixtures"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	header := `<https://api.github.com/users?page=3&per_page=2>; rel="next", <https://api.github.com/users?page=5&per_page=2>; rel="last"`
+
+	links := client.ParseLinkHeader(header)
+
+	if links["next"] != "https://api.github.com/users?page=3&per_page=2" {
+		t.Errorf("Unexpected next link: %s", links["next"])
+	}
+	if links["last"] != "https://api.github.com/users?page=5&per_page=2" {
+		t.Errorf("Unexpected last link: %s", links["last"])
+	}
+	if _, ok := links["prev"]; ok {
+		t.Error("Expected no prev link")
+	}
+}
+
+func TestGitHubClient_ListUserFollowers_PageInfo(t *testing.T) {
+	tests := []struct {
+		name             string
+		page             int
+		total            int
+		expectedHasMore  bool
+		expectedNextPage int
+	}{
+		{
+			name:             "more pages available",
+			page:             1,
+			total:            5,
+			expectedHasMore:  true,
+			expectedNextPage: 2,
+		},
+		{
+			name:             "last page",
+			page:             3,
+			total:            5,
+			expectedHasMore:  false,
+			expectedNextPage: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testLogger, err := logger.New("DEBUG", "text")
+			if err != nil {
+				t.Fatalf("Failed to create test logger: %v", err)
+			}
+
+			mockClient := &mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return mocks.MockPaginatedResponse(200, fixtures.UsersListResponse, tt.page, 2, tt.total), nil
+				},
+			}
+
+			githubClient := client.NewGitHubClient("test-token", testLogger)
+			githubClient.SetHTTPClient(mockClient)
+
+			followers, pageInfo, err := githubClient.ListUserFollowers(context.Background(), "testuser", tt.page, 2)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(followers) != 2 {
+				t.Errorf("Expected 2 followers, got %d", len(followers))
+			}
+			if pageInfo.HasMore() != tt.expectedHasMore {
+				t.Errorf("Expected HasMore %v, got %v", tt.expectedHasMore, pageInfo.HasMore())
+			}
+			if pageInfo.NextPage != tt.expectedNextPage {
+				t.Errorf("Expected next page %d, got %d", tt.expectedNextPage, pageInfo.NextPage)
+			}
+		})
+	}
+}
+
+func TestGetAllPages(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxPages        int
+		expectedPages   []string
+		expectedHasMore bool
+	}{
+		{
+			name:          "follows next links to the last page",
+			maxPages:      10,
+			expectedPages: []string{"1", "2", "3"},
+		},
+		{
+			name:            "stops at the page cap",
+			maxPages:        2,
+			expectedPages:   []string{"1", "2"},
+			expectedHasMore: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testLogger, err := logger.New("ERROR", "text")
+			if err != nil {
+				t.Fatalf("Failed to create test logger: %v", err)
+			}
+
+			var pages []string
+			githubClient := client.NewGitHubClient("test-token", testLogger)
+			githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					page := req.URL.Query().Get("page")
+					pages = append(pages, page)
+					n, _ := strconv.Atoi(page)
+					return mocks.MockPaginatedResponse(200, fixtures.UsersListResponse, n, 2, 6), nil
+				},
+			})
+
+			followers, pageInfo, err := client.GetAllPages(context.Background(), 1, tt.maxPages, func(ctx context.Context, page int) ([]client.User, *client.PageInfo, error) {
+				return githubClient.ListUserFollowers(ctx, "testuser", page, 2)
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if strings.Join(pages, ",") != strings.Join(tt.expectedPages, ",") {
+				t.Errorf("Expected pages %v, got %v", tt.expectedPages, pages)
+			}
+			if len(followers) != 2*len(tt.expectedPages) {
+				t.Errorf("Expected %d followers, got %d", 2*len(tt.expectedPages), len(followers))
+			}
+			if pageInfo.HasMore() != tt.expectedHasMore {
+				t.Errorf("Expected HasMore %v, got %v", tt.expectedHasMore, pageInfo.HasMore())
+			}
+		})
+	}
+}
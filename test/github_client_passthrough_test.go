@@ -0,0 +1,51 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestWithToken_OverridesClientToken(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	var authorization string
+	githubClient := client.NewGitHubClient("server-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		authorization = req.Header.Get("Authorization")
+		remaining := "4000"
+		if authorization == "Bearer caller-token" {
+			remaining = "1"
+		}
+		return mocks.MockResponse(200, `{}`, map[string]string{
+			"Content-Type":          "application/json",
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": remaining,
+		}), nil
+	}})
+	githubClient.SetRateLimitPolicy(client.RateLimitPolicy{Floor: 100})
+
+	if _, err := githubClient.Get(client.WithToken(context.Background(), "caller-token"), "/user", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if authorization != "Bearer caller-token" {
+		t.Errorf("Expected the caller's token, got %q", authorization)
+	}
+	if _, ok := githubClient.RateLimitState(client.RateLimitCore); ok {
+		t.Error("Expected the caller's rate limit not to be recorded as the client's")
+	}
+
+	if _, err := githubClient.Get(context.Background(), "/user", nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if authorization != "Bearer server-token" {
+		t.Errorf("Expected the client's token without a per-request token, got %q", authorization)
+	}
+}
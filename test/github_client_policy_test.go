@@ -0,0 +1,113 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	apperrors "github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestAccessPolicy(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	var requested []string
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.Path)
+		return mocks.MockJSONResponse(http.StatusOK, `{}`), nil
+	}})
+	githubClient.SetAccessPolicy(client.AccessPolicy{
+		AllowedOwners: []string{"octo-org"},
+		AllowedRepos:  []string{"hubot/api-*"},
+		DeniedRepos:   []string{"octo-org/secret"},
+	})
+
+	for endpoint, allowed := range map[string]bool{
+		"/repos/octo-org/widgets/issues":                          true,
+		"/repos/Octo-Org/widgets":                                 true,
+		"/orgs/octo-org/teams":                                    true,
+		"/repos/octo-org/secret/issues":                           false,
+		"/repos/OCTO-ORG/Secret":                                  false,
+		"/repos/hubot/api-server/pulls":                           true,
+		"/repos/hubot/website":                                    false,
+		"/orgs/hubot":                                             true,
+		"/orgs/hubot/members":                                     false,
+		"/users/hubot":                                            true,
+		"/users/hubot/repos":                                      false,
+		"/orgs/other-org":                                         false,
+		"/repos/other-org/widgets":                                false,
+		"/users/octo-org/repos":                                   true,
+		"/users/octocat":                                          false,
+		"/user":                                                   true,
+		"/rate_limit":                                             true,
+		"/repositories/42":                                        false,
+		"/teams/7/members":                                        false,
+		"/organizations/9/repos":                                  false,
+		"/networks/octo-org/widgets/events":                       true,
+		"/networks/other-org/widgets/events":                      false,
+		"/user/following/octocat":                                 false,
+		"/user/following/octo-org":                                true,
+		"/user/starred/other-org/widgets":                         false,
+		"/user/starred/hubot/api-server":                          true,
+		"/user/installations/1/repositories/42":                   false,
+		"/graphql":                                                false,
+		"/search/issues?q=org:other-org":                          false,
+		"/search/issues?q=is:open":                                false,
+		"/search/issues?q=repo:octo-org/widgets+is:open":          true,
+		"/search/issues?q=org:octo-org+repo:other-org/widgets":    false,
+		"/repos/octo-org/widgets%2F..%2F..%2Fother-org%2Fwidgets": false,
+		"/repos/octo-org/widgets/../../other-org/widgets":         false,
+		"/repos/other-org%2Fwidgets":                              false,
+		"/repos/octo-org%2Fsecret/issues":                         false,
+	} {
+		requested = nil
+		_, err := githubClient.Get(context.Background(), endpoint, nil)
+		if allowed && err != nil {
+			t.Errorf("Expected %s allowed, got %v", endpoint, err)
+		}
+		if !allowed && (err == nil || len(requested) != 0) {
+			t.Errorf("Expected %s denied before reaching GitHub, got %v after %d requests", endpoint, err, len(requested))
+		}
+	}
+
+	// The owner of an allowed repository is only readable as a profile
+	for _, method := range []string{http.MethodPost, http.MethodPatch, http.MethodDelete} {
+		requested = nil
+		if _, err := githubClient.Request(context.Background(), method, "/orgs/hubot", nil, nil); err == nil || len(requested) != 0 {
+			t.Errorf("Expected %s /orgs/hubot denied before reaching GitHub, got %v", method, err)
+		}
+	}
+	if _, err := githubClient.Post(context.Background(), "/orgs/hubot/repos", map[string]string{"name": "widgets"}); err == nil {
+		t.Error("Expected creating a repository in the owner of an allowed repository to be denied")
+	}
+	if _, _, err := githubClient.SearchIssues(context.Background(), "org:hubot", "", "", 1, 30); err == nil {
+		t.Error("Expected a search of the owner of an allowed repository to be denied")
+	}
+	if _, err := githubClient.Delete(context.Background(), "/user/following/octocat"); err == nil {
+		t.Error("Expected unfollowing a user outside the policy to be denied")
+	}
+
+	requested = nil
+	_, _, err = githubClient.SearchIssues(context.Background(), "is:open user:octocat", "", "", 1, 30)
+	if !apperrors.IsType(err, apperrors.ErrorTypeAuthorization) || len(requested) != 0 {
+		t.Errorf("Expected a search scoped to a denied user to be denied, got %v", err)
+	}
+	if _, _, err := githubClient.SearchIssues(context.Background(), "is:open -user:octocat org:octo-org", "", "", 1, 30); err != nil {
+		t.Errorf("Expected a search scoped to an allowed organization to be allowed, got %v", err)
+	}
+
+	// Without an allow list, unscoped searches are allowed
+	githubClient.SetAccessPolicy(client.AccessPolicy{DeniedOwners: []string{"other-org"}})
+	if _, _, err := githubClient.SearchIssues(context.Background(), "is:open", "", "", 1, 30); err != nil {
+		t.Errorf("Expected an unscoped search to be allowed, got %v", err)
+	}
+	if _, _, err := githubClient.SearchIssues(context.Background(), "org:Other-Org", "", "", 1, 30); err == nil {
+		t.Error("Expected a search scoped to a denied organization to be denied")
+	}
+}
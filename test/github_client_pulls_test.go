@@ -0,0 +1,45 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestGitHubClient_GetPullRequestDiff(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	diff := "diff --git a/main.go b/main.go\n"
+	var gotPath, gotAccept string
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		gotPath = req.URL.Path
+		gotAccept = req.Header.Get("Accept")
+		return mocks.MockResponse(http.StatusOK, diff, map[string]string{"Content-Type": client.DiffMediaType}), nil
+	}})
+
+	got, err := githubClient.GetPullRequestDiff(context.Background(), "octocat", "hello-world", 42, 1024)
+	if err != nil {
+		t.Fatalf("GetPullRequestDiff failed: %v", err)
+	}
+	if got != diff {
+		t.Errorf("Expected diff %q, got %q", diff, got)
+	}
+
+	got, err = githubClient.GetPullRequestDiff(context.Background(), "octocat", "hello-world", 42, 10)
+	if err != nil {
+		t.Fatalf("GetPullRequestDiff failed: %v", err)
+	}
+	if got != diff[:10] {
+		t.Errorf("Expected the diff cut to %q, got %q", diff[:10], got)
+	}
+	if gotPath != "/repos/octocat/hello-world/pulls/42" || gotAccept != client.DiffMediaType {
+		t.Errorf("Expected a diff request for the pull request, got %s with Accept %q", gotPath, gotAccept)
+	}
+}
@@ -0,0 +1,163 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	apperrors "github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+// newBudgetClient returns a client whose responses report remaining requests
+// of resource, resetting at reset
+func newBudgetClient(t *testing.T, resource string, remaining int, reset time.Time) (*client.GitHubClient, *int) {
+	t.Helper()
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	requests := 0
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests++
+			return mocks.MockResponse(http.StatusOK, `{}`, map[string]string{
+				"Content-Type":          "application/json",
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": strconv.Itoa(remaining),
+				"X-RateLimit-Used":      strconv.Itoa(5000 - remaining),
+				"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+				"X-RateLimit-Resource":  resource,
+			}), nil
+		},
+	})
+	return githubClient, &requests
+}
+
+func TestGitHubClient_RateLimitState(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	githubClient, _ := newBudgetClient(t, "core", 4200, reset)
+
+	if _, ok := githubClient.RateLimitState(client.RateLimitCore); ok {
+		t.Error("Expected no rate limit state before the first response")
+	}
+	if _, err := githubClient.Get(context.Background(), "/user", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	state, ok := githubClient.RateLimitState(client.RateLimitCore)
+	if !ok {
+		t.Fatal("Expected the core rate limit state to be tracked")
+	}
+	if state.Limit != 5000 || state.Remaining != 4200 || state.Used != 800 || !state.Reset.Equal(reset) {
+		t.Errorf("Unexpected rate limit state %+v", state)
+	}
+	if _, ok := githubClient.RateLimitState(client.RateLimitSearch); ok {
+		t.Error("Expected the search rate limit to be tracked separately")
+	}
+}
+
+func TestGitHubClient_RateLimitFloorRejects(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	githubClient, requests := newBudgetClient(t, "core", 50, reset)
+	githubClient.SetRateLimitPolicy(client.RateLimitPolicy{Floor: 100, MaxWait: time.Second})
+
+	ctx := context.Background()
+	if _, err := githubClient.Get(ctx, "/user", nil); err != nil {
+		t.Fatalf("Expected the first request to be sent, got %v", err)
+	}
+
+	_, err := githubClient.Get(ctx, "/user", nil)
+	appErr, ok := err.(*apperrors.AppError)
+	if !ok || appErr.Type != apperrors.ErrorTypeRateLimit || !strings.Contains(appErr.Message, "rate limit budget exhausted, resets at") {
+		t.Fatalf("Expected a rate limit budget error, got %v", err)
+	}
+	if *requests != 1 {
+		t.Errorf("Expected the rejected request not to be sent, got %d requests", *requests)
+	}
+	if githubClient.RateLimitRejections() != 1 {
+		t.Errorf("Expected 1 rejection, got %d", githubClient.RateLimitRejections())
+	}
+
+	// Search has its own budget
+	if _, err := githubClient.Get(ctx, "/search/repositories", map[string]string{"q": "mcp"}); err != nil {
+		t.Errorf("Expected a search request to be sent, got %v", err)
+	}
+}
+
+func TestGitHubClient_RateLimitFloorWaitsForReset(t *testing.T) {
+	reset := time.Now().Add(1500 * time.Millisecond).Truncate(time.Second)
+	githubClient, requests := newBudgetClient(t, "core", 10, reset)
+	githubClient.SetRateLimitPolicy(client.RateLimitPolicy{Floor: 100, MaxWait: 5 * time.Second})
+
+	ctx := context.Background()
+	if _, err := githubClient.Get(ctx, "/user", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := githubClient.Get(ctx, "/user", nil); err != nil {
+		t.Fatalf("Expected the request to wait for the reset, got %v", err)
+	}
+	if time.Now().Before(reset) {
+		t.Error("Expected the request to be sent after the reset")
+	}
+	if *requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", *requests)
+	}
+}
+
+func TestGitHubClient_RateLimitErrors(t *testing.T) {
+	reset := time.Now().Add(90 * time.Second)
+	tests := []struct {
+		name     string
+		status   int
+		message  string
+		headers  map[string]string
+		wantType apperrors.ErrorType
+		wantWait int
+	}{
+		{name: "secondary with retry after", status: 403, message: "You have exceeded a secondary rate limit. Please wait a few minutes before you try again.",
+			headers: map[string]string{"Retry-After": "30"}, wantType: apperrors.ErrorTypeSecondaryRateLimit, wantWait: 30},
+		{name: "secondary without retry after", status: 429, message: "You have triggered an abuse detection mechanism.",
+			wantType: apperrors.ErrorTypeSecondaryRateLimit, wantWait: 60},
+		{name: "primary", status: 403, message: "API rate limit exceeded for user ID 1.",
+			headers:  map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": strconv.FormatInt(reset.Unix(), 10)},
+			wantType: apperrors.ErrorTypeRateLimit, wantWait: 90},
+		{name: "permission", status: 403, message: "Must have admin rights to Repository.", wantType: apperrors.ErrorTypeAuthorization},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testLogger, err := logger.New("ERROR", "text")
+			if err != nil {
+				t.Fatalf("Failed to create test logger: %v", err)
+			}
+			githubClient := client.NewGitHubClient("test-token", testLogger)
+			githubClient.SetRetryPolicy(client.RetryPolicy{MaxAttempts: 1})
+			githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					headers := map[string]string{"Content-Type": "application/json"}
+					for name, value := range tt.headers {
+						headers[name] = value
+					}
+					return mocks.MockResponse(tt.status, `{"message": "`+tt.message+`"}`, headers), nil
+				},
+			})
+			_, err = githubClient.Get(context.Background(), "/user", nil)
+			appErr, ok := err.(*apperrors.AppError)
+			if !ok || appErr.Type != tt.wantType {
+				t.Fatalf("Expected a %s error, got %v", tt.wantType, err)
+			}
+			wait, _ := appErr.Context["retry_after_seconds"].(int)
+			if wait < tt.wantWait-1 || wait > tt.wantWait {
+				t.Errorf("Expected to wait %d seconds, got %d", tt.wantWait, wait)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestGitHubClient_ListRepositories(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/users/octocat/repos" {
+				t.Errorf("Unexpected request path: %s", req.URL.Path)
+			}
+			query := req.URL.Query()
+			for key, want := range map[string]string{"type": "owner", "sort": "pushed", "direction": "desc", "page": "2", "per_page": "1"} {
+				if got := query.Get(key); got != want {
+					t.Errorf("Expected %s=%s, got %q", key, want, got)
+				}
+			}
+			resp := mocks.MockJSONResponse(200, `[{"id": 1296269, "name": "hello-world", "full_name": "octocat/hello-world",
+				"owner": {"login": "octocat"}, "default_branch": "main", "stargazers_count": 80, "unknown_field": "dropped"}]`)
+			resp.Header.Set("Link", `<https://api.github.com/users/octocat/repos?page=3&per_page=1>; rel="next"`)
+			return resp, nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+
+	repos, pageInfo, err := githubClient.ListRepositories(context.Background(), "octocat", "owner", "pushed", "desc", 2, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(repos) != 1 || repos[0].FullName != "octocat/hello-world" || repos[0].Owner.Login != "octocat" || repos[0].StargazersCount != 80 {
+		t.Fatalf("Unexpected repositories: %+v", repos)
+	}
+	if !pageInfo.HasMore() {
+		t.Error("Expected further pages")
+	}
+}
+
+func TestGitHubClient_RepositoryManagement(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	var requests []string
+	var bodies []map[string]interface{}
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			var body map[string]interface{}
+			if req.Body != nil {
+				json.NewDecoder(req.Body).Decode(&body)
+			}
+			bodies = append(bodies, body)
+			if req.Method == http.MethodDelete {
+				return mocks.MockResponse(204, "", nil), nil
+			}
+			return mocks.MockJSONResponse(200, `{"id": 1296269, "name": "hello-world", "full_name": "octocat/hello-world",
+				"owner": {"login": "octocat"}, "visibility": "public", "allow_squash_merge": true}`), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+	ctx := context.Background()
+
+	repo, err := githubClient.GetRepository(ctx, "octocat", "hello-world")
+	if err != nil {
+		t.Fatalf("GetRepository failed: %v", err)
+	}
+	if repo.FullName != "octocat/hello-world" || repo.AllowSquashMerge == nil || !*repo.AllowSquashMerge || repo.AllowRebaseMerge != nil {
+		t.Errorf("Unexpected repository: %+v", repo)
+	}
+	if _, err := githubClient.CreateRepository(ctx, "", map[string]interface{}{"name": "hello-world"}); err != nil {
+		t.Fatalf("CreateRepository failed: %v", err)
+	}
+	if _, err := githubClient.CreateRepository(ctx, "octo-org", map[string]interface{}{"name": "hello-world", "visibility": "internal"}); err != nil {
+		t.Fatalf("CreateRepository failed: %v", err)
+	}
+	if _, err := githubClient.UpdateRepository(ctx, "octocat", "hello-world", map[string]interface{}{"has_wiki": false}); err != nil {
+		t.Fatalf("UpdateRepository failed: %v", err)
+	}
+	if err := githubClient.DeleteRepository(ctx, "octocat", "hello-world"); err != nil {
+		t.Fatalf("DeleteRepository failed: %v", err)
+	}
+	if _, err := githubClient.ForkRepository(ctx, "octocat", "hello-world", "octo-org", "", true); err != nil {
+		t.Fatalf("ForkRepository failed: %v", err)
+	}
+	if _, err := githubClient.TransferRepository(ctx, "octocat", "hello-world", "octo-org", "", []int64{12}); err != nil {
+		t.Fatalf("TransferRepository failed: %v", err)
+	}
+
+	want := []string{
+		"GET /repos/octocat/hello-world",
+		"POST /user/repos",
+		"POST /orgs/octo-org/repos",
+		"PATCH /repos/octocat/hello-world",
+		"DELETE /repos/octocat/hello-world",
+		"POST /repos/octocat/hello-world/forks",
+		"POST /repos/octocat/hello-world/transfer",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("Expected requests %v, got %v", want, requests)
+	}
+	if fork := bodies[5]; fork["organization"] != "octo-org" || fork["default_branch_only"] != true || fork["name"] != nil {
+		t.Errorf("Unexpected fork request body: %v", fork)
+	}
+	if transfer := bodies[6]; transfer["new_owner"] != "octo-org" || len(transfer["team_ids"].([]interface{})) != 1 {
+		t.Errorf("Unexpected transfer request body: %v", transfer)
+	}
+}
@@ -0,0 +1,159 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/execstats"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+// newRetryingClient returns a client retrying quickly, answering requests
+// with the responses in order
+func newRetryingClient(t *testing.T, responses ...func(req *http.Request) (*http.Response, error)) (*client.GitHubClient, *int) {
+	t.Helper()
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	attempts := 0
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			respond := responses[min(attempts, len(responses)-1)]
+			attempts++
+			return respond(req)
+		},
+	})
+	githubClient.SetRetryPolicy(client.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond})
+	return githubClient, &attempts
+}
+
+func respondStatus(status int, headers map[string]string, body string) func(*http.Request) (*http.Response, error) {
+	return func(*http.Request) (*http.Response, error) {
+		return mocks.MockResponse(status, body, headers), nil
+	}
+}
+
+var respondOK = respondStatus(http.StatusOK, map[string]string{"Content-Type": "application/json"}, `{"login": "octocat"}`)
+
+func TestGitHubClient_RetryServerError(t *testing.T) {
+	githubClient, attempts := newRetryingClient(t,
+		respondStatus(http.StatusBadGateway, nil, "Bad Gateway"),
+		func(*http.Request) (*http.Response, error) { return nil, errors.New("connection reset by peer") },
+		respondOK)
+
+	ctx, stats := execstats.NewContext(context.Background())
+	if _, err := githubClient.Get(ctx, "/user", nil); err != nil {
+		t.Fatalf("Expected the request to succeed after retries, got %v", err)
+	}
+	if *attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *attempts)
+	}
+	if snapshot := stats.Snapshot(); snapshot.Retries != 2 || snapshot.GitHubRoundTrips != 3 {
+		t.Errorf("Expected 2 retries in 3 round trips, got %+v", snapshot)
+	}
+}
+
+func TestGitHubClient_RetryGivesUp(t *testing.T) {
+	githubClient, attempts := newRetryingClient(t, respondStatus(http.StatusServiceUnavailable, nil, "Unavailable"))
+
+	if _, err := githubClient.Get(context.Background(), "/user", nil); err == nil {
+		t.Fatal("Expected an error once attempts are exhausted")
+	}
+	if *attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *attempts)
+	}
+}
+
+func TestGitHubClient_RetryNonIdempotent(t *testing.T) {
+	githubClient, attempts := newRetryingClient(t, respondStatus(http.StatusBadGateway, nil, "Bad Gateway"), respondOK)
+
+	if _, err := githubClient.Post(context.Background(), "/user/repos", map[string]string{"name": "x"}); err == nil {
+		t.Fatal("Expected the server error to be returned")
+	}
+	if *attempts != 1 {
+		t.Errorf("Expected POST not to be retried after a server error, got %d attempts", *attempts)
+	}
+}
+
+func TestGitHubClient_RetrySecondaryRateLimit(t *testing.T) {
+	var bodies []string
+	record := func(req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+	}
+	githubClient, attempts := newRetryingClient(t,
+		func(req *http.Request) (*http.Response, error) {
+			record(req)
+			return mocks.MockResponse(http.StatusForbidden,
+				`{"message": "You have exceeded a secondary rate limit"}`,
+				map[string]string{"Retry-After": "0"}), nil
+		},
+		func(req *http.Request) (*http.Response, error) {
+			record(req)
+			return respondOK(req)
+		})
+
+	if _, err := githubClient.Post(context.Background(), "/user/repos", map[string]string{"name": "x"}); err != nil {
+		t.Fatalf("Expected the request to succeed after the rate limit, got %v", err)
+	}
+	if *attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", *attempts)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Errorf("Expected the body to be resent, got %q", bodies)
+	}
+}
+
+func TestGitHubClient_RetryAfterTooLong(t *testing.T) {
+	githubClient, attempts := newRetryingClient(t,
+		respondStatus(http.StatusTooManyRequests, map[string]string{"Retry-After": "120"}, "slow down"),
+		respondOK)
+
+	if _, err := githubClient.Get(context.Background(), "/user", nil); err == nil {
+		t.Fatal("Expected the rate limit error to be returned")
+	}
+	if *attempts != 1 {
+		t.Errorf("Expected no retry beyond the maximum delay, got %d attempts", *attempts)
+	}
+}
+
+func TestGitHubClient_RetryForbidden(t *testing.T) {
+	githubClient, attempts := newRetryingClient(t,
+		respondStatus(http.StatusForbidden, map[string]string{"Content-Type": "application/json"}, `{"message": "Resource not accessible by integration"}`),
+		respondOK)
+
+	if _, err := githubClient.Get(context.Background(), "/user", nil); err == nil {
+		t.Fatal("Expected the permission error to be returned")
+	}
+	if *attempts != 1 {
+		t.Errorf("Expected permission errors not to be retried, got %d attempts", *attempts)
+	}
+}
+
+func TestGitHubClient_RetryCancelled(t *testing.T) {
+	githubClient, attempts := newRetryingClient(t, respondStatus(http.StatusBadGateway, nil, "Bad Gateway"))
+	githubClient.SetRetryPolicy(client.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := githubClient.Get(ctx, "/user", nil); err == nil {
+		t.Fatal("Expected an error when the context ends during backoff")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the backoff to stop with the context, took %v", elapsed)
+	}
+	if *attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", *attempts)
+	}
+}
@@ -0,0 +1,187 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	apperrors "github.com/nicholasflintwillow/github-mcp/internal/errors"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+// memoryStore is a SharedStore kept in memory, failing every operation
+// while down is set
+type memoryStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	down   bool
+	calls  int
+	closed bool
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.down {
+		return nil, false, errors.New("connection refused")
+	}
+	value, ok := s.values[key]
+	return value, ok, nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.down {
+		return errors.New("connection refused")
+	}
+	s.values[key] = value
+	return nil
+}
+
+func (s *memoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// flushSharedStore waits for the writes of githubClient to the shared store
+func flushSharedStore(t *testing.T, githubClient *client.GitHubClient) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := githubClient.CloseSharedStore(ctx); err != nil {
+		t.Fatalf("CloseSharedStore failed: %v", err)
+	}
+}
+
+// newReplicaClient returns a client sharing store whose GitHub replies with
+// an ETag and remaining rate limit, and 304 to revalidations
+func newReplicaClient(t *testing.T, store client.SharedStore, remaining int, ifNoneMatch *[]string) *client.GitHubClient {
+	t.Helper()
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		*ifNoneMatch = append(*ifNoneMatch, req.Header.Get("If-None-Match"))
+		headers := map[string]string{
+			"ETag":                  `"v1"`,
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": fmt.Sprint(remaining),
+			"X-RateLimit-Reset":     fmt.Sprint(time.Now().Add(time.Hour).Unix()),
+		}
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			return mocks.MockResponse(http.StatusNotModified, "", headers), nil
+		}
+		headers["Content-Type"] = "application/json"
+		return mocks.MockResponse(http.StatusOK, `{"login": "octocat"}`, headers), nil
+	}})
+	githubClient.SetResponseCache(10)
+	githubClient.SetSharedStore(store)
+	return githubClient
+}
+
+func TestSharedStore_Cache(t *testing.T) {
+	store := &memoryStore{values: map[string][]byte{}}
+	var first, second []string
+	replica1 := newReplicaClient(t, store, 4000, &first)
+	replica2 := newReplicaClient(t, store, 4000, &second)
+
+	if _, err := replica1.GetUser(context.Background(), "octocat"); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	flushSharedStore(t, replica1)
+	user, err := replica2.GetUser(context.Background(), "octocat")
+	if err != nil || user.Login != "octocat" {
+		t.Fatalf("Expected the cached user, got %+v (%v)", user, err)
+	}
+	if len(second) != 1 || second[0] != `"v1"` {
+		t.Errorf("Expected the second replica to revalidate the first one's response, got If-None-Match %q", second)
+	}
+	if replica2.CachedResponses() != 1 {
+		t.Errorf("Expected 1 cached response, got %d", replica2.CachedResponses())
+	}
+}
+
+func TestSharedStore_RateLimit(t *testing.T) {
+	store := &memoryStore{values: map[string][]byte{}}
+	var first, second []string
+	replica1 := newReplicaClient(t, store, 50, &first)
+	replica2 := newReplicaClient(t, store, 4000, &second)
+	replica2.SetRateLimitPolicy(client.RateLimitPolicy{Floor: 100, MaxWait: time.Second})
+
+	if _, err := replica1.GetUser(context.Background(), "octocat"); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	flushSharedStore(t, replica1)
+	_, err := replica2.GetUser(context.Background(), "hubot")
+	if !apperrors.IsType(err, apperrors.ErrorTypeRateLimit) || len(second) != 0 {
+		t.Errorf("Expected the budget the first replica saw to hold back the second, got %v after %d requests", err, len(second))
+	}
+}
+
+func TestSharedStore_Unavailable(t *testing.T) {
+	store := &memoryStore{values: map[string][]byte{}, down: true}
+	var ifNoneMatch []string
+	githubClient := newReplicaClient(t, store, 4000, &ifNoneMatch)
+	githubClient.SetRateLimitPolicy(client.RateLimitPolicy{Floor: 100, MaxWait: time.Second})
+
+	for i := 0; i < 2; i++ {
+		if _, err := githubClient.GetUser(context.Background(), "octocat"); err != nil {
+			t.Fatalf("Expected requests to succeed without the shared store, got %v", err)
+		}
+	}
+	if len(ifNoneMatch) != 2 || ifNoneMatch[1] != `"v1"` {
+		t.Errorf("Expected the local cache to keep working, got If-None-Match %q", ifNoneMatch)
+	}
+}
+
+func TestSharedStore_Backoff(t *testing.T) {
+	store := &memoryStore{values: map[string][]byte{}, down: true}
+	var ifNoneMatch []string
+	githubClient := newReplicaClient(t, store, 4000, &ifNoneMatch)
+
+	for _, login := range []string{"octocat", "hubot", "monalisa"} {
+		if _, err := githubClient.GetUser(context.Background(), login); err != nil {
+			t.Fatalf("Expected requests to succeed without the shared store, got %v", err)
+		}
+	}
+	flushSharedStore(t, githubClient)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if store.calls != 1 {
+		t.Errorf("Expected the failed store to be skipped after the first call, got %d calls", store.calls)
+	}
+	if !store.closed {
+		t.Error("Expected CloseSharedStore to close the store")
+	}
+}
+
+func TestSharedStore_CloseWritesPending(t *testing.T) {
+	store := &memoryStore{values: map[string][]byte{}}
+	var ifNoneMatch []string
+	githubClient := newReplicaClient(t, store, 4000, &ifNoneMatch)
+
+	if _, err := githubClient.GetUser(context.Background(), "octocat"); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	flushSharedStore(t, githubClient)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.values) != 2 {
+		t.Errorf("Expected the response and rate limit to be written before closing, got %d keys", len(store.values))
+	}
+}
@@ -0,0 +1,53 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestTimeout_ShorterOfClientAndContext(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		deadline time.Duration
+		expected time.Duration
+	}{
+		{name: "client timeout sooner", timeout: time.Minute, deadline: time.Hour, expected: time.Minute},
+		{name: "context deadline sooner", timeout: time.Hour, deadline: time.Minute, expected: time.Minute},
+		{name: "no client timeout", timeout: 0, deadline: time.Hour, expected: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var remaining time.Duration
+			githubClient := client.NewGitHubClient("test-token", testLogger)
+			githubClient.SetTimeout(tt.timeout)
+			githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+				if deadline, ok := req.Context().Deadline(); ok {
+					remaining = time.Until(deadline)
+				}
+				return mocks.MockJSONResponse(http.StatusOK, `{}`), nil
+			}})
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.deadline)
+			defer cancel()
+			if _, err := githubClient.Get(ctx, "/user", nil); err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			if remaining > tt.expected || remaining < tt.expected-10*time.Second {
+				t.Errorf("Expected the request to be bounded by %v, got %v left", tt.expected, remaining)
+			}
+		})
+	}
+}
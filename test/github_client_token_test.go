@@ -0,0 +1,92 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+func TestGitHubClient_GetTokenInfo(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		scopes     []string
+		expiration string
+	}{
+		{
+			name: "classic token",
+			headers: map[string]string{
+				"X-OAuth-Scopes":                         "repo, read:org,  admin:org_hook",
+				"GitHub-Authentication-Token-Expiration": "2026-12-01 00:00:00 UTC",
+			},
+			scopes:     []string{"repo", "read:org", "admin:org_hook"},
+			expiration: "2026-12-01 00:00:00 UTC",
+		},
+		{
+			name:    "fine-grained token",
+			headers: map[string]string{},
+			scopes:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.headers["Content-Type"] = "application/json"
+			mockClient := &mocks.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if req.URL.Path != "/user" {
+						t.Errorf("Expected /user, got %s", req.URL.Path)
+					}
+					return mocks.MockResponse(200, `{"login": "octocat"}`, tt.headers), nil
+				},
+			}
+
+			githubClient := client.NewGitHubClient("test-token", testLogger)
+			githubClient.SetHTTPClient(mockClient)
+
+			info, err := githubClient.GetTokenInfo(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if info.Login != "octocat" {
+				t.Errorf("Expected login octocat, got %s", info.Login)
+			}
+			if !reflect.DeepEqual(info.Scopes, tt.scopes) {
+				t.Errorf("Expected scopes %v, got %v", tt.scopes, info.Scopes)
+			}
+			if info.Expiration != tt.expiration {
+				t.Errorf("Expected expiration %q, got %q", tt.expiration, info.Expiration)
+			}
+		})
+	}
+}
+
+func TestGitHubClient_GetTokenInfo_Unauthorized(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+
+	mockClient := &mocks.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mocks.MockErrorResponse(401, "Bad credentials"), nil
+		},
+	}
+
+	githubClient := client.NewGitHubClient("test-token", testLogger)
+	githubClient.SetHTTPClient(mockClient)
+
+	if _, err := githubClient.GetTokenInfo(context.Background()); err == nil {
+		t.Fatal("Expected an error for an invalid token")
+	}
+}
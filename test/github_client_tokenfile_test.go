@@ -0,0 +1,126 @@
+package test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+// writeCredential replaces the file at path, moving its modification time
+// forward so the change is seen even on coarse filesystem clocks
+func writeCredential(t *testing.T, path, content string, age time.Duration) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set the modification time of %s: %v", path, err)
+	}
+}
+
+func TestTokenFile_ReloadsRotatedToken(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "token")
+	writeCredential(t, path, "first-token\n", time.Hour)
+
+	var authorization string
+	githubClient := client.NewGitHubClient("", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		authorization = req.Header.Get("Authorization")
+		return mocks.MockJSONResponse(200, `{}`), nil
+	}})
+	if err := githubClient.SetTokenFile(path, time.Nanosecond); err != nil {
+		t.Fatalf("SetTokenFile failed: %v", err)
+	}
+
+	githubClient.Get(context.Background(), "/user", nil)
+	if authorization != "Bearer first-token" {
+		t.Errorf("Expected the token from the file, got %q", authorization)
+	}
+
+	writeCredential(t, path, "second-token\n", 0)
+	githubClient.Get(context.Background(), "/user", nil)
+	if authorization != "Bearer second-token" {
+		t.Errorf("Expected the rotated token, got %q", authorization)
+	}
+
+	// A rotation in progress keeps the previous token
+	os.Remove(path)
+	githubClient.Get(context.Background(), "/user", nil)
+	if authorization != "Bearer second-token" {
+		t.Errorf("Expected the previous token while the file is missing, got %q", authorization)
+	}
+}
+
+func TestTokenFile_Missing(t *testing.T) {
+	githubClient := client.NewGitHubClient("", nil)
+	if err := githubClient.SetTokenFile(filepath.Join(t.TempDir(), "missing"), 0); err == nil {
+		t.Error("Expected a missing token file to be rejected")
+	}
+}
+
+func TestTokenFile_RejectsTokenPool(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	writeCredential(t, path, "file-token\n", time.Hour)
+
+	githubClient := client.NewGitHubClient("", nil)
+	if err := githubClient.SetTokenPool([]string{"first", "second"}); err != nil {
+		t.Fatalf("SetTokenPool failed: %v", err)
+	}
+	if err := githubClient.SetTokenFile(path, 0); err == nil {
+		t.Error("Expected a token file to be rejected after a token pool")
+	}
+
+	githubClient = client.NewGitHubClient("", nil)
+	if err := githubClient.SetTokenFile(path, 0); err != nil {
+		t.Fatalf("SetTokenFile failed: %v", err)
+	}
+	if err := githubClient.SetTokenPool([]string{"first", "second"}); err == nil {
+		t.Error("Expected a token pool to be rejected after a token file")
+	}
+}
+
+func TestAppAuth_ReloadsRotatedKeyFile(t *testing.T) {
+	server := newAppServer(t, time.Hour)
+	path := filepath.Join(t.TempDir(), "app.pem")
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	writeCredential(t, path, string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(oldKey)})), time.Hour)
+
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	githubClient := client.NewGitHubClient("", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: server.do})
+	if err := githubClient.SetAppAuth(client.AppAuthConfig{AppID: 42, PrivateKeyFile: path, FileCheckInterval: time.Nanosecond}); err != nil {
+		t.Fatalf("SetAppAuth failed: %v", err)
+	}
+
+	if err := githubClient.ValidateToken(context.Background()); err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Errorf("Expected the old key to be rejected, got %v", err)
+	}
+
+	writeCredential(t, path, string(server.privateKeyPEM()), 0)
+	if err := githubClient.ValidateToken(context.Background()); err != nil {
+		t.Errorf("Expected the rotated key to be used, got %v", err)
+	}
+}
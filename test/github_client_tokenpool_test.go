@@ -0,0 +1,91 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+)
+
+// newPoolClient returns a client rotating between tokens whose responses
+// report the rate limit remaining[token], or are rejected when it is negative
+func newPoolClient(t *testing.T, tokens []string, remaining map[string]int, used *[]string) *client.GitHubClient {
+	t.Helper()
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	githubClient := client.NewGitHubClient("", testLogger)
+	githubClient.SetHTTPClient(&mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		*used = append(*used, token)
+		if remaining[token] < 0 {
+			return mocks.MockErrorResponse(401, "Bad credentials"), nil
+		}
+		remaining[token]--
+		return mocks.MockResponse(200, `{}`, map[string]string{
+			"Content-Type":          "application/json",
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": fmt.Sprint(remaining[token]),
+			"X-RateLimit-Reset":     fmt.Sprint(time.Now().Add(time.Hour).Unix()),
+			"X-RateLimit-Resource":  "core",
+		}), nil
+	}})
+	if err := githubClient.SetTokenPool(tokens); err != nil {
+		t.Fatalf("SetTokenPool failed: %v", err)
+	}
+	return githubClient
+}
+
+func TestTokenPool_PrefersMostBudget(t *testing.T) {
+	var used []string
+	githubClient := newPoolClient(t, []string{"a", "b", "c"}, map[string]int{"a": 10, "b": 500, "c": 100}, &used)
+
+	for i := 0; i < 5; i++ {
+		if _, err := githubClient.Get(context.Background(), "/user", nil); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+
+	// Every token is tried once before the one with the most budget is preferred
+	if got := strings.Join(used, ","); got != "a,b,c,b,b" {
+		t.Errorf("Expected tokens a,b,c,b,b, got %s", got)
+	}
+	state, ok := githubClient.RateLimitState(client.RateLimitCore)
+	if !ok || state.Remaining != 497 {
+		t.Errorf("Expected the budget of the best token, got %+v", state)
+	}
+}
+
+func TestTokenPool_SkipsRejectedTokens(t *testing.T) {
+	var used []string
+	githubClient := newPoolClient(t, []string{"revoked", "valid"}, map[string]int{"revoked": -1, "valid": 100}, &used)
+
+	if _, err := githubClient.Get(context.Background(), "/user", nil); err == nil {
+		t.Error("Expected the request with the revoked token to fail")
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := githubClient.Get(context.Background(), "/user", nil); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+	if got := strings.Join(used, ","); got != "revoked,valid,valid,valid" {
+		t.Errorf("Expected the revoked token to be used once, got %s", got)
+	}
+}
+
+func TestTokenPool_AllRejected(t *testing.T) {
+	var used []string
+	githubClient := newPoolClient(t, []string{"revoked"}, map[string]int{"revoked": -1}, &used)
+
+	githubClient.Get(context.Background(), "/user", nil)
+	if _, err := githubClient.Get(context.Background(), "/user", nil); err == nil || len(used) != 1 {
+		t.Errorf("Expected requests to fail without reaching GitHub once every token is rejected, got %v after %d requests", err, len(used))
+	}
+}
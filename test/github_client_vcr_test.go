@@ -0,0 +1,83 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nicholasflintwillow/github-mcp/internal/client"
+	"github.com/nicholasflintwillow/github-mcp/internal/logger"
+	"github.com/nicholasflintwillow/github-mcp/test/fixtures"
+	"github.com/nicholasflintwillow/github-mcp/test/mocks"
+	"github.com/nicholasflintwillow/github-mcp/test/vcr"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	testLogger, err := logger.New("ERROR", "text")
+	if err != nil {
+		t.Fatalf("Failed to create test logger: %v", err)
+	}
+	cassette := filepath.Join(t.TempDir(), "users.json")
+	archive := []byte{0x1f, 0x8b, 0x08, 0x00, 0xff}
+
+	sent := 0
+	live := &mocks.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		sent++
+		if strings.HasSuffix(req.URL.Path, "/tarball/main") {
+			return mocks.MockResponse(http.StatusOK, string(archive), map[string]string{"Content-Type": "application/x-gzip"}), nil
+		}
+		return mocks.MockJSONResponse(http.StatusOK, fixtures.UserResponse), nil
+	}}
+	recorder, err := vcr.New(cassette, vcr.ModeRecord, live)
+	if err != nil {
+		t.Fatalf("Failed to create recorder: %v", err)
+	}
+	recording := client.NewGitHubClient("secret-token", testLogger)
+	recording.SetHTTPClient(recorder)
+	if _, err := recording.GetUser(context.Background(), "testuser"); err != nil {
+		t.Fatalf("Recorded request failed: %v", err)
+	}
+	if _, err := recording.DownloadArchive(context.Background(), "octocat", "hello-world", "tarball", "main", &bytes.Buffer{}); err != nil {
+		t.Fatalf("Recorded download failed: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Failed to save cassette: %v", err)
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("Failed to read cassette: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Error("Expected the cassette not to contain the token")
+	}
+
+	player, err := vcr.New(cassette, vcr.ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Failed to load cassette: %v", err)
+	}
+	replaying := client.NewGitHubClient("other-token", testLogger)
+	replaying.SetHTTPClient(player)
+	user, err := replaying.GetUser(context.Background(), "testuser")
+	if err != nil || user.Login != "testuser" {
+		t.Fatalf("Expected the recorded user, got %+v (%v)", user, err)
+	}
+	var downloaded bytes.Buffer
+	if _, err := replaying.DownloadArchive(context.Background(), "octocat", "hello-world", "tarball", "main", &downloaded); err != nil {
+		t.Fatalf("Replayed download failed: %v", err)
+	}
+	if !bytes.Equal(downloaded.Bytes(), archive) {
+		t.Errorf("Expected the recorded archive %x, got %x", archive, downloaded.Bytes())
+	}
+	if sent != 2 || len(player.Unused()) != 0 {
+		t.Errorf("Expected replay to send nothing and use every interaction, sent %d with %d unused", sent-2, len(player.Unused()))
+	}
+
+	if _, err := replaying.GetUser(context.Background(), "hubot"); err == nil {
+		t.Error("Expected a request missing from the cassette to fail")
+	}
+}
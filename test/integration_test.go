@@ -106,6 +106,22 @@ func TestConfigValidation(t *testing.T) {
 			t.Error("Invalid config should return error")
 		}
 	})
+
+	t.Run("ClientCAWithoutServerCertificate", func(t *testing.T) {
+		cfg := &config.Config{
+			Port:                  8443,
+			Host:                  "localhost",
+			GitHubToken:           "test_token",
+			LogLevel:              "INFO",
+			LogFormat:             "json",
+			MaxConcurrentRequests: 100,
+			TLSClientCAFile:       "/etc/github-mcp/clients.pem",
+		}
+
+		if err := cfg.Validate(); err == nil {
+			t.Error("Client CA without server certificate should return error")
+		}
+	})
 }
 
 func TestLoggerInitialization(t *testing.T) {
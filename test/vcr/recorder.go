@@ -0,0 +1,266 @@
+// Package vcr records GitHub API responses to cassette files and replays
+// them, so tests can exercise the client and tools against real responses
+// without reaching the network.
+package vcr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+// Mode selects whether a recorder replays or records interactions
+type Mode string
+
+const (
+	// ModeReplay answers requests from the cassette and fails those it does
+	// not hold
+	ModeReplay Mode = "replay"
+	// ModeRecord sends requests to GitHub and saves the interactions
+	ModeRecord Mode = "record"
+)
+
+// ModeEnv is the environment variable selecting the mode of Start
+const ModeEnv = "VCR_MODE"
+
+// redactedHeaders are response headers not written to cassettes
+var redactedHeaders = []string{"Set-Cookie", "X-Github-Request-Id", "Date"}
+
+// HTTPClient sends requests when recording; *http.Client implements it
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Interaction is a recorded request and the response GitHub sent to it
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request; headers such as Authorization are not kept
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded response. Bodies that are not UTF-8 text, such as
+// archives, are kept base64 encoded.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+	Base64     bool        `json:"base64,omitempty"`
+}
+
+// cassette is the file format of recorded interactions
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an HTTP client recording or replaying the interactions of a
+// cassette. It can be passed to GitHubClient.SetHTTPClient.
+type Recorder struct {
+	path string
+	mode Mode
+	live HTTPClient
+
+	mu           sync.Mutex
+	interactions []Interaction
+	// used marks the interactions already replayed
+	used []bool
+}
+
+// New returns a recorder for the cassette at path. In replay mode the
+// cassette is read; in record mode requests are sent with live and Save
+// writes them to path.
+func New(path string, mode Mode, live HTTPClient) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, live: live}
+	switch mode {
+	case ModeRecord:
+		if live == nil {
+			return nil, fmt.Errorf("recording needs an HTTP client")
+		}
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		var c cassette
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+		}
+		r.interactions = c.Interactions
+		r.used = make([]bool, len(c.Interactions))
+	default:
+		return nil, fmt.Errorf("unknown mode %q", mode)
+	}
+	return r, nil
+}
+
+// Start returns a recorder for the cassette testdata/cassettes/<name>.json,
+// in the mode VCR_MODE selects, replay by default. Recordings are saved when
+// the test ends. A test without its cassette is skipped when replaying.
+func Start(t testing.TB, name string) *Recorder {
+	t.Helper()
+	path := filepath.Join("testdata", "cassettes", name+".json")
+	mode := Mode(os.Getenv(ModeEnv))
+	if mode == "" {
+		mode = ModeReplay
+	}
+	if mode == ModeReplay {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			t.Skipf("No cassette %s; record it with %s=%s", path, ModeEnv, ModeRecord)
+		}
+	}
+
+	r, err := New(path, mode, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("Failed to start recorder: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := r.Save(); err != nil {
+			t.Errorf("Failed to save cassette: %v", err)
+		}
+	})
+	return r
+}
+
+// Do records or replays req
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	recorded, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+// replay returns the first unused response recorded for req
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request != recorded {
+			continue
+		}
+		r.used[i] = true
+		return interaction.Response.httpResponse(req), nil
+	}
+	return nil, fmt.Errorf("cassette %s holds no response to %s %s", r.path, recorded.Method, recorded.URL)
+}
+
+// record sends req and keeps the interaction
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	resp, err := r.live.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	header := resp.Header.Clone()
+	for _, name := range redactedHeaders {
+		header.Del(name)
+	}
+	// The body is stored decoded
+	header.Del("Content-Length")
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request:  recorded,
+		Response: newResponse(resp.StatusCode, header, body),
+	})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette; it does nothing
+// when replaying
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// Unused returns the recorded interactions not replayed, which usually
+// means the code under test no longer makes a request it used to
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []Interaction
+	for i, interaction := range r.interactions {
+		if r.mode == ModeReplay && !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// newRequest returns how req is recorded, restoring its body for sending
+func newRequest(req *http.Request) (Request, error) {
+	recorded := Request{Method: req.Method, URL: req.URL.String()}
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return recorded, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	recorded.Body = strings.TrimSpace(string(body))
+	return recorded, nil
+}
+
+// newResponse returns how a response is recorded
+func newResponse(statusCode int, header http.Header, body []byte) Response {
+	if utf8.Valid(body) {
+		return Response{StatusCode: statusCode, Header: header, Body: string(body)}
+	}
+	return Response{StatusCode: statusCode, Header: header, Body: base64.StdEncoding.EncodeToString(body), Base64: true}
+}
+
+// httpResponse returns the recorded response as a response to req
+func (r Response) httpResponse(req *http.Request) *http.Response {
+	body := []byte(r.Body)
+	if r.Base64 {
+		body, _ = base64.StdEncoding.DecodeString(r.Body)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}